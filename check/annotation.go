@@ -44,6 +44,20 @@ type Annotation interface {
 	//
 	// Will only potentially be produced for breaking change rules.
 	AgainstFileLocation() descriptor.FileLocation
+	// Policy is the Policy of the Rule that failed, if the Rule is a policy Rule.
+	//
+	// Only populated on Annotations returned from Clients.
+	Policy() Policy
+	// Severity is the Severity of the failure.
+	//
+	// This is the Severity set with the SeverityOverridesOptionKey option if present, the
+	// Severity of the Policy if present, and SeverityError otherwise.
+	Severity() Severity
+	// Waiver is the Waiver that covers the failure, if any.
+	//
+	// Only populated on Annotations returned from Clients when Waivers are passed with
+	// CheckWithWaivers. Waived failures should generally be recorded but not block changes.
+	Waiver() *Waiver
 
 	toProto() *checkv1.Annotation
 
@@ -57,6 +71,10 @@ type annotation struct {
 	message             string
 	fileLocation        descriptor.FileLocation
 	againstFileLocation descriptor.FileLocation
+	policy              Policy
+	waiver              *Waiver
+	// severityOverride is the Severity set with SeverityOverridesOptionKey, or 0 if not set.
+	severityOverride Severity
 }
 
 func newAnnotation(
@@ -92,6 +110,24 @@ func (a *annotation) AgainstFileLocation() descriptor.FileLocation {
 	return a.againstFileLocation
 }
 
+func (a *annotation) Policy() Policy {
+	return a.policy
+}
+
+func (a *annotation) Severity() Severity {
+	if a.severityOverride != 0 {
+		return a.severityOverride
+	}
+	if a.policy != nil {
+		return a.policy.Severity()
+	}
+	return SeverityError
+}
+
+func (a *annotation) Waiver() *Waiver {
+	return a.waiver
+}
+
 func (a *annotation) toProto() *checkv1.Annotation {
 	if a == nil {
 		return nil
@@ -114,6 +150,21 @@ func (a *annotation) toProto() *checkv1.Annotation {
 
 func (*annotation) isAnnotation() {}
 
+// annotationWithPolicyAndWaiver returns a copy of the Annotation with the given Policy and Waiver.
+func annotationWithPolicyAndWaiver(a Annotation, policy Policy, waiver *Waiver) Annotation {
+	annotation := *(a.(*annotation))
+	annotation.policy = policy
+	annotation.waiver = waiver
+	return &annotation
+}
+
+// annotationWithSeverityOverride returns a copy of the Annotation with the given overridden Severity.
+func annotationWithSeverityOverride(a Annotation, severity Severity) Annotation {
+	annotation := *(a.(*annotation))
+	annotation.severityOverride = severity
+	return &annotation
+}
+
 func sortAnnotations(annotations []Annotation) {
 	sort.Slice(
 		annotations,
@@ -0,0 +1,181 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"buf.build/go/bufplugin/descriptor"
+)
+
+// Baseline is a set of known, accepted failures.
+//
+// Baselines allow strict Rules to be adopted incrementally on existing schemas: failures
+// that are in the Baseline are removed from the Annotations returned from Check when the
+// Baseline is passed with CheckWithBaseline, so that only new failures are reported.
+//
+// A Baseline is created from the current failures with NewBaseline, and is typically stored
+// in a file with WriteBaseline and read with ReadBaseline. Regenerate the Baseline as failures
+// are fixed, so that they cannot be reintroduced.
+type Baseline struct {
+	// Entries are the known failures.
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry identifies a known failure.
+//
+// A failure is identified by its Rule, its file, and the element of the file that it is
+// reported on, so that entries continue to match when the lines of a file change.
+type BaselineEntry struct {
+	// RuleID is the ID of the Rule that reported the failure.
+	//
+	// Required.
+	RuleID string `json:"rule_id"`
+	// FileName is the name of the file that the failure was reported on.
+	//
+	// Empty if the failure was not reported on a file.
+	FileName string `json:"file_name,omitempty"`
+	// Identifier identifies the failure within the file.
+	//
+	// This is the fully-qualified name of the innermost message, field, extension, oneof,
+	// enum, enum value, service, or method that contains the location of the failure. If
+	// the location is not within any of these, this is the message of the failure.
+	Identifier string `json:"identifier,omitempty"`
+}
+
+// NewBaseline returns a new Baseline that contains all the failures of the Annotations.
+//
+// To regenerate a Baseline, pass the Annotations from a Check call that does not use
+// CheckWithBaseline. The entries of the returned Baseline are unique and sorted, so that
+// regenerated Baselines can be compared with diff tools.
+func NewBaseline(annotations []Annotation) *Baseline {
+	entries := make([]BaselineEntry, len(annotations))
+	for i, annotation := range annotations {
+		entries[i] = baselineEntryForAnnotation(annotation)
+	}
+	slices.SortFunc(entries, compareBaselineEntries)
+	return &Baseline{
+		Entries: slices.Compact(entries),
+	}
+}
+
+// ReadBaseline reads a Baseline written by WriteBaseline.
+//
+// The Baseline is validated.
+func ReadBaseline(reader io.Reader) (*Baseline, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	baseline := &Baseline{}
+	if err := decoder.Decode(baseline); err != nil {
+		return nil, fmt.Errorf("invalid baseline: %w", err)
+	}
+	if err := validateBaseline(baseline); err != nil {
+		return nil, err
+	}
+	return baseline, nil
+}
+
+// WriteBaseline writes the Baseline as indented JSON.
+func WriteBaseline(writer io.Writer, baseline *Baseline) error {
+	if err := validateBaseline(baseline); err != nil {
+		return err
+	}
+	if baseline.Entries == nil {
+		baseline = &Baseline{Entries: []BaselineEntry{}}
+	}
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(data, '\n'))
+	return err
+}
+
+// CheckWithBaseline returns a new CheckCallOption that removes the failures in the Baseline
+// from the Annotations returned from Check.
+//
+// An Annotation is removed if an entry of the Baseline has the same RuleID, FileName, and
+// Identifier as the Annotation. Multiple failures of the same Rule on the same element are
+// all removed by a single entry. The Baseline is applied before any Waivers.
+func CheckWithBaseline(baseline *Baseline) CheckCallOption {
+	return func(checkCallOptions *checkCallOptions) {
+		checkCallOptions.baseline = baseline
+	}
+}
+
+// *** PRIVATE ***
+
+func validateBaseline(baseline *Baseline) error {
+	if baseline == nil {
+		return errors.New("check.Baseline is nil")
+	}
+	for i, entry := range baseline.Entries {
+		if entry.RuleID == "" {
+			return fmt.Errorf("check.Baseline: RuleID is empty for entry %d", i)
+		}
+	}
+	return nil
+}
+
+// applyBaseline returns the Annotations that are not in the Baseline.
+func applyBaseline(baseline *Baseline, annotations []Annotation) []Annotation {
+	entries := make(map[BaselineEntry]struct{}, len(baseline.Entries))
+	for _, entry := range baseline.Entries {
+		entries[entry] = struct{}{}
+	}
+	return slices.DeleteFunc(
+		annotations,
+		func(annotation Annotation) bool {
+			_, ok := entries[baselineEntryForAnnotation(annotation)]
+			return ok
+		},
+	)
+}
+
+func baselineEntryForAnnotation(annotation Annotation) BaselineEntry {
+	entry := BaselineEntry{
+		RuleID:     annotation.RuleID(),
+		Identifier: annotation.Message(),
+	}
+	fileLocation := annotation.FileLocation()
+	if fileLocation == nil {
+		// Failures of breaking Rules for deleted elements may only have an AgainstFileLocation.
+		fileLocation = annotation.AgainstFileLocation()
+	}
+	if fileLocation == nil {
+		return entry
+	}
+	fileDescriptor := fileLocation.FileDescriptor().ProtoreflectFileDescriptor()
+	entry.FileName = fileDescriptor.Path()
+	if elementDescriptor := descriptor.DescriptorForSourcePath(fileDescriptor, fileLocation.SourcePath()); elementDescriptor != fileDescriptor {
+		entry.Identifier = string(elementDescriptor.FullName())
+	}
+	return entry
+}
+
+func compareBaselineEntries(one BaselineEntry, two BaselineEntry) int {
+	if c := cmp.Compare(one.RuleID, two.RuleID); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(one.FileName, two.FileName); c != 0 {
+		return c
+	}
+	return cmp.Compare(one.Identifier, two.Identifier)
+}
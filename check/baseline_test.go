@@ -0,0 +1,146 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestBaseline(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, err := NewClientForSpec(
+		&Spec{
+			Rules: []*RuleSpec{
+				{
+					ID:      "RULE1",
+					Default: true,
+					Purpose: "Checks RULE1.",
+					Type:    RuleTypeLint,
+					Handler: RuleHandlerFunc(
+						func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+							for _, fileDescriptor := range request.FileDescriptors() {
+								messages := fileDescriptor.ProtoreflectFileDescriptor().Messages()
+								for i := range messages.Len() {
+									responseWriter.AddAnnotation(
+										WithMessagef("Message %q is bad.", messages.Get(i).Name()),
+										WithDescriptor(messages.Get(i)),
+									)
+								}
+								responseWriter.AddAnnotation(
+									WithMessage("File is bad."),
+									WithFileName(fileDescriptor.ProtoreflectFileDescriptor().Path()),
+								)
+							}
+							return nil
+						},
+					),
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	request := testNewBaselineRequest(t, "Foo", "Bar")
+	response, err := client.Check(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 3)
+	baseline := NewBaseline(response.Annotations())
+	require.Equal(
+		t,
+		[]BaselineEntry{
+			{RuleID: "RULE1", FileName: "foo.proto", Identifier: "File is bad."},
+			{RuleID: "RULE1", FileName: "foo.proto", Identifier: "foo.Bar"},
+			{RuleID: "RULE1", FileName: "foo.proto", Identifier: "foo.Foo"},
+		},
+		baseline.Entries,
+	)
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, WriteBaseline(buffer, baseline))
+	readBaseline, err := ReadBaseline(buffer)
+	require.NoError(t, err)
+	require.Equal(t, baseline, readBaseline)
+
+	response, err = client.Check(ctx, request, CheckWithBaseline(readBaseline))
+	require.NoError(t, err)
+	require.Empty(t, response.Annotations())
+
+	// Entries continue to match when elements move, and only new failures are reported.
+	response, err = client.Check(ctx, testNewBaselineRequest(t, "Baz", "Bar", "Foo"), CheckWithBaseline(readBaseline))
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 1)
+	require.Equal(t, `Message "Baz" is bad.`, response.Annotations()[0].Message())
+
+	// Regenerating from fewer failures removes the fixed entries.
+	response, err = client.Check(ctx, testNewBaselineRequest(t, "Foo"))
+	require.NoError(t, err)
+	require.Len(t, NewBaseline(response.Annotations()).Entries, 2)
+}
+
+func TestReadBaselineInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadBaseline(strings.NewReader(`{"entries":[{"file_name":"foo.proto"}]}`))
+	require.Error(t, err)
+	_, err = ReadBaseline(strings.NewReader(`{"entries":[],"unknown":true}`))
+	require.Error(t, err)
+	require.Error(t, WriteBaseline(bytes.NewBuffer(nil), nil))
+
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, WriteBaseline(buffer, NewBaseline(nil)))
+	require.JSONEq(t, `{"entries":[]}`, buffer.String())
+}
+
+func testNewBaselineRequest(t *testing.T, messageNames ...string) Request {
+	messageTypes := make([]*descriptorpb.DescriptorProto, len(messageNames))
+	locations := make([]*descriptorpb.SourceCodeInfo_Location, len(messageNames))
+	for i, messageName := range messageNames {
+		messageTypes[i] = &descriptorpb.DescriptorProto{
+			Name: proto.String(messageName),
+		}
+		locations[i] = &descriptorpb.SourceCodeInfo_Location{
+			Path: []int32{4, int32(i)},
+			Span: []int32{int32(i), 0, 10},
+		}
+	}
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:        proto.String("foo.proto"),
+					Package:     proto.String("foo"),
+					MessageType: messageTypes,
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+						Location: locations,
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	return request
+}
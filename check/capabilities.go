@@ -0,0 +1,170 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"buf.build/go/bufplugin/internal/pkg/compression"
+	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"pluginrpc.com/pluginrpc"
+)
+
+// CapabilitiesFlagName is the name of the flag that makes Main print the Capabilities of
+// the plugin to stdout as JSON and exit.
+//
+// See GetCapabilities.
+const CapabilitiesFlagName = "capabilities"
+
+// Capabilities are the capabilities of a plugin.
+//
+// Clients use these to only use features that a plugin supports, so that plugins built
+// with different versions of this package can be used together.
+type Capabilities struct {
+	// ProtocolVersions are the pluginrpc protocol versions that the plugin supports.
+	ProtocolVersions []int `json:"protocol_versions,omitempty"`
+	// Services are the fully-qualified names of the services that the plugin serves,
+	// for example "buf.plugin.check.v1.CheckService".
+	Services []string `json:"services,omitempty"`
+	// Compressions are the names of the compressions that the plugin supports, in order
+	// of preference.
+	//
+	// See NewRunnerWithCompression.
+	Compressions []string `json:"compressions,omitempty"`
+	// Chunking says whether the plugin supports chunked Check requests.
+	//
+	// See NewRunnerWithChunking.
+	Chunking bool `json:"chunking,omitempty"`
+	// State says whether the plugin supports Requests with state.
+	//
+	// See WithState.
+	State bool `json:"state,omitempty"`
+	// Daemon says whether the plugin can be run as a daemon that serves a stream of calls.
+	//
+	// See NewClientForDaemon.
+	Daemon bool `json:"daemon,omitempty"`
+}
+
+// GetCapabilities gets the Capabilities of the plugin run by the given pluginrpc.Runner.
+//
+// The plugin is invoked with --capabilities, see CapabilitiesFlagName. If the plugin does
+// not support --capabilities, for example because it was built with an older version of
+// this package, the Capabilities are derived from the pluginrpc protocol version and
+// pluginrpc.Spec of the plugin instead.
+//
+// This invokes the plugin at least once. Callers should get the Capabilities of a plugin
+// once and reuse them.
+func GetCapabilities(ctx context.Context, runner pluginrpc.Runner) (*Capabilities, error) {
+	stdout := bytes.NewBuffer(nil)
+	if err := runner.Run(
+		ctx,
+		pluginrpc.Env{
+			Args:   []string{"--" + CapabilitiesFlagName},
+			Stdout: stdout,
+			Stderr: io.Discard,
+		},
+	); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return getCapabilitiesForProtocolAndSpec(ctx, runner)
+	}
+	capabilities := &Capabilities{}
+	if err := json.Unmarshal(stdout.Bytes(), capabilities); err != nil {
+		return nil, err
+	}
+	return capabilities, nil
+}
+
+// *** PRIVATE ***
+
+// getCapabilitiesForServer gets the Capabilities of a plugin that calls Main with the
+// given pluginrpc.Server.
+func getCapabilitiesForServer(ctx context.Context, server pluginrpc.Server) (*Capabilities, error) {
+	capabilities, err := getCapabilitiesForProtocolAndSpec(ctx, pluginrpc.NewServerRunner(server))
+	if err != nil {
+		return nil, err
+	}
+	capabilities.Compressions = slices.Clone(compression.Names)
+	capabilities.Daemon = true
+	return capabilities, nil
+}
+
+// getCapabilitiesForProtocolAndSpec gets the Capabilities that can be derived from the
+// protocol version and pluginrpc.Spec of the plugin run by the pluginrpc.Runner.
+func getCapabilitiesForProtocolAndSpec(ctx context.Context, runner pluginrpc.Runner) (*Capabilities, error) {
+	stdout := bytes.NewBuffer(nil)
+	if err := runner.Run(
+		ctx,
+		pluginrpc.Env{
+			Args:   []string{"--" + pluginrpc.ProtocolFlagName},
+			Stdout: stdout,
+			Stderr: io.Discard,
+		},
+	); err != nil {
+		return nil, err
+	}
+	protocolVersion, err := strconv.Atoi(strings.TrimSpace(stdout.String()))
+	if err != nil {
+		return nil, fmt.Errorf("--%s did not return a properly-formed protocol version: %w", pluginrpc.ProtocolFlagName, err)
+	}
+	spec, err := pluginrpc.NewClient(runner).Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string]struct{})
+	for _, procedure := range spec.Procedures() {
+		service, ok := serviceForProcedurePath(procedure.Path())
+		if !ok {
+			return nil, fmt.Errorf("invalid procedure path: %q", procedure.Path())
+		}
+		services[service] = struct{}{}
+	}
+	return &Capabilities{
+		ProtocolVersions: []int{protocolVersion},
+		Services:         xslices.MapKeysToSortedSlice(services),
+		Chunking:         spec.ProcedureForPath(checkChunkedPath) != nil,
+		State:            spec.ProcedureForPath(checkWithStatePath) != nil,
+	}, nil
+}
+
+// serviceForProcedurePath returns the service name of a procedure path of the form
+// /package.Service/Method.
+func serviceForProcedurePath(procedurePath string) (string, bool) {
+	service, _, ok := strings.Cut(strings.TrimPrefix(procedurePath, "/"), "/")
+	return service, ok && service != ""
+}
+
+// writeCapabilities writes the Capabilities of a plugin that calls Main with the given
+// pluginrpc.Server as JSON.
+func writeCapabilities(ctx context.Context, writer io.Writer, server pluginrpc.Server) error {
+	capabilities, err := getCapabilitiesForServer(ctx, server)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(capabilities)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(data, '\n'))
+	return err
+}
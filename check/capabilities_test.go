@@ -0,0 +1,58 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	t.Parallel()
+
+	expected := &Capabilities{
+		ProtocolVersions: []int{1},
+		Services:         []string{"buf.plugin.check.v1.CheckService", "bufplugin.ext.check.v1.SeverityService", "bufplugin.ext.check.v1.StateService"},
+		Compressions:     []string{"zstd", "gzip"},
+		Chunking:         true,
+		State:            true,
+		Daemon:           true,
+	}
+	// Main supports --capabilities.
+	capabilities, err := GetCapabilities(context.Background(), pluginrpc.NewExecRunner(os.Args[0]))
+	require.NoError(t, err)
+	require.Equal(t, expected, capabilities)
+
+	// The Capabilities of plugins that do not support --capabilities are derived from
+	// their protocol version and spec.
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+	capabilities, err = GetCapabilities(context.Background(), pluginrpc.NewServerRunner(server))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&Capabilities{
+			ProtocolVersions: []int{1},
+			Services:         []string{"buf.plugin.check.v1.CheckService", "bufplugin.ext.check.v1.SeverityService", "bufplugin.ext.check.v1.StateService"},
+			Chunking:         true,
+			State:            true,
+		},
+		capabilities,
+	)
+}
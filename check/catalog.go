@@ -0,0 +1,315 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"time"
+
+	"buf.build/go/bufplugin/info"
+	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"buf.build/go/bufplugin/option"
+)
+
+// CatalogFlagName is the name of the flag that makes Main print the Catalog of the plugin
+// to stdout as JSON and exit.
+//
+// See NewCatalog.
+const CatalogFlagName = "catalog"
+
+// Catalog is a machine-readable description of everything a plugin provides: its
+// PluginInfo, Rules, Categories, and options.
+//
+// Catalogs are meant to be uploaded to plugin registries, so that registry listings are
+// derived from the same Spec that the plugin runs with. Catalogs marshal to and from JSON
+// with encoding/json.
+type Catalog struct {
+	// Info is the information about the plugin.
+	//
+	// Nil if the plugin has no PluginInfo.
+	Info *CatalogInfo `json:"info,omitempty"`
+	// Rules are the Rules of the plugin, sorted by ID.
+	Rules []*CatalogRule `json:"rules"`
+	// Categories are the Categories of the plugin, sorted by ID.
+	Categories []*CatalogCategory `json:"categories,omitempty"`
+	// Options are the options that the plugin accepts, in the order of the option.Schema.
+	Options []*CatalogOption `json:"options,omitempty"`
+}
+
+// CatalogInfo is the information about a plugin within a Catalog.
+//
+// See info.PluginInfo for the meaning of the fields.
+type CatalogInfo struct {
+	Documentation          string                 `json:"documentation,omitempty"`
+	URL                    string                 `json:"url,omitempty"`
+	License                *CatalogLicense        `json:"license,omitempty"`
+	AdditionalLicenses     []*CatalogLicense      `json:"additional_licenses,omitempty"`
+	Version                string                 `json:"version,omitempty"`
+	Authors                []*CatalogContact      `json:"authors,omitempty"`
+	Maintainers            []*CatalogContact      `json:"maintainers,omitempty"`
+	MinimumBufVersion      string                 `json:"minimum_buf_version,omitempty"`
+	MinimumProtocolVersion int                    `json:"minimum_protocol_version,omitempty"`
+	Keywords               []string               `json:"keywords,omitempty"`
+	Deprecated             bool                   `json:"deprecated,omitempty"`
+	DeprecationMessage     string                 `json:"deprecation_message,omitempty"`
+	SourceRevision         *CatalogSourceRevision `json:"source_revision,omitempty"`
+	ChangelogURL           string                 `json:"changelog_url,omitempty"`
+	SupportURL             string                 `json:"support_url,omitempty"`
+}
+
+// CatalogLicense is a license within a Catalog.
+//
+// See info.License for the meaning of the fields.
+type CatalogLicense struct {
+	SPDXLicenseID         string `json:"spdx_license_id,omitempty"`
+	SPDXLicenseExpression string `json:"spdx_license_expression,omitempty"`
+	Component             string `json:"component,omitempty"`
+	Text                  string `json:"text,omitempty"`
+	URL                   string `json:"url,omitempty"`
+}
+
+// CatalogContact is a contact within a Catalog.
+//
+// See info.Contact for the meaning of the fields.
+type CatalogContact struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// CatalogSourceRevision is a source revision within a Catalog.
+//
+// See info.SourceRevision for the meaning of the fields.
+type CatalogSourceRevision struct {
+	VCS      string     `json:"vcs,omitempty"`
+	Revision string     `json:"revision,omitempty"`
+	Modified bool       `json:"modified,omitempty"`
+	Time     *time.Time `json:"time,omitempty"`
+}
+
+// CatalogRule is a Rule within a Catalog.
+//
+// See Rule for the meaning of the fields.
+type CatalogRule struct {
+	ID               string         `json:"id"`
+	Type             string         `json:"type"`
+	Purpose          string         `json:"purpose"`
+	CategoryIDs      []string       `json:"category_ids,omitempty"`
+	Default          bool           `json:"default,omitempty"`
+	Deprecated       bool           `json:"deprecated,omitempty"`
+	ReplacementIDs   []string       `json:"replacement_ids,omitempty"`
+	Documentation    string         `json:"documentation,omitempty"`
+	Examples         []string       `json:"examples,omitempty"`
+	DocumentationURL string         `json:"documentation_url,omitempty"`
+	Policy           *CatalogPolicy `json:"policy,omitempty"`
+}
+
+// CatalogPolicy is the Policy of a Rule within a Catalog.
+//
+// See Policy for the meaning of the fields.
+type CatalogPolicy struct {
+	ID       string `json:"id"`
+	Severity string `json:"severity"`
+	Owner    string `json:"owner,omitempty"`
+}
+
+// CatalogCategory is a Category within a Catalog.
+//
+// See Category for the meaning of the fields.
+type CatalogCategory struct {
+	ID               string   `json:"id"`
+	Purpose          string   `json:"purpose"`
+	Deprecated       bool     `json:"deprecated,omitempty"`
+	ReplacementIDs   []string `json:"replacement_ids,omitempty"`
+	Documentation    string   `json:"documentation,omitempty"`
+	Examples         []string `json:"examples,omitempty"`
+	DocumentationURL string   `json:"documentation_url,omitempty"`
+}
+
+// CatalogOption is an option key within a Catalog.
+//
+// See option.KeySpec for the meaning of the fields. The Default and Example values of
+// secret options are replaced by option.RedactedValue.
+type CatalogOption struct {
+	Key           string   `json:"key"`
+	Type          string   `json:"type"`
+	Description   string   `json:"description,omitempty"`
+	Required      bool     `json:"required,omitempty"`
+	Default       any      `json:"default,omitempty"`
+	Example       any      `json:"example,omitempty"`
+	AllowedValues []any    `json:"allowed_values,omitempty"`
+	Aliases       []string `json:"aliases,omitempty"`
+	Secret        bool     `json:"secret,omitempty"`
+}
+
+// NewCatalog returns a new Catalog for the PluginDocumentation.
+//
+// Use a PluginDocumentation from NewPluginDocumentationForSpec to include the properties
+// that are not transmitted over the wire, such as Rule.Documentation and the options. Plugins
+// invoked by Main print their Catalog when given the --catalog flag, see CatalogFlagName.
+func NewCatalog(pluginDocumentation PluginDocumentation) (*Catalog, error) {
+	if pluginDocumentation == nil {
+		return nil, errors.New("PluginDocumentation is nil")
+	}
+	catalog := &Catalog{
+		Rules:      xslices.Map(pluginDocumentation.Rules(), ruleToCatalogRule),
+		Categories: xslices.Map(pluginDocumentation.Categories(), categoryToCatalogCategory),
+	}
+	if pluginInfo := pluginDocumentation.PluginInfo(); pluginInfo != nil {
+		catalog.Info = pluginInfoToCatalogInfo(pluginInfo)
+	}
+	if optionsSchema := pluginDocumentation.OptionsSchema(); optionsSchema != nil {
+		catalog.Options = xslices.Map(optionsSchema.Keys, keySpecToCatalogOption)
+	}
+	return catalog, nil
+}
+
+// *** PRIVATE ***
+
+// writeCatalog writes the Catalog for the Spec as indented JSON.
+func writeCatalog(writer io.Writer, spec *Spec) error {
+	pluginDocumentation, err := NewPluginDocumentationForSpec(spec)
+	if err != nil {
+		return err
+	}
+	catalog, err := NewCatalog(pluginDocumentation)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(data, '\n'))
+	return err
+}
+
+func pluginInfoToCatalogInfo(pluginInfo info.PluginInfo) *CatalogInfo {
+	catalogInfo := &CatalogInfo{
+		Documentation:          pluginInfo.Documentation(),
+		URL:                    urlString(pluginInfo.URL()),
+		AdditionalLicenses:     xslices.Map(pluginInfo.AdditionalLicenses(), licenseToCatalogLicense),
+		Version:                pluginInfo.Version(),
+		Authors:                xslices.Map(pluginInfo.Authors(), contactToCatalogContact),
+		Maintainers:            xslices.Map(pluginInfo.Maintainers(), contactToCatalogContact),
+		MinimumBufVersion:      pluginInfo.MinimumBufVersion(),
+		MinimumProtocolVersion: pluginInfo.MinimumProtocolVersion(),
+		Keywords:               pluginInfo.Keywords(),
+		Deprecated:             pluginInfo.Deprecated(),
+		DeprecationMessage:     pluginInfo.DeprecationMessage(),
+		ChangelogURL:           urlString(pluginInfo.ChangelogURL()),
+		SupportURL:             urlString(pluginInfo.SupportURL()),
+	}
+	if license := pluginInfo.License(); license != nil {
+		catalogInfo.License = licenseToCatalogLicense(license)
+	}
+	if sourceRevision := pluginInfo.SourceRevision(); sourceRevision != nil {
+		catalogInfo.SourceRevision = &CatalogSourceRevision{
+			VCS:      sourceRevision.VCS(),
+			Revision: sourceRevision.Revision(),
+			Modified: sourceRevision.Modified(),
+		}
+		if revisionTime := sourceRevision.Time(); !revisionTime.IsZero() {
+			catalogInfo.SourceRevision.Time = &revisionTime
+		}
+	}
+	return catalogInfo
+}
+
+func licenseToCatalogLicense(license info.License) *CatalogLicense {
+	return &CatalogLicense{
+		SPDXLicenseID:         license.SPDXLicenseID(),
+		SPDXLicenseExpression: license.SPDXLicenseExpression(),
+		Component:             license.Component(),
+		Text:                  license.Text(),
+		URL:                   urlString(license.URL()),
+	}
+}
+
+func contactToCatalogContact(contact info.Contact) *CatalogContact {
+	return &CatalogContact{
+		Name:  contact.Name(),
+		Email: contact.Email(),
+		URL:   urlString(contact.URL()),
+	}
+}
+
+func ruleToCatalogRule(rule Rule) *CatalogRule {
+	catalogRule := &CatalogRule{
+		ID:               rule.ID(),
+		Type:             rule.Type().String(),
+		Purpose:          rule.Purpose(),
+		CategoryIDs:      xslices.Map(rule.Categories(), Category.ID),
+		Default:          rule.Default(),
+		Deprecated:       rule.Deprecated(),
+		ReplacementIDs:   rule.ReplacementIDs(),
+		Documentation:    rule.Documentation(),
+		Examples:         rule.Examples(),
+		DocumentationURL: urlString(rule.DocumentationURL()),
+	}
+	if policy := rule.Policy(); policy != nil {
+		catalogRule.Policy = &CatalogPolicy{
+			ID:       policy.ID(),
+			Severity: policy.Severity().String(),
+			Owner:    policy.Owner(),
+		}
+	}
+	return catalogRule
+}
+
+func categoryToCatalogCategory(category Category) *CatalogCategory {
+	return &CatalogCategory{
+		ID:               category.ID(),
+		Purpose:          category.Purpose(),
+		Deprecated:       category.Deprecated(),
+		ReplacementIDs:   category.ReplacementIDs(),
+		Documentation:    category.Documentation(),
+		Examples:         category.Examples(),
+		DocumentationURL: urlString(category.DocumentationURL()),
+	}
+}
+
+func keySpecToCatalogOption(keySpec *option.KeySpec) *CatalogOption {
+	catalogOption := &CatalogOption{
+		Key:           keySpec.Key,
+		Type:          keySpec.Type.String(),
+		Description:   keySpec.Description,
+		Required:      keySpec.Required,
+		Default:       keySpec.Default,
+		Example:       keySpec.Example,
+		AllowedValues: keySpec.AllowedValues,
+		Aliases:       keySpec.Aliases,
+		Secret:        keySpec.Secret,
+	}
+	if keySpec.Secret {
+		if catalogOption.Default != nil {
+			catalogOption.Default = option.RedactedValue
+		}
+		if catalogOption.Example != nil {
+			catalogOption.Example = option.RedactedValue
+		}
+	}
+	return catalogOption
+}
+
+func urlString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,154 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"buf.build/go/bufplugin/info"
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCatalog(t *testing.T) {
+	t.Parallel()
+
+	spec := &Spec{
+		Rules: []*RuleSpec{
+			{
+				ID:               "RULE1",
+				CategoryIDs:      []string{"CATEGORY1"},
+				Default:          true,
+				Purpose:          "Checks RULE1.",
+				Type:             RuleTypeLint,
+				Documentation:    "Long documentation.",
+				Examples:         []string{"Example."},
+				DocumentationURL: "https://example.com/rule1",
+				Policy: &PolicySpec{
+					ID:       "POLICY1",
+					Severity: SeverityWarning,
+					Owner:    "team-a",
+				},
+				Handler: nopRuleHandler,
+			},
+			testNewSimpleLintRuleSpec("RULE2", nil, false, true, []string{"RULE1"}),
+		},
+		Categories: []*CategorySpec{
+			{
+				ID:      "CATEGORY1",
+				Purpose: "Checks CATEGORY1.",
+			},
+		},
+		Options: &option.Schema{
+			Keys: []*option.KeySpec{
+				{
+					Key:         "suffix",
+					Type:        option.TypeString,
+					Description: "The suffix.",
+					Default:     "_time",
+				},
+				{
+					Key:     "api_token",
+					Type:    option.TypeString,
+					Example: "abc",
+					Secret:  true,
+				},
+			},
+		},
+		Info: &info.Spec{
+			SPDXLicenseID: "apache-2.0",
+			Version:       "v1.0.0",
+			Authors: []*info.ContactSpec{
+				{
+					Name:  "Acme",
+					Email: "plugins@example.com",
+				},
+			},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, writeCatalog(buffer, spec))
+	assert.JSONEq(
+		t,
+		`{
+			"info": {
+				"license": {"spdx_license_id": "Apache-2.0", "spdx_license_expression": "Apache-2.0"},
+				"version": "v1.0.0",
+				"authors": [{"name": "Acme", "email": "plugins@example.com"}]
+			},
+			"rules": [
+				{
+					"id": "RULE1",
+					"type": "lint",
+					"purpose": "Checks RULE1.",
+					"category_ids": ["CATEGORY1"],
+					"default": true,
+					"documentation": "Long documentation.",
+					"examples": ["Example."],
+					"documentation_url": "https://example.com/rule1",
+					"policy": {"id": "POLICY1", "severity": "warning", "owner": "team-a"}
+				},
+				{
+					"id": "RULE2",
+					"type": "lint",
+					"purpose": "Checks RULE2.",
+					"deprecated": true,
+					"replacement_ids": ["RULE1"]
+				}
+			],
+			"categories": [
+				{"id": "CATEGORY1", "purpose": "Checks CATEGORY1."}
+			],
+			"options": [
+				{"key": "suffix", "type": "string", "description": "The suffix.", "default": "_time"},
+				{"key": "api_token", "type": "string", "example": "REDACTED", "secret": true}
+			]
+		}`,
+		buffer.String(),
+	)
+	catalog := &Catalog{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), catalog))
+	require.Len(t, catalog.Rules, 2)
+	require.Equal(t, "POLICY1", catalog.Rules[0].Policy.ID)
+
+	require.Error(t, writeCatalog(bytes.NewBuffer(nil), &Spec{}))
+}
+
+func TestNewCatalogForClient(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClientForSpec(
+		&Spec{
+			Rules: []*RuleSpec{
+				testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil),
+			},
+		},
+	)
+	require.NoError(t, err)
+	pluginDocumentation, err := client.GetPluginDocumentation(context.Background())
+	require.NoError(t, err)
+	catalog, err := NewCatalog(pluginDocumentation)
+	require.NoError(t, err)
+	require.Nil(t, catalog.Info)
+	require.Len(t, catalog.Rules, 1)
+	require.Equal(t, "RULE1", catalog.Rules[0].ID)
+
+	_, err = NewCatalog(nil)
+	require.Error(t, err)
+}
@@ -17,6 +17,7 @@ package check
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"slices"
 	"sort"
 
@@ -28,6 +29,10 @@ import (
 //
 // Categories have unique IDs. On the server-side (i.e. the plugin), Categories are created
 // by CategorySpecs. Clients can list all available plugin Categories by calling ListCategories.
+//
+// As with Rules, Documentation, Examples, and DocumentationURL are not part of the v1
+// CheckService protocol, and are only populated on Categories returned from
+// NewPluginDocumentationForSpec.
 type Category interface {
 	// ID is the ID of the Category.
 	//
@@ -54,6 +59,21 @@ type Category interface {
 	// It is not valid for a deprecated Category to specfiy another deprecated Category as a replacement.
 	ReplacementIDs() []string
 
+	// Documentation returns the long-form documentation of the Category.
+	//
+	// Optional.
+	//
+	// This is CommonMark, and expands on Purpose.
+	Documentation() string
+	// Examples returns examples that illustrate the Category.
+	//
+	// Optional.
+	Examples() []string
+	// DocumentationURL returns a URL that contains further documentation for the Category.
+	//
+	// Optional.
+	DocumentationURL() *url.URL
+
 	toProto() *checkv1.Category
 
 	isCategory()
@@ -66,6 +86,8 @@ type category struct {
 	purpose        string
 	deprecated     bool
 	replacementIDs []string
+
+	documentationProperties
 }
 
 func newCategory(
@@ -73,6 +95,7 @@ func newCategory(
 	purpose string,
 	deprecated bool,
 	replacementIDs []string,
+	documentationProperties documentationProperties,
 ) (*category, error) {
 	if id == "" {
 		return nil, errors.New("check.Category: ID is empty")
@@ -88,6 +111,8 @@ func newCategory(
 		purpose:        purpose,
 		deprecated:     deprecated,
 		replacementIDs: replacementIDs,
+
+		documentationProperties: documentationProperties,
 	}, nil
 }
 
@@ -127,6 +152,7 @@ func categoryForProtoCategory(protoCategory *checkv1.Category) (Category, error)
 		protoCategory.GetPurpose(),
 		protoCategory.GetDeprecated(),
 		protoCategory.GetReplacementIds(),
+		documentationProperties{},
 	)
 }
 
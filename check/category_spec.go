@@ -34,17 +34,40 @@ type CategorySpec struct {
 	Purpose        string
 	Deprecated     bool
 	ReplacementIDs []string
+	// Documentation is the long-form documentation of the Category as CommonMark.
+	//
+	// Optional.
+	Documentation string
+	// Examples are CommonMark examples that illustrate the Category.
+	//
+	// Optional.
+	Examples []string
+	// DocumentationURL is a URL that contains further documentation for the Category.
+	//
+	// Optional.
+	//
+	// Must be absolute if set.
+	DocumentationURL string
 }
 
 // *** PRIVATE ***
 
 // Assumes that the CategorySpec is validated.
 func categorySpecToCategory(categorySpec *CategorySpec) (Category, error) {
+	documentationProperties, err := newDocumentationProperties(
+		categorySpec.Documentation,
+		categorySpec.Examples,
+		categorySpec.DocumentationURL,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return newCategory(
 		categorySpec.ID,
 		categorySpec.Purpose,
 		categorySpec.Deprecated,
 		categorySpec.ReplacementIDs,
+		documentationProperties,
 	)
 }
 
@@ -73,6 +96,14 @@ func validateCategorySpecs(
 		if err := validatePurpose(categorySpec.ID, categorySpec.Purpose); err != nil {
 			return wrapValidateCategorySpecError(err)
 		}
+		if err := validateDocumentationFields(
+			categorySpec.ID,
+			categorySpec.Documentation,
+			categorySpec.Examples,
+			categorySpec.DocumentationURL,
+		); err != nil {
+			return wrapValidateCategorySpecError(err)
+		}
 		if len(categorySpec.ReplacementIDs) > 0 && !categorySpec.Deprecated {
 			return newValidateCategorySpecErrorf("ID %q had ReplacementIDs but Deprecated was false", categorySpec.ID)
 		}
@@ -17,13 +17,20 @@ package check
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
 	"slices"
+	"time"
 
 	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	"buf.build/go/bufplugin/descriptor"
 	"buf.build/go/bufplugin/internal/gen/buf/plugin/check/v1/v1pluginrpc"
 	"buf.build/go/bufplugin/internal/pkg/thread"
 	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"buf.build/go/bufplugin/option"
 	"github.com/bufbuild/protovalidate-go"
+	"google.golang.org/protobuf/proto"
 	"pluginrpc.com/pluginrpc"
 )
 
@@ -55,11 +62,149 @@ func CheckServiceHandlerWithParallelism(parallelism int) CheckServiceHandlerOpti
 	}
 }
 
+// CheckServiceHandlerWithWarningWriter returns a new CheckServiceHandlerOption that sets
+// the io.Writer that warnings are written to, such as deprecation warnings for option
+// aliases.
+//
+// The default is to discard warnings.
+func CheckServiceHandlerWithWarningWriter(warningWriter io.Writer) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.warningWriter = warningWriter
+	}
+}
+
+// CheckServiceHandlerWithOptionLimits returns a new CheckServiceHandlerOption that sets
+// the limits on the options of a request.
+//
+// Requests with options that exceed the limits fail with CodeInvalidArgument before the
+// options are validated or any handlers are invoked.
+//
+// The default is option.DefaultLimits. Use option.Limits{} to remove all limits.
+func CheckServiceHandlerWithOptionLimits(optionLimits option.Limits) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.optionLimits = optionLimits
+	}
+}
+
+// CheckServiceHandlerWithDescriptorInterning returns a new CheckServiceHandlerOption that
+// interns the FileDescriptors of each request before they are used.
+//
+// This shares equal strings and options between FileDescriptors, reducing memory usage for
+// requests with a large number of files at the cost of some additional processing per request.
+// See descriptor.InternProtoFileDescriptors for details.
+//
+// The default is to not intern FileDescriptors.
+func CheckServiceHandlerWithDescriptorInterning() CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.descriptorInterning = true
+	}
+}
+
+// CheckServiceHandlerWithMessageSizeLimits returns a new CheckServiceHandlerOption that sets
+// the limits on the size of CheckRequests and CheckResponses.
+//
+// Requests that exceed the limit fail with CodeResourceExhausted before they are validated
+// or any handlers are invoked. Responses that exceed the limit fail with CodeResourceExhausted
+// instead of being returned. The errors state the limit and the actual size.
+//
+// The default is to not limit the size of CheckRequests and CheckResponses.
+func CheckServiceHandlerWithMessageSizeLimits(messageSizeLimits MessageSizeLimits) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.messageSizeLimits = messageSizeLimits
+	}
+}
+
+// CheckServiceHandlerWithMaxFileDescriptors returns a new CheckServiceHandlerOption that sets
+// the maximum number of FileDescriptors, and separately the maximum number of
+// AgainstFileDescriptors, in a CheckRequest.
+//
+// Requests that exceed the limit fail with CodeResourceExhausted before they are validated or
+// any handlers are invoked. This bounds the memory used by a plugin for pathological inputs.
+// The size of requests can be limited with CheckServiceHandlerWithMessageSizeLimits.
+//
+// The default is to not limit the number of FileDescriptors. A value <= 0 has no effect.
+func CheckServiceHandlerWithMaxFileDescriptors(maxFileDescriptors int) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.maxFileDescriptors = maxFileDescriptors
+	}
+}
+
+// CheckServiceHandlerWithMaxConcurrentChecks returns a new CheckServiceHandlerOption that sets
+// the maximum number of Check calls that are handled concurrently.
+//
+// Additional Check calls wait until a call completes, or until their context is done. This
+// bounds the resources used by a plugin that serves many clients, such as with ServeListener.
+//
+// The default is to not limit the number of concurrent Check calls. A value <= 0 has no effect.
+func CheckServiceHandlerWithMaxConcurrentChecks(maxConcurrentChecks int) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.maxConcurrentChecks = maxConcurrentChecks
+	}
+}
+
+// CheckServiceHandlerWithLogger returns a new CheckServiceHandlerOption that sets the
+// *slog.Logger that is available to RuleHandlers with Logger.
+//
+// The default is to discard all records.
+func CheckServiceHandlerWithLogger(logger *slog.Logger) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		if logger != nil {
+			checkServiceHandlerOptions.logger = logger
+		}
+	}
+}
+
+// CheckServiceHandlerWithTracer returns a new CheckServiceHandlerOption that sets the
+// Tracer used to trace Check calls and RuleHandler invocations.
+//
+// See package checkotel to trace with OpenTelemetry. The default is to not trace.
+func CheckServiceHandlerWithTracer(tracer Tracer) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.tracer = tracer
+	}
+}
+
+// CheckServiceHandlerWithMetrics returns a new CheckServiceHandlerOption that sets the
+// Metrics that receive metrics about Check calls and RuleHandler invocations.
+//
+// The default is to not record metrics.
+func CheckServiceHandlerWithMetrics(metrics Metrics) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.metrics = metrics
+	}
+}
+
+// CheckServiceHandlerWithCrashReportWriter returns a new CheckServiceHandlerOption that sets
+// the io.Writer that crash reports are written to.
+//
+// Panics in RuleHandlers and Spec.Before are always recovered, and result in an error with
+// CodeInternal that is returned to the caller. If a crash report writer is set, a report with
+// the stack of the panic, the version of the plugin, and a summary of the request is also
+// written to it.
+//
+// The default is to not write crash reports.
+func CheckServiceHandlerWithCrashReportWriter(crashReportWriter io.Writer) CheckServiceHandlerOption {
+	return func(checkServiceHandlerOptions *checkServiceHandlerOptions) {
+		checkServiceHandlerOptions.crashReportWriter = crashReportWriter
+	}
+}
+
 // *** PRIVATE ***
 
 type checkServiceHandler struct {
-	spec                 *Spec
-	parallelism          int
+	spec                *Spec
+	parallelism         int
+	warningWriter       io.Writer
+	crashReportWriter   io.Writer
+	optionLimits        option.Limits
+	descriptorInterning bool
+	messageSizeLimits   MessageSizeLimits
+	maxFileDescriptors  int
+	// checkSemaphore limits the number of concurrent Check calls if non-nil.
+	checkSemaphore       chan struct{}
+	logger               *slog.Logger
+	tracer               Tracer
+	metrics              Metrics
 	validator            *protovalidate.Validator
 	rules                []Rule
 	ruleIDToRule         map[string]Rule
@@ -122,9 +267,23 @@ func newCheckServiceHandler(spec *Spec, options ...CheckServiceHandlerOption) (*
 	if err != nil {
 		return nil, err
 	}
+	var checkSemaphore chan struct{}
+	if checkServiceHandlerOptions.maxConcurrentChecks > 0 {
+		checkSemaphore = make(chan struct{}, checkServiceHandlerOptions.maxConcurrentChecks)
+	}
 	return &checkServiceHandler{
 		spec:                 spec,
 		parallelism:          checkServiceHandlerOptions.parallelism,
+		warningWriter:        checkServiceHandlerOptions.warningWriter,
+		crashReportWriter:    checkServiceHandlerOptions.crashReportWriter,
+		optionLimits:         checkServiceHandlerOptions.optionLimits,
+		descriptorInterning:  checkServiceHandlerOptions.descriptorInterning,
+		messageSizeLimits:    checkServiceHandlerOptions.messageSizeLimits,
+		maxFileDescriptors:   checkServiceHandlerOptions.maxFileDescriptors,
+		checkSemaphore:       checkSemaphore,
+		logger:               checkServiceHandlerOptions.logger,
+		tracer:               checkServiceHandlerOptions.tracer,
+		metrics:              checkServiceHandlerOptions.metrics,
 		validator:            validator,
 		rules:                rules,
 		ruleIDToRuleHandler:  ruleIDToRuleHandler,
@@ -140,19 +299,150 @@ func (c *checkServiceHandler) Check(
 	ctx context.Context,
 	checkRequest *checkv1.CheckRequest,
 ) (*checkv1.CheckResponse, error) {
+	checkResponse, _, err := c.check(ctx, checkRequest, nil, nil)
+	return checkResponse, err
+}
+
+// check handles a CheckRequest with the given opaque state and Severity overrides, and
+// returns the CheckResponse and the Response it was created from.
+//
+// If state is nil, the Request has no state, and the state of the returned Response is nil.
+// The Severity overrides are applied as if the Options of the CheckRequest contained them
+// with SeverityOverridesOptionKey.
+func (c *checkServiceHandler) check(
+	ctx context.Context,
+	checkRequest *checkv1.CheckRequest,
+	state []byte,
+	ruleIDToSeverity map[string]Severity,
+) (*checkv1.CheckResponse, Response, error) {
+	// Enforce limits before validating so that oversized requests are rejected cheaply.
+	if err := c.messageSizeLimits.validateRequest(checkRequest); err != nil {
+		return nil, nil, err
+	}
+	return c.checkSizeValidated(ctx, checkRequest, state, ruleIDToSeverity)
+}
+
+// checkSizeValidated is check for a CheckRequest whose size has already been validated.
+//
+// This is used for CheckRequests that were sent in chunks, where the size limits apply to
+// each chunk rather than to the whole CheckRequest.
+func (c *checkServiceHandler) checkSizeValidated(
+	ctx context.Context,
+	checkRequest *checkv1.CheckRequest,
+	state []byte,
+	ruleIDToSeverity map[string]Severity,
+) (_ *checkv1.CheckResponse, _ Response, retErr error) {
+	ctx, span := startCheckSpan(ctx, c.tracer, false, checkRequest)
+	var checkResponse *checkv1.CheckResponse
+	defer func() { span.End(len(checkResponse.GetAnnotations()), retErr) }()
+	if c.metrics != nil {
+		start := time.Now()
+		defer func() { recordCheck(ctx, c.metrics, start, checkRequest, checkResponse, retErr) }()
+	}
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		c.logger.DebugContext(
+			ctx,
+			"check request received",
+			slog.Int("request_size", proto.Size(checkRequest)),
+			slog.Int("file_descriptors", len(checkRequest.GetFileDescriptors())),
+			slog.Int("against_file_descriptors", len(checkRequest.GetAgainstFileDescriptors())),
+		)
+	}
+	if err := validateFileDescriptorCounts(checkRequest, c.maxFileDescriptors); err != nil {
+		return nil, nil, err
+	}
+	if c.checkSemaphore != nil {
+		select {
+		case c.checkSemaphore <- struct{}{}:
+			defer func() { <-c.checkSemaphore }()
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+	if err := option.ValidateLimits(c.optionLimits, checkRequest.GetOptions()); err != nil {
+		return nil, nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+	}
 	if err := c.validator.Validate(checkRequest); err != nil {
-		return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+		return nil, nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+	}
+	if c.descriptorInterning {
+		if err := descriptor.InternProtoFileDescriptors(
+			checkRequest.GetFileDescriptors(),
+			checkRequest.GetAgainstFileDescriptors(),
+		); err != nil {
+			return nil, nil, err
+		}
+	}
+	var requestOptions []RequestOption
+	if state != nil {
+		requestOptions = append(requestOptions, WithState(state))
 	}
-	request, err := RequestForProtoRequest(checkRequest)
+	request, err := requestForProtoRequest(checkRequest, requestOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ruleIDToSeverity) > 0 {
+		request, err = requestWithSeverityOverrides(request, ruleIDToSeverity)
+		if err != nil {
+			return nil, nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+		}
+	}
+	response, err := c.handleRequest(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	checkResponse = response.toProto()
+	if err := c.validator.Validate(checkResponse); err != nil {
+		return nil, nil, err
+	}
+	if err := c.messageSizeLimits.validateResponse(checkResponse); err != nil {
+		return nil, nil, err
+	}
+	return checkResponse, response, nil
+}
+
+// handleRequest runs the Rules for the Request.
+//
+// This is the part of Check that operates on a Request, and is used directly by Clients created
+// with NewClientForSpec, so that Requests and Responses do not need to be serialized.
+func (c *checkServiceHandler) handleRequest(ctx context.Context, request Request) (Response, error) {
+	ctx = contextWithLogger(ctx, c.logger)
+	// Log the options before environment variables are expanded.
+	requestOptions := request.Options()
+	ruleIDToSeverity, request, err := c.requestWithoutSeverityOverrides(request)
 	if err != nil {
 		return nil, err
 	}
-	if c.spec.Before != nil {
-		ctx, request, err = c.spec.Before(ctx, request)
+	if c.spec.Options != nil {
+		options, err := option.ExpandEnv(c.spec.Options, request.Options(), os.LookupEnv)
+		if err != nil {
+			return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+		}
+		if err := option.ValidateOptions(c.spec.Options, options); err != nil {
+			return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+		}
+		options, warnings := option.ResolveAliases(c.spec.Options, options)
+		c.writeWarnings(warnings)
+		options = option.OptionsWithDefaults(c.spec.Options, options)
+		request, err = requestWithOptions(request, option.CanonicalizeAllowedValues(c.spec.Options, options))
 		if err != nil {
 			return nil, err
 		}
 	}
+	if c.spec.Before != nil {
+		if err := callWithPanicRecovery(
+			c.crashReportWriter,
+			"Before",
+			request,
+			func() error {
+				var err error
+				ctx, request, err = c.spec.Before(ctx, request)
+				return err
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
 	rules := xslices.Filter(c.rules, func(rule Rule) bool { return rule.Default() })
 	if ruleIDs := request.RuleIDs(); len(ruleIDs) > 0 {
 		rules = make([]Rule, 0)
@@ -164,6 +454,14 @@ func (c *checkServiceHandler) Check(
 			rules = append(rules, rule)
 		}
 	}
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		c.logger.DebugContext(
+			ctx,
+			"running rules",
+			slog.Any("rule_ids", xslices.Map(rules, Rule.ID)),
+			redactedOptionsAttr(requestOptions),
+		)
+	}
 	multiResponseWriter, err := newMultiResponseWriter(request)
 	if err != nil {
 		return nil, err
@@ -179,11 +477,39 @@ func (c *checkServiceHandler) Check(
 						// This should never happen.
 						return fmt.Errorf("no RuleHandler for id %q", rule.ID())
 					}
-					return ruleHandler.Handle(
-						ctx,
-						multiResponseWriter.newResponseWriter(rule.ID()),
+					ctx, span := startRuleSpan(ctx, c.tracer, rule.ID())
+					responseWriter := multiResponseWriter.newResponseWriter(rule.ID())
+					ruleRequest := request
+					if request.State() != nil {
+						ruleRequest = newRuleRequest(request, multiResponseWriter.ruleState(rule.ID()))
+					}
+					start := time.Now()
+					err := callWithPanicRecovery(
+						c.crashReportWriter,
+						fmt.Sprintf("rule %q", rule.ID()),
 						request,
+						func() error {
+							return ruleHandler.Handle(
+								contextWithLogger(ctx, c.logger.With(slog.String("rule_id", rule.ID()))),
+								responseWriter,
+								ruleRequest,
+							)
+						},
 					)
+					numAnnotations := int(responseWriter.numAnnotations.Load())
+					span.End(numAnnotations, err)
+					if c.metrics != nil {
+						c.metrics.RecordRule(
+							ctx,
+							RuleMetrics{
+								RuleID:         rule.ID(),
+								Duration:       time.Since(start),
+								NumAnnotations: numAnnotations,
+								Err:            err,
+							},
+						)
+					}
+					return err
 				}
 			},
 		),
@@ -195,11 +521,41 @@ func (c *checkServiceHandler) Check(
 	if err != nil {
 		return nil, err
 	}
-	checkResponse := response.toProto()
-	if err := c.validator.Validate(checkResponse); err != nil {
-		return nil, err
+	if len(ruleIDToSeverity) == 0 {
+		return response, nil
+	}
+	return newResponse(applySeverityOverrides(response.Annotations(), ruleIDToSeverity), response.State())
+}
+
+// requestWithoutSeverityOverrides returns the Severity overrides of the Request by Rule ID,
+// and the Request without SeverityOverridesOptionKey.
+func (c *checkServiceHandler) requestWithoutSeverityOverrides(request Request) (map[string]Severity, Request, error) {
+	ruleIDToSeverity, options, err := severityOverridesForOptions(request.Options())
+	if err != nil {
+		return nil, nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+	}
+	if ruleIDToSeverity == nil {
+		return nil, request, nil
+	}
+	for _, ruleID := range xslices.MapKeysToSortedSlice(ruleIDToSeverity) {
+		if _, ok := c.ruleIDToRule[ruleID]; !ok {
+			return nil, nil, pluginrpc.NewErrorf(pluginrpc.CodeInvalidArgument, "option %q: unknown rule ID: %q", SeverityOverridesOptionKey, ruleID)
+		}
+	}
+	request, err = requestWithOptions(request, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ruleIDToSeverity, request, nil
+}
+
+func (c *checkServiceHandler) writeWarnings(warnings []string) {
+	if c.warningWriter == nil {
+		return
+	}
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(c.warningWriter, "warning: %s\n", warning)
 	}
-	return checkResponse, nil
 }
 
 func (c *checkServiceHandler) ListRules(_ context.Context, listRulesRequest *checkv1.ListRulesRequest) (*checkv1.ListRulesResponse, error) {
@@ -299,9 +655,47 @@ func (c *checkServiceHandler) getCategoriesAndNextPageToken(pageSize int, pageTo
 }
 
 type checkServiceHandlerOptions struct {
-	parallelism int
+	parallelism         int
+	warningWriter       io.Writer
+	crashReportWriter   io.Writer
+	optionLimits        option.Limits
+	descriptorInterning bool
+	messageSizeLimits   MessageSizeLimits
+	maxFileDescriptors  int
+	maxConcurrentChecks int
+	logger              *slog.Logger
+	tracer              Tracer
+	metrics             Metrics
 }
 
 func newCheckServiceHandlerOptions() *checkServiceHandlerOptions {
-	return &checkServiceHandlerOptions{}
+	return &checkServiceHandlerOptions{
+		optionLimits: option.DefaultLimits,
+		logger:       discardLogger,
+	}
+}
+
+// validateFileDescriptorCounts returns an error with CodeResourceExhausted if the request
+// has more than maxFileDescriptors FileDescriptors or AgainstFileDescriptors.
+func validateFileDescriptorCounts(checkRequest *checkv1.CheckRequest, maxFileDescriptors int) error {
+	if maxFileDescriptors <= 0 {
+		return nil
+	}
+	if count := len(checkRequest.GetFileDescriptors()); count > maxFileDescriptors {
+		return pluginrpc.NewErrorf(
+			pluginrpc.CodeResourceExhausted,
+			"request has %d FileDescriptors, which exceeds the maximum of %d",
+			count,
+			maxFileDescriptors,
+		)
+	}
+	if count := len(checkRequest.GetAgainstFileDescriptors()); count > maxFileDescriptors {
+		return pluginrpc.NewErrorf(
+			pluginrpc.CodeResourceExhausted,
+			"request has %d AgainstFileDescriptors, which exceeds the maximum of %d",
+			count,
+			maxFileDescriptors,
+		)
+	}
+	return nil
 }
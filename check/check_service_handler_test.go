@@ -15,11 +15,14 @@
 package check
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
 	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
 	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	optionv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/option/v1"
+	"buf.build/go/bufplugin/option"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
@@ -115,3 +118,410 @@ func TestCheckServiceHandlerUniqueFiles(t *testing.T) {
 	require.ErrorAs(t, err, &pluginrpcError)
 	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
 }
+
+func TestCheckServiceHandlerOptionSchema(t *testing.T) {
+	t.Parallel()
+
+	checkServiceHandler, err := NewCheckServiceHandler(
+		&Spec{
+			Rules: []*RuleSpec{
+				testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil),
+			},
+			Options: &option.Schema{
+				Keys: []*option.KeySpec{
+					{Key: "foo_key", Type: option.TypeString},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = checkServiceHandler.Check(
+		context.Background(),
+		&checkv1.CheckRequest{
+			FileDescriptors: []*descriptorv1.FileDescriptor{
+				{
+					FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+						Name:           proto.String("foo.proto"),
+						SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+					},
+				},
+			},
+			Options: []*optionv1.Option{
+				{
+					Key: "foo_key",
+					Value: &optionv1.Value{
+						Type: &optionv1.Value_StringValue{
+							StringValue: "foo",
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = checkServiceHandler.Check(
+		context.Background(),
+		&checkv1.CheckRequest{
+			FileDescriptors: []*descriptorv1.FileDescriptor{
+				{
+					FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+						Name:           proto.String("foo.proto"),
+						SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+					},
+				},
+			},
+			Options: []*optionv1.Option{
+				{
+					Key: "foo_key",
+					Value: &optionv1.Value{
+						Type: &optionv1.Value_Int64Value{
+							Int64Value: 1,
+						},
+					},
+				},
+				{
+					Key: "bar_key",
+					Value: &optionv1.Value{
+						Type: &optionv1.Value_BoolValue{
+							BoolValue: true,
+						},
+					},
+				},
+			},
+		},
+	)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
+	require.ErrorContains(t, err, `option "bar_key": unknown option`)
+	require.ErrorContains(t, err, `option "foo_key": expected value of type string, got int64`)
+}
+
+func TestCheckServiceHandlerOptionLimits(t *testing.T) {
+	t.Parallel()
+
+	checkServiceHandler, err := NewCheckServiceHandler(
+		&Spec{
+			Rules: []*RuleSpec{
+				testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil),
+			},
+		},
+		CheckServiceHandlerWithOptionLimits(option.Limits{MaxKeys: 1}),
+	)
+	require.NoError(t, err)
+	protoOption := &optionv1.Option{
+		Key: "foo_key",
+		Value: &optionv1.Value{
+			Type: &optionv1.Value_BoolValue{
+				BoolValue: true,
+			},
+		},
+	}
+	checkRequest := &checkv1.CheckRequest{
+		FileDescriptors: []*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:           proto.String("foo.proto"),
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				},
+			},
+		},
+		Options: []*optionv1.Option{protoOption},
+	}
+	_, err = checkServiceHandler.Check(context.Background(), checkRequest)
+	require.NoError(t, err)
+
+	checkRequest.Options = append(checkRequest.Options, protoOption)
+	_, err = checkServiceHandler.Check(context.Background(), checkRequest)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
+	require.ErrorContains(t, err, "too many options: got 2, maximum is 1")
+}
+
+func TestCheckServiceHandlerDescriptorInterning(t *testing.T) {
+	t.Parallel()
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			for _, fileDescriptor := range request.FileDescriptors() {
+				responseWriter.AddAnnotation(WithMessage(fileDescriptor.FileDescriptorProto().GetName()))
+			}
+			return nil
+		},
+	)
+	checkServiceHandler, err := NewCheckServiceHandler(
+		&Spec{
+			Rules: []*RuleSpec{ruleSpec},
+		},
+		CheckServiceHandlerWithDescriptorInterning(),
+	)
+	require.NoError(t, err)
+	response, err := checkServiceHandler.Check(
+		context.Background(),
+		&checkv1.CheckRequest{
+			FileDescriptors: []*descriptorv1.FileDescriptor{
+				{
+					FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+						Name:           proto.String("foo.proto"),
+						SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, response.GetAnnotations(), 1)
+	require.Equal(t, "foo.proto", response.GetAnnotations()[0].GetMessage())
+}
+
+func TestCheckServiceHandlerOptionSchemaDefaults(t *testing.T) {
+	t.Parallel()
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			value, ok, err := request.Options().GetString("foo_key")
+			if err != nil {
+				return err
+			}
+			if ok {
+				responseWriter.AddAnnotation(WithMessage(value))
+			}
+			return nil
+		},
+	)
+	checkServiceHandler, err := NewCheckServiceHandler(
+		&Spec{
+			Rules: []*RuleSpec{
+				ruleSpec,
+			},
+			Options: &option.Schema{
+				Keys: []*option.KeySpec{
+					{Key: "foo_key", Type: option.TypeString, Default: "foo"},
+					{Key: "bar_key", Type: option.TypeInt64, Required: true},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	fileDescriptors := []*descriptorv1.FileDescriptor{
+		{
+			FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+				Name:           proto.String("foo.proto"),
+				SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+			},
+		},
+	}
+	checkResponse, err := checkServiceHandler.Check(
+		context.Background(),
+		&checkv1.CheckRequest{
+			FileDescriptors: fileDescriptors,
+			Options: []*optionv1.Option{
+				{
+					Key: "bar_key",
+					Value: &optionv1.Value{
+						Type: &optionv1.Value_Int64Value{
+							Int64Value: 1,
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, checkResponse.GetAnnotations(), 1)
+	require.Equal(t, "foo", checkResponse.GetAnnotations()[0].GetMessage())
+
+	_, err = checkServiceHandler.Check(
+		context.Background(),
+		&checkv1.CheckRequest{
+			FileDescriptors: fileDescriptors,
+		},
+	)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
+	require.ErrorContains(t, err, `option "bar_key": required option is not set, expected value of type int64`)
+}
+
+func TestCheckServiceHandlerOptionSchemaAliases(t *testing.T) {
+	t.Parallel()
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			value, _, err := request.Options().GetString("new_key")
+			if err != nil {
+				return err
+			}
+			responseWriter.AddAnnotation(WithMessage(value))
+			return nil
+		},
+	)
+	warningBuffer := bytes.NewBuffer(nil)
+	checkServiceHandler, err := NewCheckServiceHandler(
+		&Spec{
+			Rules: []*RuleSpec{
+				ruleSpec,
+			},
+			Options: &option.Schema{
+				Keys: []*option.KeySpec{
+					{Key: "new_key", Type: option.TypeString, Aliases: []string{"old_key"}},
+				},
+			},
+		},
+		CheckServiceHandlerWithWarningWriter(warningBuffer),
+	)
+	require.NoError(t, err)
+
+	checkResponse, err := checkServiceHandler.Check(
+		context.Background(),
+		&checkv1.CheckRequest{
+			FileDescriptors: []*descriptorv1.FileDescriptor{
+				{
+					FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+						Name:           proto.String("foo.proto"),
+						SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+					},
+				},
+			},
+			Options: []*optionv1.Option{
+				{
+					Key: "old_key",
+					Value: &optionv1.Value{
+						Type: &optionv1.Value_StringValue{
+							StringValue: "foo",
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, checkResponse.GetAnnotations(), 1)
+	require.Equal(t, "foo", checkResponse.GetAnnotations()[0].GetMessage())
+	require.Equal(t, "warning: option \"old_key\" is deprecated, use \"new_key\" instead\n", warningBuffer.String())
+}
+
+func TestCheckServiceHandlerOptionSchemaAllowedValuesCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			value, _, err := request.Options().GetString("naming_style")
+			if err != nil {
+				return err
+			}
+			responseWriter.AddAnnotation(WithMessage(value))
+			return nil
+		},
+	)
+	checkServiceHandler, err := NewCheckServiceHandler(
+		&Spec{
+			Rules: []*RuleSpec{
+				ruleSpec,
+			},
+			Options: &option.Schema{
+				Keys: []*option.KeySpec{
+					{
+						Key:                          "naming_style",
+						Type:                         option.TypeString,
+						AllowedValues:                []any{"snake_case", "camelCase"},
+						AllowedValuesCaseInsensitive: true,
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	checkResponse, err := checkServiceHandler.Check(
+		context.Background(),
+		&checkv1.CheckRequest{
+			FileDescriptors: []*descriptorv1.FileDescriptor{
+				{
+					FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+						Name:           proto.String("foo.proto"),
+						SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+					},
+				},
+			},
+			Options: []*optionv1.Option{
+				{
+					Key: "naming_style",
+					Value: &optionv1.Value{
+						Type: &optionv1.Value_StringValue{
+							StringValue: "CAMELCASE",
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, checkResponse.GetAnnotations(), 1)
+	require.Equal(t, "camelCase", checkResponse.GetAnnotations()[0].GetMessage())
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestCheckServiceHandlerOptionSchemaExpandEnv(t *testing.T) {
+	t.Setenv("BUFPLUGIN_TEST_SUFFIX", "_time")
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			value, _, err := request.Options().GetString("suffix")
+			if err != nil {
+				return err
+			}
+			responseWriter.AddAnnotation(WithMessage(value))
+			return nil
+		},
+	)
+	checkServiceHandler, err := NewCheckServiceHandler(
+		&Spec{
+			Rules: []*RuleSpec{
+				ruleSpec,
+			},
+			Options: &option.Schema{
+				Keys: []*option.KeySpec{
+					{Key: "suffix", Type: option.TypeString, ExpandEnv: true},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	checkResponse, err := checkServiceHandler.Check(
+		context.Background(),
+		&checkv1.CheckRequest{
+			FileDescriptors: []*descriptorv1.FileDescriptor{
+				{
+					FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+						Name:           proto.String("foo.proto"),
+						SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+					},
+				},
+			},
+			Options: []*optionv1.Option{
+				{
+					Key: "suffix",
+					Value: &optionv1.Value{
+						Type: &optionv1.Value_StringValue{
+							StringValue: "${BUFPLUGIN_TEST_SUFFIX}",
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, checkResponse.GetAnnotations(), 1)
+	require.Equal(t, "_time", checkResponse.GetAnnotations()[0].GetMessage())
+}
@@ -0,0 +1,536 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/info"
+	checkv1pluginrpc "buf.build/go/bufplugin/internal/gen/buf/plugin/check/v1/v1pluginrpc"
+	infov1pluginrpc "buf.build/go/bufplugin/internal/gen/buf/plugin/info/v1/v1pluginrpc"
+	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"buf.build/go/bufplugin/option"
+	"github.com/bufbuild/protovalidate-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	protocolVersion = 1
+	listPageSize    = 250
+	// unknownRuleID is a valid Rule ID that no plugin is expected to have.
+	unknownRuleID = "CONFORMANCE_UNKNOWN_RULE"
+	// hugeOptionKey is the key of the option with a huge value.
+	hugeOptionKey = "conformance_huge_option"
+	// hugeOptionSize is the size of the value of the option with a huge value.
+	hugeOptionSize = 4 << 20
+	// cancellationDelay is how long a Check call runs before it is cancelled.
+	cancellationDelay = 10 * time.Millisecond
+	// cancellationGracePeriod is how long a Check call may take to return after it is cancelled.
+	cancellationGracePeriod = 5 * time.Second
+)
+
+// malformedRequestData is not a valid request in any pluginrpc format: the varint of the
+// first binary tag never terminates, and it is not valid JSON.
+var malformedRequestData = []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+func newCases() []*Case {
+	return []*Case{
+		{
+			Name:        "protocol-version",
+			Description: "The plugin prints the supported pluginrpc protocol version with --protocol.",
+			run:         runProtocolVersion,
+		},
+		{
+			Name:        "spec",
+			Description: "The plugin prints a pluginrpc spec with the CheckService procedures with --spec.",
+			run:         runSpec,
+		},
+		{
+			Name:        "list-rules",
+			Description: "ListRules returns valid Rules with unique IDs and known Category IDs.",
+			run:         runListRules,
+		},
+		{
+			Name:        "list-rules-pagination",
+			Description: "ListRules returns the same Rules when paginated with a page size of 1.",
+			run:         runListRulesPagination,
+		},
+		{
+			Name:        "list-categories",
+			Description: "ListCategories returns valid Categories with unique IDs.",
+			run:         runListCategories,
+		},
+		{
+			Name:        "plugin-info",
+			Description: "GetPluginInfo returns valid PluginInfo, if the PluginInfoService is implemented.",
+			run:         runPluginInfo,
+		},
+		{
+			Name:        "check-default-rules",
+			Description: "Check with no Rule IDs succeeds, with valid Annotations for known Rules.",
+			run:         runCheckDefaultRules,
+		},
+		{
+			Name:        "check-all-rules",
+			Description: "Check with all Rule IDs succeeds, with valid Annotations for the requested Rules.",
+			run:         runCheckAllRules,
+		},
+		{
+			Name:        "check-against-file-descriptors",
+			Description: "Check with against FileDescriptors succeeds, with valid Annotations.",
+			run:         runCheckAgainstFileDescriptors,
+		},
+		{
+			Name:        "check-empty-file-descriptors",
+			Description: "Check with no FileDescriptors fails with invalid_argument.",
+			run:         runCheckEmptyFileDescriptors,
+		},
+		{
+			Name:        "check-unknown-rule-id",
+			Description: "Check with an unknown Rule ID fails with invalid_argument.",
+			run:         runCheckUnknownRuleID,
+		},
+		{
+			Name:        "check-malformed-request",
+			Description: "Check with a malformed request returns an error, as opposed to exiting with a non-zero exit code.",
+			run:         runCheckMalformedRequest,
+		},
+		{
+			Name:        "check-huge-options",
+			Description: "Check with a huge option value succeeds, or fails with invalid_argument or resource_exhausted.",
+			run:         runCheckHugeOptions,
+		},
+		{
+			Name:        "check-cancellation",
+			Description: "A cancelled Check returns promptly, and the plugin can be called again.",
+			run:         runCheckCancellation,
+		},
+	}
+}
+
+type env struct {
+	runner             pluginrpc.Runner
+	pluginrpcClient    pluginrpc.Client
+	checkServiceClient checkv1pluginrpc.CheckServiceClient
+	client             check.Client
+	validator          *protovalidate.Validator
+}
+
+func newEnv(runner pluginrpc.Runner) (*env, error) {
+	pluginrpcClient := pluginrpc.NewClient(runner)
+	checkServiceClient, err := checkv1pluginrpc.NewCheckServiceClient(pluginrpcClient)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, err
+	}
+	return &env{
+		runner:             runner,
+		pluginrpcClient:    pluginrpcClient,
+		checkServiceClient: checkServiceClient,
+		client:             check.NewClient(pluginrpcClient, check.ClientWithCaching()),
+		validator:          validator,
+	}, nil
+}
+
+func runProtocolVersion(ctx context.Context, env *env) error {
+	stdout := bytes.NewBuffer(nil)
+	if err := env.runner.Run(
+		ctx,
+		pluginrpc.Env{
+			Args:   []string{"--" + pluginrpc.ProtocolFlagName},
+			Stdout: stdout,
+			Stderr: io.Discard,
+		},
+	); err != nil {
+		return err
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(stdout.String()))
+	if err != nil {
+		return fmt.Errorf("--%s did not print a protocol version: %q", pluginrpc.ProtocolFlagName, stdout.String())
+	}
+	if version != protocolVersion {
+		return fmt.Errorf("expected protocol version %d, got %d", protocolVersion, version)
+	}
+	return nil
+}
+
+func runSpec(ctx context.Context, env *env) error {
+	spec, err := env.pluginrpcClient.Spec(ctx)
+	if err != nil {
+		return err
+	}
+	for _, procedurePath := range []string{
+		checkv1pluginrpc.CheckServiceCheckPath,
+		checkv1pluginrpc.CheckServiceListRulesPath,
+		checkv1pluginrpc.CheckServiceListCategoriesPath,
+	} {
+		if spec.ProcedureForPath(procedurePath) == nil {
+			return fmt.Errorf("spec does not contain required procedure %q", procedurePath)
+		}
+	}
+	return nil
+}
+
+func runListRules(ctx context.Context, env *env) error {
+	protoRules, err := listProtoRules(ctx, env, listPageSize)
+	if err != nil {
+		return err
+	}
+	if len(protoRules) == 0 {
+		return errors.New("plugin has no Rules")
+	}
+	// The Client verifies that Rule IDs are unique, and that all Category IDs exist.
+	_, err = env.client.ListRules(ctx)
+	return err
+}
+
+func runListRulesPagination(ctx context.Context, env *env) error {
+	rules, err := env.client.ListRules(ctx)
+	if err != nil {
+		return err
+	}
+	protoRules, err := listProtoRules(ctx, env, 1)
+	if err != nil {
+		return err
+	}
+	expectedRuleIDs := xslices.Map(rules, check.Rule.ID)
+	ruleIDs := xslices.Map(protoRules, (*checkv1.Rule).GetId)
+	slices.Sort(ruleIDs)
+	if !slices.Equal(expectedRuleIDs, ruleIDs) {
+		return fmt.Errorf("expected Rule IDs %v with a page size of 1, got %v", expectedRuleIDs, ruleIDs)
+	}
+	return nil
+}
+
+func runListCategories(ctx context.Context, env *env) error {
+	var pageToken string
+	for {
+		response, err := env.checkServiceClient.ListCategories(
+			ctx,
+			&checkv1.ListCategoriesRequest{
+				PageSize:  listPageSize,
+				PageToken: pageToken,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if err := env.validateResponse(response); err != nil {
+			return err
+		}
+		pageToken = response.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+	// The Client verifies that Category IDs are unique.
+	_, err := env.client.ListCategories(ctx)
+	return err
+}
+
+func runPluginInfo(ctx context.Context, env *env) error {
+	spec, err := env.pluginrpcClient.Spec(ctx)
+	if err != nil {
+		return err
+	}
+	if spec.ProcedureForPath(infov1pluginrpc.PluginInfoServiceGetPluginInfoPath) == nil {
+		return nil
+	}
+	_, err = info.NewClient(env.pluginrpcClient).GetPluginInfo(ctx)
+	return err
+}
+
+func runCheckDefaultRules(ctx context.Context, env *env) error {
+	rules, err := env.client.ListRules(ctx)
+	if err != nil {
+		return err
+	}
+	request, err := newRequest()
+	if err != nil {
+		return err
+	}
+	return env.check(ctx, request, xslices.Map(rules, check.Rule.ID))
+}
+
+func runCheckAllRules(ctx context.Context, env *env) error {
+	rules, err := env.client.ListRules(ctx)
+	if err != nil {
+		return err
+	}
+	ruleIDs := xslices.Map(rules, check.Rule.ID)
+	request, err := newRequest(check.WithRuleIDs(ruleIDs...))
+	if err != nil {
+		return err
+	}
+	return env.check(ctx, request, ruleIDs)
+}
+
+func runCheckAgainstFileDescriptors(ctx context.Context, env *env) error {
+	rules, err := env.client.ListRules(ctx)
+	if err != nil {
+		return err
+	}
+	againstFileDescriptors, err := newFileDescriptors()
+	if err != nil {
+		return err
+	}
+	request, err := newRequest(check.WithAgainstFileDescriptors(againstFileDescriptors))
+	if err != nil {
+		return err
+	}
+	return env.check(ctx, request, xslices.Map(rules, check.Rule.ID))
+}
+
+func runCheckEmptyFileDescriptors(ctx context.Context, env *env) error {
+	_, err := env.checkServiceClient.Check(ctx, &checkv1.CheckRequest{})
+	return validateErrorCode(err, pluginrpc.CodeInvalidArgument)
+}
+
+func runCheckUnknownRuleID(ctx context.Context, env *env) error {
+	_, err := env.checkServiceClient.Check(
+		ctx,
+		&checkv1.CheckRequest{
+			FileDescriptors: newProtoFileDescriptors(),
+			RuleIds:         []string{unknownRuleID},
+		},
+	)
+	return validateErrorCode(err, pluginrpc.CodeInvalidArgument)
+}
+
+func runCheckMalformedRequest(ctx context.Context, env *env) error {
+	checkServiceClient, err := checkv1pluginrpc.NewCheckServiceClient(
+		pluginrpc.NewClient(&malformedRequestRunner{runner: env.runner}),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = checkServiceClient.Check(
+		ctx,
+		&checkv1.CheckRequest{
+			FileDescriptors: newProtoFileDescriptors(),
+		},
+	)
+	return validateErrorCode(err)
+}
+
+func runCheckHugeOptions(ctx context.Context, env *env) error {
+	options, err := option.NewOptions(map[string]any{hugeOptionKey: strings.Repeat("a", hugeOptionSize)})
+	if err != nil {
+		return err
+	}
+	protoOptions, err := options.ToProto()
+	if err != nil {
+		return err
+	}
+	response, err := env.checkServiceClient.Check(
+		ctx,
+		&checkv1.CheckRequest{
+			FileDescriptors: newProtoFileDescriptors(),
+			Options:         protoOptions,
+		},
+	)
+	if err != nil {
+		return validateErrorCode(err, pluginrpc.CodeInvalidArgument, pluginrpc.CodeResourceExhausted)
+	}
+	return env.validateResponse(response)
+}
+
+func runCheckCancellation(ctx context.Context, env *env) error {
+	request, err := newRequest()
+	if err != nil {
+		return err
+	}
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = env.client.Check(cancelCtx, request)
+	}()
+	select {
+	case <-done:
+	case <-time.After(cancellationDelay):
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(cancellationGracePeriod):
+			return fmt.Errorf("call to Check did not return within %v of being cancelled", cancellationGracePeriod)
+		}
+	}
+	// The plugin can be called again after a cancelled call.
+	_, err = env.client.Check(ctx, request)
+	return err
+}
+
+// listProtoRules lists the Rules with the given page size, and validates each response.
+func listProtoRules(ctx context.Context, env *env, pageSize int) ([]*checkv1.Rule, error) {
+	var protoRules []*checkv1.Rule
+	var pageToken string
+	for {
+		response, err := env.checkServiceClient.ListRules(
+			ctx,
+			&checkv1.ListRulesRequest{
+				PageSize:  uint32(pageSize),
+				PageToken: pageToken,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := env.validateResponse(response); err != nil {
+			return nil, err
+		}
+		if len(response.GetRules()) > pageSize {
+			return nil, fmt.Errorf("ListRules returned %d Rules for a page size of %d", len(response.GetRules()), pageSize)
+		}
+		protoRules = append(protoRules, response.GetRules()...)
+		pageToken = response.GetNextPageToken()
+		if pageToken == "" {
+			return protoRules, nil
+		}
+		if len(response.GetRules()) == 0 {
+			return nil, errors.New("ListRules returned an empty page with a next page token")
+		}
+	}
+}
+
+// check calls Check with the Client, which verifies that all Annotations refer to files
+// within the Request, and verifies that all Annotations are for the given Rule IDs.
+func (e *env) check(ctx context.Context, request check.Request, ruleIDs []string) error {
+	response, err := e.client.Check(ctx, request)
+	if err != nil {
+		return err
+	}
+	for _, annotation := range response.Annotations() {
+		if !slices.Contains(ruleIDs, annotation.RuleID()) {
+			return fmt.Errorf("unexpected Rule ID %q on Annotation", annotation.RuleID())
+		}
+	}
+	return nil
+}
+
+func (e *env) validateResponse(response proto.Message) error {
+	if err := e.validator.Validate(response); err != nil {
+		return fmt.Errorf("invalid %s: %w", response.ProtoReflect().Descriptor().Name(), err)
+	}
+	return nil
+}
+
+// validateErrorCode verifies that the error was returned by the plugin as opposed to the
+// plugin exiting with a non-zero exit code, and that the error has one of the given codes.
+//
+// If no codes are given, any code is accepted.
+func validateErrorCode(err error, codes ...pluginrpc.Code) error {
+	if err == nil {
+		return errors.New("expected an error, but the call succeeded")
+	}
+	exitError := &pluginrpc.ExitError{}
+	if errors.As(err, &exitError) {
+		return fmt.Errorf("expected the plugin to return an error, but the plugin exited: %w", err)
+	}
+	pluginrpcError := &pluginrpc.Error{}
+	if !errors.As(err, &pluginrpcError) {
+		return fmt.Errorf("expected the plugin to return an error, got: %w", err)
+	}
+	if len(codes) > 0 && !slices.Contains(codes, pluginrpcError.Code()) {
+		return fmt.Errorf("expected an error with code %s, got: %w", codesString(codes), err)
+	}
+	return nil
+}
+
+func codesString(codes []pluginrpc.Code) string {
+	return strings.Join(xslices.Map(codes, pluginrpc.Code.String), " or ")
+}
+
+func newRequest(options ...check.RequestOption) (check.Request, error) {
+	fileDescriptors, err := newFileDescriptors()
+	if err != nil {
+		return nil, err
+	}
+	return check.NewRequest(fileDescriptors, options...)
+}
+
+func newFileDescriptors() ([]descriptor.FileDescriptor, error) {
+	return descriptor.FileDescriptorsForProtoFileDescriptors(newProtoFileDescriptors())
+}
+
+// newProtoFileDescriptors returns a small, valid set of FileDescriptors that exercises
+// common Rules.
+func newProtoFileDescriptors() []*descriptorv1.FileDescriptor {
+	return []*descriptorv1.FileDescriptor{
+		{
+			FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+				Name:    proto.String("conformance/v1/conformance.proto"),
+				Package: proto.String("conformance.v1"),
+				Syntax:  proto.String("proto3"),
+				MessageType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("ConformanceMessage"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{
+								Name:     proto.String("conformance_field"),
+								Number:   proto.Int32(1),
+								Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+								Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+								JsonName: proto.String("conformanceField"),
+							},
+						},
+					},
+				},
+				EnumType: []*descriptorpb.EnumDescriptorProto{
+					{
+						Name: proto.String("ConformanceEnum"),
+						Value: []*descriptorpb.EnumValueDescriptorProto{
+							{
+								Name:   proto.String("CONFORMANCE_ENUM_UNSPECIFIED"),
+								Number: proto.Int32(0),
+							},
+						},
+					},
+				},
+				SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+			},
+		},
+	}
+}
+
+// malformedRequestRunner replaces the request of all calls with malformedRequestData.
+type malformedRequestRunner struct {
+	runner pluginrpc.Runner
+}
+
+func (r *malformedRequestRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	if env.Stdin != nil {
+		env.Stdin = bytes.NewReader(malformedRequestData)
+	}
+	return r.runner.Run(ctx, env)
+}
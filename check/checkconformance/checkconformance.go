@@ -0,0 +1,204 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkconformance verifies that check plugins implement the protocol correctly.
+//
+// The conformance Cases exercise a plugin binary over the full protocol surface: the
+// pluginrpc flags, listing of Rules and Categories, well-formed Check calls, and the error
+// handling for malformed requests, empty FileDescriptors, huge options, and cancellation.
+// As the Cases only rely on the protocol, plugins written in any language can be verified.
+//
+// Plugins built with this library should pass all Cases by construction. Authors of plugins
+// that implement the protocol themselves should run the Cases as part of their CI, either
+// with Run, or with the bufplugin-check-conformance command:
+//
+//	go run buf.build/go/bufplugin/cmd/bufplugin-check-conformance path/to/plugin
+package checkconformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// DefaultCaseTimeout is the default timeout for a single Case.
+const DefaultCaseTimeout = 30 * time.Second
+
+// Case is a single conformance test case.
+type Case struct {
+	// Name is the unique name of the Case, such as "check-empty-file-descriptors".
+	Name string
+	// Description is a user-readable description of what the Case verifies.
+	Description string
+
+	run func(context.Context, *env) error
+}
+
+// Cases returns all conformance Cases, in the order in which they are run.
+func Cases() []*Case {
+	return newCases()
+}
+
+// Result is the result of running a single Case.
+type Result struct {
+	// Case is the Case that was run.
+	Case *Case
+	// Duration is how long the Case took to run.
+	Duration time.Duration
+	// Err is the reason that the Case failed, or nil if the Case passed.
+	Err error
+}
+
+// Passed returns true if the Case passed.
+func (r *Result) Passed() bool {
+	return r.Err == nil
+}
+
+// Report is the result of running conformance Cases against a plugin.
+type Report struct {
+	// Results are the Results of the Cases, in the order in which they were run.
+	Results []*Result
+}
+
+// Passed returns true if all Cases passed.
+func (r *Report) Passed() bool {
+	for _, result := range r.Results {
+		if !result.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteText writes a user-readable summary of the Report, with one line per Case.
+func (r *Report) WriteText(writer io.Writer) error {
+	var numPassed int
+	for _, result := range r.Results {
+		duration := result.Duration.Round(time.Millisecond)
+		if result.Passed() {
+			numPassed++
+			if _, err := fmt.Fprintf(writer, "PASS %s (%v)\n", result.Case.Name, duration); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(writer, "FAIL %s (%v): %v\n", result.Case.Name, duration, result.Err); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(writer, "%d/%d cases passed\n", numPassed, len(r.Results))
+	return err
+}
+
+// Run runs the conformance Cases against the plugin run by the given pluginrpc.Runner.
+//
+// A returned error indicates that the Cases could not be run. Failing Cases are reported
+// on the Report.
+func Run(ctx context.Context, runner pluginrpc.Runner, options ...RunOption) (*Report, error) {
+	runOptions := newRunOptions()
+	for _, option := range options {
+		option(runOptions)
+	}
+	cases, err := selectCases(Cases(), runOptions.caseNames)
+	if err != nil {
+		return nil, err
+	}
+	env, err := newEnv(runner)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	for _, c := range cases {
+		report.Results = append(report.Results, runCase(ctx, env, c, runOptions.caseTimeout))
+	}
+	return report, nil
+}
+
+// RunOption is an option for Run.
+type RunOption func(*runOptions)
+
+// RunWithCaseTimeout returns a new RunOption that sets the timeout for each Case.
+//
+// The default is DefaultCaseTimeout.
+func RunWithCaseTimeout(caseTimeout time.Duration) RunOption {
+	return func(runOptions *runOptions) {
+		runOptions.caseTimeout = caseTimeout
+	}
+}
+
+// RunWithCaseNames returns a new RunOption that only runs the Cases with the given names.
+//
+// Run returns an error if a name does not match a Case.
+// The default is to run all Cases.
+func RunWithCaseNames(caseNames ...string) RunOption {
+	return func(runOptions *runOptions) {
+		runOptions.caseNames = append(runOptions.caseNames, caseNames...)
+	}
+}
+
+// *** PRIVATE ***
+
+func runCase(ctx context.Context, env *env, c *Case, caseTimeout time.Duration) *Result {
+	ctx, cancel := context.WithTimeout(ctx, caseTimeout)
+	defer cancel()
+	start := time.Now()
+	err := c.run(ctx, env)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %v: %w", caseTimeout, err)
+	}
+	return &Result{
+		Case:     c,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+}
+
+func selectCases(cases []*Case, caseNames []string) ([]*Case, error) {
+	if len(caseNames) == 0 {
+		return cases, nil
+	}
+	nameToCase := make(map[string]*Case, len(cases))
+	for _, c := range cases {
+		nameToCase[c.Name] = c
+	}
+	selected := make(map[string]struct{}, len(caseNames))
+	for _, caseName := range caseNames {
+		if _, ok := nameToCase[caseName]; !ok {
+			return nil, fmt.Errorf("unknown case: %q", caseName)
+		}
+		selected[caseName] = struct{}{}
+	}
+	var selectedCases []*Case
+	for _, c := range cases {
+		if _, ok := selected[c.Name]; ok {
+			selectedCases = append(selectedCases, c)
+		}
+	}
+	return selectedCases, nil
+}
+
+type runOptions struct {
+	caseTimeout time.Duration
+	caseNames   []string
+}
+
+func newRunOptions() *runOptions {
+	return &runOptions{
+		caseTimeout: DefaultCaseTimeout,
+	}
+}
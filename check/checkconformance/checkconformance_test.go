@@ -0,0 +1,181 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/info"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	server, err := check.NewServer(testNewSpec())
+	require.NoError(t, err)
+	report, err := Run(context.Background(), pluginrpc.NewServerRunner(server))
+	require.NoError(t, err)
+	require.Len(t, report.Results, len(Cases()))
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, report.WriteText(buffer))
+	require.True(t, report.Passed(), buffer.String())
+	require.Contains(t, buffer.String(), "14/14 cases passed")
+}
+
+func TestRunNonConforming(t *testing.T) {
+	t.Parallel()
+
+	server, err := check.NewServer(testNewSpec())
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+	// A plugin that crashes on all Check calls.
+	runner := testRunnerFunc(
+		func(ctx context.Context, env pluginrpc.Env) error {
+			if len(env.Args) > 0 && env.Args[0] == "check" {
+				return pluginrpc.NewExitError(2, errors.New("crash"))
+			}
+			return serverRunner.Run(ctx, env)
+		},
+	)
+	report, err := Run(context.Background(), runner)
+	require.NoError(t, err)
+	require.False(t, report.Passed())
+	var failedCaseNames []string
+	for _, result := range report.Results {
+		if !result.Passed() {
+			failedCaseNames = append(failedCaseNames, result.Case.Name)
+		}
+	}
+	require.Equal(
+		t,
+		[]string{
+			"check-default-rules",
+			"check-all-rules",
+			"check-against-file-descriptors",
+			"check-empty-file-descriptors",
+			"check-unknown-rule-id",
+			"check-malformed-request",
+			"check-huge-options",
+			"check-cancellation",
+		},
+		failedCaseNames,
+	)
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, report.WriteText(buffer))
+	require.Contains(t, buffer.String(), "FAIL check-malformed-request")
+	require.Contains(t, buffer.String(), "6/14 cases passed")
+}
+
+func TestRunWithCaseNames(t *testing.T) {
+	t.Parallel()
+
+	server, err := check.NewServer(testNewSpec())
+	require.NoError(t, err)
+	runner := pluginrpc.NewServerRunner(server)
+	report, err := Run(
+		context.Background(),
+		runner,
+		RunWithCaseNames("check-unknown-rule-id", "protocol-version"),
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		// Cases are run in order.
+		[]string{"protocol-version", "check-unknown-rule-id"},
+		testCaseNames(report),
+	)
+	require.True(t, report.Passed())
+	_, err = Run(context.Background(), runner, RunWithCaseNames("unknown"))
+	require.Error(t, err)
+}
+
+func TestCases(t *testing.T) {
+	t.Parallel()
+
+	names := make(map[string]struct{})
+	for _, c := range Cases() {
+		require.NotEmpty(t, c.Name)
+		require.NotEmpty(t, c.Description)
+		require.NotContains(t, names, c.Name)
+		names[c.Name] = struct{}{}
+	}
+}
+
+func testCaseNames(report *Report) []string {
+	caseNames := make([]string, 0, len(report.Results))
+	for _, result := range report.Results {
+		caseNames = append(caseNames, result.Case.Name)
+	}
+	return caseNames
+}
+
+func testNewSpec() *check.Spec {
+	return &check.Spec{
+		Rules: []*check.RuleSpec{
+			{
+				ID:          "FIELD_NAME",
+				CategoryIDs: []string{"NAMING"},
+				Default:     true,
+				Purpose:     "Checks field names.",
+				Type:        check.RuleTypeLint,
+				Handler: check.RuleHandlerFunc(
+					func(_ context.Context, responseWriter check.ResponseWriter, request check.Request) error {
+						for _, fileDescriptor := range request.FileDescriptors() {
+							messages := fileDescriptor.ProtoreflectFileDescriptor().Messages()
+							for i := 0; i < messages.Len(); i++ {
+								fields := messages.Get(i).Fields()
+								for j := 0; j < fields.Len(); j++ {
+									responseWriter.AddAnnotation(check.WithDescriptor(fields.Get(j)))
+								}
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ID:      "FIELD_NO_DELETE",
+				Purpose: "Checks that fields are not deleted.",
+				Type:    check.RuleTypeBreaking,
+				Handler: check.RuleHandlerFunc(
+					func(context.Context, check.ResponseWriter, check.Request) error {
+						return nil
+					},
+				),
+			},
+		},
+		Categories: []*check.CategorySpec{
+			{
+				ID:      "NAMING",
+				Purpose: "Checks names.",
+			},
+		},
+		Info: &info.Spec{
+			Documentation: "A plugin for conformance tests.",
+		},
+	}
+}
+
+type testRunnerFunc func(context.Context, pluginrpc.Env) error
+
+func (f testRunnerFunc) Run(ctx context.Context, env pluginrpc.Env) error {
+	return f(ctx, env)
+}
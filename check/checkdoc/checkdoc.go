@@ -0,0 +1,422 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkdoc renders documentation for check plugins.
+//
+// Documentation is rendered from a check.PluginDocumentation, so that published
+// documentation is always derived from the same Spec that the plugin itself runs with.
+// To include properties that are not transmitted over the wire, such as
+// check.Rule.Documentation, create the check.PluginDocumentation with
+// check.NewPluginDocumentationForSpec.
+package checkdoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/info"
+	"buf.build/go/bufplugin/option"
+)
+
+const (
+	// IndexFilePath is the path of the index file within the files returned by MarkdownFiles.
+	IndexFilePath = "index.md"
+
+	rulesDirPath      = "rules"
+	categoriesDirPath = "categories"
+)
+
+// MarkdownFiles renders a Markdown documentation set for the plugin.
+//
+// The returned map is from relative file path to file content. It contains IndexFilePath,
+// which describes the plugin and lists all Rules, Categories, and options, as well as one file per
+// Rule at "rules/<ID>.md" and one file per Category at "categories/<ID>.md". Files link to
+// each other with relative links.
+func MarkdownFiles(pluginDocumentation check.PluginDocumentation, options ...MarkdownOption) (map[string][]byte, error) {
+	markdownOptions := newMarkdownOptions()
+	for _, option := range options {
+		option(markdownOptions)
+	}
+	if pluginDocumentation == nil {
+		return nil, errors.New("checkdoc: PluginDocumentation is nil")
+	}
+	pathToData := map[string][]byte{
+		IndexFilePath: []byte(renderIndex(pluginDocumentation, markdownOptions.title)),
+	}
+	for _, rule := range pluginDocumentation.Rules() {
+		pathToData[rulePath(rule.ID())] = []byte(renderRule(rule))
+	}
+	for _, category := range pluginDocumentation.Categories() {
+		pathToData[categoryPath(category.ID())] = []byte(renderCategory(category, pluginDocumentation.Rules()))
+	}
+	return pathToData, nil
+}
+
+// WriteMarkdownFiles renders a Markdown documentation set for the plugin with MarkdownFiles,
+// and writes it to the given directory.
+//
+// The directory and any subdirectories are created if they do not exist. Existing files
+// with the same paths are overwritten.
+func WriteMarkdownFiles(dirPath string, pluginDocumentation check.PluginDocumentation, options ...MarkdownOption) error {
+	pathToData, err := MarkdownFiles(pluginDocumentation, options...)
+	if err != nil {
+		return err
+	}
+	for _, path := range sortedKeys(pathToData) {
+		filePath := filepath.Join(dirPath, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filePath, pathToData[path], 0644); err != nil { //nolint:gosec
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkdownOption is an option for MarkdownFiles and WriteMarkdownFiles.
+type MarkdownOption func(*markdownOptions)
+
+// MarkdownWithTitle returns a new MarkdownOption that sets the title of the index file.
+//
+// The default is "Plugin documentation".
+func MarkdownWithTitle(title string) MarkdownOption {
+	return func(markdownOptions *markdownOptions) {
+		markdownOptions.title = title
+	}
+}
+
+// *** PRIVATE ***
+
+func renderIndex(pluginDocumentation check.PluginDocumentation, title string) string {
+	var sb strings.Builder
+	writeHeading(&sb, 1, title)
+	if pluginInfo := pluginDocumentation.PluginInfo(); pluginInfo != nil {
+		writePluginInfo(&sb, pluginInfo)
+	}
+	writeHeading(&sb, 2, "Rules")
+	_, _ = sb.WriteString("| ID | Type | Categories | Default | Purpose |\n")
+	_, _ = sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, rule := range pluginDocumentation.Rules() {
+		_, _ = fmt.Fprintf(
+			&sb,
+			"| %s | %s | %s | %s | %s |\n",
+			markdownLink(rule.ID(), rulePath(rule.ID())),
+			rule.Type().String(),
+			categoryLinks(rule.Categories(), ""),
+			yesNo(rule.Default()),
+			escapeTableCell(ruleSummary(rule)),
+		)
+	}
+	if categories := pluginDocumentation.Categories(); len(categories) > 0 {
+		_, _ = sb.WriteString("\n")
+		writeHeading(&sb, 2, "Categories")
+		_, _ = sb.WriteString("| ID | Purpose |\n")
+		_, _ = sb.WriteString("| --- | --- |\n")
+		for _, category := range categories {
+			_, _ = fmt.Fprintf(
+				&sb,
+				"| %s | %s |\n",
+				markdownLink(category.ID(), categoryPath(category.ID())),
+				escapeTableCell(categorySummary(category)),
+			)
+		}
+	}
+	if optionsSchema := pluginDocumentation.OptionsSchema(); optionsSchema != nil && len(optionsSchema.Keys) > 0 {
+		_, _ = sb.WriteString("\n")
+		writeHeading(&sb, 2, "Options")
+		_, _ = sb.WriteString("| Key | Type | Required | Default | Example | Description |\n")
+		_, _ = sb.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, keySpec := range optionsSchema.Keys {
+			_, _ = fmt.Fprintf(
+				&sb,
+				"| %s | %s | %s | %s | %s | %s |\n",
+				optionKeyString(keySpec),
+				keySpec.Type.String(),
+				yesNo(keySpec.Required),
+				optionValueString(keySpec.Default, keySpec.Secret),
+				optionValueString(keySpec.Example, keySpec.Secret),
+				escapeTableCell(keySpec.Description),
+			)
+		}
+	}
+	return sb.String()
+}
+
+func writePluginInfo(sb *strings.Builder, pluginInfo info.PluginInfo) {
+	if pluginInfo.Deprecated() {
+		_, _ = sb.WriteString("> **Deprecated.**")
+		if deprecationMessage := pluginInfo.DeprecationMessage(); deprecationMessage != "" {
+			_, _ = sb.WriteString(" ")
+			_, _ = sb.WriteString(deprecationMessage)
+		}
+		_, _ = sb.WriteString("\n\n")
+	}
+	if documentation := strings.TrimSpace(pluginInfo.Documentation()); documentation != "" {
+		_, _ = sb.WriteString(documentation)
+		_, _ = sb.WriteString("\n\n")
+	}
+	var properties [][2]string
+	if url := pluginInfo.URL(); url != nil {
+		properties = append(properties, [2]string{"Homepage", "<" + url.String() + ">"})
+	}
+	if version := pluginInfo.Version(); version != "" {
+		properties = append(properties, [2]string{"Version", version})
+	}
+	if license := pluginInfo.License(); license != nil {
+		properties = append(properties, [2]string{"License", licenseString(license)})
+	}
+	for _, additionalLicense := range pluginInfo.AdditionalLicenses() {
+		properties = append(
+			properties,
+			[2]string{"License (" + additionalLicense.Component() + ")", licenseString(additionalLicense)},
+		)
+	}
+	if authors := pluginInfo.Authors(); len(authors) > 0 {
+		properties = append(properties, [2]string{"Authors", contactsString(authors)})
+	}
+	if maintainers := pluginInfo.Maintainers(); len(maintainers) > 0 {
+		properties = append(properties, [2]string{"Maintainers", contactsString(maintainers)})
+	}
+	if minimumBufVersion := pluginInfo.MinimumBufVersion(); minimumBufVersion != "" {
+		properties = append(properties, [2]string{"Minimum buf version", minimumBufVersion})
+	}
+	if keywords := pluginInfo.Keywords(); len(keywords) > 0 {
+		properties = append(properties, [2]string{"Keywords", strings.Join(keywords, ", ")})
+	}
+	if changelogURL := pluginInfo.ChangelogURL(); changelogURL != nil {
+		properties = append(properties, [2]string{"Changelog", "<" + changelogURL.String() + ">"})
+	}
+	if supportURL := pluginInfo.SupportURL(); supportURL != nil {
+		properties = append(properties, [2]string{"Support", "<" + supportURL.String() + ">"})
+	}
+	if len(properties) == 0 {
+		return
+	}
+	for _, property := range properties {
+		_, _ = fmt.Fprintf(sb, "- **%s:** %s\n", property[0], property[1])
+	}
+	_, _ = sb.WriteString("\n")
+}
+
+func renderRule(rule check.Rule) string {
+	var sb strings.Builder
+	writeHeading(&sb, 1, rule.ID())
+	_, _ = sb.WriteString(rule.Purpose())
+	_, _ = sb.WriteString("\n\n")
+	_, _ = fmt.Fprintf(&sb, "- **Type:** %s\n", rule.Type().String())
+	_, _ = fmt.Fprintf(&sb, "- **Default:** %s\n", yesNo(rule.Default()))
+	if categories := rule.Categories(); len(categories) > 0 {
+		_, _ = fmt.Fprintf(&sb, "- **Categories:** %s\n", categoryLinks(categories, "../"))
+	}
+	if rule.Deprecated() {
+		_, _ = fmt.Fprintf(&sb, "- **Deprecated:** %s\n", replacementString(rule.ReplacementIDs()))
+	}
+	if documentationURL := rule.DocumentationURL(); documentationURL != nil {
+		_, _ = fmt.Fprintf(&sb, "- **More information:** <%s>\n", documentationURL.String())
+	}
+	writeDocumentationAndExamples(&sb, rule.Documentation(), rule.Examples())
+	_, _ = fmt.Fprintf(&sb, "\n%s\n", markdownLink("Back to index", "../"+IndexFilePath))
+	return sb.String()
+}
+
+func renderCategory(category check.Category, rules []check.Rule) string {
+	var sb strings.Builder
+	writeHeading(&sb, 1, category.ID())
+	_, _ = sb.WriteString(category.Purpose())
+	_, _ = sb.WriteString("\n\n")
+	if category.Deprecated() {
+		_, _ = fmt.Fprintf(&sb, "- **Deprecated:** %s\n", replacementString(category.ReplacementIDs()))
+	}
+	if documentationURL := category.DocumentationURL(); documentationURL != nil {
+		_, _ = fmt.Fprintf(&sb, "- **More information:** <%s>\n", documentationURL.String())
+	}
+	if category.Deprecated() || category.DocumentationURL() != nil {
+		_, _ = sb.WriteString("\n")
+	}
+	writeHeading(&sb, 2, "Rules")
+	for _, rule := range rules {
+		for _, ruleCategory := range rule.Categories() {
+			if ruleCategory.ID() == category.ID() {
+				_, _ = fmt.Fprintf(&sb, "- %s: %s\n", markdownLink(rule.ID(), "../"+rulePath(rule.ID())), ruleSummary(rule))
+				break
+			}
+		}
+	}
+	writeDocumentationAndExamples(&sb, category.Documentation(), category.Examples())
+	_, _ = fmt.Fprintf(&sb, "\n%s\n", markdownLink("Back to index", "../"+IndexFilePath))
+	return sb.String()
+}
+
+func writeDocumentationAndExamples(sb *strings.Builder, documentation string, examples []string) {
+	if documentation = strings.TrimSpace(documentation); documentation != "" {
+		_, _ = sb.WriteString("\n")
+		writeHeading(sb, 2, "Documentation")
+		_, _ = sb.WriteString(documentation)
+		_, _ = sb.WriteString("\n")
+	}
+	if len(examples) > 0 {
+		_, _ = sb.WriteString("\n")
+		writeHeading(sb, 2, "Examples")
+		for i, example := range examples {
+			if i > 0 {
+				_, _ = sb.WriteString("\n")
+			}
+			_, _ = sb.WriteString(strings.TrimSpace(example))
+			_, _ = sb.WriteString("\n")
+		}
+	}
+}
+
+func writeHeading(sb *strings.Builder, level int, text string) {
+	_, _ = sb.WriteString(strings.Repeat("#", level))
+	_, _ = sb.WriteString(" ")
+	_, _ = sb.WriteString(text)
+	_, _ = sb.WriteString("\n\n")
+}
+
+func ruleSummary(rule check.Rule) string {
+	if rule.Deprecated() {
+		return "**Deprecated.** " + rule.Purpose()
+	}
+	return rule.Purpose()
+}
+
+func categorySummary(category check.Category) string {
+	if category.Deprecated() {
+		return "**Deprecated.** " + category.Purpose()
+	}
+	return category.Purpose()
+}
+
+func replacementString(replacementIDs []string) string {
+	if len(replacementIDs) == 0 {
+		return "yes"
+	}
+	return "yes, replaced by " + strings.Join(replacementIDs, ", ")
+}
+
+func categoryLinks(categories []check.Category, prefix string) string {
+	links := make([]string, len(categories))
+	for i, category := range categories {
+		links[i] = markdownLink(category.ID(), prefix+categoryPath(category.ID()))
+	}
+	return strings.Join(links, ", ")
+}
+
+func licenseString(license info.License) string {
+	var name string
+	switch {
+	case license.SPDXLicenseExpression() != "":
+		name = license.SPDXLicenseExpression()
+	case license.SPDXLicenseID() != "":
+		name = license.SPDXLicenseID()
+	default:
+		name = "Custom"
+	}
+	if url := license.URL(); url != nil {
+		return markdownLink(name, url.String())
+	}
+	return name
+}
+
+func contactsString(contacts []info.Contact) string {
+	contactStrings := make([]string, len(contacts))
+	for i, contact := range contacts {
+		switch {
+		case contact.URL() != nil:
+			contactStrings[i] = markdownLink(contact.Name(), contact.URL().String())
+		case contact.Email() != "":
+			contactStrings[i] = markdownLink(contact.Name(), "mailto:"+contact.Email())
+		default:
+			contactStrings[i] = contact.Name()
+		}
+	}
+	return strings.Join(contactStrings, ", ")
+}
+
+func optionKeyString(keySpec *option.KeySpec) string {
+	keyString := "`" + keySpec.Key + "`"
+	if len(keySpec.Aliases) == 0 {
+		return keyString
+	}
+	aliasStrings := make([]string, len(keySpec.Aliases))
+	for i, alias := range keySpec.Aliases {
+		aliasStrings[i] = "`" + alias + "`"
+	}
+	return keyString + " (deprecated aliases: " + strings.Join(aliasStrings, ", ") + ")"
+}
+
+// optionValueString returns the JSON representation of a Default or Example value.
+func optionValueString(value any, secret bool) string {
+	if value == nil {
+		return ""
+	}
+	if secret {
+		return option.RedactedValue
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return escapeTableCell(fmt.Sprintf("%v", value))
+	}
+	return "`" + escapeTableCell(string(data)) + "`"
+}
+
+func markdownLink(text string, target string) string {
+	return "[" + text + "](" + target + ")"
+}
+
+func escapeTableCell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", `\|`), "\n", " ")
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func rulePath(id string) string {
+	return rulesDirPath + "/" + id + ".md"
+}
+
+func categoryPath(id string) string {
+	return categoriesDirPath + "/" + id + ".md"
+}
+
+func sortedKeys(pathToData map[string][]byte) []string {
+	paths := make([]string, 0, len(pathToData))
+	for path := range pathToData {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+type markdownOptions struct {
+	title string
+}
+
+func newMarkdownOptions() *markdownOptions {
+	return &markdownOptions{
+		title: "Plugin documentation",
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkdoc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/info"
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownFiles(t *testing.T) {
+	t.Parallel()
+
+	pluginDocumentation, err := check.NewPluginDocumentationForSpec(
+		&check.Spec{
+			Rules: []*check.RuleSpec{
+				{
+					ID:            "RULE1",
+					CategoryIDs:   []string{"CATEGORY1"},
+					Default:       true,
+					Purpose:       "Checks RULE1.",
+					Type:          check.RuleTypeLint,
+					Handler:       testNopRuleHandler,
+					Documentation: "Some long documentation.",
+					Examples:      []string{"```proto\nmessage Foo {}\n```"},
+				},
+				{
+					ID:             "RULE2",
+					Purpose:        "Checks RULE2.",
+					Type:           check.RuleTypeLint,
+					Handler:        testNopRuleHandler,
+					Deprecated:     true,
+					ReplacementIDs: []string{"RULE1"},
+				},
+			},
+			Categories: []*check.CategorySpec{
+				{
+					ID:      "CATEGORY1",
+					Purpose: "Checks CATEGORY1.",
+				},
+			},
+			Options: &option.Schema{
+				Keys: []*option.KeySpec{
+					{
+						Key:         "timestamp_suffix",
+						Type:        option.TypeString,
+						Default:     "_time",
+						Example:     "_ts",
+						Description: "The suffix that | Timestamp fields must have.",
+						Aliases:     []string{"suffix"},
+					},
+					{
+						Key:      "api_token",
+						Type:     option.TypeString,
+						Required: true,
+						Secret:   true,
+						Example:  "secret",
+					},
+				},
+			},
+			Info: &info.Spec{
+				Documentation: "A plugin.",
+				SPDXLicenseID: "apache-2.0",
+				Version:       "v1.0.0",
+			},
+		},
+	)
+	require.NoError(t, err)
+	pathToData, err := MarkdownFiles(pluginDocumentation, MarkdownWithTitle("test-plugin"))
+	require.NoError(t, err)
+	require.Len(t, pathToData, 4)
+	require.Equal(
+		t,
+		`# test-plugin
+
+A plugin.
+
+- **Version:** v1.0.0
+- **License:** Apache-2.0
+
+## Rules
+
+| ID | Type | Categories | Default | Purpose |
+| --- | --- | --- | --- | --- |
+| [RULE1](rules/RULE1.md) | lint | [CATEGORY1](categories/CATEGORY1.md) | yes | Checks RULE1. |
+| [RULE2](rules/RULE2.md) | lint |  | no | **Deprecated.** Checks RULE2. |
+
+## Categories
+
+| ID | Purpose |
+| --- | --- |
+| [CATEGORY1](categories/CATEGORY1.md) | Checks CATEGORY1. |
+
+## Options
+
+| Key | Type | Required | Default | Example | Description |
+| --- | --- | --- | --- | --- | --- |
+| `+"`timestamp_suffix` (deprecated aliases: `suffix`)"+` | string | no | `+"`\"_time\"`"+` | `+"`\"_ts\"`"+` | The suffix that \| Timestamp fields must have. |
+| `+"`api_token`"+` | string | yes |  | REDACTED |  |
+`,
+		string(pathToData[IndexFilePath]),
+	)
+	require.Equal(
+		t,
+		"# RULE1\n\nChecks RULE1.\n\n"+
+			"- **Type:** lint\n"+
+			"- **Default:** yes\n"+
+			"- **Categories:** [CATEGORY1](../categories/CATEGORY1.md)\n\n"+
+			"## Documentation\n\nSome long documentation.\n\n"+
+			"## Examples\n\n```proto\nmessage Foo {}\n```\n\n"+
+			"[Back to index](../index.md)\n",
+		string(pathToData["rules/RULE1.md"]),
+	)
+	require.Contains(t, string(pathToData["rules/RULE2.md"]), "- **Deprecated:** yes, replaced by RULE1\n")
+	require.Contains(t, string(pathToData["categories/CATEGORY1.md"]), "- [RULE1](../rules/RULE1.md): Checks RULE1.\n")
+
+	dirPath := t.TempDir()
+	require.NoError(t, WriteMarkdownFiles(dirPath, pluginDocumentation, MarkdownWithTitle("test-plugin")))
+	data, err := os.ReadFile(filepath.Join(dirPath, "rules", "RULE1.md"))
+	require.NoError(t, err)
+	require.Equal(t, pathToData["rules/RULE1.md"], data)
+}
+
+var testNopRuleHandler = check.RuleHandlerFunc(
+	func(context.Context, check.ResponseWriter, check.Request) error {
+		return nil
+	},
+)
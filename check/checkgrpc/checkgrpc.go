@@ -0,0 +1,275 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkgrpc serves check plugins over gRPC, and calls check plugins served over gRPC.
+//
+// This allows a plugin to be deployed as a remote service behind standard infrastructure for
+// authentication, load balancing, and TLS. The gRPC-Web and Connect protocols are also
+// supported by the server.
+//
+// This is a separate package so that plugins, which only import package check, do not
+// include an HTTP and gRPC stack in their binaries.
+package checkgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"buf.build/go/bufplugin/check"
+	grpcv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/grpc/v1"
+	"buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/grpc/v1/grpcv1pluginrpc"
+	"buf.build/go/bufplugin/internal/pkg/cache"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+// NewHandler returns a new http.Handler that serves the given Spec over gRPC.
+//
+// This serves the same services as check.NewServer, with the gRPC path of each RPC being
+// the path of its pluginrpc procedure. Procedures that are not RPCs of a Protobuf service,
+// such as procedures added with check.ServerWithProcedure for which no service is registered
+// with protoregistry.GlobalFiles, are not served. Use NewClient to create a check.Client
+// for the plugin.
+//
+// The http.Handler must be served with HTTP/2 for gRPC, for example with TLS, or with
+// golang.org/x/net/http2/h2c for unencrypted HTTP/2.
+//
+//	handler, err := checkgrpc.NewHandler(spec)
+//	if err != nil {
+//		return err
+//	}
+//	return http.ListenAndServeTLS(":8443", "cert.pem", "key.pem", handler)
+func NewHandler(spec *check.Spec, options ...check.ServerOption) (http.Handler, error) {
+	server, err := check.NewServer(spec, options...)
+	if err != nil {
+		return nil, err
+	}
+	pluginrpcClient := pluginrpc.NewClient(pluginrpc.NewServerRunner(server))
+	pluginrpcSpec, err := pluginrpcClient.Spec(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	getSpecResponse := &grpcv1.GetSpecResponse{}
+	for _, procedure := range pluginrpcSpec.Procedures() {
+		path := procedure.Path()
+		methodDescriptor, ok := methodDescriptorForPath(path)
+		if !ok {
+			continue
+		}
+		mux.Handle(path, newHandler(pluginrpcClient, methodDescriptor, path))
+		getSpecResponse.ProcedurePaths = append(getSpecResponse.ProcedurePaths, path)
+	}
+	mux.Handle(
+		grpcv1pluginrpc.SpecServiceGetSpecPath,
+		connect.NewUnaryHandler(
+			grpcv1pluginrpc.SpecServiceGetSpecPath,
+			func(context.Context, *connect.Request[grpcv1.GetSpecRequest]) (*connect.Response[grpcv1.GetSpecResponse], error) {
+				return connect.NewResponse(getSpecResponse), nil
+			},
+		),
+	)
+	return mux, nil
+}
+
+// NewRunner returns a new pluginrpc.Runner for a plugin served over gRPC at the given base URL,
+// such as with NewHandler.
+//
+// The base URL is the URL that the gRPC services are served under, for example
+// "https://plugin.example.com". The http.Client must support HTTP/2, see connect.HTTPClient.
+// Errors from the server are returned as pluginrpc.Errors with the same code.
+//
+// The procedures that the plugin serves are fetched on first use. The pluginrpc.Runner can
+// be passed to check.NewClient, or to info.NewClient to only get the plugin's PluginInfo.
+func NewRunner(httpClient connect.HTTPClient, baseURL string) pluginrpc.Runner {
+	return newRunner(httpClient, strings.TrimSuffix(baseURL, "/"))
+}
+
+// NewClient returns a new check.Client for a plugin served over gRPC at the given base URL,
+// such as with NewHandler.
+//
+// This is a convenience function for check.NewClient with a pluginrpc.Client for NewRunner.
+func NewClient(httpClient connect.HTTPClient, baseURL string, options ...check.ClientOption) check.Client {
+	return check.NewClient(pluginrpc.NewClient(NewRunner(httpClient, baseURL)), options...)
+}
+
+// *** PRIVATE ***
+
+type runner struct {
+	httpClient connect.HTTPClient
+	baseURL    string
+	server     *cache.Singleton[pluginrpc.Server]
+}
+
+func newRunner(httpClient connect.HTTPClient, baseURL string) *runner {
+	runner := &runner{
+		httpClient: httpClient,
+		baseURL:    baseURL,
+	}
+	runner.server = cache.NewSingleton(runner.getServerUncached)
+	return runner
+}
+
+func (r *runner) Run(ctx context.Context, env pluginrpc.Env) error {
+	server, err := r.server.Get(ctx)
+	if err != nil {
+		return err
+	}
+	return server.Serve(ctx, env)
+}
+
+// getServerUncached returns a pluginrpc.Server that serves each procedure of the plugin by
+// calling it over gRPC.
+func (r *runner) getServerUncached(ctx context.Context) (pluginrpc.Server, error) {
+	getSpecResponse, err := connect.NewClient[grpcv1.GetSpecRequest, grpcv1.GetSpecResponse](
+		r.httpClient,
+		r.baseURL+grpcv1pluginrpc.SpecServiceGetSpecPath,
+		connect.WithGRPC(),
+	).CallUnary(ctx, connect.NewRequest(&grpcv1.GetSpecRequest{}))
+	if err != nil {
+		return nil, errorFromConnect(err)
+	}
+	var procedures []pluginrpc.Procedure
+	pathToMethodDescriptor := make(map[string]protoreflect.MethodDescriptor)
+	for _, path := range getSpecResponse.Msg.GetProcedurePaths() {
+		methodDescriptor, ok := methodDescriptorForPath(path)
+		if !ok {
+			// The plugin serves a service that this client does not know about.
+			continue
+		}
+		procedure, err := pluginrpc.NewProcedure(path)
+		if err != nil {
+			return nil, err
+		}
+		procedures = append(procedures, procedure)
+		pathToMethodDescriptor[path] = methodDescriptor
+	}
+	pluginrpcSpec, err := pluginrpc.NewSpec(procedures...)
+	if err != nil {
+		return nil, err
+	}
+	handler := pluginrpc.NewHandler(pluginrpcSpec)
+	serverRegistrar := pluginrpc.NewServerRegistrar()
+	for path, methodDescriptor := range pathToMethodDescriptor {
+		connectClient := connect.NewClient[dynamicpb.Message, dynamicpb.Message](
+			r.httpClient,
+			r.baseURL+path,
+			connect.WithGRPC(),
+			connect.WithSchema(methodDescriptor),
+			connect.WithResponseInitializer(newMessageInitializer(methodDescriptor.Output())),
+		)
+		serverRegistrar.Register(
+			path,
+			func(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+				return handler.Handle(
+					ctx,
+					handleEnv,
+					dynamicpb.NewMessage(methodDescriptor.Input()),
+					func(ctx context.Context, request any) (any, error) {
+						response, err := connectClient.CallUnary(ctx, connect.NewRequest(request.(*dynamicpb.Message)))
+						if err != nil {
+							return nil, errorFromConnect(err)
+						}
+						return response.Msg, nil
+					},
+					options...,
+				)
+			},
+		)
+	}
+	return pluginrpc.NewServer(pluginrpcSpec, serverRegistrar)
+}
+
+// newHandler returns a new http.Handler that serves the RPC at the given path by calling
+// the procedure with the same path on the pluginrpc.Client.
+func newHandler(
+	pluginrpcClient pluginrpc.Client,
+	methodDescriptor protoreflect.MethodDescriptor,
+	path string,
+) http.Handler {
+	return connect.NewUnaryHandler(
+		path,
+		func(ctx context.Context, request *connect.Request[dynamicpb.Message]) (*connect.Response[dynamicpb.Message], error) {
+			response := dynamicpb.NewMessage(methodDescriptor.Output())
+			if err := pluginrpcClient.Call(ctx, path, request.Msg, response); err != nil {
+				return nil, errorToConnect(err)
+			}
+			return connect.NewResponse(response), nil
+		},
+		connect.WithSchema(methodDescriptor),
+		connect.WithRequestInitializer(newMessageInitializer(methodDescriptor.Input())),
+	)
+}
+
+// methodDescriptorForPath returns the MethodDescriptor for the given gRPC path, such as
+// "/buf.plugin.check.v1.CheckService/Check", if it is registered with protoregistry.GlobalFiles.
+func methodDescriptorForPath(path string) (protoreflect.MethodDescriptor, bool) {
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok {
+		return nil, false
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, false
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(methodName))
+	if methodDescriptor == nil || methodDescriptor.IsStreamingClient() || methodDescriptor.IsStreamingServer() {
+		return nil, false
+	}
+	return methodDescriptor, true
+}
+
+// newMessageInitializer returns a function for connect.WithRequestInitializer and
+// connect.WithResponseInitializer that initializes a *dynamicpb.Message with the given
+// MessageDescriptor.
+func newMessageInitializer(messageDescriptor protoreflect.MessageDescriptor) func(connect.Spec, any) error {
+	return func(_ connect.Spec, message any) error {
+		dynamicMessage, ok := message.(*dynamicpb.Message)
+		if !ok {
+			return fmt.Errorf("unexpected message type %T", message)
+		}
+		*dynamicMessage = *dynamicpb.NewMessage(messageDescriptor)
+		return nil
+	}
+}
+
+func errorToConnect(err error) error {
+	pluginrpcError := &pluginrpc.Error{}
+	if errors.As(err, &pluginrpcError) {
+		return connect.NewError(connect.Code(pluginrpcError.Code()), pluginrpcError.Unwrap())
+	}
+	return err
+}
+
+func errorFromConnect(err error) error {
+	connectError := &connect.Error{}
+	if errors.As(err, &connectError) {
+		message := connectError.Message()
+		if message == "" {
+			message = connectError.Code().String()
+		}
+		return pluginrpc.NewError(pluginrpc.Code(connectError.Code()), errors.New(message))
+	}
+	return err
+}
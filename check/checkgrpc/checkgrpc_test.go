@@ -0,0 +1,136 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkgrpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/info"
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewSpec()
+	client := testNewClient(t, spec)
+	ctx := context.Background()
+	rules, err := client.ListRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, "RULE1", rules[0].ID())
+	require.NotNil(t, rules[0].Policy())
+	// The PluginInfoService is not served if the Spec has no Info.
+	_, err = client.GetPluginInfo(ctx)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeUnimplemented, pluginrpcError.Code())
+
+	request, err := check.NewRequest(testNewFileDescriptors(t))
+	require.NoError(t, err)
+	response, err := client.Check(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 1)
+	require.Equal(t, "foo.proto", response.Annotations()[0].Message())
+	require.Equal(t, check.SeverityError, response.Annotations()[0].Severity())
+	require.Nil(t, response.State())
+
+	request, err = check.NewRequest(testNewFileDescriptors(t), check.WithRuleIDs("RULE2"))
+	require.NoError(t, err)
+	_, err = client.Check(ctx, request)
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
+
+	// State and severity overrides are transmitted.
+	options, err := option.NewOptions(map[string]any{check.SeverityOverridesOptionKey: map[string]any{"RULE1": "warning"}})
+	require.NoError(t, err)
+	request, err = check.NewRequest(testNewFileDescriptors(t), check.WithOptions(options), check.WithState([]byte{}))
+	require.NoError(t, err)
+	response, err = client.Check(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 1)
+	require.Equal(t, check.SeverityWarning, response.Annotations()[0].Severity())
+	require.NotEmpty(t, response.State())
+
+	spec.Info = &info.Spec{
+		Documentation: "A plugin.",
+	}
+	pluginInfo, err := testNewClient(t, spec).GetPluginInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "A plugin.", pluginInfo.Documentation())
+}
+
+func testNewClient(t *testing.T, spec *check.Spec) check.Client {
+	handler, err := NewHandler(spec)
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(handler)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return NewClient(server.Client(), server.URL)
+}
+
+func testNewSpec() *check.Spec {
+	return &check.Spec{
+		Rules: []*check.RuleSpec{
+			{
+				ID:      "RULE1",
+				Default: true,
+				Purpose: "Checks RULE1.",
+				Type:    check.RuleTypeLint,
+				Policy: &check.PolicySpec{
+					ID:       "POLICY1",
+					Severity: check.SeverityError,
+				},
+				Handler: check.RuleHandlerFunc(
+					func(_ context.Context, responseWriter check.ResponseWriter, request check.Request) error {
+						for _, fileDescriptor := range request.FileDescriptors() {
+							responseWriter.AddAnnotation(
+								check.WithMessage(fileDescriptor.FileDescriptorProto().GetName()),
+								check.WithFileName(fileDescriptor.FileDescriptorProto().GetName()),
+							)
+						}
+						responseWriter.SetState([]byte("state"))
+						return nil
+					},
+				),
+			},
+		},
+	}
+}
+
+func testNewFileDescriptors(t *testing.T) []descriptor.FileDescriptor {
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:           proto.String("foo.proto"),
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	return fileDescriptors
+}
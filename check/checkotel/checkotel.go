@@ -0,0 +1,103 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkotel traces Check calls with OpenTelemetry.
+//
+// This is a separate package so that plugins that do not trace Check calls do not include
+// OpenTelemetry in their binaries.
+package checkotel
+
+import (
+	"context"
+
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/internal/gen/buf/plugin/check/v1/v1pluginrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "buf.build/go/bufplugin/check"
+
+	fileDescriptorsAttributeKey        = attribute.Key("bufplugin.check.file_descriptors")
+	againstFileDescriptorsAttributeKey = attribute.Key("bufplugin.check.against_file_descriptors")
+	requestSizeAttributeKey            = attribute.Key("bufplugin.check.request_size")
+	annotationsAttributeKey            = attribute.Key("bufplugin.check.annotations")
+	ruleIDAttributeKey                 = attribute.Key("bufplugin.check.rule_id")
+)
+
+// NewTracer returns a new check.Tracer that traces with the given OpenTelemetry
+// trace.TracerProvider.
+//
+// A span is created for each Check call, with the number of FileDescriptors, the size of the
+// request, and the number of annotations as attributes, and a child span is created for each
+// RuleHandler invocation.
+//
+//	check.Main(spec, check.MainWithTracer(checkotel.NewTracer(otel.GetTracerProvider())))
+func NewTracer(tracerProvider trace.TracerProvider) check.Tracer {
+	return &tracer{
+		tracer: tracerProvider.Tracer(tracerName),
+	}
+}
+
+// *** PRIVATE ***
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+func (t *tracer) StartCheck(ctx context.Context, checkSpanInfo check.CheckSpanInfo) (context.Context, check.Span) {
+	spanKind := trace.SpanKindServer
+	switch {
+	case checkSpanInfo.InProcess:
+		spanKind = trace.SpanKindInternal
+	case checkSpanInfo.Client:
+		spanKind = trace.SpanKindClient
+	}
+	attributes := []attribute.KeyValue{
+		fileDescriptorsAttributeKey.Int(checkSpanInfo.NumFileDescriptors),
+		againstFileDescriptorsAttributeKey.Int(checkSpanInfo.NumAgainstFileDescriptors),
+	}
+	if !checkSpanInfo.InProcess {
+		attributes = append(attributes, requestSizeAttributeKey.Int(checkSpanInfo.RequestSize))
+	}
+	ctx, otelSpan := t.tracer.Start(
+		ctx,
+		v1pluginrpc.CheckServiceCheckPath,
+		trace.WithSpanKind(spanKind),
+		trace.WithAttributes(attributes...),
+	)
+	return ctx, span{span: otelSpan}
+}
+
+func (t *tracer) StartRule(ctx context.Context, ruleID string) (context.Context, check.Span) {
+	ctx, otelSpan := t.tracer.Start(ctx, "rule "+ruleID, trace.WithAttributes(ruleIDAttributeKey.String(ruleID)))
+	return ctx, span{span: otelSpan}
+}
+
+type span struct {
+	span trace.Span
+}
+
+// End ends the span, recording the number of annotations or the error.
+func (s span) End(numAnnotations int, err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	} else {
+		s.span.SetAttributes(annotationsAttributeKey.Int(numAnnotations))
+	}
+	s.span.End()
+}
@@ -0,0 +1,111 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkotel
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	clientSpanRecorder := tracetest.NewSpanRecorder()
+	serverSpanRecorder := tracetest.NewSpanRecorder()
+	server, err := check.NewServer(
+		testNewSpec(),
+		check.ServerWithTracer(NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(serverSpanRecorder)))),
+	)
+	require.NoError(t, err)
+	client := check.NewClient(
+		pluginrpc.NewClient(pluginrpc.NewServerRunner(server)),
+		check.ClientWithTracer(NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(clientSpanRecorder)))),
+	)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(3))
+	require.NoError(t, err)
+	request, err := check.NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	require.NoError(t, err)
+
+	clientSpans := clientSpanRecorder.Ended()
+	require.Len(t, clientSpans, 1)
+	require.Equal(t, "/buf.plugin.check.v1.CheckService/Check", clientSpans[0].Name())
+	testRequireSpanAttribute(t, clientSpans[0], fileDescriptorsAttributeKey.Int(3))
+	testRequireSpanAttribute(t, clientSpans[0], annotationsAttributeKey.Int(3))
+
+	serverSpans := serverSpanRecorder.Ended()
+	require.Len(t, serverSpans, 2)
+	require.Equal(t, "rule RULE1", serverSpans[0].Name())
+	testRequireSpanAttribute(t, serverSpans[0], ruleIDAttributeKey.String("RULE1"))
+	testRequireSpanAttribute(t, serverSpans[0], annotationsAttributeKey.Int(3))
+	require.Equal(t, "/buf.plugin.check.v1.CheckService/Check", serverSpans[1].Name())
+	require.Equal(t, serverSpans[1].SpanContext().SpanID(), serverSpans[0].Parent().SpanID())
+	testRequireSpanAttribute(t, serverSpans[1], fileDescriptorsAttributeKey.Int(3))
+	testRequireSpanAttribute(t, serverSpans[1], annotationsAttributeKey.Int(3))
+}
+
+func testRequireSpanAttribute(t *testing.T, span sdktrace.ReadOnlySpan, expected attribute.KeyValue) {
+	require.Contains(t, span.Attributes(), expected)
+}
+
+func testNewSpec() *check.Spec {
+	return &check.Spec{
+		Rules: []*check.RuleSpec{
+			{
+				ID:      "RULE1",
+				Default: true,
+				Purpose: "Checks RULE1.",
+				Type:    check.RuleTypeLint,
+				Handler: check.RuleHandlerFunc(
+					func(_ context.Context, responseWriter check.ResponseWriter, request check.Request) error {
+						for _, fileDescriptor := range request.FileDescriptors() {
+							responseWriter.AddAnnotation(
+								check.WithMessage(fileDescriptor.FileDescriptorProto().GetName()),
+								check.WithFileName(fileDescriptor.FileDescriptorProto().GetName()),
+							)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	}
+}
+
+func testNewProtoFileDescriptors(count int) []*descriptorv1.FileDescriptor {
+	protoFileDescriptors := make([]*descriptorv1.FileDescriptor, count)
+	for i := range count {
+		protoFileDescriptors[i] = &descriptorv1.FileDescriptor{
+			FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+				Name:           proto.String(fmt.Sprintf("file%d.proto", i)),
+				SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+			},
+		}
+	}
+	return protoFileDescriptors
+}
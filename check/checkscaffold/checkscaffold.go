@@ -0,0 +1,364 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkscaffold generates the files for a new check plugin.
+//
+// Given a Config that declares the Rules and Categories of a plugin, Generate produces a
+// ready-to-build Go module with:
+//
+//   - go.mod for the module.
+//   - main.go with the check.Spec and main function.
+//   - One <rule_id>_rule.go file per Rule, with the check.RuleSpec and a stub RuleHandler.
+//   - main_test.go with a checktest.SpecTest, and one checktest.CheckTest per Rule.
+//   - testdata/<rule_id> directories with a .proto file for each test.
+//
+// The generated RuleHandlers do not add any Annotations; plugin authors implement them
+// and add the expected Annotations to the tests. The generated go.mod has no requirements,
+// run "go mod tidy" to add them.
+//
+// Configs are typically read from YAML with ConfigForYAML, for example:
+//
+//	module: github.com/acme/buf-plugin-acme
+//	rules:
+//	  - id: ACME_FIELD_NAME_SUFFIX
+//	    type: lint
+//	    purpose: Checks that field names have the required suffix.
+//	    categories: [ACME]
+//	    default: true
+//	  - id: ACME_FIELD_NO_DELETE
+//	    type: breaking
+//	    purpose: Checks that no fields are deleted.
+//	categories:
+//	  - id: ACME
+//	    purpose: Checks the Acme style guide.
+//
+// See the bufplugin command for a command that wraps this package.
+package checkscaffold
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the declarative description of a plugin to generate.
+type Config struct {
+	// ModulePath is the path of the Go module, such as "github.com/acme/buf-plugin-acme".
+	//
+	// Required.
+	ModulePath string `yaml:"module"`
+	// Name is the name of the plugin binary.
+	//
+	// Optional. Defaults to the last element of ModulePath.
+	Name string `yaml:"name,omitempty"`
+	// Rules are the Rules of the plugin.
+	//
+	// At least one Rule is required.
+	Rules []*RuleConfig `yaml:"rules"`
+	// Categories are the Categories of the plugin.
+	//
+	// Optional.
+	Categories []*CategoryConfig `yaml:"categories,omitempty"`
+}
+
+// RuleConfig is the declarative description of a Rule.
+//
+// The fields correspond to the fields of check.RuleSpec, and are validated the same way.
+type RuleConfig struct {
+	// Required.
+	ID string `yaml:"id"`
+	// Type is either "lint" or "breaking".
+	//
+	// Required.
+	Type string `yaml:"type"`
+	// Required.
+	Purpose     string   `yaml:"purpose"`
+	CategoryIDs []string `yaml:"categories,omitempty"`
+	Default     bool     `yaml:"default,omitempty"`
+}
+
+// CategoryConfig is the declarative description of a Category.
+//
+// The fields correspond to the fields of check.CategorySpec, and are validated the same way.
+type CategoryConfig struct {
+	// Required.
+	ID string `yaml:"id"`
+	// Required.
+	Purpose string `yaml:"purpose"`
+}
+
+// ConfigForYAML parses a Config from YAML.
+//
+// Unknown keys result in an error.
+func ConfigForYAML(data []byte) (*Config, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	config := &Config{}
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("invalid scaffold config: %w", err)
+	}
+	return config, nil
+}
+
+// ValidateConfig validates the Config.
+//
+// Rules and Categories are validated as they would be by check.ValidateSpec.
+func ValidateConfig(config *Config) error {
+	_, err := newTemplateData(config)
+	return err
+}
+
+// Generate generates the files of the plugin for the Config.
+//
+// The returned map is from slash-separated file path relative to the root of the module to
+// the content of the file.
+func Generate(config *Config) (map[string][]byte, error) {
+	data, err := newTemplateData(config)
+	if err != nil {
+		return nil, err
+	}
+	filePathToContent := make(map[string][]byte)
+	if err := generateFile(filePathToContent, "go.mod", goModTemplate, data); err != nil {
+		return nil, err
+	}
+	if err := generateFile(filePathToContent, "main.go", mainTemplate, data); err != nil {
+		return nil, err
+	}
+	if err := generateFile(filePathToContent, "main_test.go", mainTestTemplate, data); err != nil {
+		return nil, err
+	}
+	for _, rule := range data.Rules {
+		if err := generateFile(filePathToContent, rule.FileName+"_rule.go", ruleTemplate, rule); err != nil {
+			return nil, err
+		}
+		for _, dirPath := range rule.TestdataDirPaths() {
+			if err := generateFile(filePathToContent, path.Join(dirPath, "simple.proto"), protoTemplate, rule); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return filePathToContent, nil
+}
+
+// WriteFiles generates the files of the plugin for the Config, and writes them to the
+// given directory.
+//
+// The directory is created if it does not exist. WriteFiles returns an error without
+// writing any files if any of the files already exist.
+func WriteFiles(dirPath string, config *Config) error {
+	filePathToContent, err := Generate(config)
+	if err != nil {
+		return err
+	}
+	filePaths := xslices.MapKeysToSortedSlice(filePathToContent)
+	for _, filePath := range filePaths {
+		if _, err := os.Stat(filepath.Join(dirPath, filepath.FromSlash(filePath))); err == nil {
+			return fmt.Errorf("file already exists: %s", filepath.Join(dirPath, filepath.FromSlash(filePath)))
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	for _, filePath := range filePaths {
+		osFilePath := filepath.Join(dirPath, filepath.FromSlash(filePath))
+		if err := os.MkdirAll(filepath.Dir(osFilePath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(osFilePath, filePathToContent[filePath], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// *** PRIVATE ***
+
+type templateData struct {
+	ModulePath string
+	Name       string
+	Rules      []*ruleTemplateData
+	Categories []*CategoryConfig
+}
+
+func (t *templateData) RuleIDsForType(ruleType check.RuleType) []string {
+	var ruleIDs []string
+	for _, rule := range t.Rules {
+		if rule.Type == ruleType {
+			ruleIDs = append(ruleIDs, rule.ID)
+		}
+	}
+	return ruleIDs
+}
+
+type ruleTemplateData struct {
+	*RuleConfig
+
+	Type check.RuleType
+	// VarPrefix is the prefix of unexported identifiers, such as "acmeFieldNameSuffix".
+	VarPrefix string
+	// FuncSuffix is the suffix of function names, such as "AcmeFieldNameSuffix".
+	FuncSuffix string
+	// FileName is the base name of files and directories, such as "acme_field_name_suffix".
+	FileName string
+}
+
+func (r *ruleTemplateData) IsBreaking() bool {
+	return r.Type == check.RuleTypeBreaking
+}
+
+func (r *ruleTemplateData) TestdataDirPaths() []string {
+	dirPath := path.Join("testdata", r.FileName)
+	if r.IsBreaking() {
+		return []string{path.Join(dirPath, "current"), path.Join(dirPath, "previous")}
+	}
+	return []string{dirPath}
+}
+
+func newTemplateData(config *Config) (*templateData, error) {
+	if config == nil {
+		return nil, errors.New("checkscaffold.Config is nil")
+	}
+	if config.ModulePath == "" {
+		return nil, errors.New("checkscaffold.Config: ModulePath is empty")
+	}
+	if strings.ContainsAny(config.ModulePath, " \t\n\\") {
+		return nil, fmt.Errorf("checkscaffold.Config: invalid ModulePath %q", config.ModulePath)
+	}
+	name := config.Name
+	if name == "" {
+		name = path.Base(config.ModulePath)
+	}
+	if len(config.Rules) == 0 {
+		return nil, errors.New("checkscaffold.Config: no Rules")
+	}
+	rules := make([]*ruleTemplateData, len(config.Rules))
+	ruleSpecs := make([]*check.RuleSpec, len(config.Rules))
+	for i, ruleConfig := range config.Rules {
+		if ruleConfig == nil {
+			return nil, fmt.Errorf("checkscaffold.Config: Rule %d is nil", i)
+		}
+		ruleType, err := parseRuleType(ruleConfig.Type)
+		if err != nil {
+			return nil, fmt.Errorf("checkscaffold.Config: Rule %q: %w", ruleConfig.ID, err)
+		}
+		words := strings.Split(strings.ToLower(ruleConfig.ID), "_")
+		funcSuffix := strings.Join(xslices.Map(words, upperFirst), "")
+		varPrefix := words[0] + strings.Join(xslices.Map(words[1:], upperFirst), "")
+		if varPrefix != "" && varPrefix[0] >= '0' && varPrefix[0] <= '9' {
+			varPrefix = "rule" + funcSuffix
+		}
+		rules[i] = &ruleTemplateData{
+			RuleConfig: ruleConfig,
+			Type:       ruleType,
+			VarPrefix:  varPrefix,
+			FuncSuffix: funcSuffix,
+			FileName:   strings.ToLower(ruleConfig.ID),
+		}
+		ruleSpecs[i] = &check.RuleSpec{
+			ID:          ruleConfig.ID,
+			CategoryIDs: ruleConfig.CategoryIDs,
+			Default:     ruleConfig.Default,
+			Purpose:     ruleConfig.Purpose,
+			Type:        ruleType,
+			Handler:     nopRuleHandler,
+		}
+	}
+	categorySpecs := make([]*check.CategorySpec, len(config.Categories))
+	for i, categoryConfig := range config.Categories {
+		if categoryConfig == nil {
+			return nil, fmt.Errorf("checkscaffold.Config: Category %d is nil", i)
+		}
+		categorySpecs[i] = &check.CategorySpec{
+			ID:      categoryConfig.ID,
+			Purpose: categoryConfig.Purpose,
+		}
+	}
+	if err := check.ValidateSpec(
+		&check.Spec{
+			Rules:      ruleSpecs,
+			Categories: categorySpecs,
+		},
+	); err != nil {
+		return nil, err
+	}
+	return &templateData{
+		ModulePath: config.ModulePath,
+		Name:       name,
+		Rules:      rules,
+		Categories: config.Categories,
+	}, nil
+}
+
+func generateFile(filePathToContent map[string][]byte, filePath string, tmpl *template.Template, data any) error {
+	buffer := bytes.NewBuffer(nil)
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return err
+	}
+	content := buffer.Bytes()
+	if path.Ext(filePath) == ".go" {
+		formatted, err := format.Source(content)
+		if err != nil {
+			return fmt.Errorf("could not format %s: %w", filePath, err)
+		}
+		content = formatted
+	}
+	filePathToContent[filePath] = content
+	return nil
+}
+
+func parseRuleType(s string) (check.RuleType, error) {
+	for _, ruleType := range []check.RuleType{check.RuleTypeLint, check.RuleTypeBreaking} {
+		if s == ruleType.String() {
+			return ruleType, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown Type %q, must be %q or %q", s, check.RuleTypeLint, check.RuleTypeBreaking)
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var nopRuleHandler = check.RuleHandlerFunc(func(context.Context, check.ResponseWriter, check.Request) error { return nil })
+
+var templateFuncs = template.FuncMap{
+	"quote": strconv.Quote,
+	"quoteJoin": func(values []string) string {
+		return strings.Join(xslices.Map(values, strconv.Quote), ", ")
+	},
+	"lint": func() check.RuleType {
+		return check.RuleTypeLint
+	},
+	"breaking": func() check.RuleType {
+		return check.RuleTypeBreaking
+	},
+}
+
+func newTemplate(name string, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(templateFuncs).Parse(text))
+}
@@ -0,0 +1,154 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkscaffold
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigYAML = `
+module: github.com/acme/buf-plugin-acme
+rules:
+  - id: ACME_FIELD_NAME_SUFFIX
+    type: lint
+    purpose: Checks that field names have the required suffix.
+    categories: [ACME]
+    default: true
+  - id: ACME_FIELD_NO_DELETE
+    type: breaking
+    purpose: Checks that no fields are deleted.
+  - id: 2ND_LINUX_TEST
+    type: lint
+    purpose: Checks something else.
+categories:
+  - id: ACME
+    purpose: Checks the Acme style guide.
+`
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	config, err := ConfigForYAML([]byte(testConfigYAML))
+	require.NoError(t, err)
+	filePathToContent, err := Generate(config)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]string{
+			"2nd_linux_test_rule.go",
+			"acme_field_name_suffix_rule.go",
+			"acme_field_no_delete_rule.go",
+			"go.mod",
+			"main.go",
+			"main_test.go",
+			"testdata/2nd_linux_test/simple.proto",
+			"testdata/acme_field_name_suffix/simple.proto",
+			"testdata/acme_field_no_delete/current/simple.proto",
+			"testdata/acme_field_no_delete/previous/simple.proto",
+		},
+		xslices.MapKeysToSortedSlice(filePathToContent),
+	)
+	for filePath, content := range filePathToContent {
+		if filepath.Ext(filePath) != ".go" {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filePath, content, 0)
+		require.NoError(t, err, filePath)
+		require.Equal(t, "main", file.Name.Name, filePath)
+	}
+	require.Equal(t, "module github.com/acme/buf-plugin-acme\n\ngo 1.22.0\n", string(filePathToContent["go.mod"]))
+	mainFile := string(filePathToContent["main.go"])
+	require.Contains(t, mainFile, "//	  - plugin: buf-plugin-acme\n")
+	require.Contains(t, mainFile, "\t\trule2ndLinuxTestRuleSpec,\n")
+	require.Contains(t, mainFile, `ID:      "ACME",`)
+	lintRuleFile := string(filePathToContent["acme_field_name_suffix_rule.go"])
+	require.Contains(t, lintRuleFile, `const acmeFieldNameSuffixRuleID = "ACME_FIELD_NAME_SUFFIX"`)
+	require.Contains(t, lintRuleFile, "checkutil.NewFileRuleHandler(checkAcmeFieldNameSuffix, checkutil.WithoutImports())")
+	require.Contains(t, lintRuleFile, "Default:     true,")
+	breakingRuleFile := string(filePathToContent["acme_field_no_delete_rule.go"])
+	require.Contains(t, breakingRuleFile, "check.RuleTypeBreaking")
+	require.Contains(t, breakingRuleFile, "againstFileDescriptor descriptor.FileDescriptor,")
+	mainTestFile := string(filePathToContent["main_test.go"])
+	require.Contains(t, mainTestFile, "func TestAcmeFieldNoDelete(t *testing.T) {")
+	require.Contains(t, mainTestFile, `"testdata/acme_field_no_delete/previous"`)
+}
+
+func TestGenerateName(t *testing.T) {
+	t.Parallel()
+
+	config, err := ConfigForYAML([]byte(testConfigYAML))
+	require.NoError(t, err)
+	config.Name = "buf-plugin-other"
+	filePathToContent, err := Generate(config)
+	require.NoError(t, err)
+	require.Contains(t, string(filePathToContent["main.go"]), "//	  - plugin: buf-plugin-other\n")
+}
+
+func TestConfigForYAMLUnknownField(t *testing.T) {
+	t.Parallel()
+
+	_, err := ConfigForYAML([]byte("module: github.com/acme/foo\nunknown: true\n"))
+	require.Error(t, err)
+}
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	newRuleConfig := func() *RuleConfig {
+		return &RuleConfig{
+			ID:      "ACME_RULE",
+			Type:    "lint",
+			Purpose: "Checks things.",
+		}
+	}
+	require.NoError(t, ValidateConfig(&Config{ModulePath: "github.com/acme/foo", Rules: []*RuleConfig{newRuleConfig()}}))
+	require.Error(t, ValidateConfig(nil))
+	require.Error(t, ValidateConfig(&Config{Rules: []*RuleConfig{newRuleConfig()}}))
+	require.Error(t, ValidateConfig(&Config{ModulePath: "github.com/acme/foo"}))
+	for _, modify := range []func(*RuleConfig){
+		func(ruleConfig *RuleConfig) { ruleConfig.ID = "acme_rule" },
+		func(ruleConfig *RuleConfig) { ruleConfig.Type = "unknown" },
+		func(ruleConfig *RuleConfig) { ruleConfig.Purpose = "no period" },
+		func(ruleConfig *RuleConfig) { ruleConfig.CategoryIDs = []string{"UNKNOWN"} },
+	} {
+		ruleConfig := newRuleConfig()
+		modify(ruleConfig)
+		require.Error(t, ValidateConfig(&Config{ModulePath: "github.com/acme/foo", Rules: []*RuleConfig{ruleConfig}}))
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	t.Parallel()
+
+	config, err := ConfigForYAML([]byte(testConfigYAML))
+	require.NoError(t, err)
+	dirPath := filepath.Join(t.TempDir(), "plugin")
+	require.NoError(t, WriteFiles(dirPath, config))
+	data, err := os.ReadFile(filepath.Join(dirPath, "testdata", "acme_field_no_delete", "current", "simple.proto"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(data), `syntax = "proto3";`))
+
+	err = WriteFiles(dirPath, config)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "file already exists")
+}
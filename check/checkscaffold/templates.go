@@ -0,0 +1,195 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkscaffold
+
+var (
+	goModTemplate = newTemplate(
+		"go.mod",
+		`module {{.ModulePath}}
+
+go 1.22.0
+`,
+	)
+
+	mainTemplate = newTemplate(
+		"main.go",
+		`// Package main implements the {{.Name}} plugin.
+//
+// To use this plugin:
+//
+//	# buf.yaml
+//	version: v2
+{{- with .RuleIDsForType lint}}
+//	lint:
+//	  use:
+{{- range .}}
+//	    - {{.}}
+{{- end}}
+{{- end}}
+{{- with .RuleIDsForType breaking}}
+//	breaking:
+//	  use:
+{{- range .}}
+//	    - {{.}}
+{{- end}}
+{{- end}}
+//	plugins:
+//	  - plugin: {{.Name}}
+package main
+
+import (
+	"buf.build/go/bufplugin/check"
+)
+
+// spec is the Spec for the {{.Name}} plugin.
+var spec = &check.Spec{
+	Rules: []*check.RuleSpec{
+{{- range .Rules}}
+		{{.VarPrefix}}RuleSpec,
+{{- end}}
+	},
+{{- with .Categories}}
+	Categories: []*check.CategorySpec{
+{{- range .}}
+		{
+			ID:      {{quote .ID}},
+			Purpose: {{quote .Purpose}},
+		},
+{{- end}}
+	},
+{{- end}}
+}
+
+func main() {
+	check.Main(spec)
+}
+`,
+	)
+
+	ruleTemplate = newTemplate(
+		"rule.go",
+		`package main
+
+import (
+	"context"
+
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/check/checkutil"
+	"buf.build/go/bufplugin/descriptor"
+)
+
+// {{.VarPrefix}}RuleID is the Rule ID of the {{.ID}} Rule.
+const {{.VarPrefix}}RuleID = {{quote .ID}}
+
+// {{.VarPrefix}}RuleSpec is the RuleSpec for the {{.ID}} Rule.
+var {{.VarPrefix}}RuleSpec = &check.RuleSpec{
+	ID: {{.VarPrefix}}RuleID,
+{{- with .CategoryIDs}}
+	CategoryIDs: []string{ {{- quoteJoin .}}},
+{{- end}}
+{{- if .Default}}
+	Default: true,
+{{- end}}
+	Purpose: {{quote .Purpose}},
+{{- if .IsBreaking}}
+	Type:    check.RuleTypeBreaking,
+	Handler: checkutil.NewFilePairRuleHandler(check{{.FuncSuffix}}, checkutil.WithoutImports()),
+{{- else}}
+	Type:    check.RuleTypeLint,
+	Handler: checkutil.NewFileRuleHandler(check{{.FuncSuffix}}, checkutil.WithoutImports()),
+{{- end}}
+}
+
+func check{{.FuncSuffix}}(
+	_ context.Context,
+	responseWriter check.ResponseWriter,
+	_ check.Request,
+	fileDescriptor descriptor.FileDescriptor,
+{{- if .IsBreaking}}
+	againstFileDescriptor descriptor.FileDescriptor,
+{{- end}}
+) error {
+	// TODO: Implement the {{.ID}} Rule, and add an Annotation for every violation:
+	//
+	//	responseWriter.AddAnnotation(
+	//		check.WithMessage("Describe the violation."),
+	//		check.WithDescriptor(fileDescriptor.ProtoreflectFileDescriptor()),
+{{- if .IsBreaking}}
+	//		check.WithAgainstDescriptor(againstFileDescriptor.ProtoreflectFileDescriptor()),
+{{- end}}
+	//	)
+	return nil
+}
+`,
+	)
+
+	mainTestTemplate = newTemplate(
+		"main_test.go",
+		`package main
+
+import (
+	"testing"
+
+	"buf.build/go/bufplugin/check/checktest"
+)
+
+func TestSpec(t *testing.T) {
+	t.Parallel()
+	checktest.SpecTest(t, spec)
+}
+{{- range .Rules}}
+
+func Test{{.FuncSuffix}}(t *testing.T) {
+	t.Parallel()
+
+	checktest.CheckTest{
+		Request: &checktest.RequestSpec{
+{{- if .IsBreaking}}
+			Files: &checktest.ProtoFileSpec{
+				DirPaths:  []string{"testdata/{{.FileName}}/current"},
+				FilePaths: []string{"simple.proto"},
+			},
+			AgainstFiles: &checktest.ProtoFileSpec{
+				DirPaths:  []string{"testdata/{{.FileName}}/previous"},
+				FilePaths: []string{"simple.proto"},
+			},
+{{- else}}
+			Files: &checktest.ProtoFileSpec{
+				DirPaths:  []string{"testdata/{{.FileName}}"},
+				FilePaths: []string{"simple.proto"},
+			},
+{{- end}}
+			RuleIDs: []string{ {{- .VarPrefix}}RuleID},
+		},
+		Spec: spec,
+		// TODO: Add the ExpectedAnnotations for the files in testdata/{{.FileName}}.
+	}.Run(t)
+}
+{{- end}}
+`,
+	)
+
+	protoTemplate = newTemplate(
+		"simple.proto",
+		`syntax = "proto3";
+
+package simple;
+
+message Simple {
+  string name = 1;
+}
+`,
+	)
+)
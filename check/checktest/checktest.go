@@ -33,12 +33,10 @@ import (
 	"github.com/bufbuild/protocompile"
 	"github.com/bufbuild/protocompile/linker"
 	"github.com/bufbuild/protocompile/parser"
-	"github.com/bufbuild/protocompile/protoutil"
 	"github.com/bufbuild/protocompile/reporter"
 	"github.com/bufbuild/protocompile/wellknownimports"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
@@ -351,7 +349,7 @@ func compile(ctx context.Context, dirPaths []string, filePaths []string) ([]desc
 		maybeAddSyntaxUnspecified(syntaxUnspecifiedFilePaths, warningErrorWithPos)
 		maybeAddUnusedDependency(filePathToUnusedDependencyFilePaths, warningErrorWithPos)
 	}
-	fileDescriptorSet := fileDescriptorSetForFileDescriptors(files)
+	fileDescriptorSet := descriptor.ProtoreflectFileDescriptorsToFileDescriptorSet(files)
 
 	protoFileDescriptors := make([]*descriptorv1.FileDescriptor, len(fileDescriptorSet.GetFile()))
 	for i, fileDescriptorProto := range fileDescriptorSet.GetFile() {
@@ -415,28 +413,6 @@ func maybeAddUnusedDependency(
 	unusedDependencyFilePaths[errorUnusedImport.UnusedImport()] = struct{}{}
 }
 
-func fileDescriptorSetForFileDescriptors[D protoreflect.FileDescriptor](files []D) *descriptorpb.FileDescriptorSet {
-	soFar := make(map[string]struct{}, len(files))
-	slice := make([]*descriptorpb.FileDescriptorProto, 0, len(files))
-	for _, file := range files {
-		toFileDescriptorProtoSlice(file, &slice, soFar)
-	}
-	return &descriptorpb.FileDescriptorSet{File: slice}
-}
-
-func toFileDescriptorProtoSlice(file protoreflect.FileDescriptor, results *[]*descriptorpb.FileDescriptorProto, soFar map[string]struct{}) {
-	if _, exists := soFar[file.Path()]; exists {
-		return
-	}
-	soFar[file.Path()] = struct{}{}
-	// Add dependencies first so the resulting slice is in topological order
-	imports := file.Imports()
-	for i, length := 0, imports.Len(); i < length; i++ {
-		toFileDescriptorProtoSlice(imports.Get(i).FileDescriptor, results, soFar)
-	}
-	*results = append(*results, protoutil.ProtoFromFileDescriptor(file))
-}
-
 func fromSlashPaths(paths []string) []string {
 	fromSlashPaths := make([]string, len(paths))
 	for i, path := range paths {
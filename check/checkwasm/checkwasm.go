@@ -0,0 +1,198 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkwasm runs check plugins compiled to WebAssembly.
+//
+// This is a separate package so that plugins, which only import package check, do not
+// include a WebAssembly runtime in their binaries.
+package checkwasm
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	// programName is the name of the program passed as the first argument to the module.
+	programName = "plugin"
+
+	wasmPageSize       = 64 * 1024
+	wasmMaxMemoryBytes = 65536 * wasmPageSize
+)
+
+// Runner is a pluginrpc.Runner for a plugin compiled to WebAssembly.
+//
+// The Runner must be closed when no longer needed.
+type Runner interface {
+	pluginrpc.Runner
+
+	// Close releases the resources of the WebAssembly runtime.
+	//
+	// The Runner must not be used after Close.
+	Close(ctx context.Context) error
+}
+
+// NewRunner returns a new Runner for the given plugin compiled to WebAssembly.
+//
+// The plugin must target WASI preview 1, for example a plugin that calls check.Main compiled
+// with GOOS=wasip1 GOARCH=wasm. The plugin runs in a sandbox: it has no access to the file
+// system, the network, or environment variables, and its memory and the duration of each
+// invocation can be limited with RunnerWithMemoryLimit and RunnerWithTimeout. This allows
+// untrusted plugins to be run.
+//
+// The plugin is compiled once when the Runner is created, which may be expensive for large
+// plugins. Each invocation runs a new instance of the plugin.
+//
+//	runner, err := checkwasm.NewRunner(ctx, wasmModule)
+//	if err != nil {
+//		return err
+//	}
+//	defer func() { _ = runner.Close(ctx) }()
+//	client := check.NewClient(pluginrpc.NewClient(runner), check.ClientWithCaching())
+func NewRunner(ctx context.Context, wasmModule []byte, options ...RunnerOption) (Runner, error) {
+	runnerOptions := newRunnerOptions()
+	for _, option := range options {
+		option(runnerOptions)
+	}
+	runtimeConfig := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	if runnerOptions.memoryLimitBytes > 0 {
+		runtimeConfig = runtimeConfig.WithMemoryLimitPages(
+			uint32((runnerOptions.memoryLimitBytes + wasmPageSize - 1) / wasmPageSize),
+		)
+	}
+	runtime := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		return nil, errors.Join(err, runtime.Close(ctx))
+	}
+	compiledModule, err := runtime.CompileModule(ctx, wasmModule)
+	if err != nil {
+		return nil, errors.Join(err, runtime.Close(ctx))
+	}
+	return &runner{
+		runtime:        runtime,
+		compiledModule: compiledModule,
+		timeout:        runnerOptions.timeout,
+	}, nil
+}
+
+// RunnerOption is an option for a new Runner.
+type RunnerOption func(*runnerOptions)
+
+// RunnerWithMemoryLimit returns a new RunnerOption that limits the memory of the plugin to
+// the given number of bytes for each invocation, rounded up to the WebAssembly page size of
+// 64 KiB.
+//
+// An invocation that exceeds the limit fails. The default is the limit of WebAssembly, 4 GiB.
+func RunnerWithMemoryLimit(memoryLimitBytes uint64) RunnerOption {
+	return func(runnerOptions *runnerOptions) {
+		runnerOptions.memoryLimitBytes = min(memoryLimitBytes, wasmMaxMemoryBytes)
+	}
+}
+
+// RunnerWithTimeout returns a new RunnerOption that limits the duration of each invocation
+// of the plugin.
+//
+// An invocation that exceeds the timeout is stopped and fails with context.DeadlineExceeded.
+// The default is to have no timeout beyond that of the context of the invocation.
+func RunnerWithTimeout(timeout time.Duration) RunnerOption {
+	return func(runnerOptions *runnerOptions) {
+		runnerOptions.timeout = timeout
+	}
+}
+
+// *** PRIVATE ***
+
+type runner struct {
+	runtime        wazero.Runtime
+	compiledModule wazero.CompiledModule
+	timeout        time.Duration
+}
+
+func (r *runner) Run(ctx context.Context, env pluginrpc.Env) error {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	moduleConfig := wazero.NewModuleConfig().
+		// An empty name allows concurrent instances of the module.
+		WithName("").
+		WithArgs(append([]string{programName}, env.Args...)...).
+		WithStdin(readerOrEmpty(env.Stdin)).
+		WithStdout(writerOrDiscard(env.Stdout)).
+		WithStderr(writerOrDiscard(env.Stderr)).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+	module, err := r.runtime.InstantiateModule(ctx, r.compiledModule, moduleConfig)
+	if module != nil {
+		// The module is closed on exit, this is a no-op in that case.
+		defer func() { _ = module.Close(ctx) }()
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		exitError := &sys.ExitError{}
+		if errors.As(err, &exitError) {
+			if exitError.ExitCode() == 0 {
+				return nil
+			}
+			return pluginrpc.NewExitError(int(exitError.ExitCode()), exitError)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *runner) Close(ctx context.Context) error {
+	return r.runtime.Close(ctx)
+}
+
+type runnerOptions struct {
+	memoryLimitBytes uint64
+	timeout          time.Duration
+}
+
+func newRunnerOptions() *runnerOptions {
+	return &runnerOptions{}
+}
+
+func readerOrEmpty(reader io.Reader) io.Reader {
+	if reader == nil {
+		return eofReader{}
+	}
+	return reader
+}
+
+func writerOrDiscard(writer io.Writer) io.Writer {
+	if writer == nil {
+		return io.Discard
+	}
+	return writer
+}
+
+type eofReader struct{}
+
+func (eofReader) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
@@ -0,0 +1,63 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkwasm
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"buf.build/go/bufplugin/check"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping compilation of plugin to WebAssembly in short mode")
+	}
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not found")
+	}
+	wasmFilePath := filepath.Join(t.TempDir(), "plugin.wasm")
+	cmd := exec.Command(goPath, "build", "-o", wasmFilePath, "../internal/example/cmd/buf-plugin-timestamp-suffix")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	wasmModule, err := os.ReadFile(wasmFilePath)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	runner, err := NewRunner(ctx, wasmModule)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, runner.Close(ctx)) })
+	client := check.NewClient(pluginrpc.NewClient(runner), check.ClientWithCaching())
+	rules, err := client.ListRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, "TIMESTAMP_SUFFIX", rules[0].ID())
+	pluginInfo, err := client.GetPluginInfo(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, pluginInfo.License())
+
+	// The Go runtime requires more than 1 MiB of memory.
+	_, err = NewRunner(ctx, wasmModule, RunnerWithMemoryLimit(1<<20))
+	require.ErrorContains(t, err, "over limit")
+}
@@ -0,0 +1,231 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+
+	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	pluginrpcv1 "buf.build/gen/go/pluginrpc/pluginrpc/protocolbuffers/go/pluginrpc/v1"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	// checkChunkedPath is the path of the procedure that accepts a CheckRequest split into
+	// multiple chunks.
+	//
+	// This is not part of CheckService. The stdin of the procedure is a sequence of
+	// size-delimited CheckRequests that are merged into a single CheckRequest by the plugin,
+	// and the response is a CheckResponse as for Check.
+	checkChunkedPath = "/buf.plugin.check.v1.CheckService/CheckChunked"
+	checkChunkedArg  = "check-chunked"
+	checkArg         = "check"
+)
+
+// NewRunnerWithChunking returns a new pluginrpc.Runner that splits Check requests to the
+// plugin run by the given pluginrpc.Runner into multiple messages of at most maxChunkSize
+// bytes each, which are then reassembled by the plugin.
+//
+// Requests that are at most maxChunkSize bytes are sent as-is. Each chunk contains a
+// subset of the FileDescriptors of the request. A single FileDescriptor is never split,
+// so a chunk may exceed maxChunkSize if a single FileDescriptor does.
+//
+// Plugins that call Main or use NewServer support chunking. If the plugin does not support
+// chunking, for example because it was built with an older version of this package, requests
+// are sent as-is. Only the binary format is chunked. If maxChunkSize is <= 0, requests are
+// never chunked.
+//
+// Plugins apply the MaxRequestSize of their MessageSizeLimits to each chunk rather than to
+// the reassembled request, so chunking allows requests larger than MaxRequestSize to be sent.
+// The reassembled request is still subject to any limit on the number of FileDescriptors.
+//
+// If combined with NewRunnerWithCompression, pass the pluginrpc.Runner returned by
+// NewRunnerWithCompression to NewRunnerWithChunking, so that chunks are compressed.
+func NewRunnerWithChunking(runner pluginrpc.Runner, maxChunkSize int) pluginrpc.Runner {
+	return &chunkingRunner{
+		delegate:        runner,
+		pluginrpcClient: pluginrpc.NewClient(runner),
+		maxChunkSize:    maxChunkSize,
+	}
+}
+
+// *** PRIVATE ***
+
+type chunkingRunner struct {
+	delegate pluginrpc.Runner
+	// pluginrpcClient is only used to get the plugin's pluginrpc.Spec, which is cached.
+	pluginrpcClient pluginrpc.Client
+	maxChunkSize    int
+}
+
+func (c *chunkingRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	if !slices.Equal(env.Args, []string{checkArg, "--" + pluginrpc.FormatFlagName, pluginrpc.FormatBinary.String()}) ||
+		env.Stdin == nil {
+		return c.delegate.Run(ctx, env)
+	}
+	data, err := io.ReadAll(env.Stdin)
+	if err != nil {
+		return err
+	}
+	env.Stdin = bytes.NewReader(data)
+	if c.maxChunkSize <= 0 || len(data) <= c.maxChunkSize {
+		return c.delegate.Run(ctx, env)
+	}
+	spec, err := c.pluginrpcClient.Spec(ctx)
+	if err != nil {
+		return err
+	}
+	if spec.ProcedureForPath(checkChunkedPath) == nil {
+		return c.delegate.Run(ctx, env)
+	}
+	checkRequest, err := unmarshalPluginrpcCheckRequest(data)
+	if err != nil {
+		return err
+	}
+	var chunked bytes.Buffer
+	for _, chunk := range chunkCheckRequest(checkRequest, c.maxChunkSize) {
+		if _, err := protodelim.MarshalTo(&chunked, chunk); err != nil {
+			return err
+		}
+	}
+	env.Args = slices.Clone(env.Args)
+	env.Args[0] = checkChunkedArg
+	env.Stdin = &chunked
+	return c.delegate.Run(ctx, env)
+}
+
+// chunkCheckRequest splits the CheckRequest into CheckRequests that merge back into the
+// original CheckRequest with proto.Merge, in order.
+//
+// The first chunk contains all fields other than the FileDescriptors.
+func chunkCheckRequest(checkRequest *checkv1.CheckRequest, maxChunkSize int) []*checkv1.CheckRequest {
+	first := proto.Clone(checkRequest).(*checkv1.CheckRequest)
+	first.FileDescriptors = nil
+	first.AgainstFileDescriptors = nil
+	chunks := []*checkv1.CheckRequest{first}
+	chunk := first
+	size := proto.Size(first)
+	addFileDescriptor := func(fileDescriptor *descriptorv1.FileDescriptor, against bool) {
+		fileDescriptorSize := proto.Size(fileDescriptor)
+		if size > 0 && size+fileDescriptorSize > maxChunkSize {
+			chunk = &checkv1.CheckRequest{}
+			chunks = append(chunks, chunk)
+			size = 0
+		}
+		if against {
+			chunk.AgainstFileDescriptors = append(chunk.AgainstFileDescriptors, fileDescriptor)
+		} else {
+			chunk.FileDescriptors = append(chunk.FileDescriptors, fileDescriptor)
+		}
+		size += fileDescriptorSize
+	}
+	for _, fileDescriptor := range checkRequest.GetFileDescriptors() {
+		addFileDescriptor(fileDescriptor, false)
+	}
+	for _, fileDescriptor := range checkRequest.GetAgainstFileDescriptors() {
+		addFileDescriptor(fileDescriptor, true)
+	}
+	return chunks
+}
+
+// newCheckChunkedHandleFunc returns the handle function for checkChunkedPath, which
+// reassembles the CheckRequest from its chunks and handles it with the checkServiceHandler.
+//
+// The chunks are merged as they are read, and the request size limit of the
+// checkServiceHandler applies to each chunk rather than to the reassembled CheckRequest.
+func newCheckChunkedHandleFunc(
+	handler pluginrpc.Handler,
+	checkServiceHandler *checkServiceHandler,
+) func(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error {
+	return func(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+		// The chunks are read by the handle function instead of the pluginrpc.Handler, so
+		// that errors reading them are written as pluginrpc errors.
+		stdin := handleEnv.Stdin
+		handleEnv.Stdin = bytes.NewReader(nil)
+		return handler.Handle(
+			ctx,
+			handleEnv,
+			&checkv1.CheckRequest{},
+			func(ctx context.Context, _ any) (any, error) {
+				checkRequest, err := readCheckRequestChunks(stdin, checkServiceHandler.messageSizeLimits)
+				if err != nil {
+					return nil, err
+				}
+				checkResponse, _, err := checkServiceHandler.checkSizeValidated(ctx, checkRequest, nil, nil)
+				return checkResponse, err
+			},
+			options...,
+		)
+	}
+}
+
+// readCheckRequestChunks reads size-delimited CheckRequests from the reader and merges them
+// into a single CheckRequest.
+//
+// Each chunk is validated against the MessageSizeLimits before it is unmarshaled.
+func readCheckRequestChunks(reader io.Reader, messageSizeLimits MessageSizeLimits) (*checkv1.CheckRequest, error) {
+	checkRequest := &checkv1.CheckRequest{}
+	bufferedReader := bufio.NewReader(reader)
+	unmarshalOptions := protodelim.UnmarshalOptions{
+		MaxSize:          -1,
+		UnmarshalOptions: proto.UnmarshalOptions{Merge: true},
+	}
+	if messageSizeLimits.MaxRequestSize > 0 {
+		unmarshalOptions.MaxSize = int64(messageSizeLimits.MaxRequestSize)
+	}
+	for {
+		if err := unmarshalOptions.UnmarshalFrom(bufferedReader, checkRequest); err != nil {
+			if errors.Is(err, io.EOF) {
+				return checkRequest, nil
+			}
+			var sizeTooLargeError *protodelim.SizeTooLargeError
+			if errors.As(err, &sizeTooLargeError) {
+				return nil, pluginrpc.NewErrorf(
+					pluginrpc.CodeResourceExhausted,
+					"request chunk of %d bytes exceeds maximum request size of %d bytes",
+					sizeTooLargeError.Size,
+					sizeTooLargeError.MaxSize,
+				)
+			}
+			return nil, pluginrpc.NewErrorf(pluginrpc.CodeInvalidArgument, "invalid chunk: %v", err)
+		}
+	}
+}
+
+func newCheckChunkedProcedure() (pluginrpc.Procedure, error) {
+	return pluginrpc.NewProcedure(checkChunkedPath, pluginrpc.ProcedureWithArgs(checkChunkedArg))
+}
+
+func unmarshalPluginrpcCheckRequest(data []byte) (*checkv1.CheckRequest, error) {
+	request := &pluginrpcv1.Request{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		return nil, err
+	}
+	checkRequest := &checkv1.CheckRequest{}
+	if value := request.GetValue(); value != nil {
+		if err := value.UnmarshalTo(checkRequest); err != nil {
+			return nil, err
+		}
+	}
+	return checkRequest, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	optionv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/option/v1"
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRunnerWithChunking(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+	runner := &testRecordingRunner{
+		run: serverRunner.Run,
+	}
+	client := NewClient(pluginrpc.NewClient(NewRunnerWithChunking(runner, 256)))
+
+	protoFileDescriptors := testNewProtoFileDescriptors(10)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	response, err := client.Check(context.Background(), request)
+	require.NoError(t, err)
+	messages := make([]string, 0, len(response.Annotations()))
+	for _, annotation := range response.Annotations() {
+		messages = append(messages, annotation.Message())
+	}
+	require.Len(t, messages, 10)
+	require.Contains(t, messages, "file9.proto")
+	require.True(
+		t,
+		slices.ContainsFunc(runner.allArgs, func(args []string) bool { return args[0] == checkChunkedArg }),
+	)
+}
+
+func TestRunnerWithChunkingMessageSizeLimits(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(
+		testNewFileNameAnnotationSpec(),
+		ServerWithMessageSizeLimits(MessageSizeLimits{MaxRequestSize: 512}),
+	)
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+
+	protoFileDescriptors := testNewProtoFileDescriptors(40)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+
+	// The request exceeds the maximum request size of the plugin if it is not chunked.
+	_, err = NewClient(pluginrpc.NewClient(serverRunner)).Check(context.Background(), request)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeResourceExhausted, pluginrpcError.Code())
+
+	// The maximum request size applies to each chunk.
+	response, err := NewClient(pluginrpc.NewClient(NewRunnerWithChunking(serverRunner, 256))).Check(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 40)
+
+	// Chunks that exceed the maximum request size are rejected.
+	_, err = NewClient(pluginrpc.NewClient(NewRunnerWithChunking(serverRunner, 1024))).Check(context.Background(), request)
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeResourceExhausted, pluginrpcError.Code())
+	require.ErrorContains(t, err, "request chunk")
+}
+
+func TestChunkCheckRequest(t *testing.T) {
+	t.Parallel()
+
+	checkRequest := &checkv1.CheckRequest{
+		FileDescriptors:        testNewProtoFileDescriptors(5),
+		AgainstFileDescriptors: testNewProtoFileDescriptors(3),
+		Options: []*optionv1.Option{
+			{
+				Key:   "key",
+				Value: &optionv1.Value{Type: &optionv1.Value_BoolValue{BoolValue: true}},
+			},
+		},
+		RuleIds: []string{"RULE1"},
+	}
+	chunks := chunkCheckRequest(checkRequest, 100)
+	require.Greater(t, len(chunks), 2)
+	merged := &checkv1.CheckRequest{}
+	for _, chunk := range chunks {
+		proto.Merge(merged, chunk)
+	}
+	require.True(t, proto.Equal(checkRequest, merged))
+	// Chunking with a large maximum size results in a single chunk.
+	require.Len(t, chunkCheckRequest(checkRequest, 1<<20), 1)
+}
+
+func testNewProtoFileDescriptors(count int) []*descriptorv1.FileDescriptor {
+	protoFileDescriptors := make([]*descriptorv1.FileDescriptor, count)
+	for i := range count {
+		protoFileDescriptors[i] = &descriptorv1.FileDescriptor{
+			FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+				Name:           proto.String(fmt.Sprintf("file%d.proto", i)),
+				Package:        proto.String("foo.bar.baz"),
+				SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+			},
+		}
+	}
+	return protoFileDescriptors
+}
@@ -16,6 +16,8 @@ package check
 
 import (
 	"context"
+	"log/slog"
+	"time"
 
 	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
 	"buf.build/go/bufplugin/info"
@@ -48,6 +50,14 @@ type Client interface {
 	// The Categories will be sorted by Category ID.
 	// Returns error if duplicate Category IDs were detected from the underlying source.
 	ListCategories(ctx context.Context, options ...ListCategoriesCallOption) ([]Category, error)
+	// GetPluginDocumentation gets the PluginInfo, Rules, and Categories of the plugin in one call.
+	//
+	// If the plugin does not implement the PluginInfoService, PluginInfo will be nil on
+	// the returned PluginDocumentation.
+	//
+	// Documentation properties not transmitted over the wire, such as Rule.Documentation,
+	// will be empty. Use NewPluginDocumentationForSpec to get these from a Spec.
+	GetPluginDocumentation(ctx context.Context, options ...GetPluginDocumentationCallOption) (PluginDocumentation, error)
 
 	isClient()
 }
@@ -58,7 +68,7 @@ func NewClient(pluginrpcClient pluginrpc.Client, options ...ClientOption) Client
 	for _, option := range options {
 		option.applyToClient(clientOptions)
 	}
-	return newClient(pluginrpcClient, clientOptions.caching)
+	return newClientForPluginrpcClient(pluginrpcClient, clientOptions, nil)
 }
 
 // ClientOption is an option for a new Client.
@@ -75,28 +85,139 @@ type ClientOption interface {
 // - The Categories from ListCategories.
 // - PluginInfo from GetPluginInfo.
 //
-// The default is to not cache.
+// The default is to not cache. The Policies of the Rules of a plugin are always cached,
+// regardless of this option, as they are needed for every Check call.
 func ClientWithCaching() ClientOption {
 	return clientWithCachingOption{}
 }
 
+// ClientWithMessageSizeLimits returns a new ClientOption that sets the limits on the size
+// of CheckRequests sent to and CheckResponses received from the plugin.
+//
+// Requests that exceed the limit fail with CodeResourceExhausted before they are sent.
+// Responses that exceed the limit fail with CodeResourceExhausted. The errors state the
+// limit and the actual size. The limits do not apply to Check calls handled in-process by
+// Clients created with NewClientForSpec.
+//
+// For Clients created with NewClientForDaemon, NewClientForPool, and
+// NewClientForNetworkAddress, MaxResponseSize also limits the size of the responses of all
+// other calls, which are rejected as they are read from the stream, before they are read
+// into memory in full.
+//
+// The default is to not limit the size of CheckRequests and CheckResponses.
+func ClientWithMessageSizeLimits(messageSizeLimits MessageSizeLimits) ClientOption {
+	return clientWithMessageSizeLimitsOption{messageSizeLimits: messageSizeLimits}
+}
+
+// ClientWithLogger returns a new ClientOption that sets the *slog.Logger for the Client.
+//
+// For Clients created with NewClientForSpec, the logger is available to RuleHandlers with
+// Logger, as with CheckServiceHandlerWithLogger. For other Clients, Check calls to the plugin
+// are logged at slog.LevelDebug.
+//
+// If a buf version is set with ClientWithBufVersion and the plugin is deprecated, a warning
+// that includes the info.PluginInfo.DeprecationMessage is logged at slog.LevelWarn before the
+// first Check, ListRules, or ListCategories call.
+//
+// The default is to discard all records.
+func ClientWithLogger(logger *slog.Logger) ClientOption {
+	return clientWithLoggerOption{logger: logger}
+}
+
+// ClientWithTracer returns a new ClientOption that sets the Tracer used to trace Check
+// calls to the plugin.
+//
+// For Clients created with NewClientForSpec, RuleHandler invocations are also traced, as with
+// CheckServiceHandlerWithTracer. See package checkotel to trace with OpenTelemetry. The default
+// is to not trace.
+func ClientWithTracer(tracer Tracer) ClientOption {
+	return clientWithTracerOption{tracer: tracer}
+}
+
+// ClientWithMetrics returns a new ClientOption that sets the Metrics that receive metrics
+// about Check calls to the plugin.
+//
+// For Clients created with NewClientForSpec, metrics about RuleHandler invocations are also
+// recorded, as with CheckServiceHandlerWithMetrics.
+//
+// The default is to not record metrics.
+func ClientWithMetrics(metrics Metrics) ClientOption {
+	return clientWithMetricsOption{metrics: metrics}
+}
+
+// ClientWithTimeouts returns a new ClientOption that sets the timeouts for calls to the plugin.
+//
+// When a timeout expires, the context of the call is cancelled, which kills the plugin process
+// for pluginrpc.Runners that run a process, and the call fails with CodeDeadlineExceeded. This
+// ensures that a single plugin that does not respond cannot stall a caller indefinitely.
+//
+// The default is to not time out calls other than by the deadline of the context.
+func ClientWithTimeouts(timeouts Timeouts) ClientOption {
+	return clientWithTimeoutsOption{timeouts: timeouts}
+}
+
+// ClientWithBufVersion returns a new ClientOption that sets the version of buf that is
+// invoking the plugin.
+//
+// Before the first Check, ListRules, or ListCategories call, the Client gets the PluginInfo
+// of the plugin and checks that the caller meets the minimum versions required by the plugin
+// with info.CheckCompatibility, and fails the call if it does not. The buf version is only
+// checked against the MinimumBufVersion of the plugin if set. The protocol version is always
+// checked. If the plugin is deprecated, a warning is logged, see ClientWithLogger.
+//
+// The default is to not get the PluginInfo, and not check the buf or protocol version. An
+// empty bufVersion has no effect.
+func ClientWithBufVersion(bufVersion string) ClientOption {
+	return clientWithBufVersionOption{bufVersion: bufVersion}
+}
+
+// ClientWithPluginrpcClientOptions returns a new ClientOption that adds pluginrpc.ClientOptions
+// to use when constructing the pluginrpc.Client.
+//
+// This is an escape hatch for integrators that need to configure the pluginrpc.Client in ways
+// that this package does not provide, for example with pluginrpc.ClientWithStderr. The option
+// applies to constructors that create their own pluginrpc.Client, such as NewClientForSpec,
+// NewClientForDaemon, NewClientForPool, and NewClientForNetworkAddress. It has no effect on
+// NewClient, which is given a pluginrpc.Client that should be configured directly.
+//
+// This may be specified multiple times, in which case the pluginrpc.ClientOptions are appended.
+func ClientWithPluginrpcClientOptions(pluginrpcClientOptions ...pluginrpc.ClientOption) ClientOption {
+	return clientWithPluginrpcClientOptionsOption{pluginrpcClientOptions: pluginrpcClientOptions}
+}
+
 // NewClientForSpec return a new Client that directly uses the given Spec.
 //
+// Calls to Check are handled in-process without serializing the Request or Response: the
+// FileDescriptors of the Request are passed to the RuleHandlers by reference. As a result,
+// validation that only applies to serialized requests, such as option.Limits, is not performed.
+// All other calls go through a pluginrpc.Server for the Spec.
+//
 // This should primarily be used for testing.
 func NewClientForSpec(spec *Spec, options ...ClientForSpecOption) (Client, error) {
 	clientForSpecOptions := newClientForSpecOptions()
 	for _, option := range options {
 		option.applyToClientForSpec(clientForSpecOptions)
 	}
-	server, err := NewServer(spec)
+	checkServiceHandler, err := newCheckServiceHandler(
+		spec,
+		CheckServiceHandlerWithLogger(clientForSpecOptions.logger),
+		CheckServiceHandlerWithTracer(clientForSpecOptions.tracer),
+		CheckServiceHandlerWithMetrics(clientForSpecOptions.metrics),
+	)
 	if err != nil {
 		return nil, err
 	}
-	return newClient(
+	server, err := newServer(spec, checkServiceHandler, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newClientForPluginrpcClient(
 		pluginrpc.NewClient(
 			pluginrpc.NewServerRunner(server),
+			clientForSpecOptions.pluginrpcClientOptions...,
 		),
-		clientForSpecOptions.caching,
+		&clientForSpecOptions.clientOptions,
+		checkServiceHandler.handleRequest,
 	), nil
 }
 
@@ -114,45 +235,183 @@ type ListRulesCallOption func(*listRulesCallOptions)
 // ListCategoriesCallOption is an option for a Client.ListCategories call.
 type ListCategoriesCallOption func(*listCategoriesCallOptions)
 
+// GetPluginDocumentationCallOption is an option for a Client.GetPluginDocumentation call.
+type GetPluginDocumentationCallOption func(*getPluginDocumentationCallOptions)
+
 // *** PRIVATE ***
 
 type client struct {
 	info.Client
 
-	pluginrpcClient pluginrpc.Client
-
-	caching bool
-
-	// Singleton ordering: rules -> categories -> checkServiceClient
+	caching           bool
+	messageSizeLimits MessageSizeLimits
+	logger            *slog.Logger
+	tracer            Tracer
+	metrics           Metrics
+	timeouts          Timeouts
+	bufVersion        string
+	// handleRequest handles Check calls in-process if set.
+	handleRequest func(context.Context, Request) (Response, error)
+
+	// checkWithStateFunc makes a Check call with state, and returns the updated state.
+	checkWithStateFunc func(
+		context.Context,
+		v1pluginrpc.CheckServiceClient,
+		*checkv1.CheckRequest,
+		[]byte,
+	) (*checkv1.CheckResponse, []byte, error)
+	// checkWithSeverityOverridesFunc makes a Check call with Severity overrides, and returns
+	// the Severity that the plugin set on each Annotation and the updated state.
+	checkWithSeverityOverridesFunc func(
+		context.Context,
+		*checkv1.CheckRequest,
+		[]byte,
+		map[string]Severity,
+	) (*checkv1.CheckResponse, []Severity, []byte, error)
+
+	// verifiedPluginInfo and policies are always cached, as the PluginInfo and Policies of a
+	// plugin are static, and policies are needed for every Check call with Annotations.
+	//
+	// Singleton ordering: rules -> categories -> checkServiceClient, rules -> policies
+	verifiedPluginInfo *cache.Singleton[struct{}]
 	rules              *cache.Singleton[[]Rule]
 	categories         *cache.Singleton[[]Category]
+	policies           *cache.Singleton[map[string]Policy]
 	checkServiceClient *cache.Singleton[v1pluginrpc.CheckServiceClient]
 }
 
 func newClient(
-	pluginrpcClient pluginrpc.Client,
-	caching bool,
+	infoClient info.Client,
+	getCheckServiceClient func(context.Context) (v1pluginrpc.CheckServiceClient, error),
+	listPolicies func(context.Context) (map[string]Policy, error),
+	checkWithState func(
+		context.Context,
+		v1pluginrpc.CheckServiceClient,
+		*checkv1.CheckRequest,
+		[]byte,
+	) (*checkv1.CheckResponse, []byte, error),
+	checkWithSeverityOverrides func(
+		context.Context,
+		*checkv1.CheckRequest,
+		[]byte,
+		map[string]Severity,
+	) (*checkv1.CheckResponse, []Severity, []byte, error),
+	clientOptions *clientOptions,
+	handleRequest func(context.Context, Request) (Response, error),
 ) *client {
-	var infoClientOptions []info.ClientOption
-	if caching {
-		infoClientOptions = append(infoClientOptions, info.ClientWithCaching())
-	}
 	client := &client{
-		Client:          info.NewClient(pluginrpcClient, infoClientOptions...),
-		pluginrpcClient: pluginrpcClient,
-		caching:         caching,
+		Client:             infoClient,
+		caching:            clientOptions.caching,
+		messageSizeLimits:  clientOptions.messageSizeLimits,
+		logger:             clientOptions.logger,
+		tracer:             clientOptions.tracer,
+		metrics:            clientOptions.metrics,
+		timeouts:           clientOptions.timeouts,
+		bufVersion:         clientOptions.bufVersion,
+		handleRequest:      handleRequest,
+		checkWithStateFunc: checkWithState,
+
+		checkWithSeverityOverridesFunc: checkWithSeverityOverrides,
 	}
+	client.verifiedPluginInfo = cache.NewSingleton(client.verifyPluginInfoUncached)
 	client.rules = cache.NewSingleton(client.listRulesUncached)
 	client.categories = cache.NewSingleton(client.listCategoriesUncached)
-	client.checkServiceClient = cache.NewSingleton(client.getCheckServiceClientUncached)
+	client.policies = cache.NewSingleton(listPolicies)
+	client.checkServiceClient = cache.NewSingleton(getCheckServiceClient)
 	return client
 }
 
-func (c *client) Check(ctx context.Context, request Request, _ ...CheckCallOption) (Response, error) {
+// newClientForPluginrpcClient returns a new client that uses the given pluginrpc.Client.
+func newClientForPluginrpcClient(
+	pluginrpcClient pluginrpc.Client,
+	clientOptions *clientOptions,
+	handleRequest func(context.Context, Request) (Response, error),
+) *client {
+	var infoClientOptions []info.ClientOption
+	if clientOptions.caching {
+		infoClientOptions = append(infoClientOptions, info.ClientWithCaching())
+	}
+	return newClient(
+		info.NewClient(pluginrpcClient, infoClientOptions...),
+		func(ctx context.Context) (v1pluginrpc.CheckServiceClient, error) {
+			return getCheckServiceClientForPluginrpcClient(ctx, pluginrpcClient)
+		},
+		func(ctx context.Context) (map[string]Policy, error) {
+			return listPoliciesForPluginrpcClient(ctx, pluginrpcClient)
+		},
+		func(
+			ctx context.Context,
+			checkServiceClient v1pluginrpc.CheckServiceClient,
+			checkRequest *checkv1.CheckRequest,
+			state []byte,
+		) (*checkv1.CheckResponse, []byte, error) {
+			return checkWithStateForPluginrpcClient(ctx, pluginrpcClient, checkServiceClient, checkRequest, state)
+		},
+		func(
+			ctx context.Context,
+			checkRequest *checkv1.CheckRequest,
+			state []byte,
+			ruleIDToSeverity map[string]Severity,
+		) (*checkv1.CheckResponse, []Severity, []byte, error) {
+			return checkWithSeverityOverridesForPluginrpcClient(ctx, pluginrpcClient, checkRequest, state, ruleIDToSeverity)
+		},
+		clientOptions,
+		handleRequest,
+	)
+}
+
+func (c *client) Check(ctx context.Context, request Request, options ...CheckCallOption) (_ Response, retErr error) {
+	ctx, handleTimeout := withTimeout(ctx, c.timeouts.Check, "Check")
+	defer func() { retErr = handleTimeout(retErr) }()
+	checkCallOptions := newCheckCallOptions()
+	for _, option := range options {
+		option(checkCallOptions)
+	}
+	if err := validateWaivers(checkCallOptions.waivers); err != nil {
+		return nil, err
+	}
+	if checkCallOptions.baseline != nil {
+		if err := validateBaseline(checkCallOptions.baseline); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := c.verifiedPluginInfo.Get(ctx); err != nil {
+		return nil, err
+	}
+	response, err := c.checkWithoutPolicies(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if checkCallOptions.baseline != nil {
+		response, err = newResponse(applyBaseline(checkCallOptions.baseline, response.Annotations()), response.State())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.applyAnnotationProperties(ctx, response, checkCallOptions.waivers)
+}
+
+// checkWithoutPolicies makes the Check call, either in-process or to the plugin.
+func (c *client) checkWithoutPolicies(ctx context.Context, request Request) (Response, error) {
+	if c.handleRequest != nil {
+		return c.checkInProcess(ctx, request)
+	}
 	checkServiceClient, err := c.checkServiceClient.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// The Severity overrides are sent separately from the Options, as map values cannot be
+	// represented in the Protobuf representation of Options.
+	ruleIDToSeverity, options, err := severityOverridesForOptions(request.Options())
+	if err != nil {
+		return nil, err
+	}
+	if ruleIDToSeverity != nil {
+		request, err = requestWithOptions(request, options)
+		if err != nil {
+			return nil, err
+		}
+	}
 	multiResponseWriter, err := newMultiResponseWriter(request)
 	if err != nil {
 		return nil, err
@@ -161,43 +420,240 @@ func (c *client) Check(ctx context.Context, request Request, _ ...CheckCallOptio
 	if err != nil {
 		return nil, err
 	}
+	// The state returned for each CheckRequest is passed to the next, so that the state
+	// accumulates across CheckRequests chunked by Rule ID.
+	state := request.State()
 	for _, protoRequest := range protoRequests {
-		protoResponse, err := checkServiceClient.Check(ctx, protoRequest)
+		var protoResponse *checkv1.CheckResponse
+		var annotationSeverities []Severity
+		protoResponse, annotationSeverities, state, err = c.checkProto(ctx, checkServiceClient, protoRequest, state, ruleIDToSeverity)
 		if err != nil {
 			return nil, err
 		}
-		for _, protoAnnotation := range protoResponse.GetAnnotations() {
-			multiResponseWriter.addAnnotation(
-				protoAnnotation.GetRuleId(),
-				WithMessage(protoAnnotation.GetMessage()),
-				WithFileNameAndSourcePath(
-					protoAnnotation.GetFileLocation().GetFileName(),
-					protoAnnotation.GetFileLocation().GetSourcePath(),
-				),
-				WithAgainstFileNameAndSourcePath(
-					protoAnnotation.GetAgainstFileLocation().GetFileName(),
-					protoAnnotation.GetAgainstFileLocation().GetSourcePath(),
-				),
+		for i, protoAnnotation := range protoResponse.GetAnnotations() {
+			var severity Severity
+			if annotationSeverities != nil {
+				severity = annotationSeverities[i]
+			}
+			addProtoAnnotation(multiResponseWriter, protoAnnotation, severity)
+		}
+	}
+	if state != nil {
+		if err := multiResponseWriter.setState(state); err != nil {
+			return nil, err
+		}
+	}
+	return multiResponseWriter.toResponse()
+}
+
+// applyAnnotationProperties sets the Policy and Waiver on each Annotation of the Response.
+//
+// These are not part of the v1 CheckService protocol, and are therefore set by the Client.
+func (c *client) applyAnnotationProperties(
+	ctx context.Context,
+	response Response,
+	waivers []Waiver,
+) (Response, error) {
+	annotations := response.Annotations()
+	if len(annotations) == 0 {
+		return response, nil
+	}
+	ruleIDToPolicy, err := c.listPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ruleIDToPolicy) == 0 && len(waivers) == 0 {
+		return response, nil
+	}
+	now := time.Now()
+	for i, annotation := range annotations {
+		policy := ruleIDToPolicy[annotation.RuleID()]
+		waiver := findWaiver(waivers, annotation, now)
+		if policy != nil || waiver != nil {
+			annotations[i] = annotationWithPolicyAndWaiver(annotation, policy, waiver)
+		}
+	}
+	return newResponse(annotations, response.State())
+}
+
+// checkProto makes a single Check call to the plugin.
+//
+// If state is non-nil, the call is made with the state, and the updated state is returned.
+// If ruleIDToSeverity is non-empty, the call is made with the Severity overrides, and the
+// Severity that the plugin set on each Annotation is returned. Otherwise, the returned
+// Severities are nil.
+func (c *client) checkProto(
+	ctx context.Context,
+	checkServiceClient v1pluginrpc.CheckServiceClient,
+	protoRequest *checkv1.CheckRequest,
+	state []byte,
+	ruleIDToSeverity map[string]Severity,
+) (_ *checkv1.CheckResponse, _ []Severity, _ []byte, retErr error) {
+	ctx, span := startCheckSpan(ctx, c.tracer, true, protoRequest)
+	var protoResponse *checkv1.CheckResponse
+	defer func() { span.End(len(protoResponse.GetAnnotations()), retErr) }()
+	start := time.Now()
+	if c.metrics != nil {
+		defer func() { recordCheck(ctx, c.metrics, start, protoRequest, protoResponse, retErr) }()
+	}
+	if err := c.messageSizeLimits.validateRequest(protoRequest); err != nil {
+		return nil, nil, nil, err
+	}
+	var annotationSeverities []Severity
+	var err error
+	switch {
+	case len(ruleIDToSeverity) > 0:
+		protoResponse, annotationSeverities, state, err = c.checkWithSeverityOverridesFunc(ctx, protoRequest, state, ruleIDToSeverity)
+	case state != nil:
+		protoResponse, state, err = c.checkWithStateFunc(ctx, checkServiceClient, protoRequest, state)
+	default:
+		protoResponse, err = checkServiceClient.Check(ctx, protoRequest)
+	}
+	if err != nil {
+		c.logger.DebugContext(
+			ctx,
+			"check call failed",
+			slog.Duration("duration", time.Since(start)),
+			slog.Any("error", err),
+		)
+		return nil, nil, nil, err
+	}
+	c.logger.DebugContext(
+		ctx,
+		"check call completed",
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("file_descriptors", len(protoRequest.GetFileDescriptors())),
+		slog.Int("annotations", len(protoResponse.GetAnnotations())),
+	)
+	if err := c.messageSizeLimits.validateResponse(protoResponse); err != nil {
+		return nil, nil, nil, err
+	}
+	return protoResponse, annotationSeverities, state, nil
+}
+
+// checkInProcess handles a Check call without serializing the Request or Response.
+func (c *client) checkInProcess(ctx context.Context, request Request) (_ Response, retErr error) {
+	ctx, span := startInProcessCheckSpan(ctx, c.tracer, request)
+	var numAnnotations int
+	defer func() { span.End(numAnnotations, retErr) }()
+	if c.metrics != nil {
+		start := time.Now()
+		defer func() {
+			c.metrics.RecordCheck(
+				ctx,
+				CheckMetrics{
+					Duration:           time.Since(start),
+					NumFileDescriptors: len(request.FileDescriptors()),
+					NumAnnotations:     numAnnotations,
+					Err:                retErr,
+				},
 			)
+		}()
+	}
+	response, err := c.handleRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	numAnnotations = len(response.Annotations())
+	// Rebuild the Annotations from their file names and source paths, as is done for
+	// Responses received over the wire, so that the Response is the same as if the
+	// Check call was made to a plugin.
+	multiResponseWriter, err := newMultiResponseWriter(request)
+	if err != nil {
+		return nil, err
+	}
+	for _, responseAnnotation := range response.Annotations() {
+		addProtoAnnotation(
+			multiResponseWriter,
+			responseAnnotation.toProto(),
+			responseAnnotation.(*annotation).severityOverride,
+		)
+	}
+	if state := response.State(); state != nil {
+		if err := multiResponseWriter.setState(state); err != nil {
+			return nil, err
 		}
 	}
 	return multiResponseWriter.toResponse()
 }
 
-func (c *client) ListRules(ctx context.Context, _ ...ListRulesCallOption) ([]Rule, error) {
+func (c *client) ListRules(ctx context.Context, _ ...ListRulesCallOption) (_ []Rule, retErr error) {
+	ctx, handleTimeout := withTimeout(ctx, c.timeouts.ListRules, "ListRules")
+	defer func() { retErr = handleTimeout(retErr) }()
+	if _, err := c.verifiedPluginInfo.Get(ctx); err != nil {
+		return nil, err
+	}
 	if !c.caching {
 		return c.listRulesUncached(ctx)
 	}
 	return c.rules.Get(ctx)
 }
 
-func (c *client) ListCategories(ctx context.Context, _ ...ListCategoriesCallOption) ([]Category, error) {
+func (c *client) ListCategories(ctx context.Context, _ ...ListCategoriesCallOption) (_ []Category, retErr error) {
+	ctx, handleTimeout := withTimeout(ctx, c.timeouts.ListCategories, "ListCategories")
+	defer func() { retErr = handleTimeout(retErr) }()
+	if _, err := c.verifiedPluginInfo.Get(ctx); err != nil {
+		return nil, err
+	}
 	if !c.caching {
 		return c.listCategoriesUncached(ctx)
 	}
 	return c.categories.Get(ctx)
 }
 
+func (c *client) GetPluginDocumentation(
+	ctx context.Context,
+	_ ...GetPluginDocumentationCallOption,
+) (PluginDocumentation, error) {
+	pluginInfo, err := c.GetPluginInfo(ctx)
+	if err != nil && !isUnimplementedError(err) {
+		return nil, err
+	}
+	rules, err := c.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := c.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newPluginDocumentation(pluginInfo, rules, categories, nil), nil
+}
+
+func (c *client) listPolicies(ctx context.Context) (map[string]Policy, error) {
+	return c.policies.Get(ctx)
+}
+
+// verifyPluginInfoUncached checks that the caller meets the minimum versions required by
+// the plugin, and warns if the plugin is deprecated. Plugins that do not implement the
+// PluginInfoService have no requirements.
+//
+// This is a no-op if no buf version is set, so that the plugin is not invoked to get its
+// PluginInfo for every Client.
+func (c *client) verifyPluginInfoUncached(ctx context.Context) (struct{}, error) {
+	if c.bufVersion == "" {
+		return struct{}{}, nil
+	}
+	pluginInfo, err := c.GetPluginInfo(ctx)
+	if err != nil {
+		if isUnimplementedError(err) {
+			return struct{}{}, nil
+		}
+		return struct{}{}, err
+	}
+	if err := info.CheckCompatibility(pluginInfo, c.bufVersion); err != nil {
+		return struct{}{}, pluginrpc.NewError(pluginrpc.CodeFailedPrecondition, err)
+	}
+	if pluginInfo.Deprecated() {
+		attrs := []slog.Attr{}
+		if deprecationMessage := pluginInfo.DeprecationMessage(); deprecationMessage != "" {
+			attrs = append(attrs, slog.String("deprecation_message", deprecationMessage))
+		}
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "plugin is deprecated", attrs...)
+	}
+	return struct{}{}, nil
+}
+
 func (c *client) listRulesUncached(ctx context.Context) ([]Rule, error) {
 	checkServiceClient, err := c.checkServiceClient.Get(ctx)
 	if err != nil {
@@ -233,10 +689,14 @@ func (c *client) listRulesUncached(ctx context.Context) ([]Rule, error) {
 		// We know there are no duplicate IDs from validation.
 		categoryIDToCategory[category.ID()] = category
 	}
+	ruleIDToPolicy, err := c.listPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
 	rules, err := xslices.MapError(
 		protoRules,
 		func(protoRule *checkv1.Rule) (Rule, error) {
-			return ruleForProtoRule(protoRule, categoryIDToCategory)
+			return ruleForProtoRule(protoRule, categoryIDToCategory, ruleIDToPolicy)
 		},
 	)
 	if err != nil {
@@ -284,8 +744,13 @@ func (c *client) listCategoriesUncached(ctx context.Context) ([]Category, error)
 	return categories, nil
 }
 
-func (c *client) getCheckServiceClientUncached(ctx context.Context) (v1pluginrpc.CheckServiceClient, error) {
-	spec, err := c.pluginrpcClient.Spec(ctx)
+func (*client) isClient() {}
+
+func getCheckServiceClientForPluginrpcClient(
+	ctx context.Context,
+	pluginrpcClient pluginrpc.Client,
+) (v1pluginrpc.CheckServiceClient, error) {
+	spec, err := pluginrpcClient.Spec(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -299,25 +764,56 @@ func (c *client) getCheckServiceClientUncached(ctx context.Context) (v1pluginrpc
 			return nil, pluginrpc.NewErrorf(pluginrpc.CodeUnimplemented, "procedure unimplemented: %q", procedurePath)
 		}
 	}
-	return v1pluginrpc.NewCheckServiceClient(c.pluginrpcClient)
+	return v1pluginrpc.NewCheckServiceClient(pluginrpcClient)
 }
 
-func (*client) isClient() {}
+// addProtoAnnotation adds the Annotation to the multiResponseWriter, with the Severity that
+// the plugin set on the Annotation, or 0 if the plugin did not set a Severity.
+func addProtoAnnotation(multiResponseWriter *multiResponseWriter, protoAnnotation *checkv1.Annotation, severity Severity) {
+	multiResponseWriter.addAnnotation(
+		protoAnnotation.GetRuleId(),
+		WithMessage(protoAnnotation.GetMessage()),
+		WithFileNameAndSourcePath(
+			protoAnnotation.GetFileLocation().GetFileName(),
+			protoAnnotation.GetFileLocation().GetSourcePath(),
+		),
+		WithAgainstFileNameAndSourcePath(
+			protoAnnotation.GetAgainstFileLocation().GetFileName(),
+			protoAnnotation.GetAgainstFileLocation().GetSourcePath(),
+		),
+		withSeverityOverride(severity),
+	)
+}
 
 type clientOptions struct {
-	caching bool
+	caching           bool
+	messageSizeLimits MessageSizeLimits
+	logger            *slog.Logger
+	tracer            Tracer
+	metrics           Metrics
+	timeouts          Timeouts
+	bufVersion        string
+	// pluginrpcClientOptions are the options added with ClientWithPluginrpcClientOptions.
+	pluginrpcClientOptions []pluginrpc.ClientOption
 }
 
 func newClientOptions() *clientOptions {
-	return &clientOptions{}
+	return &clientOptions{
+		logger: discardLogger,
+	}
 }
 
+// clientForSpecOptions are the options for NewClientForSpec.
+//
+// The messageSizeLimits are not used, as Check calls are handled in-process.
 type clientForSpecOptions struct {
-	caching bool
+	clientOptions
 }
 
 func newClientForSpecOptions() *clientForSpecOptions {
-	return &clientForSpecOptions{}
+	return &clientForSpecOptions{
+		clientOptions: *newClientOptions(),
+	}
 }
 
 type clientWithCachingOption struct{}
@@ -326,12 +822,127 @@ func (clientWithCachingOption) applyToClient(clientOptions *clientOptions) {
 	clientOptions.caching = true
 }
 
-func (clientWithCachingOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
-	clientForSpecOptions.caching = true
+func (c clientWithCachingOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
+	c.applyToClient(&clientForSpecOptions.clientOptions)
 }
 
-type checkCallOptions struct{}
+type clientWithMessageSizeLimitsOption struct {
+	messageSizeLimits MessageSizeLimits
+}
+
+func (c clientWithMessageSizeLimitsOption) applyToClient(clientOptions *clientOptions) {
+	clientOptions.messageSizeLimits = c.messageSizeLimits
+}
+
+func (c clientWithMessageSizeLimitsOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
+	c.applyToClient(&clientForSpecOptions.clientOptions)
+}
+
+type clientWithLoggerOption struct {
+	logger *slog.Logger
+}
+
+func (c clientWithLoggerOption) applyToClient(clientOptions *clientOptions) {
+	if c.logger != nil {
+		clientOptions.logger = c.logger
+	}
+}
+
+func (c clientWithLoggerOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
+	c.applyToClient(&clientForSpecOptions.clientOptions)
+}
+
+type clientWithTracerOption struct {
+	tracer Tracer
+}
+
+func (c clientWithTracerOption) applyToClient(clientOptions *clientOptions) {
+	clientOptions.tracer = c.tracer
+}
+
+func (c clientWithTracerOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
+	c.applyToClient(&clientForSpecOptions.clientOptions)
+}
+
+type clientWithMetricsOption struct {
+	metrics Metrics
+}
+
+func (c clientWithMetricsOption) applyToClient(clientOptions *clientOptions) {
+	clientOptions.metrics = c.metrics
+}
+
+func (c clientWithMetricsOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
+	c.applyToClient(&clientForSpecOptions.clientOptions)
+}
+
+type clientWithTimeoutsOption struct {
+	timeouts Timeouts
+}
+
+func (c clientWithTimeoutsOption) applyToClient(clientOptions *clientOptions) {
+	clientOptions.timeouts = c.timeouts
+}
+
+func (c clientWithTimeoutsOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
+	c.applyToClient(&clientForSpecOptions.clientOptions)
+}
+
+type clientWithBufVersionOption struct {
+	bufVersion string
+}
+
+func (c clientWithBufVersionOption) applyToClient(clientOptions *clientOptions) {
+	clientOptions.bufVersion = c.bufVersion
+}
+
+func (c clientWithBufVersionOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
+	c.applyToClient(&clientForSpecOptions.clientOptions)
+}
+
+type clientWithPluginrpcClientOptionsOption struct {
+	pluginrpcClientOptions []pluginrpc.ClientOption
+}
+
+func (c clientWithPluginrpcClientOptionsOption) applyToClient(clientOptions *clientOptions) {
+	clientOptions.pluginrpcClientOptions = append(clientOptions.pluginrpcClientOptions, c.pluginrpcClientOptions...)
+}
+
+func (c clientWithPluginrpcClientOptionsOption) applyToClientForSpec(clientForSpecOptions *clientForSpecOptions) {
+	c.applyToClient(&clientForSpecOptions.clientOptions)
+}
+
+// newPluginrpcClient returns a new pluginrpc.Client for the runner with the
+// pluginrpc.ClientOptions added to the given ClientOptions.
+func newPluginrpcClient(runner pluginrpc.Runner, options []ClientOption) pluginrpc.Client {
+	return pluginrpc.NewClient(runner, newClientOptionsForClientOptions(options).pluginrpcClientOptions...)
+}
+
+// maxResponseSizeForClientOptions returns the MaxResponseSize set with
+// ClientWithMessageSizeLimits, for runners that read responses from a stream.
+func maxResponseSizeForClientOptions(options []ClientOption) int {
+	return newClientOptionsForClientOptions(options).messageSizeLimits.MaxResponseSize
+}
+
+func newClientOptionsForClientOptions(options []ClientOption) *clientOptions {
+	clientOptions := newClientOptions()
+	for _, option := range options {
+		option.applyToClient(clientOptions)
+	}
+	return clientOptions
+}
+
+type checkCallOptions struct {
+	waivers  []Waiver
+	baseline *Baseline
+}
+
+func newCheckCallOptions() *checkCallOptions {
+	return &checkCallOptions{}
+}
 
 type listRulesCallOptions struct{}
 
 type listCategoriesCallOptions struct{}
+
+type getPluginDocumentationCallOptions struct{}
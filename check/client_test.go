@@ -15,14 +15,23 @@
 package check
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/descriptor"
 	"buf.build/go/bufplugin/info"
 	"buf.build/go/bufplugin/internal/pkg/xslices"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"pluginrpc.com/pluginrpc"
 )
 
@@ -171,6 +180,28 @@ func TestPluginInfo(t *testing.T) {
 			Info: &info.Spec{
 				SPDXLicenseID: "apache-2.0",
 				LicenseURL:    "https://foo.com/license",
+				Version:       "v1.2.3",
+				Authors: []*info.ContactSpec{
+					{
+						Name:  "Foo Team",
+						Email: "foo@example.com",
+						URL:   "https://example.com/foo",
+					},
+				},
+				Maintainers: []*info.ContactSpec{
+					{
+						Name: "Bar Team",
+					},
+				},
+				Keywords: []string{"grpc", "naming"},
+				SourceRevision: &info.SourceRevisionSpec{
+					VCS:      "git",
+					Revision: "0123456789abcdef",
+					Modified: true,
+					Time:     time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC),
+				},
+				ChangelogURL: "https://foo.com/changelog",
+				SupportURL:   "https://foo.com/support",
 			},
 		},
 	)
@@ -183,6 +214,78 @@ func TestPluginInfo(t *testing.T) {
 	// Case-sensitive.
 	require.Equal(t, "Apache-2.0", license.SPDXLicenseID())
 	require.Equal(t, "https://foo.com/license", license.URL().String())
+	// Transmitted over the PluginInfoExtensionService.
+	require.Equal(t, "v1.2.3", pluginInfo.Version())
+	require.Len(t, pluginInfo.Authors(), 1)
+	require.Equal(t, "Foo Team", pluginInfo.Authors()[0].Name())
+	require.Equal(t, "foo@example.com", pluginInfo.Authors()[0].Email())
+	require.Equal(t, "https://example.com/foo", pluginInfo.Authors()[0].URL().String())
+	require.Len(t, pluginInfo.Maintainers(), 1)
+	require.Equal(t, "Bar Team", pluginInfo.Maintainers()[0].Name())
+	require.Nil(t, pluginInfo.Maintainers()[0].URL())
+	require.Equal(t, []string{"grpc", "naming"}, pluginInfo.Keywords())
+	sourceRevision := pluginInfo.SourceRevision()
+	require.NotNil(t, sourceRevision)
+	require.Equal(t, "git", sourceRevision.VCS())
+	require.Equal(t, "0123456789abcdef", sourceRevision.Revision())
+	require.True(t, sourceRevision.Modified())
+	require.True(t, time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC).Equal(sourceRevision.Time()))
+	require.NotNil(t, pluginInfo.ChangelogURL())
+	require.Equal(t, "https://foo.com/changelog", pluginInfo.ChangelogURL().String())
+	require.NotNil(t, pluginInfo.SupportURL())
+	require.Equal(t, "https://foo.com/support", pluginInfo.SupportURL().String())
+}
+
+func TestPluginInfoLicensesAndShortDocumentation(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClientForSpec(
+		&Spec{
+			Rules: []*RuleSpec{
+				{
+					ID:      "RULE1",
+					Purpose: "Test RULE1.",
+					Type:    RuleTypeLint,
+					Handler: nopRuleHandler,
+				},
+			},
+			Info: &info.Spec{
+				Documentation:         "A plugin.\n\nMore about the plugin.",
+				ShortDocumentation:    "Short.",
+				SPDXLicenseExpression: "mit or apache-2.0",
+				LicenseURL:            "https://foo.com/license",
+				AdditionalLicenses: []*info.LicenseSpec{
+					{
+						Component:   "vendor/foo",
+						LicenseText: "Foo license.",
+					},
+					{
+						Component:     "vendor/bar",
+						SPDXLicenseID: "bsd-3-clause",
+						LicenseURL:    "https://bar.com/license",
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	pluginInfo, err := client.GetPluginInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Short.", pluginInfo.ShortDocumentation())
+	license := pluginInfo.License()
+	require.NotNil(t, license)
+	require.Equal(t, "MIT OR Apache-2.0", license.SPDXLicenseExpression())
+	require.Empty(t, license.SPDXLicenseID())
+	require.Equal(t, "https://foo.com/license", license.URL().String())
+	additionalLicenses := pluginInfo.AdditionalLicenses()
+	require.Len(t, additionalLicenses, 2)
+	require.Equal(t, "vendor/foo", additionalLicenses[0].Component())
+	require.Equal(t, "Foo license.", additionalLicenses[0].Text())
+	require.Empty(t, additionalLicenses[0].SPDXLicenseExpression())
+	require.Equal(t, "vendor/bar", additionalLicenses[1].Component())
+	require.Equal(t, "BSD-3-Clause", additionalLicenses[1].SPDXLicenseID())
+	require.Equal(t, "BSD-3-Clause", additionalLicenses[1].SPDXLicenseExpression())
+	require.Equal(t, "https://bar.com/license", additionalLicenses[1].URL().String())
 }
 
 func TestPluginInfoUnimplemented(t *testing.T) {
@@ -207,3 +310,212 @@ func TestPluginInfoUnimplemented(t *testing.T) {
 	require.ErrorAs(t, err, &pluginrpcError)
 	require.Equal(t, pluginrpc.CodeUnimplemented, pluginrpcError.Code())
 }
+
+func TestClientBufVersion(t *testing.T) {
+	t.Parallel()
+
+	spec := &Spec{
+		Rules: []*RuleSpec{
+			{
+				ID:      "RULE1",
+				Purpose: "Test RULE1.",
+				Type:    RuleTypeLint,
+				Handler: nopRuleHandler,
+			},
+		},
+		Info: &info.Spec{
+			MinimumBufVersion: "v1.50.0",
+		},
+	}
+	client, err := NewClientForSpec(spec, ClientWithBufVersion("v1.49.1"))
+	require.NoError(t, err)
+	pluginInfo, err := client.GetPluginInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "v1.50.0", pluginInfo.MinimumBufVersion())
+	_, err = client.ListRules(context.Background())
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeFailedPrecondition, pluginrpcError.Code())
+	require.ErrorContains(t, err, "plugin requires buf v1.50.0 or later")
+	_, err = client.Check(context.Background(), testNewPolicyRequest(t))
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeFailedPrecondition, pluginrpcError.Code())
+
+	client, err = NewClientForSpec(spec, ClientWithBufVersion("v1.50.0"))
+	require.NoError(t, err)
+	_, err = client.ListRules(context.Background())
+	require.NoError(t, err)
+	// The buf version is not checked if not set.
+	client, err = NewClientForSpec(spec)
+	require.NoError(t, err)
+	_, err = client.ListRules(context.Background())
+	require.NoError(t, err)
+
+	// The plugin is only invoked to get its PluginInfo if the buf version is set.
+	server, err := NewServer(spec)
+	require.NoError(t, err)
+	recordingRunner := &testRecordingRunner{run: pluginrpc.NewServerRunner(server).Run}
+	_, err = NewClient(pluginrpc.NewClient(recordingRunner)).ListRules(context.Background())
+	require.NoError(t, err)
+	for _, args := range recordingRunner.allArgs {
+		require.NotEqual(t, "info", args[0])
+		require.NotEqual(t, "info-extension", args[0])
+	}
+	_, err = NewClient(pluginrpc.NewClient(recordingRunner), ClientWithBufVersion("v1.49.1")).ListRules(context.Background())
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeFailedPrecondition, pluginrpcError.Code())
+}
+
+func TestClientDeprecatedPlugin(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	client, err := NewClientForSpec(
+		&Spec{
+			Rules: []*RuleSpec{
+				{
+					ID:      "RULE1",
+					Purpose: "Test RULE1.",
+					Type:    RuleTypeLint,
+					Handler: nopRuleHandler,
+				},
+			},
+			Info: &info.Spec{
+				Deprecated:         true,
+				DeprecationMessage: "Use buf-plugin-bar instead.",
+			},
+		},
+		ClientWithLogger(slog.New(slog.NewTextHandler(buffer, nil))),
+		ClientWithBufVersion("v1.50.0"),
+	)
+	require.NoError(t, err)
+	pluginInfo, err := client.GetPluginInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, pluginInfo.Deprecated())
+	require.Equal(t, "Use buf-plugin-bar instead.", pluginInfo.DeprecationMessage())
+	_, err = client.ListRules(context.Background())
+	require.NoError(t, err)
+	_, err = client.ListCategories(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(buffer.String(), "plugin is deprecated"))
+	require.Contains(t, buffer.String(), "level=WARN")
+	require.Contains(t, buffer.String(), `deprecation_message="Use buf-plugin-bar instead."`)
+}
+
+func TestGetPluginDocumentation(t *testing.T) {
+	t.Parallel()
+
+	spec := &Spec{
+		Rules: []*RuleSpec{
+			{
+				ID:               "RULE1",
+				CategoryIDs:      []string{"CATEGORY1"},
+				Purpose:          "Test RULE1.",
+				Type:             RuleTypeLint,
+				Handler:          nopRuleHandler,
+				Documentation:    "# RULE1\n\nChecks everything.",
+				Examples:         []string{"```proto\nsyntax = \"proto3\";\n```"},
+				DocumentationURL: "https://foo.com/rules/rule1",
+			},
+		},
+		Categories: []*CategorySpec{
+			{
+				ID:            "CATEGORY1",
+				Purpose:       "Test CATEGORY1.",
+				Documentation: "All the rules.",
+			},
+		},
+		Info: &info.Spec{
+			Documentation: "A plugin.",
+		},
+	}
+
+	pluginDocumentation, err := NewPluginDocumentationForSpec(spec)
+	require.NoError(t, err)
+	require.NotNil(t, pluginDocumentation.PluginInfo())
+	require.Equal(t, "A plugin.", pluginDocumentation.PluginInfo().Documentation())
+	rules := pluginDocumentation.Rules()
+	require.Len(t, rules, 1)
+	require.Equal(t, "# RULE1\n\nChecks everything.", rules[0].Documentation())
+	require.Equal(t, []string{"```proto\nsyntax = \"proto3\";\n```"}, rules[0].Examples())
+	require.NotNil(t, rules[0].DocumentationURL())
+	require.Equal(t, "https://foo.com/rules/rule1", rules[0].DocumentationURL().String())
+	categories := pluginDocumentation.Categories()
+	require.Len(t, categories, 1)
+	require.Equal(t, "All the rules.", categories[0].Documentation())
+
+	client, err := NewClientForSpec(spec)
+	require.NoError(t, err)
+	pluginDocumentation, err = client.GetPluginDocumentation(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, pluginDocumentation.PluginInfo())
+	require.Equal(t, "A plugin.", pluginDocumentation.PluginInfo().Documentation())
+	rules = pluginDocumentation.Rules()
+	require.Len(t, rules, 1)
+	require.Equal(t, "Test RULE1.", rules[0].Purpose())
+	// Not transmitted over the wire.
+	require.Empty(t, rules[0].Documentation())
+	require.Nil(t, rules[0].DocumentationURL())
+	require.Len(t, pluginDocumentation.Categories(), 1)
+
+	spec.Info = nil
+	client, err = NewClientForSpec(spec)
+	require.NoError(t, err)
+	pluginDocumentation, err = client.GetPluginDocumentation(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, pluginDocumentation.PluginInfo())
+	require.Len(t, pluginDocumentation.Rules(), 1)
+}
+
+func TestClientForSpecCheckInProcess(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name: proto.String("foo.proto"),
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name: proto.String("Foo"),
+						},
+					},
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+						Location: []*descriptorpb.SourceCodeInfo_Location{
+							{
+								Path: []int32{4, 0},
+								Span: []int32{0, 0, 1},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			// The FileDescriptors are passed by reference, not serialized.
+			require.Same(t, fileDescriptors[0].FileDescriptorProto(), request.FileDescriptors()[0].FileDescriptorProto())
+			responseWriter.AddAnnotation(
+				WithMessage("foo"),
+				WithDescriptor(request.FileDescriptors()[0].ProtoreflectFileDescriptor().Messages().Get(0)),
+			)
+			return nil
+		},
+	)
+	client, err := NewClientForSpec(&Spec{Rules: []*RuleSpec{ruleSpec}})
+	require.NoError(t, err)
+	response, err := client.Check(context.Background(), request)
+	require.NoError(t, err)
+	annotations := response.Annotations()
+	require.Len(t, annotations, 1)
+	require.Equal(t, "RULE1", annotations[0].RuleID())
+	require.Equal(t, "foo", annotations[0].Message())
+	require.Equal(t, "foo.proto", annotations[0].FileLocation().FileDescriptor().FileDescriptorProto().GetName())
+	require.Equal(t, protoreflect.SourcePath{4, 0}, annotations[0].FileLocation().SourcePath())
+}
@@ -0,0 +1,52 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+
+	"buf.build/go/bufplugin/internal/pkg/compression"
+	"pluginrpc.com/pluginrpc"
+)
+
+// NewRunnerWithCompression returns a new pluginrpc.Runner that compresses the requests
+// and responses sent to and received from the plugin run by the given pluginrpc.Runner.
+//
+// FileDescriptorSets for large modules compress well, and transferring them over stdio
+// can otherwise dominate the time taken to invoke a plugin.
+//
+// Compression is negotiated with the plugin on the first invocation using GetCapabilities:
+// zstd is preferred, and gzip is used if the plugin does not support zstd. Plugins that call
+// Main support compression. If the plugin does not support compression, for example because
+// it was built with an older version of this package, requests and responses are sent
+// uncompressed. Negotiation requires at least one additional invocation of the plugin.
+//
+//	client := check.NewClient(
+//		pluginrpc.NewClient(
+//			check.NewRunnerWithCompression(pluginrpc.NewExecRunner("buf-plugin-foo")),
+//		),
+//	)
+func NewRunnerWithCompression(runner pluginrpc.Runner) pluginrpc.Runner {
+	return compression.NewRunner(
+		runner,
+		func(ctx context.Context) ([]string, error) {
+			capabilities, err := GetCapabilities(ctx, runner)
+			if err != nil {
+				return nil, err
+			}
+			return capabilities.Compressions, nil
+		},
+	)
+}
@@ -0,0 +1,99 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/internal/pkg/compression"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRunnerWithCompression(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+
+	compressingRunner := &testRecordingRunner{
+		run: func(ctx context.Context, env pluginrpc.Env) error {
+			if slices.Equal(env.Args, []string{"--" + CapabilitiesFlagName}) {
+				return writeCapabilities(ctx, env.Stdout, server)
+			}
+			return compression.Serve(ctx, server, env)
+		},
+	}
+	testCheckFileName(t, NewClient(pluginrpc.NewClient(NewRunnerWithCompression(compressingRunner))))
+	require.Equal(t, []string{"--" + CapabilitiesFlagName}, compressingRunner.allArgs[0])
+	for _, args := range compressingRunner.allArgs[1:] {
+		require.Equal(t, []string{"--compression", "zstd"}, args[:2])
+	}
+
+	// Main supports compression.
+	execRunner := pluginrpc.NewExecRunner(os.Args[0])
+	testCheckFileName(t, NewClient(pluginrpc.NewClient(NewRunnerWithCompression(execRunner))))
+
+	// Plugins that do not support compression are invoked without compression.
+	plainServerRunner := pluginrpc.NewServerRunner(server)
+	plainRunner := &testRecordingRunner{
+		run: plainServerRunner.Run,
+	}
+	testCheckFileName(t, NewClient(pluginrpc.NewClient(NewRunnerWithCompression(plainRunner))))
+	for _, args := range plainRunner.allArgs {
+		require.NotContains(t, args, "--compression")
+	}
+}
+
+func testCheckFileName(t *testing.T, client Client) {
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:           proto.String("foo.proto"),
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	response, err := client.Check(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 1)
+	require.Equal(t, "foo.proto", response.Annotations()[0].Message())
+}
+
+type testRecordingRunner struct {
+	run     func(context.Context, pluginrpc.Env) error
+	allArgs [][]string
+	lock    sync.Mutex
+}
+
+func (r *testRecordingRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	r.lock.Lock()
+	r.allArgs = append(r.allArgs, env.Args)
+	r.lock.Unlock()
+	return r.run(ctx, env)
+}
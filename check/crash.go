@@ -0,0 +1,90 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"buf.build/go/bufplugin/descriptor"
+	"pluginrpc.com/pluginrpc"
+)
+
+// *** PRIVATE ***
+
+// callWithPanicRecovery calls f, and converts a panic in f into an error with CodeInternal.
+//
+// A panic with an error wrapping descriptor.ErrInvalidFileDescriptor, which is how
+// ProtoreflectFileDescriptor reports a file that cannot be built, is not a bug in the plugin,
+// and is instead converted into an error with CodeInvalidArgument without a crash report.
+//
+// The where string describes what was called, such as "rule \"FOO\"". If crashReportWriter
+// is non-nil, a crash report with the stack of the panic, the version of the plugin, and a
+// summary of the Request is written to it.
+func callWithPanicRecovery(
+	crashReportWriter io.Writer,
+	where string,
+	request Request,
+	f func() error,
+) (retErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(error); ok && errors.Is(err, descriptor.ErrInvalidFileDescriptor) {
+				retErr = pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+				return
+			}
+			if crashReportWriter != nil {
+				_, _ = crashReportWriter.Write(newCrashReport(r, where, request, debug.Stack()))
+			}
+			retErr = pluginrpc.NewErrorf(pluginrpc.CodeInternal, "plugin panicked in %s: %v", where, r)
+		}
+	}()
+	return f()
+}
+
+func newCrashReport(r any, where string, request Request, stack []byte) []byte {
+	buffer := bytes.NewBuffer(nil)
+	_, _ = fmt.Fprintf(buffer, "panic in %s: %v\n\n", where, r)
+	_, _ = fmt.Fprintf(buffer, "plugin: %s\n", pluginVersion())
+	_, _ = fmt.Fprintf(buffer, "go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	_, _ = fmt.Fprintf(
+		buffer,
+		"request: %d file(s), %d against file(s)",
+		len(request.FileDescriptors()),
+		len(request.AgainstFileDescriptors()),
+	)
+	if ruleIDs := request.RuleIDs(); len(ruleIDs) > 0 {
+		_, _ = fmt.Fprintf(buffer, ", rule IDs %s", strings.Join(ruleIDs, ","))
+	}
+	_, _ = fmt.Fprintf(buffer, "\n\n%s\n", stack)
+	return buffer.Bytes()
+}
+
+// pluginVersion returns the module path and version of the plugin binary, if available.
+func pluginVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok || buildInfo.Main.Path == "" {
+		return "unknown"
+	}
+	if buildInfo.Main.Version == "" {
+		return buildInfo.Main.Path
+	}
+	return buildInfo.Main.Path + "@" + buildInfo.Main.Version
+}
@@ -0,0 +1,131 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRuleHandlerPanic(t *testing.T) {
+	t.Parallel()
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(context.Context, ResponseWriter, Request) error {
+			panic("boom")
+		},
+	)
+	spec := &Spec{
+		Rules: []*RuleSpec{
+			ruleSpec,
+			testNewSimpleLintRuleSpec("RULE2", nil, true, false, nil),
+		},
+	}
+	crashReportBuffer := &bytes.Buffer{}
+	server, err := NewServer(spec, ServerWithCrashReportWriter(crashReportBuffer))
+	require.NoError(t, err)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(2))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+
+	// The panic is returned as an error over the protocol.
+	client := NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server)))
+	_, err = client.Check(context.Background(), request)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInternal, pluginrpcError.Code())
+	require.Contains(t, pluginrpcError.Error(), `plugin panicked in rule "RULE1": boom`)
+	crashReport := crashReportBuffer.String()
+	require.Contains(t, crashReport, `panic in rule "RULE1": boom`)
+	require.Contains(t, crashReport, "request: 2 file(s), 0 against file(s)")
+	require.Contains(t, crashReport, "goroutine")
+
+	// Panics are also recovered for in-process Check calls.
+	client, err = NewClientForSpec(spec)
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInternal, pluginrpcError.Code())
+}
+
+func TestRuleHandlerInvalidFileDescriptor(t *testing.T) {
+	t.Parallel()
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, _ ResponseWriter, request Request) error {
+			for _, fileDescriptor := range request.FileDescriptors() {
+				_ = fileDescriptor.ProtoreflectFileDescriptor()
+			}
+			return nil
+		},
+	)
+	spec := &Spec{
+		Rules: []*RuleSpec{
+			ruleSpec,
+		},
+	}
+	crashReportBuffer := &bytes.Buffer{}
+	server, err := NewServer(spec, ServerWithCrashReportWriter(crashReportBuffer))
+	require.NoError(t, err)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:   proto.String("foo.proto"),
+					Syntax: proto.String("proto3"),
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name: proto.String("Foo"),
+							Field: []*descriptorpb.FieldDescriptorProto{
+								{
+									Name:     proto.String("bar"),
+									Number:   proto.Int32(1),
+									Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+									Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+									TypeName: proto.String(".Undefined"),
+									JsonName: proto.String("bar"),
+								},
+							},
+						},
+					},
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+
+	// A file that cannot be built fails the request with CodeInvalidArgument, and is not a crash.
+	client := NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server)))
+	_, err = client.Check(context.Background(), request)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
+	require.Contains(t, pluginrpcError.Error(), `could not build file "foo.proto"`)
+	require.Empty(t, crashReportBuffer.String())
+}
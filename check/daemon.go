@@ -0,0 +1,80 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+
+	"buf.build/go/bufplugin/internal/pkg/streamrpc"
+)
+
+// DaemonClient is a Client for a plugin run as a daemon.
+//
+// The DaemonClient must be closed when no longer needed, which stops the daemon.
+type DaemonClient interface {
+	Client
+
+	// Ping pings the daemon, starting it if it is not running.
+	//
+	// The ping is answered by the daemon without invoking any handlers. Calls to the daemon
+	// are handled sequentially, so Ping waits for any in-progress call to complete. If the
+	// daemon does not respond before the context is done, it is stopped, and is restarted on
+	// the next call. Supervisors can use this to proactively restart a daemon that is hung.
+	Ping(ctx context.Context) error
+	// Close stops the daemon.
+	//
+	// Calls after Close start a new daemon.
+	Close() error
+}
+
+// NewClientForDaemon returns a new DaemonClient that runs the given program as a daemon.
+//
+// The program must call Main, which runs the plugin as a daemon when invoked with --daemon,
+// see DaemonFlagName. The daemon is started on the first call, and is reused for all
+// subsequent calls, so that the cost of starting the plugin is only paid once. Calls are
+// handled sequentially. If the daemon exits, for example because of its idle timeout, it is
+// restarted on the next call.
+//
+// As with NewExecRunner, the daemon is run with no environment variables.
+func NewClientForDaemon(programName string, options ...ClientOption) DaemonClient {
+	processRunner := streamrpc.NewProcessRunner(
+		programName,
+		[]string{"--" + DaemonFlagName},
+		maxResponseSizeForClientOptions(options),
+	)
+	return &daemonClient{
+		Client: NewClient(
+			newPluginrpcClient(processRunner, options),
+			options...,
+		),
+		processRunner: processRunner,
+	}
+}
+
+// *** PRIVATE ***
+
+type daemonClient struct {
+	Client
+
+	processRunner streamrpc.ProcessRunner
+}
+
+func (d *daemonClient) Ping(ctx context.Context) error {
+	return streamrpc.Ping(ctx, d.processRunner)
+}
+
+func (d *daemonClient) Close() error {
+	return d.processRunner.Close()
+}
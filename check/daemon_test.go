@@ -0,0 +1,103 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/internal/pkg/compression"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestMain(m *testing.M) {
+	// Some tests invoke the test binary itself as the plugin.
+	if filepath.Base(os.Args[0]) == testBlockingPluginProgramName {
+		Main(testNewBlockingSpec())
+		return
+	}
+	if filepath.Base(os.Args[0]) == testEnvPluginProgramName {
+		Main(testNewEnvSpec())
+		return
+	}
+	if slices.Equal(os.Args[1:], []string{"--" + DaemonFlagName}) ||
+		slices.Equal(os.Args[1:], []string{"--" + CapabilitiesFlagName}) ||
+		compression.IsCompressionArgs(os.Args[1:]) {
+		Main(testNewFileNameAnnotationSpec())
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func TestDaemon(t *testing.T) {
+	t.Parallel()
+
+	client := NewClientForDaemon(os.Args[0])
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	require.NoError(t, client.Ping(context.Background()))
+	rules, err := client.ListRules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, "RULE1", rules[0].ID())
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:           proto.String("foo.proto"),
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	// The daemon is reused across calls, and restarted after Close.
+	for range 2 {
+		for range 3 {
+			response, err := client.Check(context.Background(), request)
+			require.NoError(t, err)
+			require.Len(t, response.Annotations(), 1)
+			require.Equal(t, "foo.proto", response.Annotations()[0].Message())
+		}
+		require.NoError(t, client.Close())
+	}
+}
+
+func testNewFileNameAnnotationSpec() *Spec {
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			for _, fileDescriptor := range request.FileDescriptors() {
+				responseWriter.AddAnnotation(
+					WithMessage(fileDescriptor.FileDescriptorProto().GetName()),
+					WithFileName(fileDescriptor.FileDescriptorProto().GetName()),
+				)
+			}
+			return nil
+		},
+	)
+	return &Spec{
+		Rules: []*RuleSpec{ruleSpec},
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"buf.build/go/bufplugin/option"
+)
+
+const (
+	// DebugEnvKey is the environment variable that enables debug logging for plugins
+	// that call Main.
+	//
+	// The value is parsed with strconv.ParseBool. If true, records at slog.LevelDebug and
+	// above are written to stderr.
+	DebugEnvKey = "BUFPLUGIN_DEBUG"
+	// LogLevelEnvKey is the environment variable that sets the level of the logging to
+	// stderr for plugins that call Main.
+	//
+	// The value is one of "debug", "info", "warn", or "error", case-insensitive, as parsed by
+	// slog.Level.UnmarshalText. This takes precedence over DebugEnvKey.
+	LogLevelEnvKey = "BUFPLUGIN_LOG_LEVEL"
+)
+
+// *** PRIVATE ***
+
+const redactedOptionValue = "[REDACTED]"
+
+// secretOptionKeySubstrings are the substrings of option keys whose values are redacted
+// when logged.
+var secretOptionKeySubstrings = []string{
+	"secret",
+	"token",
+	"password",
+	"passwd",
+	"credential",
+	"private_key",
+	"api_key",
+	"apikey",
+	"auth",
+}
+
+// newLoggerForEnv returns a new *slog.Logger that writes to the writer at the level
+// given by the environment, or nil if neither DebugEnvKey nor LogLevelEnvKey is set.
+func newLoggerForEnv(writer io.Writer, getenv func(string) string) (*slog.Logger, error) {
+	var level slog.Level
+	if logLevel := getenv(LogLevelEnvKey); logLevel != "" {
+		if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", LogLevelEnvKey, err)
+		}
+	} else if debug := getenv(DebugEnvKey); debug != "" {
+		enabled, err := strconv.ParseBool(debug)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %q", DebugEnvKey, debug)
+		}
+		if !enabled {
+			return nil, nil
+		}
+		level = slog.LevelDebug
+	} else {
+		return nil, nil
+	}
+	return slog.New(slog.NewTextHandler(writer, &slog.HandlerOptions{Level: level})), nil
+}
+
+// redactedOptionsAttr returns a slog.Attr for the options, with the values of keys that
+// appear to be secrets redacted.
+//
+// The options should be logged before environment variables are expanded, so that values
+// that are read from the environment are not logged.
+func redactedOptionsAttr(options option.Options) slog.Attr {
+	var attrs []any
+	options.Range(
+		func(key string, value any) {
+			if isSecretOptionKey(key) {
+				value = redactedOptionValue
+			}
+			attrs = append(attrs, slog.Any(key, value))
+		},
+	)
+	return slog.Group("options", attrs...)
+}
+
+func isSecretOptionKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secretOptionKeySubstring := range secretOptionKeySubstrings {
+		if strings.Contains(key, secretOptionKeySubstring) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLoggerForEnv(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		env          map[string]string
+		expectNil    bool
+		expectErr    bool
+		expectDebug  bool
+		expectWarn   bool
+		expectNoInfo bool
+	}{
+		{env: map[string]string{}, expectNil: true},
+		{env: map[string]string{DebugEnvKey: "false"}, expectNil: true},
+		{env: map[string]string{DebugEnvKey: "1"}, expectDebug: true},
+		{env: map[string]string{DebugEnvKey: "yes please"}, expectErr: true},
+		{env: map[string]string{LogLevelEnvKey: "WARN"}, expectWarn: true, expectNoInfo: true},
+		{env: map[string]string{LogLevelEnvKey: "info", DebugEnvKey: "true"}},
+		{env: map[string]string{LogLevelEnvKey: "verbose"}, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		logger, err := newLoggerForEnv(&bytes.Buffer{}, func(key string) string { return testCase.env[key] })
+		if testCase.expectErr {
+			require.Error(t, err, testCase.env)
+			continue
+		}
+		require.NoError(t, err, testCase.env)
+		if testCase.expectNil {
+			require.Nil(t, logger, testCase.env)
+			continue
+		}
+		require.NotNil(t, logger, testCase.env)
+		ctx := context.Background()
+		require.Equal(t, testCase.expectDebug, logger.Enabled(ctx, slog.LevelDebug), testCase.env)
+		require.Equal(t, !testCase.expectNoInfo, logger.Enabled(ctx, slog.LevelInfo), testCase.env)
+		require.True(t, logger.Enabled(ctx, slog.LevelWarn), testCase.env)
+	}
+}
+
+func TestDebugLoggingRedactsOptions(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	client, err := NewClientForSpec(
+		testNewFileNameAnnotationSpec(),
+		ClientWithLogger(slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	)
+	require.NoError(t, err)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(1))
+	require.NoError(t, err)
+	options, err := option.NewOptions(
+		map[string]any{
+			"api_token": "hunter2",
+			"max_depth": int64(3),
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors, WithOptions(options))
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	require.NoError(t, err)
+	output := buffer.String()
+	require.Contains(t, output, "running rules")
+	require.Contains(t, output, "RULE1")
+	require.Contains(t, output, "options.max_depth=3")
+	require.Contains(t, output, "options.api_token="+redactedOptionValue)
+	require.NotContains(t, output, "hunter2")
+}
@@ -0,0 +1,209 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// NewRunnerWithDiskCache returns a new pluginrpc.Runner that caches the responses of the
+// plugin run by the given pluginrpc.Runner for calls that only return metadata, in files
+// in the given cache directory.
+//
+// The cached calls are ListRules, ListCategories, GetPluginInfo, and the calls to get the
+// protocol version and the pluginrpc.Spec of the plugin. These can only change if the plugin
+// binary changes, so the cache is keyed by the SHA-256 digest of the contents of the binary
+// at programPath, which is computed once, on the first call. Clients for unchanged plugins
+// then do not need to start the plugin to list its Rules, which saves starting a process for
+// each run of a tool such as buf lint. Calls to Check are never cached.
+//
+// The cache directory is created if it does not exist. Failures to read from or write to the
+// cache are not returned, and result in the plugin being invoked.
+//
+//	client := check.NewClient(
+//		pluginrpc.NewClient(
+//			check.NewRunnerWithDiskCache(
+//				pluginrpc.NewExecRunner(programPath),
+//				programPath,
+//				filepath.Join(userCacheDir, "bufplugin"),
+//			),
+//		),
+//	)
+func NewRunnerWithDiskCache(runner pluginrpc.Runner, programPath string, cacheDirPath string) pluginrpc.Runner {
+	return &diskCacheRunner{
+		delegate:     runner,
+		programPath:  programPath,
+		cacheDirPath: cacheDirPath,
+	}
+}
+
+// *** PRIVATE ***
+
+// diskCacheVersion is the version of the format of the cache, which is part of every key.
+//
+// This must be changed if the format of cache files changes.
+const diskCacheVersion = "v1"
+
+type diskCacheRunner struct {
+	delegate     pluginrpc.Runner
+	programPath  string
+	cacheDirPath string
+
+	// digest is the digest of the plugin binary.
+	//
+	// The digest is only computed once, so that it is not computed on every call.
+	digest    string
+	digestErr error
+	lock      sync.Mutex
+}
+
+func (d *diskCacheRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	if !isDiskCacheableArgs(env.Args) {
+		return d.delegate.Run(ctx, env)
+	}
+	var stdin []byte
+	if env.Stdin != nil {
+		var err error
+		stdin, err = io.ReadAll(env.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+	runEnv := pluginrpc.Env{
+		Args:   env.Args,
+		Stdout: env.Stdout,
+		Stderr: env.Stderr,
+	}
+	if env.Stdin != nil {
+		runEnv.Stdin = bytes.NewReader(stdin)
+	}
+	digest, err := d.getDigest()
+	if err != nil {
+		// The plugin is invoked without the cache, and reports its own error if the binary
+		// does not exist.
+		return d.delegate.Run(ctx, runEnv)
+	}
+	cacheFilePath := filepath.Join(d.cacheDirPath, diskCacheKey(digest, env.Args, stdin))
+	if stdout, err := os.ReadFile(cacheFilePath); err == nil {
+		if env.Stdout != nil {
+			_, err = env.Stdout.Write(stdout)
+		}
+		return err
+	}
+	var stdout bytes.Buffer
+	runEnv.Stdout = &stdout
+	if err := d.delegate.Run(ctx, runEnv); err != nil {
+		if env.Stdout != nil {
+			_, _ = env.Stdout.Write(stdout.Bytes())
+		}
+		return err
+	}
+	// Failures to write to the cache result in the plugin being invoked on the next call.
+	_ = writeFileAtomic(d.cacheDirPath, cacheFilePath, stdout.Bytes())
+	if env.Stdout != nil {
+		_, err = env.Stdout.Write(stdout.Bytes())
+	}
+	return err
+}
+
+func (d *diskCacheRunner) getDigest() (string, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.digest == "" && d.digestErr == nil {
+		d.digest, d.digestErr = fileSHA256Digest(d.programPath)
+	}
+	return d.digest, d.digestErr
+}
+
+// isDiskCacheableArgs returns true if the invocation with the given args only returns
+// metadata that is determined by the plugin binary.
+func isDiskCacheableArgs(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "--" + pluginrpc.ProtocolFlagName,
+		"--" + pluginrpc.SpecFlagName,
+		"list-rules",
+		"list-categories",
+		listPoliciesArg,
+		"info",
+		"info-extension":
+		return true
+	default:
+		return false
+	}
+}
+
+// diskCacheKey returns the name of the cache file for an invocation.
+func diskCacheKey(digest string, args []string, stdin []byte) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, diskCacheVersion+"\x00"+digest+"\x00")
+	for _, arg := range args {
+		_, _ = io.WriteString(hash, arg+"\x00")
+	}
+	_, _ = hash.Write(stdin)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func fileSHA256Digest(filePath string) (_ string, retErr error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		retErr = errors.Join(retErr, file.Close())
+	}()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeFileAtomic writes the file by writing to a temporary file in the directory and
+// renaming it, so that concurrent readers never see a partially-written file.
+func writeFileAtomic(dirPath string, filePath string, data []byte) (retErr error) {
+	if err := os.MkdirAll(dirPath, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dirPath, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			_ = os.Remove(file.Name())
+		}
+	}()
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), filePath)
+}
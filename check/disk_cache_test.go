@@ -0,0 +1,123 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"buf.build/go/bufplugin/info"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRunnerWithDiskCache(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+	programPath := filepath.Join(t.TempDir(), "buf-plugin-test")
+	require.NoError(t, os.WriteFile(programPath, []byte("v1"), 0o600))
+	cacheDirPath := filepath.Join(t.TempDir(), "cache")
+
+	newClient := func() (Client, *testRecordingRunner) {
+		recordingRunner := &testRecordingRunner{run: serverRunner.Run}
+		return NewClient(
+			pluginrpc.NewClient(
+				NewRunnerWithDiskCache(recordingRunner, programPath, cacheDirPath),
+			),
+		), recordingRunner
+	}
+	listRules := func(client Client) {
+		rules, err := client.ListRules(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		require.Equal(t, "RULE1", rules[0].ID())
+	}
+
+	client, recordingRunner := newClient()
+	listRules(client)
+	require.NotEmpty(t, recordingRunner.allArgs)
+
+	// A new Client for the same binary does not invoke the plugin for metadata.
+	client, recordingRunner = newClient()
+	listRules(client)
+	_, err = client.GetPluginInfo(context.Background())
+	require.True(t, isUnimplementedError(err))
+	require.Empty(t, recordingRunner.allArgs)
+	// Check is never cached.
+	testCheckFileName(t, client)
+	require.Len(t, recordingRunner.allArgs, 1)
+	require.Equal(t, "check", recordingRunner.allArgs[0][0])
+
+	// Changing the binary invalidates the cache.
+	require.NoError(t, os.WriteFile(programPath, []byte("v2"), 0o600))
+	client, recordingRunner = newClient()
+	listRules(client)
+	require.NotEmpty(t, recordingRunner.allArgs)
+}
+
+func TestRunnerWithDiskCacheWarm(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewFileNameAnnotationSpec()
+	spec.Rules[0].Policy = &PolicySpec{
+		ID:       "POLICY1",
+		Severity: SeverityWarning,
+	}
+	spec.Info = &info.Spec{
+		SPDXLicenseID: "apache-2.0",
+		LicenseURL:    "https://foo.com/license",
+	}
+	server, err := NewServer(spec)
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+	programPath := filepath.Join(t.TempDir(), "buf-plugin-test")
+	require.NoError(t, os.WriteFile(programPath, []byte("v1"), 0o600))
+	cacheDirPath := filepath.Join(t.TempDir(), "cache")
+
+	run := func() *testRecordingRunner {
+		recordingRunner := &testRecordingRunner{run: serverRunner.Run}
+		client := NewClient(
+			pluginrpc.NewClient(
+				NewRunnerWithDiskCache(recordingRunner, programPath, cacheDirPath),
+			),
+		)
+		rules, err := client.ListRules(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		require.Equal(t, "POLICY1", rules[0].Policy().ID())
+		categories, err := client.ListCategories(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, categories)
+		pluginInfo, err := client.GetPluginInfo(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "Apache-2.0", pluginInfo.License().SPDXLicenseID())
+		return recordingRunner
+	}
+
+	recordingRunner := run()
+	var commands []string
+	for _, args := range recordingRunner.allArgs {
+		commands = append(commands, args[0])
+	}
+	require.Contains(t, commands, "info-extension")
+	require.Contains(t, commands, listPoliciesArg)
+	// A warm cache results in the plugin not being invoked at all.
+	require.Empty(t, run().allArgs)
+}
@@ -0,0 +1,203 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"unicode/utf8"
+
+	"buf.build/go/bufplugin/info"
+	"buf.build/go/bufplugin/option"
+	"pluginrpc.com/pluginrpc"
+)
+
+// PluginDocumentation is all documentation for a plugin: its PluginInfo, Rules, and Categories.
+//
+// This contains everything needed to build a complete documentation page for a plugin.
+// Clients can get a PluginDocumentation in one call with GetPluginDocumentation.
+//
+// PluginDocumentations returned from NewPluginDocumentationForSpec have all properties of
+// the PluginInfo, Rules, and Categories populated, including those that are not transmitted
+// over the wire, such as Rule.Documentation.
+type PluginDocumentation interface {
+	// PluginInfo returns the PluginInfo for the plugin.
+	//
+	// Optional. Will be nil if the plugin does not implement the PluginInfoService.
+	PluginInfo() info.PluginInfo
+	// Rules returns the Rules for the plugin, sorted by Rule ID.
+	//
+	// Always present.
+	Rules() []Rule
+	// Categories returns the Categories for the plugin, sorted by Category ID.
+	//
+	// Optional.
+	Categories() []Category
+	// OptionsSchema returns the schema for the options that the plugin accepts.
+	//
+	// Optional. Use option.SchemaToJSONSchema to produce a JSON Schema document that editors
+	// and configuration validators can use.
+	//
+	// Not transmitted over the wire, and will therefore be nil on PluginDocumentations
+	// returned from GetPluginDocumentation. Plugins invoked by Main print the JSON Schema
+	// document for their options when given the --options-json-schema flag.
+	OptionsSchema() *option.Schema
+
+	isPluginDocumentation()
+}
+
+// NewPluginDocumentationForSpec returns a new PluginDocumentation for the given Spec.
+//
+// The Spec will be validated.
+func NewPluginDocumentationForSpec(spec *Spec) (PluginDocumentation, error) {
+	checkServiceHandler, err := newCheckServiceHandler(spec)
+	if err != nil {
+		return nil, err
+	}
+	var pluginInfo info.PluginInfo
+	if spec.Info != nil {
+		pluginInfo, err = info.NewPluginInfoForSpec(spec.Info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newPluginDocumentation(
+		pluginInfo,
+		checkServiceHandler.rules,
+		checkServiceHandler.categories,
+		spec.Options,
+	), nil
+}
+
+// *** PRIVATE ***
+
+type pluginDocumentation struct {
+	pluginInfo    info.PluginInfo
+	rules         []Rule
+	categories    []Category
+	optionsSchema *option.Schema
+}
+
+func newPluginDocumentation(
+	pluginInfo info.PluginInfo,
+	rules []Rule,
+	categories []Category,
+	optionsSchema *option.Schema,
+) *pluginDocumentation {
+	return &pluginDocumentation{
+		pluginInfo:    pluginInfo,
+		rules:         rules,
+		categories:    categories,
+		optionsSchema: optionsSchema,
+	}
+}
+
+func (p *pluginDocumentation) PluginInfo() info.PluginInfo {
+	return p.pluginInfo
+}
+
+func (p *pluginDocumentation) Rules() []Rule {
+	return slices.Clone(p.rules)
+}
+
+func (p *pluginDocumentation) Categories() []Category {
+	return slices.Clone(p.categories)
+}
+
+func (p *pluginDocumentation) OptionsSchema() *option.Schema {
+	return p.optionsSchema
+}
+
+func (*pluginDocumentation) isPluginDocumentation() {}
+
+// documentationProperties are the documentation properties shared by Rules and Categories.
+//
+// These are not part of the v1 CheckService protocol, and are therefore only populated
+// from RuleSpecs and CategorySpecs.
+type documentationProperties struct {
+	documentation    string
+	examples         []string
+	documentationURL *url.URL
+}
+
+// Assumes that the fields are validated.
+func newDocumentationProperties(
+	documentation string,
+	examples []string,
+	documentationURL string,
+) (documentationProperties, error) {
+	var parsedDocumentationURL *url.URL
+	if documentationURL != "" {
+		var err error
+		parsedDocumentationURL, err = url.Parse(documentationURL)
+		if err != nil {
+			return documentationProperties{}, err
+		}
+	}
+	return documentationProperties{
+		documentation:    documentation,
+		examples:         slices.Clone(examples),
+		documentationURL: parsedDocumentationURL,
+	}, nil
+}
+
+func (d documentationProperties) Documentation() string {
+	return d.documentation
+}
+
+func (d documentationProperties) Examples() []string {
+	return slices.Clone(d.examples)
+}
+
+func (d documentationProperties) DocumentationURL() *url.URL {
+	return d.documentationURL
+}
+
+func validateDocumentationFields(
+	id string,
+	documentation string,
+	examples []string,
+	documentationURL string,
+) error {
+	if !utf8.ValidString(documentation) {
+		return fmt.Errorf("Documentation for ID %q must be valid UTF-8", id)
+	}
+	for i, example := range examples {
+		if example == "" {
+			return fmt.Errorf("Examples for ID %q has an empty value at index %d", id, i)
+		}
+		if !utf8.ValidString(example) {
+			return fmt.Errorf("Examples for ID %q has a value that is not valid UTF-8 at index %d", id, i)
+		}
+	}
+	if documentationURL != "" {
+		parsedDocumentationURL, err := url.Parse(documentationURL)
+		if err != nil {
+			return fmt.Errorf("DocumentationURL %q for ID %q is invalid: %w", documentationURL, id, err)
+		}
+		if !parsedDocumentationURL.IsAbs() {
+			return fmt.Errorf("DocumentationURL %q for ID %q must be absolute", documentationURL, id)
+		}
+	}
+	return nil
+}
+
+// isUnimplementedError returns true if the error is a pluginrpc.Error with CodeUnimplemented.
+func isUnimplementedError(err error) bool {
+	pluginrpcError := &pluginrpc.Error{}
+	return errors.As(err, &pluginrpcError) && pluginrpcError.Code() == pluginrpc.CodeUnimplemented
+}
@@ -0,0 +1,207 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// NewExecRunner returns a new pluginrpc.Runner that uses os/exec to run the given program
+// as the plugin.
+//
+// This is the same as pluginrpc.NewExecRunner, except for how the plugin is stopped when the
+// context is cancelled. pluginrpc.NewExecRunner kills the plugin immediately, which does not
+// give the plugin a chance to clean up, and leaves any processes that the plugin started
+// running. Instead, the plugin is sent an interrupt signal, which cancels the call for plugins
+// that call Main, and is killed if it has not exited after a grace period, see
+// ExecRunnerWithCancelGracePeriod. On Unix platforms, the plugin is run in its own process
+// group, and the signals are sent to the process group, so that processes started by the
+// plugin are also stopped. As a result, the plugin does not receive signals sent to the
+// process group of the current process, such as from pressing Ctrl-C in a terminal. On
+// platforms that do not support sending an interrupt signal, such as Windows, the plugin is
+// killed immediately.
+//
+// If the context is cancelled, the error of the context is returned. As with
+// pluginrpc.NewExecRunner, the plugin is run with no environment variables, other than those
+// forwarded with ExecRunnerWithForwardedEnv. Plugins can be further restricted with
+// ExecRunnerWithSandbox.
+func NewExecRunner(programName string, options ...ExecRunnerOption) pluginrpc.Runner {
+	execRunnerOptions := newExecRunnerOptions()
+	for _, option := range options {
+		option(execRunnerOptions)
+	}
+	return &execRunner{
+		programName:       programName,
+		args:              execRunnerOptions.args,
+		cancelGracePeriod: execRunnerOptions.cancelGracePeriod,
+		forwardedEnvNames: execRunnerOptions.forwardedEnvNames,
+		sandbox:           execRunnerOptions.sandbox,
+	}
+}
+
+// ExecRunnerOption is an option for a new exec pluginrpc.Runner.
+type ExecRunnerOption func(*execRunnerOptions)
+
+// ExecRunnerWithArgs returns a new ExecRunnerOption that specifies a sub-command to invoke
+// on the program.
+//
+// See pluginrpc.ExecRunnerWithArgs.
+func ExecRunnerWithArgs(args ...string) ExecRunnerOption {
+	return func(execRunnerOptions *execRunnerOptions) {
+		execRunnerOptions.args = args
+	}
+}
+
+// ExecRunnerWithCancelGracePeriod returns a new ExecRunnerOption that sets the duration
+// that the plugin has to exit after it is sent an interrupt signal because the context was
+// cancelled, before it is killed.
+//
+// The default is 5 seconds. A value <= 0 results in the plugin being killed immediately.
+func ExecRunnerWithCancelGracePeriod(cancelGracePeriod time.Duration) ExecRunnerOption {
+	return func(execRunnerOptions *execRunnerOptions) {
+		execRunnerOptions.cancelGracePeriod = cancelGracePeriod
+	}
+}
+
+// ExecRunnerWithForwardedEnv returns a new ExecRunnerOption that forwards the environment
+// variables with the given names from the current process to the plugin.
+//
+// Plugins are otherwise run with no environment variables, so this is required for
+// references to environment variables in the values of options with option.KeySpec.ExpandEnv
+// set to be expanded by the plugin. Only forward the variables that the plugin is expected
+// to reference. Variables that are not set in the current process are not forwarded. The
+// variables are looked up on each invocation, and values in Sandbox.Env take precedence.
+//
+// The default is to forward no environment variables.
+func ExecRunnerWithForwardedEnv(names ...string) ExecRunnerOption {
+	return func(execRunnerOptions *execRunnerOptions) {
+		execRunnerOptions.forwardedEnvNames = append(execRunnerOptions.forwardedEnvNames, names...)
+	}
+}
+
+// *** PRIVATE ***
+
+const defaultCancelGracePeriod = 5 * time.Second
+
+type execRunner struct {
+	programName       string
+	args              []string
+	cancelGracePeriod time.Duration
+	forwardedEnvNames []string
+	sandbox           Sandbox
+}
+
+func (e *execRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	parentCtx := ctx
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	cmd := exec.CommandContext(ctx, e.programName, append(slices.Clone(e.args), env.Args...)...) //nolint:gosec
+	cmd.Env = e.env()
+	cmd.Dir = e.sandbox.Dir
+	// Nil values for stdio result in the null device.
+	cmd.Stdin = env.Stdin
+	cmd.Stdout = env.Stdout
+	cmd.Stderr = env.Stderr
+	if e.sandbox.MaxOutputSize > 0 {
+		// The plugin is stopped once the limit is exceeded, as it would otherwise block on
+		// writing to a pipe that is no longer read from.
+		outputLimiter := newOutputLimiter(e.sandbox.MaxOutputSize, cancel)
+		cmd.Stdout = outputLimiter.newWriter(env.Stdout)
+		cmd.Stderr = outputLimiter.newWriter(env.Stderr)
+	}
+	// killTimer is set by cmd.Cancel, which has always returned by the time cmd.Wait returns.
+	var killTimer *time.Timer
+	cmd.Cancel = func() error {
+		if e.cancelGracePeriod <= 0 {
+			return killProcessGroup(cmd.Process)
+		}
+		if err := interruptProcessGroup(cmd.Process); err != nil {
+			return killProcessGroup(cmd.Process)
+		}
+		// os/exec only kills the plugin itself after WaitDelay, processes started by the
+		// plugin are killed here. If the process group has already exited, this is a no-op.
+		killTimer = time.AfterFunc(e.cancelGracePeriod, func() { _ = killProcessGroup(cmd.Process) })
+		return nil
+	}
+	if e.cancelGracePeriod > 0 {
+		// The plugin is killed if it has not exited after the grace period. This also
+		// stops waiting on stdio if processes started by the plugin hold it open.
+		cmd.WaitDelay = e.cancelGracePeriod
+	}
+	if err := e.sandbox.apply(cmd); err != nil {
+		return err
+	}
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		// Do not keep the timer or the process around once the plugin has exited.
+		if killTimer != nil {
+			killTimer.Stop()
+		}
+	}()
+	if err := cmd.Wait(); err != nil {
+		if causeErr := contextCauseError(ctx, parentCtx); causeErr != nil {
+			return causeErr
+		}
+		if ctxErr := parentCtx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		exitError := &exec.ExitError{}
+		if errors.As(err, &exitError) {
+			return pluginrpc.NewExitError(exitError.ExitCode(), exitError)
+		}
+		return err
+	}
+	return nil
+}
+
+// env returns the environment of the plugin.
+func (e *execRunner) env() []string {
+	var env []string
+	for _, name := range e.forwardedEnvNames {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	// os/exec uses the last value of each variable, so values in the Sandbox take precedence.
+	env = append(env, e.sandbox.Env...)
+	if len(env) == 0 {
+		// Match pluginrpc: the plugin has access to no environment variables.
+		return []string{"__EMPTY_ENV=1"}
+	}
+	return env
+}
+
+type execRunnerOptions struct {
+	args              []string
+	cancelGracePeriod time.Duration
+	forwardedEnvNames []string
+	sandbox           Sandbox
+}
+
+func newExecRunnerOptions() *execRunnerOptions {
+	return &execRunnerOptions{
+		cancelGracePeriod: defaultCancelGracePeriod,
+	}
+}
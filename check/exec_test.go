@@ -0,0 +1,176 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+// testBlockingPluginProgramName is the name of a link to the test binary that runs
+// the Spec returned by testNewBlockingSpec.
+const testBlockingPluginProgramName = "test-blocking-plugin"
+
+// testEnvPluginProgramName is the name of a link to the test binary that runs the Spec
+// returned by testNewEnvSpec.
+const testEnvPluginProgramName = "test-env-plugin"
+
+// testEnvVarName is the name of the environment variable referenced by the tests that
+// use testNewEnvSpec.
+const testEnvVarName = "BUFPLUGIN_TEST_TOKEN"
+
+func TestExecRunnerCancel(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupt signals are not supported on Windows")
+	}
+	client := NewClient(
+		pluginrpc.NewClient(
+			NewExecRunner(
+				testNewBlockingPluginProgramName(t),
+				// The plugin exits because of the interrupt, long before it would be killed.
+				ExecRunnerWithCancelGracePeriod(time.Minute),
+			),
+		),
+	)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(1))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	// ListRules is not blocked, and makes sure that the plugin has started.
+	_, err = client.ListRules(context.Background())
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Check(ctx, request)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 30*time.Second)
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestExecRunnerWithForwardedEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugin is a symlink, which is not supported on Windows")
+	}
+	t.Setenv(testEnvVarName, "secret")
+	programName := testNewPluginProgramName(t, testEnvPluginProgramName)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(1))
+	require.NoError(t, err)
+	options, err := option.NewOptions(map[string]any{"token": "${" + testEnvVarName + "}"})
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors, WithOptions(options))
+	require.NoError(t, err)
+
+	// The plugin is run with an empty environment by default, so the reference cannot be
+	// expanded, even though the variable is set in the current process.
+	_, err = NewClient(pluginrpc.NewClient(NewExecRunner(programName))).Check(context.Background(), request)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
+	require.ErrorContains(t, err, `environment variable "`+testEnvVarName+`" is not set`)
+
+	response, err := NewClient(
+		pluginrpc.NewClient(
+			NewExecRunner(programName, ExecRunnerWithForwardedEnv(testEnvVarName, "BUFPLUGIN_TEST_UNSET")),
+		),
+	).Check(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 1)
+	require.Equal(t, "secret", response.Annotations()[0].Message())
+
+	// Values in the Sandbox take precedence over forwarded values.
+	response, err = NewClient(
+		pluginrpc.NewClient(
+			NewExecRunner(
+				programName,
+				ExecRunnerWithForwardedEnv(testEnvVarName),
+				ExecRunnerWithSandbox(Sandbox{Env: []string{testEnvVarName + "=override"}}),
+			),
+		),
+	).Check(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 1)
+	require.Equal(t, "override", response.Annotations()[0].Message())
+}
+
+// testNewBlockingPluginProgramName returns the path of a new link to the test binary that
+// runs the Spec returned by testNewBlockingSpec.
+func testNewBlockingPluginProgramName(t *testing.T) string {
+	return testNewPluginProgramName(t, testBlockingPluginProgramName)
+}
+
+// testNewPluginProgramName returns the path of a new link with the given name to the
+// test binary.
+func testNewPluginProgramName(t *testing.T, name string) string {
+	programName := filepath.Join(t.TempDir(), name)
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	require.NoError(t, os.Symlink(executable, programName))
+	return programName
+}
+
+// testNewBlockingSpec returns a new Spec with a Rule that blocks until the context is done.
+func testNewBlockingSpec() *Spec {
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(ctx context.Context, _ ResponseWriter, _ Request) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	)
+	return &Spec{
+		Rules: []*RuleSpec{ruleSpec},
+	}
+}
+
+// testNewEnvSpec returns a new Spec with a Rule that adds an annotation with the value of
+// the "token" option, which has ExpandEnv set.
+func testNewEnvSpec() *Spec {
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			token, err := option.GetStringValue(request.Options(), "token")
+			if err != nil {
+				return err
+			}
+			responseWriter.AddAnnotation(WithMessage(token))
+			return nil
+		},
+	)
+	return &Spec{
+		Rules: []*RuleSpec{ruleSpec},
+		Options: &option.Schema{
+			Keys: []*option.KeySpec{
+				{
+					Key:       "token",
+					Type:      option.TypeString,
+					Secret:    true,
+					ExpandEnv: true,
+				},
+			},
+		},
+	}
+}
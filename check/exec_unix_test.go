@@ -0,0 +1,65 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package check
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestExecRunnerCancelProcessGroup(t *testing.T) {
+	t.Parallel()
+
+	// The plugin starts a process that ignores the interrupt signal, and writes its PID.
+	runner := NewExecRunner(
+		"/bin/sh",
+		ExecRunnerWithArgs("-c", "trap '' INT; /bin/sleep 60 & echo $!; wait"),
+		ExecRunnerWithCancelGracePeriod(100*time.Millisecond),
+	)
+	stdoutReader, stdoutWriter := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	runErrC := make(chan error, 1)
+	go func() {
+		runErrC <- runner.Run(ctx, pluginrpc.Env{Stdout: stdoutWriter})
+		_ = stdoutWriter.Close()
+	}()
+	line, err := bufio.NewReader(stdoutReader).ReadString('\n')
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(line))
+	require.NoError(t, err)
+	go func() { _, _ = io.Copy(io.Discard, stdoutReader) }()
+	cancel()
+	require.ErrorIs(t, <-runErrC, context.Canceled)
+	require.Eventually(
+		t,
+		func() bool {
+			return errors.Is(syscall.Kill(pid, 0), syscall.ESRCH)
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+}
@@ -0,0 +1,95 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestMaxFileDescriptors(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(5))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors, WithAgainstFileDescriptors(fileDescriptors[:2]))
+	require.NoError(t, err)
+
+	testCheck := func(maxFileDescriptors int) error {
+		server, err := NewServer(testNewFileNameAnnotationSpec(), ServerWithMaxFileDescriptors(maxFileDescriptors))
+		require.NoError(t, err)
+		client := NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server)))
+		_, err = client.Check(context.Background(), request)
+		return err
+	}
+
+	require.NoError(t, testCheck(0))
+	require.NoError(t, testCheck(5))
+	err = testCheck(4)
+	require.Error(t, err)
+	require.Equal(t, pluginrpc.CodeResourceExhausted, pluginrpc.WrapError(err).Code())
+	require.Contains(t, err.Error(), "request has 5 FileDescriptors, which exceeds the maximum of 4")
+}
+
+func TestMaxConcurrentChecks(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(context.Context, ResponseWriter, Request) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+	)
+	checkServiceHandler, err := newCheckServiceHandler(
+		&Spec{Rules: []*RuleSpec{ruleSpec}},
+		CheckServiceHandlerWithMaxConcurrentChecks(1),
+	)
+	require.NoError(t, err)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(1))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	checkRequests, err := request.toProtos()
+	require.NoError(t, err)
+	require.Len(t, checkRequests, 1)
+
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := checkServiceHandler.Check(context.Background(), checkRequests[0])
+		firstDone <- err
+	}()
+	<-started
+
+	// The second Check waits for the first to complete, and gives up when its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = checkServiceHandler.Check(ctx, checkRequests[0])
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Empty(t, started)
+
+	close(release)
+	require.NoError(t, <-firstDone)
+	_, err = checkServiceHandler.Check(context.Background(), checkRequests[0])
+	require.NoError(t, err)
+}
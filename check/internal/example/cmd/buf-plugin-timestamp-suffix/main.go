@@ -48,7 +48,6 @@ import (
 	"buf.build/go/bufplugin/check"
 	"buf.build/go/bufplugin/check/checkutil"
 	"buf.build/go/bufplugin/info"
-	"buf.build/go/bufplugin/option"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -97,11 +96,11 @@ func checkTimestampSuffix(
 	fieldDescriptor protoreflect.FieldDescriptor,
 ) error {
 	timestampSuffix := defaultTimestampSuffix
-	timestampSuffixOptionValue, err := option.GetStringValue(request.Options(), timestampSuffixOptionKey)
+	timestampSuffixOptionValue, ok, err := request.Options().GetString(timestampSuffixOptionKey)
 	if err != nil {
 		return err
 	}
-	if timestampSuffixOptionValue != "" {
+	if ok {
 		timestampSuffix = timestampSuffixOptionValue
 	}
 
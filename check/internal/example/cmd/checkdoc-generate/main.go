@@ -0,0 +1,60 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main implements a command that generates Markdown documentation for a plugin.
+//
+// This demonstrates the checkdoc package against a compiled plugin binary:
+//
+//	checkdoc-generate path/to/buf-plugin-timestamp-suffix path/to/docs
+//
+// Properties that are not transmitted over the wire, such as per-Rule documentation,
+// are not available from a binary. Plugin authors that want these in their published
+// documentation should call checkdoc.WriteMarkdownFiles with the result of
+// check.NewPluginDocumentationForSpec from a test or a go:generate command that has
+// access to their Spec.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/check/checkdoc"
+	"pluginrpc.com/pluginrpc"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: %s <plugin-binary> <output-dir>", filepath.Base(os.Args[0]))
+	}
+	client := check.NewClient(pluginrpc.NewClient(pluginrpc.NewExecRunner(args[0])))
+	pluginDocumentation, err := client.GetPluginDocumentation(ctx)
+	if err != nil {
+		return err
+	}
+	return checkdoc.WriteMarkdownFiles(
+		args[1],
+		pluginDocumentation,
+		checkdoc.MarkdownWithTitle(filepath.Base(args[0])),
+	)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Logger returns the *slog.Logger for the given context.
+//
+// Within RuleHandlers and Spec.Before, this is the logger configured with
+// CheckServiceHandlerWithLogger, ServerWithLogger, MainWithLogger, or ClientWithLogger for
+// Clients created with NewClientForSpec. Within RuleHandlers, records have the ID of the
+// Rule as the "rule_id" attribute. Plugins must not write to stdout, as stdout is used for
+// the responses of the plugin, and should log with this logger instead.
+//
+// If no logger was configured, a logger that discards all records is returned.
+func Logger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return discardLogger
+}
+
+// *** PRIVATE ***
+
+var discardLogger = slog.New(discardHandler{})
+
+type loggerContextKey struct{}
+
+func contextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
@@ -0,0 +1,53 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	require.NotNil(t, Logger(context.Background()))
+	Logger(context.Background()).Info("discarded")
+
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.Handler = RuleHandlerFunc(
+		func(ctx context.Context, _ ResponseWriter, request Request) error {
+			Logger(ctx).Info("checking", slog.Int("files", len(request.FileDescriptors())))
+			return nil
+		},
+	)
+	var buffer bytes.Buffer
+	client, err := NewClientForSpec(
+		&Spec{Rules: []*RuleSpec{ruleSpec}},
+		ClientWithLogger(slog.New(slog.NewTextHandler(&buffer, nil))),
+	)
+	require.NoError(t, err)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(2))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	require.NoError(t, err)
+	require.Contains(t, buffer.String(), "msg=checking rule_id=RULE1 files=2")
+}
@@ -15,12 +15,61 @@
 package check
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	"buf.build/go/bufplugin/internal/pkg/compression"
+	"buf.build/go/bufplugin/internal/pkg/streamrpc"
+	"buf.build/go/bufplugin/option"
 	"pluginrpc.com/pluginrpc"
 )
 
+const defaultDaemonIdleTimeout = 5 * time.Minute
+
+// OptionsJSONSchemaFlagName is the name of the flag that makes Main print the JSON Schema
+// document for the options of the plugin to stdout and exit.
+//
+// See option.SchemaToJSONSchema. If the Spec has no Options schema, the document accepts
+// any options.
+const OptionsJSONSchemaFlagName = "options-json-schema"
+
+// DaemonFlagName is the name of the flag that makes Main run the plugin as a daemon.
+//
+// A daemon serves calls sequentially on stdin and stdout, instead of handling a single call
+// and exiting. The daemon exits when stdin is closed, or when no call has been received for
+// the idle timeout, see MainWithDaemonIdleTimeout. Use NewClientForDaemon to create a Client
+// for a plugin run as a daemon. A daemon also answers pings, see DaemonClient.Ping.
+const DaemonFlagName = "daemon"
+
 // Main is the main entrypoint for a plugin that implements the given Spec.
 //
 // A plugin just needs to provide a Spec, and then call this function within main.
+// Warnings, such as deprecation warnings for option aliases, are written to stderr.
+// Stdout is reserved for the responses of the plugin: os.Stdout is set to os.Stderr, so
+// that output written to os.Stdout by RuleHandlers or libraries does not corrupt responses.
+// If the only argument is --options-json-schema, the JSON Schema document for the options
+// of the plugin is printed instead. See OptionsJSONSchemaFlagName. If the only argument
+// is --catalog, the Catalog of the plugin is printed as JSON. See CatalogFlagName. If the
+// only argument is --daemon, the plugin is run as a daemon. See DaemonFlagName. If the only
+// argument is --capabilities, the Capabilities of the plugin are printed. See
+// CapabilitiesFlagName.
+// Compressed requests from Clients using NewRunnerWithCompression are also handled.
+//
+// If a RuleHandler panics, the call fails with CodeInternal, and a crash report with the
+// stack of the panic, the version of the plugin, and a summary of the request is written
+// to stderr. See CheckServiceHandlerWithCrashReportWriter.
+//
+// If MainWithLogger is not set, and DebugEnvKey or LogLevelEnvKey is set in the environment,
+// records are written to stderr, including the size of each request, the Rules that are run,
+// and the options, with the values of options that appear to be secrets redacted. Note that
+// pluginrpc.NewExecRunner runs plugins with an empty environment, so these must be set by the
+// caller for plugins invoked by other programs.
 //
 //	func main() {
 //		check.Main(
@@ -42,14 +91,75 @@ func Main(spec *Spec, options ...MainOption) {
 	for _, option := range options {
 		option(mainOptions)
 	}
-	pluginrpc.Main(
-		func() (pluginrpc.Server, error) {
-			return NewServer(
-				spec,
-				ServerWithParallelism(mainOptions.parallelism),
-			)
-		},
-	)
+	// Writes to stdout by the plugin, or by libraries that it uses, would corrupt the
+	// responses of the plugin, so they are sent to stderr instead. Responses are written
+	// to the original stdout, which pluginrpc.OSEnv also uses.
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	if slices.Equal(os.Args[1:], []string{"--" + OptionsJSONSchemaFlagName}) {
+		if err := writeOptionsJSONSchema(stdout, spec); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+	if slices.Equal(os.Args[1:], []string{"--" + CatalogFlagName}) {
+		if err := writeCatalog(stdout, spec); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+	if mainOptions.logger == nil {
+		logger, err := newLoggerForEnv(os.Stderr, os.Getenv)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		mainOptions.logger = logger
+	}
+	newServer := func() (pluginrpc.Server, error) {
+		serverOptions := []ServerOption{
+			ServerWithParallelism(mainOptions.parallelism),
+			ServerWithWarningWriter(os.Stderr),
+			ServerWithCrashReportWriter(os.Stderr),
+			ServerWithOptionLimits(mainOptions.optionLimits),
+			ServerWithMessageSizeLimits(mainOptions.messageSizeLimits),
+			ServerWithMaxFileDescriptors(mainOptions.maxFileDescriptors),
+			ServerWithLogger(mainOptions.logger),
+			ServerWithTracer(mainOptions.tracer),
+			ServerWithMetrics(mainOptions.metrics),
+		}
+		if mainOptions.descriptorInterning {
+			serverOptions = append(serverOptions, ServerWithDescriptorInterning())
+		}
+		serverOptions = append(serverOptions, mainOptions.serverOptions...)
+		return NewServer(spec, serverOptions...)
+	}
+	if slices.Equal(os.Args[1:], []string{"--" + CapabilitiesFlagName}) {
+		if err := runCapabilities(newServer, stdout); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+	if slices.Equal(os.Args[1:], []string{"--" + DaemonFlagName}) {
+		if err := runDaemon(newServer, stdout, mainOptions.daemonIdleTimeout, mainOptions.messageSizeLimits.MaxRequestSize); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+	if compression.IsCompressionArgs(os.Args[1:]) {
+		if err := runCompressed(newServer); err != nil {
+			if errString := err.Error(); errString != "" {
+				_, _ = fmt.Fprintln(os.Stderr, errString)
+			}
+			os.Exit(pluginrpc.WrapExitError(err).ExitCode())
+		}
+		return
+	}
+	pluginrpc.Main(newServer)
 }
 
 // MainOption is an option for Main.
@@ -71,12 +181,204 @@ func MainWithParallelism(parallelism int) MainOption {
 	}
 }
 
+// MainWithOptionLimits returns a new MainOption that sets the limits on the options
+// of a request.
+//
+// The default is option.DefaultLimits. Use option.Limits{} to remove all limits.
+func MainWithOptionLimits(optionLimits option.Limits) MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.optionLimits = optionLimits
+	}
+}
+
+// MainWithDescriptorInterning returns a new MainOption that interns the FileDescriptors
+// of each request before they are used.
+//
+// This is useful for plugins that are run against a large number of files.
+// See CheckServiceHandlerWithDescriptorInterning for details.
+func MainWithDescriptorInterning() MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.descriptorInterning = true
+	}
+}
+
+// MainWithMessageSizeLimits returns a new MainOption that sets the limits on the size
+// of CheckRequests and CheckResponses.
+//
+// See CheckServiceHandlerWithMessageSizeLimits for details. When running as a daemon,
+// MaxRequestSize also limits the size of the requests of all other calls, which are
+// rejected as they are read from stdin, before they are read into memory in full.
+func MainWithMessageSizeLimits(messageSizeLimits MessageSizeLimits) MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.messageSizeLimits = messageSizeLimits
+	}
+}
+
+// MainWithMaxFileDescriptors returns a new MainOption that sets the maximum number of
+// FileDescriptors, and separately the maximum number of AgainstFileDescriptors, in a
+// CheckRequest.
+//
+// Together with MainWithMessageSizeLimits, this protects the machine that the plugin runs
+// on from pathological inputs. Calls to a plugin run as a daemon are handled sequentially,
+// so the number of concurrent calls does not need to be limited.
+//
+// See CheckServiceHandlerWithMaxFileDescriptors for details.
+func MainWithMaxFileDescriptors(maxFileDescriptors int) MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.maxFileDescriptors = maxFileDescriptors
+	}
+}
+
+// MainWithProcedure returns a new MainOption that adds a procedure to the plugin in addition
+// to the procedures for the Check and PluginInfo services.
+//
+// See ServerWithProcedure for details.
+func MainWithProcedure(
+	procedure pluginrpc.Procedure,
+	handleFunc func(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error,
+) MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.serverOptions = append(mainOptions.serverOptions, ServerWithProcedure(procedure, handleFunc))
+	}
+}
+
+// MainWithPluginrpcServerOptions returns a new MainOption that adds pluginrpc.ServerOptions
+// to use when constructing the pluginrpc.Server.
+//
+// See ServerWithPluginrpcServerOptions for details.
+func MainWithPluginrpcServerOptions(pluginrpcServerOptions ...pluginrpc.ServerOption) MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.serverOptions = append(
+			mainOptions.serverOptions,
+			ServerWithPluginrpcServerOptions(pluginrpcServerOptions...),
+		)
+	}
+}
+
+// MainWithLogger returns a new MainOption that sets the *slog.Logger that is available
+// to RuleHandlers with Logger.
+//
+// The logger should write to stderr, as stdout is used for the responses of the plugin:
+//
+//	check.MainWithLogger(slog.New(slog.NewJSONHandler(os.Stderr, nil)))
+//
+// The default is to discard all records, unless DebugEnvKey or LogLevelEnvKey is set.
+func MainWithLogger(logger *slog.Logger) MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.logger = logger
+	}
+}
+
+// MainWithTracer returns a new MainOption that sets the Tracer used to trace Check calls
+// and RuleHandler invocations.
+//
+// See CheckServiceHandlerWithTracer for details.
+func MainWithTracer(tracer Tracer) MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.tracer = tracer
+	}
+}
+
+// MainWithMetrics returns a new MainOption that sets the Metrics that receive metrics
+// about Check calls and RuleHandler invocations.
+//
+// See CheckServiceHandlerWithMetrics for details.
+func MainWithMetrics(metrics Metrics) MainOption {
+	return func(mainOptions *mainOptions) {
+		mainOptions.metrics = metrics
+	}
+}
+
+// MainWithDaemonIdleTimeout returns a new MainOption that sets the duration after which
+// a plugin run as a daemon exits if no call has been received.
+//
+// A value of 0 disables the idle timeout. The default is 5 minutes.
+// See DaemonFlagName for more details.
+func MainWithDaemonIdleTimeout(daemonIdleTimeout time.Duration) MainOption {
+	return func(mainOptions *mainOptions) {
+		if daemonIdleTimeout < 0 {
+			daemonIdleTimeout = 0
+		}
+		mainOptions.daemonIdleTimeout = daemonIdleTimeout
+	}
+}
+
 // *** PRIVATE ***
 
 type mainOptions struct {
-	parallelism int
+	parallelism         int
+	optionLimits        option.Limits
+	descriptorInterning bool
+	daemonIdleTimeout   time.Duration
+	messageSizeLimits   MessageSizeLimits
+	maxFileDescriptors  int
+	logger              *slog.Logger
+	tracer              Tracer
+	metrics             Metrics
+	// serverOptions are the ServerOptions added by MainWithProcedure and
+	// MainWithPluginrpcServerOptions.
+	serverOptions []ServerOption
 }
 
 func newMainOptions() *mainOptions {
-	return &mainOptions{}
+	return &mainOptions{
+		optionLimits:      option.DefaultLimits,
+		daemonIdleTimeout: defaultDaemonIdleTimeout,
+	}
+}
+
+// runDaemon serves calls on stdin and stdout until stdin is closed, the idle timeout elapses,
+// or an interrupt signal is received.
+func runDaemon(
+	newServer func() (pluginrpc.Server, error),
+	stdout io.Writer,
+	idleTimeout time.Duration,
+	maxRequestSize int,
+) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	server, err := newServer()
+	if err != nil {
+		return err
+	}
+	return streamrpc.ServeStream(ctx, os.Stdin, stdout, server, idleTimeout, maxRequestSize)
+}
+
+// runCapabilities writes the Capabilities of the plugin to stdout.
+func runCapabilities(newServer func() (pluginrpc.Server, error), stdout io.Writer) error {
+	server, err := newServer()
+	if err != nil {
+		return err
+	}
+	return writeCapabilities(context.Background(), stdout, server)
+}
+
+// runCompressed serves a single call whose stdin and stdout are compressed.
+func runCompressed(newServer func() (pluginrpc.Server, error)) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	server, err := newServer()
+	if err != nil {
+		return err
+	}
+	return compression.Serve(ctx, server, pluginrpc.OSEnv)
+}
+
+// writeOptionsJSONSchema writes the JSON Schema document for the options of the plugin.
+func writeOptionsJSONSchema(writer io.Writer, spec *Spec) error {
+	if err := ValidateSpec(spec); err != nil {
+		return err
+	}
+	optionsSchema := spec.Options
+	if optionsSchema == nil {
+		optionsSchema = &option.Schema{
+			AllowUnknownKeys: true,
+		}
+	}
+	data, err := option.SchemaToJSONSchema(optionsSchema)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(data, '\n'))
+	return err
 }
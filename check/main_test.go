@@ -0,0 +1,80 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"testing"
+
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOptionsJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	spec := &Spec{
+		Rules: []*RuleSpec{
+			testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil),
+		},
+		Options: &option.Schema{
+			Keys: []*option.KeySpec{
+				{
+					Key:  "timestamp_suffix",
+					Type: option.TypeString,
+				},
+			},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, writeOptionsJSONSchema(buffer, spec))
+	assert.JSONEq(
+		t,
+		`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"timestamp_suffix": {"type": "string", "minLength": 1}
+			},
+			"additionalProperties": false
+		}`,
+		buffer.String(),
+	)
+
+	spec.Options = nil
+	buffer.Reset()
+	require.NoError(t, writeOptionsJSONSchema(buffer, spec))
+	assert.JSONEq(
+		t,
+		`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"additionalProperties": true
+		}`,
+		buffer.String(),
+	)
+
+	documentation, err := NewPluginDocumentationForSpec(
+		&Spec{
+			Rules: []*RuleSpec{
+				testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil),
+			},
+			Options: &option.Schema{AllowUnknownKeys: true},
+		},
+	)
+	require.NoError(t, err)
+	assert.True(t, documentation.OptionsSchema().AllowUnknownKeys)
+}
@@ -0,0 +1,61 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"pluginrpc.com/pluginrpc"
+)
+
+// MessageSizeLimits are limits on the size of the Protobuf encoding of the requests and
+// responses of Check calls.
+//
+// A zero value for any field means that there is no limit. Note that Protobuf messages
+// cannot be larger than 2GiB regardless of these limits.
+type MessageSizeLimits struct {
+	// MaxRequestSize is the maximum size in bytes of a CheckRequest.
+	//
+	// For requests sent with NewRunnerWithChunking, this is the maximum size of each chunk.
+	MaxRequestSize int
+	// MaxResponseSize is the maximum size in bytes of a CheckResponse.
+	MaxResponseSize int
+}
+
+// *** PRIVATE ***
+
+// validateRequest returns an error with CodeResourceExhausted if the request is too large.
+func (m MessageSizeLimits) validateRequest(request proto.Message) error {
+	return validateMessageSize("request", m.MaxRequestSize, request)
+}
+
+// validateResponse returns an error with CodeResourceExhausted if the response is too large.
+func (m MessageSizeLimits) validateResponse(response proto.Message) error {
+	return validateMessageSize("response", m.MaxResponseSize, response)
+}
+
+func validateMessageSize(messageType string, maxSize int, message proto.Message) error {
+	if maxSize <= 0 {
+		return nil
+	}
+	if size := proto.Size(message); size > maxSize {
+		return pluginrpc.NewError(
+			pluginrpc.CodeResourceExhausted,
+			fmt.Errorf("%s of %d bytes exceeds maximum %s size of %d bytes", messageType, size, messageType, maxSize),
+		)
+	}
+	return nil
+}
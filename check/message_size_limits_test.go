@@ -0,0 +1,66 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"testing"
+
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestMessageSizeLimits(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(10))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+
+	testCheck := func(serverOptions []ServerOption, clientOptions []ClientOption) error {
+		server, err := NewServer(testNewFileNameAnnotationSpec(), serverOptions...)
+		require.NoError(t, err)
+		client := NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server)), clientOptions...)
+		_, err = client.Check(context.Background(), request)
+		return err
+	}
+
+	require.NoError(t, testCheck(nil, nil))
+	require.NoError(
+		t,
+		testCheck(
+			[]ServerOption{ServerWithMessageSizeLimits(MessageSizeLimits{MaxRequestSize: 1 << 20, MaxResponseSize: 1 << 20})},
+			[]ClientOption{ClientWithMessageSizeLimits(MessageSizeLimits{MaxRequestSize: 1 << 20, MaxResponseSize: 1 << 20})},
+		),
+	)
+	for _, err := range []error{
+		testCheck([]ServerOption{ServerWithMessageSizeLimits(MessageSizeLimits{MaxRequestSize: 100})}, nil),
+		testCheck(nil, []ClientOption{ClientWithMessageSizeLimits(MessageSizeLimits{MaxRequestSize: 100})}),
+	} {
+		require.Error(t, err)
+		require.Equal(t, pluginrpc.CodeResourceExhausted, pluginrpc.WrapError(err).Code())
+		require.Regexp(t, `request of \d+ bytes exceeds maximum request size of 100 bytes`, err.Error())
+	}
+	for _, err := range []error{
+		testCheck([]ServerOption{ServerWithMessageSizeLimits(MessageSizeLimits{MaxResponseSize: 10})}, nil),
+		testCheck(nil, []ClientOption{ClientWithMessageSizeLimits(MessageSizeLimits{MaxResponseSize: 10})}),
+	} {
+		require.Error(t, err)
+		require.Equal(t, pluginrpc.CodeResourceExhausted, pluginrpc.WrapError(err).Code())
+		require.Regexp(t, `response of \d+ bytes exceeds maximum response size of 10 bytes`, err.Error())
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"time"
+
+	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Metrics receives metrics about Check calls.
+//
+// Implementations typically record durations and sizes as histograms and the number of
+// calls and annotations as counters, using the metrics system of the caller. Methods may
+// be called concurrently, and should return quickly, as they are called inline.
+type Metrics interface {
+	// RecordCheck is called after each Check call.
+	RecordCheck(ctx context.Context, checkMetrics CheckMetrics)
+	// RecordRule is called after each invocation of a RuleHandler.
+	//
+	// This is only called on the plugin side, or for Clients created with NewClientForSpec.
+	RecordRule(ctx context.Context, ruleMetrics RuleMetrics)
+}
+
+// CheckMetrics are the metrics for a Check call.
+type CheckMetrics struct {
+	// Duration is the duration of the call.
+	Duration time.Duration
+	// RequestSize is the size in bytes of the Protobuf encoding of the CheckRequest.
+	//
+	// This is 0 for Check calls handled in-process by Clients created with NewClientForSpec.
+	RequestSize int
+	// ResponseSize is the size in bytes of the Protobuf encoding of the CheckResponse.
+	//
+	// This is 0 if the call failed, or for Check calls handled in-process by Clients
+	// created with NewClientForSpec.
+	ResponseSize int
+	// NumFileDescriptors is the number of FileDescriptors in the request.
+	NumFileDescriptors int
+	// NumAnnotations is the number of annotations in the response.
+	NumAnnotations int
+	// Err is the error of the call, if any.
+	Err error
+}
+
+// RuleMetrics are the metrics for an invocation of a RuleHandler.
+type RuleMetrics struct {
+	// RuleID is the ID of the Rule.
+	RuleID string
+	// Duration is the duration of the invocation.
+	Duration time.Duration
+	// NumAnnotations is the number of annotations added by the RuleHandler.
+	NumAnnotations int
+	// Err is the error returned by the RuleHandler, if any.
+	Err error
+}
+
+// *** PRIVATE ***
+
+// recordCheck records the CheckMetrics for a Check call that started at start.
+//
+// The checkResponse may be nil if the call failed.
+func recordCheck(
+	ctx context.Context,
+	metrics Metrics,
+	start time.Time,
+	checkRequest *checkv1.CheckRequest,
+	checkResponse *checkv1.CheckResponse,
+	err error,
+) {
+	checkMetrics := CheckMetrics{
+		Duration:           time.Since(start),
+		RequestSize:        proto.Size(checkRequest),
+		NumFileDescriptors: len(checkRequest.GetFileDescriptors()),
+		NumAnnotations:     len(checkResponse.GetAnnotations()),
+		Err:                err,
+	}
+	if checkResponse != nil {
+		checkMetrics.ResponseSize = proto.Size(checkResponse)
+	}
+	metrics.RecordCheck(ctx, checkMetrics)
+}
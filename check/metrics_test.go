@@ -0,0 +1,87 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	clientMetrics := &testMetrics{}
+	serverMetrics := &testMetrics{}
+	server, err := NewServer(testNewFileNameAnnotationSpec(), ServerWithMetrics(serverMetrics))
+	require.NoError(t, err)
+	client := NewClient(
+		pluginrpc.NewClient(pluginrpc.NewServerRunner(server)),
+		ClientWithMetrics(clientMetrics),
+	)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(3))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	require.NoError(t, err)
+
+	for _, metrics := range []*testMetrics{clientMetrics, serverMetrics} {
+		require.Len(t, metrics.checkMetrics, 1)
+		checkMetrics := metrics.checkMetrics[0]
+		require.NoError(t, checkMetrics.Err)
+		require.Equal(t, 3, checkMetrics.NumFileDescriptors)
+		require.Equal(t, 3, checkMetrics.NumAnnotations)
+		require.Positive(t, checkMetrics.RequestSize)
+		require.Positive(t, checkMetrics.ResponseSize)
+		require.Positive(t, checkMetrics.Duration)
+	}
+	require.Empty(t, clientMetrics.ruleMetrics)
+	require.Len(t, serverMetrics.ruleMetrics, 1)
+	require.Equal(t, "RULE1", serverMetrics.ruleMetrics[0].RuleID)
+	require.Equal(t, 3, serverMetrics.ruleMetrics[0].NumAnnotations)
+
+	// Clients created with NewClientForSpec record metrics for RuleHandlers.
+	specMetrics := &testMetrics{}
+	client, err = NewClientForSpec(testNewFileNameAnnotationSpec(), ClientWithMetrics(specMetrics))
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, specMetrics.checkMetrics, 1)
+	require.Equal(t, 3, specMetrics.checkMetrics[0].NumAnnotations)
+	require.Len(t, specMetrics.ruleMetrics, 1)
+}
+
+type testMetrics struct {
+	checkMetrics []CheckMetrics
+	ruleMetrics  []RuleMetrics
+	lock         sync.Mutex
+}
+
+func (m *testMetrics) RecordCheck(_ context.Context, checkMetrics CheckMetrics) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.checkMetrics = append(m.checkMetrics, checkMetrics)
+}
+
+func (m *testMetrics) RecordRule(_ context.Context, ruleMetrics RuleMetrics) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ruleMetrics = append(m.ruleMetrics, ruleMetrics)
+}
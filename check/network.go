@@ -0,0 +1,76 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"net"
+
+	"buf.build/go/bufplugin/internal/pkg/streamrpc"
+)
+
+// ServeListener serves the given Spec on the net.Listener until the context is cancelled.
+//
+// This allows a plugin to run as a long-lived service, such as a sidecar, instead of being
+// invoked as a new process for every call. Use NewClientForNetworkAddress to create a Client
+// for the plugin.
+//
+// Each connection handles a single call. Requests larger than the MaxRequestSize set with
+// ServerWithMessageSizeLimits are rejected before they are read into memory. When the context is cancelled, the net.Listener is
+// closed, in-flight calls are cancelled, and ServeListener returns nil once they complete.
+// Otherwise, ServeListener returns the error from the net.Listener.
+//
+//	listener, err := net.Listen("unix", "/tmp/buf-plugin-timestamp-suffix.sock")
+//	if err != nil {
+//		return err
+//	}
+//	return check.ServeListener(ctx, listener, spec)
+func ServeListener(ctx context.Context, listener net.Listener, spec *Spec, options ...ServerOption) error {
+	server, err := NewServer(spec, options...)
+	if err != nil {
+		return err
+	}
+	serverOptions := newServerOptions()
+	for _, option := range options {
+		option(serverOptions)
+	}
+	return streamrpc.Serve(ctx, listener, server, serverOptions.messageSizeLimits.MaxRequestSize)
+}
+
+// PingNetworkAddress pings the plugin served with ServeListener at the given network and
+// address.
+//
+// The ping is answered without invoking any handlers, and is answered while other calls are
+// in progress. PingNetworkAddress returns an error if the plugin does not respond before the
+// context is done, which allows supervisors to distinguish a plugin that is hung from one
+// that is handling slow calls.
+func PingNetworkAddress(ctx context.Context, network string, address string) error {
+	return streamrpc.Ping(ctx, streamrpc.NewRunner(network, address, 0))
+}
+
+// NewClientForNetworkAddress returns a new Client for a plugin served with ServeListener at
+// the given network and address.
+//
+// The network and address are as accepted by net.Dial, for example "tcp" and "localhost:8080",
+// or "unix" and "/tmp/buf-plugin-timestamp-suffix.sock". A new connection is made for each call.
+func NewClientForNetworkAddress(network string, address string, options ...ClientOption) Client {
+	return NewClient(
+		newPluginrpcClient(
+			streamrpc.NewRunner(network, address, maxResponseSizeForClientOptions(options)),
+			options,
+		),
+		options...,
+	)
+}
@@ -0,0 +1,171 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestNetwork(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewFileNameAnnotationSpec()
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "plugin.sock"))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErrC := make(chan error, 1)
+	go func() {
+		serveErrC <- ServeListener(ctx, listener, spec)
+	}()
+
+	client := NewClientForNetworkAddress(listener.Addr().Network(), listener.Addr().String())
+	rules, err := client.ListRules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, "RULE1", rules[0].ID())
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:           proto.String("foo.proto"),
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	response, err := client.Check(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, response.Annotations(), 1)
+	require.Equal(t, "foo.proto", response.Annotations()[0].Message())
+
+	// Errors are propagated.
+	request, err = NewRequest(fileDescriptors, WithRuleIDs("RULE2"))
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
+
+	cancel()
+	require.NoError(t, <-serveErrC)
+}
+
+func TestNetworkMessageSizeLimits(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewFileNameAnnotationSpec()
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "plugin.sock"))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErrC := make(chan error, 1)
+	go func() {
+		serveErrC <- ServeListener(
+			ctx,
+			listener,
+			spec,
+			ServerWithMessageSizeLimits(MessageSizeLimits{MaxRequestSize: 1 << 10}),
+		)
+	}()
+	network, address := listener.Addr().Network(), listener.Addr().String()
+
+	// Requests that exceed the limit of the server are rejected as they are read.
+	client := NewClientForNetworkAddress(network, address)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:           proto.String(strings.Repeat("a", 1<<10) + ".proto"),
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	require.Error(t, err)
+	_, err = client.ListRules(context.Background())
+	require.NoError(t, err)
+
+	// Responses that exceed the limit of the client are rejected as they are read.
+	client = NewClientForNetworkAddress(
+		network,
+		address,
+		ClientWithMessageSizeLimits(MessageSizeLimits{MaxResponseSize: 10}),
+	)
+	_, err = client.ListRules(context.Background())
+	require.ErrorContains(t, err, "stdout of size")
+
+	cancel()
+	require.NoError(t, <-serveErrC)
+}
+
+func TestPingNetworkAddress(t *testing.T) {
+	t.Parallel()
+
+	// The ping is answered while a call is in progress.
+	checkStartedC := make(chan struct{})
+	releaseCheckC := make(chan struct{})
+	spec := testNewFileNameAnnotationSpec()
+	spec.Rules[0].Handler = RuleHandlerFunc(
+		func(context.Context, ResponseWriter, Request) error {
+			close(checkStartedC)
+			<-releaseCheckC
+			return nil
+		},
+	)
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "plugin.sock"))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErrC := make(chan error, 1)
+	go func() {
+		serveErrC <- ServeListener(ctx, listener, spec)
+	}()
+	network, address := listener.Addr().Network(), listener.Addr().String()
+
+	require.NoError(t, PingNetworkAddress(context.Background(), network, address))
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(1))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	checkErrC := make(chan error, 1)
+	go func() {
+		_, err := NewClientForNetworkAddress(network, address).Check(context.Background(), request)
+		checkErrC <- err
+	}()
+	<-checkStartedC
+	require.NoError(t, PingNetworkAddress(context.Background(), network, address))
+	close(releaseCheckC)
+	require.NoError(t, <-checkErrC)
+
+	cancel()
+	require.NoError(t, <-serveErrC)
+	require.Error(t, PingNetworkAddress(context.Background(), network, address))
+}
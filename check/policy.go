@@ -0,0 +1,238 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	extcheckv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1"
+	extcheckv1pluginrpc "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1/checkv1pluginrpc"
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	// SeverityInfo is a finding that is informational only.
+	SeverityInfo Severity = 1
+	// SeverityWarning is a finding that should be addressed, but does not need to block changes.
+	SeverityWarning Severity = 2
+	// SeverityError is a finding that should block changes.
+	SeverityError Severity = 3
+
+	listPoliciesPath = extcheckv1pluginrpc.PolicyServiceListPoliciesPath
+	listPoliciesArg  = "list-policies"
+)
+
+var (
+	severityToString = map[Severity]string{
+		SeverityInfo:    "info",
+		SeverityWarning: "warning",
+		SeverityError:   "error",
+	}
+	stringToSeverity = map[string]Severity{
+		"info":    SeverityInfo,
+		"warning": SeverityWarning,
+		"error":   SeverityError,
+	}
+	severityToProtoSeverity = map[Severity]extcheckv1.Severity{
+		SeverityInfo:    extcheckv1.Severity_SEVERITY_INFO,
+		SeverityWarning: extcheckv1.Severity_SEVERITY_WARNING,
+		SeverityError:   extcheckv1.Severity_SEVERITY_ERROR,
+	}
+	protoSeverityToSeverity = map[extcheckv1.Severity]Severity{
+		extcheckv1.Severity_SEVERITY_INFO:    SeverityInfo,
+		extcheckv1.Severity_SEVERITY_WARNING: SeverityWarning,
+		extcheckv1.Severity_SEVERITY_ERROR:   SeverityError,
+	}
+)
+
+// Severity is the severity of a finding.
+//
+// Severities are ordered: a greater Severity is more severe.
+type Severity int
+
+// String implements fmt.Stringer.
+func (s Severity) String() string {
+	if str, ok := severityToString[s]; ok {
+		return str
+	}
+	return strconv.Itoa(int(s))
+}
+
+// ParseSeverity parses the Severity from its string representation, such as "warning".
+//
+// Parsing is case-insensitive.
+func ParseSeverity(s string) (Severity, error) {
+	severity, ok := stringToSeverity[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown severity: %q", s)
+	}
+	return severity, nil
+}
+
+// Policy is the governance metadata of a policy Rule.
+//
+// Policy Rules check compliance with an organization's policies rather than code quality.
+// Their findings are typically fed to audit systems, and only block changes if their
+// Severity says so. A Rule is a policy Rule if Rule.Policy is non-nil.
+//
+// On the server-side (i.e. the plugin), Policies are created by PolicySpecs. Clients get the
+// Policy of a Rule with ListRules, and the Policy of the Rule of an Annotation with
+// Annotation.Policy.
+type Policy interface {
+	// ID is the identifier of the policy within the organization's policy catalog, such as
+	// "SEC-042".
+	//
+	// Always present. Multiple Rules may implement the same policy.
+	ID() string
+	// Severity is the Severity of findings for the policy.
+	//
+	// Always present.
+	Severity() Severity
+	// Owner is the team or individual responsible for the policy.
+	//
+	// Optional.
+	Owner() string
+
+	isPolicy()
+}
+
+// PolicySpec is the spec for the Policy of a Rule.
+//
+// Set PolicySpec on a RuleSpec to make the Rule a policy Rule.
+type PolicySpec struct {
+	// Required.
+	ID string
+	// Required.
+	Severity Severity
+	// Optional.
+	Owner string
+}
+
+// *** PRIVATE ***
+
+type policy struct {
+	id       string
+	severity Severity
+	owner    string
+}
+
+func newPolicy(id string, severity Severity, owner string) (*policy, error) {
+	if id == "" {
+		return nil, errors.New("check.Policy: ID is empty")
+	}
+	if _, ok := severityToString[severity]; !ok {
+		return nil, fmt.Errorf("check.Policy: unknown Severity %v for ID %q", severity, id)
+	}
+	return &policy{
+		id:       id,
+		severity: severity,
+		owner:    owner,
+	}, nil
+}
+
+func (p *policy) ID() string {
+	return p.id
+}
+
+func (p *policy) Severity() Severity {
+	return p.severity
+}
+
+func (p *policy) Owner() string {
+	return p.owner
+}
+
+func (*policy) isPolicy() {}
+
+// policySpecToPolicy returns nil if the PolicySpec is nil.
+func policySpecToPolicy(policySpec *PolicySpec) (Policy, error) {
+	if policySpec == nil {
+		return nil, nil
+	}
+	return newPolicy(policySpec.ID, policySpec.Severity, policySpec.Owner)
+}
+
+// policyServiceHandler implements the PolicyService, which is only registered for plugins
+// that have policy Rules.
+type policyServiceHandler struct {
+	rules []Rule
+}
+
+func newPolicyServiceHandler(rules []Rule) *policyServiceHandler {
+	return &policyServiceHandler{
+		rules: rules,
+	}
+}
+
+func (p *policyServiceHandler) ListPolicies(
+	context.Context,
+	*extcheckv1.ListPoliciesRequest,
+) (*extcheckv1.ListPoliciesResponse, error) {
+	response := &extcheckv1.ListPoliciesResponse{}
+	for _, rule := range p.rules {
+		if policy := rule.Policy(); policy != nil {
+			response.Policies = append(
+				response.Policies,
+				&extcheckv1.Policy{
+					RuleId:   rule.ID(),
+					Id:       policy.ID(),
+					Severity: severityToProtoSeverity[policy.Severity()],
+					Owner:    policy.Owner(),
+				},
+			)
+		}
+	}
+	return response, nil
+}
+
+// listPoliciesForPluginrpcClient calls the ListPolicies procedure, and returns a map from
+// Rule ID to Policy.
+//
+// Returns an empty map without calling the plugin if the plugin does not implement the
+// ListPolicies procedure, as is the case for plugins without policy Rules.
+func listPoliciesForPluginrpcClient(ctx context.Context, pluginrpcClient pluginrpc.Client) (map[string]Policy, error) {
+	spec, err := pluginrpcClient.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if spec.ProcedureForPath(listPoliciesPath) == nil {
+		return map[string]Policy{}, nil
+	}
+	policyServiceClient, err := extcheckv1pluginrpc.NewPolicyServiceClient(pluginrpcClient)
+	if err != nil {
+		return nil, err
+	}
+	response, err := policyServiceClient.ListPolicies(ctx, &extcheckv1.ListPoliciesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	ruleIDToPolicy := make(map[string]Policy, len(response.GetPolicies()))
+	for _, protoPolicy := range response.GetPolicies() {
+		severity, ok := protoSeverityToSeverity[protoPolicy.GetSeverity()]
+		if !ok {
+			return nil, fmt.Errorf("unknown severity %v for policy %q", protoPolicy.GetSeverity(), protoPolicy.GetId())
+		}
+		policy, err := newPolicy(protoPolicy.GetId(), severity, protoPolicy.GetOwner())
+		if err != nil {
+			return nil, err
+		}
+		ruleIDToPolicy[protoPolicy.GetRuleId()] = policy
+	}
+	return ruleIDToPolicy, nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewPolicySpec()
+	client, err := NewClientForSpec(spec)
+	require.NoError(t, err)
+	testPolicy(t, client)
+	server, err := NewServer(spec)
+	require.NoError(t, err)
+	testPolicy(t, NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server))))
+	testPolicy(t, NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server)), ClientWithCaching()))
+}
+
+func TestPolicyNotImplemented(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+	pluginrpcSpec, err := pluginrpc.NewClient(pluginrpc.NewServerRunner(server)).Spec(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, pluginrpcSpec.ProcedureForPath(listPoliciesPath))
+
+	runner := &testRecordingRunner{
+		run: pluginrpc.NewServerRunner(server).Run,
+	}
+	client := NewClient(pluginrpc.NewClient(runner))
+	testCheckFileName(t, client)
+	numCalls := len(runner.allArgs)
+	response, err := client.Check(context.Background(), testNewPolicyRequest(t))
+	require.NoError(t, err)
+	// The ListPolicies procedure is not called for plugins without policy Rules.
+	require.Len(t, runner.allArgs, numCalls+1)
+	for _, annotation := range response.Annotations() {
+		require.Nil(t, annotation.Policy())
+		require.Equal(t, SeverityError, annotation.Severity())
+		require.Nil(t, annotation.Waiver())
+	}
+}
+
+func TestPolicyAlwaysCached(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewPolicySpec())
+	require.NoError(t, err)
+	runner := &testRecordingRunner{
+		run: pluginrpc.NewServerRunner(server).Run,
+	}
+	// Policies are cached even without ClientWithCaching.
+	client := NewClient(pluginrpc.NewClient(runner))
+	for range 2 {
+		_, err := client.Check(context.Background(), testNewPolicyRequest(t))
+		require.NoError(t, err)
+		_, err = client.ListRules(context.Background())
+		require.NoError(t, err)
+	}
+	var numListPoliciesCalls int
+	for _, args := range runner.allArgs {
+		if slices.Contains(args, listPoliciesArg) {
+			numListPoliciesCalls++
+		}
+	}
+	require.Equal(t, 1, numListPoliciesCalls)
+}
+
+func TestWaivers(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClientForSpec(testNewPolicySpec())
+	require.NoError(t, err)
+	now := time.Now()
+	response, err := client.Check(
+		context.Background(),
+		testNewPolicyRequest(t),
+		CheckWithWaivers(
+			Waiver{
+				ID:       "WAIVER-1",
+				RuleID:   "POLICY_RULE",
+				FileName: "file0.proto",
+				Reason:   "Legacy file.",
+				Expiry:   now.Add(time.Hour),
+			},
+			Waiver{
+				ID:       "WAIVER-2",
+				RuleID:   "POLICY_RULE",
+				FileName: "file1.proto",
+				Expiry:   now.Add(-time.Hour),
+			},
+			Waiver{
+				ID:     "WAIVER-3",
+				RuleID: "PLAIN_RULE",
+			},
+		),
+	)
+	require.NoError(t, err)
+	fileNameToRuleIDToWaiverID := make(map[string]map[string]string)
+	for _, annotation := range response.Annotations() {
+		fileName := annotation.FileLocation().FileDescriptor().ProtoreflectFileDescriptor().Path()
+		if fileNameToRuleIDToWaiverID[fileName] == nil {
+			fileNameToRuleIDToWaiverID[fileName] = make(map[string]string)
+		}
+		var waiverID string
+		if waiver := annotation.Waiver(); waiver != nil {
+			waiverID = waiver.ID
+		}
+		fileNameToRuleIDToWaiverID[fileName][annotation.RuleID()] = waiverID
+	}
+	require.Equal(
+		t,
+		map[string]map[string]string{
+			"file0.proto": {
+				"PLAIN_RULE":  "WAIVER-3",
+				"POLICY_RULE": "WAIVER-1",
+			},
+			"file1.proto": {
+				"PLAIN_RULE": "WAIVER-3",
+				// WAIVER-2 is expired.
+				"POLICY_RULE": "",
+			},
+		},
+		fileNameToRuleIDToWaiverID,
+	)
+
+	_, err = client.Check(context.Background(), testNewPolicyRequest(t), CheckWithWaivers(Waiver{ID: "WAIVER-1"}))
+	require.Error(t, err)
+}
+
+func TestValidateSpecPolicy(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewPolicySpec()
+	spec.Rules[1].Policy.ID = ""
+	validateRuleSpecError := &validateRuleSpecError{}
+	require.ErrorAs(t, ValidateSpec(spec), &validateRuleSpecError)
+
+	spec = testNewPolicySpec()
+	spec.Rules[1].Policy.Severity = 0
+	require.ErrorAs(t, ValidateSpec(spec), &validateRuleSpecError)
+}
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	for _, severity := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		parsedSeverity, err := ParseSeverity(severity.String())
+		require.NoError(t, err)
+		require.Equal(t, severity, parsedSeverity)
+	}
+	severity, err := ParseSeverity("WARNING")
+	require.NoError(t, err)
+	require.Equal(t, SeverityWarning, severity)
+	_, err = ParseSeverity("fatal")
+	require.Error(t, err)
+	require.Equal(t, "4", Severity(4).String())
+}
+
+func testPolicy(t *testing.T, client Client) {
+	rules, err := client.ListRules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, "PLAIN_RULE", rules[0].ID())
+	require.Nil(t, rules[0].Policy())
+	require.Equal(t, "POLICY_RULE", rules[1].ID())
+	policy := rules[1].Policy()
+	require.NotNil(t, policy)
+	require.Equal(t, "SEC-042", policy.ID())
+	require.Equal(t, SeverityWarning, policy.Severity())
+	require.Equal(t, "security-team", policy.Owner())
+
+	response, err := client.Check(context.Background(), testNewPolicyRequest(t))
+	require.NoError(t, err)
+	annotations := response.Annotations()
+	require.Len(t, annotations, 4)
+	for _, annotation := range annotations {
+		require.Nil(t, annotation.Waiver())
+		switch annotation.RuleID() {
+		case "PLAIN_RULE":
+			require.Nil(t, annotation.Policy())
+			require.Equal(t, SeverityError, annotation.Severity())
+		case "POLICY_RULE":
+			require.NotNil(t, annotation.Policy())
+			require.Equal(t, "SEC-042", annotation.Policy().ID())
+			require.Equal(t, SeverityWarning, annotation.Severity())
+		default:
+			require.Fail(t, "unexpected rule ID", annotation.RuleID())
+		}
+	}
+}
+
+func testNewPolicySpec() *Spec {
+	plainRuleSpec := testNewSimpleLintRuleSpec("PLAIN_RULE", nil, true, false, nil)
+	plainRuleSpec.Handler = testFileNameRuleHandler
+	policyRuleSpec := testNewSimpleLintRuleSpec("POLICY_RULE", nil, true, false, nil)
+	policyRuleSpec.Handler = testFileNameRuleHandler
+	policyRuleSpec.Policy = &PolicySpec{
+		ID:       "SEC-042",
+		Severity: SeverityWarning,
+		Owner:    "security-team",
+	}
+	return &Spec{
+		Rules: []*RuleSpec{plainRuleSpec, policyRuleSpec},
+	}
+}
+
+func testNewPolicyRequest(t *testing.T) Request {
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(2))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	return request
+}
+
+var testFileNameRuleHandler = RuleHandlerFunc(
+	func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+		for _, fileDescriptor := range request.FileDescriptors() {
+			responseWriter.AddAnnotation(
+				WithMessage(fileDescriptor.FileDescriptorProto().GetName()),
+				WithFileName(fileDescriptor.FileDescriptorProto().GetName()),
+			)
+		}
+		return nil
+	},
+)
@@ -0,0 +1,208 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"time"
+
+	"buf.build/go/bufplugin/internal/pkg/streamrpc"
+	"pluginrpc.com/pluginrpc"
+)
+
+// PoolConfig is the configuration for a pool of plugin processes.
+type PoolConfig struct {
+	// Size is the maximum number of plugin processes in the pool, and therefore the
+	// maximum number of concurrent calls to the plugin.
+	//
+	// A value <= 0 results in runtime.GOMAXPROCS(0) processes.
+	Size int
+	// MaxRequestsPerProcess is the maximum number of invocations handled by a single plugin
+	// process. After this number of invocations, the process is stopped, and a new process is
+	// started on the next invocation. This bounds the impact of memory leaks in plugins.
+	//
+	// A value <= 0 means that processes are never recycled.
+	MaxRequestsPerProcess int
+	// HealthCheckIdleTime is the duration after which an idle plugin process is pinged
+	// before it is reused. Processes that do not respond are restarted.
+	//
+	// A value <= 0 means that processes are never health-checked.
+	HealthCheckIdleTime time.Duration
+	// HealthCheckTimeout is the timeout of a health check.
+	//
+	// A value <= 0 results in a timeout of 5 seconds.
+	HealthCheckTimeout time.Duration
+}
+
+// PoolClient is a Client for a pool of plugin processes.
+//
+// The PoolClient must be closed when no longer needed, which stops all processes.
+type PoolClient interface {
+	Client
+
+	// Close stops all processes in the pool, waiting for in-progress calls to complete.
+	//
+	// Calls after Close start new processes.
+	Close() error
+}
+
+// NewClientForPool returns a new PoolClient that runs the given program as a pool of daemons.
+//
+// The program must call Main, which runs the plugin as a daemon when invoked with --daemon,
+// see DaemonFlagName. Processes are started as needed, up to PoolConfig.Size, and are reused
+// across calls. Each process handles a single call at a time; calls wait for a process to be
+// available. If a process exits, it is restarted on the next call that uses it.
+//
+// This is intended for servers that invoke plugins at a high rate, where the cost of starting
+// a process for each call is prohibitive. For a single process, use NewClientForDaemon.
+func NewClientForPool(programName string, poolConfig PoolConfig, options ...ClientOption) PoolClient {
+	poolRunner := newPoolRunner(
+		func() streamrpc.ProcessRunner {
+			return streamrpc.NewProcessRunner(
+				programName,
+				[]string{"--" + DaemonFlagName},
+				maxResponseSizeForClientOptions(options),
+			)
+		},
+		poolConfig,
+	)
+	return &poolClient{
+		Client: NewClient(
+			newPluginrpcClient(poolRunner, options),
+			options...,
+		),
+		poolRunner: poolRunner,
+	}
+}
+
+// *** PRIVATE ***
+
+const defaultPoolHealthCheckTimeout = 5 * time.Second
+
+type poolClient struct {
+	Client
+
+	poolRunner *poolRunner
+}
+
+func (p *poolClient) Close() error {
+	return p.poolRunner.Close()
+}
+
+type poolRunner struct {
+	poolConfig PoolConfig
+	// processes holds the processes that are not in use.
+	//
+	// All processes are in processes when there are no in-progress invocations.
+	processes chan *pooledProcess
+}
+
+func newPoolRunner(newProcessRunner func() streamrpc.ProcessRunner, poolConfig PoolConfig) *poolRunner {
+	if poolConfig.Size <= 0 {
+		poolConfig.Size = runtime.GOMAXPROCS(0)
+	}
+	if poolConfig.HealthCheckTimeout <= 0 {
+		poolConfig.HealthCheckTimeout = defaultPoolHealthCheckTimeout
+	}
+	processes := make(chan *pooledProcess, poolConfig.Size)
+	for range poolConfig.Size {
+		// ProcessRunners start their process on the first invocation.
+		processes <- &pooledProcess{
+			processRunner: newProcessRunner(),
+		}
+	}
+	return &poolRunner{
+		poolConfig: poolConfig,
+		processes:  processes,
+	}
+}
+
+func (p *poolRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	var process *pooledProcess
+	select {
+	case process = <-p.processes:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { p.processes <- process }()
+	if err := p.healthCheck(ctx, process); err != nil {
+		return err
+	}
+	err := process.processRunner.Run(ctx, env)
+	process.numRequests++
+	process.lastUsed = time.Now()
+	if p.poolConfig.MaxRequestsPerProcess > 0 && process.numRequests >= p.poolConfig.MaxRequestsPerProcess {
+		err = errors.Join(err, process.stop())
+	}
+	return err
+}
+
+// Close stops all processes, waiting for in-progress invocations to complete.
+func (p *poolRunner) Close() error {
+	processes := make([]*pooledProcess, 0, p.poolConfig.Size)
+	defer func() {
+		for _, process := range processes {
+			p.processes <- process
+		}
+	}()
+	var errs []error
+	for range p.poolConfig.Size {
+		process := <-p.processes
+		processes = append(processes, process)
+		errs = append(errs, process.stop())
+	}
+	return errors.Join(errs...)
+}
+
+// healthCheck pings the process if it has been idle for longer than the health check
+// idle time, and stops the process if it does not respond.
+//
+// The process is restarted on the next invocation. An error is only returned if the
+// context is done.
+func (p *poolRunner) healthCheck(ctx context.Context, process *pooledProcess) error {
+	if p.poolConfig.HealthCheckIdleTime <= 0 ||
+		process.numRequests == 0 ||
+		time.Since(process.lastUsed) < p.poolConfig.HealthCheckIdleTime {
+		return nil
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, p.poolConfig.HealthCheckTimeout)
+	defer cancel()
+	if err := streamrpc.Ping(pingCtx, process.processRunner); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return process.stop()
+	}
+	process.lastUsed = time.Now()
+	return nil
+}
+
+// pooledProcess is a process in a pool.
+//
+// A pooledProcess is only used by one invocation at a time, so it does not need to be
+// synchronized.
+type pooledProcess struct {
+	processRunner streamrpc.ProcessRunner
+	// numRequests is the number of invocations handled by the current process.
+	numRequests int
+	lastUsed    time.Time
+}
+
+func (p *pooledProcess) stop() error {
+	p.numRequests = 0
+	return p.processRunner.Close()
+}
@@ -0,0 +1,135 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/internal/pkg/streamrpc"
+	"buf.build/go/bufplugin/internal/pkg/thread"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestPool(t *testing.T) {
+	t.Parallel()
+
+	client := NewClientForPool(
+		os.Args[0],
+		PoolConfig{
+			Size:                  2,
+			MaxRequestsPerProcess: 3,
+		},
+	)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(1))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+	jobs := make([]func(context.Context) error, 10)
+	for i := range jobs {
+		jobs[i] = func(ctx context.Context) error {
+			_, err := client.Check(ctx, request)
+			return err
+		}
+	}
+	require.NoError(t, thread.Parallelize(context.Background(), jobs))
+	require.NoError(t, client.Close())
+	testCheckFileName(t, client)
+}
+
+func TestPoolRunnerRecycle(t *testing.T) {
+	t.Parallel()
+
+	var processRunners []*testProcessRunner
+	poolRunner := newPoolRunner(
+		func() streamrpc.ProcessRunner {
+			processRunner := &testProcessRunner{}
+			processRunners = append(processRunners, processRunner)
+			return processRunner
+		},
+		PoolConfig{
+			Size:                  1,
+			MaxRequestsPerProcess: 2,
+		},
+	)
+	for range 5 {
+		require.NoError(t, poolRunner.Run(context.Background(), pluginrpc.Env{}))
+	}
+	require.Len(t, processRunners, 1)
+	require.Equal(t, 5, processRunners[0].numRuns)
+	require.Equal(t, 2, processRunners[0].numCloses)
+	require.NoError(t, poolRunner.Close())
+	require.Equal(t, 3, processRunners[0].numCloses)
+}
+
+func TestPoolRunnerHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	processRunner := &testProcessRunner{}
+	poolRunner := newPoolRunner(
+		func() streamrpc.ProcessRunner { return processRunner },
+		PoolConfig{
+			Size:                1,
+			HealthCheckIdleTime: time.Nanosecond,
+		},
+	)
+	// The first invocation starts the process, and is not health-checked.
+	require.NoError(t, poolRunner.Run(context.Background(), pluginrpc.Env{}))
+	require.Equal(t, 0, processRunner.numPings)
+	// Idle processes are pinged before reuse.
+	require.NoError(t, poolRunner.Run(context.Background(), pluginrpc.Env{}))
+	require.Equal(t, 1, processRunner.numPings)
+	require.Equal(t, 0, processRunner.numCloses)
+	// Processes that fail the health check are stopped.
+	processRunner.pingErr = errors.New("unhealthy")
+	require.NoError(t, poolRunner.Run(context.Background(), pluginrpc.Env{}))
+	require.Equal(t, 2, processRunner.numPings)
+	require.Equal(t, 1, processRunner.numCloses)
+	require.Equal(t, 3, processRunner.numRuns)
+}
+
+type testProcessRunner struct {
+	pingErr   error
+	numRuns   int
+	numPings  int
+	numCloses int
+	lock      sync.Mutex
+}
+
+func (p *testProcessRunner) Run(_ context.Context, env pluginrpc.Env) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(env.Args) == 1 && env.Args[0] == "--"+streamrpc.PingFlagName {
+		p.numPings++
+		return p.pingErr
+	}
+	p.numRuns++
+	return nil
+}
+
+func (p *testProcessRunner) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.numCloses++
+	return nil
+}
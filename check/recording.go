@@ -0,0 +1,183 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// Recording is a recording of a single invocation of a plugin.
+//
+// Recordings are created with NewRunnerWithRecording, and can be replayed against a plugin
+// with ReplayRecording. Recordings of Check calls contain the FileDescriptors of the request,
+// including all comments from the source files, and should be treated as sensitive.
+type Recording struct {
+	// Args are the args of the invocation.
+	Args []string `json:"args,omitempty"`
+	// Stdin is the stdin of the invocation.
+	Stdin []byte `json:"stdin,omitempty"`
+	// Stdout is the stdout of the invocation.
+	Stdout []byte `json:"stdout,omitempty"`
+	// Stderr is the stderr of the invocation.
+	Stderr []byte `json:"stderr,omitempty"`
+	// ExitCode is the exit code of the invocation.
+	ExitCode int `json:"exit_code,omitempty"`
+	// Error is the error of the invocation, if the invocation failed for a reason other
+	// than a non-zero exit code, such as a failure to start the plugin.
+	Error string `json:"error,omitempty"`
+}
+
+// ReadRecordingFile reads a Recording from a file written by NewRunnerWithRecording.
+func ReadRecordingFile(filePath string) (*Recording, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	recording := &Recording{}
+	if err := json.Unmarshal(data, recording); err != nil {
+		return nil, fmt.Errorf("invalid recording file %q: %w", filePath, err)
+	}
+	return recording, nil
+}
+
+// NewRunnerWithRecording returns a new pluginrpc.Runner that records every invocation of the
+// plugin run by the given pluginrpc.Runner to a file in the given directory.
+//
+// Each invocation, including the args, stdin, stdout, stderr, and exit code, is written as
+// a JSON-encoded Recording to a new file in the directory, named by the time of the
+// invocation so that the files sort in the order of the invocations. The directory must
+// exist. Failures to write a Recording are returned as the error of the invocation.
+//
+// Recordings allow bugs reported from other environments to be reproduced exactly, without
+// access to the original sources, by replaying them with ReplayRecording:
+//
+//	client := check.NewClient(
+//		pluginrpc.NewClient(
+//			check.NewRunnerWithRecording(pluginrpc.NewExecRunner("buf-plugin-foo"), "recordings"),
+//		),
+//	)
+func NewRunnerWithRecording(runner pluginrpc.Runner, dirPath string) pluginrpc.Runner {
+	return &recordingRunner{
+		delegate: runner,
+		dirPath:  dirPath,
+	}
+}
+
+// ReplayRecording invokes the plugin run by the given pluginrpc.Runner with the args and
+// stdin of the Recording, and returns a new Recording of the invocation.
+//
+// The stdout, stderr, and exit code of the returned Recording can be compared to those of
+// the given Recording to check if the behavior of the plugin was reproduced. A non-zero
+// exit code is not an error, and is set on the returned Recording.
+func ReplayRecording(ctx context.Context, runner pluginrpc.Runner, recording *Recording) (*Recording, error) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	err := runner.Run(
+		ctx,
+		pluginrpc.Env{
+			Args:   recording.Args,
+			Stdin:  bytes.NewReader(recording.Stdin),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		},
+	)
+	replayedRecording := newRecording(recording.Args, recording.Stdin, stdout.Bytes(), stderr.Bytes(), err)
+	if replayedRecording.Error != "" {
+		return nil, err
+	}
+	return replayedRecording, nil
+}
+
+// *** PRIVATE ***
+
+type recordingRunner struct {
+	delegate pluginrpc.Runner
+	dirPath  string
+}
+
+func (r *recordingRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	var stdin []byte
+	if env.Stdin != nil {
+		var err error
+		stdin, err = io.ReadAll(env.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	recordingEnv := pluginrpc.Env{
+		Args:   env.Args,
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	if env.Stdin != nil {
+		recordingEnv.Stdin = bytes.NewReader(stdin)
+	}
+	if env.Stdout != nil {
+		recordingEnv.Stdout = io.MultiWriter(&stdout, env.Stdout)
+	}
+	if env.Stderr != nil {
+		recordingEnv.Stderr = io.MultiWriter(&stderr, env.Stderr)
+	}
+	err := r.delegate.Run(ctx, recordingEnv)
+	recording := newRecording(env.Args, stdin, stdout.Bytes(), stderr.Bytes(), err)
+	return errors.Join(err, r.writeRecording(recording))
+}
+
+func (r *recordingRunner) writeRecording(recording *Recording) (retErr error) {
+	data, err := json.Marshal(recording)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(
+		r.dirPath,
+		time.Now().UTC().Format("20060102T150405.000000000Z")+"-*.json",
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		retErr = errors.Join(retErr, file.Close())
+	}()
+	_, err = file.Write(data)
+	return err
+}
+
+func newRecording(args []string, stdin []byte, stdout []byte, stderr []byte, err error) *Recording {
+	recording := &Recording{
+		Args:   args,
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+	if err != nil {
+		if exitError := (&pluginrpc.ExitError{}); errors.As(err, &exitError) {
+			recording.ExitCode = exitError.ExitCode()
+		} else {
+			recording.Error = err.Error()
+		}
+	}
+	return recording
+}
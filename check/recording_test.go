@@ -0,0 +1,69 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRecording(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+	dirPath := t.TempDir()
+	testCheckFileName(t, NewClient(pluginrpc.NewClient(NewRunnerWithRecording(serverRunner, dirPath))))
+
+	dirEntries, err := os.ReadDir(dirPath)
+	require.NoError(t, err)
+	var recording *Recording
+	for _, dirEntry := range dirEntries {
+		dirEntryRecording, err := ReadRecordingFile(filepath.Join(dirPath, dirEntry.Name()))
+		require.NoError(t, err)
+		if len(dirEntryRecording.Args) > 0 && dirEntryRecording.Args[0] == "check" {
+			recording = dirEntryRecording
+		}
+	}
+	require.NotNil(t, recording)
+	require.NotEmpty(t, recording.Stdin)
+	require.NotEmpty(t, recording.Stdout)
+	require.Zero(t, recording.ExitCode)
+
+	// Replaying the recording against the same plugin reproduces the response.
+	replayedRecording, err := ReplayRecording(context.Background(), serverRunner, recording)
+	require.NoError(t, err)
+	require.Equal(t, recording, replayedRecording)
+
+	// Non-zero exit codes are recorded.
+	replayedRecording, err = ReplayRecording(
+		context.Background(),
+		&testRecordingRunner{
+			run: func(context.Context, pluginrpc.Env) error {
+				return pluginrpc.NewExitError(3, errors.New("failed"))
+			},
+		},
+		recording,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 3, replayedRecording.ExitCode)
+}
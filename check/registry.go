@@ -0,0 +1,98 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Registry is a registry of Specs by name, for hosting plugins in-process.
+//
+// This allows a program to use many plugins as libraries with the same programming model as
+// plugins run as separate programs: plugins are implemented as Specs, and are used through
+// Clients. The Clients handle calls to Check in-process, see NewClientForSpec.
+//
+// A Registry is safe for concurrent use.
+type Registry interface {
+	// Register registers the Spec with the given name.
+	//
+	// The Spec is validated with ValidateSpec. Returns error if the Spec is invalid, or if a
+	// Spec is already registered with the name.
+	Register(name string, spec *Spec) error
+	// Client returns a new Client for the Spec registered with the given name.
+	//
+	// Returns error if no Spec is registered with the name.
+	Client(name string, options ...ClientForSpecOption) (Client, error)
+	// Names returns the sorted names of all registered Specs.
+	Names() []string
+
+	isRegistry()
+}
+
+// NewRegistry returns a new, empty Registry.
+func NewRegistry() Registry {
+	return &registry{
+		nameToSpec: make(map[string]*Spec),
+	}
+}
+
+// *** PRIVATE ***
+
+type registry struct {
+	nameToSpec map[string]*Spec
+	lock       sync.RWMutex
+}
+
+func (r *registry) Register(name string, spec *Spec) error {
+	if name == "" {
+		return errors.New("name must not be empty")
+	}
+	if err := ValidateSpec(spec); err != nil {
+		return fmt.Errorf("invalid Spec for %q: %w", name, err)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.nameToSpec[name]; ok {
+		return fmt.Errorf("a Spec is already registered with name %q", name)
+	}
+	r.nameToSpec[name] = spec
+	return nil
+}
+
+func (r *registry) Client(name string, options ...ClientForSpecOption) (Client, error) {
+	r.lock.RLock()
+	spec, ok := r.nameToSpec[name]
+	r.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no Spec registered with name %q", name)
+	}
+	return NewClientForSpec(spec, options...)
+}
+
+func (r *registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names := make([]string, 0, len(r.nameToSpec))
+	for name := range r.nameToSpec {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (*registry) isRegistry() {}
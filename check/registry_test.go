@@ -0,0 +1,38 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	require.NoError(t, registry.Register("file-name", testNewFileNameAnnotationSpec()))
+	require.NoError(t, registry.Register("blocking", testNewBlockingSpec()))
+	require.Error(t, registry.Register("file-name", testNewFileNameAnnotationSpec()))
+	require.Error(t, registry.Register("invalid", &Spec{}))
+	require.Equal(t, []string{"blocking", "file-name"}, registry.Names())
+
+	client, err := registry.Client("file-name")
+	require.NoError(t, err)
+	testCheckFileName(t, client)
+	_, err = registry.Client("unknown")
+	require.Error(t, err)
+}
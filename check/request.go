@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"slices"
 	"sort"
+	"sync"
 
 	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
 	"buf.build/go/bufplugin/descriptor"
@@ -35,6 +36,19 @@ type Request interface {
 	//
 	// FileDescriptors are guaranteed to be unique with respect to their name.
 	FileDescriptors() []descriptor.FileDescriptor
+	// DependencyGraph returns the DependencyGraph of FileDescriptors.
+	//
+	// This can be used to traverse the FileDescriptors in dependency order, or to find the
+	// files that import a given file.
+	DependencyGraph() descriptor.DependencyGraph
+	// Resolver returns a descriptor.Resolver for FileDescriptors.
+	//
+	// This can be used to resolve the types and extensions defined within FileDescriptors,
+	// for example to unmarshal custom options that are defined within the files being checked.
+	// See descriptor.Resolver for more details.
+	//
+	// The Resolver is built on first use, and the same Resolver is returned on every call.
+	Resolver() (descriptor.Resolver, error)
 	// AgainstFileDescriptors contains the FileDescriptors to check against, in the
 	// case of breaking change plugins.
 	//
@@ -43,6 +57,10 @@ type Request interface {
 	//
 	// FileDescriptors are guaranteed to be unique with respect to their name.
 	AgainstFileDescriptors() []descriptor.FileDescriptor
+	// AgainstResolver returns a descriptor.Resolver for AgainstFileDescriptors.
+	//
+	// The Resolver is built on first use, and the same Resolver is returned on every call.
+	AgainstResolver() (descriptor.Resolver, error)
 	// Options contains any options passed to the plugin.
 	//
 	// Will never be nil, but may have no values.
@@ -58,6 +76,21 @@ type Request interface {
 	// RuleHandlers can safely ignore this - the handling of RuleIDs will have already
 	// been performed prior to the Request reaching the RuleHandler.
 	RuleIDs() []string
+	// State returns the opaque state supplied by the caller with WithState.
+	//
+	// Returns nil if no state was supplied. The state is produced by a previous Check call,
+	// see Response.State. Callers should treat the state as opaque; RuleHandlers should use
+	// RuleState instead.
+	State() []byte
+	// RuleState returns the state of the Rule being run, as set with ResponseWriter.SetState
+	// during a previous Check call.
+	//
+	// Returns nil if the Rule has no state, or if the caller did not supply state with
+	// WithState. Only populated on Requests passed to RuleHandlers.
+	//
+	// This can be used to implement baselines: a Rule stores the violations it has accepted,
+	// and only adds Annotations for new violations.
+	RuleState() []byte
 
 	// toProtos converts the Request into one or more CheckRequests.
 	//
@@ -106,8 +139,30 @@ func WithRuleIDs(ruleIDs ...string) RequestOption {
 	}
 }
 
+// WithState adds the given opaque state to the Request.
+//
+// The state should be the value of Response.State from a previous Check call, or empty to
+// start with no state. If state is supplied, the Response will have the updated state,
+// which the caller is responsible for persisting between calls.
+//
+// If the plugin does not support state, the state is returned unchanged.
+func WithState(state []byte) RequestOption {
+	return func(requestOptions *requestOptions) {
+		if state == nil {
+			state = []byte{}
+		}
+		requestOptions.state = state
+	}
+}
+
 // RequestForProtoRequest returns a new Request for the given checkv1.Request.
 func RequestForProtoRequest(protoRequest *checkv1.CheckRequest) (Request, error) {
+	return requestForProtoRequest(protoRequest)
+}
+
+// *** PRIVATE ***
+
+func requestForProtoRequest(protoRequest *checkv1.CheckRequest, requestOptions ...RequestOption) (Request, error) {
 	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(protoRequest.GetFileDescriptors())
 	if err != nil {
 		return nil, err
@@ -122,19 +177,27 @@ func RequestForProtoRequest(protoRequest *checkv1.CheckRequest) (Request, error)
 	}
 	return NewRequest(
 		fileDescriptors,
-		WithAgainstFileDescriptors(againstFileDescriptors),
-		WithOptions(options),
-		WithRuleIDs(protoRequest.GetRuleIds()...),
+		append(
+			[]RequestOption{
+				WithAgainstFileDescriptors(againstFileDescriptors),
+				WithOptions(options),
+				WithRuleIDs(protoRequest.GetRuleIds()...),
+			},
+			requestOptions...,
+		)...,
 	)
 }
 
-// *** PRIVATE ***
-
 type request struct {
 	fileDescriptors        []descriptor.FileDescriptor
+	dependencyGraph        descriptor.DependencyGraph
 	againstFileDescriptors []descriptor.FileDescriptor
 	options                option.Options
 	ruleIDs                []string
+	state                  []byte
+
+	getResolver        func() (descriptor.Resolver, error)
+	getAgainstResolver func() (descriptor.Resolver, error)
 }
 
 func newRequest(
@@ -158,11 +221,27 @@ func newRequest(
 	if err := validateFileDescriptors(requestOptions.againstFileDescriptors); err != nil {
 		return nil, err
 	}
+	dependencyGraph, err := descriptor.NewDependencyGraph(fileDescriptors)
+	if err != nil {
+		return nil, err
+	}
 	return &request{
 		fileDescriptors:        fileDescriptors,
+		dependencyGraph:        dependencyGraph,
 		againstFileDescriptors: requestOptions.againstFileDescriptors,
 		options:                requestOptions.options,
 		ruleIDs:                requestOptions.ruleIDs,
+		state:                  requestOptions.state,
+		getResolver: sync.OnceValues(
+			func() (descriptor.Resolver, error) {
+				return descriptor.ResolverForFileDescriptors(fileDescriptors)
+			},
+		),
+		getAgainstResolver: sync.OnceValues(
+			func() (descriptor.Resolver, error) {
+				return descriptor.ResolverForFileDescriptors(requestOptions.againstFileDescriptors)
+			},
+		),
 	}, nil
 }
 
@@ -170,10 +249,22 @@ func (r *request) FileDescriptors() []descriptor.FileDescriptor {
 	return slices.Clone(r.fileDescriptors)
 }
 
+func (r *request) DependencyGraph() descriptor.DependencyGraph {
+	return r.dependencyGraph
+}
+
+func (r *request) Resolver() (descriptor.Resolver, error) {
+	return r.getResolver()
+}
+
 func (r *request) AgainstFileDescriptors() []descriptor.FileDescriptor {
 	return slices.Clone(r.againstFileDescriptors)
 }
 
+func (r *request) AgainstResolver() (descriptor.Resolver, error) {
+	return r.getAgainstResolver()
+}
+
 func (r *request) Options() option.Options {
 	return r.options
 }
@@ -182,6 +273,14 @@ func (r *request) RuleIDs() []string {
 	return slices.Clone(r.ruleIDs)
 }
 
+func (r *request) State() []byte {
+	return slices.Clone(r.state)
+}
+
+func (*request) RuleState() []byte {
+	return nil
+}
+
 func (r *request) toProtos() ([]*checkv1.CheckRequest, error) {
 	if r == nil {
 		return nil, nil
@@ -223,6 +322,37 @@ func (r *request) toProtos() ([]*checkv1.CheckRequest, error) {
 
 func (*request) isRequest() {}
 
+// requestWithOptions returns a copy of the Request with its Options replaced.
+func requestWithOptions(request Request, options option.Options) (Request, error) {
+	requestOptions := []RequestOption{
+		WithAgainstFileDescriptors(request.AgainstFileDescriptors()),
+		WithOptions(options),
+		WithRuleIDs(request.RuleIDs()...),
+	}
+	if state := request.State(); state != nil {
+		requestOptions = append(requestOptions, WithState(state))
+	}
+	return NewRequest(request.FileDescriptors(), requestOptions...)
+}
+
+// ruleRequest is a Request for a single Rule, with the state of the Rule.
+type ruleRequest struct {
+	Request
+
+	ruleState []byte
+}
+
+func newRuleRequest(request Request, ruleState []byte) *ruleRequest {
+	return &ruleRequest{
+		Request:   request,
+		ruleState: ruleState,
+	}
+}
+
+func (r *ruleRequest) RuleState() []byte {
+	return slices.Clone(r.ruleState)
+}
+
 func validateFileDescriptors(fileDescriptors []descriptor.FileDescriptor) error {
 	_, err := fileNameToFileDescriptorForFileDescriptors(fileDescriptors)
 	return err
@@ -231,7 +361,7 @@ func validateFileDescriptors(fileDescriptors []descriptor.FileDescriptor) error
 func fileNameToFileDescriptorForFileDescriptors(fileDescriptors []descriptor.FileDescriptor) (map[string]descriptor.FileDescriptor, error) {
 	fileNameToFileDescriptor := make(map[string]descriptor.FileDescriptor, len(fileDescriptors))
 	for _, fileDescriptor := range fileDescriptors {
-		fileName := fileDescriptor.ProtoreflectFileDescriptor().Path()
+		fileName := fileDescriptor.FileDescriptorProto().GetName()
 		if _, ok := fileNameToFileDescriptor[fileName]; ok {
 			return nil, fmt.Errorf("duplicate file name: %q", fileName)
 		}
@@ -244,6 +374,10 @@ type requestOptions struct {
 	againstFileDescriptors []descriptor.FileDescriptor
 	options                option.Options
 	ruleIDs                []string
+	state                  []byte
+
+	getResolver        func() (descriptor.Resolver, error)
+	getAgainstResolver func() (descriptor.Resolver, error)
 }
 
 func newRequestOptions() *requestOptions {
@@ -0,0 +1,101 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestRequestResolver(t *testing.T) {
+	t.Parallel()
+
+	// The custom option (foo.safe) = true, set as unknown fields as a compiler would
+	// produce when the option is not linked into the Go binary.
+	messageOptions := &descriptorpb.MessageOptions{}
+	messageOptions.ProtoReflect().SetUnknown(
+		protowire.AppendVarint(protowire.AppendTag(nil, 50000, protowire.VarintType), 1),
+	)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+				IsImport:            true,
+			},
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:       proto.String("foo.proto"),
+					Package:    proto.String("foo"),
+					Dependency: []string{"google/protobuf/descriptor.proto"},
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name:    proto.String("Foo"),
+							Options: messageOptions,
+						},
+					},
+					Extension: []*descriptorpb.FieldDescriptorProto{
+						{
+							Name:     proto.String("safe"),
+							Number:   proto.Int32(50000),
+							Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+							Type:     descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+							Extendee: proto.String(".google.protobuf.MessageOptions"),
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors)
+	require.NoError(t, err)
+
+	resolver, err := request.Resolver()
+	require.NoError(t, err)
+	sameResolver, err := request.Resolver()
+	require.NoError(t, err)
+	assert.Same(t, resolver, sameResolver)
+
+	messageDescriptor := fileDescriptors[1].ProtoreflectFileDescriptor().Messages().ByName("Foo")
+	require.NotNil(t, messageDescriptor)
+	data, err := proto.Marshal(messageDescriptor.Options())
+	require.NoError(t, err)
+	resolvedMessageOptions := &descriptorpb.MessageOptions{}
+	require.NoError(t, proto.UnmarshalOptions{Resolver: resolver}.Unmarshal(data, resolvedMessageOptions))
+	assert.Empty(t, resolvedMessageOptions.ProtoReflect().GetUnknown())
+	var values []string
+	resolvedMessageOptions.ProtoReflect().Range(
+		func(fieldDescriptor protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			values = append(values, fmt.Sprintf("%s=%v", fieldDescriptor.FullName(), value.Interface()))
+			return true
+		},
+	)
+	assert.Equal(t, []string{"foo.safe=true"}, values)
+
+	againstResolver, err := request.AgainstResolver()
+	require.NoError(t, err)
+	_, err = againstResolver.FindExtensionByName("foo.safe")
+	assert.Error(t, err)
+}
@@ -27,6 +27,11 @@ type Response interface {
 	//
 	// The returned annotations will be sorted.
 	Annotations() []Annotation
+	// State returns the updated opaque state.
+	//
+	// Returns nil if no state was supplied on the Request with WithState. Callers should
+	// persist the state, and supply it on the next Request.
+	State() []byte
 
 	toProto() *checkv1.CheckResponse
 
@@ -37,12 +42,14 @@ type Response interface {
 
 type response struct {
 	annotations []Annotation
+	state       []byte
 }
 
-func newResponse(annotations []Annotation) (*response, error) {
+func newResponse(annotations []Annotation, state []byte) (*response, error) {
 	sortAnnotations(annotations)
 	return &response{
 		annotations: annotations,
+		state:       state,
 	}, nil
 }
 
@@ -50,6 +57,10 @@ func (r *response) Annotations() []Annotation {
 	return slices.Clone(r.annotations)
 }
 
+func (r *response) State() []byte {
+	return slices.Clone(r.state)
+}
+
 func (r *response) toProto() *checkv1.CheckResponse {
 	return &checkv1.CheckResponse{
 		Annotations: xslices.Map(r.annotations, Annotation.toProto),
@@ -17,7 +17,9 @@ package check
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"buf.build/go/bufplugin/descriptor"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -50,6 +52,14 @@ type ResponseWriter interface {
 	//
 	// Most users will use WithDescriptor/WithAgainstDescriptor as opposed to their lower-level variants.
 	AddAnnotation(options ...AddAnnotationOption)
+	// SetState sets the state of the Rule that is tied to this ResponseWriter.
+	//
+	// The state is returned to the caller as part of the opaque Response.State, and is
+	// available as Request.RuleState on the next Check call. Setting a nil state removes the
+	// state of the Rule. If SetState is not called, the state of the Rule is unchanged.
+	//
+	// Has no effect if the caller did not supply state with WithState.
+	SetState(state []byte)
 
 	isResponseWriter()
 }
@@ -163,9 +173,11 @@ type multiResponseWriter struct {
 	againstFileNameToFileDescriptor map[string]descriptor.FileDescriptor
 
 	annotations []Annotation
-	written     bool
-	errs        []error
-	lock        sync.RWMutex
+	// ruleIDToState is nil if the Request has no state.
+	ruleIDToState map[string][]byte
+	written       bool
+	errs          []error
+	lock          sync.RWMutex
 }
 
 func newMultiResponseWriter(request Request) (*multiResponseWriter, error) {
@@ -177,9 +189,17 @@ func newMultiResponseWriter(request Request) (*multiResponseWriter, error) {
 	if err != nil {
 		return nil, err
 	}
+	var ruleIDToState map[string][]byte
+	if state := request.State(); state != nil {
+		ruleIDToState, err = unmarshalState(state)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &multiResponseWriter{
 		fileNameToFileDescriptor:        fileNameToFileDescriptor,
 		againstFileNameToFileDescriptor: againstFileNameToFileDescriptor,
+		ruleIDToState:                   ruleIDToState,
 	}, nil
 }
 
@@ -239,14 +259,57 @@ func (m *multiResponseWriter) addAnnotation(
 		m.errs = append(m.errs, err)
 		return
 	}
+	annotation.severityOverride = addAnnotationOptions.severityOverride
 
 	m.annotations = append(m.annotations, annotation)
 }
 
-func (m *multiResponseWriter) toResponse() (Response, error) {
+// ruleState returns the state of the Rule with the given ID.
+func (m *multiResponseWriter) ruleState(ruleID string) []byte {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
+	return m.ruleIDToState[ruleID]
+}
+
+func (m *multiResponseWriter) setRuleState(ruleID string, state []byte) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.ruleIDToState == nil {
+		return
+	}
+	if m.written {
+		m.errs = append(m.errs, errCannotReuseResponseWriter)
+		return
+	}
+	if state == nil {
+		delete(m.ruleIDToState, ruleID)
+		return
+	}
+	m.ruleIDToState[ruleID] = slices.Clone(state)
+}
+
+// setState replaces the state of all Rules with the given opaque state.
+//
+// This is used by Clients to set the state returned from a plugin.
+func (m *multiResponseWriter) setState(state []byte) error {
+	ruleIDToState, err := unmarshalState(state)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.ruleIDToState = ruleIDToState
+	return nil
+}
+
+func (m *multiResponseWriter) toResponse() (Response, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
 	if len(m.errs) > 0 {
 		return nil, errors.Join(m.errs...)
 	}
@@ -255,12 +318,22 @@ func (m *multiResponseWriter) toResponse() (Response, error) {
 	}
 	m.written = true
 
-	return newResponse(m.annotations)
+	var state []byte
+	if m.ruleIDToState != nil {
+		var err error
+		state, err = marshalState(m.ruleIDToState)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newResponse(m.annotations, state)
 }
 
 type responseWriter struct {
 	multiResponseWriter *multiResponseWriter
 	id                  string
+	// numAnnotations is the number of calls to AddAnnotation.
+	numAnnotations atomic.Int64
 }
 
 func newResponseWriter(
@@ -276,9 +349,14 @@ func newResponseWriter(
 func (r *responseWriter) AddAnnotation(
 	options ...AddAnnotationOption,
 ) {
+	r.numAnnotations.Add(1)
 	r.multiResponseWriter.addAnnotation(r.id, options...)
 }
 
+func (r *responseWriter) SetState(state []byte) {
+	r.multiResponseWriter.setRuleState(r.id, state)
+}
+
 func (*responseWriter) isResponseWriter() {}
 
 type addAnnotationOptions struct {
@@ -289,6 +367,8 @@ type addAnnotationOptions struct {
 	sourcePath        protoreflect.SourcePath
 	againstFileName   string
 	againstSourcePath protoreflect.SourcePath
+	// severityOverride is only set by Clients, see withSeverityOverride.
+	severityOverride Severity
 }
 
 func newAddAnnotationOptions() *addAnnotationOptions {
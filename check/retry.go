@@ -0,0 +1,156 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"syscall"
+	"time"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// DefaultRetryPolicy is a RetryPolicy suitable for most plugins.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 50 * time.Millisecond,
+	MaxBackoff:     1 * time.Second,
+}
+
+// RetryPolicy is a policy for retrying invocations of a plugin that fail because of
+// transient transport failures.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first attempt.
+	//
+	// A value <= 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the duration to wait before the first retry.
+	//
+	// The duration is doubled for each subsequent retry, up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum duration to wait before a retry.
+	//
+	// A value of 0 means that there is no maximum.
+	MaxBackoff time.Duration
+}
+
+// NewRunnerWithRetry returns a new pluginrpc.Runner that retries invocations of the plugin
+// run by the given pluginrpc.Runner that fail because of transient transport failures.
+//
+// Transient failures are failures to start the plugin or communicate with it, such as a
+// broken pipe while the plugin is starting, or a refused or reset connection on socket
+// transports. Invocations where the plugin ran and returned an error, or exited with a
+// non-zero exit code, are deterministic and are not retried. Invocations are not retried
+// once the context is done.
+//
+// Stdout and stderr are only written to for the final attempt.
+//
+//	client := check.NewClient(
+//		pluginrpc.NewClient(
+//			check.NewRunnerWithRetry(pluginrpc.NewExecRunner("buf-plugin-foo"), check.DefaultRetryPolicy),
+//		),
+//	)
+func NewRunnerWithRetry(runner pluginrpc.Runner, retryPolicy RetryPolicy) pluginrpc.Runner {
+	return &retryRunner{
+		delegate:    runner,
+		retryPolicy: retryPolicy,
+	}
+}
+
+// *** PRIVATE ***
+
+type retryRunner struct {
+	delegate    pluginrpc.Runner
+	retryPolicy RetryPolicy
+}
+
+func (r *retryRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	if r.retryPolicy.MaxAttempts <= 1 {
+		return r.delegate.Run(ctx, env)
+	}
+	var stdin []byte
+	if env.Stdin != nil {
+		var err error
+		stdin, err = io.ReadAll(env.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+	backoff := r.retryPolicy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		attemptEnv := pluginrpc.Env{
+			Args:   env.Args,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		if env.Stdin != nil {
+			attemptEnv.Stdin = bytes.NewReader(stdin)
+		}
+		err := r.delegate.Run(ctx, attemptEnv)
+		if err == nil || attempt >= r.retryPolicy.MaxAttempts || ctx.Err() != nil || !isTransientError(err) {
+			if env.Stdout != nil {
+				if _, writeErr := env.Stdout.Write(stdout.Bytes()); writeErr != nil {
+					return errors.Join(err, writeErr)
+				}
+			}
+			if env.Stderr != nil {
+				if _, writeErr := env.Stderr.Write(stderr.Bytes()); writeErr != nil {
+					return errors.Join(err, writeErr)
+				}
+			}
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+		if r.retryPolicy.MaxBackoff > 0 {
+			backoff = min(backoff, r.retryPolicy.MaxBackoff)
+		}
+	}
+}
+
+// isTransientError returns true if the error is a transport failure that may succeed
+// if retried.
+func isTransientError(err error) bool {
+	if exitError := (&pluginrpc.ExitError{}); errors.As(err, &exitError) {
+		// The plugin ran and exited with a non-zero exit code.
+		return false
+	}
+	if pluginrpcError := (&pluginrpc.Error{}); errors.As(err, &pluginrpcError) {
+		return false
+	}
+	for _, errno := range []syscall.Errno{
+		syscall.EPIPE,
+		syscall.EAGAIN,
+		syscall.ECONNREFUSED,
+		syscall.ECONNRESET,
+		syscall.ETXTBSY,
+	} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
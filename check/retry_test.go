@@ -0,0 +1,74 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRunnerWithRetry(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+	retryPolicy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	}
+
+	// Transient failures are retried, and their output is discarded.
+	var numFailures int
+	runner := &testRecordingRunner{
+		run: func(ctx context.Context, env pluginrpc.Env) error {
+			if numFailures < 2 {
+				numFailures++
+				_, _ = io.WriteString(env.Stdout, "garbage")
+				return fmt.Errorf("write |1: %w", syscall.EPIPE)
+			}
+			numFailures = 0
+			return serverRunner.Run(ctx, env)
+		},
+	}
+	testCheckFileName(t, NewClient(pluginrpc.NewClient(NewRunnerWithRetry(runner, retryPolicy))))
+
+	// Failures are returned once the maximum number of attempts is reached.
+	runner = &testRecordingRunner{
+		run: func(context.Context, pluginrpc.Env) error {
+			return syscall.ECONNREFUSED
+		},
+	}
+	_, err = NewClient(pluginrpc.NewClient(NewRunnerWithRetry(runner, retryPolicy))).ListRules(context.Background())
+	require.ErrorIs(t, err, syscall.ECONNREFUSED)
+	require.Len(t, runner.allArgs, 3)
+
+	// Deterministic failures are not retried.
+	runner = &testRecordingRunner{
+		run: func(context.Context, pluginrpc.Env) error {
+			return pluginrpc.NewExitError(1, syscall.EPIPE)
+		},
+	}
+	_, err = NewClient(pluginrpc.NewClient(NewRunnerWithRetry(runner, retryPolicy))).ListRules(context.Background())
+	require.Error(t, err)
+	require.Len(t, runner.allArgs, 1)
+}
@@ -17,6 +17,7 @@ package check
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"slices"
 	"sort"
 
@@ -28,6 +29,10 @@ import (
 //
 // Rules have unique IDs. On the server-side (i.e. the plugin), Rules are created
 // by RuleSpecs. Clients can list all available plugin Rules by calling ListRules.
+//
+// Some properties are not part of the v1 CheckService protocol. These are only populated
+// on Rules returned from NewPluginDocumentationForSpec, and will be empty on Rules returned
+// from a Client. Such properties are noted below.
 type Rule interface {
 	// ID is the ID of the Rule.
 	//
@@ -70,6 +75,37 @@ type Rule interface {
 	// It is not valid for a deprecated Rule to specfiy another deprecated Rule as a replacement.
 	ReplacementIDs() []string
 
+	// Documentation returns the long-form documentation of the Rule.
+	//
+	// Optional.
+	//
+	// This is CommonMark, and expands on Purpose.
+	//
+	// Not transmitted over the CheckService.
+	Documentation() string
+	// Examples returns examples that illustrate the Rule.
+	//
+	// Optional.
+	//
+	// Each example is CommonMark, typically a short explanation followed by a fenced code block.
+	//
+	// Not transmitted over the CheckService.
+	Examples() []string
+	// DocumentationURL returns a URL that contains further documentation for the Rule.
+	//
+	// Optional.
+	//
+	// Not transmitted over the CheckService.
+	DocumentationURL() *url.URL
+
+	// Policy returns the Policy of the Rule, if the Rule is a policy Rule.
+	//
+	// Optional.
+	//
+	// Policies are transmitted with the ListPolicies procedure if the plugin has any policy
+	// Rules, and will be populated on Rules returned from a Client.
+	Policy() Policy
+
 	toProto() *checkv1.Rule
 
 	isRule()
@@ -85,6 +121,9 @@ type rule struct {
 	ruleType       RuleType
 	deprecated     bool
 	replacementIDs []string
+	policy         Policy
+
+	documentationProperties
 }
 
 func newRule(
@@ -95,6 +134,8 @@ func newRule(
 	ruleType RuleType,
 	deprecated bool,
 	replacementIDs []string,
+	policy Policy,
+	documentationProperties documentationProperties,
 ) (*rule, error) {
 	if id == "" {
 		return nil, errors.New("check.Rule: ID is empty")
@@ -116,6 +157,9 @@ func newRule(
 		ruleType:       ruleType,
 		deprecated:     deprecated,
 		replacementIDs: replacementIDs,
+		policy:         policy,
+
+		documentationProperties: documentationProperties,
 	}, nil
 }
 
@@ -147,6 +191,10 @@ func (r *rule) ReplacementIDs() []string {
 	return slices.Clone(r.replacementIDs)
 }
 
+func (r *rule) Policy() Policy {
+	return r.policy
+}
+
 func (r *rule) toProto() *checkv1.Rule {
 	if r == nil {
 		return nil
@@ -165,7 +213,11 @@ func (r *rule) toProto() *checkv1.Rule {
 
 func (*rule) isRule() {}
 
-func ruleForProtoRule(protoRule *checkv1.Rule, idToCategory map[string]Category) (Rule, error) {
+func ruleForProtoRule(
+	protoRule *checkv1.Rule,
+	idToCategory map[string]Category,
+	ruleIDToPolicy map[string]Policy,
+) (Rule, error) {
 	categories, err := xslices.MapError(
 		protoRule.GetCategoryIds(),
 		func(id string) (Category, error) {
@@ -188,6 +240,8 @@ func ruleForProtoRule(protoRule *checkv1.Rule, idToCategory map[string]Category)
 		ruleType,
 		protoRule.GetDeprecated(),
 		protoRule.GetReplacementIds(),
+		ruleIDToPolicy[protoRule.GetId()],
+		documentationProperties{},
 	)
 }
 
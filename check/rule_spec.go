@@ -51,6 +51,24 @@ type RuleSpec struct {
 	Type           RuleType
 	Deprecated     bool
 	ReplacementIDs []string
+	// Documentation is the long-form documentation of the Rule as CommonMark.
+	//
+	// Optional.
+	Documentation string
+	// Examples are CommonMark examples that illustrate the Rule.
+	//
+	// Optional.
+	Examples []string
+	// DocumentationURL is a URL that contains further documentation for the Rule.
+	//
+	// Optional.
+	//
+	// Must be absolute if set.
+	DocumentationURL string
+	// Policy makes the Rule a policy Rule if set.
+	//
+	// Optional.
+	Policy *PolicySpec
 	// Required.
 	Handler RuleHandler
 }
@@ -59,6 +77,14 @@ type RuleSpec struct {
 
 // Assumes that the RuleSpec is validated.
 func ruleSpecToRule(ruleSpec *RuleSpec, idToCategory map[string]Category) (Rule, error) {
+	documentationProperties, err := newDocumentationProperties(
+		ruleSpec.Documentation,
+		ruleSpec.Examples,
+		ruleSpec.DocumentationURL,
+	)
+	if err != nil {
+		return nil, err
+	}
 	categories, err := xslices.MapError(
 		ruleSpec.CategoryIDs,
 		func(id string) (Category, error) {
@@ -72,6 +98,10 @@ func ruleSpecToRule(ruleSpec *RuleSpec, idToCategory map[string]Category) (Rule,
 	if err != nil {
 		return nil, err
 	}
+	policy, err := policySpecToPolicy(ruleSpec.Policy)
+	if err != nil {
+		return nil, err
+	}
 	return newRule(
 		ruleSpec.ID,
 		categories,
@@ -80,6 +110,8 @@ func ruleSpecToRule(ruleSpec *RuleSpec, idToCategory map[string]Category) (Rule,
 		ruleSpec.Type,
 		ruleSpec.Deprecated,
 		ruleSpec.ReplacementIDs,
+		policy,
+		documentationProperties,
 	)
 }
 
@@ -107,12 +139,28 @@ func validateRuleSpecs(
 		if err := validatePurpose(ruleSpec.ID, ruleSpec.Purpose); err != nil {
 			return wrapValidateRuleSpecError(err)
 		}
+		if err := validateDocumentationFields(
+			ruleSpec.ID,
+			ruleSpec.Documentation,
+			ruleSpec.Examples,
+			ruleSpec.DocumentationURL,
+		); err != nil {
+			return wrapValidateRuleSpecError(err)
+		}
 		if ruleSpec.Type == 0 {
 			return newValidateRuleSpecErrorf("Type is not set for ID %q", ruleSpec.ID)
 		}
 		if _, ok := ruleTypeToProtoRuleType[ruleSpec.Type]; !ok {
 			return newValidateRuleSpecErrorf("Type is unknown: %q", ruleSpec.Type)
 		}
+		if ruleSpec.Policy != nil {
+			if ruleSpec.Policy.ID == "" {
+				return newValidateRuleSpecErrorf("Policy.ID is not set for ID %q", ruleSpec.ID)
+			}
+			if _, ok := severityToString[ruleSpec.Policy.Severity]; !ok {
+				return newValidateRuleSpecErrorf("Policy.Severity is not set or unknown for ID %q: %v", ruleSpec.ID, ruleSpec.Policy.Severity)
+			}
+		}
 		if ruleSpec.Handler == nil {
 			return newValidateRuleSpecErrorf("Handler is not set for ID %q", ruleSpec.ID)
 		}
@@ -0,0 +1,182 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// Sandbox restricts plugins run by a pluginrpc.Runner created with NewExecRunner, to reduce
+// the impact of running untrusted plugins.
+//
+// The zero value results in no restrictions other than the defaults of NewExecRunner, which
+// runs plugins with no environment variables in the current working directory.
+//
+// MaxCPUTime, MaxMemory, and DenyNetwork are only supported on Linux. On other platforms,
+// invocations fail if any of these are set.
+//
+// MaxCPUTime and MaxMemory are applied as resource limits before the plugin is executed, so
+// they also apply to every process that the plugin starts. As os/exec cannot set resource
+// limits for a child process, the current executable is run as a helper that sets the limits
+// and then executes the plugin. Resource limits are per process, so a plugin that starts
+// other processes can exceed the limits in total. For limits on the total usage, run the
+// plugin in a container or cgroup instead.
+type Sandbox struct {
+	// Env is the environment of the plugin, as "KEY=value" strings.
+	//
+	// The environment is never inherited from the current process. If empty, the plugin is
+	// run with no environment variables other than those forwarded with
+	// ExecRunnerWithForwardedEnv. Values set here take precedence over forwarded values.
+	Env []string
+	// Dir is the working directory of the plugin.
+	//
+	// If empty, the plugin is run in the current working directory.
+	Dir string
+	// MaxCPUTime is the maximum CPU time that the plugin may use.
+	//
+	// The plugin is killed by the operating system if it exceeds the limit. A value of 0 means
+	// that there is no limit. The limit is rounded up to a whole number of seconds.
+	MaxCPUTime time.Duration
+	// MaxMemory is the maximum size in bytes of the virtual memory of the plugin.
+	//
+	// Allocations fail once the limit is reached, which typically results in the plugin
+	// crashing. A value of 0 means that there is no limit.
+	MaxMemory int64
+	// MaxOutputSize is the maximum number of bytes that the plugin may write to stdout and
+	// stderr combined.
+	//
+	// The plugin is stopped if it exceeds the limit, and the invocation fails with
+	// CodeResourceExhausted. A value of 0 means that there is no limit.
+	MaxOutputSize int64
+	// DenyNetwork runs the plugin without network access.
+	//
+	// On Linux, the plugin is run in new user and network namespaces, which requires
+	// unprivileged user namespaces to be enabled.
+	DenyNetwork bool
+}
+
+// ExecRunnerWithSandbox returns a new ExecRunnerOption that runs the plugin in the given
+// Sandbox.
+//
+//	client := check.NewClient(
+//		pluginrpc.NewClient(
+//			check.NewExecRunner(
+//				"buf-plugin-foo",
+//				check.ExecRunnerWithSandbox(
+//					check.Sandbox{
+//						MaxCPUTime:    time.Minute,
+//						MaxOutputSize: 64 << 20,
+//						DenyNetwork:   true,
+//					},
+//				),
+//			),
+//		),
+//	)
+//
+// The default is to not restrict the plugin.
+func ExecRunnerWithSandbox(sandbox Sandbox) ExecRunnerOption {
+	return func(execRunnerOptions *execRunnerOptions) {
+		execRunnerOptions.sandbox = sandbox
+	}
+}
+
+// *** PRIVATE ***
+
+// errSandboxUnsupported is returned if a Sandbox restriction is not supported on the
+// current platform.
+var errSandboxUnsupported = fmt.Errorf("MaxCPUTime, MaxMemory, and DenyNetwork are not supported on %s", runtime.GOOS)
+
+// outputLimiter limits the total number of bytes written to its writers.
+//
+// The writers are written to concurrently by os/exec.
+type outputLimiter struct {
+	maxSize int64
+	// onExceeded is called once when the limit is exceeded.
+	onExceeded func(error)
+	size       int64
+	exceeded   bool
+	lock       sync.Mutex
+}
+
+func newOutputLimiter(maxSize int64, onExceeded func(error)) *outputLimiter {
+	return &outputLimiter{
+		maxSize:    maxSize,
+		onExceeded: onExceeded,
+	}
+}
+
+// newWriter returns a new io.Writer that writes to the delegate, counting towards the limit.
+//
+// If the delegate is nil, writes are discarded.
+func (o *outputLimiter) newWriter(delegate io.Writer) io.Writer {
+	if delegate == nil {
+		delegate = io.Discard
+	}
+	return &limitedWriter{
+		outputLimiter: o,
+		delegate:      delegate,
+	}
+}
+
+func (o *outputLimiter) add(n int) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.size += int64(n)
+	if o.size <= o.maxSize {
+		return nil
+	}
+	err := pluginrpc.NewErrorf(
+		pluginrpc.CodeResourceExhausted,
+		"plugin exceeded the maximum output size of %d bytes",
+		o.maxSize,
+	)
+	if !o.exceeded {
+		o.exceeded = true
+		o.onExceeded(err)
+	}
+	return err
+}
+
+type limitedWriter struct {
+	outputLimiter *outputLimiter
+	delegate      io.Writer
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if err := l.outputLimiter.add(len(p)); err != nil {
+		return 0, err
+	}
+	return l.delegate.Write(p)
+}
+
+// contextCauseError returns the cause of the context if it is done because of the given
+// cancel cause, as opposed to the parent context being done.
+func contextCauseError(ctx context.Context, parentCtx context.Context) error {
+	if parentCtx.Err() != nil {
+		return nil
+	}
+	if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.Canceled) {
+		return cause
+	}
+	return nil
+}
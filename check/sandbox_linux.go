@@ -0,0 +1,119 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// *** PRIVATE ***
+
+// sandboxHelperArg0 is the argv[0] that the current executable is run with to act as the
+// sandbox helper, see runSandboxHelper.
+const sandboxHelperArg0 = "bufplugin-sandbox-helper"
+
+func init() {
+	if len(os.Args) > 0 && os.Args[0] == sandboxHelperArg0 {
+		runSandboxHelper(os.Args[1:])
+	}
+}
+
+// apply applies the restrictions of the Sandbox to the command before it is started.
+//
+// Resource limits cannot be set for a child process by os/exec, and setting them on the
+// current process would affect all of its goroutines. Instead, the current executable is
+// run as a helper that sets the limits on itself and then executes the plugin, which
+// inherits them. The plugin therefore never runs without the limits.
+func (s Sandbox) apply(cmd *exec.Cmd) error {
+	if s.MaxCPUTime > 0 || s.MaxMemory > 0 {
+		// If the program could not be found, cmd.Err is returned by cmd.Start.
+		if cmd.Err == nil {
+			// RLIMIT_CPU is in seconds, rounded up so that the limit is never lower than requested.
+			maxCPUSeconds := int64((s.MaxCPUTime + time.Second - 1) / time.Second)
+			cmd.Args = append(
+				[]string{
+					sandboxHelperArg0,
+					strconv.FormatInt(maxCPUSeconds, 10),
+					strconv.FormatInt(s.MaxMemory, 10),
+					cmd.Path,
+				},
+				cmd.Args...,
+			)
+			cmd.Path = "/proc/self/exe"
+		}
+	}
+	if s.DenyNetwork {
+		// A new network namespace has no interfaces other than a loopback interface that is down.
+		// Creating a network namespace requires a user namespace for unprivileged users. The
+		// current user and group are mapped into the user namespace, so that file access is not
+		// affected.
+		cmd.SysProcAttr = &syscall.SysProcAttr{
+			Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET,
+			UidMappings: []syscall.SysProcIDMap{
+				{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1},
+			},
+			GidMappings: []syscall.SysProcIDMap{
+				{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1},
+			},
+		}
+	}
+	return nil
+}
+
+// runSandboxHelper sets the resource limits given by the arguments on the current process,
+// and then executes the plugin in its place.
+//
+// The arguments are the maximum CPU time in seconds, the maximum memory in bytes, the path
+// of the plugin, and the arguments of the plugin including argv[0], as set by Sandbox.apply.
+// A limit of 0 means that there is no limit. This never returns.
+func runSandboxHelper(args []string) {
+	if err := execSandboxHelper(args); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", sandboxHelperArg0, err)
+		os.Exit(1)
+	}
+}
+
+func execSandboxHelper(args []string) error {
+	if len(args) < 4 {
+		return fmt.Errorf("expected at least 4 arguments, got %d", len(args))
+	}
+	maxCPUSeconds, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	maxMemory, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return err
+	}
+	if maxCPUSeconds > 0 {
+		if err := unix.Setrlimit(unix.RLIMIT_CPU, &unix.Rlimit{Cur: maxCPUSeconds, Max: maxCPUSeconds}); err != nil {
+			return fmt.Errorf("could not set CPU time limit: %w", err)
+		}
+	}
+	if maxMemory > 0 {
+		if err := unix.Setrlimit(unix.RLIMIT_AS, &unix.Rlimit{Cur: maxMemory, Max: maxMemory}); err != nil {
+			return fmt.Errorf("could not set memory limit: %w", err)
+		}
+	}
+	// The environment of the helper is the environment of the plugin.
+	return syscall.Exec(args[2], args[3:], os.Environ())
+}
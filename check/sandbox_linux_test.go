@@ -0,0 +1,46 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestExecRunnerWithSandboxLimitsBeforeExec(t *testing.T) {
+	t.Parallel()
+
+	// The shell reports the limits that it was executed with. If the limits were applied
+	// after the shell was started, they would not be reported.
+	runner := NewExecRunner(
+		"sh",
+		ExecRunnerWithArgs("-c", "ulimit -t; ulimit -v"),
+		ExecRunnerWithSandbox(
+			Sandbox{
+				MaxCPUTime: 90*time.Second + time.Millisecond,
+				MaxMemory:  1 << 30,
+			},
+		),
+	)
+	stdout := &bytes.Buffer{}
+	require.NoError(t, runner.Run(context.Background(), pluginrpc.Env{Stdout: stdout}))
+	require.Equal(t, []string{"91", "1048576"}, strings.Fields(stdout.String()))
+}
@@ -0,0 +1,31 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package check
+
+import (
+	"os/exec"
+)
+
+// *** PRIVATE ***
+
+// apply applies the restrictions of the Sandbox to the command before it is started.
+func (s Sandbox) apply(*exec.Cmd) error {
+	if s.MaxCPUTime > 0 || s.MaxMemory > 0 || s.DenyNetwork {
+		return errSandboxUnsupported
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestExecRunnerWithSandbox(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugin is a symlink, which is not supported on Windows")
+	}
+	programName := testNewBlockingPluginProgramName(t)
+	listRules := func(sandbox Sandbox) error {
+		_, err := NewClient(
+			pluginrpc.NewClient(
+				NewExecRunner(programName, ExecRunnerWithSandbox(sandbox)),
+			),
+		).ListRules(context.Background())
+		return err
+	}
+
+	require.NoError(t, listRules(Sandbox{Dir: t.TempDir(), Env: []string{"FOO=bar"}}))
+
+	err := listRules(Sandbox{MaxOutputSize: 1})
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeResourceExhausted, pluginrpcError.Code())
+
+	processSandbox := Sandbox{
+		MaxCPUTime:  time.Minute,
+		MaxMemory:   16 << 30,
+		DenyNetwork: true,
+	}
+	err = listRules(processSandbox)
+	if runtime.GOOS != "linux" {
+		require.ErrorIs(t, err, errSandboxUnsupported)
+		return
+	}
+	if errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOSPC) {
+		t.Skipf("unprivileged user namespaces are not available: %v", err)
+	}
+	require.NoError(t, err)
+}
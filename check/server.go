@@ -15,9 +15,17 @@
 package check
 
 import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+
 	"buf.build/go/bufplugin/info"
+	extcheckv1pluginrpc "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1/checkv1pluginrpc"
+	extinfov1pluginrpc "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1/infov1pluginrpc"
 	checkv1pluginrpc "buf.build/go/bufplugin/internal/gen/buf/plugin/check/v1/v1pluginrpc"
 	infov1pluginrpc "buf.build/go/bufplugin/internal/gen/buf/plugin/info/v1/v1pluginrpc"
+	"buf.build/go/bufplugin/option"
 	"pluginrpc.com/pluginrpc"
 )
 
@@ -29,23 +37,216 @@ import (
 // - The Check RPC on the command "check".
 // - The ListRules RPC on the command "list-rules".
 // - The ListCategories RPC on the command "list-categories".
+// - The CheckChunked RPC on the command "check-chunked", see NewRunnerWithChunking.
+// - The CheckWithState RPC on the command "check-with-state", see WithState.
+// - The CheckWithSeverityOverrides RPC on the command "check-with-severity-overrides".
 // - The GetPluginInfo RPC on the command "info" (if spec.Info is present).
+// - The GetPluginInfoExtension RPC on the command "info-extension" (if spec.Info is present).
+// - The ListPolicies RPC on the command "list-policies" (if any RuleSpec has a Policy).
+// - Any procedures added with ServerWithProcedure.
 func NewServer(spec *Spec, options ...ServerOption) (pluginrpc.Server, error) {
 	serverOptions := newServerOptions()
 	for _, option := range options {
 		option(serverOptions)
 	}
-
-	checkServiceHandler, err := NewCheckServiceHandler(spec, CheckServiceHandlerWithParallelism(serverOptions.parallelism))
+	checkServiceHandler, err := newCheckServiceHandlerForServerOptions(spec, options...)
 	if err != nil {
 		return nil, err
 	}
+	return newServer(spec, checkServiceHandler, serverOptions.procedures, serverOptions.pluginrpcServerOptions)
+}
+
+// ServerOption is an option for Server.
+type ServerOption func(*serverOptions)
+
+// ServerWithParallelism returns a new ServerOption that sets the parallelism
+// by which Rules will be run.
+//
+// If this is set to a value >= 1, this many concurrent Rules can be run at the same time.
+// A value of 0 indicates the default behavior, which is to use runtime.GOMAXPROCS(0).
+//
+// A value if < 0 has no effect.
+func ServerWithParallelism(parallelism int) ServerOption {
+	return func(serverOptions *serverOptions) {
+		if parallelism < 0 {
+			parallelism = 0
+		}
+		serverOptions.parallelism = parallelism
+	}
+}
+
+// ServerWithWarningWriter returns a new ServerOption that sets the io.Writer that
+// warnings are written to, such as deprecation warnings for option aliases.
+//
+// The default is to discard warnings.
+func ServerWithWarningWriter(warningWriter io.Writer) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.warningWriter = warningWriter
+	}
+}
+
+// ServerWithCrashReportWriter returns a new ServerOption that sets the io.Writer that
+// crash reports are written to when a RuleHandler panics.
+//
+// See CheckServiceHandlerWithCrashReportWriter. The default is to not write crash reports.
+func ServerWithCrashReportWriter(crashReportWriter io.Writer) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.crashReportWriter = crashReportWriter
+	}
+}
+
+// ServerWithOptionLimits returns a new ServerOption that sets the limits on the options
+// of a request.
+//
+// The default is option.DefaultLimits. Use option.Limits{} to remove all limits.
+func ServerWithOptionLimits(optionLimits option.Limits) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.optionLimits = optionLimits
+	}
+}
+
+// ServerWithDescriptorInterning returns a new ServerOption that interns the FileDescriptors
+// of each request before they are used.
+//
+// See CheckServiceHandlerWithDescriptorInterning for details.
+func ServerWithDescriptorInterning() ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.descriptorInterning = true
+	}
+}
+
+// ServerWithMessageSizeLimits returns a new ServerOption that sets the limits on the size
+// of CheckRequests and CheckResponses.
+//
+// See CheckServiceHandlerWithMessageSizeLimits for details.
+func ServerWithMessageSizeLimits(messageSizeLimits MessageSizeLimits) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.messageSizeLimits = messageSizeLimits
+	}
+}
+
+// ServerWithMaxFileDescriptors returns a new ServerOption that sets the maximum number of
+// FileDescriptors, and separately the maximum number of AgainstFileDescriptors, in a
+// CheckRequest.
+//
+// See CheckServiceHandlerWithMaxFileDescriptors for details.
+func ServerWithMaxFileDescriptors(maxFileDescriptors int) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.maxFileDescriptors = maxFileDescriptors
+	}
+}
+
+// ServerWithMaxConcurrentChecks returns a new ServerOption that sets the maximum number of
+// Check calls that are handled concurrently.
+//
+// See CheckServiceHandlerWithMaxConcurrentChecks for details.
+func ServerWithMaxConcurrentChecks(maxConcurrentChecks int) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.maxConcurrentChecks = maxConcurrentChecks
+	}
+}
+
+// ServerWithLogger returns a new ServerOption that sets the *slog.Logger that is
+// available to RuleHandlers with Logger.
+//
+// See CheckServiceHandlerWithLogger for details.
+func ServerWithLogger(logger *slog.Logger) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.logger = logger
+	}
+}
+
+// ServerWithTracer returns a new ServerOption that sets the Tracer used to trace Check
+// calls and RuleHandler invocations.
+//
+// See CheckServiceHandlerWithTracer for details.
+func ServerWithTracer(tracer Tracer) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.tracer = tracer
+	}
+}
+
+// ServerWithMetrics returns a new ServerOption that sets the Metrics that receive metrics
+// about Check calls and RuleHandler invocations.
+//
+// See CheckServiceHandlerWithMetrics for details.
+func ServerWithMetrics(metrics Metrics) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.metrics = metrics
+	}
+}
+
+// ServerWithProcedure returns a new ServerOption that adds a procedure to the pluginrpc.Server
+// in addition to the procedures for the Check and PluginInfo services.
+//
+// This is an escape hatch for integrators that need to serve procedures that this package does
+// not provide. The handleFunc is registered for the path of the Procedure, as with
+// pluginrpc.ServerRegistrar. The path and args of the Procedure must not conflict with the
+// procedures registered by NewServer, otherwise NewServer returns an error.
+//
+// This may be specified multiple times to add multiple procedures.
+func ServerWithProcedure(
+	procedure pluginrpc.Procedure,
+	handleFunc func(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error,
+) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.procedures = append(
+			serverOptions.procedures,
+			&serverProcedure{
+				procedure:  procedure,
+				handleFunc: handleFunc,
+			},
+		)
+	}
+}
+
+// ServerWithPluginrpcServerOptions returns a new ServerOption that adds pluginrpc.ServerOptions
+// to use when constructing the pluginrpc.Server.
+//
+// This is an escape hatch for integrators that need to configure the pluginrpc.Server in ways
+// that this package does not provide. The pluginrpc.ServerOptions are applied after the options
+// set by NewServer, and therefore take precedence. For example, pluginrpc.ServerWithDoc overrides
+// the documentation derived from spec.Info.
+//
+// This may be specified multiple times, in which case the pluginrpc.ServerOptions are appended.
+func ServerWithPluginrpcServerOptions(pluginrpcServerOptions ...pluginrpc.ServerOption) ServerOption {
+	return func(serverOptions *serverOptions) {
+		serverOptions.pluginrpcServerOptions = append(
+			serverOptions.pluginrpcServerOptions,
+			pluginrpcServerOptions...,
+		)
+	}
+}
+
+// *** PRIVATE ***
+
+// serverProcedure is a procedure added with ServerWithProcedure.
+type serverProcedure struct {
+	procedure  pluginrpc.Procedure
+	handleFunc func(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error
+}
+
+// newServer returns a new pluginrpc.Server for the Spec that uses the given checkServiceHandler.
+//
+// The procedures and pluginrpcServerOptions are added to those for the Spec.
+func newServer(
+	spec *Spec,
+	checkServiceHandler *checkServiceHandler,
+	procedures []*serverProcedure,
+	pluginrpcServerOptions []pluginrpc.ServerOption,
+) (pluginrpc.Server, error) {
 	var pluginInfoServiceHandler infov1pluginrpc.PluginInfoServiceHandler
+	var pluginInfoExtensionServiceHandler extinfov1pluginrpc.PluginInfoExtensionServiceHandler
 	if spec.Info != nil {
+		var err error
 		pluginInfoServiceHandler, err = info.NewPluginInfoServiceHandler(spec.Info)
 		if err != nil {
 			return nil, err
 		}
+		pluginInfoExtensionServiceHandler, err = info.NewPluginInfoExtensionServiceHandler(spec.Info)
+		if err != nil {
+			return nil, err
+		}
 	}
 	pluginrpcSpec, err := checkv1pluginrpc.CheckServiceSpecBuilder{
 		Check:          []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs("check")},
@@ -55,6 +256,38 @@ func NewServer(spec *Spec, options ...ServerOption) (pluginrpc.Server, error) {
 	if err != nil {
 		return nil, err
 	}
+	checkChunkedProcedure, err := newCheckChunkedProcedure()
+	if err != nil {
+		return nil, err
+	}
+	checkChunkedSpec, err := pluginrpc.NewSpec(checkChunkedProcedure)
+	if err != nil {
+		return nil, err
+	}
+	pluginrpcSpec, err = pluginrpc.MergeSpecs(pluginrpcSpec, checkChunkedSpec)
+	if err != nil {
+		return nil, err
+	}
+	checkWithStateSpec, err := extcheckv1pluginrpc.StateServiceSpecBuilder{
+		CheckWithState: []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs(checkWithStateArg)},
+	}.Build()
+	if err != nil {
+		return nil, err
+	}
+	pluginrpcSpec, err = pluginrpc.MergeSpecs(pluginrpcSpec, checkWithStateSpec)
+	if err != nil {
+		return nil, err
+	}
+	checkWithSeverityOverridesSpec, err := extcheckv1pluginrpc.SeverityServiceSpecBuilder{
+		CheckWithSeverityOverrides: []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs(checkWithSeverityOverridesArg)},
+	}.Build()
+	if err != nil {
+		return nil, err
+	}
+	pluginrpcSpec, err = pluginrpc.MergeSpecs(pluginrpcSpec, checkWithSeverityOverridesSpec)
+	if err != nil {
+		return nil, err
+	}
 	if pluginInfoServiceHandler != nil {
 		pluginrpcInfoSpec, err := infov1pluginrpc.PluginInfoServiceSpecBuilder{
 			GetPluginInfo: []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs("info")},
@@ -66,57 +299,135 @@ func NewServer(spec *Spec, options ...ServerOption) (pluginrpc.Server, error) {
 		if err != nil {
 			return nil, err
 		}
+		pluginrpcInfoExtensionSpec, err := extinfov1pluginrpc.PluginInfoExtensionServiceSpecBuilder{
+			GetPluginInfoExtension: []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs("info-extension")},
+		}.Build()
+		if err != nil {
+			return nil, err
+		}
+		pluginrpcSpec, err = pluginrpc.MergeSpecs(pluginrpcSpec, pluginrpcInfoExtensionSpec)
+		if err != nil {
+			return nil, err
+		}
+	}
+	hasPolicies := slices.ContainsFunc(checkServiceHandler.rules, func(rule Rule) bool { return rule.Policy() != nil })
+	if hasPolicies {
+		listPoliciesSpec, err := extcheckv1pluginrpc.PolicyServiceSpecBuilder{
+			ListPolicies: []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs(listPoliciesArg)},
+		}.Build()
+		if err != nil {
+			return nil, err
+		}
+		pluginrpcSpec, err = pluginrpc.MergeSpecs(pluginrpcSpec, listPoliciesSpec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(procedures) > 0 {
+		extraProcedures := make([]pluginrpc.Procedure, len(procedures))
+		for i, procedure := range procedures {
+			extraProcedures[i] = procedure.procedure
+		}
+		extraSpec, err := pluginrpc.NewSpec(extraProcedures...)
+		if err != nil {
+			return nil, err
+		}
+		pluginrpcSpec, err = pluginrpc.MergeSpecs(pluginrpcSpec, extraSpec)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	serverRegistrar := pluginrpc.NewServerRegistrar()
 	handler := pluginrpc.NewHandler(pluginrpcSpec)
 	checkServiceServer := checkv1pluginrpc.NewCheckServiceServer(handler, checkServiceHandler)
 	checkv1pluginrpc.RegisterCheckServiceServer(serverRegistrar, checkServiceServer)
+	serverRegistrar.Register(checkChunkedPath, newCheckChunkedHandleFunc(handler, checkServiceHandler))
+	stateServiceServer := extcheckv1pluginrpc.NewStateServiceServer(handler, newStateServiceHandler(checkServiceHandler))
+	extcheckv1pluginrpc.RegisterStateServiceServer(serverRegistrar, stateServiceServer)
+	severityServiceServer := extcheckv1pluginrpc.NewSeverityServiceServer(handler, newSeverityServiceHandler(checkServiceHandler))
+	extcheckv1pluginrpc.RegisterSeverityServiceServer(serverRegistrar, severityServiceServer)
 	if pluginInfoServiceHandler != nil {
 		pluginInfoServiceServer := infov1pluginrpc.NewPluginInfoServiceServer(handler, pluginInfoServiceHandler)
 		infov1pluginrpc.RegisterPluginInfoServiceServer(serverRegistrar, pluginInfoServiceServer)
+		pluginInfoExtensionServiceServer := extinfov1pluginrpc.NewPluginInfoExtensionServiceServer(handler, pluginInfoExtensionServiceHandler)
+		extinfov1pluginrpc.RegisterPluginInfoExtensionServiceServer(serverRegistrar, pluginInfoExtensionServiceServer)
+	}
+	if hasPolicies {
+		policyServiceServer := extcheckv1pluginrpc.NewPolicyServiceServer(handler, newPolicyServiceHandler(checkServiceHandler.rules))
+		extcheckv1pluginrpc.RegisterPolicyServiceServer(serverRegistrar, policyServiceServer)
+	}
+	for _, procedure := range procedures {
+		serverRegistrar.Register(procedure.procedure.Path(), procedure.handleFunc)
 	}
 
 	// Add documentation to -h/--help.
-	var pluginrpcServerOptions []pluginrpc.ServerOption
+	var defaultPluginrpcServerOptions []pluginrpc.ServerOption
 	if spec.Info != nil {
 		pluginInfo, err := info.NewPluginInfoForSpec(spec.Info)
 		if err != nil {
 			return nil, err
 		}
-		if documentation := pluginInfo.Documentation(); documentation != "" {
-			pluginrpcServerOptions = append(
-				pluginrpcServerOptions,
+		if documentation := info.DocumentationToPlainText(pluginInfo.Documentation()); documentation != "" {
+			defaultPluginrpcServerOptions = append(
+				defaultPluginrpcServerOptions,
 				pluginrpc.ServerWithDoc(documentation),
 			)
 		}
 	}
-	return pluginrpc.NewServer(pluginrpcSpec, serverRegistrar, pluginrpcServerOptions...)
+	return pluginrpc.NewServer(
+		pluginrpcSpec,
+		serverRegistrar,
+		append(defaultPluginrpcServerOptions, pluginrpcServerOptions...)...,
+	)
 }
 
-// ServerOption is an option for Server.
-type ServerOption func(*serverOptions)
-
-// ServerWithParallelism returns a new ServerOption that sets the parallelism
-// by which Rules will be run.
-//
-// If this is set to a value >= 1, this many concurrent Rules can be run at the same time.
-// A value of 0 indicates the default behavior, which is to use runtime.GOMAXPROCS(0).
-//
-// A value if < 0 has no effect.
-func ServerWithParallelism(parallelism int) ServerOption {
-	return func(serverOptions *serverOptions) {
-		if parallelism < 0 {
-			parallelism = 0
-		}
-		serverOptions.parallelism = parallelism
+// newCheckServiceHandlerForServerOptions returns a new checkServiceHandler for the Spec
+// configured with the given ServerOptions.
+func newCheckServiceHandlerForServerOptions(spec *Spec, options ...ServerOption) (*checkServiceHandler, error) {
+	serverOptions := newServerOptions()
+	for _, option := range options {
+		option(serverOptions)
+	}
+	checkServiceHandlerOptions := []CheckServiceHandlerOption{
+		CheckServiceHandlerWithParallelism(serverOptions.parallelism),
+		CheckServiceHandlerWithWarningWriter(serverOptions.warningWriter),
+		CheckServiceHandlerWithCrashReportWriter(serverOptions.crashReportWriter),
+		CheckServiceHandlerWithOptionLimits(serverOptions.optionLimits),
+		CheckServiceHandlerWithMessageSizeLimits(serverOptions.messageSizeLimits),
+		CheckServiceHandlerWithMaxFileDescriptors(serverOptions.maxFileDescriptors),
+		CheckServiceHandlerWithMaxConcurrentChecks(serverOptions.maxConcurrentChecks),
+		CheckServiceHandlerWithLogger(serverOptions.logger),
+		CheckServiceHandlerWithTracer(serverOptions.tracer),
+		CheckServiceHandlerWithMetrics(serverOptions.metrics),
 	}
+	if serverOptions.descriptorInterning {
+		checkServiceHandlerOptions = append(checkServiceHandlerOptions, CheckServiceHandlerWithDescriptorInterning())
+	}
+	return newCheckServiceHandler(spec, checkServiceHandlerOptions...)
 }
 
 type serverOptions struct {
-	parallelism int
+	parallelism         int
+	warningWriter       io.Writer
+	crashReportWriter   io.Writer
+	optionLimits        option.Limits
+	descriptorInterning bool
+	messageSizeLimits   MessageSizeLimits
+	maxFileDescriptors  int
+	maxConcurrentChecks int
+	logger              *slog.Logger
+	tracer              Tracer
+	metrics             Metrics
+	// procedures are the procedures added with ServerWithProcedure.
+	procedures []*serverProcedure
+	// pluginrpcServerOptions are the options added with ServerWithPluginrpcServerOptions.
+	pluginrpcServerOptions []pluginrpc.ServerOption
 }
 
 func newServerOptions() *serverOptions {
-	return &serverOptions{}
+	return &serverOptions{
+		optionLimits: option.DefaultLimits,
+	}
 }
@@ -0,0 +1,99 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestServerWithProcedure(t *testing.T) {
+	t.Parallel()
+
+	procedure, err := pluginrpc.NewProcedure("/test.v1.EchoService/Echo", pluginrpc.ProcedureWithArgs("echo"))
+	require.NoError(t, err)
+	server, err := NewServer(
+		testNewFileNameAnnotationSpec(),
+		ServerWithProcedure(
+			procedure,
+			func(_ context.Context, handleEnv pluginrpc.HandleEnv, _ ...pluginrpc.HandleOption) error {
+				_, err := io.Copy(handleEnv.Stdout, handleEnv.Stdin)
+				return err
+			},
+		),
+	)
+	require.NoError(t, err)
+	stdout := &bytes.Buffer{}
+	require.NoError(
+		t,
+		server.Serve(
+			context.Background(),
+			pluginrpc.Env{
+				Args:   []string{"echo"},
+				Stdin:  bytes.NewReader([]byte("hello")),
+				Stdout: stdout,
+				Stderr: io.Discard,
+			},
+		),
+	)
+	require.Equal(t, "hello", stdout.String())
+
+	// The built-in procedures are still served.
+	client := NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server)))
+	testCheckFileName(t, client)
+
+	// Procedures that conflict with the built-in procedures are rejected.
+	procedure, err = pluginrpc.NewProcedure("/test.v1.CheckService/Check", pluginrpc.ProcedureWithArgs("check"))
+	require.NoError(t, err)
+	_, err = NewServer(
+		testNewFileNameAnnotationSpec(),
+		ServerWithProcedure(
+			procedure,
+			func(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error {
+				return nil
+			},
+		),
+	)
+	require.Error(t, err)
+}
+
+func TestServerWithPluginrpcServerOptions(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(
+		testNewFileNameAnnotationSpec(),
+		ServerWithPluginrpcServerOptions(pluginrpc.ServerWithDoc("Custom documentation.")),
+	)
+	require.NoError(t, err)
+	stdout := &bytes.Buffer{}
+	require.NoError(
+		t,
+		server.Serve(
+			context.Background(),
+			pluginrpc.Env{
+				Args:   []string{"--help"},
+				Stdin:  bytes.NewReader(nil),
+				Stdout: stdout,
+				Stderr: stdout,
+			},
+		),
+	)
+	require.Contains(t, stdout.String(), "Custom documentation.")
+}
@@ -0,0 +1,300 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	extcheckv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1"
+	extcheckv1pluginrpc "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1/checkv1pluginrpc"
+	"buf.build/go/bufplugin/option"
+	"google.golang.org/protobuf/proto"
+	"pluginrpc.com/pluginrpc"
+)
+
+// SeverityOverridesOptionKey is the key of the option that overrides the Severity of the
+// Annotations of Rules.
+//
+// The option is recognized by the framework for every plugin, so plugin authors do not
+// need to declare or handle it. The value is a map from Rule ID to severity, where
+// severity is one of "info", "warning", or "error":
+//
+//	# buf.yaml
+//	plugins:
+//	  - plugin: buf-plugin-acme
+//	    options:
+//	      bufplugin_severity_overrides:
+//	        ACME_FIELD_NAME_SUFFIX: warning
+//
+// The overrides are applied by the plugin: the option is removed from the Options of the
+// Request before the Options are validated against the Spec's option.Schema and before any
+// RuleHandlers are invoked, and the overridden Severity is set on the Annotations that the
+// plugin returns. Requests fail with CodeInvalidArgument if the value is malformed or names
+// a Rule that the plugin does not have. The overridden Severity is returned from
+// Annotation.Severity, taking precedence over the Severity of the Rule's Policy.
+//
+// As map values cannot be represented in the Protobuf representation of Options, Clients
+// send the overrides separately from the other Options. Clients return an error if the
+// plugin was built with a version of this package that does not support severity overrides.
+//
+// The key has option.ReservedKeyPrefix, so it cannot conflict with the options of a plugin.
+const SeverityOverridesOptionKey = option.ReservedKeyPrefix + "severity_overrides"
+
+// *** PRIVATE ***
+
+const (
+	// checkWithSeverityOverridesPath is the path of the procedure that accepts a
+	// CheckRequest with Severity overrides, and returns a CheckResponse with the Severity
+	// the plugin set on each Annotation.
+	//
+	// This is not part of the v1 CheckService protocol. Clients only use this procedure if
+	// the Options of the Request contain SeverityOverridesOptionKey.
+	checkWithSeverityOverridesPath = extcheckv1pluginrpc.SeverityServiceCheckWithSeverityOverridesPath
+	checkWithSeverityOverridesArg  = "check-with-severity-overrides"
+)
+
+// severityOverridesForOptions returns the Severity overrides of the Options by Rule ID, and
+// the Options without SeverityOverridesOptionKey.
+//
+// If the Options do not contain SeverityOverridesOptionKey, the returned map is nil and the
+// Options are returned as-is.
+func severityOverridesForOptions(options option.Options) (map[string]Severity, option.Options, error) {
+	ruleIDToSeverityString, ok, err := options.GetStringMap(SeverityOverridesOptionKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, options, nil
+	}
+	ruleIDToSeverity := make(map[string]Severity, len(ruleIDToSeverityString))
+	for ruleID, severityString := range ruleIDToSeverityString {
+		severity, err := ParseSeverity(severityString)
+		if err != nil {
+			return nil, nil, fmt.Errorf("option %q: rule ID %q: %w", SeverityOverridesOptionKey, ruleID, err)
+		}
+		ruleIDToSeverity[ruleID] = severity
+	}
+	keyToValue := make(map[string]any)
+	options.Range(
+		func(key string, value any) {
+			if key != SeverityOverridesOptionKey {
+				keyToValue[key] = value
+			}
+		},
+	)
+	optionsWithoutSeverityOverrides, err := option.NewOptions(keyToValue)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ruleIDToSeverity, optionsWithoutSeverityOverrides, nil
+}
+
+// requestWithSeverityOverrides returns the Request with the Severity overrides set in its
+// Options with SeverityOverridesOptionKey.
+func requestWithSeverityOverrides(request Request, ruleIDToSeverity map[string]Severity) (Request, error) {
+	ruleIDToSeverityString := make(map[string]any, len(ruleIDToSeverity))
+	for ruleID, severity := range ruleIDToSeverity {
+		if _, ok := severityToString[severity]; !ok {
+			return nil, fmt.Errorf("option %q: unknown Severity %v for rule ID %q", SeverityOverridesOptionKey, severity, ruleID)
+		}
+		ruleIDToSeverityString[ruleID] = severity.String()
+	}
+	severityOverridesOptions, err := option.NewOptions(
+		map[string]any{
+			SeverityOverridesOptionKey: ruleIDToSeverityString,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return requestWithOptions(request, option.Merge(request.Options(), severityOverridesOptions))
+}
+
+// applySeverityOverrides returns the Annotations with the overridden Severities set.
+func applySeverityOverrides(annotations []Annotation, ruleIDToSeverity map[string]Severity) []Annotation {
+	if len(ruleIDToSeverity) == 0 {
+		return annotations
+	}
+	for i, annotation := range annotations {
+		if severity, ok := ruleIDToSeverity[annotation.RuleID()]; ok {
+			annotations[i] = annotationWithSeverityOverride(annotation, severity)
+		}
+	}
+	return annotations
+}
+
+// withSeverityOverride returns a new AddAnnotationOption that sets the Severity that the
+// plugin set on the Annotation.
+//
+// This is only used by Clients, so that plugin authors cannot set a Severity directly.
+func withSeverityOverride(severity Severity) AddAnnotationOption {
+	return func(addAnnotationOptions *addAnnotationOptions) {
+		addAnnotationOptions.severityOverride = severity
+	}
+}
+
+// severityServiceHandler implements the SeverityService with a checkServiceHandler.
+type severityServiceHandler struct {
+	checkServiceHandler *checkServiceHandler
+}
+
+func newSeverityServiceHandler(checkServiceHandler *checkServiceHandler) *severityServiceHandler {
+	return &severityServiceHandler{
+		checkServiceHandler: checkServiceHandler,
+	}
+}
+
+func (s *severityServiceHandler) CheckWithSeverityOverrides(
+	ctx context.Context,
+	request *extcheckv1.CheckWithSeverityOverridesRequest,
+) (*extcheckv1.CheckWithSeverityOverridesResponse, error) {
+	checkRequest := &checkv1.CheckRequest{}
+	if err := proto.Unmarshal(request.GetCheckRequest(), checkRequest); err != nil {
+		return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+	}
+	if len(request.GetRuleIdToSeverity()) == 0 {
+		return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, errors.New("rule_id_to_severity is empty"))
+	}
+	ruleIDToSeverity := make(map[string]Severity, len(request.GetRuleIdToSeverity()))
+	for ruleID, protoSeverity := range request.GetRuleIdToSeverity() {
+		severity, ok := protoSeverityToSeverity[protoSeverity]
+		if !ok {
+			return nil, pluginrpc.NewErrorf(pluginrpc.CodeInvalidArgument, "unknown severity %v for rule ID %q", protoSeverity, ruleID)
+		}
+		ruleIDToSeverity[ruleID] = severity
+	}
+	// A nil state means that the Request has no state, see checkServiceHandler.check.
+	var state []byte
+	if request.State != nil {
+		state = request.GetState()
+	}
+	checkResponse, response, err := s.checkServiceHandler.check(ctx, checkRequest, state, ruleIDToSeverity)
+	if err != nil {
+		return nil, err
+	}
+	checkResponseData, err := proto.Marshal(checkResponse)
+	if err != nil {
+		return nil, err
+	}
+	annotations := response.Annotations()
+	annotationSeverities := make([]extcheckv1.Severity, len(annotations))
+	for i, annotation := range annotations {
+		if severity, ok := ruleIDToSeverity[annotation.RuleID()]; ok {
+			annotationSeverities[i] = severityToProtoSeverity[severity]
+		}
+	}
+	return &extcheckv1.CheckWithSeverityOverridesResponse{
+		CheckResponse:        checkResponseData,
+		AnnotationSeverities: annotationSeverities,
+		State:                response.State(),
+	}, nil
+}
+
+// checkWithSeverityOverridesForPluginrpcClient calls the CheckWithSeverityOverrides procedure.
+//
+// An error is returned if the plugin does not implement the procedure.
+func checkWithSeverityOverridesForPluginrpcClient(
+	ctx context.Context,
+	pluginrpcClient pluginrpc.Client,
+	checkRequest *checkv1.CheckRequest,
+	state []byte,
+	ruleIDToSeverity map[string]Severity,
+) (*checkv1.CheckResponse, []Severity, []byte, error) {
+	spec, err := pluginrpcClient.Spec(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if spec.ProcedureForPath(checkWithSeverityOverridesPath) == nil {
+		return nil, nil, nil, fmt.Errorf("option %q: plugin does not support severity overrides", SeverityOverridesOptionKey)
+	}
+	severityServiceClient, err := extcheckv1pluginrpc.NewSeverityServiceClient(pluginrpcClient)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return checkWithSeverityOverrides(
+		ctx,
+		func(
+			ctx context.Context,
+			request *extcheckv1.CheckWithSeverityOverridesRequest,
+		) (*extcheckv1.CheckWithSeverityOverridesResponse, error) {
+			return severityServiceClient.CheckWithSeverityOverrides(ctx, request)
+		},
+		checkRequest,
+		state,
+		ruleIDToSeverity,
+	)
+}
+
+// checkWithSeverityOverrides makes a CheckWithSeverityOverrides call with the given function.
+//
+// If state is non-nil, the call is made with the state, and the updated state is returned.
+// The returned Severities are the Severities that the plugin set on each Annotation of the
+// CheckResponse, with 0 for Annotations whose Severity was not overridden.
+func checkWithSeverityOverrides(
+	ctx context.Context,
+	call func(context.Context, *extcheckv1.CheckWithSeverityOverridesRequest) (*extcheckv1.CheckWithSeverityOverridesResponse, error),
+	checkRequest *checkv1.CheckRequest,
+	state []byte,
+	ruleIDToSeverity map[string]Severity,
+) (*checkv1.CheckResponse, []Severity, []byte, error) {
+	checkRequestData, err := proto.Marshal(checkRequest)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ruleIDToProtoSeverity := make(map[string]extcheckv1.Severity, len(ruleIDToSeverity))
+	for ruleID, severity := range ruleIDToSeverity {
+		ruleIDToProtoSeverity[ruleID] = severityToProtoSeverity[severity]
+	}
+	response, err := call(
+		ctx,
+		&extcheckv1.CheckWithSeverityOverridesRequest{
+			CheckRequest:     checkRequestData,
+			RuleIdToSeverity: ruleIDToProtoSeverity,
+			State:            state,
+		},
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	checkResponse := &checkv1.CheckResponse{}
+	if err := proto.Unmarshal(response.GetCheckResponse(), checkResponse); err != nil {
+		return nil, nil, nil, err
+	}
+	protoAnnotationSeverities := response.GetAnnotationSeverities()
+	if len(protoAnnotationSeverities) != len(checkResponse.GetAnnotations()) {
+		return nil, nil, nil, fmt.Errorf("expected %d annotation severities but got %d", len(checkResponse.GetAnnotations()), len(protoAnnotationSeverities))
+	}
+	annotationSeverities := make([]Severity, len(protoAnnotationSeverities))
+	for i, protoSeverity := range protoAnnotationSeverities {
+		if protoSeverity == extcheckv1.Severity_SEVERITY_UNSPECIFIED {
+			continue
+		}
+		severity, ok := protoSeverityToSeverity[protoSeverity]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("unknown severity %v for annotation %d", protoSeverity, i)
+		}
+		annotationSeverities[i] = severity
+	}
+	if state != nil {
+		state = response.GetState()
+		if state == nil {
+			state = []byte{}
+		}
+	}
+	return checkResponse, annotationSeverities, state, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestSeverityOverrides(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewSeverityOverridesSpec()
+	client, err := NewClientForSpec(spec)
+	require.NoError(t, err)
+	testSeverityOverrides(t, client)
+	server, err := NewServer(spec)
+	require.NoError(t, err)
+	testSeverityOverrides(t, NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server))))
+}
+
+func TestSeverityOverridesWithState(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewSeverityOverridesSpec())
+	require.NoError(t, err)
+	client := NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server)))
+	request := testNewSeverityOverridesRequest(t, map[string]any{"RULE1": "warning"})
+	request, err = NewRequest(request.FileDescriptors(), WithOptions(request.Options()), WithState([]byte{}))
+	require.NoError(t, err)
+	response, err := client.Check(context.Background(), request)
+	require.NoError(t, err)
+	require.NotNil(t, response.State())
+	annotations := response.Annotations()
+	require.Len(t, annotations, 2)
+	require.Equal(t, SeverityWarning, annotations[0].Severity())
+}
+
+func TestSeverityOverridesInvalid(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewSeverityOverridesSpec()
+	server, err := NewServer(spec)
+	require.NoError(t, err)
+	for _, client := range []Client{
+		testNewClientForSpec(t, spec),
+		NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server))),
+	} {
+		for _, value := range []any{
+			[]string{"RULE1=warning"},
+			map[string]any{"RULE1": "unknown"},
+			map[string]any{"RULE1": int64(1)},
+			map[string]any{"RULE1": []string{"warning"}},
+			true,
+		} {
+			_, err := client.Check(context.Background(), testNewSeverityOverridesRequest(t, value))
+			require.Error(t, err, value)
+		}
+	}
+	// Unknown Rule IDs can only be detected by the plugin.
+	_, err = NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server))).Check(
+		context.Background(),
+		testNewSeverityOverridesRequest(t, map[string]any{"RULE3": "warning"}),
+	)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpcError.Code())
+}
+
+func TestSeverityOverridesReservedKey(t *testing.T) {
+	t.Parallel()
+
+	require.Error(
+		t,
+		ValidateSpec(
+			&Spec{
+				Rules: []*RuleSpec{
+					testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil),
+				},
+				Options: &option.Schema{
+					Keys: []*option.KeySpec{
+						{
+							Key:  SeverityOverridesOptionKey,
+							Type: option.TypeString,
+						},
+					},
+				},
+			},
+		),
+	)
+}
+
+func testSeverityOverrides(t *testing.T, client Client) {
+	response, err := client.Check(context.Background(), testNewSeverityOverridesRequest(t, map[string]any{"RULE1": "warning", "RULE2": "info"}))
+	require.NoError(t, err)
+	annotations := response.Annotations()
+	require.Len(t, annotations, 2)
+	require.Equal(t, "RULE1", annotations[0].RuleID())
+	require.Equal(t, SeverityWarning, annotations[0].Severity())
+	require.Equal(t, "RULE2", annotations[1].RuleID())
+	require.Equal(t, SeverityInfo, annotations[1].Severity())
+	require.Equal(t, SeverityError, annotations[1].Policy().Severity())
+
+	response, err = client.Check(context.Background(), testNewSeverityOverridesRequest(t, nil))
+	require.NoError(t, err)
+	annotations = response.Annotations()
+	require.Len(t, annotations, 2)
+	require.Equal(t, SeverityError, annotations[0].Severity())
+	require.Equal(t, SeverityError, annotations[1].Severity())
+}
+
+// testNewSeverityOverridesSpec returns a Spec with two Rules that each add one Annotation,
+// and fail if they see SeverityOverridesOptionKey. RULE2 is a policy Rule.
+func testNewSeverityOverridesSpec() *Spec {
+	newHandler := func(message string) RuleHandler {
+		return RuleHandlerFunc(
+			func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+				if _, ok := request.Options().Get(SeverityOverridesOptionKey); ok {
+					return errors.New("handler saw " + SeverityOverridesOptionKey)
+				}
+				suffix, _, err := request.Options().GetString("suffix")
+				if err != nil {
+					return err
+				}
+				responseWriter.AddAnnotation(WithMessage(message + suffix))
+				return nil
+			},
+		)
+	}
+	ruleSpec1 := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec1.Handler = newHandler("one")
+	ruleSpec2 := testNewSimpleLintRuleSpec("RULE2", nil, true, false, nil)
+	ruleSpec2.Handler = newHandler("two")
+	ruleSpec2.Policy = &PolicySpec{
+		ID:       "POLICY2",
+		Severity: SeverityError,
+	}
+	return &Spec{
+		Rules: []*RuleSpec{ruleSpec1, ruleSpec2},
+		Options: &option.Schema{
+			Keys: []*option.KeySpec{
+				{
+					Key:  "suffix",
+					Type: option.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// testNewSeverityOverridesRequest returns a new Request with SeverityOverridesOptionKey set
+// to the value, or not set if the value is nil.
+func testNewSeverityOverridesRequest(t *testing.T, value any) Request {
+	keyToValue := map[string]any{
+		"suffix": "!",
+	}
+	if value != nil {
+		keyToValue[SeverityOverridesOptionKey] = value
+	}
+	options, err := option.NewOptions(keyToValue)
+	require.NoError(t, err)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(1))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors, WithOptions(options))
+	require.NoError(t, err)
+	return request
+}
+
+func testNewClientForSpec(t *testing.T, spec *Spec) Client {
+	client, err := NewClientForSpec(spec)
+	require.NoError(t, err)
+	return client
+}
@@ -19,6 +19,7 @@ import (
 
 	"buf.build/go/bufplugin/info"
 	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"buf.build/go/bufplugin/option"
 )
 
 // Spec is the spec for a plugin.
@@ -48,6 +49,17 @@ type Spec struct {
 	//
 	// If not set, the resulting server will not implement the PluginInfoService.
 	Info *info.Spec
+	// Options is the schema for the options that the plugin accepts.
+	//
+	// Optional.
+	//
+	// If set, the Options of every Request are validated against the Schema before
+	// Before or any RuleHandlers are invoked, and the Default of every KeySpec is
+	// applied to the Options that Before and the RuleHandlers see.
+	//
+	// The Schema must not contain keys or aliases with option.ReservedKeyPrefix, such as
+	// SeverityOverridesOptionKey, as these are reserved by the framework.
+	Options *option.Schema
 
 	// Before is a function that will be executed before any RuleHandlers are
 	// invoked that returns a new Context and Request. This new Context and
@@ -85,5 +97,10 @@ func ValidateSpec(spec *Spec) error {
 			return err
 		}
 	}
+	if spec.Options != nil {
+		if err := option.ValidateSchema(spec.Options); err != nil {
+			return err
+		}
+	}
 	return nil
 }
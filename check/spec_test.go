@@ -135,6 +135,29 @@ func TestValidateSpec(t *testing.T) {
 		},
 	}
 	require.ErrorAs(t, ValidateSpec(spec), &validateCategorySpecError)
+
+	// Spec that has a rule with a relative documentation URL.
+	ruleSpec := testNewSimpleLintRuleSpec("RULE1", nil, true, false, nil)
+	ruleSpec.DocumentationURL = "docs/rule1"
+	spec = &Spec{
+		Rules: []*RuleSpec{
+			ruleSpec,
+		},
+	}
+	require.ErrorAs(t, ValidateSpec(spec), &validateRuleSpecError)
+
+	// Spec that has a category with an empty example.
+	categorySpec := testNewSimpleCategorySpec("CATEGORY1", false, nil)
+	categorySpec.Examples = []string{""}
+	spec = &Spec{
+		Rules: []*RuleSpec{
+			testNewSimpleLintRuleSpec("RULE1", []string{"CATEGORY1"}, true, false, nil),
+		},
+		Categories: []*CategorySpec{
+			categorySpec,
+		},
+	}
+	require.ErrorAs(t, ValidateSpec(spec), &validateCategorySpecError)
 }
 
 func testNewSimpleLintRuleSpec(
@@ -0,0 +1,152 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	extcheckv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1"
+	extcheckv1pluginrpc "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1/checkv1pluginrpc"
+	v1pluginrpc "buf.build/go/bufplugin/internal/gen/buf/plugin/check/v1/v1pluginrpc"
+	"google.golang.org/protobuf/proto"
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	// checkWithStatePath is the path of the procedure that accepts a CheckRequest with
+	// opaque state, and returns a CheckResponse with the updated state.
+	//
+	// This is not part of the v1 CheckService protocol. Clients only use this procedure if
+	// the Request has state, and fall back to Check if the plugin does not implement it.
+	checkWithStatePath = extcheckv1pluginrpc.StateServiceCheckWithStatePath
+	checkWithStateArg  = "check-with-state"
+)
+
+// *** PRIVATE ***
+
+// unmarshalState returns a map from Rule ID to state for the opaque state.
+//
+// Callers never look inside the state, but the structure is owned by this package, see
+// extcheckv1.State, so that Rules do not need to coordinate their storage. An empty state
+// results in an empty map.
+func unmarshalState(state []byte) (map[string][]byte, error) {
+	ruleIDToState := make(map[string][]byte)
+	if len(state) == 0 {
+		return ruleIDToState, nil
+	}
+	protoState := &extcheckv1.State{}
+	if err := proto.Unmarshal(state, protoState); err != nil {
+		return nil, fmt.Errorf("invalid state: %w", err)
+	}
+	for ruleID, ruleState := range protoState.GetRuleIdToState() {
+		ruleIDToState[ruleID] = ruleState
+	}
+	return ruleIDToState, nil
+}
+
+func marshalState(ruleIDToState map[string][]byte) ([]byte, error) {
+	// Deterministic, so that the same state always results in the same bytes.
+	return proto.MarshalOptions{Deterministic: true}.Marshal(&extcheckv1.State{RuleIdToState: ruleIDToState})
+}
+
+// stateServiceHandler implements the StateService with a checkServiceHandler.
+type stateServiceHandler struct {
+	checkServiceHandler *checkServiceHandler
+}
+
+func newStateServiceHandler(checkServiceHandler *checkServiceHandler) *stateServiceHandler {
+	return &stateServiceHandler{
+		checkServiceHandler: checkServiceHandler,
+	}
+}
+
+func (s *stateServiceHandler) CheckWithState(
+	ctx context.Context,
+	request *extcheckv1.CheckWithStateRequest,
+) (*extcheckv1.CheckWithStateResponse, error) {
+	checkRequest := &checkv1.CheckRequest{}
+	if err := proto.Unmarshal(request.GetCheckRequest(), checkRequest); err != nil {
+		return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+	}
+	state := request.GetState()
+	if state == nil {
+		state = []byte{}
+	}
+	checkResponse, response, err := s.checkServiceHandler.check(ctx, checkRequest, state, nil)
+	if err != nil {
+		return nil, err
+	}
+	checkResponseData, err := proto.Marshal(checkResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &extcheckv1.CheckWithStateResponse{
+		CheckResponse: checkResponseData,
+		State:         response.State(),
+	}, nil
+}
+
+// checkWithStateForPluginrpcClient calls the CheckWithState procedure.
+//
+// If the plugin does not implement the procedure, Check is called with the
+// checkServiceClient instead, and the state is returned unchanged.
+func checkWithStateForPluginrpcClient(
+	ctx context.Context,
+	pluginrpcClient pluginrpc.Client,
+	checkServiceClient v1pluginrpc.CheckServiceClient,
+	checkRequest *checkv1.CheckRequest,
+	state []byte,
+) (*checkv1.CheckResponse, []byte, error) {
+	spec, err := pluginrpcClient.Spec(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if spec.ProcedureForPath(checkWithStatePath) == nil {
+		checkResponse, err := checkServiceClient.Check(ctx, checkRequest)
+		if err != nil {
+			return nil, nil, err
+		}
+		return checkResponse, state, nil
+	}
+	checkRequestData, err := proto.Marshal(checkRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+	stateServiceClient, err := extcheckv1pluginrpc.NewStateServiceClient(pluginrpcClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	response, err := stateServiceClient.CheckWithState(
+		ctx,
+		&extcheckv1.CheckWithStateRequest{
+			CheckRequest: checkRequestData,
+			State:        state,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	checkResponse := &checkv1.CheckResponse{}
+	if err := proto.Unmarshal(response.GetCheckResponse(), checkResponse); err != nil {
+		return nil, nil, err
+	}
+	state = response.GetState()
+	if state == nil {
+		state = []byte{}
+	}
+	return checkResponse, state, nil
+}
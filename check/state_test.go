@@ -0,0 +1,164 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"buf.build/go/bufplugin/descriptor"
+	checkv1pluginrpc "buf.build/go/bufplugin/internal/gen/buf/plugin/check/v1/v1pluginrpc"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestState(t *testing.T) {
+	t.Parallel()
+
+	spec := testNewStateSpec()
+	client, err := NewClientForSpec(spec)
+	require.NoError(t, err)
+	testState(t, client)
+	server, err := NewServer(spec)
+	require.NoError(t, err)
+	testState(t, NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server))))
+}
+
+func TestStateNotSupported(t *testing.T) {
+	t.Parallel()
+
+	inProcessClient, err := NewClientForSpec(testNewStateSpec())
+	require.NoError(t, err)
+	state := testCheckState(t, inProcessClient, 1, []byte{}).State()
+	// The plugin only serves the CheckService, as plugins built with older versions of
+	// this package do.
+	checkServiceHandler, err := NewCheckServiceHandler(testNewStateSpec())
+	require.NoError(t, err)
+	pluginrpcSpec, err := checkv1pluginrpc.CheckServiceSpecBuilder{}.Build()
+	require.NoError(t, err)
+	serverRegistrar := pluginrpc.NewServerRegistrar()
+	checkv1pluginrpc.RegisterCheckServiceServer(
+		serverRegistrar,
+		checkv1pluginrpc.NewCheckServiceServer(pluginrpc.NewHandler(pluginrpcSpec), checkServiceHandler),
+	)
+	server, err := pluginrpc.NewServer(pluginrpcSpec, serverRegistrar)
+	require.NoError(t, err)
+
+	// State is returned unchanged if the plugin does not support state.
+	client := NewClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server)))
+	response := testCheckState(t, client, 2, state)
+	require.Len(t, response.Annotations(), 2)
+	require.Equal(t, state, response.State())
+}
+
+func TestStateInvalid(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClientForSpec(testNewStateSpec())
+	require.NoError(t, err)
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(1))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors, WithState([]byte("{")))
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	require.Error(t, err)
+}
+
+func testState(t *testing.T, client Client) {
+	// Without state, all files are violations, and no state is returned.
+	response := testCheckState(t, client, 2, nil)
+	require.Len(t, response.Annotations(), 2)
+	require.Nil(t, response.State())
+
+	// The first Check with state accepts the existing violations, and reports them.
+	response = testCheckState(t, client, 2, []byte{})
+	require.Len(t, response.Annotations(), 2)
+	state := response.State()
+	require.NotEmpty(t, state)
+
+	// Only new violations are reported.
+	response = testCheckState(t, client, 3, state)
+	require.Equal(t, []string{"file2.proto"}, testAnnotationMessages(response))
+	state = response.State()
+	response = testCheckState(t, client, 3, state)
+	require.Empty(t, response.Annotations())
+	require.Equal(t, state, response.State())
+
+	// The state of Rules that are not run is carried over.
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(4))
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors, WithRuleIDs("STATELESS_RULE"), WithState(state))
+	require.NoError(t, err)
+	response, err = client.Check(context.Background(), request)
+	require.NoError(t, err)
+	require.Empty(t, response.Annotations())
+	require.Equal(t, state, response.State())
+}
+
+func testCheckState(t *testing.T, client Client, numFiles int, state []byte) Response {
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(testNewProtoFileDescriptors(numFiles))
+	require.NoError(t, err)
+	var requestOptions []RequestOption
+	if state != nil {
+		requestOptions = append(requestOptions, WithState(state))
+	}
+	request, err := NewRequest(fileDescriptors, requestOptions...)
+	require.NoError(t, err)
+	response, err := client.Check(context.Background(), request)
+	require.NoError(t, err)
+	return response
+}
+
+func testAnnotationMessages(response Response) []string {
+	messages := make([]string, 0, len(response.Annotations()))
+	for _, annotation := range response.Annotations() {
+		messages = append(messages, annotation.Message())
+	}
+	return messages
+}
+
+// testNewStateSpec returns a Spec with a Rule that reports files that are not in its
+// baseline, and a Rule that never reports anything.
+func testNewStateSpec() *Spec {
+	baselineRuleSpec := testNewSimpleLintRuleSpec("BASELINE_RULE", nil, true, false, nil)
+	baselineRuleSpec.Handler = RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			var baseline []string
+			if ruleState := request.RuleState(); len(ruleState) > 0 {
+				baseline = strings.Split(string(ruleState), "\n")
+			}
+			newBaseline := slices.Clone(baseline)
+			for _, fileDescriptor := range request.FileDescriptors() {
+				fileName := fileDescriptor.FileDescriptorProto().GetName()
+				if slices.Contains(baseline, fileName) {
+					continue
+				}
+				responseWriter.AddAnnotation(
+					WithMessage(fileName),
+					WithFileName(fileName),
+				)
+				newBaseline = append(newBaseline, fileName)
+			}
+			responseWriter.SetState([]byte(strings.Join(newBaseline, "\n")))
+			return nil
+		},
+	)
+	statelessRuleSpec := testNewSimpleLintRuleSpec("STATELESS_RULE", nil, false, false, nil)
+	return &Spec{
+		Rules: []*RuleSpec{baselineRuleSpec, statelessRuleSpec},
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"unicode"
+
+	pluginrpcv1 "buf.build/gen/go/pluginrpc/pluginrpc/protocolbuffers/go/pluginrpc/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"pluginrpc.com/pluginrpc"
+)
+
+// NewRunnerWithStdoutValidation returns a new pluginrpc.Runner that validates that the stdout
+// of the plugin run by the given pluginrpc.Runner only contains a response.
+//
+// A common failure is a plugin, or a library that it uses, printing to stdout, which corrupts
+// the response of the plugin. Without validation, this results in an error to unmarshal the
+// response that does not say what happened. With validation, the invocation fails with
+// CodeInternal and an error that contains the unexpected output.
+//
+// Unexpected output is detected by stdout failing to parse as a response, or by trailing
+// bytes after the response. Unknown fields are allowed, as plugins built with newer versions
+// of pluginrpc may send them, so output that happens to parse as unknown fields of a binary
+// response is not detected.
+//
+// Plugins that call Main are protected from this, as Main sends writes to os.Stdout to stderr.
+// This is primarily useful for plugins that do not use this library, or that write directly
+// to the stdout file descriptor.
+//
+//	client := check.NewClient(
+//		pluginrpc.NewClient(
+//			check.NewRunnerWithStdoutValidation(pluginrpc.NewExecRunner("buf-plugin-foo")),
+//		),
+//	)
+func NewRunnerWithStdoutValidation(runner pluginrpc.Runner) pluginrpc.Runner {
+	return &stdoutValidationRunner{
+		delegate: runner,
+	}
+}
+
+// *** PRIVATE ***
+
+// maxStrayOutputSize is the maximum number of bytes of unexpected output included in errors.
+const maxStrayOutputSize = 256
+
+type stdoutValidationRunner struct {
+	delegate pluginrpc.Runner
+}
+
+func (s *stdoutValidationRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	var stdout bytes.Buffer
+	if err := s.delegate.Run(
+		ctx,
+		pluginrpc.Env{
+			Args:   env.Args,
+			Stdin:  env.Stdin,
+			Stdout: &stdout,
+			Stderr: env.Stderr,
+		},
+	); err != nil {
+		// Write what we have, as the caller may use it to report the error.
+		if env.Stdout != nil {
+			_, _ = env.Stdout.Write(stdout.Bytes())
+		}
+		return err
+	}
+	if err := validateStdout(env.Args, stdout.Bytes()); err != nil {
+		return err
+	}
+	if env.Stdout != nil {
+		if _, err := env.Stdout.Write(stdout.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStdout validates that the stdout of an invocation with the given args only
+// contains the expected response.
+//
+// Invocations that do not produce a pluginrpc response, such as --help, are not validated.
+func validateStdout(args []string, stdout []byte) error {
+	isValid := stdoutValidatorForArgs(args)
+	if isValid == nil || isValid(stdout) {
+		return nil
+	}
+	return newStrayOutputError(stdout, isValid)
+}
+
+// stdoutValidatorForArgs returns a function that returns true if stdout only contains the
+// expected response for an invocation with the given args, or nil if the invocation does not
+// produce a pluginrpc response.
+func stdoutValidatorForArgs(args []string) func([]byte) bool {
+	if len(args) == 1 && args[0] == "--"+pluginrpc.ProtocolFlagName {
+		return func(stdout []byte) bool {
+			_, err := strconv.Atoi(string(bytes.TrimSpace(stdout)))
+			return err == nil
+		}
+	}
+	if len(args) < 2 || args[len(args)-2] != "--"+pluginrpc.FormatFlagName {
+		return nil
+	}
+	newMessage := func() proto.Message { return &pluginrpcv1.Response{} }
+	if args[0] == "--"+pluginrpc.SpecFlagName {
+		newMessage = func() proto.Message { return &pluginrpcv1.Spec{} }
+	}
+	switch format := args[len(args)-1]; format {
+	case pluginrpc.FormatBinary.String():
+		return func(stdout []byte) bool {
+			// Text before or after a binary response almost never parses as valid fields.
+			return proto.Unmarshal(stdout, newMessage()) == nil
+		}
+	case pluginrpc.FormatJSON.String():
+		return func(stdout []byte) bool {
+			// protojson rejects trailing bytes after the response.
+			return protojson.Unmarshal(stdout, newMessage()) == nil
+		}
+	default:
+		return nil
+	}
+}
+
+// newStrayOutputError returns a new error for stdout that contains unexpected output.
+//
+// Unexpected output is typically lines of text printed before the response. If removing
+// the leading lines of text from stdout results in a valid response, the removed lines are
+// included in the error. Otherwise, the start of stdout is included.
+func newStrayOutputError(stdout []byte, isValid func([]byte) bool) error {
+	strayOutput := stdout
+	textPrefix := stdout[:textPrefixLen(stdout)]
+	for i, b := range textPrefix {
+		if b == '\n' && isValid(stdout[i+1:]) {
+			strayOutput = stdout[:i+1]
+			break
+		}
+	}
+	if len(strayOutput) > maxStrayOutputSize {
+		strayOutput = strayOutput[:maxStrayOutputSize]
+	}
+	return pluginrpc.NewErrorf(
+		pluginrpc.CodeInternal,
+		"plugin wrote unexpected output to stdout, which must only contain the response of the plugin: %q",
+		strayOutput,
+	)
+}
+
+// textPrefixLen returns the length of the prefix of data that consists of printable text.
+func textPrefixLen(data []byte) int {
+	for i, r := range string(data) {
+		if r == unicode.ReplacementChar || (!unicode.IsPrint(r) && !unicode.IsSpace(r)) {
+			return i
+		}
+	}
+	return len(data)
+}
@@ -0,0 +1,89 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestRunnerWithStdoutValidation(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+	testCheckFileName(t, NewClient(pluginrpc.NewClient(NewRunnerWithStdoutValidation(serverRunner))))
+
+	// Output written to stdout before the response is reported.
+	pollutingRunner := &testRecordingRunner{
+		run: func(ctx context.Context, env pluginrpc.Env) error {
+			if len(env.Args) > 0 && env.Args[0] == "list-rules" {
+				_, _ = io.WriteString(env.Stdout, "hello from a library\n")
+			}
+			return serverRunner.Run(ctx, env)
+		},
+	}
+	_, err = NewClient(pluginrpc.NewClient(NewRunnerWithStdoutValidation(pollutingRunner))).ListRules(context.Background())
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInternal, pluginrpcError.Code())
+	require.Contains(t, pluginrpcError.Error(), `: "hello from a library\n"`)
+
+	// Output written to stdout after the response is reported.
+	pollutingRunner = &testRecordingRunner{
+		run: func(ctx context.Context, env pluginrpc.Env) error {
+			if err := serverRunner.Run(ctx, env); err != nil {
+				return err
+			}
+			if len(env.Args) > 0 && env.Args[0] == "list-rules" {
+				_, _ = io.WriteString(env.Stdout, "bye from a library\n")
+			}
+			return nil
+		},
+	}
+	_, err = NewClient(pluginrpc.NewClient(NewRunnerWithStdoutValidation(pollutingRunner))).ListRules(context.Background())
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeInternal, pluginrpcError.Code())
+
+	// Unknown fields, for example from a plugin built with a newer version of pluginrpc, are allowed.
+	extendingRunner := &testRecordingRunner{
+		run: func(ctx context.Context, env pluginrpc.Env) error {
+			if err := serverRunner.Run(ctx, env); err != nil {
+				return err
+			}
+			if len(env.Args) > 0 && env.Args[0] == "list-rules" {
+				_, _ = env.Stdout.Write(protowire.AppendString(protowire.AppendTag(nil, 100, protowire.BytesType), "new"))
+			}
+			return nil
+		},
+	}
+	_, err = NewClient(pluginrpc.NewClient(NewRunnerWithStdoutValidation(extendingRunner))).ListRules(context.Background())
+	require.NoError(t, err)
+
+	// Output written to stdout for --protocol is reported.
+	require.NoError(t, validateStdout([]string{"--protocol"}, []byte("1\n")))
+	err = validateStdout([]string{"--protocol"}, []byte("starting up\n1\n"))
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Contains(t, pluginrpcError.Error(), `"starting up\n"`)
+
+	// Invocations that do not produce a response are not validated.
+	require.NoError(t, validateStdout([]string{"--help"}, []byte("usage")))
+}
@@ -0,0 +1,71 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// Timeouts are timeouts for calls to a plugin.
+//
+// A zero value for any field means that there is no timeout for the call other than
+// the deadline of the context.
+type Timeouts struct {
+	// Check is the timeout for a Client.Check call.
+	Check time.Duration
+	// ListRules is the timeout for a Client.ListRules call.
+	//
+	// This includes listing the Categories that the Rules reference.
+	ListRules time.Duration
+	// ListCategories is the timeout for a Client.ListCategories call.
+	ListCategories time.Duration
+}
+
+// *** PRIVATE ***
+
+// withTimeout returns a context that is done after the timeout, along with a function
+// that cancels the context and converts the error of the call if the timeout expired.
+//
+// If the timeout is <= 0, the context is returned as-is.
+//
+// When the timeout expires, the plugin process is killed by the pluginrpc.Runner, and
+// the call fails with CodeDeadlineExceeded.
+func withTimeout(
+	ctx context.Context,
+	timeout time.Duration,
+	procedureName string,
+) (context.Context, func(error) error) {
+	if timeout <= 0 {
+		return ctx, func(err error) error { return err }
+	}
+	timeoutErr := pluginrpc.NewErrorf(
+		pluginrpc.CodeDeadlineExceeded,
+		"%s call to plugin timed out after %v",
+		procedureName,
+		timeout,
+	)
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, timeoutErr)
+	return ctx, func(err error) error {
+		defer cancel()
+		if err != nil && errors.Is(context.Cause(ctx), timeoutErr) {
+			return timeoutErr
+		}
+		return err
+	}
+}
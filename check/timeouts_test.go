@@ -0,0 +1,68 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestClientWithTimeouts(t *testing.T) {
+	t.Parallel()
+
+	server, err := NewServer(testNewFileNameAnnotationSpec())
+	require.NoError(t, err)
+	serverRunner := pluginrpc.NewServerRunner(server)
+	// Check calls never complete until the context is cancelled.
+	runner := &testRecordingRunner{
+		run: func(ctx context.Context, env pluginrpc.Env) error {
+			if len(env.Args) > 0 && env.Args[0] == "check" {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return serverRunner.Run(ctx, env)
+		},
+	}
+	client := NewClient(
+		pluginrpc.NewClient(runner),
+		ClientWithTimeouts(
+			Timeouts{
+				Check:     10 * time.Millisecond,
+				ListRules: time.Minute,
+			},
+		),
+	)
+	request, err := NewRequest(nil)
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), request)
+	pluginrpcError := &pluginrpc.Error{}
+	require.ErrorAs(t, err, &pluginrpcError)
+	require.Equal(t, pluginrpc.CodeDeadlineExceeded, pluginrpcError.Code())
+
+	// The timeout does not affect later calls.
+	rules, err := client.ListRules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	// Cancellation of the context is not reported as a timeout.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Check(ctx, request)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,123 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+
+	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Tracer traces Check calls.
+//
+// Implementations typically start a span in the tracing system of the caller, see package
+// checkotel for OpenTelemetry. Methods may be called concurrently, and should return quickly,
+// as they are called inline.
+type Tracer interface {
+	// StartCheck is called at the start of each Check call.
+	//
+	// The returned context is used for the call, and the returned Span is ended when the
+	// call completes.
+	StartCheck(ctx context.Context, checkSpanInfo CheckSpanInfo) (context.Context, Span)
+	// StartRule is called before each invocation of the RuleHandler of the Rule with the given ID.
+	//
+	// The returned context is passed to the RuleHandler, and the returned Span is ended when
+	// the RuleHandler returns. This is only called on the plugin side, or for Clients created
+	// with NewClientForSpec.
+	StartRule(ctx context.Context, ruleID string) (context.Context, Span)
+}
+
+// Span is a traced Check call or RuleHandler invocation started by a Tracer.
+type Span interface {
+	// End is called when the Check call or RuleHandler invocation completes, with the
+	// number of annotations in the response, or the error if it failed.
+	End(numAnnotations int, err error)
+}
+
+// CheckSpanInfo describes a traced Check call.
+type CheckSpanInfo struct {
+	// Client is true if the call is made by a Client, and false if the call is handled by
+	// the plugin.
+	Client bool
+	// InProcess is true if the call is handled in-process by a Client created with
+	// NewClientForSpec.
+	InProcess bool
+	// NumFileDescriptors is the number of FileDescriptors in the request.
+	NumFileDescriptors int
+	// NumAgainstFileDescriptors is the number of AgainstFileDescriptors in the request.
+	NumAgainstFileDescriptors int
+	// RequestSize is the size in bytes of the Protobuf encoding of the CheckRequest.
+	//
+	// This is 0 if InProcess is true.
+	RequestSize int
+}
+
+// *** PRIVATE ***
+
+// startCheckSpan starts a Span for a Check call with the given CheckRequest.
+//
+// If the tracer is nil, a no-op Span is returned.
+func startCheckSpan(
+	ctx context.Context,
+	tracer Tracer,
+	client bool,
+	checkRequest *checkv1.CheckRequest,
+) (context.Context, Span) {
+	if tracer == nil {
+		return ctx, nopSpan{}
+	}
+	return tracer.StartCheck(
+		ctx,
+		CheckSpanInfo{
+			Client:                    client,
+			NumFileDescriptors:        len(checkRequest.GetFileDescriptors()),
+			NumAgainstFileDescriptors: len(checkRequest.GetAgainstFileDescriptors()),
+			RequestSize:               proto.Size(checkRequest),
+		},
+	)
+}
+
+// startInProcessCheckSpan starts a Span for a Check call handled in-process.
+//
+// If the tracer is nil, a no-op Span is returned.
+func startInProcessCheckSpan(ctx context.Context, tracer Tracer, request Request) (context.Context, Span) {
+	if tracer == nil {
+		return ctx, nopSpan{}
+	}
+	return tracer.StartCheck(
+		ctx,
+		CheckSpanInfo{
+			Client:                    true,
+			InProcess:                 true,
+			NumFileDescriptors:        len(request.FileDescriptors()),
+			NumAgainstFileDescriptors: len(request.AgainstFileDescriptors()),
+		},
+	)
+}
+
+// startRuleSpan starts a Span for the RuleHandler of the Rule with the given ID.
+//
+// If the tracer is nil, a no-op Span is returned.
+func startRuleSpan(ctx context.Context, tracer Tracer, ruleID string) (context.Context, Span) {
+	if tracer == nil {
+		return ctx, nopSpan{}
+	}
+	return tracer.StartRule(ctx, ruleID)
+}
+
+type nopSpan struct{}
+
+func (nopSpan) End(int, error) {}
@@ -0,0 +1,98 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"errors"
+	"time"
+)
+
+// Waiver is an approved exception to a Rule.
+//
+// Waivers are applied by Clients to the Annotations returned from Check when passed with
+// CheckWithWaivers. Waived Annotations are still returned, with Annotation.Waiver set, so
+// that audit systems can record both the finding and the exception that covers it.
+type Waiver struct {
+	// ID is the identifier of the waiver, such as a ticket number.
+	//
+	// Optional.
+	ID string
+	// RuleID is the ID of the Rule that the Waiver applies to.
+	//
+	// Required.
+	RuleID string
+	// FileName is the name of the file that the Waiver applies to.
+	//
+	// Optional. If empty, the Waiver applies to Annotations for all files.
+	FileName string
+	// Reason is a user-readable justification for the Waiver.
+	//
+	// Optional.
+	Reason string
+	// Owner is the team or individual that approved the Waiver.
+	//
+	// Optional.
+	Owner string
+	// Expiry is the time at which the Waiver stops applying.
+	//
+	// Optional. If zero, the Waiver never expires.
+	Expiry time.Time
+}
+
+// Expired returns true if the Waiver has an Expiry that is not after the given time.
+func (w Waiver) Expired(now time.Time) bool {
+	return !w.Expiry.IsZero() && !w.Expiry.After(now)
+}
+
+// CheckWithWaivers returns a new CheckCallOption that applies the given Waivers to the
+// Annotations returned from Check.
+//
+// An Annotation is covered by a Waiver if the Waiver has the same RuleID, the Waiver either
+// has no FileName or has the same FileName as the FileLocation of the Annotation, and the
+// Waiver is not expired at the time of the Check call. If multiple Waivers cover an Annotation,
+// the first is used.
+func CheckWithWaivers(waivers ...Waiver) CheckCallOption {
+	return func(checkCallOptions *checkCallOptions) {
+		checkCallOptions.waivers = append(checkCallOptions.waivers, waivers...)
+	}
+}
+
+// *** PRIVATE ***
+
+func validateWaivers(waivers []Waiver) error {
+	for _, waiver := range waivers {
+		if waiver.RuleID == "" {
+			return errors.New("check.Waiver: RuleID is empty")
+		}
+	}
+	return nil
+}
+
+// findWaiver returns the first unexpired Waiver that covers the Annotation, or nil.
+func findWaiver(waivers []Waiver, annotation Annotation, now time.Time) *Waiver {
+	for _, waiver := range waivers {
+		if waiver.RuleID != annotation.RuleID() || waiver.Expired(now) {
+			continue
+		}
+		if waiver.FileName != "" {
+			fileLocation := annotation.FileLocation()
+			if fileLocation == nil || fileLocation.FileDescriptor().ProtoreflectFileDescriptor().Path() != waiver.FileName {
+				continue
+			}
+		}
+		return &waiver
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main implements a command that runs the check plugin conformance Cases against
+// a plugin binary.
+//
+//	bufplugin-check-conformance [--case name]... [--timeout duration] path/to/plugin [plugin-args]...
+//
+// The command prints one line per Case, and exits with a non-zero exit code if any Case
+// failed. See the checkconformance package for the Cases.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"buf.build/go/bufplugin/check/checkconformance"
+	"pluginrpc.com/pluginrpc"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flagSet := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "usage: %s [flags] <plugin-binary> [plugin-args...]\n\n", flagSet.Name())
+		flagSet.PrintDefaults()
+		_, _ = fmt.Fprintln(stderr, "\ncases:")
+		for _, c := range checkconformance.Cases() {
+			_, _ = fmt.Fprintf(stderr, "  %s\n    \t%s\n", c.Name, c.Description)
+		}
+	}
+	var caseNames stringSliceFlag
+	flagSet.Var(&caseNames, "case", "only run the case with the given name; may be specified multiple times")
+	caseTimeout := flagSet.Duration("timeout", checkconformance.DefaultCaseTimeout, "the timeout for each case")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if flagSet.NArg() < 1 {
+		flagSet.Usage()
+		return errors.New("no plugin binary specified")
+	}
+	report, err := checkconformance.Run(
+		ctx,
+		pluginrpc.NewExecRunner(
+			flagSet.Arg(0),
+			pluginrpc.ExecRunnerWithArgs(flagSet.Args()[1:]...),
+		),
+		checkconformance.RunWithCaseNames(caseNames...),
+		checkconformance.RunWithCaseTimeout(*caseTimeout),
+	)
+	if err != nil {
+		return err
+	}
+	if err := report.WriteText(stdout); err != nil {
+		return err
+	}
+	if !report.Passed() {
+		return errors.New("plugin failed conformance")
+	}
+	return nil
+}
+
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main implements the bufplugin command, which contains tooling for plugin authors.
+//
+//	bufplugin scaffold <config-file> <output-dir>
+//
+// The scaffold subcommand reads a YAML file that declares the Rules and Categories of a
+// check plugin, and writes a new Go module for the plugin to the output directory. See the
+// checkscaffold package for the format of the file.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"buf.build/go/bufplugin/check/checkscaffold"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) < 1 {
+		printUsage(stderr)
+		return errors.New("no subcommand specified")
+	}
+	switch args[0] {
+	case "scaffold":
+		return runScaffold(ctx, args[1:], stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		printUsage(stdout)
+		return nil
+	default:
+		printUsage(stderr)
+		return fmt.Errorf("unknown subcommand: %q", args[0])
+	}
+}
+
+func runScaffold(_ context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flagSet := flag.NewFlagSet("bufplugin scaffold", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "usage: %s <config-file> <output-dir>\n", flagSet.Name())
+	}
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 2 {
+		flagSet.Usage()
+		return errors.New("expected a config file and an output directory")
+	}
+	data, err := os.ReadFile(flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	config, err := checkscaffold.ConfigForYAML(data)
+	if err != nil {
+		return err
+	}
+	dirPath := flagSet.Arg(1)
+	if err := checkscaffold.WriteFiles(dirPath, config); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(
+		stdout,
+		"Wrote plugin to %s. To build and test it, run:\n\n\tcd %s\n\tgo mod tidy\n\tgo test ./...\n",
+		dirPath,
+		dirPath,
+	)
+	return err
+}
+
+func printUsage(writer io.Writer) {
+	_, _ = fmt.Fprintln(writer, `usage: bufplugin <subcommand> [args...]
+
+subcommands:
+  scaffold <config-file> <output-dir>
+    	generate a new check plugin from a YAML description of its rules`)
+}
@@ -0,0 +1,263 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	// The field number of buf_extension on buf.alpha.image.v1.ImageFile.
+	bufImageFileExtensionFieldNumber protowire.Number = 8042
+	// The field numbers of buf.alpha.image.v1.ImageFileExtension.
+	bufImageFileExtensionIsImportFieldNumber            protowire.Number = 1
+	bufImageFileExtensionUnusedDependencyFieldNumber    protowire.Number = 3
+	bufImageFileExtensionIsSyntaxUnspecifiedFieldNumber protowire.Number = 4
+	// The field number of file on buf.alpha.image.v1.Image.
+	bufImageFileFieldNumber protowire.Number = 1
+)
+
+// FileDescriptorsForBufImage returns a new slice of FileDescriptors for the given binary
+// Buf image, as produced by "buf build -o image.binpb".
+//
+// IsImport, IsSyntaxUnspecified, and UnusedDependencyIndexes are read from the Buf extension
+// of each image file. Other properties of the Buf extension, such as module information, are
+// ignored. Compressed images must be decompressed before calling this function.
+func FileDescriptorsForBufImage(data []byte) ([]FileDescriptor, error) {
+	fileDescriptorSet := &descriptorpb.FileDescriptorSet{}
+	// A Buf image is wire-compatible with a FileDescriptorSet, with the Buf extension
+	// of each image file parsed as an unknown field of the FileDescriptorProto.
+	if err := proto.Unmarshal(data, fileDescriptorSet); err != nil {
+		return nil, err
+	}
+	protoFileDescriptors := make([]*descriptorv1.FileDescriptor, len(fileDescriptorSet.GetFile()))
+	for i, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		protoFileDescriptor, err := bufImageFileToProtoFileDescriptor(fileDescriptorProto)
+		if err != nil {
+			return nil, err
+		}
+		protoFileDescriptors[i] = protoFileDescriptor
+	}
+	return FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+}
+
+// FileDescriptorsForBufImageJSON returns a new slice of FileDescriptors for the given JSON
+// Buf image, as produced by "buf build -o image.json".
+//
+// See FileDescriptorsForBufImage for how the Buf extension of each image file is handled.
+func FileDescriptorsForBufImageJSON(data []byte) ([]FileDescriptor, error) {
+	var jsonImage struct {
+		File []map[string]json.RawMessage `json:"file"`
+	}
+	if err := json.Unmarshal(data, &jsonImage); err != nil {
+		return nil, err
+	}
+	protoFileDescriptors := make([]*descriptorv1.FileDescriptor, len(jsonImage.File))
+	for i, jsonImageFile := range jsonImage.File {
+		var jsonExtension bufImageFileExtensionJSON
+		for _, key := range []string{"bufExtension", "buf_extension"} {
+			if value, ok := jsonImageFile[key]; ok {
+				if err := json.Unmarshal(value, &jsonExtension); err != nil {
+					return nil, err
+				}
+				delete(jsonImageFile, key)
+			}
+		}
+		jsonFileDescriptorProto, err := json.Marshal(jsonImageFile)
+		if err != nil {
+			return nil, err
+		}
+		fileDescriptorProto := &descriptorpb.FileDescriptorProto{}
+		if err := protojson.Unmarshal(jsonFileDescriptorProto, fileDescriptorProto); err != nil {
+			return nil, err
+		}
+		protoFileDescriptors[i] = &descriptorv1.FileDescriptor{
+			FileDescriptorProto: fileDescriptorProto,
+			IsImport:            jsonExtension.IsImport,
+			IsSyntaxUnspecified: jsonExtension.IsSyntaxUnspecified,
+			UnusedDependency:    jsonExtension.UnusedDependency,
+		}
+	}
+	return FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+}
+
+// FileDescriptorsToBufImage returns the binary Buf image for the given FileDescriptors.
+//
+// IsImport, IsSyntaxUnspecified, and UnusedDependencyIndexes are written to the Buf extension
+// of each image file. The image files are in the same order as the given FileDescriptors.
+func FileDescriptorsToBufImage(fileDescriptors []FileDescriptor) ([]byte, error) {
+	var data []byte
+	for _, fileDescriptor := range fileDescriptors {
+		fileData, err := proto.MarshalOptions{Deterministic: true}.Marshal(fileDescriptor.FileDescriptorProto())
+		if err != nil {
+			return nil, err
+		}
+		fileData = protowire.AppendTag(fileData, bufImageFileExtensionFieldNumber, protowire.BytesType)
+		fileData = protowire.AppendBytes(fileData, bufImageFileExtensionBytes(fileDescriptor))
+		data = protowire.AppendTag(data, bufImageFileFieldNumber, protowire.BytesType)
+		data = protowire.AppendBytes(data, fileData)
+	}
+	return data, nil
+}
+
+// FileDescriptorsToBufImageJSON returns the JSON Buf image for the given FileDescriptors.
+//
+// See FileDescriptorsToBufImage for how the Buf extension of each image file is written.
+func FileDescriptorsToBufImageJSON(fileDescriptors []FileDescriptor) ([]byte, error) {
+	jsonImageFiles := make([]map[string]json.RawMessage, len(fileDescriptors))
+	for i, fileDescriptor := range fileDescriptors {
+		jsonFileDescriptorProto, err := protojson.Marshal(fileDescriptor.FileDescriptorProto())
+		if err != nil {
+			return nil, err
+		}
+		var jsonImageFile map[string]json.RawMessage
+		if err := json.Unmarshal(jsonFileDescriptorProto, &jsonImageFile); err != nil {
+			return nil, err
+		}
+		jsonExtension, err := json.Marshal(
+			bufImageFileExtensionJSON{
+				IsImport:            fileDescriptor.IsImport(),
+				IsSyntaxUnspecified: fileDescriptor.IsSyntaxUnspecified(),
+				UnusedDependency:    fileDescriptor.UnusedDependencyIndexes(),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		jsonImageFile["bufExtension"] = jsonExtension
+		jsonImageFiles[i] = jsonImageFile
+	}
+	return json.Marshal(
+		map[string][]map[string]json.RawMessage{
+			"file": jsonImageFiles,
+		},
+	)
+}
+
+// *** PRIVATE ***
+
+// bufImageFileExtensionJSON is the JSON representation of buf.alpha.image.v1.ImageFileExtension.
+type bufImageFileExtensionJSON struct {
+	IsImport            bool    `json:"isImport"`
+	IsSyntaxUnspecified bool    `json:"isSyntaxUnspecified"`
+	UnusedDependency    []int32 `json:"unusedDependency,omitempty"`
+}
+
+// bufImageFileToProtoFileDescriptor converts a FileDescriptorProto parsed from a Buf image
+// file into a descriptorv1.FileDescriptor, removing the Buf extension from the unknown fields.
+func bufImageFileToProtoFileDescriptor(fileDescriptorProto *descriptorpb.FileDescriptorProto) (*descriptorv1.FileDescriptor, error) {
+	protoFileDescriptor := &descriptorv1.FileDescriptor{
+		FileDescriptorProto: fileDescriptorProto,
+	}
+	unknown := fileDescriptorProto.ProtoReflect().GetUnknown()
+	var remainingUnknown []byte
+	for len(unknown) > 0 {
+		number, wireType, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		fieldLength := protowire.ConsumeFieldValue(number, wireType, unknown[n:])
+		if fieldLength < 0 {
+			return nil, protowire.ParseError(fieldLength)
+		}
+		field := unknown[:n+fieldLength]
+		unknown = unknown[n+fieldLength:]
+		if number != bufImageFileExtensionFieldNumber || wireType != protowire.BytesType {
+			remainingUnknown = append(remainingUnknown, field...)
+			continue
+		}
+		extensionData, _ := protowire.ConsumeBytes(field[n:])
+		if err := mergeBufImageFileExtension(protoFileDescriptor, extensionData); err != nil {
+			return nil, fmt.Errorf("invalid Buf extension for file %q: %w", fileDescriptorProto.GetName(), err)
+		}
+	}
+	fileDescriptorProto.ProtoReflect().SetUnknown(remainingUnknown)
+	return protoFileDescriptor, nil
+}
+
+func mergeBufImageFileExtension(protoFileDescriptor *descriptorv1.FileDescriptor, data []byte) error {
+	for len(data) > 0 {
+		number, wireType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		switch {
+		case number == bufImageFileExtensionIsImportFieldNumber && wireType == protowire.VarintType:
+			value, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			protoFileDescriptor.IsImport = protowire.DecodeBool(value)
+			data = data[n:]
+		case number == bufImageFileExtensionIsSyntaxUnspecifiedFieldNumber && wireType == protowire.VarintType:
+			value, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			protoFileDescriptor.IsSyntaxUnspecified = protowire.DecodeBool(value)
+			data = data[n:]
+		case number == bufImageFileExtensionUnusedDependencyFieldNumber && wireType == protowire.VarintType:
+			value, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			protoFileDescriptor.UnusedDependency = append(protoFileDescriptor.UnusedDependency, int32(value))
+			data = data[n:]
+		case number == bufImageFileExtensionUnusedDependencyFieldNumber && wireType == protowire.BytesType:
+			// Packed encoding.
+			packed, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			for len(packed) > 0 {
+				value, m := protowire.ConsumeVarint(packed)
+				if m < 0 {
+					return protowire.ParseError(m)
+				}
+				protoFileDescriptor.UnusedDependency = append(protoFileDescriptor.UnusedDependency, int32(value))
+				packed = packed[m:]
+			}
+			data = data[n:]
+		default:
+			n := protowire.ConsumeFieldValue(number, wireType, data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+		}
+	}
+	return nil
+}
+
+func bufImageFileExtensionBytes(fileDescriptor FileDescriptor) []byte {
+	var data []byte
+	data = protowire.AppendTag(data, bufImageFileExtensionIsImportFieldNumber, protowire.VarintType)
+	data = protowire.AppendVarint(data, protowire.EncodeBool(fileDescriptor.IsImport()))
+	for _, unusedDependencyIndex := range fileDescriptor.UnusedDependencyIndexes() {
+		data = protowire.AppendTag(data, bufImageFileExtensionUnusedDependencyFieldNumber, protowire.VarintType)
+		data = protowire.AppendVarint(data, uint64(unusedDependencyIndex))
+	}
+	data = protowire.AppendTag(data, bufImageFileExtensionIsSyntaxUnspecifiedFieldNumber, protowire.VarintType)
+	data = protowire.AppendVarint(data, protowire.EncodeBool(fileDescriptor.IsSyntaxUnspecified()))
+	return data
+}
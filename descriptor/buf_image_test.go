@@ -0,0 +1,109 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestBufImageRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors := testNewBufImageFileDescriptors(t)
+
+	data, err := FileDescriptorsToBufImage(fileDescriptors)
+	require.NoError(t, err)
+	// A binary Buf image can be read as a FileDescriptorSet.
+	fileDescriptorSet := &descriptorpb.FileDescriptorSet{}
+	require.NoError(t, proto.Unmarshal(data, fileDescriptorSet))
+	require.Len(t, fileDescriptorSet.GetFile(), 2)
+	assert.Equal(t, "b.proto", fileDescriptorSet.GetFile()[1].GetName())
+	roundTripFileDescriptors, err := FileDescriptorsForBufImage(data)
+	require.NoError(t, err)
+	testAssertFileDescriptorsEqual(t, fileDescriptors, roundTripFileDescriptors)
+	assert.Empty(t, roundTripFileDescriptors[1].FileDescriptorProto().ProtoReflect().GetUnknown())
+
+	data, err = FileDescriptorsToBufImageJSON(fileDescriptors)
+	require.NoError(t, err)
+	roundTripFileDescriptors, err = FileDescriptorsForBufImageJSON(data)
+	require.NoError(t, err)
+	testAssertFileDescriptorsEqual(t, fileDescriptors, roundTripFileDescriptors)
+}
+
+func TestFileDescriptorsForBufImageJSON(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := FileDescriptorsForBufImageJSON(
+		[]byte(`{
+  "file": [
+    {
+      "name": "a.proto",
+      "bufExtension": {
+        "isImport": true,
+        "isSyntaxUnspecified": true,
+        "moduleInfo": {"name": {"remote": "buf.build", "owner": "foo", "repository": "bar"}}
+      }
+    },
+    {
+      "name": "b.proto",
+      "syntax": "proto3",
+      "dependency": ["a.proto"],
+      "bufExtension": {
+        "isImport": false,
+        "isSyntaxUnspecified": false,
+        "unusedDependency": [0]
+      }
+    }
+  ]
+}`),
+	)
+	require.NoError(t, err)
+	require.Len(t, fileDescriptors, 2)
+	assert.True(t, fileDescriptors[0].IsImport())
+	assert.True(t, fileDescriptors[0].IsSyntaxUnspecified())
+	assert.False(t, fileDescriptors[1].IsImport())
+	assert.Equal(t, []int32{0}, fileDescriptors[1].UnusedDependencyIndexes())
+	assert.Equal(t, []string{"a.proto"}, fileDescriptors[1].FileDescriptorProto().GetDependency())
+}
+
+func testNewBufImageFileDescriptors(t *testing.T) []FileDescriptor {
+	aFileDescriptor := testNewProtoFileDescriptor("a.proto", "")
+	aFileDescriptor.IsImport = true
+	aFileDescriptor.IsSyntaxUnspecified = true
+	aFileDescriptor.FileDescriptorProto.Syntax = nil
+	bFileDescriptor := testNewProtoFileDescriptor("b.proto", ".Foo", "a.proto")
+	bFileDescriptor.UnusedDependency = []int32{0}
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			aFileDescriptor,
+			bFileDescriptor,
+		},
+	)
+	require.NoError(t, err)
+	return fileDescriptors
+}
+
+func testAssertFileDescriptorsEqual(t *testing.T, expected []FileDescriptor, actual []FileDescriptor) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.True(t, proto.Equal(expected[i].ToProto(), actual[i].ToProto()), expected[i].FileDescriptorProto().GetName())
+	}
+}
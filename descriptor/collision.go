@@ -0,0 +1,232 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Symbol is a fully-qualified name defined within a FileDescriptor.
+type Symbol struct {
+	// FullName is the fully-qualified name of the Symbol, without a leading period.
+	FullName string
+	// ElementType is the type of element that defines the Symbol.
+	ElementType ElementType
+	// FileDescriptor is the FileDescriptor that defines the Symbol.
+	FileDescriptor FileDescriptor
+	// SourcePath is the path of the element that defines the Symbol within the
+	// FileDescriptorProto.
+	//
+	// Use FileLocationForSourcePath to get the location of the element.
+	SourcePath protoreflect.SourcePath
+}
+
+// SymbolCollision is a set of Symbols that collide, as returned by SymbolCollisions.
+type SymbolCollision struct {
+	// CaseInsensitive says that the Symbols have different fully-qualified names that only
+	// differ by case.
+	//
+	// If false, the Symbols have the same fully-qualified name.
+	CaseInsensitive bool
+	// Symbols are the colliding Symbols, at least two.
+	//
+	// If CaseInsensitive is true, there is one Symbol per distinct fully-qualified name.
+	// The Symbols are sorted by fully-qualified name, then by file name, then by path.
+	Symbols []Symbol
+}
+
+// SymbolCollisions returns the collisions between the symbols defined in the FileDescriptors.
+//
+// Symbols are the fully-qualified names of packages, messages, fields, oneofs, enums, enum
+// values, services, methods, and extensions. As in C++, enum values are scoped to the parent
+// of their enum, not to the enum itself.
+//
+// Two kinds of collisions are returned. Exact collisions are symbols with the same
+// fully-qualified name, which compilers reject. A package may be declared by any number of
+// files, so multiple declarations of the same package are not a collision, however a package
+// that collides with another kind of symbol is. Case-insensitive collisions are symbols whose
+// fully-qualified names only differ by case, which are valid, but cause problems for
+// languages and file systems that are case-insensitive.
+//
+// Only the FileDescriptorProtos are inspected, so this works for FileDescriptors that could not
+// be built into protoreflect.FileDescriptors because of collisions. Exact collisions are
+// returned first, sorted by fully-qualified name, followed by case-insensitive collisions,
+// sorted by lowercase fully-qualified name.
+func SymbolCollisions(fileDescriptors []FileDescriptor) []SymbolCollision {
+	fullNameToSymbols := make(map[string][]Symbol)
+	for _, fileDescriptor := range fileDescriptors {
+		addFileSymbols(fullNameToSymbols, fileDescriptor)
+	}
+	fullNames := make([]string, 0, len(fullNameToSymbols))
+	for fullName := range fullNameToSymbols {
+		fullNames = append(fullNames, fullName)
+	}
+	slices.Sort(fullNames)
+
+	var exactCollisions []SymbolCollision
+	lowerFullNameToFullNames := make(map[string][]string)
+	var lowerFullNames []string
+	for _, fullName := range fullNames {
+		symbols := fullNameToSymbols[fullName]
+		sortSymbols(symbols)
+		if isSymbolCollision(symbols) {
+			exactCollisions = append(
+				exactCollisions,
+				SymbolCollision{
+					Symbols: symbols,
+				},
+			)
+		}
+		lowerFullName := strings.ToLower(fullName)
+		if _, ok := lowerFullNameToFullNames[lowerFullName]; !ok {
+			lowerFullNames = append(lowerFullNames, lowerFullName)
+		}
+		lowerFullNameToFullNames[lowerFullName] = append(lowerFullNameToFullNames[lowerFullName], fullName)
+	}
+	slices.Sort(lowerFullNames)
+	var caseInsensitiveCollisions []SymbolCollision
+	for _, lowerFullName := range lowerFullNames {
+		collidingFullNames := lowerFullNameToFullNames[lowerFullName]
+		if len(collidingFullNames) < 2 {
+			continue
+		}
+		symbols := make([]Symbol, len(collidingFullNames))
+		for i, fullName := range collidingFullNames {
+			symbols[i] = fullNameToSymbols[fullName][0]
+		}
+		caseInsensitiveCollisions = append(
+			caseInsensitiveCollisions,
+			SymbolCollision{
+				CaseInsensitive: true,
+				Symbols:         symbols,
+			},
+		)
+	}
+	return append(exactCollisions, caseInsensitiveCollisions...)
+}
+
+// *** PRIVATE ***
+
+// isSymbolCollision returns true if the Symbols with the same fully-qualified name collide.
+func isSymbolCollision(symbols []Symbol) bool {
+	if len(symbols) < 2 {
+		return false
+	}
+	for _, symbol := range symbols {
+		if symbol.ElementType != ElementTypePackage {
+			return true
+		}
+	}
+	return false
+}
+
+func sortSymbols(symbols []Symbol) {
+	slices.SortFunc(
+		symbols,
+		func(one Symbol, two Symbol) int {
+			if compare := strings.Compare(one.FullName, two.FullName); compare != 0 {
+				return compare
+			}
+			if compare := strings.Compare(
+				one.FileDescriptor.FileDescriptorProto().GetName(),
+				two.FileDescriptor.FileDescriptorProto().GetName(),
+			); compare != 0 {
+				return compare
+			}
+			return slices.Compare(one.SourcePath, two.SourcePath)
+		},
+	)
+}
+
+func addFileSymbols(fullNameToSymbols map[string][]Symbol, fileDescriptor FileDescriptor) {
+	fileDescriptorProto := fileDescriptor.FileDescriptorProto()
+	addSymbol := func(fullName string, elementType ElementType, sourcePath protoreflect.SourcePath) {
+		fullNameToSymbols[fullName] = append(
+			fullNameToSymbols[fullName],
+			Symbol{
+				FullName:       fullName,
+				ElementType:    elementType,
+				FileDescriptor: fileDescriptor,
+				SourcePath:     sourcePath,
+			},
+		)
+	}
+	// Each component of the package is a symbol, for example "foo" and "foo.bar" for
+	// the package "foo.bar".
+	packageName := fileDescriptorProto.GetPackage()
+	if packageName != "" {
+		components := strings.Split(packageName, ".")
+		for i := range components {
+			addSymbol(strings.Join(components[:i+1], "."), ElementTypePackage, protoreflect.SourcePath{2})
+		}
+	}
+	var addMessages func(scope string, descriptorProtos []*descriptorpb.DescriptorProto, sourcePath protoreflect.SourcePath)
+	addEnums := func(scope string, enumDescriptorProtos []*descriptorpb.EnumDescriptorProto, sourcePath protoreflect.SourcePath) {
+		for i, enumDescriptorProto := range enumDescriptorProtos {
+			enumSourcePath := appendSourcePath(sourcePath, int32(i))
+			addSymbol(joinSymbolName(scope, enumDescriptorProto.GetName()), ElementTypeEnum, enumSourcePath)
+			for j, enumValueDescriptorProto := range enumDescriptorProto.GetValue() {
+				addSymbol(
+					joinSymbolName(scope, enumValueDescriptorProto.GetName()),
+					ElementTypeEnumValue,
+					appendSourcePath(enumSourcePath, 2, int32(j)),
+				)
+			}
+		}
+	}
+	addExtensions := func(scope string, fieldDescriptorProtos []*descriptorpb.FieldDescriptorProto, sourcePath protoreflect.SourcePath) {
+		for i, fieldDescriptorProto := range fieldDescriptorProtos {
+			addSymbol(joinSymbolName(scope, fieldDescriptorProto.GetName()), ElementTypeExtension, appendSourcePath(sourcePath, int32(i)))
+		}
+	}
+	addMessages = func(scope string, descriptorProtos []*descriptorpb.DescriptorProto, sourcePath protoreflect.SourcePath) {
+		for i, descriptorProto := range descriptorProtos {
+			messageSourcePath := appendSourcePath(sourcePath, int32(i))
+			messageName := joinSymbolName(scope, descriptorProto.GetName())
+			addSymbol(messageName, ElementTypeMessage, messageSourcePath)
+			for j, fieldDescriptorProto := range descriptorProto.GetField() {
+				addSymbol(joinSymbolName(messageName, fieldDescriptorProto.GetName()), ElementTypeField, appendSourcePath(messageSourcePath, 2, int32(j)))
+			}
+			for j, oneofDescriptorProto := range descriptorProto.GetOneofDecl() {
+				addSymbol(joinSymbolName(messageName, oneofDescriptorProto.GetName()), ElementTypeOneof, appendSourcePath(messageSourcePath, 8, int32(j)))
+			}
+			addMessages(messageName, descriptorProto.GetNestedType(), appendSourcePath(messageSourcePath, 3))
+			addEnums(messageName, descriptorProto.GetEnumType(), appendSourcePath(messageSourcePath, 4))
+			addExtensions(messageName, descriptorProto.GetExtension(), appendSourcePath(messageSourcePath, 6))
+		}
+	}
+	addMessages(packageName, fileDescriptorProto.GetMessageType(), protoreflect.SourcePath{4})
+	addEnums(packageName, fileDescriptorProto.GetEnumType(), protoreflect.SourcePath{5})
+	for i, serviceDescriptorProto := range fileDescriptorProto.GetService() {
+		serviceSourcePath := protoreflect.SourcePath{6, int32(i)}
+		serviceName := joinSymbolName(packageName, serviceDescriptorProto.GetName())
+		addSymbol(serviceName, ElementTypeService, serviceSourcePath)
+		for j, methodDescriptorProto := range serviceDescriptorProto.GetMethod() {
+			addSymbol(joinSymbolName(serviceName, methodDescriptorProto.GetName()), ElementTypeMethod, appendSourcePath(serviceSourcePath, 2, int32(j)))
+		}
+	}
+	addExtensions(packageName, fileDescriptorProto.GetExtension(), protoreflect.SourcePath{7})
+}
+
+func joinSymbolName(scope string, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
@@ -0,0 +1,113 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestSymbolCollisions(t *testing.T) {
+	t.Parallel()
+
+	a := testNewCollisionFileDescriptor(
+		&descriptorpb.FileDescriptorProto{
+			Name:    proto.String("a.proto"),
+			Package: proto.String("foo.bar"),
+			MessageType: []*descriptorpb.DescriptorProto{
+				{
+					Name: proto.String("Foo"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						{Name: proto.String("one")},
+						{Name: proto.String("ONE")},
+					},
+				},
+			},
+			EnumType: []*descriptorpb.EnumDescriptorProto{
+				{
+					Name: proto.String("Color"),
+					Value: []*descriptorpb.EnumValueDescriptorProto{
+						{Name: proto.String("RED")},
+					},
+				},
+			},
+		},
+	)
+	b := testNewCollisionFileDescriptor(
+		&descriptorpb.FileDescriptorProto{
+			Name:    proto.String("b.proto"),
+			Package: proto.String("foo.bar"),
+			MessageType: []*descriptorpb.DescriptorProto{
+				{Name: proto.String("Bar")},
+				{Name: proto.String("RED")},
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{
+				{Name: proto.String("BarService")},
+			},
+		},
+	)
+	c := testNewCollisionFileDescriptor(
+		&descriptorpb.FileDescriptorProto{
+			Name:    proto.String("c.proto"),
+			Package: proto.String("foo"),
+			MessageType: []*descriptorpb.DescriptorProto{
+				{Name: proto.String("bar")},
+			},
+		},
+	)
+
+	// Packages declared by multiple files are not collisions.
+	assert.Len(t, SymbolCollisions([]FileDescriptor{a, b}), 2)
+	assert.Empty(t, SymbolCollisions([]FileDescriptor{b}))
+
+	collisions := SymbolCollisions([]FileDescriptor{a, b, c})
+	require.Len(t, collisions, 3)
+
+	// Package components are symbols.
+	assert.False(t, collisions[0].CaseInsensitive)
+	require.Len(t, collisions[0].Symbols, 3)
+	assert.Equal(t, "foo.bar", collisions[0].Symbols[0].FullName)
+	assert.Equal(t, ElementTypePackage, collisions[0].Symbols[0].ElementType)
+	assert.Equal(t, ElementTypePackage, collisions[0].Symbols[1].ElementType)
+	assert.Equal(t, ElementTypeMessage, collisions[0].Symbols[2].ElementType)
+	assert.Equal(t, "c.proto", collisions[0].Symbols[2].FileDescriptor.FileDescriptorProto().GetName())
+
+	// Enum values are scoped to the parent of the enum.
+	assert.False(t, collisions[1].CaseInsensitive)
+	require.Len(t, collisions[1].Symbols, 2)
+	assert.Equal(t, "foo.bar.RED", collisions[1].Symbols[0].FullName)
+	assert.Equal(t, ElementTypeEnumValue, collisions[1].Symbols[0].ElementType)
+	assert.Equal(t, "a.proto", collisions[1].Symbols[0].FileDescriptor.FileDescriptorProto().GetName())
+	assert.Equal(t, protoreflect.SourcePath{5, 0, 2, 0}, collisions[1].Symbols[0].SourcePath)
+	assert.Equal(t, ElementTypeMessage, collisions[1].Symbols[1].ElementType)
+	assert.Equal(t, "b.proto", collisions[1].Symbols[1].FileDescriptor.FileDescriptorProto().GetName())
+	assert.Equal(t, protoreflect.SourcePath{4, 1}, collisions[1].Symbols[1].SourcePath)
+
+	assert.True(t, collisions[2].CaseInsensitive)
+	require.Len(t, collisions[2].Symbols, 2)
+	assert.Equal(t, "foo.bar.Foo.ONE", collisions[2].Symbols[0].FullName)
+	assert.Equal(t, protoreflect.SourcePath{4, 0, 2, 1}, collisions[2].Symbols[0].SourcePath)
+	assert.Equal(t, "foo.bar.Foo.one", collisions[2].Symbols[1].FullName)
+	assert.Equal(t, ElementTypeField, collisions[2].Symbols[1].ElementType)
+}
+
+func testNewCollisionFileDescriptor(fileDescriptorProto *descriptorpb.FileDescriptorProto) FileDescriptor {
+	return newFileDescriptor(nil, fileDescriptorProto, false, false, nil)
+}
@@ -0,0 +1,187 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Comments are the comments attached to an element within a FileDescriptor.
+type Comments interface {
+	// FileLocation returns the FileLocation of the element the comments are attached to.
+	//
+	// SourceCodeInfo does not record the positions of comments themselves, so the positions
+	// of the comments are relative to this location: leading and leading detached comments
+	// come before the start of the element, and trailing comments come after the end of the
+	// element.
+	FileLocation() FileLocation
+	// Leading returns the comment directly before the element, or nil if there is none.
+	Leading() Comment
+	// Trailing returns the comment directly after the element, or nil if there is none.
+	Trailing() Comment
+	// LeadingDetached returns the comments before the element that are separated from the
+	// element by a blank line, in the order they appear in the file.
+	LeadingDetached() []Comment
+
+	isComments()
+}
+
+// Comment is a single comment within a FileDescriptor.
+//
+// Comments may be written with either "//" or "/* */" delimiters. Compilers remove the
+// delimiters, as well as the leading "*" of each line of a block comment, before recording the
+// comment in SourceCodeInfo. The Text and Lines of a Comment are further normalized so that
+// equivalent comments in either style are identical.
+type Comment interface {
+	// Raw returns the comment as recorded within SourceCodeInfo.
+	Raw() string
+	// Text returns the normalized comment, with lines separated by "\n".
+	//
+	// The leading whitespace shared by all lines is removed, trailing whitespace is removed
+	// from each line, and leading and trailing blank lines are removed.
+	Text() string
+	// Lines returns the lines of the normalized comment.
+	Lines() []string
+
+	isComment()
+}
+
+// *** PRIVATE ***
+
+type comments struct {
+	fileLocation    FileLocation
+	leading         Comment
+	trailing        Comment
+	leadingDetached []Comment
+}
+
+func newComments(fileLocation FileLocation) *comments {
+	comments := &comments{
+		fileLocation: fileLocation,
+	}
+	if leadingComments := fileLocation.LeadingComments(); leadingComments != "" {
+		comments.leading = newComment(leadingComments)
+	}
+	if trailingComments := fileLocation.TrailingComments(); trailingComments != "" {
+		comments.trailing = newComment(trailingComments)
+	}
+	for _, leadingDetachedComments := range fileLocation.unclonedLeadingDetachedComments() {
+		comments.leadingDetached = append(comments.leadingDetached, newComment(leadingDetachedComments))
+	}
+	return comments
+}
+
+func (c *comments) FileLocation() FileLocation {
+	return c.fileLocation
+}
+
+func (c *comments) Leading() Comment {
+	return c.leading
+}
+
+func (c *comments) Trailing() Comment {
+	return c.trailing
+}
+
+func (c *comments) LeadingDetached() []Comment {
+	return slices.Clone(c.leadingDetached)
+}
+
+func (*comments) isComments() {}
+
+type comment struct {
+	raw   string
+	lines []string
+}
+
+func newComment(raw string) *comment {
+	return &comment{
+		raw:   raw,
+		lines: normalizeCommentLines(raw),
+	}
+}
+
+func (c *comment) Raw() string {
+	return c.raw
+}
+
+func (c *comment) Text() string {
+	return strings.Join(c.lines, "\n")
+}
+
+func (c *comment) Lines() []string {
+	return slices.Clone(c.lines)
+}
+
+func (*comment) isComment() {}
+
+// commentsForSourcePath returns the Comments for the element at the path within the
+// FileDescriptor.
+//
+// Returns false if there is no SourceCodeInfo location for the path.
+func commentsForSourcePath(fileDescriptor FileDescriptor, sourcePath protoreflect.SourcePath) (Comments, bool) {
+	fileLocation, ok := FileLocationForSourcePath(fileDescriptor, sourcePath)
+	if !ok {
+		return nil, false
+	}
+	return newComments(fileLocation), true
+}
+
+func normalizeCommentLines(raw string) []string {
+	lines := strings.Split(raw, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	lines = trimBlankCommentLines(lines)
+	prefix := commonCommentIndentation(lines)
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return lines
+}
+
+func trimBlankCommentLines(lines []string) []string {
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// commonCommentIndentation returns the leading whitespace shared by all non-blank lines.
+func commonCommentIndentation(lines []string) string {
+	var prefix string
+	var set bool
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		indentation := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !set {
+			prefix = indentation
+			set = true
+			continue
+		}
+		for !strings.HasPrefix(indentation, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,103 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestFileDescriptorComments(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:   proto.String("foo.proto"),
+					Syntax: proto.String("proto3"),
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name: proto.String("Foo"),
+						},
+						{
+							Name: proto.String("Bar"),
+						},
+					},
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+						Location: []*descriptorpb.SourceCodeInfo_Location{
+							{
+								Path:                    []int32{4, 0},
+								Span:                    []int32{5, 0, 6, 1},
+								LeadingComments:         proto.String(" Foo is a message.\n\n   Indented.\n"),
+								TrailingComments:        proto.String(" Trailing. \n"),
+								LeadingDetachedComments: []string{" Detached one.\n", "\n Detached two.\n"},
+							},
+							{
+								Path: []int32{4, 1},
+								Span: []int32{8, 0, 9, 1},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	fileDescriptor := fileDescriptors[0]
+	messageDescriptor := fileDescriptor.ProtoreflectFileDescriptor().Messages().ByName("Foo")
+	sourcePath, err := SourcePathForDescriptor(messageDescriptor)
+	require.NoError(t, err)
+
+	comments, ok := fileDescriptor.Comments(sourcePath)
+	require.True(t, ok)
+	assert.Equal(t, 5, comments.FileLocation().StartLine())
+	require.NotNil(t, comments.Leading())
+	assert.Equal(t, " Foo is a message.\n\n   Indented.\n", comments.Leading().Raw())
+	assert.Equal(t, "Foo is a message.\n\n  Indented.", comments.Leading().Text())
+	assert.Equal(t, []string{"Foo is a message.", "", "  Indented."}, comments.Leading().Lines())
+	require.NotNil(t, comments.Trailing())
+	assert.Equal(t, "Trailing.", comments.Trailing().Text())
+	leadingDetached := comments.LeadingDetached()
+	require.Len(t, leadingDetached, 2)
+	assert.Equal(t, "Detached one.", leadingDetached[0].Text())
+	assert.Equal(t, "Detached two.", leadingDetached[1].Text())
+
+	comments, ok = fileDescriptor.Comments(protoreflect.SourcePath{4, 1})
+	require.True(t, ok)
+	assert.Nil(t, comments.Leading())
+	assert.Nil(t, comments.Trailing())
+	assert.Empty(t, comments.LeadingDetached())
+
+	_, ok = fileDescriptor.Comments(protoreflect.SourcePath{4, 2})
+	assert.False(t, ok)
+}
+
+func TestNormalizeCommentLines(t *testing.T) {
+	t.Parallel()
+
+	// A "//" comment and the equivalent "/* */" comment, as recorded by protoc.
+	assert.Equal(t, []string{"Foo.", "Bar."}, normalizeCommentLines(" Foo.\n Bar.\n"))
+	assert.Equal(t, []string{"Foo.", "Bar."}, normalizeCommentLines("\n Foo.\n Bar.\n "))
+	assert.Equal(t, []string{"* one", "* two"}, normalizeCommentLines(" * one\n * two\n"))
+	assert.Equal(t, []string{"Foo.", "\tBar."}, normalizeCommentLines("\tFoo.\n\t\tBar.\t\n"))
+	assert.Empty(t, normalizeCommentLines("\n  \n"))
+}
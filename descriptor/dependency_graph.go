@@ -0,0 +1,281 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DependencyGraph is the graph of imports between a set of FileDescriptors.
+//
+// Files are identified by their name, that is the value of FileDescriptorProto.Name.
+// Only files within the set are part of the graph; imports of files that are not in the
+// set are ignored. All returned file names are in topological order: every file comes after
+// all of the files it imports, with ties broken by name. A DependencyGraph is safe for
+// concurrent use.
+type DependencyGraph interface {
+	// FileDescriptors returns the FileDescriptors in topological order.
+	FileDescriptors() []FileDescriptor
+	// FileNames returns the names of the files in topological order.
+	FileNames() []string
+	// FileDescriptor returns the FileDescriptor for the file name.
+	//
+	// Returns false if the file is not part of the graph.
+	FileDescriptor(fileName string) (FileDescriptor, bool)
+	// Dependencies returns the names of the files directly imported by the file.
+	//
+	// Returns nil if the file is not part of the graph.
+	Dependencies(fileName string) []string
+	// TransitiveDependencies returns the names of the files transitively imported by the file,
+	// not including the file itself.
+	//
+	// Returns nil if the file is not part of the graph.
+	TransitiveDependencies(fileName string) []string
+	// Dependents returns the names of the files that directly import the file.
+	//
+	// Returns nil if the file is not part of the graph.
+	Dependents(fileName string) []string
+	// TransitiveDependents returns the names of the files that transitively import the file,
+	// not including the file itself.
+	//
+	// Returns nil if the file is not part of the graph.
+	TransitiveDependents(fileName string) []string
+
+	isDependencyGraph()
+}
+
+// NewDependencyGraph returns a new DependencyGraph for the given FileDescriptors.
+//
+// Returns an error if there are duplicate file names, or if there is an import cycle. The
+// error for an import cycle lists the files within the cycle, for example
+// "import cycle: a.proto -> b.proto -> a.proto".
+func NewDependencyGraph(fileDescriptors []FileDescriptor) (DependencyGraph, error) {
+	return newDependencyGraph(fileDescriptors)
+}
+
+// *** PRIVATE ***
+
+type dependencyGraph struct {
+	fileNameToFileDescriptor map[string]FileDescriptor
+	// Sorted topologically.
+	fileNames []string
+	// Index within fileNames, used to sort topologically.
+	fileNameToIndex map[string]int
+	// Sorted topologically.
+	fileNameToDependencies map[string][]string
+	// Sorted topologically.
+	fileNameToDependents map[string][]string
+}
+
+func newDependencyGraph(fileDescriptors []FileDescriptor) (*dependencyGraph, error) {
+	fileNameToFileDescriptor := make(map[string]FileDescriptor, len(fileDescriptors))
+	for _, fileDescriptor := range fileDescriptors {
+		fileName := fileDescriptor.FileDescriptorProto().GetName()
+		if _, ok := fileNameToFileDescriptor[fileName]; ok {
+			return nil, fmt.Errorf("duplicate file name: %q", fileName)
+		}
+		fileNameToFileDescriptor[fileName] = fileDescriptor
+	}
+	fileNameToDependencies := make(map[string][]string, len(fileNameToFileDescriptor))
+	for fileName, fileDescriptor := range fileNameToFileDescriptor {
+		var dependencies []string
+		for _, dependency := range fileDescriptor.FileDescriptorProto().GetDependency() {
+			if _, ok := fileNameToFileDescriptor[dependency]; ok && !slices.Contains(dependencies, dependency) {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		fileNameToDependencies[fileName] = dependencies
+	}
+	fileNames, err := topologicalSort(fileNameToDependencies)
+	if err != nil {
+		return nil, err
+	}
+	fileNameToIndex := make(map[string]int, len(fileNames))
+	for i, fileName := range fileNames {
+		fileNameToIndex[fileName] = i
+	}
+	fileNameToDependents := make(map[string][]string, len(fileNames))
+	for _, fileName := range fileNames {
+		for _, dependency := range fileNameToDependencies[fileName] {
+			// Since we iterate in topological order, dependents are appended in topological order.
+			fileNameToDependents[dependency] = append(fileNameToDependents[dependency], fileName)
+		}
+	}
+	dependencyGraph := &dependencyGraph{
+		fileNameToFileDescriptor: fileNameToFileDescriptor,
+		fileNames:                fileNames,
+		fileNameToIndex:          fileNameToIndex,
+		fileNameToDependencies:   fileNameToDependencies,
+		fileNameToDependents:     fileNameToDependents,
+	}
+	for _, dependencies := range fileNameToDependencies {
+		dependencyGraph.sortTopologically(dependencies)
+	}
+	return dependencyGraph, nil
+}
+
+func (d *dependencyGraph) FileDescriptors() []FileDescriptor {
+	fileDescriptors := make([]FileDescriptor, len(d.fileNames))
+	for i, fileName := range d.fileNames {
+		fileDescriptors[i] = d.fileNameToFileDescriptor[fileName]
+	}
+	return fileDescriptors
+}
+
+func (d *dependencyGraph) FileNames() []string {
+	return slices.Clone(d.fileNames)
+}
+
+func (d *dependencyGraph) FileDescriptor(fileName string) (FileDescriptor, bool) {
+	fileDescriptor, ok := d.fileNameToFileDescriptor[fileName]
+	return fileDescriptor, ok
+}
+
+func (d *dependencyGraph) Dependencies(fileName string) []string {
+	if _, ok := d.fileNameToFileDescriptor[fileName]; !ok {
+		return nil
+	}
+	return slices.Clone(d.fileNameToDependencies[fileName])
+}
+
+func (d *dependencyGraph) TransitiveDependencies(fileName string) []string {
+	return d.transitive(fileName, d.fileNameToDependencies)
+}
+
+func (d *dependencyGraph) Dependents(fileName string) []string {
+	if _, ok := d.fileNameToFileDescriptor[fileName]; !ok {
+		return nil
+	}
+	return slices.Clone(d.fileNameToDependents[fileName])
+}
+
+func (d *dependencyGraph) TransitiveDependents(fileName string) []string {
+	return d.transitive(fileName, d.fileNameToDependents)
+}
+
+func (*dependencyGraph) isDependencyGraph() {}
+
+// transitive returns the files reachable from the file via the edges, not including the file
+// itself, sorted topologically.
+func (d *dependencyGraph) transitive(fileName string, fileNameToEdges map[string][]string) []string {
+	if _, ok := d.fileNameToFileDescriptor[fileName]; !ok {
+		return nil
+	}
+	seen := map[string]struct{}{
+		fileName: {},
+	}
+	var result []string
+	stack := slices.Clone(fileNameToEdges[fileName])
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := seen[current]; ok {
+			continue
+		}
+		seen[current] = struct{}{}
+		result = append(result, current)
+		stack = append(stack, fileNameToEdges[current]...)
+	}
+	d.sortTopologically(result)
+	return result
+}
+
+func (d *dependencyGraph) sortTopologically(fileNames []string) {
+	sort.Slice(
+		fileNames,
+		func(i int, j int) bool {
+			return d.fileNameToIndex[fileNames[i]] < d.fileNameToIndex[fileNames[j]]
+		},
+	)
+}
+
+// topologicalSort returns the file names sorted so that every file comes after all of its
+// dependencies, with ties broken by name.
+//
+// Every dependency must be a key of fileNameToDependencies.
+//
+// Returns an error if there is an import cycle.
+func topologicalSort(fileNameToDependencies map[string][]string) ([]string, error) {
+	// Kahn's algorithm, always choosing the smallest available name for determinism.
+	fileNameToNumRemainingDependencies := make(map[string]int, len(fileNameToDependencies))
+	fileNameToDependents := make(map[string][]string, len(fileNameToDependencies))
+	var available []string
+	for fileName, dependencies := range fileNameToDependencies {
+		fileNameToNumRemainingDependencies[fileName] = len(dependencies)
+		for _, dependency := range dependencies {
+			fileNameToDependents[dependency] = append(fileNameToDependents[dependency], fileName)
+		}
+		if len(dependencies) == 0 {
+			available = append(available, fileName)
+		}
+	}
+	fileNames := make([]string, 0, len(fileNameToDependencies))
+	for len(available) > 0 {
+		sort.Strings(available)
+		fileName := available[0]
+		available = available[1:]
+		fileNames = append(fileNames, fileName)
+		for _, dependent := range fileNameToDependents[fileName] {
+			fileNameToNumRemainingDependencies[dependent]--
+			if fileNameToNumRemainingDependencies[dependent] == 0 {
+				available = append(available, dependent)
+			}
+		}
+	}
+	if len(fileNames) != len(fileNameToDependencies) {
+		return nil, fmt.Errorf(
+			"import cycle: %s",
+			strings.Join(findImportCycle(fileNameToDependencies, fileNameToNumRemainingDependencies), " -> "),
+		)
+	}
+	return fileNames, nil
+}
+
+// findImportCycle finds an import cycle among the files with remaining dependencies after
+// topologicalSort.
+//
+// The cycle starts and ends with the same file.
+func findImportCycle(
+	fileNameToDependencies map[string][]string,
+	fileNameToNumRemainingDependencies map[string]int,
+) []string {
+	var start string
+	for fileName, numRemainingDependencies := range fileNameToNumRemainingDependencies {
+		if numRemainingDependencies > 0 && (start == "" || fileName < start) {
+			start = fileName
+		}
+	}
+	// Every file with remaining dependencies has a remaining dependency that also has
+	// remaining dependencies, so following these edges must eventually revisit a file.
+	fileNameToPathIndex := make(map[string]int)
+	var path []string
+	current := start
+	for {
+		if pathIndex, ok := fileNameToPathIndex[current]; ok {
+			return append(path[pathIndex:], current)
+		}
+		fileNameToPathIndex[current] = len(path)
+		path = append(path, current)
+		for _, dependency := range fileNameToDependencies[current] {
+			if fileNameToNumRemainingDependencies[dependency] > 0 {
+				current = dependency
+				break
+			}
+		}
+	}
+}
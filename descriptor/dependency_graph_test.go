@@ -0,0 +1,106 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("d.proto", "", "a.proto"),
+			testNewProtoFileDescriptor("c.proto", "", "b.proto", "a.proto"),
+			testNewProtoFileDescriptor("b.proto", "", "a.proto"),
+			testNewProtoFileDescriptor("a.proto", ""),
+			testNewProtoFileDescriptor("e.proto", ""),
+		},
+	)
+	require.NoError(t, err)
+	dependencyGraph, err := NewDependencyGraph(fileDescriptors)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a.proto", "b.proto", "c.proto", "d.proto", "e.proto"}, dependencyGraph.FileNames())
+	graphFileDescriptors := dependencyGraph.FileDescriptors()
+	require.Len(t, graphFileDescriptors, 5)
+	assert.Equal(t, "a.proto", graphFileDescriptors[0].FileDescriptorProto().GetName())
+	fileDescriptor, ok := dependencyGraph.FileDescriptor("c.proto")
+	require.True(t, ok)
+	assert.Equal(t, "c.proto", fileDescriptor.FileDescriptorProto().GetName())
+	_, ok = dependencyGraph.FileDescriptor("unknown.proto")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"a.proto", "b.proto"}, dependencyGraph.Dependencies("c.proto"))
+	assert.Equal(t, []string{"a.proto"}, dependencyGraph.TransitiveDependencies("b.proto"))
+	assert.Equal(t, []string{"a.proto", "b.proto"}, dependencyGraph.TransitiveDependencies("c.proto"))
+	assert.Empty(t, dependencyGraph.TransitiveDependencies("a.proto"))
+	assert.Equal(t, []string{"b.proto", "c.proto", "d.proto"}, dependencyGraph.Dependents("a.proto"))
+	assert.Equal(t, []string{"c.proto"}, dependencyGraph.Dependents("b.proto"))
+	assert.Equal(t, []string{"b.proto", "c.proto", "d.proto"}, dependencyGraph.TransitiveDependents("a.proto"))
+	assert.Empty(t, dependencyGraph.TransitiveDependents("e.proto"))
+	assert.Nil(t, dependencyGraph.Dependencies("unknown.proto"))
+	assert.Nil(t, dependencyGraph.TransitiveDependents("unknown.proto"))
+
+	// Imports of files outside of the set are ignored.
+	dependencyGraph, err = NewDependencyGraph(
+		[]FileDescriptor{
+			graphFileDescriptors[2],
+			graphFileDescriptors[1],
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b.proto", "c.proto"}, dependencyGraph.FileNames())
+	assert.Equal(t, []string{"b.proto"}, dependencyGraph.Dependencies("c.proto"))
+
+	_, err = NewDependencyGraph(
+		[]FileDescriptor{
+			graphFileDescriptors[0],
+			graphFileDescriptors[0],
+		},
+	)
+	assert.EqualError(t, err, `duplicate file name: "a.proto"`)
+}
+
+func TestDependencyGraphImportCycle(t *testing.T) {
+	t.Parallel()
+
+	// FileDescriptorsForProtoFileDescriptors rejects import cycles, so construct the
+	// FileDescriptors directly.
+	_, err := NewDependencyGraph(
+		[]FileDescriptor{
+			testNewDependencyGraphFileDescriptor("a.proto"),
+			testNewDependencyGraphFileDescriptor("b.proto", "c.proto"),
+			testNewDependencyGraphFileDescriptor("c.proto", "a.proto", "d.proto"),
+			testNewDependencyGraphFileDescriptor("d.proto", "b.proto"),
+		},
+	)
+	assert.EqualError(t, err, "import cycle: b.proto -> c.proto -> d.proto -> b.proto")
+}
+
+func testNewDependencyGraphFileDescriptor(name string, dependencies ...string) FileDescriptor {
+	return newFileDescriptor(
+		nil,
+		testNewProtoFileDescriptor(name, "", dependencies...).GetFileDescriptorProto(),
+		false,
+		false,
+		nil,
+	)
+}
@@ -0,0 +1,489 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// ChangeTypeAdded says that an element is present in the current FileDescriptors but not
+	// in the against FileDescriptors.
+	ChangeTypeAdded ChangeType = 1
+	// ChangeTypeRemoved says that an element is present in the against FileDescriptors but
+	// not in the current FileDescriptors.
+	ChangeTypeRemoved ChangeType = 2
+	// ChangeTypeChanged says that a property of an element differs between the current and
+	// against FileDescriptors.
+	ChangeTypeChanged ChangeType = 3
+)
+
+const (
+	// ElementTypeFile is a file.
+	ElementTypeFile ElementType = 1
+	// ElementTypeMessage is a message.
+	ElementTypeMessage ElementType = 2
+	// ElementTypeField is a field of a message.
+	ElementTypeField ElementType = 3
+	// ElementTypeExtension is an extension.
+	ElementTypeExtension ElementType = 4
+	// ElementTypeEnum is an enum.
+	ElementTypeEnum ElementType = 5
+	// ElementTypeEnumValue is a value of an enum.
+	ElementTypeEnumValue ElementType = 6
+	// ElementTypeService is a service.
+	ElementTypeService ElementType = 7
+	// ElementTypeMethod is a method of a service.
+	ElementTypeMethod ElementType = 8
+	// ElementTypeOneof is a oneof of a message.
+	ElementTypeOneof ElementType = 9
+	// ElementTypePackage is a package, or a component of a package.
+	ElementTypePackage ElementType = 10
+)
+
+var (
+	changeTypeToString = map[ChangeType]string{
+		ChangeTypeAdded:   "added",
+		ChangeTypeRemoved: "removed",
+		ChangeTypeChanged: "changed",
+	}
+	elementTypeToString = map[ElementType]string{
+		ElementTypeFile:      "file",
+		ElementTypeMessage:   "message",
+		ElementTypeField:     "field",
+		ElementTypeExtension: "extension",
+		ElementTypeEnum:      "enum",
+		ElementTypeEnumValue: "enum value",
+		ElementTypeService:   "service",
+		ElementTypeMethod:    "method",
+		ElementTypeOneof:     "oneof",
+		ElementTypePackage:   "package",
+	}
+)
+
+// ChangeType is the type of a Change.
+type ChangeType int
+
+// String implements fmt.Stringer.
+func (t ChangeType) String() string {
+	if s, ok := changeTypeToString[t]; ok {
+		return s
+	}
+	return strconv.Itoa(int(t))
+}
+
+// ElementType is the type of an element within FileDescriptors, such as the element that a
+// Change applies to.
+//
+// Diff never produces Changes for ElementTypeOneof or ElementTypePackage.
+type ElementType int
+
+// String implements fmt.Stringer.
+func (t ElementType) String() string {
+	if s, ok := elementTypeToString[t]; ok {
+		return s
+	}
+	return strconv.Itoa(int(t))
+}
+
+// Change is a single difference between two sets of FileDescriptors, as returned by Diff.
+type Change struct {
+	// Type is the type of the Change.
+	Type ChangeType
+	// ElementType is the type of the element that changed.
+	ElementType ElementType
+	// Name is the path of the file for ElementTypeFile, and the fully-qualified name of
+	// the element otherwise.
+	Name string
+	// Property is the property of the element that changed.
+	//
+	// Only set for ChangeTypeChanged. Properties are the name of the corresponding field in
+	// the descriptor, such as "number" or "json_name", "file" if an element moved to another
+	// file, or "option <name>" for options, such as "option deprecated" or
+	// "option (acme.v1.safe)" for custom options.
+	Property string
+	// Before is the value of Property in the against FileDescriptors.
+	//
+	// Only set for ChangeTypeChanged. Empty if the property was not set.
+	Before string
+	// After is the value of Property in the current FileDescriptors.
+	//
+	// Only set for ChangeTypeChanged. Empty if the property is not set.
+	After string
+	// Location is the location of the element in the current FileDescriptors.
+	//
+	// Nil for ChangeTypeRemoved.
+	Location FileLocation
+	// AgainstLocation is the location of the element in the against FileDescriptors.
+	//
+	// Nil for ChangeTypeAdded.
+	AgainstLocation FileLocation
+}
+
+// Diff returns the Changes from againstFileDescriptors to fileDescriptors.
+//
+// This is the shared comparison core for breaking change and changelog plugins. Files are
+// matched by path, and all other elements are matched by fully-qualified name. Custom options
+// are resolved against the FileDescriptors that they are defined in. Imports are compared
+// like any other file; filter the Changes on Location and AgainstLocation to ignore them.
+//
+// The returned Changes are sorted by Name, then ElementType, then Type, then Property.
+func Diff(fileDescriptors []FileDescriptor, againstFileDescriptors []FileDescriptor) ([]Change, error) {
+	currentDiffSide, err := newDiffSide(fileDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	againstDiffSide, err := newDiffSide(againstFileDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	for name, againstElement := range againstDiffSide.nameToElement {
+		currentElement, ok := currentDiffSide.nameToElement[name]
+		if !ok || currentElement.elementType != againstElement.elementType {
+			changes = append(
+				changes,
+				Change{
+					Type:            ChangeTypeRemoved,
+					ElementType:     againstElement.elementType,
+					Name:            name,
+					AgainstLocation: againstElement.location(),
+				},
+			)
+			continue
+		}
+		currentProperties := currentDiffSide.properties(currentElement)
+		againstProperties := againstDiffSide.properties(againstElement)
+		for _, property := range sortedUnionKeys(currentProperties, againstProperties) {
+			if currentProperties[property] == againstProperties[property] {
+				continue
+			}
+			changes = append(
+				changes,
+				Change{
+					Type:            ChangeTypeChanged,
+					ElementType:     currentElement.elementType,
+					Name:            name,
+					Property:        property,
+					Before:          againstProperties[property],
+					After:           currentProperties[property],
+					Location:        currentElement.location(),
+					AgainstLocation: againstElement.location(),
+				},
+			)
+		}
+	}
+	for name, currentElement := range currentDiffSide.nameToElement {
+		againstElement, ok := againstDiffSide.nameToElement[name]
+		if !ok || currentElement.elementType != againstElement.elementType {
+			changes = append(
+				changes,
+				Change{
+					Type:        ChangeTypeAdded,
+					ElementType: currentElement.elementType,
+					Name:        name,
+					Location:    currentElement.location(),
+				},
+			)
+		}
+	}
+	sort.Slice(
+		changes,
+		func(i int, j int) bool {
+			one, two := changes[i], changes[j]
+			if one.Name != two.Name {
+				return one.Name < two.Name
+			}
+			if one.ElementType != two.ElementType {
+				return one.ElementType < two.ElementType
+			}
+			if one.Type != two.Type {
+				return one.Type < two.Type
+			}
+			return one.Property < two.Property
+		},
+	)
+	return changes, nil
+}
+
+// *** PRIVATE ***
+
+type diffElement struct {
+	elementType    ElementType
+	descriptor     protoreflect.Descriptor
+	fileDescriptor FileDescriptor
+}
+
+func (e *diffElement) location() FileLocation {
+	if e.elementType == ElementTypeFile {
+		return NewFileLocation(e.fileDescriptor, protoreflect.SourceLocation{})
+	}
+	sourceLocations := e.fileDescriptor.ProtoreflectFileDescriptor().SourceLocations()
+	return NewFileLocation(e.fileDescriptor, sourceLocations.ByDescriptor(e.descriptor))
+}
+
+// diffSide is one side of a Diff.
+type diffSide struct {
+	resolver      Resolver
+	nameToElement map[string]*diffElement
+}
+
+func newDiffSide(fileDescriptors []FileDescriptor) (*diffSide, error) {
+	resolver, err := ResolverForFileDescriptors(fileDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	d := &diffSide{
+		resolver:      resolver,
+		nameToElement: make(map[string]*diffElement),
+	}
+	for _, fileDescriptor := range fileDescriptors {
+		protoreflectFileDescriptor, err := fileDescriptor.BuildProtoreflectFileDescriptor()
+		if err != nil {
+			return nil, err
+		}
+		d.add(fileDescriptor, ElementTypeFile, protoreflectFileDescriptor, protoreflectFileDescriptor.Path())
+		d.addMessages(fileDescriptor, protoreflectFileDescriptor.Messages())
+		d.addEnums(fileDescriptor, protoreflectFileDescriptor.Enums())
+		d.addExtensions(fileDescriptor, protoreflectFileDescriptor.Extensions())
+		services := protoreflectFileDescriptor.Services()
+		for i := range services.Len() {
+			service := services.Get(i)
+			d.add(fileDescriptor, ElementTypeService, service, string(service.FullName()))
+			methods := service.Methods()
+			for j := range methods.Len() {
+				d.add(fileDescriptor, ElementTypeMethod, methods.Get(j), string(methods.Get(j).FullName()))
+			}
+		}
+	}
+	return d, nil
+}
+
+func (d *diffSide) addMessages(fileDescriptor FileDescriptor, messages protoreflect.MessageDescriptors) {
+	for i := range messages.Len() {
+		message := messages.Get(i)
+		if message.IsMapEntry() {
+			// Map entries are compared as part of their map field.
+			continue
+		}
+		d.add(fileDescriptor, ElementTypeMessage, message, string(message.FullName()))
+		fields := message.Fields()
+		for j := range fields.Len() {
+			d.add(fileDescriptor, ElementTypeField, fields.Get(j), string(fields.Get(j).FullName()))
+		}
+		d.addMessages(fileDescriptor, message.Messages())
+		d.addEnums(fileDescriptor, message.Enums())
+		d.addExtensions(fileDescriptor, message.Extensions())
+	}
+}
+
+func (d *diffSide) addEnums(fileDescriptor FileDescriptor, enums protoreflect.EnumDescriptors) {
+	for i := range enums.Len() {
+		enum := enums.Get(i)
+		d.add(fileDescriptor, ElementTypeEnum, enum, string(enum.FullName()))
+		values := enum.Values()
+		for j := range values.Len() {
+			d.add(fileDescriptor, ElementTypeEnumValue, values.Get(j), string(values.Get(j).FullName()))
+		}
+	}
+}
+
+func (d *diffSide) addExtensions(fileDescriptor FileDescriptor, extensions protoreflect.ExtensionDescriptors) {
+	for i := range extensions.Len() {
+		d.add(fileDescriptor, ElementTypeExtension, extensions.Get(i), string(extensions.Get(i).FullName()))
+	}
+}
+
+func (d *diffSide) add(fileDescriptor FileDescriptor, elementType ElementType, descriptor protoreflect.Descriptor, name string) {
+	d.nameToElement[name] = &diffElement{
+		elementType:    elementType,
+		descriptor:     descriptor,
+		fileDescriptor: fileDescriptor,
+	}
+}
+
+// properties returns the properties of the element that are compared.
+//
+// Properties that are not set are not present in the returned map.
+func (d *diffSide) properties(element *diffElement) map[string]string {
+	properties := make(map[string]string)
+	setProperty := func(property string, value string) {
+		if value != "" {
+			properties[property] = value
+		}
+	}
+	switch descriptor := element.descriptor.(type) {
+	case protoreflect.FileDescriptor:
+		setProperty("package", string(descriptor.Package()))
+		setProperty("syntax", descriptor.Syntax().String())
+	case protoreflect.FieldDescriptor:
+		setProperty("number", strconv.Itoa(int(descriptor.Number())))
+		setProperty("cardinality", descriptor.Cardinality().String())
+		setProperty("type", fieldTypeString(descriptor))
+		setProperty("json_name", descriptor.JSONName())
+		if descriptor.HasDefault() {
+			setProperty("default", formatValue(descriptor, descriptor.Default()))
+		}
+		if oneof := descriptor.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			setProperty("oneof", string(oneof.Name()))
+		}
+		if descriptor.IsExtension() {
+			setProperty("extendee", string(descriptor.ContainingMessage().FullName()))
+		}
+	case protoreflect.EnumValueDescriptor:
+		setProperty("number", strconv.Itoa(int(descriptor.Number())))
+	case protoreflect.MethodDescriptor:
+		setProperty("input_type", string(descriptor.Input().FullName()))
+		setProperty("output_type", string(descriptor.Output().FullName()))
+		setProperty("client_streaming", strconv.FormatBool(descriptor.IsStreamingClient()))
+		setProperty("server_streaming", strconv.FormatBool(descriptor.IsStreamingServer()))
+	}
+	if _, ok := element.descriptor.Parent().(protoreflect.FileDescriptor); ok {
+		// Only top-level elements can move between files on their own.
+		setProperty("file", element.fileDescriptor.FileDescriptorProto().GetName())
+	}
+	for optionName, optionValue := range d.optionProperties(element.descriptor.Options()) {
+		setProperty("option "+optionName, optionValue)
+	}
+	return properties
+}
+
+// optionProperties returns the set options of the options message, with custom options
+// resolved against the Resolver of the diffSide.
+func (d *diffSide) optionProperties(options proto.Message) map[string]string {
+	optionNameToValue := make(map[string]string)
+	if options == nil || !options.ProtoReflect().IsValid() {
+		return optionNameToValue
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+	if err != nil {
+		return optionNameToValue
+	}
+	resolvedOptions := dynamicpb.NewMessage(options.ProtoReflect().Descriptor())
+	if err := (proto.UnmarshalOptions{Resolver: d.resolver}).Unmarshal(data, resolvedOptions); err != nil {
+		return optionNameToValue
+	}
+	resolvedOptions.Range(
+		func(fieldDescriptor protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			optionName := string(fieldDescriptor.Name())
+			if fieldDescriptor.IsExtension() {
+				optionName = "(" + string(fieldDescriptor.FullName()) + ")"
+			}
+			optionNameToValue[optionName] = formatValue(fieldDescriptor, value)
+			return true
+		},
+	)
+	if unknown := resolvedOptions.GetUnknown(); len(unknown) > 0 {
+		optionNameToValue["unknown"] = strconv.Quote(string(unknown))
+	}
+	return optionNameToValue
+}
+
+func fieldTypeString(fieldDescriptor protoreflect.FieldDescriptor) string {
+	if fieldDescriptor.IsMap() {
+		return fmt.Sprintf(
+			"map<%s, %s>",
+			fieldTypeString(fieldDescriptor.MapKey()),
+			fieldTypeString(fieldDescriptor.MapValue()),
+		)
+	}
+	switch fieldDescriptor.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(fieldDescriptor.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(fieldDescriptor.Enum().FullName())
+	default:
+		return fieldDescriptor.Kind().String()
+	}
+}
+
+// formatValue formats the value of the field deterministically.
+func formatValue(fieldDescriptor protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch {
+	case fieldDescriptor.IsList():
+		list := value.List()
+		elements := make([]string, list.Len())
+		for i := range list.Len() {
+			elements[i] = formatSingularValue(fieldDescriptor, list.Get(i))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case fieldDescriptor.IsMap():
+		var entries []string
+		value.Map().Range(
+			func(mapKey protoreflect.MapKey, mapValue protoreflect.Value) bool {
+				entries = append(
+					entries,
+					formatSingularValue(fieldDescriptor.MapKey(), mapKey.Value())+": "+
+						formatSingularValue(fieldDescriptor.MapValue(), mapValue),
+				)
+				return true
+			},
+		)
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ", ") + "}"
+	default:
+		return formatSingularValue(fieldDescriptor, value)
+	}
+}
+
+func formatSingularValue(fieldDescriptor protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fieldDescriptor.Kind() {
+	case protoreflect.StringKind:
+		return strconv.Quote(value.String())
+	case protoreflect.BytesKind:
+		return strconv.Quote(string(value.Bytes()))
+	case protoreflect.EnumKind:
+		if enumValue := fieldDescriptor.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		message := value.Message()
+		fields := message.Descriptor().Fields()
+		var entries []string
+		for i := range fields.Len() {
+			field := fields.Get(i)
+			if message.Has(field) {
+				entries = append(entries, string(field.Name())+": "+formatValue(field, message.Get(field)))
+			}
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+func sortedUnionKeys(one map[string]string, two map[string]string) []string {
+	keySet := make(map[string]struct{}, len(one)+len(two))
+	for key := range one {
+		keySet[key] = struct{}{}
+	}
+	for key := range two {
+		keySet[key] = struct{}{}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
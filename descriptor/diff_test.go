@@ -0,0 +1,161 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	againstFileDescriptors := testNewDiffFileDescriptors(t, false)
+	fileDescriptors := testNewDiffFileDescriptors(t, true)
+
+	changes, err := Diff(fileDescriptors, againstFileDescriptors)
+	require.NoError(t, err)
+	type testChange struct {
+		Type        ChangeType
+		ElementType ElementType
+		Name        string
+		Property    string
+		Before      string
+		After       string
+	}
+	testChanges := make([]testChange, len(changes))
+	for i, change := range changes {
+		testChanges[i] = testChange{
+			Type:        change.Type,
+			ElementType: change.ElementType,
+			Name:        change.Name,
+			Property:    change.Property,
+			Before:      change.Before,
+			After:       change.After,
+		}
+		switch change.Type {
+		case ChangeTypeAdded:
+			assert.NotNil(t, change.Location)
+			assert.Nil(t, change.AgainstLocation)
+		case ChangeTypeRemoved:
+			assert.Nil(t, change.Location)
+			assert.NotNil(t, change.AgainstLocation)
+		case ChangeTypeChanged:
+			assert.NotNil(t, change.Location)
+			assert.NotNil(t, change.AgainstLocation)
+		}
+	}
+	assert.Equal(
+		t,
+		[]testChange{
+			{Type: ChangeTypeRemoved, ElementType: ElementTypeEnumValue, Name: "foo.BAR_UNSPECIFIED"},
+			{Type: ChangeTypeRemoved, ElementType: ElementTypeEnum, Name: "foo.Bar"},
+			{Type: ChangeTypeChanged, ElementType: ElementTypeField, Name: "foo.Foo.id", Property: "type", Before: "int32", After: "int64"},
+			{Type: ChangeTypeChanged, ElementType: ElementTypeField, Name: "foo.Foo.name", Property: "option (foo.safe)", After: "true"},
+			{Type: ChangeTypeChanged, ElementType: ElementTypeField, Name: "foo.Foo.name", Property: "option deprecated", After: "true"},
+			{Type: ChangeTypeAdded, ElementType: ElementTypeField, Name: "foo.Foo.value"},
+		},
+		testChanges,
+	)
+	assert.Equal(t, "foo.proto", changes[1].AgainstLocation.FileDescriptor().FileDescriptorProto().GetName())
+
+	changes, err = Diff(fileDescriptors, fileDescriptors)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func testNewDiffFileDescriptors(t *testing.T, current bool) []FileDescriptor {
+	idType := descriptorpb.FieldDescriptorProto_TYPE_INT32
+	nameOptions := &descriptorpb.FieldOptions{}
+	fields := []*descriptorpb.FieldDescriptorProto{
+		testNewFieldDescriptorProto("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nameOptions),
+	}
+	var enums []*descriptorpb.EnumDescriptorProto
+	if current {
+		idType = descriptorpb.FieldDescriptorProto_TYPE_INT64
+		nameOptions.Deprecated = proto.Bool(true)
+		// Set the custom option (foo.safe) = true as an unknown field, as it is when parsed.
+		nameOptions.ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 50000, protowire.VarintType), 1))
+		fields = append(fields, testNewFieldDescriptorProto("value", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil))
+	} else {
+		enums = []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: proto.String("Bar"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("BAR_UNSPECIFIED"), Number: proto.Int32(0)},
+				},
+			},
+		}
+	}
+	fields = append(fields, testNewFieldDescriptorProto("id", 2, idType, nil))
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+				IsImport:            true,
+			},
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:       proto.String("foo.proto"),
+					Package:    proto.String("foo"),
+					Syntax:     proto.String("proto3"),
+					Dependency: []string{"google/protobuf/descriptor.proto"},
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name:  proto.String("Foo"),
+							Field: fields,
+						},
+					},
+					EnumType: enums,
+					Extension: []*descriptorpb.FieldDescriptorProto{
+						{
+							Name:     proto.String("safe"),
+							Number:   proto.Int32(50000),
+							Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+							Type:     descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+							Extendee: proto.String(".google.protobuf.FieldOptions"),
+							JsonName: proto.String("safe"),
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	return fileDescriptors
+}
+
+func testNewFieldDescriptorProto(
+	name string,
+	number int32,
+	fieldType descriptorpb.FieldDescriptorProto_Type,
+	options *descriptorpb.FieldOptions,
+) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     fieldType.Enum(),
+		JsonName: proto.String(name),
+		Options:  options,
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ResolvedFeaturesForDescriptor returns the resolved features of the given descriptor.
+//
+// Features are resolved the same way compilers resolve them: the defaults for the edition of
+// the file are overridden by the features set on the file, then by the features set on each
+// enclosing element, and finally by the features set on the descriptor itself. A field within
+// a oneof inherits the features of the oneof, and an extension inherits the features of the
+// scope it is declared in.
+//
+// Files that use proto2 or proto3 syntax are treated as the corresponding edition, and the
+// features implied by their syntax are inferred: a proto2 required field has a field presence
+// of LEGACY_REQUIRED, a group has a message encoding of DELIMITED, a proto3 optional field
+// has a field presence of EXPLICIT, and the packed option determines the repeated field
+// encoding.
+//
+// Every field of the returned FeatureSet is set. Features defined as extensions of
+// FeatureSet, such as language-specific features, are included only if they are explicitly
+// set, as their defaults are not known to this package.
+func ResolvedFeaturesForDescriptor(descriptor protoreflect.Descriptor) (*descriptorpb.FeatureSet, error) {
+	edition, err := editionForFileDescriptor(descriptor.ParentFile())
+	if err != nil {
+		return nil, err
+	}
+	features, err := featureSetDefaultsForEdition(edition)
+	if err != nil {
+		return nil, err
+	}
+	for _, current := range featureInheritanceChain(descriptor) {
+		proto.Merge(features, featureSetForDescriptor(current))
+		if edition == descriptorpb.Edition_EDITION_PROTO2 || edition == descriptorpb.Edition_EDITION_PROTO3 {
+			if fieldDescriptor, ok := current.(protoreflect.FieldDescriptor); ok {
+				mergeInferredFieldFeatures(features, fieldDescriptor, edition)
+			}
+		}
+	}
+	return features, nil
+}
+
+// *** PRIVATE ***
+
+// editionForFileDescriptor returns the edition of the file.
+func editionForFileDescriptor(fileDescriptor protoreflect.FileDescriptor) (descriptorpb.Edition, error) {
+	if fileDescriptor == nil {
+		return 0, fmt.Errorf("descriptor has no parent file")
+	}
+	// This is the same approach protodesc uses, as protoreflect.FileDescriptor does not expose
+	// the edition directly.
+	if editionFileDescriptor, ok := fileDescriptor.(interface{ Edition() int32 }); ok {
+		if edition := descriptorpb.Edition(editionFileDescriptor.Edition()); edition != descriptorpb.Edition_EDITION_UNKNOWN {
+			return edition, nil
+		}
+	}
+	switch syntax := fileDescriptor.Syntax(); syntax {
+	case protoreflect.Proto2:
+		return descriptorpb.Edition_EDITION_PROTO2, nil
+	case protoreflect.Proto3:
+		return descriptorpb.Edition_EDITION_PROTO3, nil
+	default:
+		return 0, fmt.Errorf("could not determine edition of file %q with syntax %v", fileDescriptor.Path(), syntax)
+	}
+}
+
+// featureSetDefaultsForEdition returns the default FeatureSet for the edition, as defined by the
+// edition_defaults options of the fields of FeatureSet.
+func featureSetDefaultsForEdition(edition descriptorpb.Edition) (*descriptorpb.FeatureSet, error) {
+	features := &descriptorpb.FeatureSet{}
+	message := features.ProtoReflect()
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldOptions, ok := field.Options().(*descriptorpb.FieldOptions)
+		if !ok {
+			continue
+		}
+		var defaultValue string
+		var defaultEdition descriptorpb.Edition
+		for _, editionDefault := range fieldOptions.GetEditionDefaults() {
+			if editionDefault.GetEdition() <= edition && editionDefault.GetEdition() >= defaultEdition {
+				defaultValue = editionDefault.GetValue()
+				defaultEdition = editionDefault.GetEdition()
+			}
+		}
+		if defaultEdition == descriptorpb.Edition_EDITION_UNKNOWN {
+			return nil, fmt.Errorf("no default for feature %q in edition %v", field.Name(), edition)
+		}
+		value, err := featureDefaultValue(field, defaultValue)
+		if err != nil {
+			return nil, err
+		}
+		message.Set(field, value)
+	}
+	return features, nil
+}
+
+func featureDefaultValue(field protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		enumValueDescriptor := field.Enum().Values().ByName(protoreflect.Name(value))
+		if enumValueDescriptor == nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown default %q for feature %q", value, field.Name())
+		}
+		return protoreflect.ValueOfEnum(enumValueDescriptor.Number()), nil
+	case protoreflect.BoolKind:
+		switch value {
+		case "true":
+			return protoreflect.ValueOfBool(true), nil
+		case "false":
+			return protoreflect.ValueOfBool(false), nil
+		}
+		return protoreflect.Value{}, fmt.Errorf("unknown default %q for feature %q", value, field.Name())
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported type %v for feature %q", field.Kind(), field.Name())
+	}
+}
+
+// featureInheritanceChain returns the descriptors whose features apply to the descriptor, from
+// the file to the descriptor itself.
+func featureInheritanceChain(descriptor protoreflect.Descriptor) []protoreflect.Descriptor {
+	var chain []protoreflect.Descriptor
+	for current := descriptor; current != nil; current = featureParent(current) {
+		chain = append(chain, current)
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+func featureParent(descriptor protoreflect.Descriptor) protoreflect.Descriptor {
+	if fieldDescriptor, ok := descriptor.(protoreflect.FieldDescriptor); ok {
+		// Synthetic oneofs for proto3 optional fields cannot have options.
+		if oneofDescriptor := fieldDescriptor.ContainingOneof(); oneofDescriptor != nil && !oneofDescriptor.IsSynthetic() {
+			return oneofDescriptor
+		}
+	}
+	return descriptor.Parent()
+}
+
+func featureSetForDescriptor(descriptor protoreflect.Descriptor) *descriptorpb.FeatureSet {
+	if options, ok := descriptor.Options().(featuresOptions); ok {
+		return options.GetFeatures()
+	}
+	return nil
+}
+
+// featuresOptions is implemented by all descriptorpb options messages.
+type featuresOptions interface {
+	GetFeatures() *descriptorpb.FeatureSet
+}
+
+// mergeInferredFieldFeatures merges the features implied by the syntax of a proto2 or proto3
+// field.
+func mergeInferredFieldFeatures(
+	features *descriptorpb.FeatureSet,
+	fieldDescriptor protoreflect.FieldDescriptor,
+	edition descriptorpb.Edition,
+) {
+	if fieldDescriptor.Cardinality() == protoreflect.Required {
+		features.FieldPresence = descriptorpb.FeatureSet_LEGACY_REQUIRED.Enum()
+	}
+	if fieldDescriptor.HasOptionalKeyword() && edition == descriptorpb.Edition_EDITION_PROTO3 {
+		features.FieldPresence = descriptorpb.FeatureSet_EXPLICIT.Enum()
+	}
+	if fieldDescriptor.Kind() == protoreflect.GroupKind {
+		features.MessageEncoding = descriptorpb.FeatureSet_DELIMITED.Enum()
+	}
+	if fieldOptions, ok := fieldDescriptor.Options().(*descriptorpb.FieldOptions); ok && fieldOptions != nil && fieldOptions.Packed != nil {
+		if fieldOptions.GetPacked() {
+			features.RepeatedFieldEncoding = descriptorpb.FeatureSet_PACKED.Enum()
+		} else {
+			features.RepeatedFieldEncoding = descriptorpb.FeatureSet_EXPANDED.Enum()
+		}
+	}
+}
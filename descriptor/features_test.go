@@ -0,0 +1,173 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestResolvedFeaturesForDescriptorEditions(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:    proto.String("foo.proto"),
+					Package: proto.String("foo"),
+					Syntax:  proto.String("editions"),
+					Edition: descriptorpb.Edition_EDITION_2023.Enum(),
+					Options: &descriptorpb.FileOptions{
+						Features: &descriptorpb.FeatureSet{
+							FieldPresence: descriptorpb.FeatureSet_IMPLICIT.Enum(),
+						},
+					},
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name: proto.String("Foo"),
+							Options: &descriptorpb.MessageOptions{
+								Features: &descriptorpb.FeatureSet{
+									Utf8Validation: descriptorpb.FeatureSet_NONE.Enum(),
+								},
+							},
+							Field: []*descriptorpb.FieldDescriptorProto{
+								testNewFeaturesFieldDescriptorProto("one", 1, nil),
+								testNewFeaturesFieldDescriptorProto(
+									"two",
+									2,
+									&descriptorpb.FeatureSet{
+										FieldPresence: descriptorpb.FeatureSet_EXPLICIT.Enum(),
+									},
+								),
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	messageDescriptor := fileDescriptors[0].ProtoreflectFileDescriptor().Messages().ByName("Foo")
+
+	features, err := ResolvedFeaturesForDescriptor(fileDescriptors[0].ProtoreflectFileDescriptor())
+	require.NoError(t, err)
+	assert.Equal(t, descriptorpb.FeatureSet_IMPLICIT, features.GetFieldPresence())
+	assert.Equal(t, descriptorpb.FeatureSet_OPEN, features.GetEnumType())
+	assert.Equal(t, descriptorpb.FeatureSet_PACKED, features.GetRepeatedFieldEncoding())
+	assert.Equal(t, descriptorpb.FeatureSet_VERIFY, features.GetUtf8Validation())
+	assert.Equal(t, descriptorpb.FeatureSet_LENGTH_PREFIXED, features.GetMessageEncoding())
+	assert.Equal(t, descriptorpb.FeatureSet_ALLOW, features.GetJsonFormat())
+
+	features, err = ResolvedFeaturesForDescriptor(messageDescriptor.Fields().ByName("one"))
+	require.NoError(t, err)
+	assert.Equal(t, descriptorpb.FeatureSet_IMPLICIT, features.GetFieldPresence())
+	assert.Equal(t, descriptorpb.FeatureSet_NONE, features.GetUtf8Validation())
+
+	features, err = ResolvedFeaturesForDescriptor(messageDescriptor.Fields().ByName("two"))
+	require.NoError(t, err)
+	assert.Equal(t, descriptorpb.FeatureSet_EXPLICIT, features.GetFieldPresence())
+	assert.Equal(t, descriptorpb.FeatureSet_NONE, features.GetUtf8Validation())
+}
+
+func TestResolvedFeaturesForDescriptorSyntax(t *testing.T) {
+	t.Parallel()
+
+	requiredField := testNewFeaturesFieldDescriptorProto("required", 1, nil)
+	requiredField.Label = descriptorpb.FieldDescriptorProto_LABEL_REQUIRED.Enum()
+	packedField := testNewFeaturesFieldDescriptorProto("packed", 2, nil)
+	packedField.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	packedField.Type = descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum()
+	packedField.Options = &descriptorpb.FieldOptions{Packed: proto.Bool(true)}
+	optionalField := testNewFeaturesFieldDescriptorProto("optional", 1, nil)
+	optionalField.Proto3Optional = proto.Bool(true)
+	optionalField.OneofIndex = proto.Int32(0)
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:   proto.String("a.proto"),
+					Syntax: proto.String("proto2"),
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name:  proto.String("A"),
+							Field: []*descriptorpb.FieldDescriptorProto{requiredField, packedField},
+						},
+					},
+				},
+			},
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:   proto.String("b.proto"),
+					Syntax: proto.String("proto3"),
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name:  proto.String("B"),
+							Field: []*descriptorpb.FieldDescriptorProto{optionalField},
+							OneofDecl: []*descriptorpb.OneofDescriptorProto{
+								{Name: proto.String("_optional")},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	proto2Fields := fileDescriptors[0].ProtoreflectFileDescriptor().Messages().ByName("A").Fields()
+	proto3Fields := fileDescriptors[1].ProtoreflectFileDescriptor().Messages().ByName("B").Fields()
+
+	features := testResolvedFeaturesForDescriptor(t, proto2Fields.ByName("required"))
+	assert.Equal(t, descriptorpb.FeatureSet_LEGACY_REQUIRED, features.GetFieldPresence())
+	assert.Equal(t, descriptorpb.FeatureSet_NONE, features.GetUtf8Validation())
+	assert.Equal(t, descriptorpb.FeatureSet_CLOSED, features.GetEnumType())
+	features = testResolvedFeaturesForDescriptor(t, proto2Fields.ByName("packed"))
+	assert.Equal(t, descriptorpb.FeatureSet_EXPLICIT, features.GetFieldPresence())
+	assert.Equal(t, descriptorpb.FeatureSet_PACKED, features.GetRepeatedFieldEncoding())
+	features = testResolvedFeaturesForDescriptor(t, proto3Fields.ByName("optional"))
+	assert.Equal(t, descriptorpb.FeatureSet_EXPLICIT, features.GetFieldPresence())
+	assert.Equal(t, descriptorpb.FeatureSet_VERIFY, features.GetUtf8Validation())
+	assert.Equal(t, descriptorpb.FeatureSet_OPEN, features.GetEnumType())
+	features = testResolvedFeaturesForDescriptor(t, fileDescriptors[1].ProtoreflectFileDescriptor())
+	assert.Equal(t, descriptorpb.FeatureSet_IMPLICIT, features.GetFieldPresence())
+}
+
+func testResolvedFeaturesForDescriptor(t *testing.T, descriptor protoreflect.Descriptor) *descriptorpb.FeatureSet {
+	features, err := ResolvedFeaturesForDescriptor(descriptor)
+	require.NoError(t, err)
+	return features
+}
+
+func testNewFeaturesFieldDescriptorProto(name string, number int32, features *descriptorpb.FeatureSet) *descriptorpb.FieldDescriptorProto {
+	fieldDescriptorProto := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		JsonName: proto.String(name),
+	}
+	if features != nil {
+		fieldDescriptorProto.Options = &descriptorpb.FieldOptions{
+			Features: features,
+		}
+	}
+	return fieldDescriptorProto
+}
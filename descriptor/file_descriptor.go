@@ -15,12 +15,17 @@
 package descriptor
 
 import (
+	"errors"
 	"fmt"
 	"slices"
+	"sort"
+	"strings"
+	"sync"
 
 	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
@@ -32,7 +37,16 @@ type FileDescriptor interface {
 	// ProtoreflectFileDescriptor returns the protoreflect.FileDescriptor representing this FileDescriptor.
 	//
 	// This will always contain SourceCodeInfo.
+	//
+	// This panics with an error wrapping ErrInvalidFileDescriptor if the file or one of its
+	// dependencies cannot be built, for example because it references an undefined type. Use
+	// BuildProtoreflectFileDescriptor to handle this case. Rules run by a check.Server do not
+	// need to, as the panic fails the request with CodeInvalidArgument.
 	ProtoreflectFileDescriptor() protoreflect.FileDescriptor
+	// BuildProtoreflectFileDescriptor returns the protoreflect.FileDescriptor representing this
+	// FileDescriptor, or an error wrapping ErrInvalidFileDescriptor if the file or one of its
+	// dependencies cannot be built.
+	BuildProtoreflectFileDescriptor() (protoreflect.FileDescriptor, error)
 
 	// FileDescriptorProto returns the FileDescriptorProto representing this File.
 	//
@@ -64,69 +78,62 @@ type FileDescriptor interface {
 	// This matches the shape of the PublicDependency and WeakDependency fields.
 	UnusedDependencyIndexes() []int32
 
+	// Comments returns the Comments attached to the element at the given path within the
+	// FileDescriptorProto.
+	//
+	// Use SourcePathForDescriptor to get the path of a descriptor.
+	//
+	// Returns false if there is no SourceCodeInfo location for the path.
+	Comments(sourcePath protoreflect.SourcePath) (Comments, bool)
+
 	// ToProto converts the FileDescriptor to its Protobuf representation.
 	ToProto() *descriptorv1.FileDescriptor
 
 	isFileDescriptor()
 }
 
+// ErrInvalidFileDescriptor is wrapped by the errors returned from BuildProtoreflectFileDescriptor
+// when a file cannot be built.
+var ErrInvalidFileDescriptor = errors.New("invalid file descriptor")
+
 // FileDescriptorsForProtoFileDescriptors returns a new slice of FileDescriptors for the given descriptorv1.FileDescriptorDescriptors.
+//
+// The returned FileDescriptors are in the same order as the given descriptorv1.FileDescriptors.
+//
+// The protoreflect.FileDescriptor of each FileDescriptor is built lazily on the first call to
+// ProtoreflectFileDescriptor, together with those of its transitive dependencies, so that
+// plugins that only use FileDescriptorProtos do not pay the cost of building protoreflect
+// descriptors for every file. The structure of the files is validated eagerly: names must be
+// unique, every dependency must be present, and there must be no import cycles. Errors from
+// building the files themselves are returned from BuildProtoreflectFileDescriptor.
+// No placeholders are substituted for files or types that cannot be built.
 func FileDescriptorsForProtoFileDescriptors(protoFileDescriptors []*descriptorv1.FileDescriptor) ([]FileDescriptor, error) {
 	if len(protoFileDescriptors) == 0 {
 		return nil, nil
 	}
-	fileNameToProtoFileDescriptor := make(map[string]*descriptorv1.FileDescriptor, len(protoFileDescriptors))
-	fileDescriptorProtos := make([]*descriptorpb.FileDescriptorProto, len(protoFileDescriptors))
-	for i, protoFileDescriptor := range protoFileDescriptors {
+	fileNameToFileDescriptorProto := make(map[string]*descriptorpb.FileDescriptorProto, len(protoFileDescriptors))
+	for _, protoFileDescriptor := range protoFileDescriptors {
 		fileDescriptorProto := protoFileDescriptor.GetFileDescriptorProto()
 		fileName := fileDescriptorProto.GetName()
-		if _, ok := fileNameToProtoFileDescriptor[fileName]; ok {
+		if _, ok := fileNameToFileDescriptorProto[fileName]; ok {
 			//  This should have been validated via protovalidate.
 			return nil, fmt.Errorf("duplicate file name: %q", fileName)
 		}
-		fileDescriptorProtos[i] = fileDescriptorProto
-		fileNameToProtoFileDescriptor[fileName] = protoFileDescriptor
+		fileNameToFileDescriptorProto[fileName] = fileDescriptorProto
 	}
-
-	protoregistryFiles, err := protodesc.NewFiles(
-		&descriptorpb.FileDescriptorSet{
-			File: fileDescriptorProtos,
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	fileDescriptors := make([]FileDescriptor, 0, len(protoFileDescriptors))
-	protoregistryFiles.RangeFiles(
-		func(protoreflectFileDescriptor protoreflect.FileDescriptor) bool {
-			protoFileDescriptor, ok := fileNameToProtoFileDescriptor[protoreflectFileDescriptor.Path()]
-			if !ok {
-				// If the protoreflect API is sane, this should never happen.
-				// However, the protoreflect API is not sane.
-				err = fmt.Errorf("unknown file: %q", protoreflectFileDescriptor.Path())
-				return false
-			}
-			fileDescriptors = append(
-				fileDescriptors,
-				newFileDescriptor(
-					protoreflectFileDescriptor,
-					protoFileDescriptor.GetFileDescriptorProto(),
-					protoFileDescriptor.GetIsImport(),
-					protoFileDescriptor.GetIsSyntaxUnspecified(),
-					protoFileDescriptor.GetUnusedDependency(),
-				),
-			)
-			return true
-		},
-	)
-	if err != nil {
+	if err := validateFileDescriptorProtoDependencies(fileNameToFileDescriptorProto); err != nil {
 		return nil, err
 	}
-	if len(fileDescriptors) != len(protoFileDescriptors) {
-		// If the protoreflect API is sane, this should never happen.
-		// However, the protoreflect API is not sane.
-		return nil, fmt.Errorf("expected %d files from protoregistry, got %d", len(protoFileDescriptors), len(fileDescriptors))
+	lazyFiles := newLazyFiles(fileNameToFileDescriptorProto)
+	fileDescriptors := make([]FileDescriptor, len(protoFileDescriptors))
+	for i, protoFileDescriptor := range protoFileDescriptors {
+		fileDescriptors[i] = newFileDescriptor(
+			lazyFiles,
+			protoFileDescriptor.GetFileDescriptorProto(),
+			protoFileDescriptor.GetIsImport(),
+			protoFileDescriptor.GetIsSyntaxUnspecified(),
+			protoFileDescriptor.GetUnusedDependency(),
+		)
 	}
 	return fileDescriptors, nil
 }
@@ -134,31 +141,39 @@ func FileDescriptorsForProtoFileDescriptors(protoFileDescriptors []*descriptorv1
 // *** PRIVATE ***
 
 type fileDescriptor struct {
-	protoreflectFileDescriptor protoreflect.FileDescriptor
-	fileDescriptorProto        *descriptorpb.FileDescriptorProto
-	isImport                   bool
-	isSyntaxUnspecified        bool
-	unusedDependencyIndexes    []int32
+	lazyFiles               *lazyFiles
+	fileDescriptorProto     *descriptorpb.FileDescriptorProto
+	isImport                bool
+	isSyntaxUnspecified     bool
+	unusedDependencyIndexes []int32
 }
 
 func newFileDescriptor(
-	protoreflectFileDescriptor protoreflect.FileDescriptor,
+	lazyFiles *lazyFiles,
 	fileDescriptorProto *descriptorpb.FileDescriptorProto,
 	isImport bool,
 	isSyntaxUnspecified bool,
 	unusedDependencyIndexes []int32,
 ) *fileDescriptor {
 	return &fileDescriptor{
-		protoreflectFileDescriptor: protoreflectFileDescriptor,
-		fileDescriptorProto:        fileDescriptorProto,
-		isImport:                   isImport,
-		isSyntaxUnspecified:        isSyntaxUnspecified,
-		unusedDependencyIndexes:    unusedDependencyIndexes,
+		lazyFiles:               lazyFiles,
+		fileDescriptorProto:     fileDescriptorProto,
+		isImport:                isImport,
+		isSyntaxUnspecified:     isSyntaxUnspecified,
+		unusedDependencyIndexes: unusedDependencyIndexes,
 	}
 }
 
 func (f *fileDescriptor) ProtoreflectFileDescriptor() protoreflect.FileDescriptor {
-	return f.protoreflectFileDescriptor
+	protoreflectFileDescriptor, err := f.lazyFiles.get(f.fileDescriptorProto.GetName())
+	if err != nil {
+		panic(err)
+	}
+	return protoreflectFileDescriptor
+}
+
+func (f *fileDescriptor) BuildProtoreflectFileDescriptor() (protoreflect.FileDescriptor, error) {
+	return f.lazyFiles.get(f.fileDescriptorProto.GetName())
 }
 
 func (f *fileDescriptor) FileDescriptorProto() *descriptorpb.FileDescriptorProto {
@@ -177,6 +192,10 @@ func (f *fileDescriptor) UnusedDependencyIndexes() []int32 {
 	return slices.Clone(f.unusedDependencyIndexes)
 }
 
+func (f *fileDescriptor) Comments(sourcePath protoreflect.SourcePath) (Comments, bool) {
+	return commentsForSourcePath(f, sourcePath)
+}
+
 func (f *fileDescriptor) ToProto() *descriptorv1.FileDescriptor {
 	if f == nil {
 		return nil
@@ -190,3 +209,115 @@ func (f *fileDescriptor) ToProto() *descriptorv1.FileDescriptor {
 }
 
 func (*fileDescriptor) isFileDescriptor() {}
+
+// lazyFiles builds protoreflect.FileDescriptors on demand.
+//
+// A lazyFiles is shared by all FileDescriptors created by a single call to
+// FileDescriptorsForProtoFileDescriptors.
+type lazyFiles struct {
+	fileNameToFileDescriptorProto map[string]*descriptorpb.FileDescriptorProto
+
+	lock                                 sync.Mutex
+	protoregistryFiles                   *protoregistry.Files
+	fileNameToProtoreflectFileDescriptor map[string]protoreflect.FileDescriptor
+	fileNameToError                      map[string]error
+}
+
+func newLazyFiles(fileNameToFileDescriptorProto map[string]*descriptorpb.FileDescriptorProto) *lazyFiles {
+	return &lazyFiles{
+		fileNameToFileDescriptorProto:        fileNameToFileDescriptorProto,
+		protoregistryFiles:                   &protoregistry.Files{},
+		fileNameToProtoreflectFileDescriptor: make(map[string]protoreflect.FileDescriptor),
+		fileNameToError:                      make(map[string]error),
+	}
+}
+
+// get gets the protoreflect.FileDescriptor for the file, building it and its transitive
+// dependencies if they have not already been built.
+//
+// If the file or one of its dependencies could not be built, the error is returned, and
+// will be returned on every subsequent call.
+func (l *lazyFiles) get(fileName string) (protoreflect.FileDescriptor, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.build(fileName)
+}
+
+// build must be called with the lock held.
+//
+// Assumes that the dependencies of all files were validated with
+// validateFileDescriptorProtoDependencies, so recursion terminates.
+func (l *lazyFiles) build(fileName string) (protoreflect.FileDescriptor, error) {
+	if err, ok := l.fileNameToError[fileName]; ok {
+		return nil, err
+	}
+	if protoreflectFileDescriptor, ok := l.fileNameToProtoreflectFileDescriptor[fileName]; ok {
+		return protoreflectFileDescriptor, nil
+	}
+	fileDescriptorProto, ok := l.fileNameToFileDescriptorProto[fileName]
+	if !ok {
+		return nil, fmt.Errorf("unknown file: %q", fileName)
+	}
+	protoreflectFileDescriptor, err := l.buildUncached(fileDescriptorProto)
+	if err != nil {
+		err = fmt.Errorf("could not build file %q: %w", fileName, err)
+		l.fileNameToError[fileName] = err
+		return nil, err
+	}
+	l.fileNameToProtoreflectFileDescriptor[fileName] = protoreflectFileDescriptor
+	return protoreflectFileDescriptor, nil
+}
+
+func (l *lazyFiles) buildUncached(fileDescriptorProto *descriptorpb.FileDescriptorProto) (protoreflect.FileDescriptor, error) {
+	for _, dependency := range fileDescriptorProto.GetDependency() {
+		if _, err := l.build(dependency); err != nil {
+			return nil, err
+		}
+	}
+	protoreflectFileDescriptor, err := protodesc.NewFile(fileDescriptorProto, l.protoregistryFiles)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFileDescriptor, err)
+	}
+	if err := l.protoregistryFiles.RegisterFile(protoreflectFileDescriptor); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFileDescriptor, err)
+	}
+	return protoreflectFileDescriptor, nil
+}
+
+// validateFileDescriptorProtoDependencies validates that every dependency of every file is
+// present, and that there are no import cycles.
+func validateFileDescriptorProtoDependencies(fileNameToFileDescriptorProto map[string]*descriptorpb.FileDescriptorProto) error {
+	// 1 is visiting, 2 is visited.
+	fileNameToState := make(map[string]int, len(fileNameToFileDescriptorProto))
+	var visit func(fileName string, path []string) error
+	visit = func(fileName string, path []string) error {
+		switch fileNameToState[fileName] {
+		case 1:
+			return fmt.Errorf("import cycle: %s", strings.Join(append(path, fileName), " -> "))
+		case 2:
+			return nil
+		}
+		fileNameToState[fileName] = 1
+		for _, dependency := range fileNameToFileDescriptorProto[fileName].GetDependency() {
+			if _, ok := fileNameToFileDescriptorProto[dependency]; !ok {
+				return fmt.Errorf("file %q imports %q, which is not present", fileName, dependency)
+			}
+			if err := visit(dependency, append(path, fileName)); err != nil {
+				return err
+			}
+		}
+		fileNameToState[fileName] = 2
+		return nil
+	}
+	fileNames := make([]string, 0, len(fileNameToFileDescriptorProto))
+	for fileName := range fileNameToFileDescriptorProto {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		if err := visit(fileName, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
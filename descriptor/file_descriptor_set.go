@@ -0,0 +1,127 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// FileDescriptorsToFileDescriptorSet returns a FileDescriptorSet containing the
+// FileDescriptorProtos of the given FileDescriptors.
+//
+// The files are in topological order: every file comes after all of the files it imports
+// that are within the given FileDescriptors. Otherwise, the files are in the same order as
+// the given FileDescriptors. If multiple FileDescriptors have the same name, only the first
+// is included.
+//
+// The FileDescriptorProtos are not copied.
+func FileDescriptorsToFileDescriptorSet(fileDescriptors []FileDescriptor) *descriptorpb.FileDescriptorSet {
+	fileNameToFileDescriptorProto := make(map[string]*descriptorpb.FileDescriptorProto, len(fileDescriptors))
+	for _, fileDescriptor := range fileDescriptors {
+		fileDescriptorProto := fileDescriptor.FileDescriptorProto()
+		if _, ok := fileNameToFileDescriptorProto[fileDescriptorProto.GetName()]; !ok {
+			fileNameToFileDescriptorProto[fileDescriptorProto.GetName()] = fileDescriptorProto
+		}
+	}
+	seen := make(map[string]struct{}, len(fileDescriptors))
+	fileDescriptorProtos := make([]*descriptorpb.FileDescriptorProto, 0, len(fileNameToFileDescriptorProto))
+	var visit func(fileName string)
+	visit = func(fileName string) {
+		fileDescriptorProto, ok := fileNameToFileDescriptorProto[fileName]
+		if !ok {
+			return
+		}
+		if _, ok := seen[fileName]; ok {
+			return
+		}
+		seen[fileName] = struct{}{}
+		// Add dependencies first so the result is in topological order.
+		for _, dependency := range fileDescriptorProto.GetDependency() {
+			visit(dependency)
+		}
+		fileDescriptorProtos = append(fileDescriptorProtos, fileDescriptorProto)
+	}
+	for _, fileDescriptor := range fileDescriptors {
+		visit(fileDescriptor.FileDescriptorProto().GetName())
+	}
+	return &descriptorpb.FileDescriptorSet{
+		File: fileDescriptorProtos,
+	}
+}
+
+// ProtoreflectFileDescriptorsToFileDescriptorSet returns a FileDescriptorSet containing the
+// given protoreflect.FileDescriptors and all of their transitive imports.
+//
+// The files are in topological order: every file comes after all of the files it imports.
+// Otherwise, the files are in the same order as the given protoreflect.FileDescriptors, with
+// each file preceded by any of its imports that were not already included. Each file is only
+// included once.
+//
+// If a protoreflect.FileDescriptor provides its original FileDescriptorProto via a
+// FileDescriptorProto or AsProto method, as is the case for the results of many compilers,
+// this FileDescriptorProto is used. Otherwise, the FileDescriptorProto is created with
+// protodesc.ToFileDescriptorProto.
+func ProtoreflectFileDescriptorsToFileDescriptorSet[D protoreflect.FileDescriptor](files []D) *descriptorpb.FileDescriptorSet {
+	seen := make(map[string]struct{}, len(files))
+	fileDescriptorProtos := make([]*descriptorpb.FileDescriptorProto, 0, len(files))
+	var visit func(file protoreflect.FileDescriptor)
+	visit = func(file protoreflect.FileDescriptor) {
+		if _, ok := seen[file.Path()]; ok {
+			return
+		}
+		seen[file.Path()] = struct{}{}
+		// Add dependencies first so the result is in topological order.
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			visit(imports.Get(i).FileDescriptor)
+		}
+		fileDescriptorProtos = append(fileDescriptorProtos, protoreflectFileDescriptorToFileDescriptorProto(file))
+	}
+	for _, file := range files {
+		visit(file)
+	}
+	return &descriptorpb.FileDescriptorSet{
+		File: fileDescriptorProtos,
+	}
+}
+
+// *** PRIVATE ***
+
+func protoreflectFileDescriptorToFileDescriptorProto(file protoreflect.FileDescriptor) *descriptorpb.FileDescriptorProto {
+	switch file := file.(type) {
+	case fileDescriptorProtoProvider:
+		return file.FileDescriptorProto()
+	case asProtoProvider:
+		if fileDescriptorProto, ok := file.AsProto().(*descriptorpb.FileDescriptorProto); ok {
+			return fileDescriptorProto
+		}
+	}
+	return protodesc.ToFileDescriptorProto(file)
+}
+
+// fileDescriptorProtoProvider is implemented by compiler results such as protocompile's
+// linker.Result.
+type fileDescriptorProtoProvider interface {
+	FileDescriptorProto() *descriptorpb.FileDescriptorProto
+}
+
+// asProtoProvider is implemented by wrappers of descriptors such as protocompile's
+// protoutil.DescriptorProtoWrapper.
+type asProtoProvider interface {
+	AsProto() proto.Message
+}
@@ -0,0 +1,78 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFileDescriptorsToFileDescriptorSet(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("c.proto", "", "b.proto", "a.proto"),
+			testNewProtoFileDescriptor("b.proto", "", "a.proto"),
+			testNewProtoFileDescriptor("a.proto", ""),
+			testNewProtoFileDescriptor("d.proto", ""),
+		},
+	)
+	require.NoError(t, err)
+	fileDescriptorSet := FileDescriptorsToFileDescriptorSet(append(fileDescriptors, fileDescriptors[2]))
+	assert.Equal(
+		t,
+		[]string{"a.proto", "b.proto", "c.proto", "d.proto"},
+		testFileDescriptorSetFileNames(fileDescriptorSet),
+	)
+	// Imports that are not present are ignored.
+	fileDescriptorSet = FileDescriptorsToFileDescriptorSet(fileDescriptors[:2])
+	assert.Equal(
+		t,
+		[]string{"b.proto", "c.proto"},
+		testFileDescriptorSetFileNames(fileDescriptorSet),
+	)
+}
+
+func TestProtoreflectFileDescriptorsToFileDescriptorSet(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptorSet := ProtoreflectFileDescriptorsToFileDescriptorSet(
+		[]protoreflect.FileDescriptor{
+			descriptorpb.File_google_protobuf_descriptor_proto,
+			timestamppb.File_google_protobuf_timestamp_proto,
+			descriptorpb.File_google_protobuf_descriptor_proto,
+		},
+	)
+	assert.Equal(
+		t,
+		[]string{"google/protobuf/descriptor.proto", "google/protobuf/timestamp.proto"},
+		testFileDescriptorSetFileNames(fileDescriptorSet),
+	)
+}
+
+func testFileDescriptorSetFileNames(fileDescriptorSet *descriptorpb.FileDescriptorSet) []string {
+	fileNames := make([]string, len(fileDescriptorSet.GetFile()))
+	for i, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		fileNames[i] = fileDescriptorProto.GetName()
+	}
+	return fileNames
+}
@@ -0,0 +1,107 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestFileDescriptorsForProtoFileDescriptorsLazy(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors := testNewFileDescriptors(t)
+	require.Len(t, fileDescriptors, 2)
+	assert.Equal(t, "google/protobuf/descriptor.proto", fileDescriptors[0].FileDescriptorProto().GetName())
+	assert.Equal(t, "foo.proto", fileDescriptors[1].FileDescriptorProto().GetName())
+	// Building a file builds its dependencies, which are then shared.
+	fooFileDescriptor := fileDescriptors[1].ProtoreflectFileDescriptor()
+	assert.Equal(t, fileDescriptors[0].ProtoreflectFileDescriptor(), fooFileDescriptor.Imports().Get(0).FileDescriptor)
+
+	// Files are not built until they are needed.
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("a.proto", ".b.Undefined"),
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "a.proto", fileDescriptors[0].FileDescriptorProto().GetName())
+	_, err = fileDescriptors[0].BuildProtoreflectFileDescriptor()
+	assert.ErrorContains(t, err, `could not build file "a.proto"`)
+	assert.ErrorIs(t, err, ErrInvalidFileDescriptor)
+	// ProtoreflectFileDescriptor panics with the same error instead of returning a placeholder.
+	assert.PanicsWithError(t, err.Error(), func() { fileDescriptors[0].ProtoreflectFileDescriptor() })
+	_, err = ResolverForFileDescriptors(fileDescriptors)
+	assert.ErrorIs(t, err, ErrInvalidFileDescriptor)
+
+	// Errors from building dependencies are returned for the files that import them.
+	fileDescriptors, err = FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("a.proto", ".b.Undefined"),
+			testNewProtoFileDescriptor("b.proto", "", "a.proto"),
+		},
+	)
+	require.NoError(t, err)
+	_, err = fileDescriptors[1].BuildProtoreflectFileDescriptor()
+	assert.ErrorContains(t, err, `could not build file "b.proto": could not build file "a.proto"`)
+	assert.ErrorIs(t, err, ErrInvalidFileDescriptor)
+
+	_, err = FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("a.proto", "", "b.proto"),
+		},
+	)
+	assert.EqualError(t, err, `file "a.proto" imports "b.proto", which is not present`)
+	_, err = FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("a.proto", "", "b.proto"),
+			testNewProtoFileDescriptor("b.proto", "", "a.proto"),
+		},
+	)
+	assert.EqualError(t, err, "import cycle: a.proto -> b.proto -> a.proto")
+}
+
+func testNewProtoFileDescriptor(name string, fieldTypeName string, dependencies ...string) *descriptorv1.FileDescriptor {
+	fileDescriptorProto := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(name),
+		Syntax:     proto.String("proto3"),
+		Dependency: dependencies,
+	}
+	if fieldTypeName != "" {
+		fileDescriptorProto.MessageType = []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Foo"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("foo"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(fieldTypeName),
+						JsonName: proto.String("foo"),
+					},
+				},
+			},
+		}
+	}
+	return &descriptorv1.FileDescriptor{
+		FileDescriptorProto: fileDescriptorProto,
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// HashFileDescriptor returns a deterministic content hash of the FileDescriptor.
+//
+// The hash is the hex-encoded SHA-256 digest of the deterministic Protobuf serialization of
+// the FileDescriptor, as returned by FileDescriptor.ToProto, so it covers the properties
+// IsImport, IsSyntaxUnspecified, and UnusedDependency as well as the
+// FileDescriptorProto. Equal FileDescriptors always have the same hash within a given
+// version of this package, which makes the hash suitable as a cache key.
+func HashFileDescriptor(fileDescriptor FileDescriptor, options ...HashOption) (string, error) {
+	hashOptions := newHashOptions()
+	for _, option := range options {
+		option(hashOptions)
+	}
+	hash := sha256.New()
+	if err := writeFileDescriptorHash(hash, fileDescriptor, hashOptions); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// HashFileDescriptors returns a deterministic content hash of the FileDescriptors.
+//
+// The hash does not depend on the order of the FileDescriptors, and is computed as with
+// HashFileDescriptor. The hash of a single FileDescriptor is equal to the result of
+// HashFileDescriptor for that FileDescriptor.
+func HashFileDescriptors(fileDescriptors []FileDescriptor, options ...HashOption) (string, error) {
+	hashOptions := newHashOptions()
+	for _, option := range options {
+		option(hashOptions)
+	}
+	sortedFileDescriptors := slices.Clone(fileDescriptors)
+	slices.SortFunc(
+		sortedFileDescriptors,
+		func(one FileDescriptor, two FileDescriptor) int {
+			return strings.Compare(one.FileDescriptorProto().GetName(), two.FileDescriptorProto().GetName())
+		},
+	)
+	hash := sha256.New()
+	for _, fileDescriptor := range sortedFileDescriptors {
+		if err := writeFileDescriptorHash(hash, fileDescriptor, hashOptions); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// HashOption is an option for HashFileDescriptor and HashFileDescriptors.
+type HashOption func(*hashOptions)
+
+// HashWithoutSourceCodeInfo returns a new HashOption that excludes SourceCodeInfo from
+// the hash.
+//
+// With this option, changes that only affect comments or formatting do not change the hash.
+func HashWithoutSourceCodeInfo() HashOption {
+	return func(hashOptions *hashOptions) {
+		hashOptions.withoutSourceCodeInfo = true
+	}
+}
+
+// *** PRIVATE ***
+
+type hashOptions struct {
+	withoutSourceCodeInfo bool
+}
+
+func newHashOptions() *hashOptions {
+	return &hashOptions{}
+}
+
+// writeFileDescriptorHash writes the length-prefixed serialization of the FileDescriptor
+// to the hash.
+func writeFileDescriptorHash(hash hash.Hash, fileDescriptor FileDescriptor, hashOptions *hashOptions) error {
+	protoFileDescriptor := fileDescriptor.ToProto()
+	if hashOptions.withoutSourceCodeInfo && protoFileDescriptor.GetFileDescriptorProto().GetSourceCodeInfo() != nil {
+		// Do not modify the FileDescriptorProto, which is shared.
+		fileDescriptorProto, ok := proto.Clone(protoFileDescriptor.GetFileDescriptorProto()).(*descriptorpb.FileDescriptorProto)
+		if !ok {
+			return fmt.Errorf("could not clone FileDescriptorProto for %q", protoFileDescriptor.GetFileDescriptorProto().GetName())
+		}
+		fileDescriptorProto.SourceCodeInfo = nil
+		protoFileDescriptor = &descriptorv1.FileDescriptor{
+			FileDescriptorProto: fileDescriptorProto,
+			IsImport:            protoFileDescriptor.GetIsImport(),
+			IsSyntaxUnspecified: protoFileDescriptor.GetIsSyntaxUnspecified(),
+			UnusedDependency:    protoFileDescriptor.GetUnusedDependency(),
+		}
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(protoFileDescriptor)
+	if err != nil {
+		return err
+	}
+	_, _ = hash.Write(binary.BigEndian.AppendUint64(nil, uint64(len(data))))
+	_, _ = hash.Write(data)
+	return nil
+}
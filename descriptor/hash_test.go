@@ -0,0 +1,110 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"slices"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestHashFileDescriptor(t *testing.T) {
+	t.Parallel()
+
+	hash, err := HashFileDescriptor(testNewHashFileDescriptor(t, "foo.proto", ""))
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+	sameHash, err := HashFileDescriptor(testNewHashFileDescriptor(t, "foo.proto", ""))
+	require.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+	otherHash, err := HashFileDescriptor(testNewHashFileDescriptor(t, "bar.proto", ""))
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+
+	commentHash, err := HashFileDescriptor(testNewHashFileDescriptor(t, "foo.proto", "A comment."))
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, commentHash)
+
+	hash, err = HashFileDescriptor(testNewHashFileDescriptor(t, "foo.proto", ""), HashWithoutSourceCodeInfo())
+	require.NoError(t, err)
+	commentHash, err = HashFileDescriptor(testNewHashFileDescriptor(t, "foo.proto", "A comment."), HashWithoutSourceCodeInfo())
+	require.NoError(t, err)
+	assert.Equal(t, hash, commentHash)
+
+	// HashWithoutSourceCodeInfo must not modify the underlying FileDescriptorProto.
+	fileDescriptor := testNewHashFileDescriptor(t, "foo.proto", "A comment.")
+	_, err = HashFileDescriptor(fileDescriptor, HashWithoutSourceCodeInfo())
+	require.NoError(t, err)
+	assert.NotNil(t, fileDescriptor.FileDescriptorProto().GetSourceCodeInfo())
+}
+
+func TestHashFileDescriptors(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors := testNewFileDescriptors(t)
+	hash, err := HashFileDescriptors(fileDescriptors)
+	require.NoError(t, err)
+	reversedFileDescriptors := slices.Clone(fileDescriptors)
+	slices.Reverse(reversedFileDescriptors)
+	reversedHash, err := HashFileDescriptors(reversedFileDescriptors)
+	require.NoError(t, err)
+	assert.Equal(t, hash, reversedHash)
+
+	partialHash, err := HashFileDescriptors(fileDescriptors[:1])
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, partialHash)
+	fileHash, err := HashFileDescriptor(fileDescriptors[0])
+	require.NoError(t, err)
+	assert.Equal(t, fileHash, partialHash)
+}
+
+func testNewHashFileDescriptor(t *testing.T, name string, leadingComments string) FileDescriptor {
+	fileDescriptorProto := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String(name),
+		Package: proto.String("foo"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Foo"),
+			},
+		},
+	}
+	if leadingComments != "" {
+		fileDescriptorProto.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{
+				{
+					Path:            []int32{4, 0},
+					Span:            []int32{0, 0, 10},
+					LeadingComments: proto.String(leadingComments),
+				},
+			},
+		}
+	}
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: fileDescriptorProto,
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, fileDescriptors, 1)
+	return fileDescriptors[0]
+}
@@ -0,0 +1,184 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Index is an index of the messages, enums, services, and extensions of a set of
+// FileDescriptors by fully-qualified name.
+//
+// Lookups are O(1). The Index is built on the first lookup, so creating an Index that is
+// never used is cheap. An Index is safe for concurrent use.
+type Index interface {
+	// Descriptor returns the message, enum, service, or extension descriptor with the given
+	// fully-qualified name, and the FileDescriptor that defines it.
+	//
+	// Returns false if there is no such descriptor.
+	Descriptor(fullName protoreflect.FullName) (protoreflect.Descriptor, FileDescriptor, bool)
+	// Message returns the message descriptor with the given fully-qualified name.
+	//
+	// Returns false if there is no such message.
+	Message(fullName protoreflect.FullName) (protoreflect.MessageDescriptor, bool)
+	// Enum returns the enum descriptor with the given fully-qualified name.
+	//
+	// Returns false if there is no such enum.
+	Enum(fullName protoreflect.FullName) (protoreflect.EnumDescriptor, bool)
+	// Service returns the service descriptor with the given fully-qualified name.
+	//
+	// Returns false if there is no such service.
+	Service(fullName protoreflect.FullName) (protoreflect.ServiceDescriptor, bool)
+	// Extension returns the extension descriptor with the given fully-qualified name.
+	//
+	// Returns false if there is no such extension.
+	Extension(fullName protoreflect.FullName) (protoreflect.ExtensionDescriptor, bool)
+	// ExtensionsForMessage returns the extensions of the message with the given
+	// fully-qualified name, sorted by field number.
+	ExtensionsForMessage(fullName protoreflect.FullName) []protoreflect.ExtensionDescriptor
+
+	isIndex()
+}
+
+// NewIndex returns a new Index for the given FileDescriptors.
+//
+// Messages, enums, and extensions nested within messages are included.
+func NewIndex(fileDescriptors []FileDescriptor) Index {
+	return newIndex(fileDescriptors)
+}
+
+// *** PRIVATE ***
+
+type indexEntry struct {
+	descriptor     protoreflect.Descriptor
+	fileDescriptor FileDescriptor
+}
+
+type index struct {
+	fileDescriptors []FileDescriptor
+
+	once                        sync.Once
+	fullNameToIndexEntry        map[protoreflect.FullName]indexEntry
+	extendeeFullNameToExtension map[protoreflect.FullName][]protoreflect.ExtensionDescriptor
+}
+
+func newIndex(fileDescriptors []FileDescriptor) *index {
+	return &index{
+		fileDescriptors: slices.Clone(fileDescriptors),
+	}
+}
+
+func (i *index) Descriptor(fullName protoreflect.FullName) (protoreflect.Descriptor, FileDescriptor, bool) {
+	i.once.Do(i.build)
+	indexEntry, ok := i.fullNameToIndexEntry[fullName]
+	if !ok {
+		return nil, nil, false
+	}
+	return indexEntry.descriptor, indexEntry.fileDescriptor, true
+}
+
+func (i *index) Message(fullName protoreflect.FullName) (protoreflect.MessageDescriptor, bool) {
+	return indexGet[protoreflect.MessageDescriptor](i, fullName)
+}
+
+func (i *index) Enum(fullName protoreflect.FullName) (protoreflect.EnumDescriptor, bool) {
+	return indexGet[protoreflect.EnumDescriptor](i, fullName)
+}
+
+func (i *index) Service(fullName protoreflect.FullName) (protoreflect.ServiceDescriptor, bool) {
+	return indexGet[protoreflect.ServiceDescriptor](i, fullName)
+}
+
+func (i *index) Extension(fullName protoreflect.FullName) (protoreflect.ExtensionDescriptor, bool) {
+	return indexGet[protoreflect.ExtensionDescriptor](i, fullName)
+}
+
+func (i *index) ExtensionsForMessage(fullName protoreflect.FullName) []protoreflect.ExtensionDescriptor {
+	i.once.Do(i.build)
+	return slices.Clone(i.extendeeFullNameToExtension[fullName])
+}
+
+func (*index) isIndex() {}
+
+func (i *index) build() {
+	i.fullNameToIndexEntry = make(map[protoreflect.FullName]indexEntry)
+	i.extendeeFullNameToExtension = make(map[protoreflect.FullName][]protoreflect.ExtensionDescriptor)
+	for _, fileDescriptor := range i.fileDescriptors {
+		protoreflectFileDescriptor := fileDescriptor.ProtoreflectFileDescriptor()
+		i.addMessages(fileDescriptor, protoreflectFileDescriptor.Messages())
+		i.addEnums(fileDescriptor, protoreflectFileDescriptor.Enums())
+		i.addExtensions(fileDescriptor, protoreflectFileDescriptor.Extensions())
+		services := protoreflectFileDescriptor.Services()
+		for j := range services.Len() {
+			i.add(fileDescriptor, services.Get(j))
+		}
+	}
+	for _, extensions := range i.extendeeFullNameToExtension {
+		slices.SortFunc(
+			extensions,
+			func(one protoreflect.ExtensionDescriptor, two protoreflect.ExtensionDescriptor) int {
+				return int(one.Number()) - int(two.Number())
+			},
+		)
+	}
+}
+
+func (i *index) addMessages(fileDescriptor FileDescriptor, messages protoreflect.MessageDescriptors) {
+	for j := range messages.Len() {
+		message := messages.Get(j)
+		i.add(fileDescriptor, message)
+		i.addMessages(fileDescriptor, message.Messages())
+		i.addEnums(fileDescriptor, message.Enums())
+		i.addExtensions(fileDescriptor, message.Extensions())
+	}
+}
+
+func (i *index) addEnums(fileDescriptor FileDescriptor, enums protoreflect.EnumDescriptors) {
+	for j := range enums.Len() {
+		i.add(fileDescriptor, enums.Get(j))
+	}
+}
+
+func (i *index) addExtensions(fileDescriptor FileDescriptor, extensions protoreflect.ExtensionDescriptors) {
+	for j := range extensions.Len() {
+		extension := extensions.Get(j)
+		i.add(fileDescriptor, extension)
+		extendeeFullName := extension.ContainingMessage().FullName()
+		i.extendeeFullNameToExtension[extendeeFullName] = append(i.extendeeFullNameToExtension[extendeeFullName], extension)
+	}
+}
+
+func (i *index) add(fileDescriptor FileDescriptor, descriptor protoreflect.Descriptor) {
+	i.fullNameToIndexEntry[descriptor.FullName()] = indexEntry{
+		descriptor:     descriptor,
+		fileDescriptor: fileDescriptor,
+	}
+}
+
+func indexGet[T protoreflect.Descriptor](i *index, fullName protoreflect.FullName) (T, bool) {
+	var zero T
+	descriptor, _, ok := i.Descriptor(fullName)
+	if !ok {
+		return zero, false
+	}
+	typedDescriptor, ok := descriptor.(T)
+	if !ok {
+		return zero, false
+	}
+	return typedDescriptor, true
+}
@@ -0,0 +1,49 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+
+	index := NewIndex(testNewFileDescriptors(t))
+
+	message, ok := index.Message("foo.Foo")
+	require.True(t, ok)
+	assert.Equal(t, protoreflect.FullName("foo.Foo"), message.FullName())
+	_, ok = index.Enum("foo.Foo")
+	assert.False(t, ok)
+	_, fileDescriptor, ok := index.Descriptor("google.protobuf.FieldOptions")
+	require.True(t, ok)
+	assert.True(t, fileDescriptor.IsImport())
+	nestedEnum, ok := index.Enum("google.protobuf.FieldOptions.CType")
+	require.True(t, ok)
+	assert.Equal(t, protoreflect.Name("CType"), nestedEnum.Name())
+	extension, ok := index.Extension("foo.safe")
+	require.True(t, ok)
+	assert.Equal(t, protoreflect.FieldNumber(50000), extension.Number())
+	extensions := index.ExtensionsForMessage("google.protobuf.FieldOptions")
+	require.Len(t, extensions, 1)
+	assert.Equal(t, protoreflect.FullName("foo.safe"), extensions[0].FullName())
+	_, _, ok = index.Descriptor("foo.Bar")
+	assert.False(t, ok)
+}
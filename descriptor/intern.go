@@ -0,0 +1,137 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// InternProtoFileDescriptors reduces the memory used by the given descriptorv1.FileDescriptors
+// by sharing equal strings and equal options messages between them.
+//
+// Descriptors commonly repeat the same strings many times, for example the names of
+// dependencies, fully-qualified type names, and JSON names, as well as the same options, for
+// example deprecated = true. Each decoded copy of these is otherwise a separate allocation.
+// For large sets of files, interning before calling FileDescriptorsForProtoFileDescriptors
+// can reduce memory usage significantly.
+//
+// All given slices share a single interning pool, so for example both the FileDescriptors and
+// the AgainstFileDescriptors of a request can be interned together.
+//
+// The descriptorv1.FileDescriptors are modified in place. Their content is unchanged, however
+// options messages may be shared between elements afterwards, so options messages must not be
+// modified after interning. This matches FileDescriptor.FileDescriptorProto, which must not be
+// modified either.
+func InternProtoFileDescriptors(protoFileDescriptorSlices ...[]*descriptorv1.FileDescriptor) error {
+	interner := newInterner()
+	for _, protoFileDescriptors := range protoFileDescriptorSlices {
+		for _, protoFileDescriptor := range protoFileDescriptors {
+			if err := interner.internMessage(protoFileDescriptor.GetFileDescriptorProto().ProtoReflect()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// *** PRIVATE ***
+
+type interner struct {
+	stringToString map[string]string
+	// The key is the full name of the options message followed by its deterministic encoding.
+	keyToOptions map[string]protoreflect.Message
+}
+
+func newInterner() *interner {
+	return &interner{
+		stringToString: make(map[string]string),
+		keyToOptions:   make(map[string]protoreflect.Message),
+	}
+}
+
+func (i *interner) internString(value string) string {
+	if interned, ok := i.stringToString[value]; ok {
+		return interned
+	}
+	i.stringToString[value] = value
+	return value
+}
+
+func (i *interner) internMessage(message protoreflect.Message) error {
+	if !message.IsValid() {
+		return nil
+	}
+	// Collect the fields first, as a message must not be modified while ranging over it.
+	var fieldDescriptors []protoreflect.FieldDescriptor
+	message.Range(
+		func(fieldDescriptor protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			fieldDescriptors = append(fieldDescriptors, fieldDescriptor)
+			return true
+		},
+	)
+	for _, fieldDescriptor := range fieldDescriptors {
+		value := message.Get(fieldDescriptor)
+		switch {
+		case fieldDescriptor.IsMap():
+			// Maps do not appear within descriptors, outside of the values of options, which
+			// are not inspected.
+		case fieldDescriptor.IsList():
+			list := value.List()
+			for j := 0; j < list.Len(); j++ {
+				switch fieldDescriptor.Kind() {
+				case protoreflect.StringKind:
+					list.Set(j, protoreflect.ValueOfString(i.internString(list.Get(j).String())))
+				case protoreflect.MessageKind, protoreflect.GroupKind:
+					if err := i.internMessage(list.Get(j).Message()); err != nil {
+						return err
+					}
+				}
+			}
+		case fieldDescriptor.Kind() == protoreflect.StringKind:
+			message.Set(fieldDescriptor, protoreflect.ValueOfString(i.internString(value.String())))
+		case fieldDescriptor.Name() == "options" && fieldDescriptor.Message() != nil:
+			options, err := i.internOptions(value.Message())
+			if err != nil {
+				return err
+			}
+			message.Set(fieldDescriptor, protoreflect.ValueOfMessage(options))
+		case fieldDescriptor.Message() != nil:
+			if err := i.internMessage(value.Message()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// internOptions returns a previously seen options message equal to the given options
+// message, or the given options message if there is none.
+func (i *interner) internOptions(options protoreflect.Message) (protoreflect.Message, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(options.Interface())
+	if err != nil {
+		return nil, err
+	}
+	key := string(options.Descriptor().FullName()) + "\x00" + string(data)
+	if interned, ok := i.keyToOptions[key]; ok {
+		return interned, nil
+	}
+	if err := i.internMessage(options); err != nil {
+		return nil, err
+	}
+	i.keyToOptions[key] = options
+	return options, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+	"unsafe"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestInternProtoFileDescriptors(t *testing.T) {
+	t.Parallel()
+
+	newProtoFileDescriptors := func() []*descriptorv1.FileDescriptor {
+		protoFileDescriptors := []*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("a.proto", ""),
+			testNewProtoFileDescriptor("b.proto", ".Foo", "a.proto"),
+			testNewProtoFileDescriptor("c.proto", ".Foo", "a.proto"),
+		}
+		for _, protoFileDescriptor := range protoFileDescriptors[1:] {
+			protoFileDescriptor.GetFileDescriptorProto().GetMessageType()[0].GetField()[0].Options = &descriptorpb.FieldOptions{
+				Deprecated: proto.Bool(true),
+			}
+		}
+		return protoFileDescriptors
+	}
+	protoFileDescriptors := newProtoFileDescriptors()
+	againstProtoFileDescriptors := newProtoFileDescriptors()
+	require.NoError(t, InternProtoFileDescriptors(protoFileDescriptors, againstProtoFileDescriptors))
+
+	// The content is unchanged.
+	expectedProtoFileDescriptors := newProtoFileDescriptors()
+	for i, protoFileDescriptor := range protoFileDescriptors {
+		assert.True(t, proto.Equal(expectedProtoFileDescriptors[i], protoFileDescriptor))
+		assert.True(t, proto.Equal(expectedProtoFileDescriptors[i], againstProtoFileDescriptors[i]))
+	}
+	// Equal strings share memory, including across slices.
+	bDependency := protoFileDescriptors[1].GetFileDescriptorProto().GetDependency()[0]
+	cDependency := protoFileDescriptors[2].GetFileDescriptorProto().GetDependency()[0]
+	againstDependency := againstProtoFileDescriptors[1].GetFileDescriptorProto().GetDependency()[0]
+	assert.Equal(t, unsafe.StringData(bDependency), unsafe.StringData(cDependency))
+	assert.Equal(t, unsafe.StringData(bDependency), unsafe.StringData(againstDependency))
+	// Equal options are shared.
+	assert.Same(
+		t,
+		protoFileDescriptors[1].GetFileDescriptorProto().GetMessageType()[0].GetField()[0].GetOptions(),
+		protoFileDescriptors[2].GetFileDescriptorProto().GetMessageType()[0].GetField()[0].GetOptions(),
+	)
+
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+	require.NoError(t, err)
+	assert.True(t, fileDescriptors[2].ProtoreflectFileDescriptor().Messages().Get(0).Fields().Get(0).Options().(*descriptorpb.FieldOptions).GetDeprecated())
+}
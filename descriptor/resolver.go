@@ -0,0 +1,88 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Resolver resolves files, descriptors, and types for a set of FileDescriptors.
+//
+// A Resolver can be used anywhere a protodesc.Resolver, protoregistry.MessageTypeResolver,
+// or protoregistry.ExtensionTypeResolver is expected, for example as the Resolver of
+// proto.UnmarshalOptions or protojson.UnmarshalOptions. This allows custom options and
+// google.protobuf.Any values that reference types defined in the FileDescriptors to be
+// resolved.
+//
+// Types are dynamic, see dynamicpb. Messages created from a Resolver are not the Go types
+// generated for the same message, even if they are linked into the plugin.
+type Resolver interface {
+	protodesc.Resolver
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+
+	// FindEnumByName looks up an enum type by its full name.
+	FindEnumByName(enum protoreflect.FullName) (protoreflect.EnumType, error)
+	// FindExtensionByName looks up an extension type by the full name of the extension field.
+	FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error)
+	// ProtoregistryFiles returns the protoregistry.Files that contains all of the files.
+	//
+	// This is not a copy - do not modify!
+	ProtoregistryFiles() *protoregistry.Files
+
+	isResolver()
+}
+
+// ResolverForFileDescriptors returns a new Resolver for the given FileDescriptors.
+//
+// The FileDescriptors should be a complete set, such as the FileDescriptors of a check.Request,
+// where every import of every file is also present. Every type and extension defined in the
+// FileDescriptors can be resolved, including extensions for custom options.
+func ResolverForFileDescriptors(fileDescriptors []FileDescriptor) (Resolver, error) {
+	protoregistryFiles := &protoregistry.Files{}
+	for _, fileDescriptor := range fileDescriptors {
+		protoreflectFileDescriptor, err := fileDescriptor.BuildProtoreflectFileDescriptor()
+		if err != nil {
+			return nil, err
+		}
+		if err := protoregistryFiles.RegisterFile(protoreflectFileDescriptor); err != nil {
+			return nil, err
+		}
+	}
+	return newResolver(protoregistryFiles), nil
+}
+
+// *** PRIVATE ***
+
+type resolver struct {
+	*protoregistry.Files
+	*dynamicpb.Types
+}
+
+func newResolver(protoregistryFiles *protoregistry.Files) *resolver {
+	return &resolver{
+		Files: protoregistryFiles,
+		Types: dynamicpb.NewTypes(protoregistryFiles),
+	}
+}
+
+func (r *resolver) ProtoregistryFiles() *protoregistry.Files {
+	return r.Files
+}
+
+func (*resolver) isResolver() {}
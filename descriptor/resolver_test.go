@@ -0,0 +1,95 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestResolverForFileDescriptors(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors := testNewFileDescriptors(t)
+	resolver, err := ResolverForFileDescriptors(fileDescriptors)
+	require.NoError(t, err)
+
+	fileDescriptor, err := resolver.FindFileByPath("foo.proto")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("foo"), fileDescriptor.Package())
+	descriptor, err := resolver.FindDescriptorByName("foo.Foo")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("foo.Foo"), descriptor.FullName())
+	messageType, err := resolver.FindMessageByName("foo.Foo")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("foo.Foo"), messageType.Descriptor().FullName())
+	extensionType, err := resolver.FindExtensionByNumber("google.protobuf.FieldOptions", 50000)
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("foo.safe"), extensionType.TypeDescriptor().FullName())
+	_, err = resolver.FindMessageByName("foo.Bar")
+	assert.Error(t, err)
+}
+
+func testNewFileDescriptors(t *testing.T) []FileDescriptor {
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+				IsImport:            true,
+			},
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:       proto.String("foo.proto"),
+					Package:    proto.String("foo"),
+					Syntax:     proto.String("proto3"),
+					Dependency: []string{"google/protobuf/descriptor.proto"},
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name: proto.String("Foo"),
+							Field: []*descriptorpb.FieldDescriptorProto{
+								{
+									Name:     proto.String("name"),
+									Number:   proto.Int32(1),
+									Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+									Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+									JsonName: proto.String("name"),
+								},
+							},
+						},
+					},
+					Extension: []*descriptorpb.FieldDescriptorProto{
+						{
+							Name:     proto.String("safe"),
+							Number:   proto.Int32(50000),
+							Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+							Type:     descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+							Extendee: proto.String(".google.protobuf.FieldOptions"),
+							JsonName: proto.String("safe"),
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	return fileDescriptors
+}
@@ -0,0 +1,212 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"slices"
+	"strings"
+
+	"buf.build/go/bufplugin/internal/pkg/compare"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// SemanticEqual returns true if the two sets of FileDescriptors are semantically equal.
+//
+// Two sets are semantically equal if they contain files with the same names, and the files
+// with the same name have semantically equal FileDescriptorProtos. The following differences
+// are not considered semantic:
+//
+//   - The order of the files within each set.
+//   - SourceCodeInfo, that is, comments and source positions.
+//   - The declaration order of messages, enums, services, extensions, and extension ranges,
+//     of fields, which are compared by number, of methods, and of dependencies.
+//   - An unset syntax versus a syntax of "proto2".
+//   - An unset json_name versus a json_name that is equal to the default JSON name.
+//
+// The declaration order of oneofs and enum values is significant, as oneofs are referenced by
+// index and the first enum value is the default value in some syntaxes.
+//
+// Only FileDescriptorProtos are compared, IsImport, IsSyntaxUnspecified, and
+// UnusedDependencyIndexes are not.
+//
+// This is useful for tests, and to determine whether the results of a previous invocation of
+// a plugin can be reused.
+func SemanticEqual(one []FileDescriptor, two []FileDescriptor) bool {
+	if len(one) != len(two) {
+		return false
+	}
+	oneFileNameToFileDescriptorProto := make(map[string]*descriptorpb.FileDescriptorProto, len(one))
+	for _, fileDescriptor := range one {
+		fileDescriptorProto := fileDescriptor.FileDescriptorProto()
+		oneFileNameToFileDescriptorProto[fileDescriptorProto.GetName()] = fileDescriptorProto
+	}
+	if len(oneFileNameToFileDescriptorProto) != len(one) {
+		// Duplicate file names.
+		return false
+	}
+	for _, fileDescriptor := range two {
+		twoFileDescriptorProto := fileDescriptor.FileDescriptorProto()
+		oneFileDescriptorProto, ok := oneFileNameToFileDescriptorProto[twoFileDescriptorProto.GetName()]
+		if !ok {
+			return false
+		}
+		// Remove the file, so that duplicate file names within two are detected.
+		delete(oneFileNameToFileDescriptorProto, twoFileDescriptorProto.GetName())
+		if !proto.Equal(
+			canonicalizeFileDescriptorProto(oneFileDescriptorProto),
+			canonicalizeFileDescriptorProto(twoFileDescriptorProto),
+		) {
+			return false
+		}
+	}
+	return true
+}
+
+// *** PRIVATE ***
+
+// canonicalizeFileDescriptorProto returns a copy of the FileDescriptorProto with all
+// non-semantic differences removed.
+func canonicalizeFileDescriptorProto(fileDescriptorProto *descriptorpb.FileDescriptorProto) *descriptorpb.FileDescriptorProto {
+	canonical, ok := proto.Clone(fileDescriptorProto).(*descriptorpb.FileDescriptorProto)
+	if !ok {
+		// This should never happen.
+		return fileDescriptorProto
+	}
+	canonical.SourceCodeInfo = nil
+	if canonical.GetSyntax() == "proto2" {
+		canonical.Syntax = nil
+	}
+	canonicalizeDependencies(canonical)
+	canonicalizeDescriptorProtos(canonical.GetMessageType())
+	sortByName(canonical.GetMessageType())
+	sortByName(canonical.GetEnumType())
+	canonicalizeFieldDescriptorProtos(canonical.GetExtension())
+	sortExtensions(canonical.GetExtension())
+	sortByName(canonical.GetService())
+	for _, serviceDescriptorProto := range canonical.GetService() {
+		sortByName(serviceDescriptorProto.GetMethod())
+	}
+	return canonical
+}
+
+// canonicalizeDependencies sorts the dependencies, and updates the indexes of public and weak
+// dependencies to match.
+func canonicalizeDependencies(fileDescriptorProto *descriptorpb.FileDescriptorProto) {
+	dependencies := fileDescriptorProto.GetDependency()
+	sortedDependencies := slices.Clone(dependencies)
+	slices.Sort(sortedDependencies)
+	remapIndexes := func(indexes []int32) []int32 {
+		remapped := make([]int32, 0, len(indexes))
+		for _, index := range indexes {
+			if index < 0 || int(index) >= len(dependencies) {
+				// Invalid, keep as-is so it still compares.
+				remapped = append(remapped, index)
+				continue
+			}
+			newIndex, _ := slices.BinarySearch(sortedDependencies, dependencies[index])
+			remapped = append(remapped, int32(newIndex))
+		}
+		slices.Sort(remapped)
+		if len(remapped) == 0 {
+			return nil
+		}
+		return remapped
+	}
+	fileDescriptorProto.PublicDependency = remapIndexes(fileDescriptorProto.GetPublicDependency())
+	fileDescriptorProto.WeakDependency = remapIndexes(fileDescriptorProto.GetWeakDependency())
+	if len(sortedDependencies) == 0 {
+		sortedDependencies = nil
+	}
+	fileDescriptorProto.Dependency = sortedDependencies
+}
+
+func canonicalizeDescriptorProtos(descriptorProtos []*descriptorpb.DescriptorProto) {
+	for _, descriptorProto := range descriptorProtos {
+		canonicalizeFieldDescriptorProtos(descriptorProto.GetField())
+		slices.SortFunc(
+			descriptorProto.GetField(),
+			func(one *descriptorpb.FieldDescriptorProto, two *descriptorpb.FieldDescriptorProto) int {
+				return compare.CompareInts(int(one.GetNumber()), int(two.GetNumber()))
+			},
+		)
+		canonicalizeFieldDescriptorProtos(descriptorProto.GetExtension())
+		sortExtensions(descriptorProto.GetExtension())
+		canonicalizeDescriptorProtos(descriptorProto.GetNestedType())
+		sortByName(descriptorProto.GetNestedType())
+		sortByName(descriptorProto.GetEnumType())
+		slices.SortFunc(
+			descriptorProto.GetExtensionRange(),
+			func(one *descriptorpb.DescriptorProto_ExtensionRange, two *descriptorpb.DescriptorProto_ExtensionRange) int {
+				return compare.CompareInts(int(one.GetStart()), int(two.GetStart()))
+			},
+		)
+		slices.SortFunc(
+			descriptorProto.GetReservedRange(),
+			func(one *descriptorpb.DescriptorProto_ReservedRange, two *descriptorpb.DescriptorProto_ReservedRange) int {
+				return compare.CompareInts(int(one.GetStart()), int(two.GetStart()))
+			},
+		)
+		slices.Sort(descriptorProto.GetReservedName())
+	}
+}
+
+func canonicalizeFieldDescriptorProtos(fieldDescriptorProtos []*descriptorpb.FieldDescriptorProto) {
+	for _, fieldDescriptorProto := range fieldDescriptorProtos {
+		if fieldDescriptorProto.JsonName != nil && fieldDescriptorProto.GetJsonName() == defaultJSONName(fieldDescriptorProto.GetName()) {
+			fieldDescriptorProto.JsonName = nil
+		}
+	}
+}
+
+// sortExtensions sorts extensions by extendee, then by number.
+func sortExtensions(fieldDescriptorProtos []*descriptorpb.FieldDescriptorProto) {
+	slices.SortFunc(
+		fieldDescriptorProtos,
+		func(one *descriptorpb.FieldDescriptorProto, two *descriptorpb.FieldDescriptorProto) int {
+			if compare := strings.Compare(one.GetExtendee(), two.GetExtendee()); compare != 0 {
+				return compare
+			}
+			return compare.CompareInts(int(one.GetNumber()), int(two.GetNumber()))
+		},
+	)
+}
+
+func sortByName[T interface{ GetName() string }](values []T) {
+	slices.SortFunc(
+		values,
+		func(one T, two T) int {
+			return strings.Compare(one.GetName(), two.GetName())
+		},
+	)
+}
+
+// defaultJSONName returns the default JSON name of a field, as computed by protoc.
+func defaultJSONName(name string) string {
+	var sb strings.Builder
+	var upperNext bool
+	for _, r := range name {
+		if r == '_' {
+			upperNext = true
+			continue
+		}
+		if upperNext && 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upperNext = false
+		_, _ = sb.WriteRune(r)
+	}
+	return sb.String()
+}
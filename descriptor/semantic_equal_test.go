@@ -0,0 +1,131 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"slices"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestSemanticEqual(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors := testNewSemanticEqualFileDescriptors(t, nil)
+	assert.True(t, SemanticEqual(fileDescriptors, fileDescriptors))
+	reversedFileDescriptors := slices.Clone(fileDescriptors)
+	slices.Reverse(reversedFileDescriptors)
+	assert.True(t, SemanticEqual(fileDescriptors, reversedFileDescriptors))
+	assert.False(t, SemanticEqual(fileDescriptors, fileDescriptors[1:]))
+	assert.False(t, SemanticEqual(fileDescriptors, []FileDescriptor{fileDescriptors[0], fileDescriptors[0], fileDescriptors[1]}))
+
+	// Non-semantic differences.
+	assert.True(
+		t,
+		SemanticEqual(
+			fileDescriptors,
+			testNewSemanticEqualFileDescriptors(
+				t,
+				func(fileDescriptorProto *descriptorpb.FileDescriptorProto) {
+					fileDescriptorProto.SourceCodeInfo = nil
+					fileDescriptorProto.Syntax = proto.String("proto2")
+					slices.Reverse(fileDescriptorProto.Dependency)
+					fileDescriptorProto.PublicDependency = []int32{1}
+					slices.Reverse(fileDescriptorProto.MessageType)
+					slices.Reverse(fileDescriptorProto.MessageType[1].Field)
+					fileDescriptorProto.MessageType[1].Field[0].JsonName = nil
+				},
+			),
+		),
+	)
+	// Semantic differences.
+	for i, modify := range []func(*descriptorpb.FileDescriptorProto){
+		func(fileDescriptorProto *descriptorpb.FileDescriptorProto) {
+			fileDescriptorProto.Package = proto.String("bar")
+		},
+		func(fileDescriptorProto *descriptorpb.FileDescriptorProto) {
+			fileDescriptorProto.PublicDependency = []int32{1}
+		},
+		func(fileDescriptorProto *descriptorpb.FileDescriptorProto) {
+			fileDescriptorProto.MessageType[0].Field[0].JsonName = proto.String("other")
+		},
+		func(fileDescriptorProto *descriptorpb.FileDescriptorProto) {
+			fileDescriptorProto.MessageType[0].Field[1].Number = proto.Int32(3)
+		},
+		func(fileDescriptorProto *descriptorpb.FileDescriptorProto) {
+			slices.Reverse(fileDescriptorProto.EnumType[0].Value)
+		},
+	} {
+		assert.False(t, SemanticEqual(fileDescriptors, testNewSemanticEqualFileDescriptors(t, modify)), i)
+	}
+}
+
+func testNewSemanticEqualFileDescriptors(t *testing.T, modify func(*descriptorpb.FileDescriptorProto)) []FileDescriptor {
+	fileDescriptorProto := &descriptorpb.FileDescriptorProto{
+		Name:             proto.String("foo.proto"),
+		Package:          proto.String("foo"),
+		Dependency:       []string{"a.proto", "b.proto"},
+		PublicDependency: []int32{0},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Foo"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					testNewSemanticEqualFieldDescriptorProto("foo_id", 1, "fooId"),
+					testNewSemanticEqualFieldDescriptorProto("foo_name", 2, "fooName"),
+				},
+			},
+			{
+				Name: proto.String("Bar"),
+			},
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: proto.String("Baz"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("BAZ_ZERO"), Number: proto.Int32(0)},
+					{Name: proto.String("BAZ_ONE"), Number: proto.Int32(1)},
+				},
+			},
+		},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{
+				{Path: []int32{4, 0}, Span: []int32{0, 0, 5, 1}},
+			},
+		},
+	}
+	if modify != nil {
+		modify(fileDescriptorProto)
+	}
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("a.proto", ""),
+			testNewProtoFileDescriptor("b.proto", ""),
+			{FileDescriptorProto: fileDescriptorProto},
+		},
+	)
+	require.NoError(t, err)
+	return fileDescriptors
+}
+
+func testNewSemanticEqualFieldDescriptorProto(name string, number int32, jsonName string) *descriptorpb.FieldDescriptorProto {
+	fieldDescriptorProto := testNewFieldDescriptorProto(name, number, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil)
+	fieldDescriptorProto.JsonName = proto.String(jsonName)
+	return fieldDescriptorProto
+}
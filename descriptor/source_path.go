@@ -0,0 +1,227 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// SourcePathForDescriptor returns the path within the FileDescriptorProto of the given
+// descriptor, as used by SourceCodeInfo.
+//
+// The descriptor must be a file, message, field, extension, oneof, enum, enum value,
+// service, or method descriptor. The path of a file descriptor is empty.
+func SourcePathForDescriptor(descriptor protoreflect.Descriptor) (protoreflect.SourcePath, error) {
+	if _, ok := descriptor.(protoreflect.FileDescriptor); ok {
+		return protoreflect.SourcePath{}, nil
+	}
+	parent := descriptor.Parent()
+	if parent == nil {
+		return nil, fmt.Errorf("descriptor %q has no parent", descriptor.FullName())
+	}
+	parentSourcePath, err := SourcePathForDescriptor(parent)
+	if err != nil {
+		return nil, err
+	}
+	parentMessageDescriptor, err := descriptorProtoMessageDescriptorFor(parent)
+	if err != nil {
+		return nil, err
+	}
+	var fieldName protoreflect.Name
+	switch descriptor := descriptor.(type) {
+	case protoreflect.MessageDescriptor:
+		fieldName = "nested_type"
+		if _, ok := parent.(protoreflect.FileDescriptor); ok {
+			fieldName = "message_type"
+		}
+	case protoreflect.FieldDescriptor:
+		fieldName = "field"
+		if descriptor.IsExtension() {
+			fieldName = "extension"
+		}
+	case protoreflect.OneofDescriptor:
+		fieldName = "oneof_decl"
+	case protoreflect.EnumDescriptor:
+		fieldName = "enum_type"
+	case protoreflect.EnumValueDescriptor:
+		fieldName = "value"
+	case protoreflect.ServiceDescriptor:
+		fieldName = "service"
+	case protoreflect.MethodDescriptor:
+		fieldName = "method"
+	default:
+		return nil, fmt.Errorf("unknown descriptor type %T for %q", descriptor, descriptor.FullName())
+	}
+	fieldDescriptor := parentMessageDescriptor.Fields().ByName(fieldName)
+	if fieldDescriptor == nil {
+		return nil, fmt.Errorf("descriptor %q has unexpected parent %q", descriptor.FullName(), parent.FullName())
+	}
+	return append(parentSourcePath, int32(fieldDescriptor.Number()), int32(descriptor.Index())), nil
+}
+
+// SourcePathForDescriptorProperty returns the path within the FileDescriptorProto of a
+// property of the given descriptor, as used by SourceCodeInfo.
+//
+// The property is the name of a field on the descriptor's Protobuf representation, for example
+// "name" or "number" for a field descriptor, whose representation is a FieldDescriptorProto,
+// or "package" for a file descriptor, whose representation is a FileDescriptorProto.
+func SourcePathForDescriptorProperty(descriptor protoreflect.Descriptor, property protoreflect.Name) (protoreflect.SourcePath, error) {
+	sourcePath, err := SourcePathForDescriptor(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	messageDescriptor, err := descriptorProtoMessageDescriptorFor(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	fieldDescriptor := messageDescriptor.Fields().ByName(property)
+	if fieldDescriptor == nil {
+		return nil, fmt.Errorf("%s has no property %q", messageDescriptor.FullName(), property)
+	}
+	return append(sourcePath, int32(fieldDescriptor.Number())), nil
+}
+
+// SourcePathForDescriptorOption returns the path within the FileDescriptorProto of an option
+// set on the given descriptor, as used by SourceCodeInfo.
+//
+// The option is identified by its field number within the options message of the descriptor,
+// for example the number of a custom option extension.
+func SourcePathForDescriptorOption(descriptor protoreflect.Descriptor, optionNumber protoreflect.FieldNumber) (protoreflect.SourcePath, error) {
+	sourcePath, err := SourcePathForDescriptorProperty(descriptor, "options")
+	if err != nil {
+		return nil, err
+	}
+	return append(sourcePath, int32(optionNumber)), nil
+}
+
+// DescriptorForSourcePath returns the innermost descriptor that contains the given path
+// within the FileDescriptor.
+//
+// This is the inverse of SourcePathForDescriptor, except that paths to properties and
+// options of a descriptor also return the descriptor. For example, the path of the number
+// of a field returns the field descriptor. Paths that are not within any message, field,
+// extension, oneof, enum, enum value, service, or method return the file descriptor.
+func DescriptorForSourcePath(fileDescriptor protoreflect.FileDescriptor, sourcePath protoreflect.SourcePath) protoreflect.Descriptor {
+	var descriptor protoreflect.Descriptor = fileDescriptor
+	for len(sourcePath) >= 2 {
+		child := childDescriptorForSourcePathElements(descriptor, sourcePath[0], int(sourcePath[1]))
+		if child == nil {
+			break
+		}
+		descriptor = child
+		sourcePath = sourcePath[2:]
+	}
+	return descriptor
+}
+
+// FileLocationForSourcePath returns the FileLocation for the given path within the
+// FileDescriptor.
+//
+// Returns false if the FileDescriptor has no SourceCodeInfo location for the path.
+func FileLocationForSourcePath(fileDescriptor FileDescriptor, sourcePath protoreflect.SourcePath) (FileLocation, bool) {
+	sourceLocation := fileDescriptor.ProtoreflectFileDescriptor().SourceLocations().ByPath(sourcePath)
+	if !slices.Equal(sourceLocation.Path, sourcePath) {
+		return nil, false
+	}
+	return NewFileLocation(fileDescriptor, sourceLocation), true
+}
+
+// *** PRIVATE ***
+
+var (
+	fileDescriptorProtoMessageDescriptor      = (&descriptorpb.FileDescriptorProto{}).ProtoReflect().Descriptor()
+	descriptorProtoMessageDescriptor          = (&descriptorpb.DescriptorProto{}).ProtoReflect().Descriptor()
+	fieldDescriptorProtoMessageDescriptor     = (&descriptorpb.FieldDescriptorProto{}).ProtoReflect().Descriptor()
+	oneofDescriptorProtoMessageDescriptor     = (&descriptorpb.OneofDescriptorProto{}).ProtoReflect().Descriptor()
+	enumDescriptorProtoMessageDescriptor      = (&descriptorpb.EnumDescriptorProto{}).ProtoReflect().Descriptor()
+	enumValueDescriptorProtoMessageDescriptor = (&descriptorpb.EnumValueDescriptorProto{}).ProtoReflect().Descriptor()
+	serviceDescriptorProtoMessageDescriptor   = (&descriptorpb.ServiceDescriptorProto{}).ProtoReflect().Descriptor()
+	methodDescriptorProtoMessageDescriptor    = (&descriptorpb.MethodDescriptorProto{}).ProtoReflect().Descriptor()
+)
+
+// childDescriptorForSourcePathElements returns the child of the descriptor identified by
+// a field number of the descriptor's Protobuf representation and an index, or nil if there
+// is no such child.
+func childDescriptorForSourcePathElements(descriptor protoreflect.Descriptor, fieldNumber int32, index int) protoreflect.Descriptor {
+	if index < 0 {
+		return nil
+	}
+	// The field numbers are those of FileDescriptorProto, DescriptorProto,
+	// EnumDescriptorProto, and ServiceDescriptorProto.
+	switch descriptor := descriptor.(type) {
+	case protoreflect.FileDescriptor:
+		switch {
+		case fieldNumber == 4 && index < descriptor.Messages().Len():
+			return descriptor.Messages().Get(index)
+		case fieldNumber == 5 && index < descriptor.Enums().Len():
+			return descriptor.Enums().Get(index)
+		case fieldNumber == 6 && index < descriptor.Services().Len():
+			return descriptor.Services().Get(index)
+		case fieldNumber == 7 && index < descriptor.Extensions().Len():
+			return descriptor.Extensions().Get(index)
+		}
+	case protoreflect.MessageDescriptor:
+		switch {
+		case fieldNumber == 2 && index < descriptor.Fields().Len():
+			return descriptor.Fields().Get(index)
+		case fieldNumber == 3 && index < descriptor.Messages().Len():
+			return descriptor.Messages().Get(index)
+		case fieldNumber == 4 && index < descriptor.Enums().Len():
+			return descriptor.Enums().Get(index)
+		case fieldNumber == 6 && index < descriptor.Extensions().Len():
+			return descriptor.Extensions().Get(index)
+		case fieldNumber == 8 && index < descriptor.Oneofs().Len():
+			return descriptor.Oneofs().Get(index)
+		}
+	case protoreflect.EnumDescriptor:
+		if fieldNumber == 2 && index < descriptor.Values().Len() {
+			return descriptor.Values().Get(index)
+		}
+	case protoreflect.ServiceDescriptor:
+		if fieldNumber == 2 && index < descriptor.Methods().Len() {
+			return descriptor.Methods().Get(index)
+		}
+	}
+	return nil
+}
+
+// descriptorProtoMessageDescriptorFor returns the descriptor of the message that represents the
+// given descriptor within a FileDescriptorProto.
+func descriptorProtoMessageDescriptorFor(descriptor protoreflect.Descriptor) (protoreflect.MessageDescriptor, error) {
+	switch descriptor.(type) {
+	case protoreflect.FileDescriptor:
+		return fileDescriptorProtoMessageDescriptor, nil
+	case protoreflect.MessageDescriptor:
+		return descriptorProtoMessageDescriptor, nil
+	case protoreflect.FieldDescriptor:
+		return fieldDescriptorProtoMessageDescriptor, nil
+	case protoreflect.OneofDescriptor:
+		return oneofDescriptorProtoMessageDescriptor, nil
+	case protoreflect.EnumDescriptor:
+		return enumDescriptorProtoMessageDescriptor, nil
+	case protoreflect.EnumValueDescriptor:
+		return enumValueDescriptorProtoMessageDescriptor, nil
+	case protoreflect.ServiceDescriptor:
+		return serviceDescriptorProtoMessageDescriptor, nil
+	case protoreflect.MethodDescriptor:
+		return methodDescriptorProtoMessageDescriptor, nil
+	default:
+		return nil, fmt.Errorf("unknown descriptor type %T for %q", descriptor, descriptor.FullName())
+	}
+}
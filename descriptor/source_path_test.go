@@ -0,0 +1,205 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestSourcePathForDescriptor(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptor := testNewSourcePathFileDescriptor(t)
+	protoreflectFileDescriptor := fileDescriptor.ProtoreflectFileDescriptor()
+	messageDescriptor := protoreflectFileDescriptor.Messages().ByName("Foo")
+	nestedMessageDescriptor := messageDescriptor.Messages().ByName("Bar")
+	nestedEnumDescriptor := messageDescriptor.Enums().ByName("Baz")
+	serviceDescriptor := protoreflectFileDescriptor.Services().ByName("FooService")
+
+	testSourcePathForDescriptor(t, protoreflectFileDescriptor, protoreflect.SourcePath{})
+	testSourcePathForDescriptor(t, messageDescriptor, protoreflect.SourcePath{4, 0})
+	testSourcePathForDescriptor(t, messageDescriptor.Fields().ByName("two"), protoreflect.SourcePath{4, 0, 2, 1})
+	testSourcePathForDescriptor(t, messageDescriptor.Oneofs().ByName("choice"), protoreflect.SourcePath{4, 0, 8, 0})
+	testSourcePathForDescriptor(t, nestedMessageDescriptor, protoreflect.SourcePath{4, 0, 3, 0})
+	testSourcePathForDescriptor(t, nestedEnumDescriptor, protoreflect.SourcePath{4, 0, 4, 0})
+	testSourcePathForDescriptor(t, nestedEnumDescriptor.Values().ByName("BAZ_ONE"), protoreflect.SourcePath{4, 0, 4, 0, 2, 1})
+	testSourcePathForDescriptor(t, messageDescriptor.Extensions().ByName("nested_ext"), protoreflect.SourcePath{4, 0, 6, 0})
+	testSourcePathForDescriptor(t, protoreflectFileDescriptor.Extensions().ByName("ext"), protoreflect.SourcePath{7, 0})
+	testSourcePathForDescriptor(t, protoreflectFileDescriptor.Enums().ByName("Top"), protoreflect.SourcePath{5, 0})
+	testSourcePathForDescriptor(t, serviceDescriptor, protoreflect.SourcePath{6, 0})
+	testSourcePathForDescriptor(t, serviceDescriptor.Methods().ByName("Get"), protoreflect.SourcePath{6, 0, 2, 0})
+
+	sourcePath, err := SourcePathForDescriptorProperty(protoreflectFileDescriptor, "package")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.SourcePath{2}, sourcePath)
+	sourcePath, err = SourcePathForDescriptorProperty(messageDescriptor.Fields().ByName("two"), "number")
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.SourcePath{4, 0, 2, 1, 3}, sourcePath)
+	_, err = SourcePathForDescriptorProperty(messageDescriptor, "number")
+	assert.EqualError(t, err, `google.protobuf.DescriptorProto has no property "number"`)
+	sourcePath, err = SourcePathForDescriptorOption(messageDescriptor.Fields().ByName("two"), 50000)
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.SourcePath{4, 0, 2, 1, 8, 50000}, sourcePath)
+}
+
+func TestFileLocationForSourcePath(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptor := testNewSourcePathFileDescriptor(t)
+	fileLocation, ok := FileLocationForSourcePath(fileDescriptor, protoreflect.SourcePath{4, 0})
+	require.True(t, ok)
+	assert.Equal(t, 3, fileLocation.StartLine())
+	assert.Equal(t, " Foo is a message.\n", fileLocation.LeadingComments())
+	_, ok = FileLocationForSourcePath(fileDescriptor, protoreflect.SourcePath{4, 1})
+	assert.False(t, ok)
+}
+
+func TestDescriptorForSourcePath(t *testing.T) {
+	t.Parallel()
+
+	protoreflectFileDescriptor := testNewSourcePathFileDescriptor(t).ProtoreflectFileDescriptor()
+	messageDescriptor := protoreflectFileDescriptor.Messages().ByName("Foo")
+	nestedEnumDescriptor := messageDescriptor.Enums().ByName("Baz")
+	serviceDescriptor := protoreflectFileDescriptor.Services().ByName("FooService")
+
+	for _, descriptor := range []protoreflect.Descriptor{
+		protoreflectFileDescriptor,
+		messageDescriptor,
+		messageDescriptor.Fields().ByName("two"),
+		messageDescriptor.Oneofs().ByName("choice"),
+		messageDescriptor.Messages().ByName("Bar"),
+		nestedEnumDescriptor,
+		nestedEnumDescriptor.Values().ByName("BAZ_ONE"),
+		messageDescriptor.Extensions().ByName("nested_ext"),
+		protoreflectFileDescriptor.Extensions().ByName("ext"),
+		protoreflectFileDescriptor.Enums().ByName("Top"),
+		serviceDescriptor,
+		serviceDescriptor.Methods().ByName("Get"),
+	} {
+		sourcePath, err := SourcePathForDescriptor(descriptor)
+		require.NoError(t, err)
+		assert.Equal(t, descriptor.FullName(), DescriptorForSourcePath(protoreflectFileDescriptor, sourcePath).FullName())
+	}
+	// Properties and options of a field.
+	assert.Equal(t, protoreflect.FullName("foo.Foo.two"), DescriptorForSourcePath(protoreflectFileDescriptor, protoreflect.SourcePath{4, 0, 2, 1, 3}).FullName())
+	assert.Equal(t, protoreflect.FullName("foo.Foo.two"), DescriptorForSourcePath(protoreflectFileDescriptor, protoreflect.SourcePath{4, 0, 2, 1, 8, 50000}).FullName())
+	// Out of range, and the package of the file.
+	assert.Equal(t, protoreflect.FullName("foo.Foo"), DescriptorForSourcePath(protoreflectFileDescriptor, protoreflect.SourcePath{4, 0, 2, 10}).FullName())
+	assert.Equal(t, protoreflectFileDescriptor, DescriptorForSourcePath(protoreflectFileDescriptor, protoreflect.SourcePath{2}))
+}
+
+func testSourcePathForDescriptor(t *testing.T, descriptor protoreflect.Descriptor, expected protoreflect.SourcePath) {
+	sourcePath, err := SourcePathForDescriptor(descriptor)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sourcePath, descriptor.FullName())
+}
+
+func testNewSourcePathFileDescriptor(t *testing.T) FileDescriptor {
+	newField := func(name string, number int32, fieldType descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     fieldType.Enum(),
+			JsonName: proto.String(name),
+		}
+	}
+	oneField := newField("one", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	twoField := newField("two", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	twoField.OneofIndex = proto.Int32(0)
+	extField := newField("ext", 100, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	extField.Extendee = proto.String(".foo.Foo")
+	extField.JsonName = nil
+	nestedExtField := newField("nested_ext", 101, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	nestedExtField.Extendee = proto.String(".foo.Foo")
+	nestedExtField.JsonName = nil
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:    proto.String("foo.proto"),
+					Package: proto.String("foo"),
+					Syntax:  proto.String("proto2"),
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name:  proto.String("Foo"),
+							Field: []*descriptorpb.FieldDescriptorProto{oneField, twoField},
+							NestedType: []*descriptorpb.DescriptorProto{
+								{
+									Name: proto.String("Bar"),
+								},
+							},
+							EnumType: []*descriptorpb.EnumDescriptorProto{
+								{
+									Name: proto.String("Baz"),
+									Value: []*descriptorpb.EnumValueDescriptorProto{
+										{Name: proto.String("BAZ_ZERO"), Number: proto.Int32(0)},
+										{Name: proto.String("BAZ_ONE"), Number: proto.Int32(1)},
+									},
+								},
+							},
+							Extension: []*descriptorpb.FieldDescriptorProto{nestedExtField},
+							ExtensionRange: []*descriptorpb.DescriptorProto_ExtensionRange{
+								{Start: proto.Int32(100), End: proto.Int32(200)},
+							},
+							OneofDecl: []*descriptorpb.OneofDescriptorProto{
+								{Name: proto.String("choice")},
+							},
+						},
+					},
+					EnumType: []*descriptorpb.EnumDescriptorProto{
+						{
+							Name: proto.String("Top"),
+							Value: []*descriptorpb.EnumValueDescriptorProto{
+								{Name: proto.String("TOP_ZERO"), Number: proto.Int32(0)},
+							},
+						},
+					},
+					Service: []*descriptorpb.ServiceDescriptorProto{
+						{
+							Name: proto.String("FooService"),
+							Method: []*descriptorpb.MethodDescriptorProto{
+								{
+									Name:       proto.String("Get"),
+									InputType:  proto.String(".foo.Foo"),
+									OutputType: proto.String(".foo.Foo"),
+								},
+							},
+						},
+					},
+					Extension: []*descriptorpb.FieldDescriptorProto{extField},
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+						Location: []*descriptorpb.SourceCodeInfo_Location{
+							{
+								Path:            []int32{4, 0},
+								Span:            []int32{3, 0, 10, 1},
+								LeadingComments: proto.String(" Foo is a message.\n"),
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	return fileDescriptors[0]
+}
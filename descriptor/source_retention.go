@@ -0,0 +1,246 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"slices"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// FileDescriptorsWithoutSourceRetentionOptions returns new FileDescriptors with all
+// source-retention options removed.
+//
+// This mirrors protoc, which provides all options to code generators, but removes options
+// with retention RETENTION_SOURCE from the descriptors embedded in generated code for use
+// at runtime. See StripSourceRetentionOptions for details.
+//
+// The FileDescriptors should be a complete set, where every import of every file is also
+// present, so that custom options can be resolved. The given FileDescriptors are not modified.
+func FileDescriptorsWithoutSourceRetentionOptions(fileDescriptors []FileDescriptor) ([]FileDescriptor, error) {
+	resolver, err := ResolverForFileDescriptors(fileDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	protoFileDescriptors := make([]*descriptorv1.FileDescriptor, len(fileDescriptors))
+	for i, fileDescriptor := range fileDescriptors {
+		fileDescriptorProto, err := StripSourceRetentionOptions(fileDescriptor.FileDescriptorProto(), resolver)
+		if err != nil {
+			return nil, err
+		}
+		protoFileDescriptors[i] = &descriptorv1.FileDescriptor{
+			FileDescriptorProto: fileDescriptorProto,
+			IsImport:            fileDescriptor.IsImport(),
+			IsSyntaxUnspecified: fileDescriptor.IsSyntaxUnspecified(),
+			UnusedDependency:    fileDescriptor.UnusedDependencyIndexes(),
+		}
+	}
+	return FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+}
+
+// StripSourceRetentionOptions returns a copy of the FileDescriptorProto with all
+// source-retention options removed.
+//
+// An option is removed if its field has retention RETENTION_SOURCE. This applies to fields
+// within the values of options as well, for example a source-retention field of a message
+// used as a custom option. The SourceCodeInfo locations of removed options are also removed.
+//
+// Custom options are resolved using the Resolver. Custom options that cannot be resolved are
+// retained. The given FileDescriptorProto is not modified.
+func StripSourceRetentionOptions(
+	fileDescriptorProto *descriptorpb.FileDescriptorProto,
+	resolver Resolver,
+) (*descriptorpb.FileDescriptorProto, error) {
+	strippedFileDescriptorProto, ok := proto.Clone(fileDescriptorProto).(*descriptorpb.FileDescriptorProto)
+	if !ok {
+		return nil, fmt.Errorf("could not clone FileDescriptorProto for %q", fileDescriptorProto.GetName())
+	}
+	var strippedSourcePaths []protoreflect.SourcePath
+	if err := stripSourceRetentionOptionsInDescriptorProto(
+		strippedFileDescriptorProto.ProtoReflect(),
+		nil,
+		resolver,
+		&strippedSourcePaths,
+	); err != nil {
+		return nil, err
+	}
+	if sourceCodeInfo := strippedFileDescriptorProto.GetSourceCodeInfo(); sourceCodeInfo != nil && len(strippedSourcePaths) > 0 {
+		sourceCodeInfo.Location = slices.DeleteFunc(
+			sourceCodeInfo.GetLocation(),
+			func(location *descriptorpb.SourceCodeInfo_Location) bool {
+				return slices.ContainsFunc(
+					strippedSourcePaths,
+					func(strippedSourcePath protoreflect.SourcePath) bool {
+						return isSourcePathPrefix(strippedSourcePath, location.GetPath())
+					},
+				)
+			},
+		)
+	}
+	return strippedFileDescriptorProto, nil
+}
+
+// *** PRIVATE ***
+
+// stripSourceRetentionOptionsInDescriptorProto walks a message within a FileDescriptorProto,
+// stripping source-retention options from every options message.
+func stripSourceRetentionOptionsInDescriptorProto(
+	message protoreflect.Message,
+	sourcePath protoreflect.SourcePath,
+	resolver Resolver,
+	strippedSourcePaths *[]protoreflect.SourcePath,
+) error {
+	// Collect the fields first, as options are replaced, and a message must not be modified
+	// while ranging over it.
+	var fieldDescriptors []protoreflect.FieldDescriptor
+	message.Range(
+		func(fieldDescriptor protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if fieldDescriptor.Message() != nil && fieldDescriptor.Name() != "source_code_info" {
+				fieldDescriptors = append(fieldDescriptors, fieldDescriptor)
+			}
+			return true
+		},
+	)
+	for _, fieldDescriptor := range fieldDescriptors {
+		fieldSourcePath := appendSourcePath(sourcePath, int32(fieldDescriptor.Number()))
+		if fieldDescriptor.Name() == "options" {
+			if err := stripSourceRetentionOptionsInOptions(message, fieldDescriptor, fieldSourcePath, resolver, strippedSourcePaths); err != nil {
+				return err
+			}
+			continue
+		}
+		value := message.Get(fieldDescriptor)
+		if fieldDescriptor.IsList() {
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				if err := stripSourceRetentionOptionsInDescriptorProto(
+					list.Get(i).Message(),
+					appendSourcePath(fieldSourcePath, int32(i)),
+					resolver,
+					strippedSourcePaths,
+				); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := stripSourceRetentionOptionsInDescriptorProto(value.Message(), fieldSourcePath, resolver, strippedSourcePaths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stripSourceRetentionOptionsInOptions strips source-retention options from the options
+// field of the message, resolving custom options with the resolver.
+func stripSourceRetentionOptionsInOptions(
+	message protoreflect.Message,
+	optionsFieldDescriptor protoreflect.FieldDescriptor,
+	sourcePath protoreflect.SourcePath,
+	resolver Resolver,
+	strippedSourcePaths *[]protoreflect.SourcePath,
+) error {
+	options := message.Get(optionsFieldDescriptor).Message()
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(options.Interface())
+	if err != nil {
+		return err
+	}
+	resolvedOptions := dynamicpb.NewMessage(options.Descriptor())
+	if err := (proto.UnmarshalOptions{Resolver: resolver}).Unmarshal(data, resolvedOptions); err != nil {
+		return err
+	}
+	if !stripSourceRetentionFields(resolvedOptions, sourcePath, strippedSourcePaths) {
+		return nil
+	}
+	data, err = proto.MarshalOptions{Deterministic: true}.Marshal(resolvedOptions)
+	if err != nil {
+		return err
+	}
+	strippedOptions := options.New()
+	if err := proto.Unmarshal(data, strippedOptions.Interface()); err != nil {
+		return err
+	}
+	message.Set(optionsFieldDescriptor, protoreflect.ValueOfMessage(strippedOptions))
+	return nil
+}
+
+// stripSourceRetentionFields clears all source-retention fields of the message, recursively.
+//
+// Returns true if any field was cleared.
+func stripSourceRetentionFields(
+	message protoreflect.Message,
+	sourcePath protoreflect.SourcePath,
+	strippedSourcePaths *[]protoreflect.SourcePath,
+) bool {
+	var stripped bool
+	message.Range(
+		func(fieldDescriptor protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			fieldSourcePath := appendSourcePath(sourcePath, int32(fieldDescriptor.Number()))
+			if fieldOptions, ok := fieldDescriptor.Options().(*descriptorpb.FieldOptions); ok &&
+				fieldOptions.GetRetention() == descriptorpb.FieldOptions_RETENTION_SOURCE {
+				message.Clear(fieldDescriptor)
+				*strippedSourcePaths = append(*strippedSourcePaths, fieldSourcePath)
+				stripped = true
+				return true
+			}
+			switch {
+			case fieldDescriptor.IsMap():
+				// Map entries are not addressable by SourceCodeInfo paths, so only the fields
+				// within values are stripped.
+				if fieldDescriptor.MapValue().Message() != nil {
+					value.Map().Range(
+						func(_ protoreflect.MapKey, mapValue protoreflect.Value) bool {
+							if stripSourceRetentionFields(mapValue.Message(), nil, &[]protoreflect.SourcePath{}) {
+								stripped = true
+							}
+							return true
+						},
+					)
+				}
+			case fieldDescriptor.Message() != nil && fieldDescriptor.IsList():
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					if stripSourceRetentionFields(list.Get(i).Message(), appendSourcePath(fieldSourcePath, int32(i)), strippedSourcePaths) {
+						stripped = true
+					}
+				}
+			case fieldDescriptor.Message() != nil:
+				if stripSourceRetentionFields(value.Message(), fieldSourcePath, strippedSourcePaths) {
+					stripped = true
+				}
+			}
+			return true
+		},
+	)
+	return stripped
+}
+
+// appendSourcePath returns a new SourcePath with the elements appended, never sharing the
+// underlying array with sourcePath.
+func appendSourcePath(sourcePath protoreflect.SourcePath, elements ...int32) protoreflect.SourcePath {
+	newSourcePath := make(protoreflect.SourcePath, 0, len(sourcePath)+len(elements))
+	newSourcePath = append(newSourcePath, sourcePath...)
+	return append(newSourcePath, elements...)
+}
+
+// isSourcePathPrefix returns true if prefix is a prefix of sourcePath.
+func isSourcePathPrefix(prefix protoreflect.SourcePath, sourcePath []int32) bool {
+	return len(prefix) <= len(sourcePath) && slices.Equal(prefix, protoreflect.SourcePath(sourcePath[:len(prefix)]))
+}
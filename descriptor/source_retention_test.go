@@ -0,0 +1,108 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestFileDescriptorsWithoutSourceRetentionOptions(t *testing.T) {
+	t.Parallel()
+
+	optsFileDescriptor := testNewProtoFileDescriptor("opts.proto", "", "google/protobuf/descriptor.proto")
+	optsFileDescriptor.FileDescriptorProto.Extension = []*descriptorpb.FieldDescriptorProto{
+		{
+			Name:     proto.String("source"),
+			Number:   proto.Int32(50001),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+			Extendee: proto.String(".google.protobuf.MessageOptions"),
+			Options: &descriptorpb.FieldOptions{
+				Retention: descriptorpb.FieldOptions_RETENTION_SOURCE.Enum(),
+			},
+		},
+		{
+			Name:     proto.String("runtime"),
+			Number:   proto.Int32(50002),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+			Extendee: proto.String(".google.protobuf.MessageOptions"),
+		},
+	}
+	mainFileDescriptor := testNewProtoFileDescriptor("main.proto", "", "opts.proto")
+	mainFileDescriptor.FileDescriptorProto.MessageType = []*descriptorpb.DescriptorProto{
+		{
+			Name:    proto.String("Foo"),
+			Options: &descriptorpb.MessageOptions{Deprecated: proto.Bool(true)},
+		},
+	}
+	var unknown []byte
+	unknown = protowire.AppendVarint(protowire.AppendTag(unknown, 50001, protowire.VarintType), 1)
+	unknown = protowire.AppendVarint(protowire.AppendTag(unknown, 50002, protowire.VarintType), 2)
+	mainFileDescriptor.FileDescriptorProto.MessageType[0].Options.ProtoReflect().SetUnknown(unknown)
+	mainFileDescriptor.FileDescriptorProto.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+		Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{4, 0}, Span: []int32{0, 0, 5, 1}},
+			{Path: []int32{4, 0, 7, 3}, Span: []int32{1, 2, 30}},
+			{Path: []int32{4, 0, 7, 50001}, Span: []int32{2, 2, 30}},
+			{Path: []int32{4, 0, 7, 50002}, Span: []int32{3, 2, 30}},
+		},
+	}
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+				IsImport:            true,
+			},
+			optsFileDescriptor,
+			mainFileDescriptor,
+		},
+	)
+	require.NoError(t, err)
+
+	strippedFileDescriptors, err := FileDescriptorsWithoutSourceRetentionOptions(fileDescriptors)
+	require.NoError(t, err)
+	require.Len(t, strippedFileDescriptors, 3)
+	assert.True(t, strippedFileDescriptors[0].IsImport())
+	strippedMessageOptions := strippedFileDescriptors[2].FileDescriptorProto().GetMessageType()[0].GetOptions()
+	assert.True(t, strippedMessageOptions.GetDeprecated())
+	assert.Equal(
+		t,
+		[]byte(protowire.AppendVarint(protowire.AppendTag(nil, 50002, protowire.VarintType), 2)),
+		[]byte(strippedMessageOptions.ProtoReflect().GetUnknown()),
+	)
+	var strippedSourcePaths [][]int32
+	for _, location := range strippedFileDescriptors[2].FileDescriptorProto().GetSourceCodeInfo().GetLocation() {
+		strippedSourcePaths = append(strippedSourcePaths, location.GetPath())
+	}
+	assert.Equal(t, [][]int32{{4, 0}, {4, 0, 7, 3}, {4, 0, 7, 50002}}, strippedSourcePaths)
+	// The retention option itself, on the extension in opts.proto, is a runtime option.
+	assert.Equal(
+		t,
+		descriptorpb.FieldOptions_RETENTION_SOURCE,
+		strippedFileDescriptors[1].FileDescriptorProto().GetExtension()[0].GetOptions().GetRetention(),
+	)
+	// The original FileDescriptors are not modified.
+	assert.Equal(t, unknown, []byte(fileDescriptors[2].FileDescriptorProto().GetMessageType()[0].GetOptions().ProtoReflect().GetUnknown()))
+	assert.Len(t, fileDescriptors[2].FileDescriptorProto().GetSourceCodeInfo().GetLocation(), 4)
+}
@@ -0,0 +1,101 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Stats are statistics about a set of FileDescriptors.
+//
+// Stats are computed from the FileDescriptorProtos, so computing Stats does not build the
+// protoreflect.FileDescriptors.
+type Stats struct {
+	// Files is the number of files.
+	Files int
+	// ImportFiles is the number of files that are imports, see FileDescriptor.IsImport.
+	ImportFiles int
+	// Messages is the number of messages, including nested messages, but not including
+	// the synthetic entry messages of map fields.
+	Messages int
+	// Fields is the number of fields of messages, not including extensions or the fields of
+	// the synthetic entry messages of map fields.
+	Fields int
+	// Oneofs is the number of oneofs, including synthetic oneofs for proto3 optional fields.
+	Oneofs int
+	// Enums is the number of enums, including nested enums.
+	Enums int
+	// EnumValues is the number of enum values.
+	EnumValues int
+	// Services is the number of services.
+	Services int
+	// Methods is the number of methods.
+	Methods int
+	// Extensions is the number of extensions, including extensions declared within messages.
+	Extensions int
+	// Size is the total size in bytes of the encoded FileDescriptorProtos.
+	Size int
+	// SourceCodeInfoSize is the total size in bytes of the encoded SourceCodeInfo of the
+	// FileDescriptorProtos. This is included in Size.
+	SourceCodeInfoSize int
+}
+
+// StatsForFileDescriptors returns the Stats for the given FileDescriptors.
+func StatsForFileDescriptors(fileDescriptors []FileDescriptor) Stats {
+	var stats Stats
+	for _, fileDescriptor := range fileDescriptors {
+		fileDescriptorProto := fileDescriptor.FileDescriptorProto()
+		stats.Files++
+		if fileDescriptor.IsImport() {
+			stats.ImportFiles++
+		}
+		stats.addMessages(fileDescriptorProto.GetMessageType())
+		stats.addEnums(fileDescriptorProto.GetEnumType())
+		stats.Extensions += len(fileDescriptorProto.GetExtension())
+		stats.Services += len(fileDescriptorProto.GetService())
+		for _, serviceDescriptorProto := range fileDescriptorProto.GetService() {
+			stats.Methods += len(serviceDescriptorProto.GetMethod())
+		}
+		stats.Size += proto.Size(fileDescriptorProto)
+		if sourceCodeInfo := fileDescriptorProto.GetSourceCodeInfo(); sourceCodeInfo != nil {
+			stats.SourceCodeInfoSize += proto.Size(sourceCodeInfo)
+		}
+	}
+	return stats
+}
+
+// *** PRIVATE ***
+
+func (s *Stats) addMessages(descriptorProtos []*descriptorpb.DescriptorProto) {
+	for _, descriptorProto := range descriptorProtos {
+		if descriptorProto.GetOptions().GetMapEntry() {
+			continue
+		}
+		s.Messages++
+		s.Fields += len(descriptorProto.GetField())
+		s.Oneofs += len(descriptorProto.GetOneofDecl())
+		s.Extensions += len(descriptorProto.GetExtension())
+		s.addMessages(descriptorProto.GetNestedType())
+		s.addEnums(descriptorProto.GetEnumType())
+	}
+}
+
+func (s *Stats) addEnums(enumDescriptorProtos []*descriptorpb.EnumDescriptorProto) {
+	s.Enums += len(enumDescriptorProtos)
+	for _, enumDescriptorProto := range enumDescriptorProtos {
+		s.EnumValues += len(enumDescriptorProto.GetValue())
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStatsForFileDescriptors(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors := append(testNewFileDescriptors(t), testNewSourcePathFileDescriptor(t))
+	stats := StatsForFileDescriptors(fileDescriptors[1:])
+	expectedSize := proto.Size(fileDescriptors[1].FileDescriptorProto()) + proto.Size(fileDescriptors[2].FileDescriptorProto())
+	assert.Equal(
+		t,
+		Stats{
+			Files:              2,
+			ImportFiles:        0,
+			Messages:           3,
+			Fields:             3,
+			Oneofs:             1,
+			Enums:              2,
+			EnumValues:         3,
+			Services:           1,
+			Methods:            1,
+			Extensions:         3,
+			Size:               expectedSize,
+			SourceCodeInfoSize: proto.Size(fileDescriptors[2].FileDescriptorProto().GetSourceCodeInfo()),
+		},
+		stats,
+	)
+	stats = StatsForFileDescriptors(fileDescriptors)
+	assert.Equal(t, 3, stats.Files)
+	assert.Equal(t, 1, stats.ImportFiles)
+	assert.Greater(t, stats.Messages, 3)
+	assert.Equal(t, Stats{}, StatsForFileDescriptors(nil))
+}
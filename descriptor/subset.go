@@ -0,0 +1,85 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"fmt"
+	"strings"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+)
+
+// SubsetFileDescriptors returns the subset of the FileDescriptors that are targeted by the
+// given paths, along with their transitive imports.
+//
+// A path targets a file if it is equal to the name of the file, or if it is a directory that
+// contains the file. For example, "foo" targets "foo/bar.proto" and "foo/baz/bat.proto", but
+// not "foobar.proto". Every path must target at least one file.
+//
+// The targeted files have IsImport set to false, and the files that are only retained because
+// they are imported by a targeted file have IsImport set to true. All other properties are
+// unchanged. The returned FileDescriptors are in the same order as the given FileDescriptors.
+func SubsetFileDescriptors(fileDescriptors []FileDescriptor, paths []string) ([]FileDescriptor, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	dependencyGraph, err := NewDependencyGraph(fileDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	targetFileNames := make(map[string]struct{})
+	for _, path := range paths {
+		path = strings.TrimSuffix(path, "/")
+		var found bool
+		for _, fileDescriptor := range fileDescriptors {
+			fileName := fileDescriptor.FileDescriptorProto().GetName()
+			if fileName == path || strings.HasPrefix(fileName, path+"/") {
+				targetFileNames[fileName] = struct{}{}
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("path %q does not match any file", path)
+		}
+	}
+	importFileNames := make(map[string]struct{})
+	for targetFileName := range targetFileNames {
+		for _, dependency := range dependencyGraph.TransitiveDependencies(targetFileName) {
+			if _, ok := targetFileNames[dependency]; !ok {
+				importFileNames[dependency] = struct{}{}
+			}
+		}
+	}
+	protoFileDescriptors := make([]*descriptorv1.FileDescriptor, 0, len(targetFileNames)+len(importFileNames))
+	for _, fileDescriptor := range fileDescriptors {
+		fileName := fileDescriptor.FileDescriptorProto().GetName()
+		_, isTarget := targetFileNames[fileName]
+		_, isImport := importFileNames[fileName]
+		if !isTarget && !isImport {
+			continue
+		}
+		protoFileDescriptor := fileDescriptor.ToProto()
+		protoFileDescriptors = append(
+			protoFileDescriptors,
+			&descriptorv1.FileDescriptor{
+				FileDescriptorProto: protoFileDescriptor.GetFileDescriptorProto(),
+				IsImport:            isImport,
+				IsSyntaxUnspecified: protoFileDescriptor.GetIsSyntaxUnspecified(),
+				UnusedDependency:    protoFileDescriptor.GetUnusedDependency(),
+			},
+		)
+	}
+	return FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+}
@@ -0,0 +1,80 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubsetFileDescriptors(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			testNewProtoFileDescriptor("a.proto", ""),
+			testNewProtoFileDescriptor("b.proto", ".Foo", "a.proto"),
+			testNewProtoFileDescriptor("foo/c.proto", "", "b.proto"),
+			testNewProtoFileDescriptor("foo/bar/d.proto", "", "a.proto"),
+			testNewProtoFileDescriptor("foobar.proto", ""),
+		},
+	)
+	require.NoError(t, err)
+
+	subset, err := SubsetFileDescriptors(fileDescriptors, []string{"foo/c.proto"})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		map[string]bool{
+			"a.proto":     true,
+			"b.proto":     true,
+			"foo/c.proto": false,
+		},
+		testFileNameToIsImport(subset),
+	)
+	// The subset is usable on its own.
+	assert.Equal(t, "b.proto", subset[1].ProtoreflectFileDescriptor().Path())
+
+	subset, err = SubsetFileDescriptors(fileDescriptors, []string{"foo/", "a.proto"})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		map[string]bool{
+			"a.proto":         false,
+			"b.proto":         true,
+			"foo/c.proto":     false,
+			"foo/bar/d.proto": false,
+		},
+		testFileNameToIsImport(subset),
+	)
+
+	subset, err = SubsetFileDescriptors(fileDescriptors, nil)
+	require.NoError(t, err)
+	assert.Empty(t, subset)
+
+	_, err = SubsetFileDescriptors(fileDescriptors, []string{"fo"})
+	assert.EqualError(t, err, `path "fo" does not match any file`)
+}
+
+func testFileNameToIsImport(fileDescriptors []FileDescriptor) map[string]bool {
+	fileNameToIsImport := make(map[string]bool, len(fileDescriptors))
+	for _, fileDescriptor := range fileDescriptors {
+		fileNameToIsImport[fileDescriptor.FileDescriptorProto().GetName()] = fileDescriptor.IsImport()
+	}
+	return fileNameToIsImport
+}
@@ -0,0 +1,227 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ComputeUnusedDependencyIndexes computes the indexes within the Dependency field on the
+// FileDescriptorProto of those dependencies that are not used by the file.
+//
+// Unlike FileDescriptor.UnusedDependencyIndexes, which reports what the compiler provided,
+// this is computed from the contents of the file, and can be used when the FileDescriptors
+// come from a source that does not populate UnusedDependencyIndexes.
+//
+// A dependency is used if the file references a message, enum, or extension defined in the
+// dependency, or in a file publicly imported by the dependency. References include field
+// types, extendees, method input and output types, and custom options, including extensions
+// set within the values of options. As with protoc, public dependencies are never reported
+// as unused.
+//
+// The returned indexes are sorted.
+func ComputeUnusedDependencyIndexes(fileDescriptor FileDescriptor) ([]int32, error) {
+	protoreflectFileDescriptor, err := fileDescriptor.BuildProtoreflectFileDescriptor()
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := resolverForProtoreflectFileDescriptorAndImports(protoreflectFileDescriptor)
+	if err != nil {
+		return nil, err
+	}
+	usedFileNames := make(map[string]struct{})
+	addUsedDescriptor := func(descriptor protoreflect.Descriptor) {
+		if descriptor != nil && descriptor.ParentFile() != nil {
+			usedFileNames[descriptor.ParentFile().Path()] = struct{}{}
+		}
+	}
+	addUsedOptions := func(options proto.Message) {
+		addUsedOptionsFileNames(usedFileNames, resolver, options)
+	}
+	addUsedField := func(fieldDescriptor protoreflect.FieldDescriptor) {
+		addUsedDescriptor(fieldDescriptor.Message())
+		addUsedDescriptor(fieldDescriptor.Enum())
+		if fieldDescriptor.IsExtension() {
+			addUsedDescriptor(fieldDescriptor.ContainingMessage())
+		}
+		addUsedOptions(fieldDescriptor.Options())
+	}
+	addUsedEnum := func(enumDescriptor protoreflect.EnumDescriptor) {
+		addUsedOptions(enumDescriptor.Options())
+		values := enumDescriptor.Values()
+		for i := 0; i < values.Len(); i++ {
+			addUsedOptions(values.Get(i).Options())
+		}
+	}
+	var addUsedMessage func(messageDescriptor protoreflect.MessageDescriptor)
+	addUsedMessage = func(messageDescriptor protoreflect.MessageDescriptor) {
+		addUsedOptions(messageDescriptor.Options())
+		forEachDescriptor(messageDescriptor.Fields(), addUsedField)
+		forEachDescriptor(messageDescriptor.Extensions(), addUsedField)
+		forEachDescriptor(messageDescriptor.Enums(), addUsedEnum)
+		forEachDescriptor(messageDescriptor.Messages(), addUsedMessage)
+		forEachDescriptor(
+			messageDescriptor.Oneofs(),
+			func(oneofDescriptor protoreflect.OneofDescriptor) {
+				addUsedOptions(oneofDescriptor.Options())
+			},
+		)
+		extensionRanges := messageDescriptor.ExtensionRanges()
+		for i := 0; i < extensionRanges.Len(); i++ {
+			addUsedOptions(messageDescriptor.ExtensionRangeOptions(i))
+		}
+	}
+	addUsedOptions(protoreflectFileDescriptor.Options())
+	forEachDescriptor(protoreflectFileDescriptor.Messages(), addUsedMessage)
+	forEachDescriptor(protoreflectFileDescriptor.Extensions(), addUsedField)
+	forEachDescriptor(protoreflectFileDescriptor.Enums(), addUsedEnum)
+	forEachDescriptor(
+		protoreflectFileDescriptor.Services(),
+		func(serviceDescriptor protoreflect.ServiceDescriptor) {
+			addUsedOptions(serviceDescriptor.Options())
+			forEachDescriptor(
+				serviceDescriptor.Methods(),
+				func(methodDescriptor protoreflect.MethodDescriptor) {
+					addUsedDescriptor(methodDescriptor.Input())
+					addUsedDescriptor(methodDescriptor.Output())
+					addUsedOptions(methodDescriptor.Options())
+				},
+			)
+		},
+	)
+
+	var unusedDependencyIndexes []int32
+	imports := protoreflectFileDescriptor.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		fileImport := imports.Get(i)
+		if fileImport.IsPublic {
+			continue
+		}
+		if !isFileOrPublicImportUsed(fileImport.FileDescriptor, usedFileNames, make(map[string]struct{})) {
+			unusedDependencyIndexes = append(unusedDependencyIndexes, int32(i))
+		}
+	}
+	return unusedDependencyIndexes, nil
+}
+
+// *** PRIVATE ***
+
+// forEachDescriptor calls f for each descriptor in the list.
+func forEachDescriptor[D protoreflect.Descriptor](
+	descriptors interface {
+		Len() int
+		Get(int) D
+	},
+	f func(D),
+) {
+	for i := 0; i < descriptors.Len(); i++ {
+		f(descriptors.Get(i))
+	}
+}
+
+// isFileOrPublicImportUsed returns true if the file, or any file it transitively publicly
+// imports, is used.
+func isFileOrPublicImportUsed(
+	fileDescriptor protoreflect.FileDescriptor,
+	usedFileNames map[string]struct{},
+	seen map[string]struct{},
+) bool {
+	if _, ok := seen[fileDescriptor.Path()]; ok {
+		return false
+	}
+	seen[fileDescriptor.Path()] = struct{}{}
+	if _, ok := usedFileNames[fileDescriptor.Path()]; ok {
+		return true
+	}
+	imports := fileDescriptor.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if fileImport := imports.Get(i); fileImport.IsPublic && isFileOrPublicImportUsed(fileImport.FileDescriptor, usedFileNames, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// addUsedOptionsFileNames adds the files that define the extensions set within the options,
+// including within the values of options.
+func addUsedOptionsFileNames(usedFileNames map[string]struct{}, resolver *resolver, options proto.Message) {
+	if options == nil || !options.ProtoReflect().IsValid() {
+		return
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+	if err != nil {
+		return
+	}
+	resolvedOptions := dynamicpb.NewMessage(options.ProtoReflect().Descriptor())
+	if err := (proto.UnmarshalOptions{Resolver: resolver}).Unmarshal(data, resolvedOptions); err != nil {
+		return
+	}
+	addUsedMessageValueFileNames(usedFileNames, resolvedOptions)
+}
+
+func addUsedMessageValueFileNames(usedFileNames map[string]struct{}, message protoreflect.Message) {
+	message.Range(
+		func(fieldDescriptor protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			if fieldDescriptor.IsExtension() {
+				usedFileNames[fieldDescriptor.ParentFile().Path()] = struct{}{}
+			}
+			switch {
+			case fieldDescriptor.IsMap():
+				if fieldDescriptor.MapValue().Message() != nil {
+					value.Map().Range(
+						func(_ protoreflect.MapKey, mapValue protoreflect.Value) bool {
+							addUsedMessageValueFileNames(usedFileNames, mapValue.Message())
+							return true
+						},
+					)
+				}
+			case fieldDescriptor.Message() != nil && fieldDescriptor.IsList():
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					addUsedMessageValueFileNames(usedFileNames, list.Get(i).Message())
+				}
+			case fieldDescriptor.Message() != nil:
+				addUsedMessageValueFileNames(usedFileNames, value.Message())
+			}
+			return true
+		},
+	)
+}
+
+// resolverForProtoreflectFileDescriptorAndImports returns a new resolver for the file and its
+// transitive imports.
+func resolverForProtoreflectFileDescriptorAndImports(fileDescriptor protoreflect.FileDescriptor) (*resolver, error) {
+	protoregistryFiles := &protoregistry.Files{}
+	var register func(fileDescriptor protoreflect.FileDescriptor) error
+	register = func(fileDescriptor protoreflect.FileDescriptor) error {
+		if _, err := protoregistryFiles.FindFileByPath(fileDescriptor.Path()); err == nil {
+			return nil
+		}
+		imports := fileDescriptor.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			if err := register(imports.Get(i).FileDescriptor); err != nil {
+				return err
+			}
+		}
+		return protoregistryFiles.RegisterFile(fileDescriptor)
+	}
+	if err := register(fileDescriptor); err != nil {
+		return nil, err
+	}
+	return newResolver(protoregistryFiles), nil
+}
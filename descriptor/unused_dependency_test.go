@@ -0,0 +1,89 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestComputeUnusedDependencyIndexes(t *testing.T) {
+	t.Parallel()
+
+	optsFileDescriptor := testNewProtoFileDescriptor("opts.proto", "", "google/protobuf/descriptor.proto")
+	optsFileDescriptor.FileDescriptorProto.Extension = []*descriptorpb.FieldDescriptorProto{
+		{
+			Name:     proto.String("tag"),
+			Number:   proto.Int32(50001),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+			Extendee: proto.String(".google.protobuf.MessageOptions"),
+		},
+	}
+	reexportFileDescriptor := testNewProtoFileDescriptor("reexport.proto", "", "base.proto")
+	reexportFileDescriptor.FileDescriptorProto.PublicDependency = []int32{0}
+	mainFileDescriptor := testNewProtoFileDescriptor(
+		"main.proto",
+		".Base",
+		"opts.proto",
+		"reexport.proto",
+		"unused.proto",
+		"public.proto",
+	)
+	mainFileDescriptor.FileDescriptorProto.PublicDependency = []int32{3}
+	messageOptions := &descriptorpb.MessageOptions{}
+	messageOptions.ProtoReflect().SetUnknown(
+		protowire.AppendVarint(protowire.AppendTag(nil, 50001, protowire.VarintType), 1),
+	)
+	mainFileDescriptor.FileDescriptorProto.MessageType[0].Options = messageOptions
+	baseFileDescriptor := testNewProtoFileDescriptor("base.proto", "")
+	baseFileDescriptor.FileDescriptorProto.MessageType = []*descriptorpb.DescriptorProto{
+		{
+			Name: proto.String("Base"),
+		},
+	}
+	fileDescriptors, err := FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+				IsImport:            true,
+			},
+			optsFileDescriptor,
+			baseFileDescriptor,
+			reexportFileDescriptor,
+			testNewProtoFileDescriptor("unused.proto", ""),
+			testNewProtoFileDescriptor("public.proto", ""),
+			mainFileDescriptor,
+		},
+	)
+	require.NoError(t, err)
+
+	unusedDependencyIndexes, err := ComputeUnusedDependencyIndexes(fileDescriptors[6])
+	require.NoError(t, err)
+	assert.Equal(t, []int32{2}, unusedDependencyIndexes)
+	unusedDependencyIndexes, err = ComputeUnusedDependencyIndexes(fileDescriptors[1])
+	require.NoError(t, err)
+	assert.Empty(t, unusedDependencyIndexes)
+	unusedDependencyIndexes, err = ComputeUnusedDependencyIndexes(fileDescriptors[3])
+	require.NoError(t, err)
+	assert.Empty(t, unusedDependencyIndexes)
+}
@@ -0,0 +1,213 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrSkipChildren can be returned from an Enter hook of a Visitor to skip the children of
+// the element. The Exit hook of the element is still called.
+//
+// Walk never returns ErrSkipChildren.
+var ErrSkipChildren = errors.New("skip children")
+
+// Visitor has the hooks called by Walk.
+//
+// Every hook is optional. Each Enter hook is called before the children of the element are
+// walked, and each Exit hook is called after. If a hook returns an error other than
+// ErrSkipChildren, Walk stops and returns the error.
+type Visitor struct {
+	EnterFile      func(FileDescriptor) error
+	ExitFile       func(FileDescriptor) error
+	EnterMessage   func(protoreflect.MessageDescriptor) error
+	ExitMessage    func(protoreflect.MessageDescriptor) error
+	EnterField     func(protoreflect.FieldDescriptor) error
+	ExitField      func(protoreflect.FieldDescriptor) error
+	EnterOneof     func(protoreflect.OneofDescriptor) error
+	ExitOneof      func(protoreflect.OneofDescriptor) error
+	EnterEnum      func(protoreflect.EnumDescriptor) error
+	ExitEnum       func(protoreflect.EnumDescriptor) error
+	EnterEnumValue func(protoreflect.EnumValueDescriptor) error
+	ExitEnumValue  func(protoreflect.EnumValueDescriptor) error
+	EnterService   func(protoreflect.ServiceDescriptor) error
+	ExitService    func(protoreflect.ServiceDescriptor) error
+	EnterMethod    func(protoreflect.MethodDescriptor) error
+	ExitMethod     func(protoreflect.MethodDescriptor) error
+	EnterExtension func(protoreflect.ExtensionDescriptor) error
+	ExitExtension  func(protoreflect.ExtensionDescriptor) error
+}
+
+// Walk walks the elements of the FileDescriptors depth-first, calling the hooks of the Visitor.
+//
+// Files are walked in the given order. The children of a file are its messages, enums,
+// services, and extensions, in that order. The children of a message are its fields,
+// oneofs, nested messages, nested enums, and extensions, in that order. The fields of
+// a oneof are walked as children of the message, not of the oneof. The children of an
+// enum are its values, and the children of a service are its methods. Within each kind,
+// elements are walked in declaration order.
+//
+// Map entry messages are walked like any other nested message.
+func Walk(fileDescriptors []FileDescriptor, visitor Visitor) error {
+	for _, fileDescriptor := range fileDescriptors {
+		if err := walkElement(
+			fileDescriptor,
+			visitor.EnterFile,
+			visitor.ExitFile,
+			func() error {
+				protoreflectFileDescriptor, err := fileDescriptor.BuildProtoreflectFileDescriptor()
+				if err != nil {
+					return err
+				}
+				return visitor.walkFile(protoreflectFileDescriptor)
+			},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// *** PRIVATE ***
+
+func (v Visitor) walkFile(fileDescriptor protoreflect.FileDescriptor) error {
+	if err := v.walkMessages(fileDescriptor.Messages()); err != nil {
+		return err
+	}
+	if err := v.walkEnums(fileDescriptor.Enums()); err != nil {
+		return err
+	}
+	services := fileDescriptor.Services()
+	for i := range services.Len() {
+		serviceDescriptor := services.Get(i)
+		if err := walkElement(
+			serviceDescriptor,
+			v.EnterService,
+			v.ExitService,
+			func() error {
+				methods := serviceDescriptor.Methods()
+				for j := range methods.Len() {
+					if err := walkElement(methods.Get(j), v.EnterMethod, v.ExitMethod, nil); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		); err != nil {
+			return err
+		}
+	}
+	return v.walkExtensions(fileDescriptor.Extensions())
+}
+
+func (v Visitor) walkMessages(messages protoreflect.MessageDescriptors) error {
+	for i := range messages.Len() {
+		messageDescriptor := messages.Get(i)
+		if err := walkElement(
+			messageDescriptor,
+			v.EnterMessage,
+			v.ExitMessage,
+			func() error {
+				fields := messageDescriptor.Fields()
+				for j := range fields.Len() {
+					if err := walkElement(fields.Get(j), v.EnterField, v.ExitField, nil); err != nil {
+						return err
+					}
+				}
+				oneofs := messageDescriptor.Oneofs()
+				for j := range oneofs.Len() {
+					if err := walkElement(oneofs.Get(j), v.EnterOneof, v.ExitOneof, nil); err != nil {
+						return err
+					}
+				}
+				if err := v.walkMessages(messageDescriptor.Messages()); err != nil {
+					return err
+				}
+				if err := v.walkEnums(messageDescriptor.Enums()); err != nil {
+					return err
+				}
+				return v.walkExtensions(messageDescriptor.Extensions())
+			},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v Visitor) walkEnums(enums protoreflect.EnumDescriptors) error {
+	for i := range enums.Len() {
+		enumDescriptor := enums.Get(i)
+		if err := walkElement(
+			enumDescriptor,
+			v.EnterEnum,
+			v.ExitEnum,
+			func() error {
+				values := enumDescriptor.Values()
+				for j := range values.Len() {
+					if err := walkElement(values.Get(j), v.EnterEnumValue, v.ExitEnumValue, nil); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v Visitor) walkExtensions(extensions protoreflect.ExtensionDescriptors) error {
+	for i := range extensions.Len() {
+		if err := walkElement(extensions.Get(i), v.EnterExtension, v.ExitExtension, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkElement calls enter, then walkChildren unless enter returned ErrSkipChildren, then exit.
+//
+// enter, exit, and walkChildren may be nil.
+func walkElement[D any](
+	element D,
+	enter func(D) error,
+	exit func(D) error,
+	walkChildren func() error,
+) error {
+	skipChildren := false
+	if enter != nil {
+		if err := enter(element); err != nil {
+			if !errors.Is(err, ErrSkipChildren) {
+				return err
+			}
+			skipChildren = true
+		}
+	}
+	if !skipChildren && walkChildren != nil {
+		if err := walkChildren(); err != nil {
+			return err
+		}
+	}
+	if exit != nil {
+		if err := exit(element); err != nil && !errors.Is(err, ErrSkipChildren) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	fileDescriptor := testNewSourcePathFileDescriptor(t)
+
+	var events []string
+	visitor := testNewRecordingVisitor(&events)
+	require.NoError(t, Walk([]FileDescriptor{fileDescriptor}, visitor))
+	assert.Equal(
+		t,
+		[]string{
+			"enter file foo.proto",
+			"enter message foo.Foo",
+			"enter field foo.Foo.one",
+			"exit field foo.Foo.one",
+			"enter field foo.Foo.two",
+			"exit field foo.Foo.two",
+			"enter oneof foo.Foo.choice",
+			"exit oneof foo.Foo.choice",
+			"enter message foo.Foo.Bar",
+			"exit message foo.Foo.Bar",
+			"enter enum foo.Foo.Baz",
+			"enter enum value foo.Foo.BAZ_ZERO",
+			"exit enum value foo.Foo.BAZ_ZERO",
+			"enter enum value foo.Foo.BAZ_ONE",
+			"exit enum value foo.Foo.BAZ_ONE",
+			"exit enum foo.Foo.Baz",
+			"enter extension foo.Foo.nested_ext",
+			"exit extension foo.Foo.nested_ext",
+			"exit message foo.Foo",
+			"enter enum foo.Top",
+			"enter enum value foo.TOP_ZERO",
+			"exit enum value foo.TOP_ZERO",
+			"exit enum foo.Top",
+			"enter service foo.FooService",
+			"enter method foo.FooService.Get",
+			"exit method foo.FooService.Get",
+			"exit service foo.FooService",
+			"enter extension foo.ext",
+			"exit extension foo.ext",
+			"exit file foo.proto",
+		},
+		events,
+	)
+
+	events = nil
+	visitor = testNewRecordingVisitor(&events)
+	enterMessage := visitor.EnterMessage
+	visitor.EnterMessage = func(messageDescriptor protoreflect.MessageDescriptor) error {
+		_ = enterMessage(messageDescriptor)
+		return ErrSkipChildren
+	}
+	enterEnum := visitor.EnterEnum
+	visitor.EnterEnum = func(enumDescriptor protoreflect.EnumDescriptor) error {
+		_ = enterEnum(enumDescriptor)
+		return ErrSkipChildren
+	}
+	visitor.EnterService = func(protoreflect.ServiceDescriptor) error {
+		return errors.New("stop")
+	}
+	assert.EqualError(t, Walk([]FileDescriptor{fileDescriptor}, visitor), "stop")
+	assert.Equal(
+		t,
+		[]string{
+			"enter file foo.proto",
+			"enter message foo.Foo",
+			"exit message foo.Foo",
+			"enter enum foo.Top",
+			"exit enum foo.Top",
+		},
+		events,
+	)
+}
+
+func testNewRecordingVisitor(events *[]string) Visitor {
+	record := func(event string, fullName protoreflect.FullName) error {
+		*events = append(*events, event+" "+string(fullName))
+		return nil
+	}
+	return Visitor{
+		EnterFile: func(fileDescriptor FileDescriptor) error {
+			*events = append(*events, "enter file "+fileDescriptor.FileDescriptorProto().GetName())
+			return nil
+		},
+		ExitFile: func(fileDescriptor FileDescriptor) error {
+			*events = append(*events, "exit file "+fileDescriptor.FileDescriptorProto().GetName())
+			return nil
+		},
+		EnterMessage: func(d protoreflect.MessageDescriptor) error { return record("enter message", d.FullName()) },
+		ExitMessage:  func(d protoreflect.MessageDescriptor) error { return record("exit message", d.FullName()) },
+		EnterField:   func(d protoreflect.FieldDescriptor) error { return record("enter field", d.FullName()) },
+		ExitField:    func(d protoreflect.FieldDescriptor) error { return record("exit field", d.FullName()) },
+		EnterOneof:   func(d protoreflect.OneofDescriptor) error { return record("enter oneof", d.FullName()) },
+		ExitOneof:    func(d protoreflect.OneofDescriptor) error { return record("exit oneof", d.FullName()) },
+		EnterEnum:    func(d protoreflect.EnumDescriptor) error { return record("enter enum", d.FullName()) },
+		ExitEnum:     func(d protoreflect.EnumDescriptor) error { return record("exit enum", d.FullName()) },
+		EnterEnumValue: func(d protoreflect.EnumValueDescriptor) error {
+			return record("enter enum value", d.FullName())
+		},
+		ExitEnumValue: func(d protoreflect.EnumValueDescriptor) error {
+			return record("exit enum value", d.FullName())
+		},
+		EnterService: func(d protoreflect.ServiceDescriptor) error { return record("enter service", d.FullName()) },
+		ExitService:  func(d protoreflect.ServiceDescriptor) error { return record("exit service", d.FullName()) },
+		EnterMethod:  func(d protoreflect.MethodDescriptor) error { return record("enter method", d.FullName()) },
+		ExitMethod:   func(d protoreflect.MethodDescriptor) error { return record("exit method", d.FullName()) },
+		EnterExtension: func(d protoreflect.ExtensionDescriptor) error {
+			return record("enter extension", d.FullName())
+		},
+		ExitExtension: func(d protoreflect.ExtensionDescriptor) error {
+			return record("exit extension", d.FullName())
+		},
+	}
+}
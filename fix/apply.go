@@ -0,0 +1,147 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Result is the result of Apply.
+type Result struct {
+	// FileNameToSource contains the new source of every file that at least one applied
+	// Fix edited.
+	//
+	// Files that were not edited are not present.
+	FileNameToSource map[string][]byte
+	// Applied are the Fixes that were applied, in the order they were given to Apply.
+	Applied []Fix
+	// Conflicts are the Fixes that were not applied because they conflict with a Fix that
+	// was applied, in the order they were given to Apply.
+	Conflicts []*Conflict
+}
+
+// Conflict is a Fix that was not applied by Apply because one of its Edits overlaps with
+// an Edit of a Fix that was applied.
+type Conflict struct {
+	// Fix is the Fix that was not applied.
+	Fix Fix
+	// Edit is the Edit of Fix that overlaps with ConflictingEdit.
+	Edit Edit
+	// ConflictingFix is the applied Fix that Fix conflicts with.
+	ConflictingFix Fix
+	// ConflictingEdit is the Edit of ConflictingFix that overlaps with Edit.
+	ConflictingEdit Edit
+}
+
+// Apply applies the Fixes to the source of the files.
+//
+// Fixes are considered in order, and each Fix is applied atomically: if any of its Edits
+// overlaps with an Edit of a Fix that was already applied, none of its Edits are applied,
+// and the Fix is reported as a Conflict. Two Edits overlap if they edit the same file and
+// either their spans overlap or they start at the same offset. Earlier Fixes therefore
+// take precedence over later Fixes. Callers can apply the Fixes reported as Conflicts by
+// running the plugin again on the result.
+//
+// Offsets of all Edits are relative to the given source, not to the source after other
+// Edits are applied.
+//
+// The given map is not modified. An error is returned if an Edit references a file that
+// is not in fileNameToSource, or has a span that is out of range for the source of the file.
+func Apply(fileNameToSource map[string][]byte, fixes []Fix) (*Result, error) {
+	// Validate all Edits before applying anything so that a Result is never partial.
+	for _, fix := range fixes {
+		for _, edit := range fix.Edits() {
+			source, ok := fileNameToSource[edit.FileName()]
+			if !ok {
+				return nil, fmt.Errorf("fix for rule %q edits unknown file %q", fix.RuleID(), edit.FileName())
+			}
+			if edit.End() > len(source) {
+				return nil, fmt.Errorf(
+					"fix for rule %q has edit %s out of range for file of %d bytes",
+					fix.RuleID(),
+					editString(edit),
+					len(source),
+				)
+			}
+		}
+	}
+	result := &Result{}
+	fileNameToAppliedEdits := make(map[string][]*appliedEdit)
+	for _, fix := range fixes {
+		if conflict := findConflict(fix, fileNameToAppliedEdits); conflict != nil {
+			result.Conflicts = append(result.Conflicts, conflict)
+			continue
+		}
+		for _, edit := range fix.Edits() {
+			fileNameToAppliedEdits[edit.FileName()] = append(
+				fileNameToAppliedEdits[edit.FileName()],
+				&appliedEdit{edit: edit, fix: fix},
+			)
+		}
+		result.Applied = append(result.Applied, fix)
+	}
+	if len(fileNameToAppliedEdits) > 0 {
+		result.FileNameToSource = make(map[string][]byte, len(fileNameToAppliedEdits))
+	}
+	for fileName, appliedEdits := range fileNameToAppliedEdits {
+		edits := make([]Edit, len(appliedEdits))
+		for i, appliedEdit := range appliedEdits {
+			edits[i] = appliedEdit.edit
+		}
+		result.FileNameToSource[fileName] = applyEdits(fileNameToSource[fileName], edits)
+	}
+	return result, nil
+}
+
+// *** PRIVATE ***
+
+// appliedEdit is an Edit of a Fix that was applied.
+type appliedEdit struct {
+	edit Edit
+	fix  Fix
+}
+
+// findConflict returns the Conflict between the Fix and the Edits already applied, or nil
+// if there is no Conflict.
+func findConflict(fix Fix, fileNameToAppliedEdits map[string][]*appliedEdit) *Conflict {
+	for _, edit := range fix.Edits() {
+		for _, appliedEdit := range fileNameToAppliedEdits[edit.FileName()] {
+			if editsOverlap(edit, appliedEdit.edit) {
+				return &Conflict{
+					Fix:             fix,
+					Edit:            edit,
+					ConflictingFix:  appliedEdit.fix,
+					ConflictingEdit: appliedEdit.edit,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// applyEdits returns the source with the non-overlapping Edits applied.
+func applyEdits(source []byte, edits []Edit) []byte {
+	edits = slices.Clone(edits)
+	slices.SortFunc(edits, func(one Edit, two Edit) int { return one.Start() - two.Start() })
+	newSource := make([]byte, 0, len(source))
+	offset := 0
+	for _, edit := range edits {
+		newSource = append(newSource, source[offset:edit.Start()]...)
+		newSource = append(newSource, edit.Replacement()...)
+		offset = edit.End()
+	}
+	return append(newSource, source[offset:]...)
+}
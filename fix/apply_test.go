@@ -0,0 +1,109 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	fileNameToSource := map[string][]byte{
+		"a.proto": []byte("message Foo {}\n"),
+		"b.proto": []byte("message Bar {}\n"),
+	}
+	renameFoo := testNewFix(t, "RULE1", testNewEdit(t, "a.proto", 8, 11, "Baz"))
+	// Conflicts with renameFoo.
+	renameFooAgain := testNewFix(t, "RULE2", testNewEdit(t, "a.proto", 9, 10, "a"))
+	// Does not conflict with renameFoo, as it inserts at the end of its span.
+	insertComment := testNewFix(
+		t,
+		"RULE3",
+		testNewEdit(t, "a.proto", 0, 0, "// Foo.\n"),
+		testNewEdit(t, "b.proto", 0, 0, "// Bar.\n"),
+	)
+	// Conflicts with insertComment, as it inserts at the same offset.
+	insertOtherComment := testNewFix(t, "RULE4", testNewEdit(t, "b.proto", 0, 0, "// Other.\n"))
+	deleteNewline := testNewFix(t, "RULE5", testNewEdit(t, "b.proto", 14, 15, ""))
+
+	result, err := Apply(
+		fileNameToSource,
+		[]Fix{renameFoo, renameFooAgain, insertComment, insertOtherComment, deleteNewline},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		map[string][]byte{
+			"a.proto": []byte("// Foo.\nmessage Baz {}\n"),
+			"b.proto": []byte("// Bar.\nmessage Bar {}"),
+		},
+		result.FileNameToSource,
+	)
+	require.Equal(t, []Fix{renameFoo, insertComment, deleteNewline}, result.Applied)
+	require.Equal(
+		t,
+		[]*Conflict{
+			{
+				Fix:             renameFooAgain,
+				Edit:            renameFooAgain.Edits()[0],
+				ConflictingFix:  renameFoo,
+				ConflictingEdit: renameFoo.Edits()[0],
+			},
+			{
+				Fix:             insertOtherComment,
+				Edit:            insertOtherComment.Edits()[0],
+				ConflictingFix:  insertComment,
+				ConflictingEdit: insertComment.Edits()[1],
+			},
+		},
+		result.Conflicts,
+	)
+	// The input is not modified.
+	require.Equal(t, "message Foo {}\n", string(fileNameToSource["a.proto"]))
+}
+
+func TestApplyInvalid(t *testing.T) {
+	t.Parallel()
+
+	fileNameToSource := map[string][]byte{
+		"a.proto": []byte("message Foo {}\n"),
+	}
+	_, err := Apply(fileNameToSource, []Fix{testNewFix(t, "RULE1", testNewEdit(t, "b.proto", 0, 0, "x"))})
+	require.ErrorContains(t, err, `unknown file "b.proto"`)
+	_, err = Apply(fileNameToSource, []Fix{testNewFix(t, "RULE1", testNewEdit(t, "a.proto", 10, 20, "x"))})
+	require.ErrorContains(t, err, "out of range")
+
+	_, err = NewEdit("a.proto", 2, 1, "")
+	require.Error(t, err)
+	_, err = NewFix("RULE1", "", testNewEdit(t, "a.proto", 0, 5, ""), testNewEdit(t, "a.proto", 4, 6, ""))
+	require.ErrorContains(t, err, "overlap")
+	_, err = NewFix("RULE1", "")
+	require.Error(t, err)
+}
+
+func testNewEdit(t *testing.T, fileName string, start int, end int, replacement string) Edit {
+	edit, err := NewEdit(fileName, start, end, replacement)
+	require.NoError(t, err)
+	return edit
+}
+
+func testNewFix(t *testing.T, ruleID string, edits ...Edit) Fix {
+	fix, err := NewFix(ruleID, "", edits...)
+	require.NoError(t, err)
+	return fix
+}
@@ -0,0 +1,75 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"context"
+
+	extfixv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/fix/v1"
+	"pluginrpc.com/pluginrpc"
+)
+
+// Client is a client for a fix plugin.
+type Client interface {
+	// Fix invokes the plugin and returns the Fixes it produced.
+	//
+	// The Fixes are ordered by the order of the Rules within the Spec of the plugin, and then
+	// by the order in which the RuleHandlers added them. Use Apply to apply them.
+	Fix(ctx context.Context, request Request) ([]Fix, error)
+
+	isClient()
+}
+
+// NewClient returns a new Client for the given pluginrpc.Client.
+func NewClient(pluginrpcClient pluginrpc.Client) Client {
+	return newClient(pluginrpcClient)
+}
+
+// NewClientForSpec return a new Client that directly uses the given Spec.
+//
+// This should primarily be used for testing.
+func NewClientForSpec(spec *Spec) (Client, error) {
+	server, err := NewServer(spec)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server))), nil
+}
+
+// *** PRIVATE ***
+
+type client struct {
+	pluginrpcClient pluginrpc.Client
+}
+
+func newClient(pluginrpcClient pluginrpc.Client) *client {
+	return &client{
+		pluginrpcClient: pluginrpcClient,
+	}
+}
+
+func (c *client) Fix(ctx context.Context, request Request) ([]Fix, error) {
+	protoRequest, err := requestToProto(request)
+	if err != nil {
+		return nil, err
+	}
+	protoResponse := &extfixv1.FixResponse{}
+	if err := c.pluginrpcClient.Call(ctx, FixProcedurePath, protoRequest, protoResponse); err != nil {
+		return nil, err
+	}
+	return fixesForProto(protoResponse)
+}
+
+func (*client) isClient() {}
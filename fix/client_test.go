@@ -0,0 +1,122 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/descriptor"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestClientForSpec(t *testing.T) {
+	t.Parallel()
+
+	spec := &Spec{
+		Rules: []*RuleSpec{
+			{
+				ID:      "RENAME_FOO",
+				Handler: testNewReplaceRuleHandler("Foo", "Baz"),
+			},
+			{
+				ID:      "RENAME_FOO_AGAIN",
+				Handler: testNewReplaceRuleHandler("Foo", "Qux"),
+			},
+		},
+	}
+	require.NoError(t, ValidateSpec(spec))
+	client, err := NewClientForSpec(spec)
+	require.NoError(t, err)
+	fileNameToSource := map[string][]byte{
+		"a.proto": []byte("syntax = \"proto3\";\n\nmessage Foo {}\n"),
+	}
+	request := testNewRequest(t, fileNameToSource)
+
+	fixes, err := client.Fix(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, fixes, 2)
+	require.Equal(t, "RENAME_FOO", fixes[0].RuleID())
+	require.Equal(t, `replace "Foo" with "Baz"`, fixes[0].Message())
+	require.Equal(t, "RENAME_FOO_AGAIN", fixes[1].RuleID())
+	result, err := Apply(fileNameToSource, fixes)
+	require.NoError(t, err)
+	require.Equal(t, "syntax = \"proto3\";\n\nmessage Baz {}\n", string(result.FileNameToSource["a.proto"]))
+	require.Len(t, result.Conflicts, 1)
+	require.Equal(t, "RENAME_FOO_AGAIN", result.Conflicts[0].Fix.RuleID())
+
+	request = testNewRequest(t, fileNameToSource, check.WithRuleIDs("RENAME_FOO_AGAIN"))
+	fixes, err = client.Fix(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, fixes, 1)
+	require.Equal(t, "RENAME_FOO_AGAIN", fixes[0].RuleID())
+
+	request = testNewRequest(t, fileNameToSource, check.WithRuleIDs("UNKNOWN"))
+	_, err = client.Fix(context.Background(), request)
+	require.Error(t, err)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpc.WrapError(err).Code())
+}
+
+func testNewReplaceRuleHandler(old string, replacement string) RuleHandler {
+	return RuleHandlerFunc(
+		func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+			for _, fileDescriptor := range request.FileDescriptors() {
+				fileName := fileDescriptor.ProtoreflectFileDescriptor().Path()
+				source, ok := request.Source(fileName)
+				if !ok {
+					continue
+				}
+				start := bytes.Index(source, []byte(old))
+				if start < 0 {
+					continue
+				}
+				edit, err := NewEdit(fileName, start, start+len(old), replacement)
+				if err != nil {
+					return err
+				}
+				if err := responseWriter.AddFix(`replace "`+old+`" with "`+replacement+`"`, edit); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
+
+func testNewRequest(t *testing.T, fileNameToSource map[string][]byte, options ...check.RequestOption) Request {
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(
+		[]*descriptorv1.FileDescriptor{
+			{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:        proto.String("a.proto"),
+					Syntax:      proto.String("proto3"),
+					MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Foo")}},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	checkRequest, err := check.NewRequest(fileDescriptors, options...)
+	require.NoError(t, err)
+	request, err := NewRequest(checkRequest, fileNameToSource)
+	require.NoError(t, err)
+	return request
+}
@@ -0,0 +1,114 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Edit is a replacement of a byte span of a file.
+//
+// The span is the half-open range [Start, End) of byte offsets into the source of the
+// file. If Start equals End, the Edit is an insertion at Start. If Replacement is
+// empty, the Edit is a deletion.
+type Edit interface {
+	// FileName is the name of the file to edit.
+	//
+	// This matches the name of the corresponding descriptor.FileDescriptor.
+	// This will always be present.
+	FileName() string
+	// Start is the byte offset of the start of the span, inclusive.
+	Start() int
+	// End is the byte offset of the end of the span, exclusive.
+	//
+	// This will always be greater than or equal to Start.
+	End() int
+	// Replacement is the content that replaces the span.
+	Replacement() string
+
+	isEdit()
+}
+
+// NewEdit returns a new Edit.
+//
+// The fileName must be non-empty, and 0 <= start <= end.
+func NewEdit(fileName string, start int, end int, replacement string) (Edit, error) {
+	return newEdit(fileName, start, end, replacement)
+}
+
+// *** PRIVATE ***
+
+type edit struct {
+	fileName    string
+	start       int
+	end         int
+	replacement string
+}
+
+func newEdit(fileName string, start int, end int, replacement string) (*edit, error) {
+	if fileName == "" {
+		return nil, errors.New("fix.Edit: FileName is empty")
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("fix.Edit: Start %d for %q is negative", start, fileName)
+	}
+	if end < start {
+		return nil, fmt.Errorf("fix.Edit: End %d for %q is less than Start %d", end, fileName, start)
+	}
+	return &edit{
+		fileName:    fileName,
+		start:       start,
+		end:         end,
+		replacement: replacement,
+	}, nil
+}
+
+func (e *edit) FileName() string {
+	return e.fileName
+}
+
+func (e *edit) Start() int {
+	return e.start
+}
+
+func (e *edit) End() int {
+	return e.end
+}
+
+func (e *edit) Replacement() string {
+	return e.replacement
+}
+
+func (*edit) isEdit() {}
+
+// editsOverlap returns true if the two Edits edit the same file and their spans overlap.
+//
+// Two Edits also overlap if they start at the same offset, as the order in which they
+// would be applied is ambiguous.
+func editsOverlap(one Edit, two Edit) bool {
+	if one.FileName() != two.FileName() {
+		return false
+	}
+	if one.Start() == two.Start() {
+		return true
+	}
+	return one.Start() < two.End() && two.Start() < one.End()
+}
+
+// editString returns a user-readable representation of the span of the Edit.
+func editString(edit Edit) string {
+	return fmt.Sprintf("%s:[%d,%d)", edit.FileName(), edit.Start(), edit.End())
+}
@@ -0,0 +1,106 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fix implements the SDK for plugins that produce machine-applicable fixes to
+// .proto source files.
+//
+// A fix plugin is given the same FileDescriptors, Options, and Rule IDs as a check plugin,
+// along with the source of the files, and returns Fixes. Each Fix is a set of Edits that
+// replace a byte span of a file with new content. Clients apply Fixes with Apply, which
+// applies all Fixes that do not conflict with each other and reports the rest as Conflicts.
+// This enables workflows such as automatically fixing lint failures.
+package fix // import "buf.build/go/bufplugin/fix"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Fix is a machine-applicable fix for a failure of a Rule.
+//
+// A Fix is applied atomically: either all of its Edits are applied, or none are.
+type Fix interface {
+	// RuleID is the ID of the Rule that the Fix is for.
+	//
+	// This will always be present.
+	RuleID() string
+	// Message is a user-readable message describing the Fix.
+	Message() string
+	// Edits are the Edits that make up the Fix.
+	//
+	// There will always be at least one Edit. Edits to the same file never overlap.
+	Edits() []Edit
+
+	isFix()
+}
+
+// NewFix returns a new Fix.
+//
+// The ruleID must be non-empty, there must be at least one Edit, and no two Edits
+// to the same file may overlap. See Apply for the definition of overlapping Edits.
+func NewFix(ruleID string, message string, edits ...Edit) (Fix, error) {
+	return newFix(ruleID, message, edits)
+}
+
+// *** PRIVATE ***
+
+type fix struct {
+	ruleID  string
+	message string
+	edits   []Edit
+}
+
+func newFix(ruleID string, message string, edits []Edit) (*fix, error) {
+	if ruleID == "" {
+		return nil, errors.New("fix.Fix: RuleID is empty")
+	}
+	if len(edits) == 0 {
+		return nil, fmt.Errorf("fix.Fix: no Edits for Rule %q", ruleID)
+	}
+	for i, edit := range edits {
+		if edit == nil {
+			return nil, fmt.Errorf("fix.Fix: nil Edit for Rule %q", ruleID)
+		}
+		for _, otherEdit := range edits[:i] {
+			if editsOverlap(edit, otherEdit) {
+				return nil, fmt.Errorf(
+					"fix.Fix: Edits for Rule %q overlap: %s and %s",
+					ruleID,
+					editString(otherEdit),
+					editString(edit),
+				)
+			}
+		}
+	}
+	return &fix{
+		ruleID:  ruleID,
+		message: message,
+		edits:   slices.Clone(edits),
+	}, nil
+}
+
+func (f *fix) RuleID() string {
+	return f.ruleID
+}
+
+func (f *fix) Message() string {
+	return f.message
+}
+
+func (f *fix) Edits() []Edit {
+	return slices.Clone(f.edits)
+}
+
+func (*fix) isFix() {}
@@ -0,0 +1,45 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"os"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// Main is the main entrypoint for a fix plugin that implements the given Spec.
+//
+// A plugin just needs to provide a Spec, and then call this function within main.
+// Stdout is reserved for the responses of the plugin: os.Stdout is set to os.Stderr, so
+// that output written to os.Stdout by RuleHandlers or libraries does not corrupt responses.
+//
+//	func main() {
+//		fix.Main(
+//			&fix.Spec{
+//				Rules: []*fix.RuleSpec{
+//					{
+//						ID:      "FIELD_LOWER_SNAKE_CASE",
+//						Handler: fix.RuleHandlerFunc(handleFieldLowerSnakeCase),
+//					},
+//				},
+//			},
+//		)
+//	}
+func Main(spec *Spec) {
+	// pluginrpc.OSEnv captured the original stdout when it was initialized.
+	os.Stdout = os.Stderr
+	pluginrpc.Main(func() (pluginrpc.Server, error) { return NewServer(spec) })
+}
@@ -0,0 +1,74 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"fmt"
+	"maps"
+
+	"buf.build/go/bufplugin/check"
+)
+
+// Request is a request to a plugin to produce Fixes.
+//
+// A Request contains the same FileDescriptors, Options, and Rule IDs as a check.Request,
+// along with the source of the files, so that Edits can be expressed as byte spans.
+type Request interface {
+	check.Request
+
+	// Source returns the source of the file with the given name.
+	//
+	// Returns false if the source of the file was not provided. The source is provided for
+	// every file in FileDescriptors that the client wants fixed; RuleHandlers should not
+	// produce Fixes for files without source.
+	Source(fileName string) ([]byte, bool)
+}
+
+// NewRequest returns a new Request for the given check.Request and source of the files.
+//
+// The keys of fileNameToSource must be names of files within the FileDescriptors of
+// the check.Request.
+func NewRequest(checkRequest check.Request, fileNameToSource map[string][]byte) (Request, error) {
+	return newRequest(checkRequest, fileNameToSource)
+}
+
+// *** PRIVATE ***
+
+type request struct {
+	check.Request
+
+	fileNameToSource map[string][]byte
+}
+
+func newRequest(checkRequest check.Request, fileNameToSource map[string][]byte) (*request, error) {
+	fileNames := make(map[string]struct{}, len(checkRequest.FileDescriptors()))
+	for _, fileDescriptor := range checkRequest.FileDescriptors() {
+		fileNames[fileDescriptor.FileDescriptorProto().GetName()] = struct{}{}
+	}
+	for fileName := range fileNameToSource {
+		if _, ok := fileNames[fileName]; !ok {
+			return nil, fmt.Errorf("fix.Request: source provided for %q, which is not in FileDescriptors", fileName)
+		}
+	}
+	return &request{
+		Request:          checkRequest,
+		fileNameToSource: maps.Clone(fileNameToSource),
+	}, nil
+}
+
+func (r *request) Source(fileName string) ([]byte, bool) {
+	source, ok := r.fileNameToSource[fileName]
+	return source, ok
+}
@@ -0,0 +1,63 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"sync"
+)
+
+// ResponseWriter is used by RuleHandlers to add Fixes.
+type ResponseWriter interface {
+	// AddFix adds a Fix for the Rule with the given message and Edits.
+	//
+	// The Fix is validated as with NewFix. The RuleID of the Fix is the ID of the Rule
+	// whose RuleHandler was invoked.
+	AddFix(message string, edits ...Edit) error
+
+	isResponseWriter()
+}
+
+// *** PRIVATE ***
+
+type responseWriter struct {
+	ruleID string
+	fixes  []Fix
+	lock   sync.Mutex
+}
+
+func newResponseWriter(ruleID string) *responseWriter {
+	return &responseWriter{
+		ruleID: ruleID,
+	}
+}
+
+func (r *responseWriter) AddFix(message string, edits ...Edit) error {
+	fix, err := newFix(r.ruleID, message, edits)
+	if err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fixes = append(r.fixes, fix)
+	return nil
+}
+
+func (r *responseWriter) getFixes() []Fix {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.fixes
+}
+
+func (*responseWriter) isResponseWriter() {}
@@ -0,0 +1,106 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"context"
+	"slices"
+
+	extfixv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/fix/v1"
+	"buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/fix/v1/fixv1pluginrpc"
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	// FixProcedurePath is the path of the Fix procedure.
+	FixProcedurePath = fixv1pluginrpc.FixServiceFixPath
+
+	fixProcedureArg = "fix"
+)
+
+// NewServer is a convenience function that creates a new pluginrpc.Server for
+// the given Spec.
+//
+// This registers the Fix procedure on the command "fix".
+func NewServer(spec *Spec) (pluginrpc.Server, error) {
+	if err := ValidateSpec(spec); err != nil {
+		return nil, err
+	}
+	pluginrpcSpec, err := fixv1pluginrpc.FixServiceSpecBuilder{
+		Fix: []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs(fixProcedureArg)},
+	}.Build()
+	if err != nil {
+		return nil, err
+	}
+	handler := pluginrpc.NewHandler(pluginrpcSpec)
+	serverRegistrar := pluginrpc.NewServerRegistrar()
+	fixServiceServer := fixv1pluginrpc.NewFixServiceServer(handler, newFixServiceHandler(spec))
+	fixv1pluginrpc.RegisterFixServiceServer(serverRegistrar, fixServiceServer)
+	return pluginrpc.NewServer(pluginrpcSpec, serverRegistrar)
+}
+
+// *** PRIVATE ***
+
+type fixServiceHandler struct {
+	spec *Spec
+}
+
+func newFixServiceHandler(spec *Spec) *fixServiceHandler {
+	return &fixServiceHandler{
+		spec: spec,
+	}
+}
+
+func (f *fixServiceHandler) Fix(ctx context.Context, protoRequest *extfixv1.FixRequest) (*extfixv1.FixResponse, error) {
+	request, err := requestForProto(protoRequest)
+	if err != nil {
+		return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+	}
+	fixes, err := handleRequest(ctx, f.spec, request)
+	if err != nil {
+		return nil, err
+	}
+	return fixesToProto(fixes)
+}
+
+// handleRequest invokes the RuleHandlers of the Rules selected by the Request, and returns
+// the Fixes they added, in the order of the Rules within the Spec.
+func handleRequest(ctx context.Context, spec *Spec, request Request) ([]Fix, error) {
+	ruleSpecs := spec.Rules
+	if ruleIDs := request.RuleIDs(); len(ruleIDs) > 0 {
+		idToRuleSpec := make(map[string]*RuleSpec, len(spec.Rules))
+		for _, ruleSpec := range spec.Rules {
+			idToRuleSpec[ruleSpec.ID] = ruleSpec
+		}
+		for _, ruleID := range ruleIDs {
+			if _, ok := idToRuleSpec[ruleID]; !ok {
+				return nil, pluginrpc.NewErrorf(pluginrpc.CodeInvalidArgument, "unknown rule ID: %q", ruleID)
+			}
+		}
+		ruleSpecs = slices.DeleteFunc(
+			slices.Clone(spec.Rules),
+			func(ruleSpec *RuleSpec) bool { return !slices.Contains(ruleIDs, ruleSpec.ID) },
+		)
+	}
+	var fixes []Fix
+	for _, ruleSpec := range ruleSpecs {
+		responseWriter := newResponseWriter(ruleSpec.ID)
+		if err := ruleSpec.Handler.Handle(ctx, responseWriter, request); err != nil {
+			return nil, err
+		}
+		fixes = append(fixes, responseWriter.getFixes()...)
+	}
+	return fixes, nil
+}
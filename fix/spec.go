@@ -0,0 +1,66 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Spec is the spec for a fix plugin.
+//
+// It is used to construct a plugin on the server-side (i.e. within the plugin).
+//
+// Generally, this is provided to Main. This library will handle Fix calls based on the
+// provided RuleSpecs.
+type Spec struct {
+	// Required.
+	//
+	// The IDs of the RuleSpecs generally match the IDs of the Rules of a check plugin
+	// whose failures the Fixes resolve.
+	Rules []*RuleSpec
+}
+
+// RuleSpec is the spec for a Rule that produces Fixes.
+type RuleSpec struct {
+	// Required.
+	ID string
+	// Required.
+	Handler RuleHandler
+}
+
+// ValidateSpec validates all values on a Spec.
+//
+// This is exposed publicly so it can be run as part of plugin tests. This will verify
+// that your Spec will result in a valid plugin.
+func ValidateSpec(spec *Spec) error {
+	if len(spec.Rules) == 0 {
+		return errors.New("fix.Spec: Rules is empty")
+	}
+	ruleIDs := make(map[string]struct{}, len(spec.Rules))
+	for _, ruleSpec := range spec.Rules {
+		if ruleSpec.ID == "" {
+			return errors.New("fix.RuleSpec: ID is empty")
+		}
+		if ruleSpec.Handler == nil {
+			return fmt.Errorf("fix.RuleSpec: Handler is not set for ID %q", ruleSpec.ID)
+		}
+		if _, ok := ruleIDs[ruleSpec.ID]; ok {
+			return fmt.Errorf("fix.Spec: duplicate rule ID %q", ruleSpec.ID)
+		}
+		ruleIDs[ruleSpec.ID] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fix
+
+import (
+	"fmt"
+	"math"
+
+	checkv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/check/v1"
+	"buf.build/go/bufplugin/check"
+	"buf.build/go/bufplugin/descriptor"
+	extfixv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/fix/v1"
+	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"google.golang.org/protobuf/proto"
+)
+
+func requestToProto(request Request) (*extfixv1.FixRequest, error) {
+	protoOptions, err := request.Options().ToProto()
+	if err != nil {
+		return nil, err
+	}
+	checkRequestData, err := proto.Marshal(
+		&checkv1.CheckRequest{
+			FileDescriptors:        xslices.Map(request.FileDescriptors(), descriptor.FileDescriptor.ToProto),
+			AgainstFileDescriptors: xslices.Map(request.AgainstFileDescriptors(), descriptor.FileDescriptor.ToProto),
+			Options:                protoOptions,
+			RuleIds:                request.RuleIDs(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	protoRequest := &extfixv1.FixRequest{
+		CheckRequest: checkRequestData,
+	}
+	for _, fileDescriptor := range request.FileDescriptors() {
+		fileName := fileDescriptor.FileDescriptorProto().GetName()
+		source, ok := request.Source(fileName)
+		if !ok {
+			continue
+		}
+		if protoRequest.Sources == nil {
+			protoRequest.Sources = make(map[string][]byte)
+		}
+		protoRequest.Sources[fileName] = source
+	}
+	return protoRequest, nil
+}
+
+func requestForProto(protoRequest *extfixv1.FixRequest) (Request, error) {
+	protoCheckRequest := &checkv1.CheckRequest{}
+	if err := proto.Unmarshal(protoRequest.GetCheckRequest(), protoCheckRequest); err != nil {
+		return nil, err
+	}
+	checkRequest, err := check.RequestForProtoRequest(protoCheckRequest)
+	if err != nil {
+		return nil, err
+	}
+	return newRequest(checkRequest, protoRequest.GetSources())
+}
+
+func fixesToProto(fixes []Fix) (*extfixv1.FixResponse, error) {
+	protoFixes := make([]*extfixv1.Fix, len(fixes))
+	for i, fix := range fixes {
+		protoEdits := make([]*extfixv1.Edit, len(fix.Edits()))
+		for j, edit := range fix.Edits() {
+			if edit.End() > math.MaxUint32 {
+				return nil, fmt.Errorf("fix.Edit: End %d for %q is too large", edit.End(), edit.FileName())
+			}
+			protoEdits[j] = &extfixv1.Edit{
+				FileName:    edit.FileName(),
+				Start:       uint32(edit.Start()),
+				End:         uint32(edit.End()),
+				Replacement: []byte(edit.Replacement()),
+			}
+		}
+		protoFixes[i] = &extfixv1.Fix{
+			RuleId:  fix.RuleID(),
+			Message: fix.Message(),
+			Edits:   protoEdits,
+		}
+	}
+	return &extfixv1.FixResponse{
+		Fixes: protoFixes,
+	}, nil
+}
+
+func fixesForProto(protoResponse *extfixv1.FixResponse) ([]Fix, error) {
+	fixes := make([]Fix, len(protoResponse.GetFixes()))
+	for i, protoFix := range protoResponse.GetFixes() {
+		edits := make([]Edit, len(protoFix.GetEdits()))
+		for j, protoEdit := range protoFix.GetEdits() {
+			edit, err := newEdit(
+				protoEdit.GetFileName(),
+				int(protoEdit.GetStart()),
+				int(protoEdit.GetEnd()),
+				string(protoEdit.GetReplacement()),
+			)
+			if err != nil {
+				return nil, err
+			}
+			edits[j] = edit
+		}
+		fix, err := newFix(protoFix.GetRuleId(), protoFix.GetMessage(), edits)
+		if err != nil {
+			return nil, err
+		}
+		fixes[i] = fix
+	}
+	return fixes, nil
+}
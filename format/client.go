@@ -0,0 +1,75 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"context"
+
+	extformatv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/format/v1"
+	"pluginrpc.com/pluginrpc"
+)
+
+// Client is a client for a format plugin.
+type Client interface {
+	// Format invokes the plugin and returns the formatted source of the files.
+	//
+	// The returned map only contains the files whose formatted source differs from their
+	// source in the Request. Files that are not present are already formatted.
+	Format(ctx context.Context, request Request) (map[string][]byte, error)
+
+	isClient()
+}
+
+// NewClient returns a new Client for the given pluginrpc.Client.
+func NewClient(pluginrpcClient pluginrpc.Client) Client {
+	return newClient(pluginrpcClient)
+}
+
+// NewClientForSpec return a new Client that directly uses the given Spec.
+//
+// This should primarily be used for testing.
+func NewClientForSpec(spec *Spec) (Client, error) {
+	server, err := NewServer(spec)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(pluginrpc.NewClient(pluginrpc.NewServerRunner(server))), nil
+}
+
+// *** PRIVATE ***
+
+type client struct {
+	pluginrpcClient pluginrpc.Client
+}
+
+func newClient(pluginrpcClient pluginrpc.Client) *client {
+	return &client{
+		pluginrpcClient: pluginrpcClient,
+	}
+}
+
+func (c *client) Format(ctx context.Context, request Request) (map[string][]byte, error) {
+	protoRequest, err := requestToProto(request)
+	if err != nil {
+		return nil, err
+	}
+	protoResponse := &extformatv1.FormatResponse{}
+	if err := c.pluginrpcClient.Call(ctx, FormatProcedurePath, protoRequest, protoResponse); err != nil {
+		return nil, err
+	}
+	return fileNameToSourceForProto(protoResponse), nil
+}
+
+func (*client) isClient() {}
@@ -0,0 +1,138 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestClientForSpec(t *testing.T) {
+	t.Parallel()
+
+	// Replaces tabs with the number of spaces given by the "indent" option.
+	spec := &Spec{
+		Handler: HandlerFunc(
+			func(_ context.Context, responseWriter ResponseWriter, request Request) error {
+				indent, _, err := request.Options().GetInt64("indent")
+				if err != nil {
+					return err
+				}
+				for _, fileName := range request.FileNames() {
+					source, _ := request.Source(fileName)
+					formatted := strings.ReplaceAll(string(source), "\t", strings.Repeat(" ", int(indent)))
+					if err := responseWriter.SetSource(fileName, []byte(formatted)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		),
+		Options: &option.Schema{
+			Keys: []*option.KeySpec{
+				{
+					Key:     "indent",
+					Type:    option.TypeInt64,
+					Default: int64(2),
+				},
+			},
+		},
+	}
+	require.NoError(t, ValidateSpec(spec))
+	server, err := NewServer(spec)
+	require.NoError(t, err)
+	fileNameToSource := map[string][]byte{
+		"a.proto": []byte("message Foo {\n\tstring name = 1;\n}\n"),
+		"b.proto": []byte("message Bar {}\n"),
+	}
+
+	for _, format := range []pluginrpc.Format{pluginrpc.FormatBinary, pluginrpc.FormatJSON} {
+		client := NewClient(
+			pluginrpc.NewClient(pluginrpc.NewServerRunner(server), pluginrpc.ClientWithFormat(format)),
+		)
+		formatted, err := client.Format(context.Background(), testNewRequest(t, fileNameToSource))
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			map[string][]byte{
+				"a.proto": []byte("message Foo {\n  string name = 1;\n}\n"),
+			},
+			formatted,
+		)
+	}
+
+	client, err := NewClientForSpec(spec)
+	require.NoError(t, err)
+	options, err := option.NewOptions(map[string]any{"indent": int64(4)})
+	require.NoError(t, err)
+	formatted, err := client.Format(context.Background(), testNewRequest(t, fileNameToSource, WithOptions(options)))
+	require.NoError(t, err)
+	require.Equal(t, "message Foo {\n    string name = 1;\n}\n", string(formatted["a.proto"]))
+
+	options, err = option.NewOptions(map[string]any{"indent": "four"})
+	require.NoError(t, err)
+	_, err = client.Format(context.Background(), testNewRequest(t, fileNameToSource, WithOptions(options)))
+	require.Error(t, err)
+	require.Equal(t, pluginrpc.CodeInvalidArgument, pluginrpc.WrapError(err).Code())
+}
+
+func TestResponseWriterUnknownFile(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClientForSpec(
+		&Spec{
+			Handler: HandlerFunc(
+				func(_ context.Context, responseWriter ResponseWriter, _ Request) error {
+					return responseWriter.SetSource("c.proto", nil)
+				},
+			),
+		},
+	)
+	require.NoError(t, err)
+	_, err = client.Format(
+		context.Background(),
+		testNewRequest(t, map[string][]byte{"a.proto": []byte("message Foo {}\n")}),
+	)
+	require.ErrorContains(t, err, `"c.proto" is not a file to format`)
+}
+
+func testNewRequest(t *testing.T, fileNameToSource map[string][]byte, options ...RequestOption) Request {
+	var protoFileDescriptors []*descriptorv1.FileDescriptor
+	for _, fileName := range []string{"a.proto", "b.proto"} {
+		protoFileDescriptors = append(
+			protoFileDescriptors,
+			&descriptorv1.FileDescriptor{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					Name:   proto.String(fileName),
+					Syntax: proto.String("proto3"),
+				},
+			},
+		)
+	}
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+	require.NoError(t, err)
+	request, err := NewRequest(fileDescriptors, fileNameToSource, options...)
+	require.NoError(t, err)
+	return request
+}
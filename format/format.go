@@ -0,0 +1,20 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package format implements the SDK for custom .proto formatter plugins.
+//
+// A format plugin is given the FileDescriptors and the source of the files to format,
+// and returns the formatted source of the files. This allows organizations to enforce
+// formatting conventions beyond those of buf format.
+package format // import "buf.build/go/bufplugin/format"
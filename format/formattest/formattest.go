@@ -0,0 +1,158 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package formattest provides testing helpers when writing format plugins.
+//
+// The easiest entry point is FormatTest. Other functions provide lower-level primitives if
+// FormatTest doesn't meet your needs.
+package formattest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"buf.build/go/bufplugin/check/checktest"
+	"buf.build/go/bufplugin/format"
+	"buf.build/go/bufplugin/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SpecTest tests your spec with format.ValidateSpec.
+//
+// Almost every plugin should run a test with SpecTest.
+//
+//	func TestSpec(t *testing.T) {
+//	  t.Parallel()
+//	  formattest.SpecTest(t, yourSpec)
+//	}
+func SpecTest(t *testing.T, spec *format.Spec) {
+	require.NoError(t, format.ValidateSpec(spec))
+}
+
+// FormatTest is a single Format test to run against a Spec.
+type FormatTest struct {
+	// Request is the request spec to test.
+	//
+	// Required.
+	Request *RequestSpec
+	// Spec is the Spec to test.
+	//
+	// Required.
+	Spec *format.Spec
+	// ExpectedFiles are the expected formatted sources of the files, keyed by file name.
+	//
+	// Only files whose formatted source differs from their source should be present.
+	ExpectedFiles map[string]string
+}
+
+// Run runs the test.
+//
+// This will:
+//
+//   - Build the Files and read their sources.
+//   - Create a new Request.
+//   - Create a new Client based on the Spec.
+//   - Call Format on the Client.
+//   - Compare the resulting sources with the ExpectedFiles, failing if there is a mismatch.
+func (f FormatTest) Run(t *testing.T) {
+	ctx := context.Background()
+
+	require.NotNil(t, f.Request)
+	require.NotNil(t, f.Spec)
+
+	request, err := f.Request.ToRequest(ctx)
+	require.NoError(t, err)
+	client, err := format.NewClientForSpec(f.Spec)
+	require.NoError(t, err)
+	fileNameToSource, err := client.Format(ctx, request)
+	require.NoError(t, err)
+	actualFiles := make(map[string]string, len(fileNameToSource))
+	for fileName, source := range fileNameToSource {
+		actualFiles[fileName] = string(source)
+	}
+	expectedFiles := f.ExpectedFiles
+	if expectedFiles == nil {
+		expectedFiles = make(map[string]string)
+	}
+	assert.Equal(t, expectedFiles, actualFiles)
+}
+
+// RequestSpec specifies request parameters to be compiled for testing.
+//
+// This allows a Request to be built from a directory of .proto files.
+type RequestSpec struct {
+	// Files specifies the files to format.
+	//
+	// The FilePaths are formatted, and their sources are read from the first of the
+	// DirPaths that contains them.
+	//
+	// Required.
+	Files *checktest.ProtoFileSpec
+	// Options are any options to pass to the plugin.
+	Options map[string]any
+}
+
+// ToRequest converts the spec into a format.Request.
+//
+// If r is nil, this returns nil.
+func (r *RequestSpec) ToRequest(ctx context.Context) (format.Request, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	if r.Files == nil {
+		return nil, errors.New("RequestSpec.Files not set")
+	}
+
+	fileDescriptors, err := r.Files.ToFileDescriptors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fileNameToSource := make(map[string][]byte, len(r.Files.FilePaths))
+	for _, filePath := range r.Files.FilePaths {
+		source, err := readSource(r.Files.DirPaths, filePath)
+		if err != nil {
+			return nil, err
+		}
+		fileNameToSource[filepath.ToSlash(filepath.Clean(filePath))] = source
+	}
+	options, err := option.NewOptions(r.Options)
+	if err != nil {
+		return nil, err
+	}
+	return format.NewRequest(fileDescriptors, fileNameToSource, format.WithOptions(options))
+}
+
+// *** PRIVATE ***
+
+// readSource reads the source of the file at the path relative to the first of the
+// dirPaths that contains it.
+func readSource(dirPaths []string, filePath string) ([]byte, error) {
+	for _, dirPath := range dirPaths {
+		source, err := os.ReadFile(filepath.Join(filepath.FromSlash(dirPath), filepath.FromSlash(filePath)))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		return source, nil
+	}
+	return nil, fmt.Errorf("%q not found in %v", filePath, dirPaths)
+}
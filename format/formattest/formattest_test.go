@@ -0,0 +1,64 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formattest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"buf.build/go/bufplugin/check/checktest"
+	"buf.build/go/bufplugin/format"
+)
+
+var trimTrailingWhitespaceSpec = &format.Spec{
+	Handler: format.HandlerFunc(
+		func(_ context.Context, responseWriter format.ResponseWriter, request format.Request) error {
+			for _, fileName := range request.FileNames() {
+				source, _ := request.Source(fileName)
+				lines := bytes.Split(source, []byte("\n"))
+				for i, line := range lines {
+					lines[i] = bytes.TrimRight(line, " \t")
+				}
+				if err := responseWriter.SetSource(fileName, bytes.Join(lines, []byte("\n"))); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	),
+}
+
+func TestSpec(t *testing.T) {
+	t.Parallel()
+	SpecTest(t, trimTrailingWhitespaceSpec)
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	FormatTest{
+		Request: &RequestSpec{
+			Files: &checktest.ProtoFileSpec{
+				DirPaths:  []string{"testdata/trailing"},
+				FilePaths: []string{"a.proto", "b.proto"},
+			},
+		},
+		Spec: trimTrailingWhitespaceSpec,
+		ExpectedFiles: map[string]string{
+			"a.proto": "syntax = \"proto3\";\n\npackage a;\n\nmessage Foo {\n  string name = 1;\n}\n",
+		},
+	}.Run(t)
+}
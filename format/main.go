@@ -0,0 +1,40 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"os"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// Main is the main entrypoint for a format plugin that implements the given Spec.
+//
+// A plugin just needs to provide a Spec, and then call this function within main.
+// Stdout is reserved for the responses of the plugin: os.Stdout is set to os.Stderr, so
+// that output written to os.Stdout by the Handler or libraries does not corrupt responses.
+//
+//	func main() {
+//		format.Main(
+//			&format.Spec{
+//				Handler: format.HandlerFunc(handle),
+//			},
+//		)
+//	}
+func Main(spec *Spec) {
+	// pluginrpc.OSEnv captured the original stdout when it was initialized.
+	os.Stdout = os.Stderr
+	pluginrpc.Main(func() (pluginrpc.Server, error) { return NewServer(spec) })
+}
@@ -0,0 +1,146 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"buf.build/go/bufplugin/descriptor"
+	"buf.build/go/bufplugin/internal/pkg/xslices"
+	"buf.build/go/bufplugin/option"
+)
+
+// Request is a request to a plugin to format files.
+type Request interface {
+	// FileDescriptors contains the FileDescriptors of the files to format, along with
+	// any FileDescriptors they import.
+	//
+	// FileDescriptors are guaranteed to be unique with respect to their name.
+	FileDescriptors() []descriptor.FileDescriptor
+	// FileNames returns the names of the files to format.
+	//
+	// Every file has a source, and a FileDescriptor within FileDescriptors.
+	// Will never be empty. The returned FileNames will be sorted.
+	FileNames() []string
+	// Source returns the source of the file with the given name.
+	//
+	// Returns false if the file is not one of the files to format.
+	Source(fileName string) ([]byte, bool)
+	// Options contains any options passed to the plugin.
+	//
+	// Will never be nil, but may have no values.
+	Options() option.Options
+
+	isRequest()
+}
+
+// NewRequest returns a new Request for the given FileDescriptors and source of the files
+// to format.
+//
+// The keys of fileNameToSource are the names of the files to format, and must be names of
+// files within fileDescriptors. At least one file is required. To set options, use WithOptions.
+func NewRequest(
+	fileDescriptors []descriptor.FileDescriptor,
+	fileNameToSource map[string][]byte,
+	options ...RequestOption,
+) (Request, error) {
+	return newRequest(fileDescriptors, fileNameToSource, options...)
+}
+
+// RequestOption is an option for a new Request.
+type RequestOption func(*requestOptions)
+
+// WithOptions adds the given Options to the Request.
+func WithOptions(options option.Options) RequestOption {
+	return func(requestOptions *requestOptions) {
+		requestOptions.options = options
+	}
+}
+
+// *** PRIVATE ***
+
+type request struct {
+	fileDescriptors  []descriptor.FileDescriptor
+	fileNameToSource map[string][]byte
+	options          option.Options
+}
+
+func newRequest(
+	fileDescriptors []descriptor.FileDescriptor,
+	fileNameToSource map[string][]byte,
+	options ...RequestOption,
+) (*request, error) {
+	requestOptions := newRequestOptions()
+	for _, option := range options {
+		option(requestOptions)
+	}
+	if requestOptions.options == nil {
+		requestOptions.options = option.EmptyOptions
+	}
+	if len(fileNameToSource) == 0 {
+		return nil, errors.New("format.Request: no files to format")
+	}
+	fileNames := xslices.ToStructMap(
+		xslices.Map(
+			fileDescriptors,
+			func(fileDescriptor descriptor.FileDescriptor) string {
+				return fileDescriptor.FileDescriptorProto().GetName()
+			},
+		),
+	)
+	if len(fileNames) != len(fileDescriptors) {
+		return nil, errors.New("format.Request: FileDescriptors have duplicate names")
+	}
+	for fileName := range fileNameToSource {
+		if _, ok := fileNames[fileName]; !ok {
+			return nil, fmt.Errorf("format.Request: source provided for %q, which is not in FileDescriptors", fileName)
+		}
+	}
+	return &request{
+		fileDescriptors:  fileDescriptors,
+		fileNameToSource: maps.Clone(fileNameToSource),
+		options:          requestOptions.options,
+	}, nil
+}
+
+func (r *request) FileDescriptors() []descriptor.FileDescriptor {
+	return slices.Clone(r.fileDescriptors)
+}
+
+func (r *request) FileNames() []string {
+	return xslices.MapKeysToSortedSlice(r.fileNameToSource)
+}
+
+func (r *request) Source(fileName string) ([]byte, bool) {
+	source, ok := r.fileNameToSource[fileName]
+	return source, ok
+}
+
+func (r *request) Options() option.Options {
+	return r.options
+}
+
+func (*request) isRequest() {}
+
+type requestOptions struct {
+	options option.Options
+}
+
+func newRequestOptions() *requestOptions {
+	return &requestOptions{}
+}
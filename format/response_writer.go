@@ -0,0 +1,78 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+// ResponseWriter is used by Handlers to set the formatted source of files.
+type ResponseWriter interface {
+	// SetSource sets the formatted source of the file with the given name.
+	//
+	// The file must be one of the FileNames of the Request, and the source must be valid
+	// UTF-8. Files whose source is not set are left unchanged. If SetSource is called
+	// multiple times for the same file, the last call wins.
+	SetSource(fileName string, source []byte) error
+
+	isResponseWriter()
+}
+
+// *** PRIVATE ***
+
+type responseWriter struct {
+	request          Request
+	fileNameToSource map[string][]byte
+	lock             sync.Mutex
+}
+
+func newResponseWriter(request Request) *responseWriter {
+	return &responseWriter{
+		request:          request,
+		fileNameToSource: make(map[string][]byte),
+	}
+}
+
+func (r *responseWriter) SetSource(fileName string, source []byte) error {
+	if _, ok := r.request.Source(fileName); !ok {
+		return fmt.Errorf("format.ResponseWriter: %q is not a file to format", fileName)
+	}
+	if !utf8.Valid(source) {
+		return fmt.Errorf("format.ResponseWriter: source of %q is not valid UTF-8", fileName)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fileNameToSource[fileName] = bytes.Clone(source)
+	return nil
+}
+
+// changedFileNameToSource returns the sources that were set and differ from the source
+// in the Request.
+func (r *responseWriter) changedFileNameToSource() map[string][]byte {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	changedFileNameToSource := make(map[string][]byte)
+	for fileName, source := range r.fileNameToSource {
+		if originalSource, _ := r.request.Source(fileName); !bytes.Equal(originalSource, source) {
+			changedFileNameToSource[fileName] = source
+		}
+	}
+	return changedFileNameToSource
+}
+
+func (*responseWriter) isResponseWriter() {}
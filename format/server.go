@@ -0,0 +1,113 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"context"
+
+	extformatv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/format/v1"
+	"buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/format/v1/formatv1pluginrpc"
+	"buf.build/go/bufplugin/option"
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	// FormatProcedurePath is the path of the Format procedure.
+	FormatProcedurePath = formatv1pluginrpc.FormatServiceFormatPath
+
+	formatProcedureArg = "format"
+)
+
+// NewServer is a convenience function that creates a new pluginrpc.Server for
+// the given Spec.
+//
+// This registers the Format procedure on the command "format".
+func NewServer(spec *Spec) (pluginrpc.Server, error) {
+	if err := ValidateSpec(spec); err != nil {
+		return nil, err
+	}
+	pluginrpcSpec, err := formatv1pluginrpc.FormatServiceSpecBuilder{
+		Format: []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs(formatProcedureArg)},
+	}.Build()
+	if err != nil {
+		return nil, err
+	}
+	handler := pluginrpc.NewHandler(pluginrpcSpec)
+	serverRegistrar := pluginrpc.NewServerRegistrar()
+	formatServiceServer := formatv1pluginrpc.NewFormatServiceServer(handler, newFormatServiceHandler(spec))
+	formatv1pluginrpc.RegisterFormatServiceServer(serverRegistrar, formatServiceServer)
+	return pluginrpc.NewServer(pluginrpcSpec, serverRegistrar)
+}
+
+// *** PRIVATE ***
+
+type formatServiceHandler struct {
+	spec *Spec
+}
+
+func newFormatServiceHandler(spec *Spec) *formatServiceHandler {
+	return &formatServiceHandler{
+		spec: spec,
+	}
+}
+
+func (f *formatServiceHandler) Format(ctx context.Context, protoRequest *extformatv1.FormatRequest) (*extformatv1.FormatResponse, error) {
+	request, err := requestForProto(protoRequest)
+	if err != nil {
+		return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+	}
+	fileNameToSource, err := handleRequest(ctx, f.spec, request)
+	if err != nil {
+		return nil, err
+	}
+	return fileNameToSourceToProto(fileNameToSource), nil
+}
+
+// handleRequest invokes the Handler, and returns the formatted source of every file
+// whose source changed.
+func handleRequest(ctx context.Context, spec *Spec, request Request) (map[string][]byte, error) {
+	if spec.Options != nil {
+		if err := option.ValidateOptions(spec.Options, request.Options()); err != nil {
+			return nil, pluginrpc.NewError(pluginrpc.CodeInvalidArgument, err)
+		}
+		var err error
+		request, err = newRequest(
+			request.FileDescriptors(),
+			requestFileNameToSource(request),
+			WithOptions(
+				option.CanonicalizeAllowedValues(
+					spec.Options,
+					option.OptionsWithDefaults(spec.Options, request.Options()),
+				),
+			),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	responseWriter := newResponseWriter(request)
+	if err := spec.Handler.Handle(ctx, responseWriter, request); err != nil {
+		return nil, err
+	}
+	return responseWriter.changedFileNameToSource(), nil
+}
+
+func requestFileNameToSource(request Request) map[string][]byte {
+	fileNameToSource := make(map[string][]byte)
+	for _, fileName := range request.FileNames() {
+		fileNameToSource[fileName], _ = request.Source(fileName)
+	}
+	return fileNameToSource
+}
@@ -0,0 +1,56 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"errors"
+
+	"buf.build/go/bufplugin/option"
+)
+
+// Spec is the spec for a format plugin.
+//
+// It is used to construct a plugin on the server-side (i.e. within the plugin).
+//
+// Generally, this is provided to Main. This library will handle Format calls based on
+// the provided Handler.
+type Spec struct {
+	// Required.
+	Handler Handler
+	// Options is the schema for the options that the plugin accepts.
+	//
+	// Optional.
+	//
+	// If set, the Options of every Request are validated against the Schema before the
+	// Handler is invoked, and the Default of every KeySpec is applied to the Options that
+	// the Handler sees.
+	Options *option.Schema
+}
+
+// ValidateSpec validates all values on a Spec.
+//
+// This is exposed publicly so it can be run as part of plugin tests. This will verify
+// that your Spec will result in a valid plugin.
+func ValidateSpec(spec *Spec) error {
+	if spec.Handler == nil {
+		return errors.New("format.Spec: Handler is not set")
+	}
+	if spec.Options != nil {
+		if err := option.ValidateSchema(spec.Options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	descriptorv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/descriptor/v1"
+	optionv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/option/v1"
+	"buf.build/go/bufplugin/descriptor"
+	extformatv1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/format/v1"
+	"buf.build/go/bufplugin/option"
+	"google.golang.org/protobuf/proto"
+)
+
+func requestToProto(request Request) (*extformatv1.FormatRequest, error) {
+	protoOptions, err := request.Options().ToProto()
+	if err != nil {
+		return nil, err
+	}
+	protoRequest := &extformatv1.FormatRequest{
+		Sources: make(map[string][]byte),
+	}
+	for _, fileDescriptor := range request.FileDescriptors() {
+		data, err := proto.Marshal(fileDescriptor.ToProto())
+		if err != nil {
+			return nil, err
+		}
+		protoRequest.FileDescriptors = append(protoRequest.FileDescriptors, data)
+	}
+	for _, protoOption := range protoOptions {
+		data, err := proto.Marshal(protoOption)
+		if err != nil {
+			return nil, err
+		}
+		protoRequest.Options = append(protoRequest.Options, data)
+	}
+	for _, fileName := range request.FileNames() {
+		protoRequest.Sources[fileName], _ = request.Source(fileName)
+	}
+	return protoRequest, nil
+}
+
+func requestForProto(protoRequest *extformatv1.FormatRequest) (Request, error) {
+	protoFileDescriptors, err := unmarshalProtos[descriptorv1.FileDescriptor](protoRequest.GetFileDescriptors())
+	if err != nil {
+		return nil, err
+	}
+	fileDescriptors, err := descriptor.FileDescriptorsForProtoFileDescriptors(protoFileDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	protoOptions, err := unmarshalProtos[optionv1.Option](protoRequest.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	options, err := option.OptionsForProtoOptions(protoOptions)
+	if err != nil {
+		return nil, err
+	}
+	return newRequest(fileDescriptors, protoRequest.GetSources(), WithOptions(options))
+}
+
+func fileNameToSourceToProto(fileNameToSource map[string][]byte) *extformatv1.FormatResponse {
+	return &extformatv1.FormatResponse{
+		Sources: fileNameToSource,
+	}
+}
+
+func fileNameToSourceForProto(protoResponse *extformatv1.FormatResponse) map[string][]byte {
+	fileNameToSource := protoResponse.GetSources()
+	if fileNameToSource == nil {
+		return make(map[string][]byte)
+	}
+	return fileNameToSource
+}
+
+func unmarshalProtos[T any, P interface {
+	*T
+	proto.Message
+}](datas [][]byte) ([]P, error) {
+	messages := make([]P, len(datas))
+	for i, data := range datas {
+		message := P(new(T))
+		if err := proto.Unmarshal(data, message); err != nil {
+			return nil, err
+		}
+		messages[i] = message
+	}
+	return messages, nil
+}
@@ -16,8 +16,11 @@ package info
 
 import (
 	"context"
+	"errors"
 
 	infov1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/info/v1"
+	extinfov1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1"
+	"buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1/infov1pluginrpc"
 	"buf.build/go/bufplugin/internal/gen/buf/plugin/info/v1/v1pluginrpc"
 	"buf.build/go/bufplugin/internal/pkg/cache"
 	"pluginrpc.com/pluginrpc"
@@ -25,7 +28,9 @@ import (
 
 // Client is a client for plugin information.
 //
-// All calls with pluginrpc.Error with CodeUnimplemented if any procedure is not implemented.
+// All calls with pluginrpc.Error with CodeUnimplemented if any procedure is not implemented,
+// with the exception of the PluginInfoExtensionService. If a plugin does not implement the
+// PluginInfoExtensionService, the properties of PluginInfo transmitted over it will be empty.
 type Client interface {
 	// GetPluginInfo gets plugin information.
 	GetPluginInfo(ctx context.Context, options ...GetPluginInfoCallOption) (PluginInfo, error)
@@ -39,7 +44,15 @@ func NewClient(pluginrpcClient pluginrpc.Client, options ...ClientOption) Client
 	for _, option := range options {
 		option.applyToClient(clientOptions)
 	}
-	return newClient(pluginrpcClient, clientOptions.caching)
+	return newClient(
+		func(ctx context.Context) (v1pluginrpc.PluginInfoServiceClient, error) {
+			return getPluginInfoServiceClientForPluginrpcClient(ctx, pluginrpcClient)
+		},
+		func(ctx context.Context) (infov1pluginrpc.PluginInfoExtensionServiceClient, error) {
+			return getPluginInfoExtensionServiceClientForPluginrpcClient(ctx, pluginrpcClient)
+		},
+		clientOptions.caching,
+	)
 }
 
 // ClientOption is an option for a new Client.
@@ -63,25 +76,29 @@ type GetPluginInfoCallOption func(*getPluginInfoCallOptions)
 // *** PRIVATE ***
 
 type client struct {
-	pluginrpcClient pluginrpc.Client
-
 	caching bool
 
-	// Singleton ordering: pluginInfo -> pluginInfoServiceClient
-	pluginInfo              *cache.Singleton[PluginInfo]
-	pluginInfoServiceClient *cache.Singleton[v1pluginrpc.PluginInfoServiceClient]
+	// Singleton ordering: pluginInfo -> pluginInfoServiceClient, pluginInfoExtensionServiceClient
+	pluginInfo                       *cache.Singleton[PluginInfo]
+	pluginInfoServiceClient          *cache.Singleton[v1pluginrpc.PluginInfoServiceClient]
+	pluginInfoExtensionServiceClient *cache.Singleton[infov1pluginrpc.PluginInfoExtensionServiceClient]
 }
 
+// newClient returns a new client.
+//
+// The getPluginInfoExtensionServiceClient function returns nil if the plugin is known to not
+// implement the PluginInfoExtensionService.
 func newClient(
-	pluginrpcClient pluginrpc.Client,
+	getPluginInfoServiceClient func(context.Context) (v1pluginrpc.PluginInfoServiceClient, error),
+	getPluginInfoExtensionServiceClient func(context.Context) (infov1pluginrpc.PluginInfoExtensionServiceClient, error),
 	caching bool,
 ) *client {
 	client := &client{
-		pluginrpcClient: pluginrpcClient,
-		caching:         caching,
+		caching: caching,
 	}
 	client.pluginInfo = cache.NewSingleton(client.getPluginInfoUncached)
-	client.pluginInfoServiceClient = cache.NewSingleton(client.getPluginInfoServiceClientUncached)
+	client.pluginInfoServiceClient = cache.NewSingleton(getPluginInfoServiceClient)
+	client.pluginInfoExtensionServiceClient = cache.NewSingleton(getPluginInfoExtensionServiceClient)
 	return client
 }
 
@@ -104,11 +121,44 @@ func (c *client) getPluginInfoUncached(ctx context.Context) (PluginInfo, error)
 	if err != nil {
 		return nil, err
 	}
-	return pluginInfoForProtoPluginInfo(response.GetPluginInfo())
+	protoPluginInfoExtension, err := c.getProtoPluginInfoExtension(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pluginInfoForProtoPluginInfo(response.GetPluginInfo(), protoPluginInfoExtension)
 }
 
-func (c *client) getPluginInfoServiceClientUncached(ctx context.Context) (v1pluginrpc.PluginInfoServiceClient, error) {
-	spec, err := c.pluginrpcClient.Spec(ctx)
+// getProtoPluginInfoExtension gets the extinfov1.PluginInfoExtension, or nil if the plugin
+// does not implement the PluginInfoExtensionService.
+func (c *client) getProtoPluginInfoExtension(ctx context.Context) (*extinfov1.PluginInfoExtension, error) {
+	pluginInfoExtensionServiceClient, err := c.pluginInfoExtensionServiceClient.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if pluginInfoExtensionServiceClient == nil {
+		return nil, nil
+	}
+	response, err := pluginInfoExtensionServiceClient.GetPluginInfoExtension(
+		ctx,
+		&extinfov1.GetPluginInfoExtensionRequest{},
+	)
+	if err != nil {
+		pluginrpcError := &pluginrpc.Error{}
+		if errors.As(err, &pluginrpcError) && pluginrpcError.Code() == pluginrpc.CodeUnimplemented {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return response.GetPluginInfoExtension(), nil
+}
+
+func (*client) isClient() {}
+
+func getPluginInfoServiceClientForPluginrpcClient(
+	ctx context.Context,
+	pluginrpcClient pluginrpc.Client,
+) (v1pluginrpc.PluginInfoServiceClient, error) {
+	spec, err := pluginrpcClient.Spec(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -119,10 +169,24 @@ func (c *client) getPluginInfoServiceClientUncached(ctx context.Context) (v1plug
 			return nil, pluginrpc.NewErrorf(pluginrpc.CodeUnimplemented, "procedure unimplemented: %q", procedurePath)
 		}
 	}
-	return v1pluginrpc.NewPluginInfoServiceClient(c.pluginrpcClient)
+	return v1pluginrpc.NewPluginInfoServiceClient(pluginrpcClient)
 }
 
-func (*client) isClient() {}
+// getPluginInfoExtensionServiceClientForPluginrpcClient returns nil if the plugin
+// does not implement the PluginInfoExtensionService.
+func getPluginInfoExtensionServiceClientForPluginrpcClient(
+	ctx context.Context,
+	pluginrpcClient pluginrpc.Client,
+) (infov1pluginrpc.PluginInfoExtensionServiceClient, error) {
+	spec, err := pluginrpcClient.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if spec.ProcedureForPath(infov1pluginrpc.PluginInfoExtensionServiceGetPluginInfoExtensionPath) == nil {
+		return nil, nil
+	}
+	return infov1pluginrpc.NewPluginInfoExtensionServiceClient(pluginrpcClient)
+}
 
 type clientOptions struct {
 	caching bool
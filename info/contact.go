@@ -0,0 +1,113 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+
+	extinfov1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1"
+)
+
+// Contact is a person or team associated with a plugin, such as an author or maintainer.
+type Contact interface {
+	// Name returns the name of the person or team.
+	//
+	// Always present.
+	Name() string
+	// Email returns the email address of the person or team.
+	//
+	// Optional.
+	Email() string
+	// URL returns a URL for the person or team.
+	//
+	// Optional.
+	// Must be absolute if set.
+	URL() *url.URL
+
+	toProto() *extinfov1.Contact
+
+	isContact()
+}
+
+// *** PRIVATE ***
+
+type contact struct {
+	name  string
+	email string
+	url   *url.URL
+}
+
+func newContact(
+	name string,
+	email string,
+	url *url.URL,
+) (*contact, error) {
+	if name == "" {
+		return nil, errors.New("info.Contact: Name is empty")
+	}
+	if email != "" {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return nil, fmt.Errorf("info.Contact: invalid email %q: %w", email, err)
+		}
+	}
+	if url != nil && url.Host == "" {
+		return nil, fmt.Errorf("url %v must be absolute", url)
+	}
+	return &contact{
+		name:  name,
+		email: email,
+		url:   url,
+	}, nil
+}
+
+func (c *contact) Name() string {
+	return c.name
+}
+
+func (c *contact) Email() string {
+	return c.email
+}
+
+func (c *contact) URL() *url.URL {
+	return c.url
+}
+
+func (c *contact) toProto() *extinfov1.Contact {
+	protoContact := &extinfov1.Contact{
+		Name:  c.name,
+		Email: c.email,
+	}
+	if c.url != nil {
+		protoContact.Url = c.url.String()
+	}
+	return protoContact
+}
+
+func (*contact) isContact() {}
+
+func contactForProtoContact(protoContact *extinfov1.Contact) (Contact, error) {
+	uri, err := parseOptionalURL(protoContact.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+	return newContact(
+		protoContact.GetName(),
+		protoContact.GetEmail(),
+		uri,
+	)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"net/mail"
+	"net/url"
+)
+
+// ContactSpec is the spec for a Contact.
+type ContactSpec struct {
+	// Name is the name of the person or team.
+	//
+	// Required.
+	Name string
+	// Email is the email address of the person or team.
+	//
+	// Optional.
+	//
+	// Must be a valid RFC 5322 address if set.
+	Email string
+	// URL is a URL for the person or team, such as a profile or team page.
+	//
+	// Optional.
+	//
+	// Must be absolute if set.
+	URL string
+}
+
+// *** PRIVATE ***
+
+// Assumes that the ContactSpec is validated.
+func contactSpecToContact(contactSpec *ContactSpec) (Contact, error) {
+	var uri *url.URL
+	if contactSpec.URL != "" {
+		var err error
+		uri, err = url.Parse(contactSpec.URL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newContact(
+		contactSpec.Name,
+		contactSpec.Email,
+		uri,
+	)
+}
+
+func validateContactSpecs(fieldName string, contactSpecs []*ContactSpec) error {
+	for _, contactSpec := range contactSpecs {
+		if contactSpec == nil {
+			return newValidateSpecErrorf("%s contains a nil ContactSpec", fieldName)
+		}
+		if contactSpec.Name == "" {
+			return newValidateSpecErrorf("%s contains a ContactSpec with an empty Name", fieldName)
+		}
+		if contactSpec.Email != "" {
+			if _, err := mail.ParseAddress(contactSpec.Email); err != nil {
+				return newValidateSpecErrorf("%s contains an invalid Email %q: %w", fieldName, contactSpec.Email, err)
+			}
+		}
+		if contactSpec.URL != "" {
+			if err := validateSpecAbsoluteURL(contactSpec.URL); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
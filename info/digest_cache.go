@@ -0,0 +1,164 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+// DigestCache is a cache of PluginInfo for plugin binaries.
+//
+// PluginInfo is fetched once per plugin binary and cached by the SHA-256 digest of the
+// binary's contents. This allows callers that query many plugins, such as IDE integrations
+// at startup, to avoid spawning a plugin process more than once. As the cache is keyed by
+// digest, a plugin binary that is replaced on disk will be queried again, while identical
+// binaries at different paths will only be queried once.
+//
+// Errors are also cached by digest, including pluginrpc.Errors with CodeUnimplemented for
+// plugins that do not implement the PluginInfoService. Context errors are not cached.
+//
+// A DigestCache is safe for concurrent use.
+type DigestCache interface {
+	// GetPluginInfo gets the PluginInfo for the plugin binary at the given path.
+	//
+	// The binary will only be invoked if the digest of its contents is not already cached.
+	GetPluginInfo(ctx context.Context, programPath string) (PluginInfo, error)
+
+	isDigestCache()
+}
+
+// NewDigestCache returns a new DigestCache.
+func NewDigestCache(options ...DigestCacheOption) DigestCache {
+	digestCacheOptions := newDigestCacheOptions()
+	for _, option := range options {
+		option(digestCacheOptions)
+	}
+	return newDigestCache(digestCacheOptions.newRunner)
+}
+
+// DigestCacheOption is an option for a new DigestCache.
+type DigestCacheOption func(*digestCacheOptions)
+
+// DigestCacheWithNewRunner returns a new DigestCacheOption that sets the function used
+// to create a pluginrpc.Runner for a plugin binary.
+//
+// The default is to use pluginrpc.NewExecRunner.
+func DigestCacheWithNewRunner(newRunner func(programPath string) pluginrpc.Runner) DigestCacheOption {
+	return func(digestCacheOptions *digestCacheOptions) {
+		digestCacheOptions.newRunner = newRunner
+	}
+}
+
+// *** PRIVATE ***
+
+type digestCache struct {
+	newRunner func(programPath string) pluginrpc.Runner
+
+	digestToEntry map[string]*digestCacheEntry
+	lock          sync.Mutex
+}
+
+func newDigestCache(newRunner func(programPath string) pluginrpc.Runner) *digestCache {
+	return &digestCache{
+		newRunner:     newRunner,
+		digestToEntry: make(map[string]*digestCacheEntry),
+	}
+}
+
+func (d *digestCache) GetPluginInfo(ctx context.Context, programPath string) (PluginInfo, error) {
+	digest, err := fileSHA256Digest(programPath)
+	if err != nil {
+		return nil, err
+	}
+	d.lock.Lock()
+	entry, ok := d.digestToEntry[digest]
+	if !ok {
+		entry = &digestCacheEntry{}
+		d.digestToEntry[digest] = entry
+	}
+	d.lock.Unlock()
+	return entry.get(
+		ctx,
+		func(ctx context.Context) (PluginInfo, error) {
+			return NewClient(pluginrpc.NewClient(d.newRunner(programPath))).GetPluginInfo(ctx)
+		},
+	)
+}
+
+func (*digestCache) isDigestCache() {}
+
+// digestCacheEntry is a single entry in a digestCache.
+//
+// This differs from cache.Singleton in that context errors are not cached.
+type digestCacheEntry struct {
+	pluginInfo PluginInfo
+	err        error
+	loaded     bool
+	lock       sync.Mutex
+}
+
+func (e *digestCacheEntry) get(
+	ctx context.Context,
+	load func(context.Context) (PluginInfo, error),
+) (PluginInfo, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.loaded {
+		return e.pluginInfo, e.err
+	}
+	pluginInfo, err := load(ctx)
+	if err != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		return nil, err
+	}
+	e.pluginInfo = pluginInfo
+	e.err = err
+	e.loaded = true
+	return pluginInfo, err
+}
+
+func fileSHA256Digest(filePath string) (_ string, retErr error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		retErr = errors.Join(retErr, file.Close())
+	}()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type digestCacheOptions struct {
+	newRunner func(programPath string) pluginrpc.Runner
+}
+
+func newDigestCacheOptions() *digestCacheOptions {
+	return &digestCacheOptions{
+		newRunner: func(programPath string) pluginrpc.Runner {
+			return pluginrpc.NewExecRunner(programPath)
+		},
+	}
+}
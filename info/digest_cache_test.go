@@ -0,0 +1,87 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"buf.build/go/bufplugin/internal/gen/buf/plugin/info/v1/v1pluginrpc"
+	"github.com/stretchr/testify/require"
+	"pluginrpc.com/pluginrpc"
+)
+
+func TestDigestCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := testNewServer(t, &Spec{Documentation: "Foo."})
+	var runnerCount atomic.Int64
+	digestCache := NewDigestCache(
+		DigestCacheWithNewRunner(
+			func(string) pluginrpc.Runner {
+				runnerCount.Add(1)
+				return pluginrpc.NewServerRunner(server)
+			},
+		),
+	)
+	tempDirPath := t.TempDir()
+	programPath1 := filepath.Join(tempDirPath, "plugin1")
+	programPath2 := filepath.Join(tempDirPath, "plugin2")
+	require.NoError(t, os.WriteFile(programPath1, []byte("binary1"), 0600))
+	require.NoError(t, os.WriteFile(programPath2, []byte("binary1"), 0600))
+
+	pluginInfo, err := digestCache.GetPluginInfo(ctx, programPath1)
+	require.NoError(t, err)
+	require.Equal(t, "Foo.", pluginInfo.Documentation())
+	require.Equal(t, int64(1), runnerCount.Load())
+	// Same path.
+	_, err = digestCache.GetPluginInfo(ctx, programPath1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), runnerCount.Load())
+	// Different path, same contents.
+	_, err = digestCache.GetPluginInfo(ctx, programPath2)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), runnerCount.Load())
+	// Changed contents.
+	require.NoError(t, os.WriteFile(programPath1, []byte("binary2"), 0600))
+	_, err = digestCache.GetPluginInfo(ctx, programPath1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), runnerCount.Load())
+
+	_, err = digestCache.GetPluginInfo(ctx, filepath.Join(tempDirPath, "missing"))
+	require.Error(t, err)
+}
+
+func testNewServer(t *testing.T, spec *Spec) pluginrpc.Server {
+	pluginInfoServiceHandler, err := NewPluginInfoServiceHandler(spec)
+	require.NoError(t, err)
+	pluginrpcSpec, err := v1pluginrpc.PluginInfoServiceSpecBuilder{
+		GetPluginInfo: []pluginrpc.ProcedureOption{pluginrpc.ProcedureWithArgs("info")},
+	}.Build()
+	require.NoError(t, err)
+	serverRegistrar := pluginrpc.NewServerRegistrar()
+	handler := pluginrpc.NewHandler(pluginrpcSpec)
+	v1pluginrpc.RegisterPluginInfoServiceServer(
+		serverRegistrar,
+		v1pluginrpc.NewPluginInfoServiceServer(handler, pluginInfoServiceHandler),
+	)
+	server, err := pluginrpc.NewServer(pluginrpcSpec, serverRegistrar)
+	require.NoError(t, err)
+	return server
+}
@@ -0,0 +1,238 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	markdownATXHeadingRegexp     = regexp.MustCompile(`^ {0,3}#{1,6}(\s+|$)`)
+	markdownATXClosingRegexp     = regexp.MustCompile(`\s+#+\s*$`)
+	markdownSetextUnderline      = regexp.MustCompile(`^ {0,3}(=+|-+)\s*$`)
+	markdownThematicBreakRegexp  = regexp.MustCompile(`^ {0,3}((\*\s*){3,}|(-\s*){3,}|(_\s*){3,})$`)
+	markdownFenceRegexp          = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+	markdownBlockQuoteRegexp     = regexp.MustCompile(`^ {0,3}>\s?`)
+	markdownBulletListItemRegexp = regexp.MustCompile(`^(\s*)[*+-]\s+`)
+	markdownImageRegexp          = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLinkRegexp           = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	markdownReferenceLinkRegexp  = regexp.MustCompile(`\[([^\]]*)\]\[[^\]]*\]`)
+	markdownAutolinkRegexp       = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	markdownHTMLTagRegexp        = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	markdownCodeSpanRegexp       = regexp.MustCompile("`+([^`]+)`+")
+	markdownStrongRegexp         = regexp.MustCompile(`(\*\*|__)([^*_]+)(\*\*|__)`)
+	markdownEmphasisStarRegexp   = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	markdownEmphasisUnderRegexp  = regexp.MustCompile(`(^|\W)_([^_\s][^_]*)_(\W|$)`)
+	markdownStrikethroughRegexp  = regexp.MustCompile(`~~([^~]+)~~`)
+	markdownEscapeRegexp         = regexp.MustCompile("\\\\([!\"#$%&'()*+,\\-./:;<=>?@\\[\\\\\\]^_`{|}~])")
+)
+
+// DocumentationToPlainText renders CommonMark documentation, such as Spec.Documentation,
+// as plain text.
+//
+// Markup is removed: headings, emphasis, code spans, and HTML tags are reduced to
+// their text, links and images are reduced to their text and alt text respectively,
+// and the contents of code blocks are kept verbatim. Paragraph and line structure
+// is otherwise preserved.
+//
+// This is not a complete CommonMark renderer, but handles the constructs commonly
+// used in plugin documentation.
+func DocumentationToPlainText(documentation string) string {
+	var lines []string
+	for _, block := range splitDocumentationBlocks(documentation) {
+		blockLines := block.plainTextLines()
+		if len(blockLines) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, blockLines...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DocumentationFirstParagraph returns the first paragraph of CommonMark documentation,
+// such as Spec.Documentation, rendered as plain text on a single line.
+//
+// Headings, code blocks, and thematic breaks are skipped. Returns empty if there is
+// no paragraph.
+//
+// This is used as the fallback for Spec.ShortDocumentation.
+func DocumentationFirstParagraph(documentation string) string {
+	for _, block := range splitDocumentationBlocks(documentation) {
+		if block.isParagraph() {
+			return strings.Join(block.plainTextLines(), " ")
+		}
+	}
+	return ""
+}
+
+// *** PRIVATE ***
+
+type documentationBlock struct {
+	lines  []string
+	isCode bool
+}
+
+func (b documentationBlock) isParagraph() bool {
+	if b.isCode {
+		return false
+	}
+	for _, line := range b.lines {
+		if !markdownATXHeadingRegexp.MatchString(line) && !markdownThematicBreakRegexp.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b documentationBlock) plainTextLines() []string {
+	if b.isCode {
+		return b.lines
+	}
+	plainTextLines := make([]string, 0, len(b.lines))
+	for i, line := range b.lines {
+		switch {
+		case markdownATXHeadingRegexp.MatchString(line):
+			line = markdownATXHeadingRegexp.ReplaceAllString(line, "")
+			line = markdownATXClosingRegexp.ReplaceAllString(line, "")
+		case i > 0 && markdownSetextUnderline.MatchString(line):
+			continue
+		case markdownThematicBreakRegexp.MatchString(line):
+			continue
+		}
+		line = markdownBlockQuoteRegexp.ReplaceAllString(line, "")
+		line = markdownBulletListItemRegexp.ReplaceAllString(line, "$1- ")
+		plainTextLines = append(plainTextLines, documentationInlineToPlainText(strings.TrimRight(line, " \t")))
+	}
+	return plainTextLines
+}
+
+// splitDocumentationBlocks splits documentation into blocks separated by blank lines,
+// keeping fenced code blocks intact.
+func splitDocumentationBlocks(documentation string) []documentationBlock {
+	documentation = strings.ReplaceAll(documentation, "\r\n", "\n")
+	var blocks []documentationBlock
+	var current documentationBlock
+	var fence string
+	flush := func() {
+		if len(current.lines) > 0 {
+			blocks = append(blocks, current)
+		}
+		current = documentationBlock{}
+	}
+	for _, line := range strings.Split(documentation, "\n") {
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+				flush()
+				continue
+			}
+			current.lines = append(current.lines, line)
+			continue
+		}
+		if match := markdownFenceRegexp.FindStringSubmatch(line); match != nil {
+			flush()
+			fence = match[1]
+			current.isCode = true
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		current.lines = append(current.lines, line)
+	}
+	flush()
+	return blocks
+}
+
+func documentationInlineToPlainText(line string) string {
+	// Extract code spans and escaped characters first so that they are kept verbatim. They
+	// are replaced with numbered placeholders delimited by runes that do not occur in the
+	// line, so that the placeholders cannot collide with the text of the line.
+	placeholderStart, placeholderEnd, ok := documentationPlaceholderRunes(line)
+	if !ok {
+		return line
+	}
+	var replacerOldNew []string
+	addPlaceholder := func(verbatim string) string {
+		placeholder := string(placeholderStart) + strconv.Itoa(len(replacerOldNew)/2) + string(placeholderEnd)
+		replacerOldNew = append(replacerOldNew, placeholder, verbatim)
+		return placeholder
+	}
+	line = markdownCodeSpanRegexp.ReplaceAllStringFunc(
+		line,
+		func(codeSpan string) string {
+			return addPlaceholder(strings.TrimSpace(markdownCodeSpanRegexp.FindStringSubmatch(codeSpan)[1]))
+		},
+	)
+	line = markdownEscapeRegexp.ReplaceAllStringFunc(
+		line,
+		func(escape string) string {
+			return addPlaceholder(escape[1:])
+		},
+	)
+	line = markdownImageRegexp.ReplaceAllString(line, "$1")
+	line = markdownLinkRegexp.ReplaceAllString(line, "$1")
+	line = markdownReferenceLinkRegexp.ReplaceAllString(line, "$1")
+	line = markdownAutolinkRegexp.ReplaceAllString(line, "$1")
+	line = markdownHTMLTagRegexp.ReplaceAllString(line, "")
+	line = markdownStrongRegexp.ReplaceAllString(line, "$2")
+	line = markdownEmphasisStarRegexp.ReplaceAllString(line, "$1")
+	line = markdownEmphasisUnderRegexp.ReplaceAllString(line, "$1$2$3")
+	line = markdownStrikethroughRegexp.ReplaceAllString(line, "$1")
+	if len(replacerOldNew) == 0 {
+		return line
+	}
+	// Placeholders that were removed with their surrounding markup, such as those in the
+	// destination of a link, are not restored.
+	return strings.NewReplacer(replacerOldNew...).Replace(line)
+}
+
+// documentationPlaceholderRunes returns two distinct runes from the Unicode private use
+// areas that do not occur in the line.
+//
+// Returns false if the line contains every such rune.
+func documentationPlaceholderRunes(line string) (rune, rune, bool) {
+	var runes []rune
+	for _, privateUseArea := range [][2]rune{{0xE000, 0xF8FF}, {0xF0000, 0xFFFFD}, {0x100000, 0x10FFFD}} {
+		for r := privateUseArea[0]; r <= privateUseArea[1]; r++ {
+			if strings.ContainsRune(line, r) {
+				continue
+			}
+			if runes = append(runes, r); len(runes) == 2 {
+				return runes[0], runes[1], true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// validateDocumentation validates that the documentation is acceptable CommonMark.
+//
+// Any sequence of characters is a valid CommonMark document, so this only verifies
+// that the documentation is valid UTF-8.
+func validateDocumentation(documentation string) error {
+	if !utf8.ValidString(documentation) {
+		return errors.New("must be valid UTF-8")
+	}
+	return nil
+}
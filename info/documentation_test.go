@@ -0,0 +1,97 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentationToPlainText(t *testing.T) {
+	t.Parallel()
+
+	testDocumentationToPlainText(t, "", "")
+	testDocumentationToPlainText(t, "Hello world.", "Hello world.")
+	testDocumentationToPlainText(
+		t,
+		"# Title #\n\nSome **bold**, *italic*, and `code_span` text with a [link](https://buf.build).",
+		"Title\n\nSome bold, italic, and code_span text with a link.",
+	)
+	testDocumentationToPlainText(
+		t,
+		"Title\n=====\n\n* one\n+ two\n\n> quoted\n\n---\n\nsnake_case_name stays \\*escaped\\*",
+		"Title\n\n- one\n- two\n\nquoted\n\nsnake_case_name stays *escaped*",
+	)
+	testDocumentationToPlainText(
+		t,
+		"Example:\n\n```yaml\nkey: **value**\n\nother: 1\n```\n\nDone.",
+		"Example:\n\nkey: **value**\n\nother: 1\n\nDone.",
+	)
+	testDocumentationToPlainText(t, "See <https://buf.build> and ![logo](logo.png).", "See https://buf.build and logo.")
+	testDocumentationToPlainText(
+		t,
+		"A [link](https://buf.build/\\_x) then \\*one\\* and `two`.",
+		"A link then *one* and two.",
+	)
+	testDocumentationToPlainText(
+		t,
+		"Control \x00 and \x01, `code` and \\*escape\\*, \ue000 and \ue001.",
+		"Control \x00 and \x01, code and *escape*, \ue000 and \ue001.",
+	)
+}
+
+func TestDocumentationFirstParagraph(t *testing.T) {
+	t.Parallel()
+
+	testDocumentationFirstParagraph(t, "", "")
+	testDocumentationFirstParagraph(t, "# Title", "")
+	testDocumentationFirstParagraph(
+		t,
+		"# Title\n\n```\ncode\n```\n\nChecks that *all* fields\nare documented.\n\nMore details.",
+		"Checks that all fields are documented.",
+	)
+}
+
+func TestPluginInfoShortDocumentation(t *testing.T) {
+	t.Parallel()
+
+	pluginInfo, err := NewPluginInfoForSpec(&Spec{Documentation: "# Plugin\n\nA **plugin**.\n\nDetails."})
+	require.NoError(t, err)
+	require.Equal(t, "A plugin.", pluginInfo.ShortDocumentation())
+	pluginInfo, err = NewPluginInfoForSpec(
+		&Spec{
+			Documentation:      "A plugin.",
+			ShortDocumentation: "Short.",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "Short.", pluginInfo.ShortDocumentation())
+	_, err = NewPluginInfoForSpec(&Spec{ShortDocumentation: "line one\nline two"})
+	require.Error(t, err)
+	_, err = NewPluginInfoForSpec(&Spec{Documentation: "invalid \xff"})
+	require.Error(t, err)
+	pluginInfo, err = NewPluginInfoForSpec(&Spec{Documentation: "Contains \x00 and `\x01`."})
+	require.NoError(t, err)
+	require.Equal(t, "Contains \x00 and \x01.", pluginInfo.ShortDocumentation())
+}
+
+func testDocumentationToPlainText(t *testing.T, documentation string, expected string) {
+	require.Equal(t, expected, DocumentationToPlainText(documentation))
+}
+
+func testDocumentationFirstParagraph(t *testing.T, documentation string, expected string) {
+	require.Equal(t, expected, DocumentationFirstParagraph(documentation))
+}
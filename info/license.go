@@ -18,8 +18,10 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	infov1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/info/v1"
+	extinfov1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1"
 	"buf.build/go/spdx"
 )
 
@@ -35,6 +37,24 @@ type License interface {
 	// Will be a valid SPDX license ID contained within https://spdx.org/licenses
 	// if present.
 	SPDXLicenseID() string
+	// SPDXLicenseExpression returns the SPDX license expression, such as "MIT OR Apache-2.0".
+	//
+	// Optional.
+	//
+	// Will be a valid, normalized SPDX license expression if present. If SPDXLicenseID is
+	// present, this will be equal to SPDXLicenseID. SPDXLicenseID will only be set if the
+	// expression consists of a single license ID from https://spdx.org/licenses.
+	//
+	// Only the SPDXLicenseID part of an expression is transmitted over the PluginInfoService,
+	// the expression itself is transmitted over the PluginInfoExtensionService.
+	SPDXLicenseExpression() string
+	// Component returns the component of the plugin that this License applies to.
+	//
+	// Empty for the License of the plugin itself. Set for the additional Licenses of
+	// components that are licensed differently, such as vendored code.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	Component() string
 	// Text returns the raw text of the License.
 	//
 	// At most one of Text and URL will be set.
@@ -46,6 +66,7 @@ type License interface {
 	URL() *url.URL
 
 	toProto() *infov1.License
+	toProtoExtension() *extinfov1.License
 
 	isLicense()
 }
@@ -53,17 +74,25 @@ type License interface {
 // *** PRIVATE ***
 
 type license struct {
-	spdxLicenseID string
-	text          string
-	url           *url.URL
+	spdxLicenseID         string
+	spdxLicenseExpression string
+	component             string
+	text                  string
+	url                   *url.URL
 }
 
 func newLicense(
 	// Case-insensitive.
 	spdxLicenseID string,
+	// Case-insensitive.
+	spdxLicenseExpression string,
+	component string,
 	text string,
 	url *url.URL,
 ) (*license, error) {
+	if spdxLicenseID != "" && spdxLicenseExpression != "" {
+		return nil, errors.New("info.License: both SPDX license ID and SPDX license expression are present")
+	}
 	if spdxLicenseID != "" {
 		spdxLicense, ok := spdx.LicenseForID(spdxLicenseID)
 		if !ok {
@@ -71,6 +100,17 @@ func newLicense(
 		}
 		// Case-sensitive.
 		spdxLicenseID = spdxLicense.ID
+		spdxLicenseExpression = spdxLicense.ID
+	} else if spdxLicenseExpression != "" {
+		var err error
+		spdxLicenseExpression, err = normalizeSPDXLicenseExpression(spdxLicenseExpression)
+		if err != nil {
+			return nil, err
+		}
+		// Only a single listed license ID can be represented as an SPDX license ID.
+		if spdxLicense, ok := spdx.LicenseForID(spdxLicenseExpression); ok {
+			spdxLicenseID = spdxLicense.ID
+		}
 	}
 	if text != "" && url != nil {
 		return nil, errors.New("info.License: both text and url are present")
@@ -79,9 +119,11 @@ func newLicense(
 		return nil, fmt.Errorf("url %v must be absolute", url)
 	}
 	return &license{
-		spdxLicenseID: spdxLicenseID,
-		text:          text,
-		url:           url,
+		spdxLicenseID:         spdxLicenseID,
+		spdxLicenseExpression: spdxLicenseExpression,
+		component:             component,
+		text:                  text,
+		url:                   url,
 	}, nil
 }
 
@@ -89,6 +131,14 @@ func (l *license) SPDXLicenseID() string {
 	return l.spdxLicenseID
 }
 
+func (l *license) SPDXLicenseExpression() string {
+	return l.spdxLicenseExpression
+}
+
+func (l *license) Component() string {
+	return l.component
+}
+
 func (l *license) Text() string {
 	return l.text
 }
@@ -116,25 +166,66 @@ func (l *license) toProto() *infov1.License {
 	return protoLicense
 }
 
+func (l *license) toProtoExtension() *extinfov1.License {
+	protoLicense := &extinfov1.License{
+		Component:             l.component,
+		SpdxLicenseExpression: l.spdxLicenseExpression,
+	}
+	if l.text != "" {
+		protoLicense.Source = &extinfov1.License_Text{
+			Text: l.text,
+		}
+	} else if l.url != nil {
+		protoLicense.Source = &extinfov1.License_Url{
+			Url: l.url.String(),
+		}
+	}
+	return protoLicense
+}
+
 func (*license) isLicense() {}
 
+// licenseForProtoLicense returns the License for the infov1.License and the SPDX license
+// expression transmitted over the PluginInfoExtensionService, which may be empty.
+//
 // Need to keep as pointer for Go nil is not nil problem.
-func licenseForProtoLicense(protoLicense *infov1.License) (*license, error) {
+func licenseForProtoLicense(protoLicense *infov1.License, spdxLicenseExpression string) (*license, error) {
 	if protoLicense == nil {
 		return nil, nil
 	}
-	text := protoLicense.GetText()
-	var uri *url.URL
-	if urlString := protoLicense.GetUrl(); urlString != "" {
-		var err error
-		uri, err = url.Parse(urlString)
-		if err != nil {
-			return nil, err
-		}
+	uri, err := parseOptionalURL(protoLicense.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+	spdxLicenseID := protoLicense.GetSpdxLicenseId()
+	if spdxLicenseExpression == "" {
+		return newLicense(spdxLicenseID, "", "", protoLicense.GetText(), uri)
+	}
+	license, err := newLicense("", spdxLicenseExpression, "", protoLicense.GetText(), uri)
+	if err != nil {
+		return nil, err
+	}
+	if spdxLicenseID != "" && !strings.EqualFold(spdxLicenseID, license.spdxLicenseID) {
+		return nil, fmt.Errorf("SPDX license ID %q does not match SPDX license expression %q", spdxLicenseID, spdxLicenseExpression)
+	}
+	return license, nil
+}
+
+// licenseForProtoExtensionLicense returns the License of a component for the given
+// extinfov1.License.
+func licenseForProtoExtensionLicense(protoLicense *extinfov1.License) (License, error) {
+	if protoLicense.GetComponent() == "" {
+		return nil, errors.New("additional license has an empty component")
+	}
+	uri, err := parseOptionalURL(protoLicense.GetUrl())
+	if err != nil {
+		return nil, err
 	}
 	return newLicense(
-		protoLicense.GetSpdxLicenseId(),
-		text,
+		"",
+		protoLicense.GetSpdxLicenseExpression(),
+		protoLicense.GetComponent(),
+		protoLicense.GetText(),
 		uri,
 	)
 }
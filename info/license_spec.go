@@ -0,0 +1,168 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"net/url"
+
+	"buf.build/go/spdx"
+)
+
+// LicenseSpec is the spec for an additional License that applies to a component of a plugin.
+//
+// This is used for components that are licensed differently from the plugin itself,
+// such as vendored code.
+type LicenseSpec struct {
+	// Component is the component of the plugin that the License applies to.
+	//
+	// Required.
+	Component string
+	// SPDXLicenseID is the SDPX ID of the License.
+	//
+	// Optional.
+	//
+	// This must be present in the SPDX license list, and can be specified in any case.
+	// At most one of SPDXLicenseID and SPDXLicenseExpression can be set.
+	SPDXLicenseID string
+	// SPDXLicenseExpression is an SPDX license expression for the License.
+	//
+	// Optional.
+	//
+	// See Spec.SPDXLicenseExpression.
+	// At most one of SPDXLicenseID and SPDXLicenseExpression can be set.
+	SPDXLicenseExpression string
+	// LicenseText is the raw text of the License.
+	//
+	// Optional.
+	//
+	// Zero or one of LicenseText and LicenseURL must be set.
+	LicenseText string
+	// LicenseURL is the URL that contains the License.
+	//
+	// Optional.
+	//
+	// Zero or one of LicenseText and LicenseURL must be set.
+	// Must be absolute if set.
+	LicenseURL string
+}
+
+// *** PRIVATE ***
+
+// Assumes that the license fields are validated.
+//
+// Returns nil if no license fields are set.
+func licenseFieldsToLicense(
+	spdxLicenseID string,
+	spdxLicenseExpression string,
+	component string,
+	licenseText string,
+	licenseURL string,
+) (*license, error) {
+	if spdxLicenseID == "" && spdxLicenseExpression == "" && licenseText == "" && licenseURL == "" {
+		return nil, nil
+	}
+	var licenseURI *url.URL
+	if licenseURL != "" {
+		var err error
+		licenseURI, err = url.Parse(licenseURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newLicense(
+		spdxLicenseID,
+		spdxLicenseExpression,
+		component,
+		licenseText,
+		licenseURI,
+	)
+}
+
+// Assumes that the LicenseSpec is validated.
+func licenseSpecToLicense(licenseSpec *LicenseSpec) (License, error) {
+	license, err := licenseFieldsToLicense(
+		licenseSpec.SPDXLicenseID,
+		licenseSpec.SPDXLicenseExpression,
+		licenseSpec.Component,
+		licenseSpec.LicenseText,
+		licenseSpec.LicenseURL,
+	)
+	// Go nil is not nil problem.
+	if err != nil || license == nil {
+		return nil, err
+	}
+	return license, nil
+}
+
+func validateLicenseFields(
+	spdxLicenseID string,
+	spdxLicenseExpression string,
+	licenseText string,
+	licenseURL string,
+) error {
+	if spdxLicenseID != "" && spdxLicenseExpression != "" {
+		return newValidateSpecError("only one of SPDXLicenseID and SPDXLicenseExpression can be set")
+	}
+	if spdxLicenseID != "" {
+		if _, ok := spdx.LicenseForID(spdxLicenseID); !ok {
+			return newValidateSpecErrorf("invalid SPDXLicenseID: %q", spdxLicenseID)
+		}
+	}
+	if spdxLicenseExpression != "" {
+		if _, err := parseSPDXLicenseExpression(spdxLicenseExpression); err != nil {
+			return newValidateSpecErrorf("invalid SPDXLicenseExpression: %w", err)
+		}
+	}
+	if licenseText != "" && licenseURL != "" {
+		return newValidateSpecError("only one of LicenseText and LicenseURL can be set")
+	}
+	if licenseURL != "" {
+		if err := validateSpecAbsoluteURL(licenseURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLicenseSpecs(licenseSpecs []*LicenseSpec) error {
+	components := make(map[string]struct{}, len(licenseSpecs))
+	for _, licenseSpec := range licenseSpecs {
+		if licenseSpec == nil {
+			return newValidateSpecError("AdditionalLicenses contains a nil LicenseSpec")
+		}
+		if licenseSpec.Component == "" {
+			return newValidateSpecError("AdditionalLicenses contains a LicenseSpec with an empty Component")
+		}
+		if _, ok := components[licenseSpec.Component]; ok {
+			return newValidateSpecErrorf("AdditionalLicenses contains duplicate Component %q", licenseSpec.Component)
+		}
+		components[licenseSpec.Component] = struct{}{}
+		if licenseSpec.SPDXLicenseID == "" &&
+			licenseSpec.SPDXLicenseExpression == "" &&
+			licenseSpec.LicenseText == "" &&
+			licenseSpec.LicenseURL == "" {
+			return newValidateSpecErrorf("AdditionalLicenses contains a LicenseSpec with no license for Component %q", licenseSpec.Component)
+		}
+		if err := validateLicenseFields(
+			licenseSpec.SPDXLicenseID,
+			licenseSpec.SPDXLicenseExpression,
+			licenseSpec.LicenseText,
+			licenseSpec.LicenseURL,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// ReadLicenseText reads the file at the given path from the fs.FS, and returns its
+// normalized content for use as Spec.LicenseText or LicenseSpec.LicenseText.
+//
+// This is typically used with an embed.FS, so that a LICENSE file can be the single
+// source of truth for the license of a plugin:
+//
+//	//go:embed LICENSE
+//	var licenseFS embed.FS
+//
+//	var spec = &check.Spec{
+//		Info: &info.Spec{
+//			SPDXLicenseID: "apache-2.0",
+//			LicenseText:   info.MustReadLicenseText(licenseFS, "LICENSE"),
+//		},
+//	}
+//
+// CRLF line endings are converted to LF, trailing whitespace is removed from every line,
+// and leading and trailing blank lines are removed.
+//
+// Returns error if the file cannot be read, or if the normalized content is empty.
+func ReadLicenseText(fsys fs.FS, path string) (string, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return "", err
+	}
+	licenseText := normalizeLicenseText(string(data))
+	if licenseText == "" {
+		return "", fmt.Errorf("license file %q is empty", path)
+	}
+	return licenseText, nil
+}
+
+// MustReadLicenseText calls ReadLicenseText, and panics on error.
+//
+// This is intended for initializing package-level Specs from an embed.FS, where
+// an error indicates a programming error.
+func MustReadLicenseText(fsys fs.FS, path string) string {
+	licenseText, err := ReadLicenseText(fsys, path)
+	if err != nil {
+		panic(err)
+	}
+	return licenseText
+}
+
+// *** PRIVATE ***
+
+func normalizeLicenseText(licenseText string) string {
+	licenseText = strings.ReplaceAll(licenseText, "\r\n", "\n")
+	lines := strings.Split(licenseText, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r\f\v")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
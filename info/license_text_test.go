@@ -0,0 +1,44 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLicenseText(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"LICENSE": &fstest.MapFile{
+			Data: []byte("\r\n\r\nMIT License  \r\n\r\nCopyright (c) Foo\t\r\n\r\n"),
+		},
+		"EMPTY": &fstest.MapFile{
+			Data: []byte(" \r\n\n\t\n"),
+		},
+	}
+	licenseText, err := ReadLicenseText(fsys, "LICENSE")
+	require.NoError(t, err)
+	require.Equal(t, "MIT License\n\nCopyright (c) Foo", licenseText)
+	require.Equal(t, licenseText, MustReadLicenseText(fsys, "LICENSE"))
+	_, err = ReadLicenseText(fsys, "EMPTY")
+	require.Error(t, err)
+	_, err = ReadLicenseText(fsys, "MISSING")
+	require.Error(t, err)
+	require.Panics(t, func() { MustReadLicenseText(fsys, "MISSING") })
+}
@@ -15,23 +15,130 @@
 package info
 
 import (
+	"fmt"
 	"net/url"
+	"slices"
 
 	infov1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/info/v1"
+	extinfov1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1"
+	"buf.build/go/bufplugin/internal/pkg/xslices"
 )
 
 // PluginInfo contains information about a plugin.
+//
+// Some properties are not part of the v1 PluginInfoService protocol. Some of these
+// are transmitted over the PluginInfoExtensionService instead, and will be empty on
+// PluginInfos returned from a Client for a plugin that does not implement it. The
+// others are only populated on PluginInfos created with NewPluginInfoForSpec, and will
+// be empty on PluginInfos returned from a Client. Such properties are noted below.
 type PluginInfo interface {
 	// Documentation returns the documentation of the plugin.
 	//
 	// Optional.
+	//
+	// This is CommonMark, see DocumentationToPlainText.
 	Documentation() string
+	// ShortDocumentation returns a single-line plain text summary of the plugin.
+	//
+	// Optional.
+	//
+	// If no ShortDocumentation was given on the Spec, this is the first paragraph
+	// of Documentation.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	ShortDocumentation() string
+	// URL returns the URL of the plugin's homepage or source repository.
+	//
+	// Optional.
+	//
+	// Not transmitted over the PluginInfoService.
+	URL() *url.URL
 	// License returns the license of the plugin.
 	//
 	// Optional.
 	License() License
+	// Version returns the version of the plugin.
+	//
+	// Optional.
+	//
+	// Will be a valid semantic version if present.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	Version() string
+	// Authors returns the people or teams that wrote the plugin.
+	//
+	// Optional.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	Authors() []Contact
+	// Maintainers returns the people or teams that currently maintain the plugin.
+	//
+	// Optional.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	Maintainers() []Contact
+	// MinimumBufVersion returns the minimum version of buf that the plugin requires.
+	//
+	// Optional.
+	//
+	// Will be a valid semantic version if present.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	MinimumBufVersion() string
+	// MinimumProtocolVersion returns the minimum Bufplugin protocol version that the plugin requires.
+	//
+	// Zero if not set.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	MinimumProtocolVersion() int
+	// Keywords returns the short tags that categorize the plugin.
+	//
+	// Optional.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	Keywords() []string
+	// AdditionalLicenses returns the Licenses of components of the plugin that are
+	// licensed differently from the plugin itself.
+	//
+	// Each License will have a Component set.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	AdditionalLicenses() []License
+	// Deprecated returns whether or not the plugin is deprecated.
+	//
+	// Clients should warn when a deprecated plugin is used, including DeprecationMessage
+	// if present.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	Deprecated() bool
+	// DeprecationMessage returns a message that tells users of a deprecated plugin what to
+	// migrate to.
+	//
+	// Optional. Will only be present if Deprecated is true.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	DeprecationMessage() string
+	// SourceRevision returns the version control revision that the plugin was built from.
+	//
+	// Optional.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	SourceRevision() SourceRevision
+	// ChangelogURL returns the URL of the changelog or release notes of the plugin.
+	//
+	// Optional.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	ChangelogURL() *url.URL
+	// SupportURL returns the URL where users can get support for the plugin or report bugs.
+	//
+	// Optional.
+	//
+	// Transmitted over the PluginInfoExtensionService.
+	SupportURL() *url.URL
 
 	toProto() *infov1.PluginInfo
+	toProtoExtension() *extinfov1.PluginInfoExtension
 
 	isPluginInfo()
 }
@@ -42,26 +149,64 @@ func NewPluginInfoForSpec(spec *Spec) (PluginInfo, error) {
 		return nil, err
 	}
 
-	var license *license
-	if spec.SPDXLicenseID != "" || spec.LicenseText != "" || spec.LicenseURL != "" {
-		var licenseURI *url.URL
-		var err error
-		if spec.LicenseURL != "" {
-			licenseURI, err = url.Parse(spec.LicenseURL)
-			if err != nil {
-				return nil, err
-			}
-		}
-		license, err = newLicense(
-			spec.SPDXLicenseID,
-			spec.LicenseText,
-			licenseURI,
-		)
-		if err != nil {
-			return nil, err
-		}
+	license, err := licenseFieldsToLicense(
+		spec.SPDXLicenseID,
+		spec.SPDXLicenseExpression,
+		"",
+		spec.LicenseText,
+		spec.LicenseURL,
+	)
+	if err != nil {
+		return nil, err
+	}
+	additionalLicenses, err := xslices.MapError(spec.AdditionalLicenses, licenseSpecToLicense)
+	if err != nil {
+		return nil, err
+	}
+	authors, err := xslices.MapError(spec.Authors, contactSpecToContact)
+	if err != nil {
+		return nil, err
 	}
-	return newPluginInfo(spec.Documentation, license)
+	maintainers, err := xslices.MapError(spec.Maintainers, contactSpecToContact)
+	if err != nil {
+		return nil, err
+	}
+	sourceRevision, err := sourceRevisionSpecToSourceRevision(spec.SourceRevision)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := parseOptionalURL(spec.URL)
+	if err != nil {
+		return nil, err
+	}
+	changelogURL, err := parseOptionalURL(spec.ChangelogURL)
+	if err != nil {
+		return nil, err
+	}
+	supportURL, err := parseOptionalURL(spec.SupportURL)
+	if err != nil {
+		return nil, err
+	}
+	return newPluginInfo(
+		spec.Documentation,
+		license,
+		extendedProperties{
+			shortDocumentation:     spec.ShortDocumentation,
+			version:                specVersion(spec),
+			authors:                authors,
+			maintainers:            maintainers,
+			minimumBufVersion:      spec.MinimumBufVersion,
+			minimumProtocolVersion: spec.MinimumProtocolVersion,
+			keywords:               slices.Clone(spec.Keywords),
+			additionalLicenses:     additionalLicenses,
+			deprecated:             spec.Deprecated,
+			deprecationMessage:     spec.DeprecationMessage,
+			sourceRevision:         sourceRevision,
+			url:                    uri,
+			changelogURL:           changelogURL,
+			supportURL:             supportURL,
+		},
+	)
 }
 
 // *** PRIVATE ***
@@ -70,15 +215,42 @@ type pluginInfo struct {
 	documentation string
 	// Need to keep as pointer for Go nil is not nil problem.
 	license *license
+
+	extendedProperties
+}
+
+// extendedProperties are the properties of a PluginInfo that are not part of the
+// v1 PluginInfoService protocol.
+//
+// These are populated from a Spec, or from the PluginInfoExtensionService for the
+// properties that it transmits.
+type extendedProperties struct {
+	shortDocumentation     string
+	version                string
+	authors                []Contact
+	maintainers            []Contact
+	minimumBufVersion      string
+	minimumProtocolVersion int
+	keywords               []string
+	additionalLicenses     []License
+	deprecated             bool
+	deprecationMessage     string
+	// Need to keep as pointer for Go nil is not nil problem.
+	sourceRevision *sourceRevision
+	url            *url.URL
+	changelogURL   *url.URL
+	supportURL     *url.URL
 }
 
 func newPluginInfo(
 	documentation string,
 	license *license,
+	extendedProperties extendedProperties,
 ) (*pluginInfo, error) {
 	return &pluginInfo{
-		documentation: documentation,
-		license:       license,
+		documentation:      documentation,
+		license:            license,
+		extendedProperties: extendedProperties,
 	}, nil
 }
 
@@ -86,6 +258,13 @@ func (p *pluginInfo) Documentation() string {
 	return p.documentation
 }
 
+func (p *pluginInfo) ShortDocumentation() string {
+	if p.shortDocumentation != "" {
+		return p.shortDocumentation
+	}
+	return DocumentationFirstParagraph(p.documentation)
+}
+
 func (p *pluginInfo) License() License {
 	// Go nil is not nil problem.
 	if p.license == nil {
@@ -94,6 +273,62 @@ func (p *pluginInfo) License() License {
 	return p.license
 }
 
+func (p *pluginInfo) Version() string {
+	return p.version
+}
+
+func (p *pluginInfo) Authors() []Contact {
+	return slices.Clone(p.authors)
+}
+
+func (p *pluginInfo) Maintainers() []Contact {
+	return slices.Clone(p.maintainers)
+}
+
+func (p *pluginInfo) MinimumBufVersion() string {
+	return p.minimumBufVersion
+}
+
+func (p *pluginInfo) MinimumProtocolVersion() int {
+	return p.minimumProtocolVersion
+}
+
+func (p *pluginInfo) Keywords() []string {
+	return slices.Clone(p.keywords)
+}
+
+func (p *pluginInfo) AdditionalLicenses() []License {
+	return slices.Clone(p.additionalLicenses)
+}
+
+func (p *pluginInfo) Deprecated() bool {
+	return p.deprecated
+}
+
+func (p *pluginInfo) DeprecationMessage() string {
+	return p.deprecationMessage
+}
+
+func (p *pluginInfo) SourceRevision() SourceRevision {
+	// Go nil is not nil problem.
+	if p.sourceRevision == nil {
+		return nil
+	}
+	return p.sourceRevision
+}
+
+func (p *pluginInfo) URL() *url.URL {
+	return p.url
+}
+
+func (p *pluginInfo) ChangelogURL() *url.URL {
+	return p.changelogURL
+}
+
+func (p *pluginInfo) SupportURL() *url.URL {
+	return p.supportURL
+}
+
 func (p *pluginInfo) toProto() *infov1.PluginInfo {
 	return &infov1.PluginInfo{
 		Documentation: p.documentation,
@@ -101,15 +336,116 @@ func (p *pluginInfo) toProto() *infov1.PluginInfo {
 	}
 }
 
+func (p *pluginInfo) toProtoExtension() *extinfov1.PluginInfoExtension {
+	protoPluginInfoExtension := &extinfov1.PluginInfoExtension{
+		Version:           p.version,
+		Authors:           xslices.Map(p.authors, Contact.toProto),
+		Maintainers:       xslices.Map(p.maintainers, Contact.toProto),
+		MinimumBufVersion: p.minimumBufVersion,
+		// Validated to be between 0 and ProtocolVersion.
+		MinimumProtocolVersion: uint32(p.minimumProtocolVersion), //nolint:gosec
+		Keywords:               p.keywords,
+		Deprecated:             p.deprecated,
+		DeprecationMessage:     p.deprecationMessage,
+		SourceRevision:         p.sourceRevision.toProto(),
+		AdditionalLicenses:     xslices.Map(p.additionalLicenses, License.toProtoExtension),
+		ShortDocumentation:     p.shortDocumentation,
+	}
+	if p.license != nil {
+		protoPluginInfoExtension.SpdxLicenseExpression = p.license.spdxLicenseExpression
+	}
+	if p.changelogURL != nil {
+		protoPluginInfoExtension.ChangelogUrl = p.changelogURL.String()
+	}
+	if p.supportURL != nil {
+		protoPluginInfoExtension.SupportUrl = p.supportURL.String()
+	}
+	return protoPluginInfoExtension
+}
+
 func (*pluginInfo) isPluginInfo() {}
 
-func pluginInfoForProtoPluginInfo(protoPluginInfo *infov1.PluginInfo) (PluginInfo, error) {
+// parseOptionalURL parses the URL if it is not empty.
+func parseOptionalURL(urlString string) (*url.URL, error) {
+	if urlString == "" {
+		return nil, nil
+	}
+	return url.Parse(urlString)
+}
+
+// pluginInfoForProtoPluginInfo returns a new PluginInfo for the given infov1.PluginInfo and
+// extinfov1.PluginInfoExtension.
+//
+// The extinfov1.PluginInfoExtension may be nil if the plugin does not implement the
+// PluginInfoExtensionService.
+func pluginInfoForProtoPluginInfo(
+	protoPluginInfo *infov1.PluginInfo,
+	protoPluginInfoExtension *extinfov1.PluginInfoExtension,
+) (PluginInfo, error) {
 	if protoPluginInfo == nil {
 		return nil, nil
 	}
-	license, err := licenseForProtoLicense(protoPluginInfo.GetLicense())
+	license, err := licenseForProtoLicense(
+		protoPluginInfo.GetLicense(),
+		protoPluginInfoExtension.GetSpdxLicenseExpression(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	additionalLicenses, err := xslices.MapError(
+		protoPluginInfoExtension.GetAdditionalLicenses(),
+		licenseForProtoExtensionLicense,
+	)
+	if err != nil {
+		return nil, err
+	}
+	version := protoPluginInfoExtension.GetVersion()
+	if version != "" && !isValidVersion(version) {
+		return nil, fmt.Errorf("invalid version: must be a semantic version: %q", version)
+	}
+	minimumBufVersion := protoPluginInfoExtension.GetMinimumBufVersion()
+	if minimumBufVersion != "" && !isValidVersion(minimumBufVersion) {
+		return nil, fmt.Errorf("invalid minimum buf version: must be a semantic version: %q", minimumBufVersion)
+	}
+	authors, err := xslices.MapError(protoPluginInfoExtension.GetAuthors(), contactForProtoContact)
+	if err != nil {
+		return nil, err
+	}
+	maintainers, err := xslices.MapError(protoPluginInfoExtension.GetMaintainers(), contactForProtoContact)
+	if err != nil {
+		return nil, err
+	}
+	sourceRevision, err := sourceRevisionForProtoSourceRevision(protoPluginInfoExtension.GetSourceRevision())
+	if err != nil {
+		return nil, err
+	}
+	changelogURL, err := parseOptionalURL(protoPluginInfoExtension.GetChangelogUrl())
+	if err != nil {
+		return nil, err
+	}
+	supportURL, err := parseOptionalURL(protoPluginInfoExtension.GetSupportUrl())
 	if err != nil {
 		return nil, err
 	}
-	return newPluginInfo(protoPluginInfo.GetDocumentation(), license)
+	return newPluginInfo(
+		protoPluginInfo.GetDocumentation(),
+		license,
+		extendedProperties{
+			shortDocumentation: protoPluginInfoExtension.GetShortDocumentation(),
+			version:            version,
+			authors:            authors,
+			maintainers:        maintainers,
+			minimumBufVersion:  minimumBufVersion,
+			// Not validated against ProtocolVersion, as a plugin may require a newer
+			// protocol version than the client supports. See CheckCompatibility.
+			minimumProtocolVersion: int(protoPluginInfoExtension.GetMinimumProtocolVersion()),
+			keywords:               protoPluginInfoExtension.GetKeywords(),
+			additionalLicenses:     additionalLicenses,
+			deprecated:             protoPluginInfoExtension.GetDeprecated(),
+			deprecationMessage:     protoPluginInfoExtension.GetDeprecationMessage(),
+			sourceRevision:         sourceRevision,
+			changelogURL:           changelogURL,
+			supportURL:             supportURL,
+		},
+	)
 }
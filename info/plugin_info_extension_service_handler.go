@@ -0,0 +1,63 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"context"
+
+	extinfov1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1"
+	"buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1/infov1pluginrpc"
+)
+
+// PluginInfoExtensionServiceGetPluginInfoExtensionPath is the path of the
+// PluginInfoExtensionService's GetPluginInfoExtension procedure.
+//
+// The PluginInfoExtensionService transmits the properties of a PluginInfo that
+// are not part of the v1 PluginInfoService protocol.
+const PluginInfoExtensionServiceGetPluginInfoExtensionPath = infov1pluginrpc.PluginInfoExtensionServiceGetPluginInfoExtensionPath
+
+// NewPluginInfoExtensionServiceHandler returns a new infov1pluginrpc.PluginInfoExtensionServiceHandler
+// for the given Spec.
+//
+// The Spec will be validated.
+func NewPluginInfoExtensionServiceHandler(spec *Spec) (infov1pluginrpc.PluginInfoExtensionServiceHandler, error) {
+	return newPluginInfoExtensionServiceHandler(spec)
+}
+
+// *** PRIVATE ***
+
+type pluginInfoExtensionServiceHandler struct {
+	getPluginInfoExtensionResponse *extinfov1.GetPluginInfoExtensionResponse
+}
+
+func newPluginInfoExtensionServiceHandler(spec *Spec) (*pluginInfoExtensionServiceHandler, error) {
+	// Also calls ValidateSpec.
+	pluginInfo, err := NewPluginInfoForSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginInfoExtensionServiceHandler{
+		getPluginInfoExtensionResponse: &extinfov1.GetPluginInfoExtensionResponse{
+			PluginInfoExtension: pluginInfo.toProtoExtension(),
+		},
+	}, nil
+}
+
+func (p *pluginInfoExtensionServiceHandler) GetPluginInfoExtension(
+	context.Context,
+	*extinfov1.GetPluginInfoExtensionRequest,
+) (*extinfov1.GetPluginInfoExtensionResponse, error) {
+	return p.getPluginInfoExtensionResponse, nil
+}
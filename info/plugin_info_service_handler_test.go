@@ -27,14 +27,19 @@ func TestPluginInfoServiceHandlerBasic(t *testing.T) {
 
 	pluginInfoServiceHandler, err := NewPluginInfoServiceHandler(
 		&Spec{
-			LicenseURL: "https://foo.com/license",
+			Documentation: "Foo.",
+			SPDXLicenseID: "apache-2.0",
+			LicenseURL:    "https://foo.com/license",
 		},
 	)
 	require.NoError(t, err)
 
-	_, err = pluginInfoServiceHandler.GetPluginInfo(
+	response, err := pluginInfoServiceHandler.GetPluginInfo(
 		context.Background(),
 		&infov1.GetPluginInfoRequest{},
 	)
 	require.NoError(t, err)
+	require.Equal(t, "Foo.", response.GetPluginInfo().GetDocumentation())
+	require.Equal(t, "Apache-2.0", response.GetPluginInfo().GetLicense().GetSpdxLicenseId())
+	require.Equal(t, "https://foo.com/license", response.GetPluginInfo().GetLicense().GetUrl())
 }
@@ -0,0 +1,125 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"errors"
+	"time"
+
+	extinfov1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SourceRevision is the version control revision that a plugin was built from.
+type SourceRevision interface {
+	// VCS returns the version control system, such as "git".
+	//
+	// Optional.
+	VCS() string
+	// Revision returns the revision identifier, such as a git commit SHA.
+	//
+	// Always present.
+	Revision() string
+	// Modified returns whether or not the source tree had local modifications
+	// when the plugin was built.
+	Modified() bool
+	// Time returns the time of the revision.
+	//
+	// Optional. Will be the zero value if not present.
+	Time() time.Time
+
+	toProto() *extinfov1.SourceRevision
+
+	isSourceRevision()
+}
+
+// *** PRIVATE ***
+
+type sourceRevision struct {
+	vcs      string
+	revision string
+	modified bool
+	time     time.Time
+}
+
+func newSourceRevision(
+	vcs string,
+	revision string,
+	modified bool,
+	time time.Time,
+) (*sourceRevision, error) {
+	if revision == "" {
+		return nil, errors.New("info.SourceRevision: Revision is empty")
+	}
+	return &sourceRevision{
+		vcs:      vcs,
+		revision: revision,
+		modified: modified,
+		time:     time,
+	}, nil
+}
+
+func (s *sourceRevision) VCS() string {
+	return s.vcs
+}
+
+func (s *sourceRevision) Revision() string {
+	return s.revision
+}
+
+func (s *sourceRevision) Modified() bool {
+	return s.modified
+}
+
+func (s *sourceRevision) Time() time.Time {
+	return s.time
+}
+
+func (s *sourceRevision) toProto() *extinfov1.SourceRevision {
+	if s == nil {
+		return nil
+	}
+	protoSourceRevision := &extinfov1.SourceRevision{
+		Vcs:      s.vcs,
+		Revision: s.revision,
+		Modified: s.modified,
+	}
+	if !s.time.IsZero() {
+		protoSourceRevision.Time = timestamppb.New(s.time)
+	}
+	return protoSourceRevision
+}
+
+func (*sourceRevision) isSourceRevision() {}
+
+// sourceRevisionForProtoSourceRevision returns nil if protoSourceRevision is nil.
+func sourceRevisionForProtoSourceRevision(protoSourceRevision *extinfov1.SourceRevision) (*sourceRevision, error) {
+	if protoSourceRevision == nil {
+		return nil, nil
+	}
+	var revisionTime time.Time
+	if protoSourceRevision.GetTime() != nil {
+		if err := protoSourceRevision.GetTime().CheckValid(); err != nil {
+			return nil, err
+		}
+		revisionTime = protoSourceRevision.GetTime().AsTime()
+	}
+	return newSourceRevision(
+		protoSourceRevision.GetVcs(),
+		protoSourceRevision.GetRevision(),
+		protoSourceRevision.GetModified(),
+		revisionTime,
+	)
+}
@@ -0,0 +1,112 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SourceRevisionSpec is the spec for a SourceRevision.
+type SourceRevisionSpec struct {
+	// VCS is the version control system, such as "git".
+	//
+	// Optional.
+	VCS string
+	// Revision is the revision identifier, such as a git commit SHA.
+	//
+	// Required.
+	//
+	// Must not contain whitespace.
+	Revision string
+	// Modified says whether or not the source tree had local modifications when
+	// the plugin was built.
+	//
+	// Optional.
+	Modified bool
+	// Time is the time of the revision.
+	//
+	// Optional.
+	Time time.Time
+}
+
+// SourceRevisionSpecFromBuildInfo returns a new SourceRevisionSpec from the VCS information
+// that the go command stamps into binaries, as read by debug.ReadBuildInfo.
+//
+// Go stamps VCS information when building a main package from within a repository,
+// unless -buildvcs=false is set. Note that Time is the time of the commit, as Go does
+// not record when the binary itself was built.
+//
+// Returns nil if no VCS information is available, for example within tests or
+// when the plugin was installed with go install from the module proxy.
+func SourceRevisionSpecFromBuildInfo() *SourceRevisionSpec {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	return sourceRevisionSpecForBuildSettings(buildInfo.Settings)
+}
+
+// *** PRIVATE ***
+
+func sourceRevisionSpecForBuildSettings(buildSettings []debug.BuildSetting) *SourceRevisionSpec {
+	sourceRevisionSpec := &SourceRevisionSpec{}
+	for _, buildSetting := range buildSettings {
+		switch buildSetting.Key {
+		case "vcs":
+			sourceRevisionSpec.VCS = buildSetting.Value
+		case "vcs.revision":
+			sourceRevisionSpec.Revision = buildSetting.Value
+		case "vcs.modified":
+			// Ignore errors, treating unparseable values as unmodified.
+			sourceRevisionSpec.Modified, _ = strconv.ParseBool(buildSetting.Value)
+		case "vcs.time":
+			// Ignore errors, treating unparseable values as unset.
+			sourceRevisionSpec.Time, _ = time.Parse(time.RFC3339Nano, buildSetting.Value)
+		}
+	}
+	if sourceRevisionSpec.Revision == "" {
+		return nil
+	}
+	return sourceRevisionSpec
+}
+
+// Assumes that the SourceRevisionSpec is validated.
+func sourceRevisionSpecToSourceRevision(sourceRevisionSpec *SourceRevisionSpec) (*sourceRevision, error) {
+	if sourceRevisionSpec == nil {
+		return nil, nil
+	}
+	return newSourceRevision(
+		sourceRevisionSpec.VCS,
+		sourceRevisionSpec.Revision,
+		sourceRevisionSpec.Modified,
+		sourceRevisionSpec.Time,
+	)
+}
+
+func validateSourceRevisionSpec(sourceRevisionSpec *SourceRevisionSpec) error {
+	if sourceRevisionSpec == nil {
+		return nil
+	}
+	if sourceRevisionSpec.Revision == "" {
+		return newValidateSpecError("SourceRevision has an empty Revision")
+	}
+	if strings.ContainsAny(sourceRevisionSpec.Revision, " \t\r\n") {
+		return newValidateSpecErrorf("SourceRevision has a Revision that contains whitespace: %q", sourceRevisionSpec.Revision)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"buf.build/go/spdx"
+)
+
+var (
+	spdxLicenseRefRegexp   = regexp.MustCompile(`^(DocumentRef-[a-zA-Z0-9.-]+:)?LicenseRef-[a-zA-Z0-9.-]+$`)
+	spdxExceptionIDRegexp  = regexp.MustCompile(`^[a-zA-Z0-9.-]+$`)
+	spdxOperatorPrecedence = map[string]int{
+		"OR":   1,
+		"AND":  2,
+		"WITH": 3,
+	}
+)
+
+// *** PRIVATE ***
+
+// spdxExpression is a parsed SPDX license expression.
+//
+// See https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions.
+//
+// Either licenseID is set, or operator, left, and right are set. For the WITH operator,
+// right is always a leaf whose licenseID is the exception ID.
+type spdxExpression struct {
+	licenseID string
+	operator  string
+	left      *spdxExpression
+	right     *spdxExpression
+}
+
+// normalizeSPDXLicenseExpression parses and validates the SPDX license expression, and
+// returns it in canonical form.
+//
+// License IDs are translated to their proper casing, operators are uppercased, and
+// only the parentheses required to preserve the meaning of the expression are kept.
+func normalizeSPDXLicenseExpression(expression string) (string, error) {
+	parsed, err := parseSPDXLicenseExpression(expression)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+func parseSPDXLicenseExpression(expression string) (*spdxExpression, error) {
+	parser := &spdxExpressionParser{
+		tokens: tokenizeSPDXLicenseExpression(expression),
+	}
+	if len(parser.tokens) == 0 {
+		return nil, errors.New("empty SPDX license expression")
+	}
+	parsed, err := parser.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPDX license expression %q: %w", expression, err)
+	}
+	if parser.index != len(parser.tokens) {
+		return nil, fmt.Errorf("invalid SPDX license expression %q: unexpected %q", expression, parser.tokens[parser.index])
+	}
+	return parsed, nil
+}
+
+func (e *spdxExpression) String() string {
+	if e.operator == "" {
+		return e.licenseID
+	}
+	return e.childString(e.left) + " " + e.operator + " " + e.childString(e.right)
+}
+
+func (e *spdxExpression) childString(child *spdxExpression) string {
+	if child.operator != "" && spdxOperatorPrecedence[child.operator] < spdxOperatorPrecedence[e.operator] {
+		return "(" + child.String() + ")"
+	}
+	return child.String()
+}
+
+type spdxExpressionParser struct {
+	tokens []string
+	index  int
+}
+
+func (p *spdxExpressionParser) parseOr() (*spdxExpression, error) {
+	return p.parseBinary("OR", p.parseAnd)
+}
+
+func (p *spdxExpressionParser) parseAnd() (*spdxExpression, error) {
+	return p.parseBinary("AND", p.parseWith)
+}
+
+func (p *spdxExpressionParser) parseBinary(operator string, parseOperand func() (*spdxExpression, error)) (*spdxExpression, error) {
+	left, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOperator() == operator {
+		p.index++
+		right, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		left = &spdxExpression{
+			operator: operator,
+			left:     left,
+			right:    right,
+		}
+	}
+	return left, nil
+}
+
+func (p *spdxExpressionParser) parseWith() (*spdxExpression, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.peekOperator() != "WITH" {
+		return left, nil
+	}
+	if left.operator != "" {
+		return nil, errors.New("WITH must follow a license ID")
+	}
+	p.index++
+	if p.index >= len(p.tokens) {
+		return nil, errors.New("expected exception ID after WITH")
+	}
+	exceptionID := p.tokens[p.index]
+	p.index++
+	if !spdxExceptionIDRegexp.MatchString(exceptionID) {
+		return nil, fmt.Errorf("invalid exception ID %q", exceptionID)
+	}
+	return &spdxExpression{
+		operator: "WITH",
+		left:     left,
+		right: &spdxExpression{
+			licenseID: exceptionID,
+		},
+	}, nil
+}
+
+func (p *spdxExpressionParser) parsePrimary() (*spdxExpression, error) {
+	if p.index >= len(p.tokens) {
+		return nil, errors.New("unexpected end of expression")
+	}
+	token := p.tokens[p.index]
+	p.index++
+	switch {
+	case token == "(":
+		parsed, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.index >= len(p.tokens) || p.tokens[p.index] != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.index++
+		return parsed, nil
+	case token == ")":
+		return nil, errors.New("unexpected closing parenthesis")
+	case spdxOperatorPrecedence[strings.ToUpper(token)] > 0:
+		return nil, fmt.Errorf("unexpected operator %q", token)
+	default:
+		licenseID, err := normalizeSPDXSimpleExpression(token)
+		if err != nil {
+			return nil, err
+		}
+		return &spdxExpression{
+			licenseID: licenseID,
+		}, nil
+	}
+}
+
+func (p *spdxExpressionParser) peekOperator() string {
+	if p.index >= len(p.tokens) {
+		return ""
+	}
+	operator := strings.ToUpper(p.tokens[p.index])
+	if _, ok := spdxOperatorPrecedence[operator]; !ok {
+		return ""
+	}
+	return operator
+}
+
+// normalizeSPDXSimpleExpression validates a license ID, an "or later" license ID
+// ending in "+", or a LicenseRef, and returns it with proper casing.
+func normalizeSPDXSimpleExpression(token string) (string, error) {
+	if spdxLicenseRefRegexp.MatchString(token) {
+		return token, nil
+	}
+	licenseID, orLater := strings.CutSuffix(token, "+")
+	spdxLicense, ok := spdx.LicenseForID(licenseID)
+	if !ok {
+		return "", fmt.Errorf("unknown SPDX license ID: %q", licenseID)
+	}
+	if orLater {
+		return spdxLicense.ID + "+", nil
+	}
+	return spdxLicense.ID, nil
+}
+
+func tokenizeSPDXLicenseExpression(expression string) []string {
+	expression = strings.ReplaceAll(expression, "(", " ( ")
+	expression = strings.ReplaceAll(expression, ")", " ) ")
+	return strings.Fields(expression)
+}
@@ -0,0 +1,106 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSPDXLicenseExpression(t *testing.T) {
+	t.Parallel()
+
+	testNormalizeSPDXLicenseExpression(t, "mit", "MIT")
+	testNormalizeSPDXLicenseExpression(t, "mit or apache-2.0", "MIT OR Apache-2.0")
+	testNormalizeSPDXLicenseExpression(t, "(MIT OR Apache-2.0)", "MIT OR Apache-2.0")
+	testNormalizeSPDXLicenseExpression(t, "(MIT AND BSD-3-Clause) OR Apache-2.0", "MIT AND BSD-3-Clause OR Apache-2.0")
+	testNormalizeSPDXLicenseExpression(t, "MIT AND (BSD-3-Clause OR Apache-2.0)", "MIT AND (BSD-3-Clause OR Apache-2.0)")
+	testNormalizeSPDXLicenseExpression(t, "GPL-2.0-only WITH Classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0")
+	testNormalizeSPDXLicenseExpression(t, "gpl-2.0-or-later+", "GPL-2.0-or-later+")
+	testNormalizeSPDXLicenseExpression(t, "LicenseRef-Proprietary OR MIT", "LicenseRef-Proprietary OR MIT")
+
+	for _, expression := range []string{
+		"",
+		"FOO",
+		"MIT OR",
+		"OR MIT",
+		"(MIT",
+		"MIT)",
+		"MIT Apache-2.0",
+		"(MIT OR Apache-2.0) WITH Classpath-exception-2.0",
+	} {
+		_, err := normalizeSPDXLicenseExpression(expression)
+		require.Error(t, err, expression)
+	}
+}
+
+func TestPluginInfoSPDXLicenseExpression(t *testing.T) {
+	t.Parallel()
+
+	pluginInfo, err := NewPluginInfoForSpec(
+		&Spec{
+			SPDXLicenseExpression: "mit or apache-2.0",
+			LicenseURL:            "https://foo.com/license",
+			AdditionalLicenses: []*LicenseSpec{
+				{
+					Component:     "vendor/bar",
+					SPDXLicenseID: "bsd-3-clause",
+					LicenseText:   "Bar license.",
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	license := pluginInfo.License()
+	require.NotNil(t, license)
+	require.Equal(t, "MIT OR Apache-2.0", license.SPDXLicenseExpression())
+	require.Empty(t, license.SPDXLicenseID())
+	additionalLicenses := pluginInfo.AdditionalLicenses()
+	require.Len(t, additionalLicenses, 1)
+	require.Equal(t, "vendor/bar", additionalLicenses[0].Component())
+	require.Equal(t, "BSD-3-Clause", additionalLicenses[0].SPDXLicenseID())
+	require.Equal(t, "BSD-3-Clause", additionalLicenses[0].SPDXLicenseExpression())
+
+	pluginInfo, err = NewPluginInfoForSpec(
+		&Spec{
+			SPDXLicenseExpression: "(apache-2.0)",
+			LicenseURL:            "https://foo.com/license",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "Apache-2.0", pluginInfo.License().SPDXLicenseID())
+
+	require.Error(t, ValidateSpec(&Spec{SPDXLicenseID: "MIT", SPDXLicenseExpression: "MIT"}))
+	require.Error(t, ValidateSpec(&Spec{AdditionalLicenses: []*LicenseSpec{{SPDXLicenseID: "MIT"}}}))
+	require.Error(t, ValidateSpec(&Spec{AdditionalLicenses: []*LicenseSpec{{Component: "foo"}}}))
+	require.Error(
+		t,
+		ValidateSpec(
+			&Spec{
+				AdditionalLicenses: []*LicenseSpec{
+					{Component: "foo", SPDXLicenseID: "MIT"},
+					{Component: "foo", SPDXLicenseID: "MIT"},
+				},
+			},
+		),
+	)
+}
+
+func testNormalizeSPDXLicenseExpression(t *testing.T, input string, expected string) {
+	actual, err := normalizeSPDXLicenseExpression(input)
+	require.NoError(t, err, input)
+	require.Equal(t, expected, actual, input)
+}
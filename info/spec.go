@@ -16,16 +16,40 @@ package info
 
 import (
 	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
 
-	"buf.build/go/spdx"
+const (
+	keywordMaxLen                  = 32
+	strictShortDocumentationMaxLen = 160
 )
 
+var keywordRegexp = regexp.MustCompile("^[a-z0-9]+(-[a-z0-9]+)*$")
+
 // Spec is the spec for the information about a plugin.
 type Spec struct {
 	// Documentation contains the documentation of the plugin.
 	//
 	// Optional.
+	//
+	// This is interpreted as CommonMark. Use DocumentationToPlainText to render it
+	// as plain text, as is done for -h/--help output.
 	Documentation string
+	// ShortDocumentation is a single-line summary of the plugin.
+	//
+	// Optional.
+	//
+	// Must not contain newlines. If not set, the first paragraph of Documentation
+	// is used, see DocumentationFirstParagraph.
+	ShortDocumentation string
+	// URL is the URL of the plugin's homepage or source repository.
+	//
+	// Optional.
+	//
+	// Must be absolute if set.
+	URL string
 	// SPDXLicenseID is the SDPX ID of the License.
 	//
 	// Optional.
@@ -35,7 +59,21 @@ type Spec struct {
 	//
 	// This can be specified in any case. This package will translate this into
 	// proper casing.
+	//
+	// At most one of SPDXLicenseID and SPDXLicenseExpression can be set.
 	SPDXLicenseID string
+	// SPDXLicenseExpression is an SPDX license expression for the License, such as
+	// "MIT OR Apache-2.0" or "(MIT AND BSD-3-Clause) OR GPL-2.0-only WITH Classpath-exception-2.0".
+	//
+	// Optional.
+	//
+	// https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions
+	//
+	// License IDs and operators can be specified in any case. This package will
+	// translate this into a normalized expression with proper casing.
+	//
+	// At most one of SPDXLicenseID and SPDXLicenseExpression can be set.
+	SPDXLicenseExpression string
 	// LicenseText is the raw text of the License.
 	//
 	// Optional.
@@ -49,28 +87,223 @@ type Spec struct {
 	// Zero or one of LicenseText and LicenseURL must be set.
 	// Must be absolute if set.
 	LicenseURL string
+	// AdditionalLicenses are the Licenses of components of the plugin that are licensed
+	// differently from the plugin itself, such as vendored code.
+	//
+	// Optional.
+	//
+	// Each Component must be unique.
+	AdditionalLicenses []*LicenseSpec
+	// Version is the version of the plugin.
+	//
+	// Optional.
+	//
+	// Must be a valid semantic version if set, with an optional leading "v",
+	// for example "v1.2.3" or "1.2.3-rc.1". If not set, BuildVersion is used.
+	Version string
+	// Authors are the people or teams that wrote the plugin.
+	//
+	// Optional.
+	Authors []*ContactSpec
+	// Maintainers are the people or teams that currently maintain the plugin.
+	//
+	// Optional.
+	//
+	// This is who users should contact with questions about the plugin's behavior.
+	Maintainers []*ContactSpec
+	// MinimumBufVersion is the minimum version of buf that the plugin requires.
+	//
+	// Optional.
+	//
+	// Must be a valid semantic version if set. See CheckCompatibility.
+	MinimumBufVersion string
+	// MinimumProtocolVersion is the minimum Bufplugin protocol version that the plugin requires.
+	//
+	// Optional.
+	//
+	// Must be between 0 and ProtocolVersion. See CheckCompatibility.
+	MinimumProtocolVersion int
+	// Keywords are short tags that categorize the plugin, such as "grpc", "naming", or "security".
+	//
+	// Optional.
+	//
+	// Keywords are used by plugin registries and search UIs. Each keyword must consist of
+	// lowercase letters, digits, and single hyphens between them, be at most 32 characters,
+	// and be unique within Keywords.
+	Keywords []string
+	// Deprecated says whether or not the plugin is deprecated.
+	//
+	// Optional.
+	//
+	// A deprecated plugin should not be used for new configurations. Clients should warn
+	// when a deprecated plugin is used.
+	Deprecated bool
+	// DeprecationMessage tells users of a deprecated plugin what to migrate to, for example
+	// "Use buf-plugin-bar instead."
+	//
+	// Optional.
+	//
+	// Can only be set if Deprecated is true.
+	DeprecationMessage string
+	// SourceRevision is the version control revision that the plugin was built from.
+	//
+	// Optional.
+	//
+	// This is typically set with SourceRevisionSpecFromBuildInfo, so that findings can be
+	// traced back to the exact build of a plugin.
+	SourceRevision *SourceRevisionSpec
+	// ChangelogURL is the URL of the changelog or release notes of the plugin.
+	//
+	// Optional.
+	//
+	// Must be absolute if set.
+	ChangelogURL string
+	// SupportURL is the URL where users can get support for the plugin or report bugs,
+	// such as an issue tracker.
+	//
+	// Optional.
+	//
+	// Must be absolute if set.
+	SupportURL string
 }
 
 // ValidateSpec validates all values on a Spec.
 func ValidateSpec(spec *Spec) error {
-	if spec.SPDXLicenseID != "" {
-		if _, ok := spdx.LicenseForID(spec.SPDXLicenseID); !ok {
-			return newValidateSpecErrorf("invalid SPDXLicenseID: %q", spec.SPDXLicenseID)
+	if err := validateDocumentation(spec.Documentation); err != nil {
+		return newValidateSpecErrorf("Documentation %v", err)
+	}
+	if err := validateDocumentation(spec.ShortDocumentation); err != nil {
+		return newValidateSpecErrorf("ShortDocumentation %v", err)
+	}
+	if strings.ContainsAny(spec.ShortDocumentation, "\r\n") {
+		return newValidateSpecError("ShortDocumentation must not contain newlines")
+	}
+	if err := validateLicenseFields(
+		spec.SPDXLicenseID,
+		spec.SPDXLicenseExpression,
+		spec.LicenseText,
+		spec.LicenseURL,
+	); err != nil {
+		return err
+	}
+	if err := validateLicenseSpecs(spec.AdditionalLicenses); err != nil {
+		return err
+	}
+	if version := specVersion(spec); version != "" && !isValidVersion(version) {
+		return newValidateSpecErrorf("invalid Version: must be a semantic version: %q", version)
+	}
+	if spec.MinimumBufVersion != "" && !isValidVersion(spec.MinimumBufVersion) {
+		return newValidateSpecErrorf("invalid MinimumBufVersion: must be a semantic version: %q", spec.MinimumBufVersion)
+	}
+	if spec.MinimumProtocolVersion < 0 || spec.MinimumProtocolVersion > ProtocolVersion {
+		return newValidateSpecErrorf("invalid MinimumProtocolVersion: must be between 0 and %d: %d", ProtocolVersion, spec.MinimumProtocolVersion)
+	}
+	if err := validateKeywords(spec.Keywords); err != nil {
+		return err
+	}
+	if err := validateContactSpecs("Authors", spec.Authors); err != nil {
+		return err
+	}
+	if err := validateContactSpecs("Maintainers", spec.Maintainers); err != nil {
+		return err
+	}
+	if spec.DeprecationMessage != "" && !spec.Deprecated {
+		return newValidateSpecError("DeprecationMessage is set but Deprecated is false")
+	}
+	if err := validateSourceRevisionSpec(spec.SourceRevision); err != nil {
+		return err
+	}
+	if spec.URL != "" {
+		if err := validateSpecAbsoluteURL(spec.URL); err != nil {
+			return err
 		}
 	}
-	if spec.LicenseText != "" && spec.LicenseURL != "" {
-		return newValidateSpecError("only one of LicenseText and LicenseURL can be set")
+	if spec.ChangelogURL != "" {
+		if err := validateSpecAbsoluteURL(spec.ChangelogURL); err != nil {
+			return err
+		}
 	}
-	if spec.LicenseURL != "" {
-		if err := validateSpecAbsoluteURL(spec.LicenseURL); err != nil {
+	if spec.SupportURL != "" {
+		if err := validateSpecAbsoluteURL(spec.SupportURL); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// ValidateSpecStrict validates all values on a Spec with ValidateSpec, and additionally
+// validates that the Spec meets the requirements for publishing a plugin to a registry
+// such as the BSR:
+//
+//   - Documentation must be set.
+//   - The short documentation, either ShortDocumentation or the first paragraph of
+//     Documentation, must be non-empty and at most 160 characters.
+//   - URL must be set.
+//   - A license must be set via SPDXLicenseID, SPDXLicenseExpression, LicenseText, or LicenseURL.
+//
+// This is exposed so that plugin authors can catch gaps locally before publishing,
+// typically from a test.
+func ValidateSpecStrict(spec *Spec) error {
+	if err := ValidateSpec(spec); err != nil {
+		return err
+	}
+	if strings.TrimSpace(spec.Documentation) == "" {
+		return newValidateSpecError("Documentation is required")
+	}
+	shortDocumentation := spec.ShortDocumentation
+	if shortDocumentation == "" {
+		shortDocumentation = DocumentationFirstParagraph(spec.Documentation)
+	}
+	if shortDocumentation == "" {
+		return newValidateSpecError("ShortDocumentation is required, either directly or as the first paragraph of Documentation")
+	}
+	if length := utf8.RuneCountInString(shortDocumentation); length > strictShortDocumentationMaxLen {
+		return newValidateSpecErrorf(
+			"ShortDocumentation must be at most %d characters, was %d: %q",
+			strictShortDocumentationMaxLen,
+			length,
+			shortDocumentation,
+		)
+	}
+	if spec.URL == "" {
+		return newValidateSpecError("URL is required")
+	}
+	if spec.SPDXLicenseID == "" &&
+		spec.SPDXLicenseExpression == "" &&
+		spec.LicenseText == "" &&
+		spec.LicenseURL == "" {
+		return newValidateSpecError("a license is required")
+	}
+	return nil
+}
+
 // *** PRIVATE ***
 
+// specVersion returns the Version of the Spec, falling back to BuildVersion.
+func specVersion(spec *Spec) string {
+	if spec.Version != "" {
+		return spec.Version
+	}
+	return BuildVersion
+}
+
+func validateKeywords(keywords []string) error {
+	seen := make(map[string]struct{}, len(keywords))
+	for _, keyword := range keywords {
+		if len(keyword) > keywordMaxLen {
+			return newValidateSpecErrorf("invalid keyword: must be at most length %d: %q", keywordMaxLen, keyword)
+		}
+		if !keywordRegexp.MatchString(keyword) {
+			return newValidateSpecErrorf("invalid keyword: does not match %q: %q", keywordRegexp.String(), keyword)
+		}
+		if _, ok := seen[keyword]; ok {
+			return newValidateSpecErrorf("duplicate keyword: %q", keyword)
+		}
+		seen[keyword] = struct{}{}
+	}
+	return nil
+}
+
 func validateSpecAbsoluteURL(urlString string) error {
 	url, err := url.Parse(urlString)
 	if err != nil {
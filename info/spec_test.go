@@ -0,0 +1,263 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSpecVersion(t *testing.T) {
+	t.Parallel()
+
+	validateSpecError := &validateSpecError{}
+
+	for _, version := range []string{
+		"",
+		"1.2.3",
+		"v1.2.3",
+		"v0.0.1-rc.1",
+		"v1.0.0-alpha+build.5",
+	} {
+		require.NoError(t, ValidateSpec(&Spec{Version: version}), version)
+	}
+	for _, version := range []string{
+		"1",
+		"v1.2",
+		"v01.2.3",
+		"latest",
+		"v1.2.3-",
+	} {
+		err := ValidateSpec(&Spec{Version: version})
+		require.ErrorAs(t, err, &validateSpecError, version)
+	}
+
+	pluginInfo, err := NewPluginInfoForSpec(&Spec{Version: "v1.2.3"})
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3", pluginInfo.Version())
+}
+
+func TestValidateSpecContacts(t *testing.T) {
+	t.Parallel()
+
+	validateSpecError := &validateSpecError{}
+
+	spec := &Spec{
+		Authors: []*ContactSpec{
+			{
+				Name:  "Foo Team",
+				Email: "foo@example.com",
+				URL:   "https://example.com/foo",
+			},
+		},
+		Maintainers: []*ContactSpec{
+			{
+				Name: "Bar Team",
+			},
+		},
+	}
+	require.NoError(t, ValidateSpec(spec))
+	pluginInfo, err := NewPluginInfoForSpec(spec)
+	require.NoError(t, err)
+	require.Len(t, pluginInfo.Authors(), 1)
+	require.Equal(t, "Foo Team", pluginInfo.Authors()[0].Name())
+	require.Equal(t, "foo@example.com", pluginInfo.Authors()[0].Email())
+	require.Equal(t, "https://example.com/foo", pluginInfo.Authors()[0].URL().String())
+	require.Len(t, pluginInfo.Maintainers(), 1)
+	require.Equal(t, "Bar Team", pluginInfo.Maintainers()[0].Name())
+	require.Nil(t, pluginInfo.Maintainers()[0].URL())
+
+	require.ErrorAs(t, ValidateSpec(&Spec{Authors: []*ContactSpec{{Email: "foo@example.com"}}}), &validateSpecError)
+	require.ErrorAs(t, ValidateSpec(&Spec{Authors: []*ContactSpec{{Name: "Foo", Email: "foo"}}}), &validateSpecError)
+	require.ErrorAs(t, ValidateSpec(&Spec{Maintainers: []*ContactSpec{{Name: "Foo", URL: "/foo"}}}), &validateSpecError)
+	require.ErrorAs(t, ValidateSpec(&Spec{Maintainers: []*ContactSpec{nil}}), &validateSpecError)
+}
+
+func TestValidateSpecKeywords(t *testing.T) {
+	t.Parallel()
+
+	validateSpecError := &validateSpecError{}
+
+	spec := &Spec{
+		Keywords: []string{"grpc", "naming", "proto3-only"},
+	}
+	require.NoError(t, ValidateSpec(spec))
+	pluginInfo, err := NewPluginInfoForSpec(spec)
+	require.NoError(t, err)
+	require.Equal(t, []string{"grpc", "naming", "proto3-only"}, pluginInfo.Keywords())
+
+	for _, keyword := range []string{
+		"",
+		"GRPC",
+		"foo bar",
+		"-foo",
+		"foo--bar",
+		"abcdefghijklmnopqrstuvwxyz0123456789",
+	} {
+		require.ErrorAs(t, ValidateSpec(&Spec{Keywords: []string{keyword}}), &validateSpecError, keyword)
+	}
+	require.ErrorAs(t, ValidateSpec(&Spec{Keywords: []string{"foo", "foo"}}), &validateSpecError)
+}
+
+func TestValidateSpecDeprecation(t *testing.T) {
+	t.Parallel()
+
+	validateSpecError := &validateSpecError{}
+
+	pluginInfo, err := NewPluginInfoForSpec(
+		&Spec{
+			Deprecated:         true,
+			DeprecationMessage: "Use buf-plugin-bar instead.",
+		},
+	)
+	require.NoError(t, err)
+	require.True(t, pluginInfo.Deprecated())
+	require.Equal(t, "Use buf-plugin-bar instead.", pluginInfo.DeprecationMessage())
+	pluginInfo, err = NewPluginInfoForSpec(&Spec{})
+	require.NoError(t, err)
+	require.False(t, pluginInfo.Deprecated())
+
+	require.ErrorAs(t, ValidateSpec(&Spec{DeprecationMessage: "Use buf-plugin-bar instead."}), &validateSpecError)
+}
+
+func TestValidateSpecSourceRevision(t *testing.T) {
+	t.Parallel()
+
+	validateSpecError := &validateSpecError{}
+
+	revisionTime := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	pluginInfo, err := NewPluginInfoForSpec(
+		&Spec{
+			SourceRevision: &SourceRevisionSpec{
+				VCS:      "git",
+				Revision: "0123456789abcdef0123456789abcdef01234567",
+				Modified: true,
+				Time:     revisionTime,
+			},
+		},
+	)
+	require.NoError(t, err)
+	sourceRevision := pluginInfo.SourceRevision()
+	require.NotNil(t, sourceRevision)
+	require.Equal(t, "git", sourceRevision.VCS())
+	require.Equal(t, "0123456789abcdef0123456789abcdef01234567", sourceRevision.Revision())
+	require.True(t, sourceRevision.Modified())
+	require.Equal(t, revisionTime, sourceRevision.Time())
+	pluginInfo, err = NewPluginInfoForSpec(&Spec{})
+	require.NoError(t, err)
+	require.Nil(t, pluginInfo.SourceRevision())
+
+	require.ErrorAs(t, ValidateSpec(&Spec{SourceRevision: &SourceRevisionSpec{}}), &validateSpecError)
+	require.ErrorAs(t, ValidateSpec(&Spec{SourceRevision: &SourceRevisionSpec{Revision: "foo bar"}}), &validateSpecError)
+}
+
+func TestSourceRevisionSpecForBuildSettings(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, sourceRevisionSpecForBuildSettings(nil))
+	require.Nil(t, sourceRevisionSpecForBuildSettings([]debug.BuildSetting{{Key: "vcs", Value: "git"}}))
+	require.Equal(
+		t,
+		&SourceRevisionSpec{
+			VCS:      "git",
+			Revision: "0123456789abcdef",
+			Modified: true,
+			Time:     time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		},
+		sourceRevisionSpecForBuildSettings(
+			[]debug.BuildSetting{
+				{Key: "-compiler", Value: "gc"},
+				{Key: "vcs", Value: "git"},
+				{Key: "vcs.revision", Value: "0123456789abcdef"},
+				{Key: "vcs.time", Value: "2024-10-01T12:00:00Z"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		),
+	)
+}
+
+func TestValidateSpecChangelogAndSupportURLs(t *testing.T) {
+	t.Parallel()
+
+	validateSpecError := &validateSpecError{}
+
+	pluginInfo, err := NewPluginInfoForSpec(
+		&Spec{
+			ChangelogURL: "https://foo.com/changelog",
+			SupportURL:   "https://foo.com/issues",
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, pluginInfo.ChangelogURL())
+	require.Equal(t, "https://foo.com/changelog", pluginInfo.ChangelogURL().String())
+	require.NotNil(t, pluginInfo.SupportURL())
+	require.Equal(t, "https://foo.com/issues", pluginInfo.SupportURL().String())
+	pluginInfo, err = NewPluginInfoForSpec(&Spec{})
+	require.NoError(t, err)
+	require.Nil(t, pluginInfo.ChangelogURL())
+	require.Nil(t, pluginInfo.SupportURL())
+
+	require.ErrorAs(t, ValidateSpec(&Spec{ChangelogURL: "changelog"}), &validateSpecError)
+	require.ErrorAs(t, ValidateSpec(&Spec{SupportURL: "/issues"}), &validateSpecError)
+}
+
+func TestValidateSpecStrict(t *testing.T) {
+	t.Parallel()
+
+	validateSpecError := &validateSpecError{}
+
+	spec := &Spec{
+		Documentation: "# Plugin\n\nChecks things.\n\nMore details.",
+		URL:           "https://foo.com/plugin",
+		SPDXLicenseID: "apache-2.0",
+	}
+	require.NoError(t, ValidateSpecStrict(spec))
+	pluginInfo, err := NewPluginInfoForSpec(spec)
+	require.NoError(t, err)
+	require.NotNil(t, pluginInfo.URL())
+	require.Equal(t, "https://foo.com/plugin", pluginInfo.URL().String())
+
+	// Passes ValidateSpec, but not ValidateSpecStrict.
+	for _, invalidSpec := range []*Spec{
+		{},
+		{
+			Documentation: "# Only a heading",
+			URL:           "https://foo.com/plugin",
+			SPDXLicenseID: "apache-2.0",
+		},
+		{
+			Documentation:      "Checks things.",
+			ShortDocumentation: strings.Repeat("a", 161),
+			URL:                "https://foo.com/plugin",
+			SPDXLicenseID:      "apache-2.0",
+		},
+		{
+			Documentation: "Checks things.",
+			SPDXLicenseID: "apache-2.0",
+		},
+		{
+			Documentation: "Checks things.",
+			URL:           "https://foo.com/plugin",
+		},
+	} {
+		require.NoError(t, ValidateSpec(invalidSpec))
+		require.ErrorAs(t, ValidateSpecStrict(invalidSpec), &validateSpecError)
+	}
+	require.ErrorAs(t, ValidateSpecStrict(&Spec{URL: "foo"}), &validateSpecError)
+}
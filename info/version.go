@@ -0,0 +1,148 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"buf.build/go/bufplugin/internal/pkg/compare"
+)
+
+// ProtocolVersion is the version of the Bufplugin protocol implemented by this package.
+//
+// This corresponds to the version suffix of the buf.plugin.*.v1 Protobuf packages.
+const ProtocolVersion = 1
+
+// semverRegexp is the regular expression for a valid semantic version, with an optional
+// leading "v".
+//
+// See https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
+var semverRegexp = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// BuildVersion is the version of the plugin, as injected at build time.
+//
+// This is meant to be set via ldflags, for example:
+//
+//	go build -ldflags "-X buf.build/go/bufplugin/info.BuildVersion=v1.2.3" ./cmd/buf-plugin-foo
+//
+// If a Spec does not have a Version set, BuildVersion will be used instead. If set,
+// BuildVersion must be a valid semantic version.
+var BuildVersion string
+
+// CheckCompatibility checks that the caller meets the minimum versions required by the plugin.
+//
+// The bufVersion is the version of buf that is invoking the plugin. If bufVersion is empty,
+// the minimum buf version is not checked. The protocol version is checked against ProtocolVersion.
+//
+// Returns an error that describes what needs to be upgraded if the caller is too old.
+//
+// Clients for check plugins call this before their first call to the plugin, see
+// check.ClientWithBufVersion.
+func CheckCompatibility(pluginInfo PluginInfo, bufVersion string) error {
+	if minimumProtocolVersion := pluginInfo.MinimumProtocolVersion(); minimumProtocolVersion > ProtocolVersion {
+		return fmt.Errorf(
+			"plugin requires Bufplugin protocol version %d or later, but the caller supports version %d: upgrade buf to use this plugin",
+			minimumProtocolVersion,
+			ProtocolVersion,
+		)
+	}
+	minimumBufVersion := pluginInfo.MinimumBufVersion()
+	if minimumBufVersion == "" || bufVersion == "" {
+		return nil
+	}
+	if !isValidVersion(bufVersion) {
+		return fmt.Errorf("invalid buf version: must be a semantic version: %q", bufVersion)
+	}
+	if compareVersions(bufVersion, minimumBufVersion) < 0 {
+		return fmt.Errorf(
+			"plugin requires buf %s or later, but buf version is %s: upgrade buf to use this plugin",
+			minimumBufVersion,
+			bufVersion,
+		)
+	}
+	return nil
+}
+
+// *** PRIVATE ***
+
+func isValidVersion(version string) bool {
+	return semverRegexp.MatchString(version)
+}
+
+// compareVersions compares two valid semantic versions per https://semver.org/#spec-item-11.
+//
+// Returns -1 if one < two, 1 if one > two, 0 otherwise. Build metadata is ignored.
+func compareVersions(one string, two string) int {
+	oneCore, onePrerelease := splitVersion(one)
+	twoCore, twoPrerelease := splitVersion(two)
+	for i := range 3 {
+		if compare := compareNumericIdentifiers(oneCore[i], twoCore[i]); compare != 0 {
+			return compare
+		}
+	}
+	switch {
+	case onePrerelease == "" && twoPrerelease == "":
+		return 0
+	case onePrerelease == "":
+		return 1
+	case twoPrerelease == "":
+		return -1
+	}
+	oneIdentifiers := strings.Split(onePrerelease, ".")
+	twoIdentifiers := strings.Split(twoPrerelease, ".")
+	for i := 0; i < len(oneIdentifiers) && i < len(twoIdentifiers); i++ {
+		if compare := comparePrereleaseIdentifiers(oneIdentifiers[i], twoIdentifiers[i]); compare != 0 {
+			return compare
+		}
+	}
+	return compare.CompareInts(len(oneIdentifiers), len(twoIdentifiers))
+}
+
+// splitVersion splits a valid semantic version into its major, minor, and patch
+// components, and its prerelease, if any.
+func splitVersion(version string) ([]string, string) {
+	version = strings.TrimPrefix(version, "v")
+	version, _, _ = strings.Cut(version, "+")
+	version, prerelease, _ := strings.Cut(version, "-")
+	return strings.Split(version, "."), prerelease
+}
+
+func comparePrereleaseIdentifiers(one string, two string) int {
+	oneInt, oneErr := strconv.ParseUint(one, 10, 64)
+	twoInt, twoErr := strconv.ParseUint(two, 10, 64)
+	switch {
+	case oneErr == nil && twoErr == nil:
+		return cmp.Compare(oneInt, twoInt)
+	case oneErr == nil:
+		// Numeric identifiers always have lower precedence than alphanumeric identifiers.
+		return -1
+	case twoErr == nil:
+		return 1
+	default:
+		return strings.Compare(one, two)
+	}
+}
+
+// Both identifiers are assumed to be valid numeric identifiers with no leading zeros.
+func compareNumericIdentifiers(one string, two string) int {
+	if compare := compare.CompareInts(len(one), len(two)); compare != 0 {
+		return compare
+	}
+	return strings.Compare(one, two)
+}
@@ -0,0 +1,75 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package info
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	testCompareVersions(t, "v1.0.0", "v1.0.0", 0)
+	testCompareVersions(t, "1.0.0", "v1.0.0", 0)
+	testCompareVersions(t, "v1.0.0+build.1", "v1.0.0+build.2", 0)
+	testCompareVersions(t, "v1.0.0", "v2.0.0", -1)
+	testCompareVersions(t, "v1.9.0", "v1.10.0", -1)
+	testCompareVersions(t, "v1.0.10", "v1.0.9", 1)
+	testCompareVersions(t, "v1.0.0-rc.1", "v1.0.0", -1)
+	// Examples from https://semver.org/#spec-item-11.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+	}
+	for i := 1; i < len(ordered); i++ {
+		testCompareVersions(t, ordered[i-1], ordered[i], -1)
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Parallel()
+
+	pluginInfo, err := NewPluginInfoForSpec(
+		&Spec{
+			MinimumBufVersion:      "v1.50.0",
+			MinimumProtocolVersion: ProtocolVersion,
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, CheckCompatibility(pluginInfo, "v1.50.0"))
+	require.NoError(t, CheckCompatibility(pluginInfo, "1.51.2"))
+	require.NoError(t, CheckCompatibility(pluginInfo, ""))
+	err = CheckCompatibility(pluginInfo, "v1.49.0")
+	require.ErrorContains(t, err, "upgrade buf")
+	err = CheckCompatibility(pluginInfo, "v1.50.0-rc.1")
+	require.ErrorContains(t, err, "upgrade buf")
+	require.Error(t, CheckCompatibility(pluginInfo, "latest"))
+
+	require.Error(t, ValidateSpec(&Spec{MinimumProtocolVersion: ProtocolVersion + 1}))
+	require.Error(t, ValidateSpec(&Spec{MinimumBufVersion: "1.50"}))
+}
+
+func testCompareVersions(t *testing.T, one string, two string, expected int) {
+	require.Equal(t, expected, compareVersions(one, two), "%s vs %s", one, two)
+	require.Equal(t, -expected, compareVersions(two, one), "%s vs %s", two, one)
+}
@@ -0,0 +1,133 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-pluginrpc-go. DO NOT EDIT.
+//
+// Source: bufplugin/ext/check/v1/policy_service.proto
+
+// Extensions to the buf.plugin.check.v1 protocol implemented by this SDK, see the check package.
+package checkv1pluginrpc
+
+import (
+	v1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1"
+	context "context"
+	fmt "fmt"
+	pluginrpc "pluginrpc.com/pluginrpc"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the pluginrpc package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of pluginrpc newer than the one compiled into your binary. You can fix
+// the problem by either regenerating this code with an older version of pluginrpc or updating the
+// pluginrpc version compiled into your binary.
+const _ = pluginrpc.IsAtLeastVersion0_1_0
+
+const (
+	// PolicyServiceListPoliciesPath is the path of the PolicyService's ListPolicies RPC.
+	PolicyServiceListPoliciesPath = "/bufplugin.ext.check.v1.PolicyService/ListPolicies"
+)
+
+// PolicyServiceSpecBuilder builds a Spec for the bufplugin.ext.check.v1.PolicyService service.
+type PolicyServiceSpecBuilder struct {
+	ListPolicies []pluginrpc.ProcedureOption
+}
+
+// Build builds a Spec for the bufplugin.ext.check.v1.PolicyService service.
+func (s PolicyServiceSpecBuilder) Build() (pluginrpc.Spec, error) {
+	procedures := make([]pluginrpc.Procedure, 0, 1)
+	procedure, err := pluginrpc.NewProcedure(PolicyServiceListPoliciesPath, s.ListPolicies...)
+	if err != nil {
+		return nil, err
+	}
+	procedures = append(procedures, procedure)
+	return pluginrpc.NewSpec(procedures...)
+}
+
+// PolicyServiceClient is a client for the bufplugin.ext.check.v1.PolicyService service.
+type PolicyServiceClient interface {
+	// ListPolicies lists the policies of all policy rules of the plugin.
+	ListPolicies(context.Context, *v1.ListPoliciesRequest, ...pluginrpc.CallOption) (*v1.ListPoliciesResponse, error)
+}
+
+// NewPolicyServiceClient constructs a client for the bufplugin.ext.check.v1.PolicyService service.
+func NewPolicyServiceClient(client pluginrpc.Client) (PolicyServiceClient, error) {
+	return &policyServiceClient{
+		client: client,
+	}, nil
+}
+
+// PolicyServiceHandler is an implementation of the bufplugin.ext.check.v1.PolicyService service.
+type PolicyServiceHandler interface {
+	// ListPolicies lists the policies of all policy rules of the plugin.
+	ListPolicies(context.Context, *v1.ListPoliciesRequest) (*v1.ListPoliciesResponse, error)
+}
+
+// PolicyServiceServer serves the bufplugin.ext.check.v1.PolicyService service.
+type PolicyServiceServer interface {
+	// ListPolicies lists the policies of all policy rules of the plugin.
+	ListPolicies(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error
+}
+
+// NewPolicyServiceServer constructs a server for the bufplugin.ext.check.v1.PolicyService service.
+func NewPolicyServiceServer(handler pluginrpc.Handler, policyServiceHandler PolicyServiceHandler) PolicyServiceServer {
+	return &policyServiceServer{
+		handler:              handler,
+		policyServiceHandler: policyServiceHandler,
+	}
+}
+
+// RegisterPolicyServiceServer registers the server for the bufplugin.ext.check.v1.PolicyService
+// service.
+func RegisterPolicyServiceServer(serverRegistrar pluginrpc.ServerRegistrar, policyServiceServer PolicyServiceServer) {
+	serverRegistrar.Register(PolicyServiceListPoliciesPath, policyServiceServer.ListPolicies)
+}
+
+// *** PRIVATE ***
+
+// policyServiceClient implements PolicyServiceClient.
+type policyServiceClient struct {
+	client pluginrpc.Client
+}
+
+// ListPolicies calls bufplugin.ext.check.v1.PolicyService.ListPolicies.
+func (c *policyServiceClient) ListPolicies(ctx context.Context, req *v1.ListPoliciesRequest, opts ...pluginrpc.CallOption) (*v1.ListPoliciesResponse, error) {
+	res := &v1.ListPoliciesResponse{}
+	if err := c.client.Call(ctx, PolicyServiceListPoliciesPath, req, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// policyServiceServer implements PolicyServiceServer.
+type policyServiceServer struct {
+	handler              pluginrpc.Handler
+	policyServiceHandler PolicyServiceHandler
+}
+
+// ListPolicies calls bufplugin.ext.check.v1.PolicyService.ListPolicies.
+func (c *policyServiceServer) ListPolicies(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+	return c.handler.Handle(
+		ctx,
+		handleEnv,
+		&v1.ListPoliciesRequest{},
+		func(ctx context.Context, anyReq any) (any, error) {
+			req, ok := anyReq.(*v1.ListPoliciesRequest)
+			if !ok {
+				return nil, fmt.Errorf("could not cast %T to a *v1.ListPoliciesRequest", anyReq)
+			}
+			return c.policyServiceHandler.ListPolicies(ctx, req)
+		},
+		options...,
+	)
+}
@@ -0,0 +1,141 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-pluginrpc-go. DO NOT EDIT.
+//
+// Source: bufplugin/ext/check/v1/severity_service.proto
+
+package checkv1pluginrpc
+
+import (
+	v1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1"
+	context "context"
+	fmt "fmt"
+	pluginrpc "pluginrpc.com/pluginrpc"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the pluginrpc package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of pluginrpc newer than the one compiled into your binary. You can fix
+// the problem by either regenerating this code with an older version of pluginrpc or updating the
+// pluginrpc version compiled into your binary.
+const _ = pluginrpc.IsAtLeastVersion0_1_0
+
+const (
+	// SeverityServiceCheckWithSeverityOverridesPath is the path of the SeverityService's
+	// CheckWithSeverityOverrides RPC.
+	SeverityServiceCheckWithSeverityOverridesPath = "/bufplugin.ext.check.v1.SeverityService/CheckWithSeverityOverrides"
+)
+
+// SeverityServiceSpecBuilder builds a Spec for the bufplugin.ext.check.v1.SeverityService service.
+type SeverityServiceSpecBuilder struct {
+	CheckWithSeverityOverrides []pluginrpc.ProcedureOption
+}
+
+// Build builds a Spec for the bufplugin.ext.check.v1.SeverityService service.
+func (s SeverityServiceSpecBuilder) Build() (pluginrpc.Spec, error) {
+	procedures := make([]pluginrpc.Procedure, 0, 1)
+	procedure, err := pluginrpc.NewProcedure(SeverityServiceCheckWithSeverityOverridesPath, s.CheckWithSeverityOverrides...)
+	if err != nil {
+		return nil, err
+	}
+	procedures = append(procedures, procedure)
+	return pluginrpc.NewSpec(procedures...)
+}
+
+// SeverityServiceClient is a client for the bufplugin.ext.check.v1.SeverityService service.
+type SeverityServiceClient interface {
+	// CheckWithSeverityOverrides runs the checks of the request, and returns the
+	// severity that the plugin set on each annotation.
+	CheckWithSeverityOverrides(context.Context, *v1.CheckWithSeverityOverridesRequest, ...pluginrpc.CallOption) (*v1.CheckWithSeverityOverridesResponse, error)
+}
+
+// NewSeverityServiceClient constructs a client for the bufplugin.ext.check.v1.SeverityService
+// service.
+func NewSeverityServiceClient(client pluginrpc.Client) (SeverityServiceClient, error) {
+	return &severityServiceClient{
+		client: client,
+	}, nil
+}
+
+// SeverityServiceHandler is an implementation of the bufplugin.ext.check.v1.SeverityService
+// service.
+type SeverityServiceHandler interface {
+	// CheckWithSeverityOverrides runs the checks of the request, and returns the
+	// severity that the plugin set on each annotation.
+	CheckWithSeverityOverrides(context.Context, *v1.CheckWithSeverityOverridesRequest) (*v1.CheckWithSeverityOverridesResponse, error)
+}
+
+// SeverityServiceServer serves the bufplugin.ext.check.v1.SeverityService service.
+type SeverityServiceServer interface {
+	// CheckWithSeverityOverrides runs the checks of the request, and returns the
+	// severity that the plugin set on each annotation.
+	CheckWithSeverityOverrides(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error
+}
+
+// NewSeverityServiceServer constructs a server for the bufplugin.ext.check.v1.SeverityService
+// service.
+func NewSeverityServiceServer(handler pluginrpc.Handler, severityServiceHandler SeverityServiceHandler) SeverityServiceServer {
+	return &severityServiceServer{
+		handler:                handler,
+		severityServiceHandler: severityServiceHandler,
+	}
+}
+
+// RegisterSeverityServiceServer registers the server for the bufplugin.ext.check.v1.SeverityService
+// service.
+func RegisterSeverityServiceServer(serverRegistrar pluginrpc.ServerRegistrar, severityServiceServer SeverityServiceServer) {
+	serverRegistrar.Register(SeverityServiceCheckWithSeverityOverridesPath, severityServiceServer.CheckWithSeverityOverrides)
+}
+
+// *** PRIVATE ***
+
+// severityServiceClient implements SeverityServiceClient.
+type severityServiceClient struct {
+	client pluginrpc.Client
+}
+
+// CheckWithSeverityOverrides calls
+// bufplugin.ext.check.v1.SeverityService.CheckWithSeverityOverrides.
+func (c *severityServiceClient) CheckWithSeverityOverrides(ctx context.Context, req *v1.CheckWithSeverityOverridesRequest, opts ...pluginrpc.CallOption) (*v1.CheckWithSeverityOverridesResponse, error) {
+	res := &v1.CheckWithSeverityOverridesResponse{}
+	if err := c.client.Call(ctx, SeverityServiceCheckWithSeverityOverridesPath, req, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// severityServiceServer implements SeverityServiceServer.
+type severityServiceServer struct {
+	handler                pluginrpc.Handler
+	severityServiceHandler SeverityServiceHandler
+}
+
+// CheckWithSeverityOverrides calls
+// bufplugin.ext.check.v1.SeverityService.CheckWithSeverityOverrides.
+func (c *severityServiceServer) CheckWithSeverityOverrides(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+	return c.handler.Handle(
+		ctx,
+		handleEnv,
+		&v1.CheckWithSeverityOverridesRequest{},
+		func(ctx context.Context, anyReq any) (any, error) {
+			req, ok := anyReq.(*v1.CheckWithSeverityOverridesRequest)
+			if !ok {
+				return nil, fmt.Errorf("could not cast %T to a *v1.CheckWithSeverityOverridesRequest", anyReq)
+			}
+			return c.severityServiceHandler.CheckWithSeverityOverrides(ctx, req)
+		},
+		options...,
+	)
+}
@@ -0,0 +1,132 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-pluginrpc-go. DO NOT EDIT.
+//
+// Source: bufplugin/ext/check/v1/state_service.proto
+
+package checkv1pluginrpc
+
+import (
+	v1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/check/v1"
+	context "context"
+	fmt "fmt"
+	pluginrpc "pluginrpc.com/pluginrpc"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the pluginrpc package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of pluginrpc newer than the one compiled into your binary. You can fix
+// the problem by either regenerating this code with an older version of pluginrpc or updating the
+// pluginrpc version compiled into your binary.
+const _ = pluginrpc.IsAtLeastVersion0_1_0
+
+const (
+	// StateServiceCheckWithStatePath is the path of the StateService's CheckWithState RPC.
+	StateServiceCheckWithStatePath = "/bufplugin.ext.check.v1.StateService/CheckWithState"
+)
+
+// StateServiceSpecBuilder builds a Spec for the bufplugin.ext.check.v1.StateService service.
+type StateServiceSpecBuilder struct {
+	CheckWithState []pluginrpc.ProcedureOption
+}
+
+// Build builds a Spec for the bufplugin.ext.check.v1.StateService service.
+func (s StateServiceSpecBuilder) Build() (pluginrpc.Spec, error) {
+	procedures := make([]pluginrpc.Procedure, 0, 1)
+	procedure, err := pluginrpc.NewProcedure(StateServiceCheckWithStatePath, s.CheckWithState...)
+	if err != nil {
+		return nil, err
+	}
+	procedures = append(procedures, procedure)
+	return pluginrpc.NewSpec(procedures...)
+}
+
+// StateServiceClient is a client for the bufplugin.ext.check.v1.StateService service.
+type StateServiceClient interface {
+	// CheckWithState runs the checks of the request, and returns the updated state.
+	CheckWithState(context.Context, *v1.CheckWithStateRequest, ...pluginrpc.CallOption) (*v1.CheckWithStateResponse, error)
+}
+
+// NewStateServiceClient constructs a client for the bufplugin.ext.check.v1.StateService service.
+func NewStateServiceClient(client pluginrpc.Client) (StateServiceClient, error) {
+	return &stateServiceClient{
+		client: client,
+	}, nil
+}
+
+// StateServiceHandler is an implementation of the bufplugin.ext.check.v1.StateService service.
+type StateServiceHandler interface {
+	// CheckWithState runs the checks of the request, and returns the updated state.
+	CheckWithState(context.Context, *v1.CheckWithStateRequest) (*v1.CheckWithStateResponse, error)
+}
+
+// StateServiceServer serves the bufplugin.ext.check.v1.StateService service.
+type StateServiceServer interface {
+	// CheckWithState runs the checks of the request, and returns the updated state.
+	CheckWithState(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error
+}
+
+// NewStateServiceServer constructs a server for the bufplugin.ext.check.v1.StateService service.
+func NewStateServiceServer(handler pluginrpc.Handler, stateServiceHandler StateServiceHandler) StateServiceServer {
+	return &stateServiceServer{
+		handler:             handler,
+		stateServiceHandler: stateServiceHandler,
+	}
+}
+
+// RegisterStateServiceServer registers the server for the bufplugin.ext.check.v1.StateService
+// service.
+func RegisterStateServiceServer(serverRegistrar pluginrpc.ServerRegistrar, stateServiceServer StateServiceServer) {
+	serverRegistrar.Register(StateServiceCheckWithStatePath, stateServiceServer.CheckWithState)
+}
+
+// *** PRIVATE ***
+
+// stateServiceClient implements StateServiceClient.
+type stateServiceClient struct {
+	client pluginrpc.Client
+}
+
+// CheckWithState calls bufplugin.ext.check.v1.StateService.CheckWithState.
+func (c *stateServiceClient) CheckWithState(ctx context.Context, req *v1.CheckWithStateRequest, opts ...pluginrpc.CallOption) (*v1.CheckWithStateResponse, error) {
+	res := &v1.CheckWithStateResponse{}
+	if err := c.client.Call(ctx, StateServiceCheckWithStatePath, req, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// stateServiceServer implements StateServiceServer.
+type stateServiceServer struct {
+	handler             pluginrpc.Handler
+	stateServiceHandler StateServiceHandler
+}
+
+// CheckWithState calls bufplugin.ext.check.v1.StateService.CheckWithState.
+func (c *stateServiceServer) CheckWithState(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+	return c.handler.Handle(
+		ctx,
+		handleEnv,
+		&v1.CheckWithStateRequest{},
+		func(ctx context.Context, anyReq any) (any, error) {
+			req, ok := anyReq.(*v1.CheckWithStateRequest)
+			if !ok {
+				return nil, fmt.Errorf("could not cast %T to a *v1.CheckWithStateRequest", anyReq)
+			}
+			return c.stateServiceHandler.CheckWithState(ctx, req)
+		},
+		options...,
+	)
+}
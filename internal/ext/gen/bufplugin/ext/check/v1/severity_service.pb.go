@@ -0,0 +1,297 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.2
+// 	protoc        (unknown)
+// source: bufplugin/ext/check/v1/severity_service.proto
+
+package checkv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A request to run checks with severity overrides.
+type CheckWithSeverityOverridesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The binary encoding of the buf.plugin.check.v1.CheckRequest.
+	//
+	// Required. The options must not contain the severity_overrides key.
+	CheckRequest []byte `protobuf:"bytes,1,opt,name=check_request,json=checkRequest,proto3" json:"check_request,omitempty"`
+	// The severity to set on the annotations of each rule, by rule ID.
+	//
+	// Required. Every rule ID must be a rule of the plugin.
+	RuleIdToSeverity map[string]Severity `protobuf:"bytes,2,rep,name=rule_id_to_severity,json=ruleIdToSeverity,proto3" json:"rule_id_to_severity,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=bufplugin.ext.check.v1.Severity"`
+	// The state returned from a previous call, in the binary encoding of State.
+	//
+	// Optional. If not set, the checks are run without state. If set and empty, there
+	// is no previous state.
+	State         []byte `protobuf:"bytes,3,opt,name=state,proto3,oneof" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckWithSeverityOverridesRequest) Reset() {
+	*x = CheckWithSeverityOverridesRequest{}
+	mi := &file_bufplugin_ext_check_v1_severity_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckWithSeverityOverridesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckWithSeverityOverridesRequest) ProtoMessage() {}
+
+func (x *CheckWithSeverityOverridesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_check_v1_severity_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckWithSeverityOverridesRequest.ProtoReflect.Descriptor instead.
+func (*CheckWithSeverityOverridesRequest) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_check_v1_severity_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckWithSeverityOverridesRequest) GetCheckRequest() []byte {
+	if x != nil {
+		return x.CheckRequest
+	}
+	return nil
+}
+
+func (x *CheckWithSeverityOverridesRequest) GetRuleIdToSeverity() map[string]Severity {
+	if x != nil {
+		return x.RuleIdToSeverity
+	}
+	return nil
+}
+
+func (x *CheckWithSeverityOverridesRequest) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// A response to a request to run checks with severity overrides.
+type CheckWithSeverityOverridesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The binary encoding of the buf.plugin.check.v1.CheckResponse.
+	CheckResponse []byte `protobuf:"bytes,1,opt,name=check_response,json=checkResponse,proto3" json:"check_response,omitempty"`
+	// The severity of each annotation of the check response, in the same order.
+	//
+	// SEVERITY_UNSPECIFIED for annotations whose severity was not overridden.
+	AnnotationSeverities []Severity `protobuf:"varint,2,rep,packed,name=annotation_severities,json=annotationSeverities,proto3,enum=bufplugin.ext.check.v1.Severity" json:"annotation_severities,omitempty"`
+	// The updated state, in the binary encoding of State.
+	//
+	// Only set if the state of the request was set.
+	State         []byte `protobuf:"bytes,3,opt,name=state,proto3,oneof" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckWithSeverityOverridesResponse) Reset() {
+	*x = CheckWithSeverityOverridesResponse{}
+	mi := &file_bufplugin_ext_check_v1_severity_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckWithSeverityOverridesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckWithSeverityOverridesResponse) ProtoMessage() {}
+
+func (x *CheckWithSeverityOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_check_v1_severity_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckWithSeverityOverridesResponse.ProtoReflect.Descriptor instead.
+func (*CheckWithSeverityOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_check_v1_severity_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckWithSeverityOverridesResponse) GetCheckResponse() []byte {
+	if x != nil {
+		return x.CheckResponse
+	}
+	return nil
+}
+
+func (x *CheckWithSeverityOverridesResponse) GetAnnotationSeverities() []Severity {
+	if x != nil {
+		return x.AnnotationSeverities
+	}
+	return nil
+}
+
+func (x *CheckWithSeverityOverridesResponse) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+var File_bufplugin_ext_check_v1_severity_service_proto protoreflect.FileDescriptor
+
+var file_bufplugin_ext_check_v1_severity_service_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
+	0x79, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x16, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x1a, 0x2b, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2f, 0x76, 0x31, 0x2f,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd4, 0x02, 0x0a, 0x21, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x69,
+	0x74, 0x68, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x7e, 0x0a, 0x13, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x74, 0x6f, 0x5f, 0x73, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4f, 0x2e, 0x62,
+	0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x69, 0x74, 0x68, 0x53,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x54, 0x6f,
+	0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x72,
+	0x75, 0x6c, 0x65, 0x49, 0x64, 0x54, 0x6f, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x19, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x1a, 0x65, 0x0a, 0x15, 0x52, 0x75,
+	0x6c, 0x65, 0x49, 0x64, 0x54, 0x6f, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x2e, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0xc7, 0x01, 0x0a, 0x22,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x69, 0x74, 0x68, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
+	0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x15, 0x61, 0x6e, 0x6e,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x14, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x19, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48,
+	0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x32, 0xa7, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x93, 0x01, 0x0a, 0x1a, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x57, 0x69, 0x74, 0x68, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x4f,
+	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x39, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x69, 0x74, 0x68, 0x53, 0x65, 0x76, 0x65, 0x72,
+	0x69, 0x74, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x57, 0x69, 0x74, 0x68, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x4f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x48, 0x5a, 0x46, 0x62, 0x75, 0x66, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x67, 0x6f, 0x2f,
+	0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x65, 0x78, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x62, 0x75, 0x66, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2f, 0x76,
+	0x31, 0x3b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_bufplugin_ext_check_v1_severity_service_proto_rawDescOnce sync.Once
+	file_bufplugin_ext_check_v1_severity_service_proto_rawDescData = file_bufplugin_ext_check_v1_severity_service_proto_rawDesc
+)
+
+func file_bufplugin_ext_check_v1_severity_service_proto_rawDescGZIP() []byte {
+	file_bufplugin_ext_check_v1_severity_service_proto_rawDescOnce.Do(func() {
+		file_bufplugin_ext_check_v1_severity_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_bufplugin_ext_check_v1_severity_service_proto_rawDescData)
+	})
+	return file_bufplugin_ext_check_v1_severity_service_proto_rawDescData
+}
+
+var file_bufplugin_ext_check_v1_severity_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_bufplugin_ext_check_v1_severity_service_proto_goTypes = []any{
+	(*CheckWithSeverityOverridesRequest)(nil),  // 0: bufplugin.ext.check.v1.CheckWithSeverityOverridesRequest
+	(*CheckWithSeverityOverridesResponse)(nil), // 1: bufplugin.ext.check.v1.CheckWithSeverityOverridesResponse
+	nil,           // 2: bufplugin.ext.check.v1.CheckWithSeverityOverridesRequest.RuleIdToSeverityEntry
+	(Severity)(0), // 3: bufplugin.ext.check.v1.Severity
+}
+var file_bufplugin_ext_check_v1_severity_service_proto_depIdxs = []int32{
+	2, // 0: bufplugin.ext.check.v1.CheckWithSeverityOverridesRequest.rule_id_to_severity:type_name -> bufplugin.ext.check.v1.CheckWithSeverityOverridesRequest.RuleIdToSeverityEntry
+	3, // 1: bufplugin.ext.check.v1.CheckWithSeverityOverridesResponse.annotation_severities:type_name -> bufplugin.ext.check.v1.Severity
+	3, // 2: bufplugin.ext.check.v1.CheckWithSeverityOverridesRequest.RuleIdToSeverityEntry.value:type_name -> bufplugin.ext.check.v1.Severity
+	0, // 3: bufplugin.ext.check.v1.SeverityService.CheckWithSeverityOverrides:input_type -> bufplugin.ext.check.v1.CheckWithSeverityOverridesRequest
+	1, // 4: bufplugin.ext.check.v1.SeverityService.CheckWithSeverityOverrides:output_type -> bufplugin.ext.check.v1.CheckWithSeverityOverridesResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_bufplugin_ext_check_v1_severity_service_proto_init() }
+func file_bufplugin_ext_check_v1_severity_service_proto_init() {
+	if File_bufplugin_ext_check_v1_severity_service_proto != nil {
+		return
+	}
+	file_bufplugin_ext_check_v1_policy_service_proto_init()
+	file_bufplugin_ext_check_v1_severity_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_bufplugin_ext_check_v1_severity_service_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bufplugin_ext_check_v1_severity_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bufplugin_ext_check_v1_severity_service_proto_goTypes,
+		DependencyIndexes: file_bufplugin_ext_check_v1_severity_service_proto_depIdxs,
+		MessageInfos:      file_bufplugin_ext_check_v1_severity_service_proto_msgTypes,
+	}.Build()
+	File_bufplugin_ext_check_v1_severity_service_proto = out.File
+	file_bufplugin_ext_check_v1_severity_service_proto_rawDesc = nil
+	file_bufplugin_ext_check_v1_severity_service_proto_goTypes = nil
+	file_bufplugin_ext_check_v1_severity_service_proto_depIdxs = nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.2
+// 	protoc        (unknown)
+// source: bufplugin/ext/check/v1/state_service.proto
+
+package checkv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A request to run checks with state.
+type CheckWithStateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The binary encoding of the buf.plugin.check.v1.CheckRequest.
+	//
+	// Required.
+	CheckRequest []byte `protobuf:"bytes,1,opt,name=check_request,json=checkRequest,proto3" json:"check_request,omitempty"`
+	// The state returned from a previous call, in the binary encoding of State.
+	//
+	// Optional. If empty, there is no previous state.
+	State         []byte `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckWithStateRequest) Reset() {
+	*x = CheckWithStateRequest{}
+	mi := &file_bufplugin_ext_check_v1_state_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckWithStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckWithStateRequest) ProtoMessage() {}
+
+func (x *CheckWithStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_check_v1_state_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckWithStateRequest.ProtoReflect.Descriptor instead.
+func (*CheckWithStateRequest) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_check_v1_state_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckWithStateRequest) GetCheckRequest() []byte {
+	if x != nil {
+		return x.CheckRequest
+	}
+	return nil
+}
+
+func (x *CheckWithStateRequest) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// A response to a request to run checks with state.
+type CheckWithStateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The binary encoding of the buf.plugin.check.v1.CheckResponse.
+	CheckResponse []byte `protobuf:"bytes,1,opt,name=check_response,json=checkResponse,proto3" json:"check_response,omitempty"`
+	// The updated state, in the binary encoding of State.
+	State         []byte `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckWithStateResponse) Reset() {
+	*x = CheckWithStateResponse{}
+	mi := &file_bufplugin_ext_check_v1_state_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckWithStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckWithStateResponse) ProtoMessage() {}
+
+func (x *CheckWithStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_check_v1_state_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckWithStateResponse.ProtoReflect.Descriptor instead.
+func (*CheckWithStateResponse) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_check_v1_state_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckWithStateResponse) GetCheckResponse() []byte {
+	if x != nil {
+		return x.CheckResponse
+	}
+	return nil
+}
+
+func (x *CheckWithStateResponse) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// The state of a plugin, which is opaque to clients.
+type State struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The state set by each rule, by rule ID.
+	RuleIdToState map[string][]byte `protobuf:"bytes,1,rep,name=rule_id_to_state,json=ruleIdToState,proto3" json:"rule_id_to_state,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	mi := &file_bufplugin_ext_check_v1_state_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_check_v1_state_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_check_v1_state_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *State) GetRuleIdToState() map[string][]byte {
+	if x != nil {
+		return x.RuleIdToState
+	}
+	return nil
+}
+
+var File_bufplugin_ext_check_v1_state_service_proto protoreflect.FileDescriptor
+
+var file_bufplugin_ext_check_v1_state_service_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x62, 0x75,
+	0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x2e, 0x76, 0x31, 0x22, 0x52, 0x0a, 0x15, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x55, 0x0a, 0x16, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22,
+	0xa4, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x59, 0x0a, 0x10, 0x72, 0x75, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x5f, 0x74, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x54, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x54, 0x6f, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x1a, 0x40, 0x0a, 0x12, 0x52, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x54, 0x6f,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x7f, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6f, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57,
+	0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x48, 0x5a, 0x46, 0x62, 0x75, 0x66, 0x2e, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x74, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74,
+	0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bufplugin_ext_check_v1_state_service_proto_rawDescOnce sync.Once
+	file_bufplugin_ext_check_v1_state_service_proto_rawDescData = file_bufplugin_ext_check_v1_state_service_proto_rawDesc
+)
+
+func file_bufplugin_ext_check_v1_state_service_proto_rawDescGZIP() []byte {
+	file_bufplugin_ext_check_v1_state_service_proto_rawDescOnce.Do(func() {
+		file_bufplugin_ext_check_v1_state_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_bufplugin_ext_check_v1_state_service_proto_rawDescData)
+	})
+	return file_bufplugin_ext_check_v1_state_service_proto_rawDescData
+}
+
+var file_bufplugin_ext_check_v1_state_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_bufplugin_ext_check_v1_state_service_proto_goTypes = []any{
+	(*CheckWithStateRequest)(nil),  // 0: bufplugin.ext.check.v1.CheckWithStateRequest
+	(*CheckWithStateResponse)(nil), // 1: bufplugin.ext.check.v1.CheckWithStateResponse
+	(*State)(nil),                  // 2: bufplugin.ext.check.v1.State
+	nil,                            // 3: bufplugin.ext.check.v1.State.RuleIdToStateEntry
+}
+var file_bufplugin_ext_check_v1_state_service_proto_depIdxs = []int32{
+	3, // 0: bufplugin.ext.check.v1.State.rule_id_to_state:type_name -> bufplugin.ext.check.v1.State.RuleIdToStateEntry
+	0, // 1: bufplugin.ext.check.v1.StateService.CheckWithState:input_type -> bufplugin.ext.check.v1.CheckWithStateRequest
+	1, // 2: bufplugin.ext.check.v1.StateService.CheckWithState:output_type -> bufplugin.ext.check.v1.CheckWithStateResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_bufplugin_ext_check_v1_state_service_proto_init() }
+func file_bufplugin_ext_check_v1_state_service_proto_init() {
+	if File_bufplugin_ext_check_v1_state_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bufplugin_ext_check_v1_state_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bufplugin_ext_check_v1_state_service_proto_goTypes,
+		DependencyIndexes: file_bufplugin_ext_check_v1_state_service_proto_depIdxs,
+		MessageInfos:      file_bufplugin_ext_check_v1_state_service_proto_msgTypes,
+	}.Build()
+	File_bufplugin_ext_check_v1_state_service_proto = out.File
+	file_bufplugin_ext_check_v1_state_service_proto_rawDesc = nil
+	file_bufplugin_ext_check_v1_state_service_proto_goTypes = nil
+	file_bufplugin_ext_check_v1_state_service_proto_depIdxs = nil
+}
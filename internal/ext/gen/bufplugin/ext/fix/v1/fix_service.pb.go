@@ -0,0 +1,403 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.2
+// 	protoc        (unknown)
+// source: bufplugin/ext/fix/v1/fix_service.proto
+
+// The protocol of fix plugins, see the fix package.
+//
+// Fix plugins are given the same inputs as check plugins, together with the sources of the
+// files to fix. The buf.plugin.* messages are embedded as their binary encoding, as this
+// protocol is not part of the bufplugin API that defines them.
+
+package fixv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A request to fix files.
+type FixRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The binary encoding of the buf.plugin.check.v1.CheckRequest that contains the files,
+	// options, and rules to run.
+	//
+	// Required.
+	CheckRequest []byte `protobuf:"bytes,1,opt,name=check_request,json=checkRequest,proto3" json:"check_request,omitempty"`
+	// The sources of the files to fix, by file name.
+	//
+	// Each file name must be the name of a file within the check_request. Files that do not
+	// have a source are not fixed.
+	Sources       map[string][]byte `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FixRequest) Reset() {
+	*x = FixRequest{}
+	mi := &file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FixRequest) ProtoMessage() {}
+
+func (x *FixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FixRequest.ProtoReflect.Descriptor instead.
+func (*FixRequest) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_fix_v1_fix_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FixRequest) GetCheckRequest() []byte {
+	if x != nil {
+		return x.CheckRequest
+	}
+	return nil
+}
+
+func (x *FixRequest) GetSources() map[string][]byte {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// A response containing fixes.
+type FixResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The fixes, in the order of the rules within the plugin, and then in the order in which
+	// they were added.
+	Fixes         []*Fix `protobuf:"bytes,1,rep,name=fixes,proto3" json:"fixes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FixResponse) Reset() {
+	*x = FixResponse{}
+	mi := &file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FixResponse) ProtoMessage() {}
+
+func (x *FixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FixResponse.ProtoReflect.Descriptor instead.
+func (*FixResponse) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_fix_v1_fix_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FixResponse) GetFixes() []*Fix {
+	if x != nil {
+		return x.Fixes
+	}
+	return nil
+}
+
+// A set of edits that fixes a single problem.
+type Fix struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the rule that added the fix.
+	//
+	// Required.
+	RuleId string `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// A user-readable message describing the fix.
+	//
+	// Optional.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The edits of the fix.
+	//
+	// Required.
+	Edits         []*Edit `protobuf:"bytes,3,rep,name=edits,proto3" json:"edits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fix) Reset() {
+	*x = Fix{}
+	mi := &file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fix) ProtoMessage() {}
+
+func (x *Fix) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fix.ProtoReflect.Descriptor instead.
+func (*Fix) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_fix_v1_fix_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Fix) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *Fix) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Fix) GetEdits() []*Edit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+// A replacement of a byte range within the source of a file.
+type Edit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the file to edit.
+	//
+	// Required.
+	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// The byte offset of the start of the range, inclusive.
+	Start uint32 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	// The byte offset of the end of the range, exclusive.
+	End uint32 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// The bytes that replace the range.
+	//
+	// Optional. If empty, the range is deleted.
+	Replacement   []byte `protobuf:"bytes,4,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Edit) Reset() {
+	*x = Edit{}
+	mi := &file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Edit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edit) ProtoMessage() {}
+
+func (x *Edit) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edit.ProtoReflect.Descriptor instead.
+func (*Edit) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_fix_v1_fix_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Edit) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Edit) GetStart() uint32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Edit) GetEnd() uint32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Edit) GetReplacement() []byte {
+	if x != nil {
+		return x.Replacement
+	}
+	return nil
+}
+
+var File_bufplugin_ext_fix_v1_fix_service_proto protoreflect.FileDescriptor
+
+var file_bufplugin_ext_fix_v1_fix_service_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f,
+	0x66, 0x69, 0x78, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x69, 0x78, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x66, 0x69, 0x78, 0x2e, 0x76, 0x31, 0x22, 0xb6,
+	0x01, 0x0a, 0x0a, 0x46, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x47, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x65, 0x78, 0x74, 0x2e, 0x66, 0x69, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x78, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3e, 0x0a, 0x0b, 0x46, 0x69, 0x78, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x66, 0x69, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x78,
+	0x52, 0x05, 0x66, 0x69, 0x78, 0x65, 0x73, 0x22, 0x6a, 0x0a, 0x03, 0x46, 0x69, 0x78, 0x12, 0x17,
+	0x0a, 0x07, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x30, 0x0a, 0x05, 0x65, 0x64, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74,
+	0x2e, 0x66, 0x69, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x05, 0x65, 0x64,
+	0x69, 0x74, 0x73, 0x22, 0x6d, 0x0a, 0x04, 0x45, 0x64, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x6e, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x32, 0x58, 0x0a, 0x0a, 0x46, 0x69, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x4a, 0x0a, 0x03, 0x46, 0x69, 0x78, 0x12, 0x20, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x66, 0x69, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x75, 0x66, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x66, 0x69, 0x78, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x44, 0x5a, 0x42,
+	0x62, 0x75, 0x66, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x75, 0x66,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x65, 0x78, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f, 0x66, 0x69, 0x78, 0x2f, 0x76, 0x31, 0x3b, 0x66, 0x69, 0x78,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bufplugin_ext_fix_v1_fix_service_proto_rawDescOnce sync.Once
+	file_bufplugin_ext_fix_v1_fix_service_proto_rawDescData = file_bufplugin_ext_fix_v1_fix_service_proto_rawDesc
+)
+
+func file_bufplugin_ext_fix_v1_fix_service_proto_rawDescGZIP() []byte {
+	file_bufplugin_ext_fix_v1_fix_service_proto_rawDescOnce.Do(func() {
+		file_bufplugin_ext_fix_v1_fix_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_bufplugin_ext_fix_v1_fix_service_proto_rawDescData)
+	})
+	return file_bufplugin_ext_fix_v1_fix_service_proto_rawDescData
+}
+
+var file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_bufplugin_ext_fix_v1_fix_service_proto_goTypes = []any{
+	(*FixRequest)(nil),  // 0: bufplugin.ext.fix.v1.FixRequest
+	(*FixResponse)(nil), // 1: bufplugin.ext.fix.v1.FixResponse
+	(*Fix)(nil),         // 2: bufplugin.ext.fix.v1.Fix
+	(*Edit)(nil),        // 3: bufplugin.ext.fix.v1.Edit
+	nil,                 // 4: bufplugin.ext.fix.v1.FixRequest.SourcesEntry
+}
+var file_bufplugin_ext_fix_v1_fix_service_proto_depIdxs = []int32{
+	4, // 0: bufplugin.ext.fix.v1.FixRequest.sources:type_name -> bufplugin.ext.fix.v1.FixRequest.SourcesEntry
+	2, // 1: bufplugin.ext.fix.v1.FixResponse.fixes:type_name -> bufplugin.ext.fix.v1.Fix
+	3, // 2: bufplugin.ext.fix.v1.Fix.edits:type_name -> bufplugin.ext.fix.v1.Edit
+	0, // 3: bufplugin.ext.fix.v1.FixService.Fix:input_type -> bufplugin.ext.fix.v1.FixRequest
+	1, // 4: bufplugin.ext.fix.v1.FixService.Fix:output_type -> bufplugin.ext.fix.v1.FixResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_bufplugin_ext_fix_v1_fix_service_proto_init() }
+func file_bufplugin_ext_fix_v1_fix_service_proto_init() {
+	if File_bufplugin_ext_fix_v1_fix_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bufplugin_ext_fix_v1_fix_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bufplugin_ext_fix_v1_fix_service_proto_goTypes,
+		DependencyIndexes: file_bufplugin_ext_fix_v1_fix_service_proto_depIdxs,
+		MessageInfos:      file_bufplugin_ext_fix_v1_fix_service_proto_msgTypes,
+	}.Build()
+	File_bufplugin_ext_fix_v1_fix_service_proto = out.File
+	file_bufplugin_ext_fix_v1_fix_service_proto_rawDesc = nil
+	file_bufplugin_ext_fix_v1_fix_service_proto_goTypes = nil
+	file_bufplugin_ext_fix_v1_fix_service_proto_depIdxs = nil
+}
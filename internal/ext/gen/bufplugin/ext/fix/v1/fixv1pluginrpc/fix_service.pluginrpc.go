@@ -0,0 +1,136 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-pluginrpc-go. DO NOT EDIT.
+//
+// Source: bufplugin/ext/fix/v1/fix_service.proto
+
+// The protocol of fix plugins, see the fix package.
+//
+// Fix plugins are given the same inputs as check plugins, together with the sources of the
+// files to fix. The buf.plugin.* messages are embedded as their binary encoding, as this
+// protocol is not part of the bufplugin API that defines them.
+package fixv1pluginrpc
+
+import (
+	v1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/fix/v1"
+	context "context"
+	fmt "fmt"
+	pluginrpc "pluginrpc.com/pluginrpc"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the pluginrpc package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of pluginrpc newer than the one compiled into your binary. You can fix
+// the problem by either regenerating this code with an older version of pluginrpc or updating the
+// pluginrpc version compiled into your binary.
+const _ = pluginrpc.IsAtLeastVersion0_1_0
+
+const (
+	// FixServiceFixPath is the path of the FixService's Fix RPC.
+	FixServiceFixPath = "/bufplugin.ext.fix.v1.FixService/Fix"
+)
+
+// FixServiceSpecBuilder builds a Spec for the bufplugin.ext.fix.v1.FixService service.
+type FixServiceSpecBuilder struct {
+	Fix []pluginrpc.ProcedureOption
+}
+
+// Build builds a Spec for the bufplugin.ext.fix.v1.FixService service.
+func (s FixServiceSpecBuilder) Build() (pluginrpc.Spec, error) {
+	procedures := make([]pluginrpc.Procedure, 0, 1)
+	procedure, err := pluginrpc.NewProcedure(FixServiceFixPath, s.Fix...)
+	if err != nil {
+		return nil, err
+	}
+	procedures = append(procedures, procedure)
+	return pluginrpc.NewSpec(procedures...)
+}
+
+// FixServiceClient is a client for the bufplugin.ext.fix.v1.FixService service.
+type FixServiceClient interface {
+	// Fix returns the fixes for the given files.
+	Fix(context.Context, *v1.FixRequest, ...pluginrpc.CallOption) (*v1.FixResponse, error)
+}
+
+// NewFixServiceClient constructs a client for the bufplugin.ext.fix.v1.FixService service.
+func NewFixServiceClient(client pluginrpc.Client) (FixServiceClient, error) {
+	return &fixServiceClient{
+		client: client,
+	}, nil
+}
+
+// FixServiceHandler is an implementation of the bufplugin.ext.fix.v1.FixService service.
+type FixServiceHandler interface {
+	// Fix returns the fixes for the given files.
+	Fix(context.Context, *v1.FixRequest) (*v1.FixResponse, error)
+}
+
+// FixServiceServer serves the bufplugin.ext.fix.v1.FixService service.
+type FixServiceServer interface {
+	// Fix returns the fixes for the given files.
+	Fix(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error
+}
+
+// NewFixServiceServer constructs a server for the bufplugin.ext.fix.v1.FixService service.
+func NewFixServiceServer(handler pluginrpc.Handler, fixServiceHandler FixServiceHandler) FixServiceServer {
+	return &fixServiceServer{
+		handler:           handler,
+		fixServiceHandler: fixServiceHandler,
+	}
+}
+
+// RegisterFixServiceServer registers the server for the bufplugin.ext.fix.v1.FixService service.
+func RegisterFixServiceServer(serverRegistrar pluginrpc.ServerRegistrar, fixServiceServer FixServiceServer) {
+	serverRegistrar.Register(FixServiceFixPath, fixServiceServer.Fix)
+}
+
+// *** PRIVATE ***
+
+// fixServiceClient implements FixServiceClient.
+type fixServiceClient struct {
+	client pluginrpc.Client
+}
+
+// Fix calls bufplugin.ext.fix.v1.FixService.Fix.
+func (c *fixServiceClient) Fix(ctx context.Context, req *v1.FixRequest, opts ...pluginrpc.CallOption) (*v1.FixResponse, error) {
+	res := &v1.FixResponse{}
+	if err := c.client.Call(ctx, FixServiceFixPath, req, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// fixServiceServer implements FixServiceServer.
+type fixServiceServer struct {
+	handler           pluginrpc.Handler
+	fixServiceHandler FixServiceHandler
+}
+
+// Fix calls bufplugin.ext.fix.v1.FixService.Fix.
+func (c *fixServiceServer) Fix(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+	return c.handler.Handle(
+		ctx,
+		handleEnv,
+		&v1.FixRequest{},
+		func(ctx context.Context, anyReq any) (any, error) {
+			req, ok := anyReq.(*v1.FixRequest)
+			if !ok {
+				return nil, fmt.Errorf("could not cast %T to a *v1.FixRequest", anyReq)
+			}
+			return c.fixServiceHandler.Fix(ctx, req)
+		},
+		options...,
+	)
+}
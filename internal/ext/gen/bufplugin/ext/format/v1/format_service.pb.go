@@ -0,0 +1,256 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.2
+// 	protoc        (unknown)
+// source: bufplugin/ext/format/v1/format_service.proto
+
+// The protocol of format plugins, see the format package.
+//
+// The buf.plugin.* messages are embedded as their binary encoding, as this protocol is not
+// part of the bufplugin API that defines them.
+
+package formatv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A request to format files.
+type FormatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The binary encodings of the buf.plugin.descriptor.v1.FileDescriptors of the files to
+	// format and their imports.
+	FileDescriptors [][]byte `protobuf:"bytes,1,rep,name=file_descriptors,json=fileDescriptors,proto3" json:"file_descriptors,omitempty"`
+	// The binary encodings of the buf.plugin.option.v1.Options for the plugin.
+	Options [][]byte `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	// The sources of the files to format, by file name.
+	//
+	// Required. Each file name must be the name of a file within file_descriptors.
+	Sources       map[string][]byte `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FormatRequest) Reset() {
+	*x = FormatRequest{}
+	mi := &file_bufplugin_ext_format_v1_format_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FormatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FormatRequest) ProtoMessage() {}
+
+func (x *FormatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_format_v1_format_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FormatRequest.ProtoReflect.Descriptor instead.
+func (*FormatRequest) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_format_v1_format_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FormatRequest) GetFileDescriptors() [][]byte {
+	if x != nil {
+		return x.FileDescriptors
+	}
+	return nil
+}
+
+func (x *FormatRequest) GetOptions() [][]byte {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *FormatRequest) GetSources() map[string][]byte {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// A response containing formatted sources.
+type FormatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The formatted sources, by file name, of every file whose formatted source differs from
+	// its source.
+	Sources       map[string][]byte `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FormatResponse) Reset() {
+	*x = FormatResponse{}
+	mi := &file_bufplugin_ext_format_v1_format_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FormatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FormatResponse) ProtoMessage() {}
+
+func (x *FormatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_format_v1_format_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FormatResponse.ProtoReflect.Descriptor instead.
+func (*FormatResponse) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_format_v1_format_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FormatResponse) GetSources() map[string][]byte {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+var File_bufplugin_ext_format_v1_format_service_proto protoreflect.FileDescriptor
+
+var file_bufplugin_ext_format_v1_format_service_proto_rawDesc = []byte{
+	0x0a, 0x2c, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17,
+	0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x22, 0xdf, 0x01, 0x0a, 0x0d, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4d,
+	0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x33, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x3a, 0x0a,
+	0x0c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9c, 0x01, 0x0a, 0x0e, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x07,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e,
+	0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x3a, 0x0a, 0x0c,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x6a, 0x0a, 0x0d, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x06, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x12, 0x26, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x65, 0x78, 0x74, 0x2e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x62, 0x75,
+	0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x4a, 0x5a, 0x48, 0x62, 0x75, 0x66, 0x2e, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bufplugin_ext_format_v1_format_service_proto_rawDescOnce sync.Once
+	file_bufplugin_ext_format_v1_format_service_proto_rawDescData = file_bufplugin_ext_format_v1_format_service_proto_rawDesc
+)
+
+func file_bufplugin_ext_format_v1_format_service_proto_rawDescGZIP() []byte {
+	file_bufplugin_ext_format_v1_format_service_proto_rawDescOnce.Do(func() {
+		file_bufplugin_ext_format_v1_format_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_bufplugin_ext_format_v1_format_service_proto_rawDescData)
+	})
+	return file_bufplugin_ext_format_v1_format_service_proto_rawDescData
+}
+
+var file_bufplugin_ext_format_v1_format_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_bufplugin_ext_format_v1_format_service_proto_goTypes = []any{
+	(*FormatRequest)(nil),  // 0: bufplugin.ext.format.v1.FormatRequest
+	(*FormatResponse)(nil), // 1: bufplugin.ext.format.v1.FormatResponse
+	nil,                    // 2: bufplugin.ext.format.v1.FormatRequest.SourcesEntry
+	nil,                    // 3: bufplugin.ext.format.v1.FormatResponse.SourcesEntry
+}
+var file_bufplugin_ext_format_v1_format_service_proto_depIdxs = []int32{
+	2, // 0: bufplugin.ext.format.v1.FormatRequest.sources:type_name -> bufplugin.ext.format.v1.FormatRequest.SourcesEntry
+	3, // 1: bufplugin.ext.format.v1.FormatResponse.sources:type_name -> bufplugin.ext.format.v1.FormatResponse.SourcesEntry
+	0, // 2: bufplugin.ext.format.v1.FormatService.Format:input_type -> bufplugin.ext.format.v1.FormatRequest
+	1, // 3: bufplugin.ext.format.v1.FormatService.Format:output_type -> bufplugin.ext.format.v1.FormatResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_bufplugin_ext_format_v1_format_service_proto_init() }
+func file_bufplugin_ext_format_v1_format_service_proto_init() {
+	if File_bufplugin_ext_format_v1_format_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bufplugin_ext_format_v1_format_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bufplugin_ext_format_v1_format_service_proto_goTypes,
+		DependencyIndexes: file_bufplugin_ext_format_v1_format_service_proto_depIdxs,
+		MessageInfos:      file_bufplugin_ext_format_v1_format_service_proto_msgTypes,
+	}.Build()
+	File_bufplugin_ext_format_v1_format_service_proto = out.File
+	file_bufplugin_ext_format_v1_format_service_proto_rawDesc = nil
+	file_bufplugin_ext_format_v1_format_service_proto_goTypes = nil
+	file_bufplugin_ext_format_v1_format_service_proto_depIdxs = nil
+}
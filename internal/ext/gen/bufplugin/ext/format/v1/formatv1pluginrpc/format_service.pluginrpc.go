@@ -0,0 +1,136 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-pluginrpc-go. DO NOT EDIT.
+//
+// Source: bufplugin/ext/format/v1/format_service.proto
+
+// The protocol of format plugins, see the format package.
+//
+// The buf.plugin.* messages are embedded as their binary encoding, as this protocol is not
+// part of the bufplugin API that defines them.
+package formatv1pluginrpc
+
+import (
+	v1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/format/v1"
+	context "context"
+	fmt "fmt"
+	pluginrpc "pluginrpc.com/pluginrpc"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the pluginrpc package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of pluginrpc newer than the one compiled into your binary. You can fix
+// the problem by either regenerating this code with an older version of pluginrpc or updating the
+// pluginrpc version compiled into your binary.
+const _ = pluginrpc.IsAtLeastVersion0_1_0
+
+const (
+	// FormatServiceFormatPath is the path of the FormatService's Format RPC.
+	FormatServiceFormatPath = "/bufplugin.ext.format.v1.FormatService/Format"
+)
+
+// FormatServiceSpecBuilder builds a Spec for the bufplugin.ext.format.v1.FormatService service.
+type FormatServiceSpecBuilder struct {
+	Format []pluginrpc.ProcedureOption
+}
+
+// Build builds a Spec for the bufplugin.ext.format.v1.FormatService service.
+func (s FormatServiceSpecBuilder) Build() (pluginrpc.Spec, error) {
+	procedures := make([]pluginrpc.Procedure, 0, 1)
+	procedure, err := pluginrpc.NewProcedure(FormatServiceFormatPath, s.Format...)
+	if err != nil {
+		return nil, err
+	}
+	procedures = append(procedures, procedure)
+	return pluginrpc.NewSpec(procedures...)
+}
+
+// FormatServiceClient is a client for the bufplugin.ext.format.v1.FormatService service.
+type FormatServiceClient interface {
+	// Format returns the formatted sources of the given files.
+	Format(context.Context, *v1.FormatRequest, ...pluginrpc.CallOption) (*v1.FormatResponse, error)
+}
+
+// NewFormatServiceClient constructs a client for the bufplugin.ext.format.v1.FormatService service.
+func NewFormatServiceClient(client pluginrpc.Client) (FormatServiceClient, error) {
+	return &formatServiceClient{
+		client: client,
+	}, nil
+}
+
+// FormatServiceHandler is an implementation of the bufplugin.ext.format.v1.FormatService service.
+type FormatServiceHandler interface {
+	// Format returns the formatted sources of the given files.
+	Format(context.Context, *v1.FormatRequest) (*v1.FormatResponse, error)
+}
+
+// FormatServiceServer serves the bufplugin.ext.format.v1.FormatService service.
+type FormatServiceServer interface {
+	// Format returns the formatted sources of the given files.
+	Format(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error
+}
+
+// NewFormatServiceServer constructs a server for the bufplugin.ext.format.v1.FormatService service.
+func NewFormatServiceServer(handler pluginrpc.Handler, formatServiceHandler FormatServiceHandler) FormatServiceServer {
+	return &formatServiceServer{
+		handler:              handler,
+		formatServiceHandler: formatServiceHandler,
+	}
+}
+
+// RegisterFormatServiceServer registers the server for the bufplugin.ext.format.v1.FormatService
+// service.
+func RegisterFormatServiceServer(serverRegistrar pluginrpc.ServerRegistrar, formatServiceServer FormatServiceServer) {
+	serverRegistrar.Register(FormatServiceFormatPath, formatServiceServer.Format)
+}
+
+// *** PRIVATE ***
+
+// formatServiceClient implements FormatServiceClient.
+type formatServiceClient struct {
+	client pluginrpc.Client
+}
+
+// Format calls bufplugin.ext.format.v1.FormatService.Format.
+func (c *formatServiceClient) Format(ctx context.Context, req *v1.FormatRequest, opts ...pluginrpc.CallOption) (*v1.FormatResponse, error) {
+	res := &v1.FormatResponse{}
+	if err := c.client.Call(ctx, FormatServiceFormatPath, req, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// formatServiceServer implements FormatServiceServer.
+type formatServiceServer struct {
+	handler              pluginrpc.Handler
+	formatServiceHandler FormatServiceHandler
+}
+
+// Format calls bufplugin.ext.format.v1.FormatService.Format.
+func (c *formatServiceServer) Format(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+	return c.handler.Handle(
+		ctx,
+		handleEnv,
+		&v1.FormatRequest{},
+		func(ctx context.Context, anyReq any) (any, error) {
+			req, ok := anyReq.(*v1.FormatRequest)
+			if !ok {
+				return nil, fmt.Errorf("could not cast %T to a *v1.FormatRequest", anyReq)
+			}
+			return c.formatServiceHandler.Format(ctx, req)
+		},
+		options...,
+	)
+}
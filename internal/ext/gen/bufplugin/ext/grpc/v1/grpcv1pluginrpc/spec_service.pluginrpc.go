@@ -0,0 +1,132 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-pluginrpc-go. DO NOT EDIT.
+//
+// Source: bufplugin/ext/grpc/v1/spec_service.proto
+
+// The gRPC transport of the plugin protocols implemented by this SDK, see the checkgrpc package.
+package grpcv1pluginrpc
+
+import (
+	v1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/grpc/v1"
+	context "context"
+	fmt "fmt"
+	pluginrpc "pluginrpc.com/pluginrpc"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the pluginrpc package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of pluginrpc newer than the one compiled into your binary. You can fix
+// the problem by either regenerating this code with an older version of pluginrpc or updating the
+// pluginrpc version compiled into your binary.
+const _ = pluginrpc.IsAtLeastVersion0_1_0
+
+const (
+	// SpecServiceGetSpecPath is the path of the SpecService's GetSpec RPC.
+	SpecServiceGetSpecPath = "/bufplugin.ext.grpc.v1.SpecService/GetSpec"
+)
+
+// SpecServiceSpecBuilder builds a Spec for the bufplugin.ext.grpc.v1.SpecService service.
+type SpecServiceSpecBuilder struct {
+	GetSpec []pluginrpc.ProcedureOption
+}
+
+// Build builds a Spec for the bufplugin.ext.grpc.v1.SpecService service.
+func (s SpecServiceSpecBuilder) Build() (pluginrpc.Spec, error) {
+	procedures := make([]pluginrpc.Procedure, 0, 1)
+	procedure, err := pluginrpc.NewProcedure(SpecServiceGetSpecPath, s.GetSpec...)
+	if err != nil {
+		return nil, err
+	}
+	procedures = append(procedures, procedure)
+	return pluginrpc.NewSpec(procedures...)
+}
+
+// SpecServiceClient is a client for the bufplugin.ext.grpc.v1.SpecService service.
+type SpecServiceClient interface {
+	// GetSpec gets the procedures that the plugin serves.
+	GetSpec(context.Context, *v1.GetSpecRequest, ...pluginrpc.CallOption) (*v1.GetSpecResponse, error)
+}
+
+// NewSpecServiceClient constructs a client for the bufplugin.ext.grpc.v1.SpecService service.
+func NewSpecServiceClient(client pluginrpc.Client) (SpecServiceClient, error) {
+	return &specServiceClient{
+		client: client,
+	}, nil
+}
+
+// SpecServiceHandler is an implementation of the bufplugin.ext.grpc.v1.SpecService service.
+type SpecServiceHandler interface {
+	// GetSpec gets the procedures that the plugin serves.
+	GetSpec(context.Context, *v1.GetSpecRequest) (*v1.GetSpecResponse, error)
+}
+
+// SpecServiceServer serves the bufplugin.ext.grpc.v1.SpecService service.
+type SpecServiceServer interface {
+	// GetSpec gets the procedures that the plugin serves.
+	GetSpec(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error
+}
+
+// NewSpecServiceServer constructs a server for the bufplugin.ext.grpc.v1.SpecService service.
+func NewSpecServiceServer(handler pluginrpc.Handler, specServiceHandler SpecServiceHandler) SpecServiceServer {
+	return &specServiceServer{
+		handler:            handler,
+		specServiceHandler: specServiceHandler,
+	}
+}
+
+// RegisterSpecServiceServer registers the server for the bufplugin.ext.grpc.v1.SpecService service.
+func RegisterSpecServiceServer(serverRegistrar pluginrpc.ServerRegistrar, specServiceServer SpecServiceServer) {
+	serverRegistrar.Register(SpecServiceGetSpecPath, specServiceServer.GetSpec)
+}
+
+// *** PRIVATE ***
+
+// specServiceClient implements SpecServiceClient.
+type specServiceClient struct {
+	client pluginrpc.Client
+}
+
+// GetSpec calls bufplugin.ext.grpc.v1.SpecService.GetSpec.
+func (c *specServiceClient) GetSpec(ctx context.Context, req *v1.GetSpecRequest, opts ...pluginrpc.CallOption) (*v1.GetSpecResponse, error) {
+	res := &v1.GetSpecResponse{}
+	if err := c.client.Call(ctx, SpecServiceGetSpecPath, req, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// specServiceServer implements SpecServiceServer.
+type specServiceServer struct {
+	handler            pluginrpc.Handler
+	specServiceHandler SpecServiceHandler
+}
+
+// GetSpec calls bufplugin.ext.grpc.v1.SpecService.GetSpec.
+func (c *specServiceServer) GetSpec(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+	return c.handler.Handle(
+		ctx,
+		handleEnv,
+		&v1.GetSpecRequest{},
+		func(ctx context.Context, anyReq any) (any, error) {
+			req, ok := anyReq.(*v1.GetSpecRequest)
+			if !ok {
+				return nil, fmt.Errorf("could not cast %T to a *v1.GetSpecRequest", anyReq)
+			}
+			return c.specServiceHandler.GetSpec(ctx, req)
+		},
+		options...,
+	)
+}
@@ -0,0 +1,200 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.2
+// 	protoc        (unknown)
+// source: bufplugin/ext/grpc/v1/spec_service.proto
+
+// The gRPC transport of the plugin protocols implemented by this SDK, see the checkgrpc package.
+
+package grpcv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A request to get the procedures of a plugin.
+type GetSpecRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSpecRequest) Reset() {
+	*x = GetSpecRequest{}
+	mi := &file_bufplugin_ext_grpc_v1_spec_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSpecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSpecRequest) ProtoMessage() {}
+
+func (x *GetSpecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_grpc_v1_spec_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSpecRequest.ProtoReflect.Descriptor instead.
+func (*GetSpecRequest) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescGZIP(), []int{0}
+}
+
+// A response containing the procedures of a plugin.
+type GetSpecResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The paths of the procedures, which are also their gRPC paths.
+	//
+	// This does not include the path of the SpecService itself.
+	ProcedurePaths []string `protobuf:"bytes,1,rep,name=procedure_paths,json=procedurePaths,proto3" json:"procedure_paths,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSpecResponse) Reset() {
+	*x = GetSpecResponse{}
+	mi := &file_bufplugin_ext_grpc_v1_spec_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSpecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSpecResponse) ProtoMessage() {}
+
+func (x *GetSpecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_grpc_v1_spec_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSpecResponse.ProtoReflect.Descriptor instead.
+func (*GetSpecResponse) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSpecResponse) GetProcedurePaths() []string {
+	if x != nil {
+		return x.ProcedurePaths
+	}
+	return nil
+}
+
+var File_bufplugin_ext_grpc_v1_spec_service_proto protoreflect.FileDescriptor
+
+var file_bufplugin_ext_grpc_v1_spec_service_proto_rawDesc = []byte{
+	0x0a, 0x28, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f,
+	0x67, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x70, 0x65, 0x63, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x62, 0x75, 0x66, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x76,
+	0x31, 0x22, 0x10, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x3a, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x64,
+	0x75, 0x72, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x32,
+	0x67, 0x0a, 0x0b, 0x53, 0x70, 0x65, 0x63, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x58,
+	0x0a, 0x07, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x25, 0x2e, 0x62, 0x75, 0x66, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x46, 0x5a, 0x44, 0x62, 0x75, 0x66, 0x2e,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x74, 0x2f,
+	0x67, 0x65, 0x6e, 0x2f, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78,
+	0x74, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x31, 0x3b, 0x67, 0x72, 0x70, 0x63, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescOnce sync.Once
+	file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescData = file_bufplugin_ext_grpc_v1_spec_service_proto_rawDesc
+)
+
+func file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescGZIP() []byte {
+	file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescOnce.Do(func() {
+		file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescData)
+	})
+	return file_bufplugin_ext_grpc_v1_spec_service_proto_rawDescData
+}
+
+var file_bufplugin_ext_grpc_v1_spec_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_bufplugin_ext_grpc_v1_spec_service_proto_goTypes = []any{
+	(*GetSpecRequest)(nil),  // 0: bufplugin.ext.grpc.v1.GetSpecRequest
+	(*GetSpecResponse)(nil), // 1: bufplugin.ext.grpc.v1.GetSpecResponse
+}
+var file_bufplugin_ext_grpc_v1_spec_service_proto_depIdxs = []int32{
+	0, // 0: bufplugin.ext.grpc.v1.SpecService.GetSpec:input_type -> bufplugin.ext.grpc.v1.GetSpecRequest
+	1, // 1: bufplugin.ext.grpc.v1.SpecService.GetSpec:output_type -> bufplugin.ext.grpc.v1.GetSpecResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_bufplugin_ext_grpc_v1_spec_service_proto_init() }
+func file_bufplugin_ext_grpc_v1_spec_service_proto_init() {
+	if File_bufplugin_ext_grpc_v1_spec_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bufplugin_ext_grpc_v1_spec_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bufplugin_ext_grpc_v1_spec_service_proto_goTypes,
+		DependencyIndexes: file_bufplugin_ext_grpc_v1_spec_service_proto_depIdxs,
+		MessageInfos:      file_bufplugin_ext_grpc_v1_spec_service_proto_msgTypes,
+	}.Build()
+	File_bufplugin_ext_grpc_v1_spec_service_proto = out.File
+	file_bufplugin_ext_grpc_v1_spec_service_proto_rawDesc = nil
+	file_bufplugin_ext_grpc_v1_spec_service_proto_goTypes = nil
+	file_bufplugin_ext_grpc_v1_spec_service_proto_depIdxs = nil
+}
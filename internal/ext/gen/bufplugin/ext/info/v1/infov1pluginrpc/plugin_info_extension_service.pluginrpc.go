@@ -0,0 +1,149 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-pluginrpc-go. DO NOT EDIT.
+//
+// Source: bufplugin/ext/info/v1/plugin_info_extension_service.proto
+
+// Extensions to the buf.plugin.info.v1 protocol implemented by this SDK.
+//
+// These carry the plugin information that buf.plugin.info.v1.PluginInfo has no fields for.
+// Plugins built with older versions of this SDK do not implement these procedures, so
+// clients must treat an unimplemented procedure as an empty PluginInfoExtension.
+package infov1pluginrpc
+
+import (
+	v1 "buf.build/go/bufplugin/internal/ext/gen/bufplugin/ext/info/v1"
+	context "context"
+	fmt "fmt"
+	pluginrpc "pluginrpc.com/pluginrpc"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the pluginrpc package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of pluginrpc newer than the one compiled into your binary. You can fix
+// the problem by either regenerating this code with an older version of pluginrpc or updating the
+// pluginrpc version compiled into your binary.
+const _ = pluginrpc.IsAtLeastVersion0_1_0
+
+const (
+	// PluginInfoExtensionServiceGetPluginInfoExtensionPath is the path of the
+	// PluginInfoExtensionService's GetPluginInfoExtension RPC.
+	PluginInfoExtensionServiceGetPluginInfoExtensionPath = "/bufplugin.ext.info.v1.PluginInfoExtensionService/GetPluginInfoExtension"
+)
+
+// PluginInfoExtensionServiceSpecBuilder builds a Spec for the
+// bufplugin.ext.info.v1.PluginInfoExtensionService service.
+type PluginInfoExtensionServiceSpecBuilder struct {
+	GetPluginInfoExtension []pluginrpc.ProcedureOption
+}
+
+// Build builds a Spec for the bufplugin.ext.info.v1.PluginInfoExtensionService service.
+func (s PluginInfoExtensionServiceSpecBuilder) Build() (pluginrpc.Spec, error) {
+	procedures := make([]pluginrpc.Procedure, 0, 1)
+	procedure, err := pluginrpc.NewProcedure(PluginInfoExtensionServiceGetPluginInfoExtensionPath, s.GetPluginInfoExtension...)
+	if err != nil {
+		return nil, err
+	}
+	procedures = append(procedures, procedure)
+	return pluginrpc.NewSpec(procedures...)
+}
+
+// PluginInfoExtensionServiceClient is a client for the
+// bufplugin.ext.info.v1.PluginInfoExtensionService service.
+type PluginInfoExtensionServiceClient interface {
+	// GetPluginInfoExtension gets the information about the plugin that is not part of
+	// buf.plugin.info.v1.PluginInfo.
+	GetPluginInfoExtension(context.Context, *v1.GetPluginInfoExtensionRequest, ...pluginrpc.CallOption) (*v1.GetPluginInfoExtensionResponse, error)
+}
+
+// NewPluginInfoExtensionServiceClient constructs a client for the
+// bufplugin.ext.info.v1.PluginInfoExtensionService service.
+func NewPluginInfoExtensionServiceClient(client pluginrpc.Client) (PluginInfoExtensionServiceClient, error) {
+	return &pluginInfoExtensionServiceClient{
+		client: client,
+	}, nil
+}
+
+// PluginInfoExtensionServiceHandler is an implementation of the
+// bufplugin.ext.info.v1.PluginInfoExtensionService service.
+type PluginInfoExtensionServiceHandler interface {
+	// GetPluginInfoExtension gets the information about the plugin that is not part of
+	// buf.plugin.info.v1.PluginInfo.
+	GetPluginInfoExtension(context.Context, *v1.GetPluginInfoExtensionRequest) (*v1.GetPluginInfoExtensionResponse, error)
+}
+
+// PluginInfoExtensionServiceServer serves the bufplugin.ext.info.v1.PluginInfoExtensionService
+// service.
+type PluginInfoExtensionServiceServer interface {
+	// GetPluginInfoExtension gets the information about the plugin that is not part of
+	// buf.plugin.info.v1.PluginInfo.
+	GetPluginInfoExtension(context.Context, pluginrpc.HandleEnv, ...pluginrpc.HandleOption) error
+}
+
+// NewPluginInfoExtensionServiceServer constructs a server for the
+// bufplugin.ext.info.v1.PluginInfoExtensionService service.
+func NewPluginInfoExtensionServiceServer(handler pluginrpc.Handler, pluginInfoExtensionServiceHandler PluginInfoExtensionServiceHandler) PluginInfoExtensionServiceServer {
+	return &pluginInfoExtensionServiceServer{
+		handler:                           handler,
+		pluginInfoExtensionServiceHandler: pluginInfoExtensionServiceHandler,
+	}
+}
+
+// RegisterPluginInfoExtensionServiceServer registers the server for the
+// bufplugin.ext.info.v1.PluginInfoExtensionService service.
+func RegisterPluginInfoExtensionServiceServer(serverRegistrar pluginrpc.ServerRegistrar, pluginInfoExtensionServiceServer PluginInfoExtensionServiceServer) {
+	serverRegistrar.Register(PluginInfoExtensionServiceGetPluginInfoExtensionPath, pluginInfoExtensionServiceServer.GetPluginInfoExtension)
+}
+
+// *** PRIVATE ***
+
+// pluginInfoExtensionServiceClient implements PluginInfoExtensionServiceClient.
+type pluginInfoExtensionServiceClient struct {
+	client pluginrpc.Client
+}
+
+// GetPluginInfoExtension calls
+// bufplugin.ext.info.v1.PluginInfoExtensionService.GetPluginInfoExtension.
+func (c *pluginInfoExtensionServiceClient) GetPluginInfoExtension(ctx context.Context, req *v1.GetPluginInfoExtensionRequest, opts ...pluginrpc.CallOption) (*v1.GetPluginInfoExtensionResponse, error) {
+	res := &v1.GetPluginInfoExtensionResponse{}
+	if err := c.client.Call(ctx, PluginInfoExtensionServiceGetPluginInfoExtensionPath, req, res, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// pluginInfoExtensionServiceServer implements PluginInfoExtensionServiceServer.
+type pluginInfoExtensionServiceServer struct {
+	handler                           pluginrpc.Handler
+	pluginInfoExtensionServiceHandler PluginInfoExtensionServiceHandler
+}
+
+// GetPluginInfoExtension calls
+// bufplugin.ext.info.v1.PluginInfoExtensionService.GetPluginInfoExtension.
+func (c *pluginInfoExtensionServiceServer) GetPluginInfoExtension(ctx context.Context, handleEnv pluginrpc.HandleEnv, options ...pluginrpc.HandleOption) error {
+	return c.handler.Handle(
+		ctx,
+		handleEnv,
+		&v1.GetPluginInfoExtensionRequest{},
+		func(ctx context.Context, anyReq any) (any, error) {
+			req, ok := anyReq.(*v1.GetPluginInfoExtensionRequest)
+			if !ok {
+				return nil, fmt.Errorf("could not cast %T to a *v1.GetPluginInfoExtensionRequest", anyReq)
+			}
+			return c.pluginInfoExtensionServiceHandler.GetPluginInfoExtension(ctx, req)
+		},
+		options...,
+	)
+}
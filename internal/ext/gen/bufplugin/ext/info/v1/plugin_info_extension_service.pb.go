@@ -0,0 +1,745 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.2
+// 	protoc        (unknown)
+// source: bufplugin/ext/info/v1/plugin_info_extension_service.proto
+
+// Extensions to the buf.plugin.info.v1 protocol implemented by this SDK.
+//
+// These carry the plugin information that buf.plugin.info.v1.PluginInfo has no fields for.
+// Plugins built with older versions of this SDK do not implement these procedures, so
+// clients must treat an unimplemented procedure as an empty PluginInfoExtension.
+
+package infov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The information about a plugin that is not part of buf.plugin.info.v1.PluginInfo.
+type PluginInfoExtension struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The semantic version of the plugin.
+	//
+	// Optional.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// The people or teams that wrote the plugin.
+	Authors []*Contact `protobuf:"bytes,2,rep,name=authors,proto3" json:"authors,omitempty"`
+	// The people or teams that currently maintain the plugin.
+	Maintainers []*Contact `protobuf:"bytes,3,rep,name=maintainers,proto3" json:"maintainers,omitempty"`
+	// The minimum semantic version of buf that the plugin requires.
+	//
+	// Optional.
+	MinimumBufVersion string `protobuf:"bytes,4,opt,name=minimum_buf_version,json=minimumBufVersion,proto3" json:"minimum_buf_version,omitempty"`
+	// The minimum Bufplugin protocol version that the plugin requires.
+	//
+	// Zero if not set.
+	MinimumProtocolVersion uint32 `protobuf:"varint,5,opt,name=minimum_protocol_version,json=minimumProtocolVersion,proto3" json:"minimum_protocol_version,omitempty"`
+	// The short tags that categorize the plugin.
+	Keywords []string `protobuf:"bytes,6,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	// Whether or not the plugin is deprecated.
+	//
+	// Clients should warn when a deprecated plugin is used, including deprecation_message
+	// if present.
+	Deprecated bool `protobuf:"varint,7,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// A message that tells users of a deprecated plugin what to migrate to.
+	//
+	// Optional. Will only be present if deprecated is true.
+	DeprecationMessage string `protobuf:"bytes,8,opt,name=deprecation_message,json=deprecationMessage,proto3" json:"deprecation_message,omitempty"`
+	// The version control revision that the plugin was built from.
+	//
+	// Optional.
+	SourceRevision *SourceRevision `protobuf:"bytes,9,opt,name=source_revision,json=sourceRevision,proto3" json:"source_revision,omitempty"`
+	// The absolute URL of the changelog or release notes of the plugin.
+	//
+	// Optional.
+	ChangelogUrl string `protobuf:"bytes,10,opt,name=changelog_url,json=changelogUrl,proto3" json:"changelog_url,omitempty"`
+	// The absolute URL where users can get support for the plugin or report bugs.
+	//
+	// Optional.
+	SupportUrl string `protobuf:"bytes,11,opt,name=support_url,json=supportUrl,proto3" json:"support_url,omitempty"`
+	// The SPDX license expression of the license of the plugin, such as "MIT OR Apache-2.0".
+	//
+	// Optional. If the expression is a single SPDX license ID, it is also present as the
+	// spdx_license_id of the buf.plugin.info.v1.License.
+	SpdxLicenseExpression string `protobuf:"bytes,12,opt,name=spdx_license_expression,json=spdxLicenseExpression,proto3" json:"spdx_license_expression,omitempty"`
+	// The licenses of components of the plugin that are licensed differently from the
+	// plugin itself.
+	AdditionalLicenses []*License `protobuf:"bytes,13,rep,name=additional_licenses,json=additionalLicenses,proto3" json:"additional_licenses,omitempty"`
+	// A single-line plain text summary of the plugin.
+	//
+	// Optional. If not present, the summary is the first paragraph of the documentation of
+	// the buf.plugin.info.v1.PluginInfo.
+	ShortDocumentation string `protobuf:"bytes,14,opt,name=short_documentation,json=shortDocumentation,proto3" json:"short_documentation,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PluginInfoExtension) Reset() {
+	*x = PluginInfoExtension{}
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginInfoExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginInfoExtension) ProtoMessage() {}
+
+func (x *PluginInfoExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginInfoExtension.ProtoReflect.Descriptor instead.
+func (*PluginInfoExtension) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PluginInfoExtension) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PluginInfoExtension) GetAuthors() []*Contact {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *PluginInfoExtension) GetMaintainers() []*Contact {
+	if x != nil {
+		return x.Maintainers
+	}
+	return nil
+}
+
+func (x *PluginInfoExtension) GetMinimumBufVersion() string {
+	if x != nil {
+		return x.MinimumBufVersion
+	}
+	return ""
+}
+
+func (x *PluginInfoExtension) GetMinimumProtocolVersion() uint32 {
+	if x != nil {
+		return x.MinimumProtocolVersion
+	}
+	return 0
+}
+
+func (x *PluginInfoExtension) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *PluginInfoExtension) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *PluginInfoExtension) GetDeprecationMessage() string {
+	if x != nil {
+		return x.DeprecationMessage
+	}
+	return ""
+}
+
+func (x *PluginInfoExtension) GetSourceRevision() *SourceRevision {
+	if x != nil {
+		return x.SourceRevision
+	}
+	return nil
+}
+
+func (x *PluginInfoExtension) GetChangelogUrl() string {
+	if x != nil {
+		return x.ChangelogUrl
+	}
+	return ""
+}
+
+func (x *PluginInfoExtension) GetSupportUrl() string {
+	if x != nil {
+		return x.SupportUrl
+	}
+	return ""
+}
+
+func (x *PluginInfoExtension) GetSpdxLicenseExpression() string {
+	if x != nil {
+		return x.SpdxLicenseExpression
+	}
+	return ""
+}
+
+func (x *PluginInfoExtension) GetAdditionalLicenses() []*License {
+	if x != nil {
+		return x.AdditionalLicenses
+	}
+	return nil
+}
+
+func (x *PluginInfoExtension) GetShortDocumentation() string {
+	if x != nil {
+		return x.ShortDocumentation
+	}
+	return ""
+}
+
+// The license of a component of a plugin.
+type License struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The component of the plugin that the license applies to.
+	//
+	// Required.
+	Component string `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	// The SPDX license expression of the license.
+	//
+	// Optional.
+	SpdxLicenseExpression string `protobuf:"bytes,2,opt,name=spdx_license_expression,json=spdxLicenseExpression,proto3" json:"spdx_license_expression,omitempty"`
+	// The source of the license.
+	//
+	// At most one of text and url is set.
+	//
+	// Types that are valid to be assigned to Source:
+	//
+	//	*License_Text
+	//	*License_Url
+	Source        isLicense_Source `protobuf_oneof:"source"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *License) Reset() {
+	*x = License{}
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *License) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*License) ProtoMessage() {}
+
+func (x *License) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use License.ProtoReflect.Descriptor instead.
+func (*License) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *License) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *License) GetSpdxLicenseExpression() string {
+	if x != nil {
+		return x.SpdxLicenseExpression
+	}
+	return ""
+}
+
+func (x *License) GetSource() isLicense_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *License) GetText() string {
+	if x != nil {
+		if x, ok := x.Source.(*License_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *License) GetUrl() string {
+	if x != nil {
+		if x, ok := x.Source.(*License_Url); ok {
+			return x.Url
+		}
+	}
+	return ""
+}
+
+type isLicense_Source interface {
+	isLicense_Source()
+}
+
+type License_Text struct {
+	// The raw text of the license.
+	Text string `protobuf:"bytes,3,opt,name=text,proto3,oneof"`
+}
+
+type License_Url struct {
+	// The absolute URL that contains the license.
+	Url string `protobuf:"bytes,4,opt,name=url,proto3,oneof"`
+}
+
+func (*License_Text) isLicense_Source() {}
+
+func (*License_Url) isLicense_Source() {}
+
+// A person or team associated with a plugin.
+type Contact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the person or team.
+	//
+	// Required.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The email address of the person or team.
+	//
+	// Optional.
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// An absolute URL for the person or team.
+	//
+	// Optional.
+	Url           string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Contact) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Contact) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// A version control revision that a plugin was built from.
+type SourceRevision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The version control system, such as "git".
+	//
+	// Optional.
+	Vcs string `protobuf:"bytes,1,opt,name=vcs,proto3" json:"vcs,omitempty"`
+	// The revision identifier, such as a git commit SHA.
+	//
+	// Required.
+	Revision string `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Whether or not the source tree had local modifications when the plugin was built.
+	Modified bool `protobuf:"varint,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	// The time of the revision.
+	//
+	// Optional.
+	Time          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourceRevision) Reset() {
+	*x = SourceRevision{}
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceRevision) ProtoMessage() {}
+
+func (x *SourceRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceRevision.ProtoReflect.Descriptor instead.
+func (*SourceRevision) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SourceRevision) GetVcs() string {
+	if x != nil {
+		return x.Vcs
+	}
+	return ""
+}
+
+func (x *SourceRevision) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *SourceRevision) GetModified() bool {
+	if x != nil {
+		return x.Modified
+	}
+	return false
+}
+
+func (x *SourceRevision) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// A request for extended plugin information.
+type GetPluginInfoExtensionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPluginInfoExtensionRequest) Reset() {
+	*x = GetPluginInfoExtensionRequest{}
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPluginInfoExtensionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPluginInfoExtensionRequest) ProtoMessage() {}
+
+func (x *GetPluginInfoExtensionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPluginInfoExtensionRequest.ProtoReflect.Descriptor instead.
+func (*GetPluginInfoExtensionRequest) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescGZIP(), []int{4}
+}
+
+// A response containing extended plugin information.
+type GetPluginInfoExtensionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The extended information about the plugin.
+	PluginInfoExtension *PluginInfoExtension `protobuf:"bytes,1,opt,name=plugin_info_extension,json=pluginInfoExtension,proto3" json:"plugin_info_extension,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetPluginInfoExtensionResponse) Reset() {
+	*x = GetPluginInfoExtensionResponse{}
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPluginInfoExtensionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPluginInfoExtensionResponse) ProtoMessage() {}
+
+func (x *GetPluginInfoExtensionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPluginInfoExtensionResponse.ProtoReflect.Descriptor instead.
+func (*GetPluginInfoExtensionResponse) Descriptor() ([]byte, []int) {
+	return file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPluginInfoExtensionResponse) GetPluginInfoExtension() *PluginInfoExtension {
+	if x != nil {
+		return x.PluginInfoExtension
+	}
+	return nil
+}
+
+var File_bufplugin_ext_info_v1_plugin_info_extension_service_proto protoreflect.FileDescriptor
+
+var file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDesc = []byte{
+	0x0a, 0x39, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f,
+	0x69, 0x6e, 0x66, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x69,
+	0x6e, 0x66, 0x6f, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x62, 0x75, 0x66,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e,
+	0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xd2, 0x05, 0x0a, 0x13, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e,
+	0x66, 0x6f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x07, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x73, 0x12,
+	0x40, 0x0a, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x2e, 0x65, 0x78, 0x74, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x52, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x62, 0x75, 0x66,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x42, 0x75, 0x66, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x38, 0x0a, 0x18, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x16, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6b,
+	0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6b,
+	0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x70,
+	0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x64, 0x65, 0x70, 0x72, 0x65,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78,
+	0x74, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x6c, 0x6f, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x6c, 0x6f, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x36,
+	0x0a, 0x17, 0x73, 0x70, 0x64, 0x78, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x65,
+	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x15, 0x73, 0x70, 0x64, 0x78, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x13, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x65, 0x78, 0x74, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x63, 0x65,
+	0x6e, 0x73, 0x65, 0x52, 0x12, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c,
+	0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x73, 0x68, 0x6f, 0x72, 0x74,
+	0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x93, 0x01, 0x0a, 0x07, 0x4c, 0x69, 0x63,
+	0x65, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x73, 0x70, 0x64, 0x78, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x15, 0x73, 0x70, 0x64, 0x78, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65,
+	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x12, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x03, 0x75, 0x72, 0x6c, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x45,
+	0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x8a, 0x01, 0x0a, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x63, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x22, 0x1f, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x15, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x13, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0xa4, 0x01, 0x0a, 0x1a, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x85, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x34, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74,
+	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2e, 0x65, 0x78, 0x74, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x46, 0x5a,
+	0x44, 0x62, 0x75, 0x66, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x67, 0x6f, 0x2f, 0x62, 0x75,
+	0x66, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2f, 0x65, 0x78, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x62, 0x75, 0x66, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x2f, 0x65, 0x78, 0x74, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x2f, 0x76, 0x31, 0x3b, 0x69,
+	0x6e, 0x66, 0x6f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescOnce sync.Once
+	file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescData = file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDesc
+)
+
+func file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescGZIP() []byte {
+	file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescOnce.Do(func() {
+		file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescData)
+	})
+	return file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDescData
+}
+
+var file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_goTypes = []any{
+	(*PluginInfoExtension)(nil),            // 0: bufplugin.ext.info.v1.PluginInfoExtension
+	(*License)(nil),                        // 1: bufplugin.ext.info.v1.License
+	(*Contact)(nil),                        // 2: bufplugin.ext.info.v1.Contact
+	(*SourceRevision)(nil),                 // 3: bufplugin.ext.info.v1.SourceRevision
+	(*GetPluginInfoExtensionRequest)(nil),  // 4: bufplugin.ext.info.v1.GetPluginInfoExtensionRequest
+	(*GetPluginInfoExtensionResponse)(nil), // 5: bufplugin.ext.info.v1.GetPluginInfoExtensionResponse
+	(*timestamppb.Timestamp)(nil),          // 6: google.protobuf.Timestamp
+}
+var file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_depIdxs = []int32{
+	2, // 0: bufplugin.ext.info.v1.PluginInfoExtension.authors:type_name -> bufplugin.ext.info.v1.Contact
+	2, // 1: bufplugin.ext.info.v1.PluginInfoExtension.maintainers:type_name -> bufplugin.ext.info.v1.Contact
+	3, // 2: bufplugin.ext.info.v1.PluginInfoExtension.source_revision:type_name -> bufplugin.ext.info.v1.SourceRevision
+	1, // 3: bufplugin.ext.info.v1.PluginInfoExtension.additional_licenses:type_name -> bufplugin.ext.info.v1.License
+	6, // 4: bufplugin.ext.info.v1.SourceRevision.time:type_name -> google.protobuf.Timestamp
+	0, // 5: bufplugin.ext.info.v1.GetPluginInfoExtensionResponse.plugin_info_extension:type_name -> bufplugin.ext.info.v1.PluginInfoExtension
+	4, // 6: bufplugin.ext.info.v1.PluginInfoExtensionService.GetPluginInfoExtension:input_type -> bufplugin.ext.info.v1.GetPluginInfoExtensionRequest
+	5, // 7: bufplugin.ext.info.v1.PluginInfoExtensionService.GetPluginInfoExtension:output_type -> bufplugin.ext.info.v1.GetPluginInfoExtensionResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_init() }
+func file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_init() {
+	if File_bufplugin_ext_info_v1_plugin_info_extension_service_proto != nil {
+		return
+	}
+	file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes[1].OneofWrappers = []any{
+		(*License_Text)(nil),
+		(*License_Url)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_goTypes,
+		DependencyIndexes: file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_depIdxs,
+		MessageInfos:      file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_msgTypes,
+	}.Build()
+	File_bufplugin_ext_info_v1_plugin_info_extension_service_proto = out.File
+	file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_rawDesc = nil
+	file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_goTypes = nil
+	file_bufplugin_ext_info_v1_plugin_info_extension_service_proto_depIdxs = nil
+}
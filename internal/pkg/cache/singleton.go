@@ -43,7 +43,9 @@ func NewSingleton[V any](get func(context.Context) (V, error)) *Singleton[V] {
 
 // Get gets the value, or returns the error in loading the value.
 //
-// The given context will be used to load the value if not already loaded.
+// The given context will be used to load the value if not already loaded. If loading
+// the value fails and the given context is done, the error is not cached, and the next
+// call to Get will try to load the value again.
 //
 // If Singletons call Singletons, lock ordering must be respected.
 func (s *Singleton[V]) Get(ctx context.Context) (V, error) {
@@ -60,7 +62,12 @@ func (s *Singleton[V]) Get(ctx context.Context) (V, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	if !s.called {
-		s.value, s.err = s.get(ctx)
+		value, err := s.get(ctx)
+		if err != nil && ctx.Err() != nil {
+			// The error is likely the result of the context, and is not cached.
+			return value, err
+		}
+		s.value, s.err = value, err
 		s.called = true
 	}
 	return s.value, s.err
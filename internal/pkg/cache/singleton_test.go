@@ -55,3 +55,25 @@ func TestBasic(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, "1", err.Error())
 }
+
+func TestContextErrorNotCached(t *testing.T) {
+	t.Parallel()
+
+	var count int
+	singleton := NewSingleton(
+		func(ctx context.Context) (int, error) {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			count++
+			return count, nil
+		},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := singleton.Get(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	value, err := singleton.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+}
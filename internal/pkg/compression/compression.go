@@ -0,0 +1,240 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compression implements negotiated compression of the stdin and stdout of
+// plugin invocations.
+//
+// The compressions that a plugin supports are discovered by the caller, for example by
+// probing the capabilities of the plugin. Invocations are then prefixed with --compression
+// and the name of the chosen compression, and stdin and stdout are compressed.
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	// Zstd is the name of zstd compression.
+	Zstd = "zstd"
+	// Gzip is the name of gzip compression.
+	Gzip = "gzip"
+
+	compressionFlag = "--compression"
+)
+
+// Names are the names of the supported compressions, in order of preference.
+var Names = []string{Zstd, Gzip}
+
+// NewRunner returns a new pluginrpc.Runner that compresses the stdin and stdout of
+// invocations of the delegate if the plugin supports it.
+//
+// The compression is negotiated once, on the first invocation, by calling getSupportedNames
+// to get the names of the compressions that the plugin supports. The first compression in
+// Names that the plugin supports is used. If getSupportedNames returns an error, no
+// compression is used.
+func NewRunner(
+	delegate pluginrpc.Runner,
+	getSupportedNames func(context.Context) ([]string, error),
+) pluginrpc.Runner {
+	return &runner{
+		delegate:          delegate,
+		getSupportedNames: getSupportedNames,
+	}
+}
+
+// IsCompressionArgs returns true if the args are those of a compressed call, which must
+// be served with Serve.
+func IsCompressionArgs(args []string) bool {
+	return len(args) >= 2 && args[0] == compressionFlag
+}
+
+// Serve serves the pluginrpc.Server with the given pluginrpc.Env, decompressing stdin
+// and compressing stdout.
+//
+// If the args are not compression args, the call is served by the server as-is.
+func Serve(ctx context.Context, server pluginrpc.Server, env pluginrpc.Env) error {
+	if !IsCompressionArgs(env.Args) {
+		return server.Serve(ctx, env)
+	}
+	name := env.Args[1]
+	if !slices.Contains(Names, name) {
+		return fmt.Errorf("unknown compression: %q", name)
+	}
+	stdin, err := readAllDecompressed(name, env.Stdin)
+	if err != nil {
+		return err
+	}
+	var stdout bytes.Buffer
+	serveErr := server.Serve(
+		ctx,
+		pluginrpc.Env{
+			Args:   env.Args[2:],
+			Stdin:  bytes.NewReader(stdin),
+			Stdout: &stdout,
+			Stderr: env.Stderr,
+		},
+	)
+	// Write stdout even if Serve failed, as errors are also written to stdout.
+	data, err := compress(name, stdout.Bytes())
+	if err != nil {
+		return errors.Join(serveErr, err)
+	}
+	if _, err := env.Stdout.Write(data); err != nil {
+		return errors.Join(serveErr, err)
+	}
+	return serveErr
+}
+
+// *** PRIVATE ***
+
+type runner struct {
+	delegate          pluginrpc.Runner
+	getSupportedNames func(context.Context) ([]string, error)
+
+	// compression is the name of the negotiated compression, or empty for none.
+	//
+	// Only valid if negotiated is true.
+	compression string
+	negotiated  bool
+	lock        sync.Mutex
+}
+
+func (r *runner) Run(ctx context.Context, env pluginrpc.Env) error {
+	name, err := r.getCompression(ctx)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return r.delegate.Run(ctx, env)
+	}
+	var stdin []byte
+	if env.Stdin != nil {
+		stdin, err = io.ReadAll(env.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+	compressedStdin, err := compress(name, stdin)
+	if err != nil {
+		return err
+	}
+	var compressedStdout bytes.Buffer
+	runErr := r.delegate.Run(
+		ctx,
+		pluginrpc.Env{
+			Args:   append([]string{compressionFlag, name}, env.Args...),
+			Stdin:  bytes.NewReader(compressedStdin),
+			Stdout: &compressedStdout,
+			Stderr: env.Stderr,
+		},
+	)
+	if compressedStdout.Len() > 0 && env.Stdout != nil {
+		stdout, err := readAllDecompressed(name, &compressedStdout)
+		if err != nil {
+			return errors.Join(runErr, err)
+		}
+		if _, err := env.Stdout.Write(stdout); err != nil {
+			return errors.Join(runErr, err)
+		}
+	}
+	return runErr
+}
+
+// getCompression negotiates the compression if not already negotiated.
+//
+// Context errors are not cached, so that a cancelled first invocation does not
+// prevent negotiation on later invocations.
+func (r *runner) getCompression(ctx context.Context) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.negotiated {
+		return r.compression, nil
+	}
+	compression, err := r.negotiate(ctx)
+	if err != nil {
+		return "", err
+	}
+	r.compression = compression
+	r.negotiated = true
+	return compression, nil
+}
+
+func (r *runner) negotiate(ctx context.Context) (string, error) {
+	supportedNames, err := r.getSupportedNames(ctx)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
+		return "", nil
+	}
+	for _, name := range Names {
+		if slices.Contains(supportedNames, name) {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+func compress(name string, data []byte) ([]byte, error) {
+	switch name {
+	case Zstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeAll(data, nil), nil
+	case Gzip:
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown compression: %q", name)
+	}
+}
+
+func readAllDecompressed(name string, reader io.Reader) ([]byte, error) {
+	switch name {
+	case Zstd:
+		decoder, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		return io.ReadAll(decoder)
+	case Gzip:
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(gzipReader)
+	default:
+		return nil, fmt.Errorf("unknown compression: %q", name)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("google.protobuf.Timestamp"), 1000)
+	for _, name := range Names {
+		compressed, err := compress(name, data)
+		require.NoError(t, err)
+		require.Less(t, len(compressed), len(data)/10)
+		decompressed, err := readAllDecompressed(name, bytes.NewReader(compressed))
+		require.NoError(t, err)
+		require.Equal(t, data, decompressed)
+	}
+	_, err := compress("lz4", data)
+	require.Error(t, err)
+}
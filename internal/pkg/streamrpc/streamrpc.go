@@ -0,0 +1,649 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package streamrpc invokes pluginrpc.Servers over streams, such as network connections or
+// the stdio of a long-lived process, instead of a new process per invocation.
+//
+// Each invocation is a request and a response written to the stream. The request contains
+// the args and stdin of the invocation, and the response contains the exit code, stdout, and
+// stderr of the invocation. A server reads requests and writes responses sequentially until
+// the end of the stream.
+//
+// Fields are read in chunks as they arrive, so the memory used is bounded by the data
+// actually sent, not the size declared by the peer. The size of the stdin of a request
+// and the stdout of a response are limited by the maxStdinSize and maxStdoutSize given
+// to the server and the runner respectively. The number and total size of the args of a
+// request are limited as well.
+//
+// An invocation with only the --ping arg is answered by the server with an empty response,
+// without invoking the pluginrpc.Server. This allows clients to check that a server is
+// responsive, see Ping.
+package streamrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"pluginrpc.com/pluginrpc"
+)
+
+const (
+	// PingFlagName is the name of the flag of a ping invocation.
+	PingFlagName = "ping"
+
+	// maxFieldSize is the maximum size of a single field on the wire, and the limit for
+	// fields that do not have a configured limit.
+	maxFieldSize = 1 << 30
+	// maxNumArgs is the maximum number of args of a request.
+	maxNumArgs = 1 << 10
+	// maxArgsSize is the maximum total size in bytes of the args of a request.
+	maxArgsSize = 1 << 20
+	// processGracePeriod is the duration that a process has to exit after it is interrupted
+	// or its stdin is closed, before it is killed.
+	processGracePeriod = 5 * time.Second
+)
+
+// ProcessRunner is a pluginrpc.Runner that invokes a long-lived process.
+//
+// The process must call ServeStream with its stdin and stdout.
+type ProcessRunner interface {
+	pluginrpc.Runner
+	io.Closer
+}
+
+// NewRunner returns a new pluginrpc.Runner that dials the given network and address
+// for each invocation.
+//
+// The network and address are as accepted by net.Dial, for example "tcp" and
+// "localhost:8080", or "unix" and "/tmp/plugin.sock". Responses with a stdout larger
+// than maxStdoutSize bytes result in an error. A maxStdoutSize of 0 or less means no
+// limit beyond the maximum size of a field on the wire.
+func NewRunner(network string, address string, maxStdoutSize int) pluginrpc.Runner {
+	return &netRunner{
+		network:       network,
+		address:       address,
+		maxStdoutSize: maxStdoutSize,
+	}
+}
+
+// NewProcessRunner returns a new ProcessRunner that starts the given program with the given
+// args on the first invocation, and uses the process for all subsequent invocations.
+//
+// If the process has exited between invocations, for example because of an idle timeout,
+// it is restarted. Invocations are serialized. The stderr of the process itself is discarded,
+// the stderr of each invocation is part of the response. Close stops the process by closing
+// its stdin. If the context of an invocation is cancelled, the process is interrupted. In
+// both cases, the process is killed if it has not exited after a grace period. Responses
+// with a stdout larger than maxStdoutSize bytes result in an error, as with NewRunner.
+func NewProcessRunner(programName string, args []string, maxStdoutSize int) ProcessRunner {
+	return &processRunner{
+		programName:   programName,
+		args:          args,
+		maxStdoutSize: maxStdoutSize,
+	}
+}
+
+// Ping pings the server that the given pluginrpc.Runner invokes.
+//
+// The pluginrpc.Runner must be one returned by NewRunner or NewProcessRunner. Ping returns
+// an error if the server does not respond before the context is done.
+func Ping(ctx context.Context, runner pluginrpc.Runner) error {
+	return runner.Run(
+		ctx,
+		pluginrpc.Env{
+			Args:   []string{"--" + PingFlagName},
+			Stdout: io.Discard,
+			Stderr: io.Discard,
+		},
+	)
+}
+
+// Serve serves the pluginrpc.Server on the net.Listener until the context is cancelled.
+//
+// Each connection is handled in a separate goroutine with ServeStream. When the context is
+// cancelled, the net.Listener is closed, in-flight invocations are cancelled, and Serve returns
+// nil after they return. Otherwise, Serve returns the error from the net.Listener.
+//
+// Requests with a stdin larger than maxStdinSize bytes result in the connection being
+// closed, see ServeStream.
+func Serve(ctx context.Context, listener net.Listener, server pluginrpc.Server, maxStdinSize int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stopListener := context.AfterFunc(ctx, func() { _ = listener.Close() })
+	defer stopListener()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Closing the connection unblocks any reads and writes when the context is cancelled.
+			stopConn := context.AfterFunc(ctx, func() { _ = conn.Close() })
+			defer stopConn()
+			// Errors cannot be reported back to the client once the stream is broken.
+			_ = ServeStream(ctx, conn, conn, server, 0, maxStdinSize)
+			_ = conn.Close()
+		}()
+	}
+}
+
+// ServeStream serves the pluginrpc.Server on the given reader and writer.
+//
+// Invocations are read from the reader and handled sequentially. ServeStream returns nil when
+// the end of the reader is reached, when the context is cancelled, or when no invocation has
+// been received for idleTimeout. An idleTimeout of 0 disables the idle timeout.
+//
+// If a request has a stdin larger than maxStdinSize bytes, ServeStream returns an error
+// without reading the rest of the request. A maxStdinSize of 0 or less means no limit
+// beyond the maximum size of a field on the wire.
+//
+// When ServeStream returns because of the context or the idle timeout, the reader may still
+// be read from by a background goroutine until it is closed.
+func ServeStream(
+	ctx context.Context,
+	reader io.Reader,
+	writer io.Writer,
+	server pluginrpc.Server,
+	idleTimeout time.Duration,
+	maxStdinSize int,
+) error {
+	requestC := make(chan *request)
+	errC := make(chan error, 1)
+	nextC := make(chan struct{})
+	go func() {
+		bufferedReader := bufio.NewReader(reader)
+		for {
+			request, err := readRequest(bufferedReader, maxStdinSize)
+			if err != nil {
+				errC <- err
+				return
+			}
+			select {
+			case requestC <- request:
+			case <-ctx.Done():
+				return
+			}
+			// Wait for the response to be written before reading the next request, so that
+			// the idle timeout only starts once an invocation has completed.
+			select {
+			case <-nextC:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	var idleC <-chan time.Time
+	var idleTimer *time.Timer
+	if idleTimeout > 0 {
+		idleTimer = time.NewTimer(idleTimeout)
+		defer idleTimer.Stop()
+		idleC = idleTimer.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-idleC:
+			return nil
+		case err := <-errC:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case request := <-requestC:
+			if idleTimer != nil {
+				idleTimer.Stop()
+			}
+			if err := writeResponse(writer, serveRequest(ctx, server, request)); err != nil {
+				return err
+			}
+			if idleTimer != nil {
+				idleTimer.Reset(idleTimeout)
+			}
+			select {
+			case nextC <- struct{}{}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// *** PRIVATE ***
+
+type request struct {
+	args  []string
+	stdin []byte
+}
+
+type response struct {
+	exitCode int
+	stdout   []byte
+	stderr   []byte
+}
+
+type netRunner struct {
+	network       string
+	address       string
+	maxStdoutSize int
+}
+
+func (r *netRunner) Run(ctx context.Context, env pluginrpc.Env) (retErr error) {
+	request, err := newRequest(env)
+	if err != nil {
+		return err
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, r.network, r.address)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		retErr = errors.Join(retErr, conn.Close())
+	}()
+	stopConn := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stopConn()
+	if err := writeRequest(conn, request); err != nil {
+		return wrapContextError(ctx, err)
+	}
+	// Signal that there are no more requests on this connection.
+	if closeWriter, ok := conn.(interface{ CloseWrite() error }); ok {
+		if err := closeWriter.CloseWrite(); err != nil {
+			return wrapContextError(ctx, err)
+		}
+	}
+	response, err := readResponse(conn, r.maxStdoutSize)
+	if err != nil {
+		return wrapContextError(ctx, err)
+	}
+	return writeResponseToEnv(env, response)
+}
+
+type processRunner struct {
+	programName   string
+	args          []string
+	maxStdoutSize int
+
+	lock   sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+func (p *processRunner) Run(ctx context.Context, env pluginrpc.Env) error {
+	request, err := newRequest(env)
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	data, err := marshalRequest(request)
+	if err != nil {
+		return err
+	}
+	for {
+		reused := p.cmd != nil
+		if !reused {
+			if err := p.start(); err != nil {
+				return err
+			}
+		}
+		response, retryable, err := p.roundTrip(ctx, data)
+		if err == nil {
+			return writeResponseToEnv(env, response)
+		}
+		// The process is in an unknown state after any error.
+		_ = p.stop()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		// A reused process may have exited since the last invocation, for example because
+		// of an idle timeout. Restart the process and try again once, but only if the
+		// process cannot have handled the request.
+		if !reused || !retryable {
+			return err
+		}
+	}
+}
+
+func (p *processRunner) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.stop()
+}
+
+func (p *processRunner) start() error {
+	cmd := exec.Command(p.programName, p.args...) //nolint:gosec
+	// Match pluginrpc: the process has access to no environment variables.
+	cmd.Env = []string{"__EMPTY_ENV=1"}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	p.cmd = cmd
+	p.stdin = stdin
+	p.stdout = bufio.NewReader(stdout)
+	return nil
+}
+
+// roundTrip writes the marshaled request to the process and reads the response.
+//
+// The returned bool is true if the error occurred before the process could have handled the
+// request, that is if writing the request failed, or stdout was closed before any of the
+// response was read. If the context is cancelled, the process is sent an interrupt signal,
+// and is killed if it has not exited after processGracePeriod.
+func (p *processRunner) roundTrip(ctx context.Context, data []byte) (*response, bool, error) {
+	process := p.cmd.Process
+	done := make(chan struct{})
+	defer close(done)
+	stopInterrupt := context.AfterFunc(ctx, func() {
+		if err := process.Signal(os.Interrupt); err == nil {
+			select {
+			case <-done:
+				return
+			case <-time.After(processGracePeriod):
+			}
+		}
+		_ = process.Kill()
+	})
+	defer stopInterrupt()
+	if _, err := p.stdin.Write(data); err != nil {
+		return nil, true, err
+	}
+	if _, err := p.stdout.Peek(1); err != nil {
+		return nil, errors.Is(err, io.EOF), err
+	}
+	response, err := readResponse(p.stdout, p.maxStdoutSize)
+	return response, false, err
+}
+
+// stop closes stdin of the process, which makes the process exit, and waits for it to exit.
+//
+// The process is killed if it has not exited after processGracePeriod. Invocations are
+// serialized, so the process is never stopped while handling an invocation.
+func (p *processRunner) stop() error {
+	if p.cmd == nil {
+		return nil
+	}
+	cmd := p.cmd
+	stdin := p.stdin
+	p.cmd = nil
+	p.stdin = nil
+	p.stdout = nil
+	killTimer := time.AfterFunc(processGracePeriod, func() { _ = cmd.Process.Kill() })
+	defer killTimer.Stop()
+	_ = stdin.Close()
+	// The exit status is not relevant.
+	_ = cmd.Wait()
+	return nil
+}
+
+func newRequest(env pluginrpc.Env) (*request, error) {
+	var stdin []byte
+	if env.Stdin != nil {
+		var err error
+		stdin, err = io.ReadAll(env.Stdin)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &request{
+		args:  env.Args,
+		stdin: stdin,
+	}, nil
+}
+
+func serveRequest(ctx context.Context, server pluginrpc.Server, request *request) *response {
+	if len(request.args) == 1 && request.args[0] == "--"+PingFlagName {
+		return &response{}
+	}
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	// Mirror pluginrpc.Main: errors are written to stderr, and determine the exit code.
+	var exitCode int
+	if err := server.Serve(
+		ctx,
+		pluginrpc.Env{
+			Args:   request.args,
+			Stdin:  bytes.NewReader(request.stdin),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		},
+	); err != nil {
+		if errString := err.Error(); errString != "" {
+			_, _ = stderr.WriteString(errString + "\n")
+		}
+		exitCode = pluginrpc.WrapExitError(err).ExitCode()
+	}
+	return &response{
+		exitCode: exitCode,
+		stdout:   stdout.Bytes(),
+		stderr:   stderr.Bytes(),
+	}
+}
+
+func writeResponseToEnv(env pluginrpc.Env, response *response) error {
+	if env.Stdout != nil {
+		if _, err := env.Stdout.Write(response.stdout); err != nil {
+			return err
+		}
+	}
+	if env.Stderr != nil {
+		if _, err := env.Stderr.Write(response.stderr); err != nil {
+			return err
+		}
+	}
+	if response.exitCode != 0 {
+		return pluginrpc.NewExitError(response.exitCode, fmt.Errorf("exit status %d", response.exitCode))
+	}
+	return nil
+}
+
+// wrapContextError returns the context error instead of err if the context is done, as
+// the error is likely the result of the stream being closed because of the context.
+func wrapContextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func writeRequest(writer io.Writer, request *request) error {
+	data, err := marshalRequest(request)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// marshalRequest returns the wire format of the request.
+//
+// Returns an error if the request has more than maxNumArgs args, or args larger than
+// maxArgsSize bytes in total, as these would be rejected by readRequest.
+func marshalRequest(request *request) ([]byte, error) {
+	if len(request.args) > maxNumArgs {
+		return nil, fmt.Errorf("number of args %d exceeds maximum %d", len(request.args), maxNumArgs)
+	}
+	var argsSize int
+	for _, arg := range request.args {
+		argsSize += len(arg)
+	}
+	if argsSize > maxArgsSize {
+		return nil, fmt.Errorf("args of size %d exceed maximum size %d", argsSize, maxArgsSize)
+	}
+	var buffer bytes.Buffer
+	if err := writeUint32(&buffer, uint32(len(request.args))); err != nil {
+		return nil, err
+	}
+	for _, arg := range request.args {
+		if err := writeField(&buffer, []byte(arg)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeField(&buffer, request.stdin); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// readRequest reads a request.
+//
+// The request may have at most maxNumArgs args, with a total size of at most maxArgsSize
+// bytes, and a stdin of at most maxStdinSize bytes.
+//
+// Returns io.EOF if the end of the reader was reached before the request started.
+func readRequest(reader io.Reader, maxStdinSize int) (*request, error) {
+	numArgs, err := readUint32(reader)
+	if err != nil {
+		return nil, err
+	}
+	if numArgs > maxNumArgs {
+		return nil, fmt.Errorf("number of args %d exceeds maximum %d", numArgs, maxNumArgs)
+	}
+	args := make([]string, 0, numArgs)
+	var argsSize int
+	for range numArgs {
+		arg, err := readField(reader, "arg", maxArgsSize)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		argsSize += len(arg)
+		if argsSize > maxArgsSize {
+			return nil, fmt.Errorf("args exceed maximum size %d", maxArgsSize)
+		}
+		args = append(args, string(arg))
+	}
+	stdin, err := readField(reader, "stdin", maxStdinSize)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return &request{
+		args:  args,
+		stdin: stdin,
+	}, nil
+}
+
+func writeResponse(writer io.Writer, response *response) error {
+	var buffer bytes.Buffer
+	if err := writeUint32(&buffer, uint32(response.exitCode)); err != nil {
+		return err
+	}
+	if err := writeField(&buffer, response.stdout); err != nil {
+		return err
+	}
+	if err := writeField(&buffer, response.stderr); err != nil {
+		return err
+	}
+	_, err := writer.Write(buffer.Bytes())
+	return err
+}
+
+func readResponse(reader io.Reader, maxStdoutSize int) (*response, error) {
+	exitCode, err := readUint32(reader)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	stdout, err := readField(reader, "stdout", maxStdoutSize)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	stderr, err := readField(reader, "stderr", maxFieldSize)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return &response{
+		exitCode: int(exitCode),
+		stdout:   stdout,
+		stderr:   stderr,
+	}, nil
+}
+
+func writeField(writer io.Writer, data []byte) error {
+	if len(data) > maxFieldSize {
+		return fmt.Errorf("field of size %d exceeds maximum size %d", len(data), maxFieldSize)
+	}
+	if err := writeUint32(writer, uint32(len(data))); err != nil {
+		return err
+	}
+	_, err := writer.Write(data)
+	return err
+}
+
+// readField reads a field of at most maxSize bytes.
+//
+// A maxSize of 0 or less, or greater than maxFieldSize, is treated as maxFieldSize.
+func readField(reader io.Reader, fieldName string, maxSize int) ([]byte, error) {
+	size, err := readUint32(reader)
+	if err != nil {
+		return nil, err
+	}
+	if maxSize <= 0 || maxSize > maxFieldSize {
+		maxSize = maxFieldSize
+	}
+	if int64(size) > int64(maxSize) {
+		return nil, fmt.Errorf("%s of size %d exceeds maximum size %d", fieldName, size, maxSize)
+	}
+	// Do not trust size for allocation, the buffer only grows as data is read.
+	var buffer bytes.Buffer
+	if _, err := io.CopyN(&buffer, reader, int64(size)); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeUint32(writer io.Writer, value uint32) error {
+	return binary.Write(writer, binary.BigEndian, value)
+}
+
+func readUint32(reader io.Reader) (uint32, error) {
+	var value uint32
+	if err := binary.Read(reader, binary.BigEndian, &value); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF, for reads within a message.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streamrpc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRequest(t *testing.T) {
+	t.Parallel()
+
+	data, err := marshalRequest(&request{args: []string{"check", "--format", "binary"}, stdin: []byte("stdin")})
+	require.NoError(t, err)
+	request, err := readRequest(bytes.NewReader(data), 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"check", "--format", "binary"}, request.args)
+	require.Equal(t, []byte("stdin"), request.stdin)
+	_, err = readRequest(bytes.NewReader(data), 1)
+	require.ErrorContains(t, err, "stdin of size 5 exceeds maximum size 1")
+}
+
+func TestReadRequestArgLimits(t *testing.T) {
+	t.Parallel()
+
+	// Requests with too many args are rejected.
+	var buffer bytes.Buffer
+	require.NoError(t, writeUint32(&buffer, maxNumArgs+1))
+	_, err := readRequest(&buffer, 0)
+	require.ErrorContains(t, err, "number of args")
+	_, err = marshalRequest(&request{args: make([]string, maxNumArgs+1)})
+	require.ErrorContains(t, err, "number of args")
+
+	// Requests with args that are too large in total are rejected.
+	buffer.Reset()
+	require.NoError(t, writeUint32(&buffer, 2))
+	require.NoError(t, writeField(&buffer, []byte(strings.Repeat("a", maxArgsSize))))
+	require.NoError(t, writeField(&buffer, []byte("a")))
+	_, err = readRequest(&buffer, 0)
+	require.ErrorContains(t, err, "args exceed maximum size")
+	_, err = marshalRequest(&request{args: []string{strings.Repeat("a", maxArgsSize), "a"}})
+	require.ErrorContains(t, err, "exceed maximum size")
+}
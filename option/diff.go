@@ -0,0 +1,140 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+const (
+	// ChangeTypeAdded says that a key is present in the new Options but not in the old Options.
+	ChangeTypeAdded ChangeType = 1
+	// ChangeTypeRemoved says that a key is present in the old Options but not in the new Options.
+	ChangeTypeRemoved ChangeType = 2
+	// ChangeTypeChanged says that a key is present in both Options with different values.
+	ChangeTypeChanged ChangeType = 3
+)
+
+var (
+	changeTypeToString = map[ChangeType]string{
+		ChangeTypeAdded:   "added",
+		ChangeTypeRemoved: "removed",
+		ChangeTypeChanged: "changed",
+	}
+)
+
+// ChangeType is the type of a Change.
+type ChangeType int
+
+// String implements fmt.Stringer.
+func (t ChangeType) String() string {
+	if s, ok := changeTypeToString[t]; ok {
+		return s
+	}
+	return strconv.Itoa(int(t))
+}
+
+// Change is a difference in a single key between two Options, as returned by Diff.
+type Change struct {
+	// Key is the key that differs.
+	Key string
+	// Type is the type of the Change.
+	Type ChangeType
+	// OldValue is the value of the key in the old Options.
+	//
+	// Nil if Type is ChangeTypeAdded.
+	OldValue any
+	// NewValue is the value of the key in the new Options.
+	//
+	// Nil if Type is ChangeTypeRemoved.
+	NewValue any
+}
+
+// Equal returns true if the two Options have the same keys with the same values.
+//
+// Values are compared in their canonical form, so Options created from []int32{1} and
+// []int64{1} are equal. A nil Options is equal to EmptyOptions.
+func Equal(one Options, two Options) bool {
+	return len(Diff(one, two)) == 0
+}
+
+// Diff returns the Changes from oldOptions to newOptions, sorted by key.
+//
+// This is useful for caching layers that need to decide whether a previous response can be
+// reused, and for test assertions. Values are compared in their canonical form. A nil Options
+// is treated as EmptyOptions.
+func Diff(oldOptions Options, newOptions Options) []Change {
+	oldKeyToValue := optionsToKeyToValue(oldOptions)
+	newKeyToValue := optionsToKeyToValue(newOptions)
+	var changes []Change
+	for key, oldValue := range oldKeyToValue {
+		newValue, ok := newKeyToValue[key]
+		switch {
+		case !ok:
+			changes = append(
+				changes,
+				Change{
+					Key:      key,
+					Type:     ChangeTypeRemoved,
+					OldValue: oldValue,
+				},
+			)
+		case !reflect.DeepEqual(oldValue, newValue):
+			changes = append(
+				changes,
+				Change{
+					Key:      key,
+					Type:     ChangeTypeChanged,
+					OldValue: oldValue,
+					NewValue: newValue,
+				},
+			)
+		}
+	}
+	for key, newValue := range newKeyToValue {
+		if _, ok := oldKeyToValue[key]; !ok {
+			changes = append(
+				changes,
+				Change{
+					Key:      key,
+					Type:     ChangeTypeAdded,
+					NewValue: newValue,
+				},
+			)
+		}
+	}
+	sort.Slice(
+		changes,
+		func(i int, j int) bool { return changes[i].Key < changes[j].Key },
+	)
+	return changes
+}
+
+// *** PRIVATE ***
+
+func optionsToKeyToValue(options Options) map[string]any {
+	keyToValue := make(map[string]any)
+	if options == nil {
+		return keyToValue
+	}
+	options.Range(
+		func(key string, value any) {
+			keyToValue[key] = value
+		},
+	)
+	return keyToValue
+}
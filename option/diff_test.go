@@ -0,0 +1,74 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	oldOptions, err := NewOptions(
+		map[string]any{
+			"foo": "bar",
+			"baz": []int32{1, 2},
+			"bat": true,
+		},
+	)
+	require.NoError(t, err)
+	newOptions, err := NewOptions(
+		map[string]any{
+			"foo": "bar",
+			"baz": []int64{1, 3},
+			"qux": 1.5,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Key:      "bat",
+				Type:     ChangeTypeRemoved,
+				OldValue: true,
+			},
+			{
+				Key:      "baz",
+				Type:     ChangeTypeChanged,
+				OldValue: []int64{1, 2},
+				NewValue: []int64{1, 3},
+			},
+			{
+				Key:      "qux",
+				Type:     ChangeTypeAdded,
+				NewValue: 1.5,
+			},
+		},
+		Diff(oldOptions, newOptions),
+	)
+	assert.False(t, Equal(oldOptions, newOptions))
+	assert.Empty(t, Diff(oldOptions, oldOptions))
+
+	canonicalOptions, err := NewOptions(map[string]any{"foo": "bar", "baz": []int64{1, 2}, "bat": true})
+	require.NoError(t, err)
+	assert.True(t, Equal(oldOptions, canonicalOptions))
+	assert.True(t, Equal(nil, EmptyOptions))
+	assert.Equal(t, "changed", ChangeTypeChanged.String())
+}
@@ -0,0 +1,303 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OptionsForJSON returns a new Options for the given JSON document.
+//
+// The document must be a JSON object of option keys to values, in the same form as the
+// options of a plugin in buf.yaml. Numbers without a fraction or exponent are int64 values,
+// and all other numbers are float64 values. Arrays are slices. Nested objects are
+// map[string]any values, which are only supported as the values of keys with
+// ReservedKeyPrefix, see Options.Get. Null is not supported.
+//
+// An empty document results in an Options with no keys.
+func OptionsForJSON(data []byte) (Options, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return EmptyOptions, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid options JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, errors.New("invalid options JSON: multiple top-level values")
+	}
+	return optionsForDocument(document)
+}
+
+// OptionsForYAML returns a new Options for the given YAML document.
+//
+// The document must be a YAML mapping of option keys to values, in the same form as the
+// options of a plugin in buf.yaml. Sequences are slices. Nested mappings are
+// map[string]any values, which are only supported as the values of keys with
+// ReservedKeyPrefix, see Options.Get. Null values are not supported.
+//
+// An empty document results in an Options with no keys.
+func OptionsForYAML(data []byte) (Options, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("invalid options YAML: %w", err)
+	}
+	if len(node.Content) == 0 {
+		return EmptyOptions, nil
+	}
+	document, err := yamlNodeToDocumentValue(node.Content[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid options YAML: %w", err)
+	}
+	if document == nil {
+		return EmptyOptions, nil
+	}
+	return optionsForDocument(document)
+}
+
+// OptionsToJSON returns the JSON document for the Options.
+//
+// The result can be parsed with OptionsForJSON to produce equivalent Options. Keys are
+// sorted. Floating-point values are always written with a fraction or exponent so that
+// they are not read back as integers. Options with []byte values cannot be represented
+// and result in an error.
+func OptionsToJSON(options Options) ([]byte, error) {
+	keyToValue, err := optionsToDocument(options)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(keyToValue)
+}
+
+// OptionsToYAML returns the YAML document for the Options.
+//
+// The result can be parsed with OptionsForYAML to produce equivalent Options. Keys are
+// sorted. Floating-point values are always written with a fraction or exponent so that
+// they are not read back as integers. Options with []byte values cannot be represented
+// and result in an error.
+func OptionsToYAML(options Options) ([]byte, error) {
+	keyToValue, err := optionsToDocument(options)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(keyToValue)
+}
+
+// *** PRIVATE ***
+
+// documentFloat is a float64 that is always encoded with a fraction or exponent.
+type documentFloat float64
+
+func (d documentFloat) MarshalJSON() ([]byte, error) {
+	if math.IsInf(float64(d), 0) || math.IsNaN(float64(d)) {
+		return nil, fmt.Errorf("unsupported float value for JSON: %v", float64(d))
+	}
+	return []byte(formatDocumentFloat(float64(d))), nil
+}
+
+func (d documentFloat) MarshalYAML() (any, error) {
+	var value string
+	switch f := float64(d); {
+	case math.IsInf(f, 1):
+		value = ".inf"
+	case math.IsInf(f, -1):
+		value = "-.inf"
+	case math.IsNaN(f):
+		value = ".nan"
+	default:
+		value = formatDocumentFloat(f)
+	}
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!float",
+		Value: value,
+	}, nil
+}
+
+func formatDocumentFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// yamlNodeToDocumentValue converts the YAML node to the value it would be decoded to,
+// except that timestamps are kept as the strings they were written as.
+func yamlNodeToDocumentValue(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlNodeToDocumentValue(node.Alias)
+	case yaml.MappingNode:
+		documentKeyToValue := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			var key string
+			if err := node.Content[i].Decode(&key); err != nil {
+				return nil, err
+			}
+			value, err := yamlNodeToDocumentValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			documentKeyToValue[key] = value
+		}
+		return documentKeyToValue, nil
+	case yaml.SequenceNode:
+		documentValues := make([]any, len(node.Content))
+		for i, subNode := range node.Content {
+			value, err := yamlNodeToDocumentValue(subNode)
+			if err != nil {
+				return nil, err
+			}
+			documentValues[i] = value
+		}
+		return documentValues, nil
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!timestamp" {
+			return node.Value, nil
+		}
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case yaml.DocumentNode:
+		return nil, errors.New("unexpected nested document")
+	default:
+		return nil, fmt.Errorf("unknown YAML node kind %v", node.Kind)
+	}
+}
+
+func optionsForDocument(document any) (Options, error) {
+	documentKeyToValue, ok := document.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("options document must be a mapping of keys to values but got %T", document)
+	}
+	keyToValue := make(map[string]any, len(documentKeyToValue))
+	for key, documentValue := range documentKeyToValue {
+		value, err := documentValueToValue(documentValue)
+		if err != nil {
+			return nil, newInvalidOptionError(key, err.Error())
+		}
+		keyToValue[key] = value
+	}
+	return NewOptions(keyToValue)
+}
+
+func documentValueToValue(documentValue any) (any, error) {
+	switch t := documentValue.(type) {
+	case nil:
+		return nil, errors.New("null values are not supported")
+	case json.Number:
+		if int64Value, err := t.Int64(); err == nil {
+			return int64Value, nil
+		}
+		float64Value, err := t.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", t.String(), err)
+		}
+		return float64Value, nil
+	case map[string]any:
+		keyToValue := make(map[string]any, len(t))
+		for key, subDocumentValue := range t {
+			value, err := documentValueToValue(subDocumentValue)
+			if err != nil {
+				return nil, err
+			}
+			keyToValue[key] = value
+		}
+		return keyToValue, nil
+	case []any:
+		values := make([]any, len(t))
+		for i, subDocumentValue := range t {
+			value, err := documentValueToValue(subDocumentValue)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	default:
+		return documentValue, nil
+	}
+}
+
+func optionsToDocument(options Options) (map[string]any, error) {
+	keyToValue := make(map[string]any)
+	var err error
+	options.Range(
+		func(key string, value any) {
+			if err != nil {
+				return
+			}
+			var documentValue any
+			documentValue, err = valueToDocumentValue(value)
+			if err != nil {
+				err = newInvalidOptionError(key, err.Error())
+				return
+			}
+			keyToValue[key] = documentValue
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return keyToValue, nil
+}
+
+func valueToDocumentValue(value any) (any, error) {
+	if _, ok := value.([]byte); ok {
+		return nil, errors.New("bytes values cannot be represented in a document")
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch kind := reflectValue.Kind(); {
+	case isFloatKind(kind):
+		return documentFloat(reflectValue.Float()), nil
+	case kind == reflect.Slice:
+		documentValues := make([]any, reflectValue.Len())
+		for i := range reflectValue.Len() {
+			documentValue, err := valueToDocumentValue(reflectValue.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			documentValues[i] = documentValue
+		}
+		return documentValues, nil
+	case kind == reflect.Map:
+		documentKeyToValue := make(map[string]any, reflectValue.Len())
+		mapIter := reflectValue.MapRange()
+		for mapIter.Next() {
+			documentValue, err := valueToDocumentValue(mapIter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			documentKeyToValue[mapIter.Key().String()] = documentValue
+		}
+		return documentKeyToValue, nil
+	default:
+		return value, nil
+	}
+}
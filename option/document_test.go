@@ -0,0 +1,153 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsForYAML(t *testing.T) {
+	t.Parallel()
+
+	options, err := OptionsForYAML(
+		[]byte(`
+timestamp_suffix: _time
+max_length: 10
+ratio: 1.0
+enabled: true
+since: 2024-01-02T15:04:05Z
+excludes:
+  - foo
+  - bar
+matrix:
+  - [1, 2]
+  - [3]
+`),
+	)
+	require.NoError(t, err)
+	testOptionsEqual(
+		t,
+		map[string]any{
+			"timestamp_suffix": "_time",
+			"max_length":       int64(10),
+			"ratio":            float64(1),
+			"enabled":          true,
+			"since":            "2024-01-02T15:04:05Z",
+			"excludes":         []string{"foo", "bar"},
+			"matrix":           [][]int64{{1, 2}, {3}},
+		},
+		options,
+	)
+
+	data, err := OptionsToYAML(options)
+	require.NoError(t, err)
+	roundTripOptions, err := OptionsForYAML(data)
+	require.NoError(t, err)
+	assert.Equal(t, options, roundTripOptions)
+
+	options, err = OptionsForYAML(nil)
+	require.NoError(t, err)
+	assert.Equal(t, EmptyOptions, options)
+
+	_, err = OptionsForYAML([]byte("- foo"))
+	assert.Error(t, err)
+	_, err = OptionsForYAML([]byte("foo:\n  bar: baz"))
+	assert.Error(t, err)
+	options, err = OptionsForYAML([]byte("bufplugin_foo:\n  bar: baz"))
+	require.NoError(t, err)
+	stringMap, _, err := options.GetStringMap("bufplugin_foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"bar": "baz"}, stringMap)
+	data, err = OptionsToYAML(options)
+	require.NoError(t, err)
+	assert.Equal(t, "bufplugin_foo:\n    bar: baz\n", string(data))
+	_, err = OptionsForYAML([]byte("foo: null"))
+	assert.Error(t, err)
+	_, err = OptionsForYAML([]byte("foo: {bar: null}"))
+	assert.Error(t, err)
+	// Maps nested in sequences have no representation on the wire.
+	_, err = OptionsForYAML([]byte("bufplugin_foo: [1, {bar: baz}]"))
+	assert.Error(t, err)
+	options, err = OptionsForYAML([]byte("foo: [1, bar]"))
+	require.NoError(t, err)
+	value, ok := options.Get("foo")
+	require.True(t, ok)
+	assert.Equal(t, []any{int64(1), "bar"}, value)
+}
+
+func TestOptionsForJSON(t *testing.T) {
+	t.Parallel()
+
+	options, err := OptionsForJSON(
+		[]byte(`{"timestamp_suffix": "_time", "max_length": 10, "ratio": 1.0, "scale": 2.5e3, "excludes": ["foo", "bar"]}`),
+	)
+	require.NoError(t, err)
+	testOptionsEqual(
+		t,
+		map[string]any{
+			"timestamp_suffix": "_time",
+			"max_length":       int64(10),
+			"ratio":            float64(1),
+			"scale":            float64(2500),
+			"excludes":         []string{"foo", "bar"},
+		},
+		options,
+	)
+
+	data, err := OptionsToJSON(options)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"excludes":["foo","bar"],"max_length":10,"ratio":1.0,"scale":2500.0,"timestamp_suffix":"_time"}`,
+		string(data),
+	)
+	roundTripOptions, err := OptionsForJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, options, roundTripOptions)
+
+	options, err = OptionsForJSON([]byte("  "))
+	require.NoError(t, err)
+	assert.Equal(t, EmptyOptions, options)
+
+	_, err = OptionsForJSON([]byte(`["foo"]`))
+	assert.Error(t, err)
+	_, err = OptionsForJSON([]byte(`{"foo": "bar"} {}`))
+	assert.Error(t, err)
+	_, err = OptionsForJSON([]byte(`{"foo": {"bar": null}}`))
+	assert.Error(t, err)
+	_, err = OptionsForJSON([]byte(`{"foo": {"bar": "baz"}}`))
+	assert.Error(t, err)
+	options, err = OptionsForJSON([]byte(`{"bufplugin_foo": {"bar": "baz"}}`))
+	require.NoError(t, err)
+	testOptionsEqual(t, map[string]any{"bufplugin_foo": map[string]any{"bar": "baz"}}, options)
+
+	options, err = NewOptions(map[string]any{"foo": []byte("bar")})
+	require.NoError(t, err)
+	_, err = OptionsToJSON(options)
+	assert.Error(t, err)
+}
+
+func testOptionsEqual(t *testing.T, expected map[string]any, options Options) {
+	actual := make(map[string]any)
+	options.Range(
+		func(key string, value any) {
+			actual[key] = value
+		},
+	)
+	assert.Equal(t, expected, actual)
+}
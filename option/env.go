@@ -0,0 +1,138 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var envVarNameRegexp = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// ExpandEnv returns a new Options with environment variable references expanded in the
+// values of every key in the Schema that has ExpandEnv set, including values set by a
+// deprecated alias.
+//
+// Within string values, and each element of string slice values:
+//
+//   - ${NAME} is replaced by the value of the environment variable NAME.
+//   - $$ is replaced by a single $.
+//   - Any other $ is left as-is.
+//
+// Values are looked up with lookupEnv, which is typically os.LookupEnv. Referencing an
+// environment variable that is not set, or an invalid variable name, is an error. Every
+// such error is reported in the returned error, which names the key and the variable but
+// never the value.
+//
+// The Schema is assumed to be valid.
+func ExpandEnv(schema *Schema, options Options, lookupEnv func(string) (string, bool)) (Options, error) {
+	expandKeys := make(map[string]struct{})
+	for _, keySpec := range schema.Keys {
+		if !keySpec.ExpandEnv {
+			continue
+		}
+		for _, key := range append([]string{keySpec.Key}, keySpec.Aliases...) {
+			expandKeys[key] = struct{}{}
+		}
+	}
+	keyToValue := make(map[string]any)
+	var invalidOptionErrors []*invalidOptionError
+	options.Range(
+		func(key string, value any) {
+			if _, ok := expandKeys[key]; ok {
+				expandedValue, err := expandEnvValue(value, lookupEnv)
+				if err != nil {
+					invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(key, err.Error()))
+					return
+				}
+				if err := validateValue(expandedValue); err != nil {
+					invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(key, "value is empty after expanding environment variables"))
+					return
+				}
+				value = expandedValue
+			}
+			keyToValue[key] = value
+		},
+	)
+	if len(invalidOptionErrors) > 0 {
+		return nil, newInvalidOptionsError(invalidOptionErrors)
+	}
+	return NewOptions(keyToValue)
+}
+
+// *** PRIVATE ***
+
+// expandEnvValue expands the value if it is a string or a slice of strings.
+//
+// Other values are returned as-is, and will be reported by ValidateOptions.
+func expandEnvValue(value any, lookupEnv func(string) (string, bool)) (any, error) {
+	switch t := value.(type) {
+	case string:
+		return expandEnvString(t, lookupEnv)
+	case []string:
+		expandedValues := make([]string, len(t))
+		for i, subValue := range t {
+			expandedValue, err := expandEnvString(subValue, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			expandedValues[i] = expandedValue
+		}
+		return expandedValues, nil
+	default:
+		if reflect.ValueOf(value).Kind() == reflect.String {
+			return expandEnvString(reflect.ValueOf(value).String(), lookupEnv)
+		}
+		return value, nil
+	}
+}
+
+func expandEnvString(s string, lookupEnv func(string) (string, bool)) (string, error) {
+	var sb strings.Builder
+	for {
+		index := strings.IndexByte(s, '$')
+		if index == -1 || index == len(s)-1 {
+			_, _ = sb.WriteString(s)
+			return sb.String(), nil
+		}
+		_, _ = sb.WriteString(s[:index])
+		switch s[index+1] {
+		case '$':
+			_ = sb.WriteByte('$')
+			s = s[index+2:]
+		case '{':
+			end := strings.IndexByte(s[index+2:], '}')
+			if end == -1 {
+				return "", errors.New("unterminated environment variable reference")
+			}
+			name := s[index+2 : index+2+end]
+			if !envVarNameRegexp.MatchString(name) {
+				return "", fmt.Errorf("invalid environment variable name %q", name)
+			}
+			envValue, ok := lookupEnv(name)
+			if !ok {
+				return "", fmt.Errorf("environment variable %q is not set", name)
+			}
+			_, _ = sb.WriteString(envValue)
+			s = s[index+2+end+1:]
+		default:
+			_ = sb.WriteByte('$')
+			s = s[index+1:]
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"API_TOKEN": "secret",
+		"HOME":      "/home/foo",
+		"EMPTY":     "",
+	}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+	schema := &Schema{
+		Keys: []*KeySpec{
+			{Key: "api_token", Type: TypeString, ExpandEnv: true, Aliases: []string{"token"}},
+			{Key: "paths", Type: TypeStringSlice, ExpandEnv: true},
+			{Key: "literal", Type: TypeString},
+		},
+		AllowUnknownKeys: true,
+	}
+	require.NoError(t, ValidateSchema(schema))
+
+	options, err := NewOptions(
+		map[string]any{
+			"token":   "Bearer ${API_TOKEN}",
+			"paths":   []string{"${HOME}/bin", "$$HOME", "$HOME", "cost: 5$"},
+			"literal": "${HOME}",
+			"unknown": "${HOME}",
+		},
+	)
+	require.NoError(t, err)
+	options, err = ExpandEnv(schema, options, lookupEnv)
+	require.NoError(t, err)
+	testOptionsEqual(
+		t,
+		map[string]any{
+			"token":   "Bearer secret",
+			"paths":   []string{"/home/foo/bin", "$HOME", "$HOME", "cost: 5$"},
+			"literal": "${HOME}",
+			"unknown": "${HOME}",
+		},
+		options,
+	)
+
+	options, err = NewOptions(
+		map[string]any{
+			"api_token": "${EMPTY}",
+			"paths":     []string{"${UNSET}", "${NOT-VALID}"},
+		},
+	)
+	require.NoError(t, err)
+	_, err = ExpandEnv(schema, options, lookupEnv)
+	assert.EqualError(
+		t,
+		err,
+		`invalid options: `+
+			`option "api_token": value is empty after expanding environment variables; `+
+			`option "paths": environment variable "UNSET" is not set`,
+	)
+	options, err = NewOptions(map[string]any{"api_token": "${API_TOKEN"})
+	require.NoError(t, err)
+	_, err = ExpandEnv(schema, options, lookupEnv)
+	assert.Error(t, err)
+
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeInt64, ExpandEnv: true})
+}
@@ -16,6 +16,7 @@ package option
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -43,3 +44,113 @@ func (u *unexpectedOptionValueTypeError) Error() string {
 	_, _ = sb.WriteString(fmt.Sprintf(`": expected %T, got %T`, u.expected, u.actual))
 	return sb.String()
 }
+
+type invalidOptionError struct {
+	key     string
+	message string
+}
+
+func newInvalidOptionError(key string, message string) *invalidOptionError {
+	return &invalidOptionError{
+		key:     key,
+		message: message,
+	}
+}
+
+func newInvalidOptionErrorf(key string, format string, args ...any) *invalidOptionError {
+	return newInvalidOptionError(key, fmt.Sprintf(format, args...))
+}
+
+func (i *invalidOptionError) Error() string {
+	if i == nil {
+		return ""
+	}
+	var sb strings.Builder
+	_, _ = sb.WriteString(`option "`)
+	_, _ = sb.WriteString(i.key)
+	_, _ = sb.WriteString(`": `)
+	_, _ = sb.WriteString(i.message)
+	return sb.String()
+}
+
+type invalidOptionsError struct {
+	invalidOptionErrors []*invalidOptionError
+}
+
+// newInvalidOptionsError returns a new invalidOptionsError.
+//
+// The given invalidOptionErrors are sorted by key so that the resulting error is deterministic.
+func newInvalidOptionsError(invalidOptionErrors []*invalidOptionError) *invalidOptionsError {
+	sort.SliceStable(
+		invalidOptionErrors,
+		func(i int, j int) bool { return invalidOptionErrors[i].key < invalidOptionErrors[j].key },
+	)
+	return &invalidOptionsError{
+		invalidOptionErrors: invalidOptionErrors,
+	}
+}
+
+func (i *invalidOptionsError) Error() string {
+	if i == nil {
+		return ""
+	}
+	if len(i.invalidOptionErrors) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	_, _ = sb.WriteString("invalid options: ")
+	for j, invalidOptionError := range i.invalidOptionErrors {
+		if j > 0 {
+			_, _ = sb.WriteString("; ")
+		}
+		_, _ = sb.WriteString(invalidOptionError.Error())
+	}
+	return sb.String()
+}
+
+func (i *invalidOptionsError) Unwrap() []error {
+	if i == nil {
+		return nil
+	}
+	errs := make([]error, len(i.invalidOptionErrors))
+	for j, invalidOptionError := range i.invalidOptionErrors {
+		errs[j] = invalidOptionError
+	}
+	return errs
+}
+
+type validateSchemaError struct {
+	delegate error
+}
+
+func newValidateSchemaErrorf(format string, args ...any) *validateSchemaError {
+	return &validateSchemaError{
+		delegate: fmt.Errorf(format, args...),
+	}
+}
+
+func wrapValidateSchemaError(delegate error) *validateSchemaError {
+	return &validateSchemaError{
+		delegate: delegate,
+	}
+}
+
+func (vs *validateSchemaError) Error() string {
+	if vs == nil {
+		return ""
+	}
+	if vs.delegate == nil {
+		return ""
+	}
+	var sb strings.Builder
+	_, _ = sb.WriteString(`invalid option.Schema: `)
+	_, _ = sb.WriteString(vs.delegate.Error())
+	return sb.String()
+}
+
+func (vs *validateSchemaError) Unwrap() error {
+	if vs == nil {
+		return nil
+	}
+	return vs.delegate
+}
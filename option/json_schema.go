@@ -0,0 +1,262 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// JSONSchemaDialect is the JSON Schema dialect of the documents produced by SchemaToJSONSchema.
+	JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+	// durationPattern matches the strings accepted by time.ParseDuration.
+	durationPattern = `^[-+]?(0|([0-9]*(\.[0-9]*)?(ns|us|µs|μs|ms|s|m|h))+)$`
+)
+
+// SchemaToJSONSchema returns a JSON Schema document that describes the options accepted by
+// the Schema.
+//
+// The document describes the options of a plugin in buf.yaml, in the same form as read by
+// OptionsForJSON and OptionsForYAML, so that editors and configuration validators can offer
+// completion and validation for a plugin's options. The dialect is JSONSchemaDialect.
+//
+// Aliases are described as deprecated properties. Keys of TypeBytes cannot be represented
+// in a document and are omitted. Values of keys with ExpandEnv set are only constrained by
+// their type, as environment variable references are expanded on the plugin side.
+//
+// The Schema will be validated.
+func SchemaToJSONSchema(schema *Schema) ([]byte, error) {
+	if err := ValidateSchema(schema); err != nil {
+		return nil, err
+	}
+	allowAdditionalProperties := schema.AllowUnknownKeys
+	rootJSONSchema := &jsonSchema{
+		Schema:               JSONSchemaDialect,
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: &allowAdditionalProperties,
+	}
+	for _, keySpec := range schema.Keys {
+		if keySpec.Type == TypeBytes {
+			continue
+		}
+		keyJSONSchema := keySpecToJSONSchema(keySpec)
+		rootJSONSchema.Properties[keySpec.Key] = keyJSONSchema
+		for _, alias := range keySpec.Aliases {
+			aliasJSONSchema := *keyJSONSchema
+			aliasJSONSchema.Deprecated = true
+			rootJSONSchema.Properties[alias] = &aliasJSONSchema
+		}
+		if !keySpec.Required {
+			continue
+		}
+		if len(keySpec.Aliases) == 0 {
+			rootJSONSchema.Required = append(rootJSONSchema.Required, keySpec.Key)
+			continue
+		}
+		// Either the key or one of its aliases must be set.
+		requiredJSONSchema := &jsonSchema{}
+		for _, key := range append([]string{keySpec.Key}, keySpec.Aliases...) {
+			requiredJSONSchema.AnyOf = append(requiredJSONSchema.AnyOf, &jsonSchema{Required: []string{key}})
+		}
+		rootJSONSchema.AllOf = append(rootJSONSchema.AllOf, requiredJSONSchema)
+	}
+	return json.Marshal(rootJSONSchema)
+}
+
+// *** PRIVATE ***
+
+// jsonSchema is the subset of JSON Schema used by SchemaToJSONSchema.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MinLength            int                    `json:"minLength,omitempty"`
+	Minimum              *int64                 `json:"minimum,omitempty"`
+	Const                any                    `json:"const,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Not                  *jsonSchema            `json:"not,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	MinItems             int                    `json:"minItems,omitempty"`
+	OneOf                []*jsonSchema          `json:"oneOf,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	AllOf                []*jsonSchema          `json:"allOf,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Examples             []any                  `json:"examples,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+	WriteOnly            bool                   `json:"writeOnly,omitempty"`
+}
+
+// keySpecToJSONSchema returns the jsonSchema for the value of the KeySpec.
+//
+// The KeySpec is assumed to be valid and not of TypeBytes.
+func keySpecToJSONSchema(keySpec *KeySpec) *jsonSchema {
+	var allowedValues []any
+	if !keySpec.ExpandEnv {
+		allowedValues = keySpec.AllowedValues
+	}
+	var keyJSONSchema *jsonSchema
+	if elemType, ok := keySpec.Type.elemType(); ok {
+		keyJSONSchema = &jsonSchema{
+			Type:     "array",
+			Items:    typeToJSONSchema(elemType, allowedValues, keySpec.AllowedValuesCaseInsensitive),
+			MinItems: 1,
+		}
+	} else {
+		keyJSONSchema = typeToJSONSchema(keySpec.Type, allowedValues, keySpec.AllowedValuesCaseInsensitive)
+	}
+	keyJSONSchema.Description = keySpec.Description
+	if documentDefault, ok := valueToJSONSchemaValue(keySpec.Default); ok {
+		keyJSONSchema.Default = documentDefault
+	}
+	if documentExample, ok := valueToJSONSchemaValue(keySpec.Example); ok {
+		keyJSONSchema.Examples = []any{documentExample}
+	}
+	keyJSONSchema.WriteOnly = keySpec.Secret
+	return keyJSONSchema
+}
+
+// valueToJSONSchemaValue converts a Default or Example value of a KeySpec to its
+// document form.
+//
+// Returns false if the value is nil or cannot be represented in a document.
+func valueToJSONSchemaValue(value any) (any, bool) {
+	if value == nil {
+		return nil, false
+	}
+	canonicalValue, err := canonicalizeValue(value)
+	if err != nil {
+		return nil, false
+	}
+	documentValue, err := valueToDocumentValue(canonicalValue)
+	if err != nil {
+		return nil, false
+	}
+	return documentValue, true
+}
+
+// typeToJSONSchema returns the jsonSchema for a scalar Type.
+func typeToJSONSchema(t Type, allowedValues []any, caseInsensitive bool) *jsonSchema {
+	switch t {
+	case TypeBool:
+		// Only true can be represented, as false is the zero value.
+		return &jsonSchema{
+			Type:  "boolean",
+			Const: true,
+		}
+	case TypeInt64:
+		return &jsonSchema{
+			Type: "integer",
+			Not:  &jsonSchema{Const: json.Number("0")},
+			Enum: allowedValuesToJSONSchemaEnum(allowedValues),
+		}
+	case TypeFloat64:
+		return &jsonSchema{
+			Type: "number",
+			Not:  &jsonSchema{Const: json.Number("0")},
+			Enum: allowedValuesToJSONSchemaEnum(allowedValues),
+		}
+	case TypeString:
+		if len(allowedValues) > 0 && caseInsensitive {
+			return &jsonSchema{
+				Type:    "string",
+				Pattern: caseInsensitivePattern(allowedValues),
+			}
+		}
+		return &jsonSchema{
+			Type:      "string",
+			MinLength: 1,
+			Enum:      allowedValuesToJSONSchemaEnum(allowedValues),
+		}
+	case TypeDuration:
+		return &jsonSchema{
+			Type:    "string",
+			Pattern: durationPattern,
+		}
+	case TypeTimestamp:
+		return &jsonSchema{
+			Type:   "string",
+			Format: "date-time",
+		}
+	case TypeByteSize:
+		minimum := int64(1)
+		return &jsonSchema{
+			OneOf: []*jsonSchema{
+				{
+					Type:      "string",
+					MinLength: 1,
+				},
+				{
+					Type:    "integer",
+					Minimum: &minimum,
+				},
+			},
+		}
+	default:
+		return &jsonSchema{}
+	}
+}
+
+func allowedValuesToJSONSchemaEnum(allowedValues []any) []any {
+	if len(allowedValues) == 0 {
+		return nil
+	}
+	enum := make([]any, 0, len(allowedValues))
+	for _, allowedValue := range allowedValues {
+		documentValue, err := valueToDocumentValue(normalizeScalarValue(allowedValue))
+		if err != nil {
+			continue
+		}
+		enum = append(enum, documentValue)
+	}
+	return enum
+}
+
+// caseInsensitivePattern returns a pattern that matches any of the string allowedValues
+// without regard to case.
+//
+// JSON Schema patterns do not support case-insensitive flags, so each letter is matched
+// by a character class of its upper and lower case forms.
+func caseInsensitivePattern(allowedValues []any) string {
+	var sb strings.Builder
+	_, _ = sb.WriteString("^(")
+	for i, allowedValue := range allowedValues {
+		if i > 0 {
+			_, _ = sb.WriteString("|")
+		}
+		for _, r := range normalizeScalarValue(allowedValue).(string) {
+			upper, lower := unicode.ToUpper(r), unicode.ToLower(r)
+			if upper == lower {
+				_, _ = sb.WriteString(regexp.QuoteMeta(string(r)))
+				continue
+			}
+			_, _ = sb.WriteString("[")
+			_, _ = sb.WriteRune(upper)
+			_, _ = sb.WriteRune(lower)
+			_, _ = sb.WriteString("]")
+		}
+	}
+	_, _ = sb.WriteString(")$")
+	return sb.String()
+}
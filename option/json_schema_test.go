@@ -0,0 +1,133 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaToJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	data, err := SchemaToJSONSchema(
+		&Schema{
+			Keys: []*KeySpec{
+				{
+					Key:      "timestamp_suffix",
+					Type:     TypeString,
+					Required: true,
+					Aliases:  []string{"suffix"},
+				},
+				{
+					Key:           "mode",
+					Type:          TypeString,
+					Description:   "The mode.",
+					Example:       "lenient",
+					Default:       "strict",
+					AllowedValues: []any{"strict", "lenient"},
+				},
+				{
+					Key:                          "level",
+					Type:                         TypeStringSlice,
+					AllowedValues:                []any{"Info"},
+					AllowedValuesCaseInsensitive: true,
+				},
+				{
+					Key:      "max_length",
+					Type:     TypeInt64,
+					Required: true,
+				},
+				{
+					Key:     "ratio",
+					Type:    TypeFloat64,
+					Default: 1.0,
+				},
+				{
+					Key:    "token",
+					Type:   TypeString,
+					Secret: true,
+				},
+				{
+					Key:  "payload",
+					Type: TypeBytes,
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"timestamp_suffix": {"type": "string", "minLength": 1},
+				"suffix": {"type": "string", "minLength": 1, "deprecated": true},
+				"mode": {"description": "The mode.", "type": "string", "minLength": 1, "enum": ["strict", "lenient"], "default": "strict", "examples": ["lenient"]},
+				"level": {"type": "array", "items": {"type": "string", "pattern": "^([Ii][Nn][Ff][Oo])$"}, "minItems": 1},
+				"max_length": {"type": "integer", "not": {"const": 0}},
+				"ratio": {"type": "number", "not": {"const": 0}, "default": 1.0},
+				"token": {"type": "string", "minLength": 1, "writeOnly": true}
+			},
+			"required": ["max_length"],
+			"additionalProperties": false,
+			"allOf": [
+				{"anyOf": [{"required": ["timestamp_suffix"]}, {"required": ["suffix"]}]}
+			]
+		}`,
+		string(data),
+	)
+
+	data, err = SchemaToJSONSchema(
+		&Schema{
+			Keys: []*KeySpec{
+				{
+					Key:  "since",
+					Type: TypeTimestamp,
+				},
+				{
+					Key:  "timeout",
+					Type: TypeDuration,
+				},
+				{
+					Key:  "max_size",
+					Type: TypeByteSize,
+				},
+			},
+			AllowUnknownKeys: true,
+		},
+	)
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"since": {"type": "string", "format": "date-time"},
+				"timeout": {"type": "string", "pattern": "^[-+]?(0|([0-9]*(\\.[0-9]*)?(ns|us|µs|μs|ms|s|m|h))+)$"},
+				"max_size": {"oneOf": [{"type": "string", "minLength": 1}, {"type": "integer", "minimum": 1}]}
+			},
+			"additionalProperties": true
+		}`,
+		string(data),
+	)
+
+	_, err = SchemaToJSONSchema(&Schema{Keys: []*KeySpec{{Key: "foo"}}})
+	assert.Error(t, err)
+}
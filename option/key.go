@@ -0,0 +1,229 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"reflect"
+	"time"
+)
+
+// KeyValue is the set of Go types that a Key can have.
+type KeyValue interface {
+	bool | int64 | float64 | string | []byte | []int64 | []float64 | []string | time.Duration | time.Time | ByteSize
+}
+
+// Key is a typed option key.
+//
+// A Key is declared once per option, typically as a package-level variable, and is then
+// used to get the value of the option with compile-time type safety across all of the
+// RuleHandlers of a plugin.
+//
+//	var timestampSuffixKey = option.NewKey(
+//		"timestamp_suffix",
+//		option.KeyWithDefault("_time"),
+//	)
+//
+//	func handle(ctx context.Context, responseWriter check.ResponseWriter, request check.Request) error {
+//		timestampSuffix, err := timestampSuffixKey.Get(request.Options())
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Use KeySpec to add the Key to a Schema.
+type Key[T KeyValue] struct {
+	key          string
+	defaultValue T
+	validate     func(T) error
+}
+
+// NewKey returns a new Key for the given option key.
+//
+// The key should satisfy the requirements of KeySpec.Key. This is validated when the
+// KeySpec of the Key is part of a Schema that is validated.
+func NewKey[T KeyValue](key string, options ...KeyOption[T]) *Key[T] {
+	keyOptions := newKeyOptions[T]()
+	for _, option := range options {
+		option(keyOptions)
+	}
+	return &Key[T]{
+		key:          key,
+		defaultValue: keyOptions.defaultValue,
+		validate:     keyOptions.validate,
+	}
+}
+
+// Key returns the option key.
+func (k *Key[T]) Key() string {
+	return k.key
+}
+
+// Get gets the value of the Key from the Options.
+//
+// If the key is not set, the default value is returned, which is the zero value of T
+// unless KeyWithDefault was used. If the key is set but its value is not of type T, or
+// the value is rejected by the validator given with KeyWithValidator, an error is returned.
+// The validator is not called for the default value.
+func (k *Key[T]) Get(options Options) (T, error) {
+	var zero T
+	if _, ok := options.Get(k.key); !ok {
+		return k.defaultValue, nil
+	}
+	anyValue, err := getKeyValue(options, k.key, zero)
+	if err != nil {
+		return zero, err
+	}
+	value, ok := anyValue.(T)
+	if !ok {
+		// This should never happen as getKeyValue returns a value of the type of zero.
+		return zero, newUnexpectedOptionValueTypeError(k.key, zero, anyValue)
+	}
+	if k.validate != nil {
+		if err := k.validate(value); err != nil {
+			return zero, newInvalidOptionError(k.key, err.Error())
+		}
+	}
+	return value, nil
+}
+
+// KeySpec returns a new KeySpec for the Key.
+//
+// The Type of the KeySpec is derived from T, and the Default is the default value of the
+// Key, if any. The returned KeySpec can be modified further before it is added to a Schema,
+// for example to set Required or AllowedValues.
+func (k *Key[T]) KeySpec() *KeySpec {
+	var zero T
+	keySpec := &KeySpec{
+		Key:  k.key,
+		Type: keyValueType(zero),
+	}
+	if !reflect.ValueOf(k.defaultValue).IsZero() {
+		keySpec.Default = keyValueToDefault(k.defaultValue)
+	}
+	return keySpec
+}
+
+// KeyOption is an option for a new Key.
+type KeyOption[T KeyValue] func(*keyOptions[T])
+
+// KeyWithDefault returns a new KeyOption that sets the value that Key.Get returns if
+// the key is not set.
+//
+// The default is the zero value of T.
+func KeyWithDefault[T KeyValue](defaultValue T) KeyOption[T] {
+	return func(keyOptions *keyOptions[T]) {
+		keyOptions.defaultValue = defaultValue
+	}
+}
+
+// KeyWithValidator returns a new KeyOption that sets a function that validates the value
+// of the key when it is set.
+//
+// If the function returns an error, Key.Get returns an error that names the key.
+func KeyWithValidator[T KeyValue](validate func(T) error) KeyOption[T] {
+	return func(keyOptions *keyOptions[T]) {
+		keyOptions.validate = validate
+	}
+}
+
+// *** PRIVATE ***
+
+type keyOptions[T KeyValue] struct {
+	defaultValue T
+	validate     func(T) error
+}
+
+func newKeyOptions[T KeyValue]() *keyOptions[T] {
+	return &keyOptions[T]{}
+}
+
+// getKeyValue gets the value of the key as the type of zero.
+func getKeyValue(options Options, key string, zero any) (any, error) {
+	switch zero.(type) {
+	case bool:
+		return GetBoolValue(options, key)
+	case int64:
+		return GetInt64Value(options, key)
+	case float64:
+		return GetFloat64Value(options, key)
+	case string:
+		return GetStringValue(options, key)
+	case []byte:
+		return GetBytesValue(options, key)
+	case []int64:
+		return GetInt64SliceValue(options, key)
+	case []float64:
+		return GetFloat64SliceValue(options, key)
+	case []string:
+		return GetStringSliceValue(options, key)
+	case time.Duration:
+		value, _, err := options.GetDuration(key)
+		return value, err
+	case time.Time:
+		value, _, err := options.GetTimestamp(key)
+		return value, err
+	case ByteSize:
+		value, _, err := options.GetByteSize(key)
+		return value, err
+	default:
+		// This should never happen as KeyValue is a closed set of types.
+		return nil, newUnexpectedOptionValueTypeError(key, zero, nil)
+	}
+}
+
+// keyValueType returns the Type for the type of zero.
+func keyValueType(zero any) Type {
+	switch zero.(type) {
+	case bool:
+		return TypeBool
+	case int64:
+		return TypeInt64
+	case float64:
+		return TypeFloat64
+	case string:
+		return TypeString
+	case []byte:
+		return TypeBytes
+	case []int64:
+		return TypeInt64Slice
+	case []float64:
+		return TypeFloat64Slice
+	case []string:
+		return TypeStringSlice
+	case time.Duration:
+		return TypeDuration
+	case time.Time:
+		return TypeTimestamp
+	case ByteSize:
+		return TypeByteSize
+	default:
+		return 0
+	}
+}
+
+// keyValueToDefault converts the value to the form expected by KeySpec.Default.
+func keyValueToDefault(value any) any {
+	switch t := value.(type) {
+	case time.Duration:
+		return t.String()
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	case ByteSize:
+		return int64(t)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	suffixKey := NewKey(
+		"timestamp_suffix",
+		KeyWithDefault("_time"),
+		KeyWithValidator(
+			func(value string) error {
+				if value[0] != '_' {
+					return errors.New("must start with an underscore")
+				}
+				return nil
+			},
+		),
+	)
+	maxLengthKey := NewKey[int64]("max_length")
+	timeoutKey := NewKey("timeout", KeyWithDefault(30*time.Second))
+
+	options, err := NewOptions(
+		map[string]any{
+			"timestamp_suffix": "_ts",
+			"max_length":       int32(10),
+		},
+	)
+	require.NoError(t, err)
+	suffix, err := suffixKey.Get(options)
+	require.NoError(t, err)
+	assert.Equal(t, "_ts", suffix)
+	maxLength, err := maxLengthKey.Get(options)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), maxLength)
+	timeout, err := timeoutKey.Get(options)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, timeout)
+
+	suffix, err = suffixKey.Get(EmptyOptions)
+	require.NoError(t, err)
+	assert.Equal(t, "_time", suffix)
+	maxLength, err = maxLengthKey.Get(EmptyOptions)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), maxLength)
+
+	options, err = NewOptions(
+		map[string]any{
+			"timestamp_suffix": "ts",
+			"max_length":       "10",
+		},
+	)
+	require.NoError(t, err)
+	_, err = suffixKey.Get(options)
+	assert.EqualError(t, err, `option "timestamp_suffix": must start with an underscore`)
+	_, err = maxLengthKey.Get(options)
+	assert.Error(t, err)
+
+	schema := &Schema{
+		Keys: []*KeySpec{
+			suffixKey.KeySpec(),
+			maxLengthKey.KeySpec(),
+			timeoutKey.KeySpec(),
+		},
+	}
+	require.NoError(t, ValidateSchema(schema))
+	assert.Equal(t, &KeySpec{Key: "timestamp_suffix", Type: TypeString, Default: "_time"}, schema.Keys[0])
+	assert.Equal(t, &KeySpec{Key: "max_length", Type: TypeInt64}, schema.Keys[1])
+	assert.Equal(t, &KeySpec{Key: "timeout", Type: TypeDuration, Default: "30s"}, schema.Keys[2])
+}
@@ -0,0 +1,122 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"fmt"
+
+	optionv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/option/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultLimits are the Limits that plugins enforce on the options of every request by
+// default.
+//
+// These are well above what any reasonable configuration needs, and exist to protect
+// plugins from hostile or accidentally large option payloads.
+var DefaultLimits = Limits{
+	MaxKeys:        1024,
+	MaxKeyLength:   256,
+	MaxDepth:       8,
+	MaxEncodedSize: 4 << 20,
+}
+
+// Limits are limits on the size of options.
+//
+// A zero value for any field means that there is no limit.
+type Limits struct {
+	// MaxKeys is the maximum number of options.
+	MaxKeys int
+	// MaxKeyLength is the maximum length of a key in bytes.
+	MaxKeyLength int
+	// MaxDepth is the maximum nesting depth of a value.
+	//
+	// Scalar values have a depth of 0, slices of scalar values have a depth of 1,
+	// slices of slices of scalar values have a depth of 2, and so on.
+	MaxDepth int
+	// MaxEncodedSize is the maximum total size in bytes of the Protobuf encoding of
+	// the options.
+	MaxEncodedSize int
+}
+
+// ValidateLimits validates that the optionv1.Options are within the Limits.
+//
+// This is meant to be called on the options of a request before they are converted to
+// Options, so that oversized payloads are rejected before any further work is done on
+// them. Every key that is too long or has a value that is nested too deeply is reported.
+func ValidateLimits(limits Limits, protoOptions []*optionv1.Option) error {
+	if limits.MaxKeys > 0 && len(protoOptions) > limits.MaxKeys {
+		return fmt.Errorf("too many options: got %d, maximum is %d", len(protoOptions), limits.MaxKeys)
+	}
+	if limits.MaxEncodedSize > 0 {
+		var encodedSize int
+		for _, protoOption := range protoOptions {
+			encodedSize += proto.Size(protoOption)
+			if encodedSize > limits.MaxEncodedSize {
+				return fmt.Errorf("options are too large: encoded size exceeds maximum of %d bytes", limits.MaxEncodedSize)
+			}
+		}
+	}
+	var invalidOptionErrors []*invalidOptionError
+	for _, protoOption := range protoOptions {
+		key := protoOption.GetKey()
+		if limits.MaxKeyLength > 0 && len(key) > limits.MaxKeyLength {
+			// Do not include oversized keys in errors.
+			invalidOptionErrors = append(
+				invalidOptionErrors,
+				newInvalidOptionErrorf(
+					key[:limits.MaxKeyLength]+"...",
+					"key is too long: got %d bytes, maximum is %d",
+					len(key),
+					limits.MaxKeyLength,
+				),
+			)
+			continue
+		}
+		if limits.MaxDepth > 0 && protoValueDepthExceeds(protoOption.GetValue(), limits.MaxDepth) {
+			invalidOptionErrors = append(
+				invalidOptionErrors,
+				newInvalidOptionErrorf(key, "value is nested too deeply: maximum depth is %d", limits.MaxDepth),
+			)
+		}
+	}
+	if len(invalidOptionErrors) > 0 {
+		return newInvalidOptionsError(invalidOptionErrors)
+	}
+	return nil
+}
+
+// *** PRIVATE ***
+
+// protoValueDepthExceeds returns true if the depth of the optionv1.Value is greater
+// than maxDepth.
+//
+// This stops descending as soon as maxDepth is exceeded, so that deeply nested values
+// are not fully traversed.
+func protoValueDepthExceeds(protoValue *optionv1.Value, maxDepth int) bool {
+	protoListValue := protoValue.GetListValue()
+	if protoListValue == nil {
+		return false
+	}
+	if maxDepth == 0 {
+		return true
+	}
+	for _, protoSubValue := range protoListValue.GetValues() {
+		if protoValueDepthExceeds(protoSubValue, maxDepth-1) {
+			return true
+		}
+	}
+	return false
+}
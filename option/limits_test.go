@@ -0,0 +1,60 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"strings"
+	"testing"
+
+	optionv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/option/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLimits(t *testing.T) {
+	t.Parallel()
+
+	protoOptions := []*optionv1.Option{
+		testNewProtoOption(t, "foo", "bar"),
+		testNewProtoOption(t, "baz", [][]string{{"bat"}}),
+	}
+	assert.NoError(t, ValidateLimits(Limits{}, protoOptions))
+	assert.NoError(t, ValidateLimits(DefaultLimits, protoOptions))
+	assert.NoError(t, ValidateLimits(Limits{MaxKeys: 2, MaxKeyLength: 3, MaxDepth: 2}, protoOptions))
+
+	err := ValidateLimits(Limits{MaxKeys: 1}, protoOptions)
+	assert.EqualError(t, err, "too many options: got 2, maximum is 1")
+	err = ValidateLimits(Limits{MaxEncodedSize: 10}, protoOptions)
+	assert.EqualError(t, err, "options are too large: encoded size exceeds maximum of 10 bytes")
+	err = ValidateLimits(Limits{MaxDepth: 1}, protoOptions)
+	assert.EqualError(t, err, `invalid options: option "baz": value is nested too deeply: maximum depth is 1`)
+
+	err = ValidateLimits(
+		Limits{MaxKeyLength: 4},
+		[]*optionv1.Option{
+			testNewProtoOption(t, strings.Repeat("a", 10), "bar"),
+		},
+	)
+	assert.EqualError(t, err, `invalid options: option "aaaa...": key is too long: got 10 bytes, maximum is 4`)
+}
+
+func testNewProtoOption(t *testing.T, key string, value any) *optionv1.Option {
+	protoValue, err := ValueToProtoValue(value)
+	require.NoError(t, err)
+	return &optionv1.Option{
+		Key:   key,
+		Value: protoValue,
+	}
+}
@@ -21,5 +21,5 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package option provides the Options type for plugins.
+// Package option provides the Options and Schema types for plugins.
 package option // import "buf.build/go/bufplugin/option"
@@ -26,11 +26,23 @@ package option
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	optionv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/option/v1"
 )
 
+// ReservedKeyPrefix is the prefix of the keys of options that are recognized by the
+// framework for every plugin, such as check.SeverityOverridesOptionKey.
+//
+// Schemas cannot contain keys or aliases with this prefix. Options with this prefix are the
+// only options that can have map values, see Options.Get.
+const ReservedKeyPrefix = "bufplugin_"
+
 // EmptyOptions is an instance of Options with no keys.
 var EmptyOptions = newOptionsNoValidate(nil)
 
@@ -45,6 +57,12 @@ var EmptyOptions = newOptionsNoValidate(nil)
 //
 // It is not possible to set a key with a not-present value. Do not add an Option with
 // a given key to denote that the key is not set.
+//
+// Options are immutable and safe for concurrent use, as the same Options are shared by all
+// RuleHandlers of a request. Slice and map values are copied when Options are created. To
+// avoid allocating on every read, Get and Range return the values held by the Options,
+// which must not be modified. The typed getters, such as GetStringSlice and
+// GetInt64SliceValue, return copies that can be modified.
 type Options interface {
 	// Get gets the option value for the given key.
 	//
@@ -57,107 +75,254 @@ type Options interface {
 	// - bool
 	// - A slice of any of the above, recursively (i.e. []string, [][]int64, ...)
 	//
-	// A caller should not modify a returned value.
+	// These are the only values that can be represented on the wire. Values are never
+	// zero, and slices and maps are never empty. If all elements of a slice have the same
+	// type, the slice has that element type, for example []string or [][]int64. Otherwise,
+	// the slice is a []any, for example []any{"foo", int64(1)} or
+	// []any{[]string{"foo"}, []int64{1}}. This applies at every level of nesting.
+	//
+	// Values passed to NewOptions are converted to the above types, so the values
+	// returned from Get are the same before and after a round trip through ToProto
+	// and OptionsForProtoOptions.
 	//
-	// The key must have at least four characters.
+	// Maps cannot be represented on the wire, as optionv1.Value has no map type. The only
+	// exception is that the value of a key with ReservedKeyPrefix may be a non-empty
+	// map[string]any whose values are any of the above. These options are recognized
+	// by the framework, which sends them separately from the other options, and ToProto
+	// returns an error for them. NewOptions returns an error for maps under any other key,
+	// and for maps nested in slices or maps, such as maps of lists of maps. Structs are
+	// not supported either.
+	//
+	// Slice and map values are shared with the Options, and must not be modified by the
+	// caller. Use a typed getter such as GetStringSlice to get a copy that can be modified.
+	//
+	// The key must have at least three characters.
 	// The key must start and end with a lowercase letter from a-z, and only consist
 	// of lowercase letters from a-z and underscores.
 	Get(key string) (any, bool)
+	// GetBool gets the bool value for the given key.
+	//
+	// Returns false for present if the key is not set. If the key is set and its
+	// value is not a bool, an error is returned.
+	GetBool(key string) (value bool, present bool, err error)
+	// GetInt64 gets the int64 value for the given key.
+	//
+	// Values of any Go integer type are converted to int64. Floating-point values
+	// are never converted, even if they have no fractional part. If the key is set
+	// and its value is not an integer, or is an unsigned integer that overflows an
+	// int64, an error is returned.
+	GetInt64(key string) (value int64, present bool, err error)
+	// GetFloat64 gets the float64 value for the given key.
+	//
+	// Values of type float32 and float64 are converted to float64. Integer values
+	// are never converted. If the key is set and its value is not a float, an
+	// error is returned.
+	GetFloat64(key string) (value float64, present bool, err error)
+	// GetString gets the string value for the given key.
+	//
+	// If the key is set and its value is not a string, an error is returned.
+	GetString(key string) (value string, present bool, err error)
+	// GetStringSlice gets the []string value for the given key.
+	//
+	// Values of type []string are returned as-is, and values of type []any where
+	// every element is a string are converted to a []string. If the key is set and its
+	// value is not a slice of strings, an error is returned.
+	//
+	// The returned value is a copy, and can be modified by the caller.
+	GetStringSlice(key string) (value []string, present bool, err error)
+	// GetStringMap gets the map[string]string value for the given key.
+	//
+	// Values of type map[string]any where every value is a string are converted to a
+	// map[string]string. If the key is set and its value is not a map of strings, an
+	// error is returned.
+	//
+	// The returned value is a copy, and can be modified by the caller.
+	GetStringMap(key string) (value map[string]string, present bool, err error)
+	// GetDuration gets the duration value for the given key.
+	//
+	// The value must be a string accepted by time.ParseDuration, such as "30s". If the
+	// key is set and its value is not a valid duration, an error is returned.
+	GetDuration(key string) (value time.Duration, present bool, err error)
+	// GetTimestamp gets the timestamp value for the given key.
+	//
+	// The value must be an RFC 3339 string, such as "2024-01-02T15:04:05Z". If the
+	// key is set and its value is not a valid timestamp, an error is returned.
+	GetTimestamp(key string) (value time.Time, present bool, err error)
+	// GetByteSize gets the byte size value for the given key.
+	//
+	// The value must be either a string accepted by ParseByteSize, such as "64MiB", or
+	// a non-negative integer number of bytes. If the key is set and its value is not a
+	// valid byte size, an error is returned.
+	GetByteSize(key string) (value ByteSize, present bool, err error)
 	// Range ranges over all key/value pairs.
 	//
-	// The range order is not deterministic.
+	// The range order is not deterministic. Slice and map values are shared with the
+	// Options, and must not be modified by the caller, as with Get.
 	Range(f func(key string, value any))
+	// Clone returns a deep copy of the Options.
+	//
+	// As Options are immutable, this is only needed when Options are handed to code that
+	// compares them by identity.
+	Clone() Options
 
 	// ToProto converts the Options to its Protobuf representation.
+	//
+	// The returned optionv1.Options are sorted by key.
 	ToProto() ([]*optionv1.Option, error)
 
 	isOption()
 }
 
 // NewOptions returns a new validated Options for the given key/value map.
+//
+// Values are validated and converted to their canonical form, which is the form they have
+// after a round trip through the Protobuf representation of the Options. See Options.Get
+// for the supported values.
+//
+// If any values are invalid, the returned error lists every invalid key, sorted by key.
 func NewOptions(keyToValue map[string]any) (Options, error) {
-	if err := validateKeyToValue(keyToValue); err != nil {
-		return nil, err
-	}
-	return newOptionsNoValidate(keyToValue), nil
+	return newOptions(keyToValue, nil)
 }
 
 // OptionsForProtoOptions returns a new Options for the given optionv1.Options.
+//
+// If any values are invalid, the returned error lists every invalid key, sorted by key.
 func OptionsForProtoOptions(protoOptions []*optionv1.Option) (Options, error) {
 	keyToValue := make(map[string]any, len(protoOptions))
+	var invalidOptionErrors []*invalidOptionError
 	for _, protoOption := range protoOptions {
 		value, err := protoValueToValue(protoOption.GetValue())
 		if err != nil {
-			return nil, err
+			invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(protoOption.GetKey(), err.Error()))
+			continue
 		}
 		keyToValue[protoOption.GetKey()] = value
 	}
-	return NewOptions(keyToValue)
+	return newOptions(keyToValue, invalidOptionErrors)
 }
 
-// GetBoolValue gets a bool value from the Options.
+// OptionsForProtoValues returns a new Options for the given map of keys to optionv1.Values.
 //
-// If the value is present and is not of type bool, an error is returned.
-func GetBoolValue(options Options, key string) (bool, error) {
-	anyValue, ok := options.Get(key)
-	if !ok {
-		return false, nil
+// This is useful for integrators that persist or transport options keyed by name rather
+// than as a list of optionv1.Options.
+//
+// If any values are invalid, the returned error lists every invalid key, sorted by key.
+func OptionsForProtoValues(keyToProtoValue map[string]*optionv1.Value) (Options, error) {
+	keyToValue := make(map[string]any, len(keyToProtoValue))
+	var invalidOptionErrors []*invalidOptionError
+	for key, protoValue := range keyToProtoValue {
+		value, err := protoValueToValue(protoValue)
+		if err != nil {
+			invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(key, err.Error()))
+			continue
+		}
+		keyToValue[key] = value
 	}
-	value, ok := anyValue.(bool)
-	if !ok {
-		return false, newUnexpectedOptionValueTypeError(key, false, anyValue)
+	return newOptions(keyToValue, invalidOptionErrors)
+}
+
+// OptionsToProto converts the Options to its Protobuf representation.
+//
+// The returned optionv1.Options are sorted by key. This is equivalent to Options.ToProto.
+func OptionsToProto(options Options) ([]*optionv1.Option, error) {
+	return options.ToProto()
+}
+
+// ValueForProtoValue returns the value for the given optionv1.Value.
+//
+// The value will be one of the types documented on Options.Get.
+func ValueForProtoValue(protoValue *optionv1.Value) (any, error) {
+	value, err := protoValueToValue(protoValue)
+	if err != nil {
+		return nil, err
 	}
-	return value, nil
+	return canonicalizeValue(value)
 }
 
-// GetInt64Value gets a int64 value from the Options.
+// ValueToProtoValue returns the optionv1.Value for the given value.
 //
-// If the value is present and is not of type int64, an error is returned.
-func GetInt64Value(options Options, key string) (int64, error) {
-	anyValue, ok := options.Get(key)
-	if !ok {
-		return 0, nil
+// The value must be valid for NewOptions.
+func ValueToProtoValue(value any) (*optionv1.Value, error) {
+	if err := validateValue(value); err != nil {
+		return nil, err
 	}
-	value, ok := anyValue.(int64)
-	if !ok {
-		return 0, newUnexpectedOptionValueTypeError(key, int64(0), anyValue)
+	return valueToProtoValue(value)
+}
+
+// Merge returns a new Options with the values of base and overrides layered in order,
+// where a later layer takes precedence over an earlier one.
+//
+// The conventional layering is, from lowest to highest precedence:
+//
+//  1. Plugin defaults, as returned by DefaultOptions.
+//  2. Options that apply to the whole plugin.
+//  3. Options that apply to a single rule.
+//
+// For example:
+//
+//	options := option.Merge(option.DefaultOptions(schema), pluginOptions, ruleOptions)
+//
+// A key that is set in a later layer replaces the value from an earlier layer entirely.
+// Values are never merged: slices are replaced rather than concatenated, and maps are
+// replaced rather than merged deeply. Since it is not possible to set a key to a not-present
+// value, a later layer cannot unset a key from an earlier layer.
+//
+// Nil Options are skipped.
+func Merge(base Options, overrides ...Options) Options {
+	keyToValue := make(map[string]any)
+	for _, options := range append([]Options{base}, overrides...) {
+		if options == nil {
+			continue
+		}
+		options.Range(
+			func(key string, value any) {
+				keyToValue[key] = value
+			},
+		)
 	}
-	return value, nil
+	return newOptionsNoValidate(keyToValue)
+}
+
+// GetBoolValue gets a bool value from the Options.
+//
+// This is equivalent to Options.GetBool without the present return value. If the value
+// is not present, the zero value is returned.
+func GetBoolValue(options Options, key string) (bool, error) {
+	value, _, err := options.GetBool(key)
+	return value, err
+}
+
+// GetInt64Value gets a int64 value from the Options.
+//
+// This is equivalent to Options.GetInt64 without the present return value. If the value
+// is not present, the zero value is returned.
+func GetInt64Value(options Options, key string) (int64, error) {
+	value, _, err := options.GetInt64(key)
+	return value, err
 }
 
 // GetFloat64Value gets a float64 value from the Options.
 //
-// If the value is present and is not of type float64, an error is returned.
+// This is equivalent to Options.GetFloat64 without the present return value. If the value
+// is not present, the zero value is returned.
 func GetFloat64Value(options Options, key string) (float64, error) {
-	anyValue, ok := options.Get(key)
-	if !ok {
-		return 0.0, nil
-	}
-	value, ok := anyValue.(float64)
-	if !ok {
-		return 0.0, newUnexpectedOptionValueTypeError(key, float64(0.0), anyValue)
-	}
-	return value, nil
+	value, _, err := options.GetFloat64(key)
+	return value, err
 }
 
 // GetStringValue gets a string value from the Options.
 //
-// If the value is present and is not of type string, an error is returned.
+// This is equivalent to Options.GetString without the present return value. If the value
+// is not present, the zero value is returned.
 func GetStringValue(options Options, key string) (string, error) {
-	anyValue, ok := options.Get(key)
-	if !ok {
-		return "", nil
-	}
-	value, ok := anyValue.(string)
-	if !ok {
-		return "", newUnexpectedOptionValueTypeError(key, "", anyValue)
-	}
-	return value, nil
+	value, _, err := options.GetString(key)
+	return value, err
 }
 
 // GetBytesValue gets a bytes value from the Options.
 //
 // If the value is present and is not of type bytes, an error is returned.
+// The returned value is a copy, and can be modified by the caller.
 func GetBytesValue(options Options, key string) ([]byte, error) {
 	anyValue, ok := options.Get(key)
 	if !ok {
@@ -167,12 +332,13 @@ func GetBytesValue(options Options, key string) ([]byte, error) {
 	if !ok {
 		return nil, newUnexpectedOptionValueTypeError(key, []byte{}, anyValue)
 	}
-	return value, nil
+	return slices.Clone(value), nil
 }
 
 // GetInt64SliceValue gets a []int64 value from the Options.
 //
 // If the value is present and is not of type []int64, an error is returned.
+// The returned value is a copy, and can be modified by the caller.
 func GetInt64SliceValue(options Options, key string) ([]int64, error) {
 	anyValue, ok := options.Get(key)
 	if !ok {
@@ -182,12 +348,13 @@ func GetInt64SliceValue(options Options, key string) ([]int64, error) {
 	if !ok {
 		return nil, newUnexpectedOptionValueTypeError(key, []int64{}, anyValue)
 	}
-	return value, nil
+	return slices.Clone(value), nil
 }
 
 // GetFloat64SliceValue gets a []float64 value from the Options.
 //
 // If the value is present and is not of type []float64, an error is returned.
+// The returned value is a copy, and can be modified by the caller.
 func GetFloat64SliceValue(options Options, key string) ([]float64, error) {
 	anyValue, ok := options.Get(key)
 	if !ok {
@@ -197,22 +364,16 @@ func GetFloat64SliceValue(options Options, key string) ([]float64, error) {
 	if !ok {
 		return nil, newUnexpectedOptionValueTypeError(key, []float64{}, anyValue)
 	}
-	return value, nil
+	return slices.Clone(value), nil
 }
 
 // GetStringSliceValue gets a []string value from the Options.
 //
-// If the value is present and is not of type []string, an error is returned.
+// This is equivalent to Options.GetStringSlice without the present return value. If the value
+// is not present, the zero value is returned.
 func GetStringSliceValue(options Options, key string) ([]string, error) {
-	anyValue, ok := options.Get(key)
-	if !ok {
-		return nil, nil
-	}
-	value, ok := anyValue.([]string)
-	if !ok {
-		return nil, newUnexpectedOptionValueTypeError(key, []string{}, anyValue)
-	}
-	return value, nil
+	value, _, err := options.GetStringSlice(key)
+	return value, err
 }
 
 // *** PRIVATE ***
@@ -232,7 +393,145 @@ func newOptionsNoValidate(keyToValue map[string]any) *options {
 
 func (o *options) Get(key string) (any, bool) {
 	value, ok := o.keyToValue[key]
-	return value, ok
+	if !ok {
+		return nil, false
+	}
+	return value, true
+}
+
+func (o *options) GetBool(key string) (bool, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return false, false, nil
+	}
+	value, ok := anyValue.(bool)
+	if !ok {
+		return false, true, newUnexpectedOptionValueTypeError(key, false, anyValue)
+	}
+	return value, true, nil
+}
+
+func (o *options) GetInt64(key string) (int64, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return 0, false, nil
+	}
+	switch reflectValue := reflect.ValueOf(anyValue); reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectValue.Int(), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value := reflectValue.Uint()
+		if value > math.MaxInt64 {
+			return 0, true, fmt.Errorf("option value %q: %d overflows int64", key, value)
+		}
+		return int64(value), true, nil
+	default:
+		return 0, true, newUnexpectedOptionValueTypeError(key, int64(0), anyValue)
+	}
+}
+
+func (o *options) GetFloat64(key string) (float64, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return 0.0, false, nil
+	}
+	switch reflectValue := reflect.ValueOf(anyValue); reflectValue.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float(), true, nil
+	default:
+		return 0.0, true, newUnexpectedOptionValueTypeError(key, float64(0.0), anyValue)
+	}
+}
+
+func (o *options) GetString(key string) (string, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return "", false, nil
+	}
+	value, ok := anyValue.(string)
+	if !ok {
+		return "", true, newUnexpectedOptionValueTypeError(key, "", anyValue)
+	}
+	return value, true, nil
+}
+
+func (o *options) GetStringSlice(key string) ([]string, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	switch value := anyValue.(type) {
+	case []string:
+		return slices.Clone(value), true, nil
+	case []any:
+		stringSlice := make([]string, len(value))
+		for i, subValue := range value {
+			stringValue, ok := subValue.(string)
+			if !ok {
+				return nil, true, newUnexpectedOptionValueTypeError(key, []string{}, anyValue)
+			}
+			stringSlice[i] = stringValue
+		}
+		return stringSlice, true, nil
+	default:
+		return nil, true, newUnexpectedOptionValueTypeError(key, []string{}, anyValue)
+	}
+}
+
+func (o *options) GetStringMap(key string) (map[string]string, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	value, ok := anyValue.(map[string]any)
+	if !ok {
+		return nil, true, newUnexpectedOptionValueTypeError(key, map[string]string{}, anyValue)
+	}
+	stringMap := make(map[string]string, len(value))
+	for subKey, subValue := range value {
+		stringValue, ok := subValue.(string)
+		if !ok {
+			return nil, true, newUnexpectedOptionValueTypeError(key, map[string]string{}, anyValue)
+		}
+		stringMap[subKey] = stringValue
+	}
+	return stringMap, true, nil
+}
+
+func (o *options) GetDuration(key string) (time.Duration, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return 0, false, nil
+	}
+	value, err := parseDurationValue(anyValue)
+	if err != nil {
+		return 0, true, newInvalidOptionError(key, err.Error())
+	}
+	return value, true, nil
+}
+
+func (o *options) GetTimestamp(key string) (time.Time, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	value, err := parseTimestampValue(anyValue)
+	if err != nil {
+		return time.Time{}, true, newInvalidOptionError(key, err.Error())
+	}
+	return value, true, nil
+}
+
+func (o *options) GetByteSize(key string) (ByteSize, bool, error) {
+	anyValue, ok := o.Get(key)
+	if !ok {
+		return 0, false, nil
+	}
+	value, err := parseByteSizeValue(anyValue)
+	if err != nil {
+		return 0, true, newInvalidOptionError(key, err.Error())
+	}
+	return value, true, nil
 }
 
 func (o *options) Range(f func(key string, value any)) {
@@ -241,12 +540,26 @@ func (o *options) Range(f func(key string, value any)) {
 	}
 }
 
+func (o *options) Clone() Options {
+	keyToValue := make(map[string]any, len(o.keyToValue))
+	for key, value := range o.keyToValue {
+		keyToValue[key] = cloneValue(value)
+	}
+	return newOptionsNoValidate(keyToValue)
+}
+
 func (o *options) ToProto() ([]*optionv1.Option, error) {
 	if o == nil {
 		return nil, nil
 	}
+	keys := make([]string, 0, len(o.keyToValue))
+	for key := range o.keyToValue {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 	protoOptions := make([]*optionv1.Option, 0, len(o.keyToValue))
-	for key, value := range o.keyToValue {
+	for _, key := range keys {
+		value := o.keyToValue[key]
 		protoValue, err := valueToProtoValue(value)
 		if err != nil {
 			return nil, err
@@ -274,12 +587,22 @@ func valueToProtoValue(value any) (*optionv1.Value, error) {
 				BoolValue: reflectValue.Bool(),
 			},
 		}, nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return &optionv1.Value{
 			Type: &optionv1.Value_Int64Value{
 				Int64Value: reflectValue.Int(),
 			},
 		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		t := reflectValue.Uint()
+		if t > math.MaxInt64 {
+			return nil, fmt.Errorf("invalid Options value %d: overflows int64", t)
+		}
+		return &optionv1.Value{
+			Type: &optionv1.Value_Int64Value{
+				Int64Value: int64(t),
+			},
+		}, nil
 	case reflect.Float32, reflect.Float64:
 		return &optionv1.Value{
 			Type: &optionv1.Value_DoubleValue{
@@ -315,7 +638,9 @@ func valueToProtoValue(value any) (*optionv1.Value, error) {
 				},
 			},
 		}, nil
-	case reflect.Invalid, reflect.Uintptr, reflect.Complex64, reflect.Complex128, reflect.Array, reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer | reflect.Ptr, reflect.Struct, reflect.UnsafePointer:
+	case reflect.Map:
+		return nil, errors.New("map values cannot be represented as an optionv1.Value")
+	case reflect.Invalid, reflect.Uintptr, reflect.Complex64, reflect.Complex128, reflect.Array, reflect.Chan, reflect.Func, reflect.Interface, reflect.Pointer | reflect.Ptr, reflect.Struct, reflect.UnsafePointer:
 		return nil, fmt.Errorf("invalid type for Options value %T", value)
 	default:
 		return nil, fmt.Errorf("invalid type for Options value %T", value)
@@ -352,85 +677,201 @@ func protoValueToValue(protoValue *optionv1.Value) (any, error) {
 			anySlice[i] = subValue
 		}
 		// We know this is of at least length 1
-		anySliceFirstType := reflect.TypeOf(anySlice[0])
-		for i := 1; i < len(anySlice); i++ {
-			anySliceSubType := reflect.TypeOf(anySlice[i])
-			if anySliceFirstType != anySliceSubType {
-				return nil, fmt.Errorf("invalid optionv1.Value: list_values must have values of the same type but detected types %v and %v", anySliceFirstType, anySliceSubType)
-			}
-		}
-		reflectSlice := reflect.MakeSlice(reflect.SliceOf(anySliceFirstType), 0, len(anySlice))
-		for _, anySliceSubValue := range anySlice {
-			reflectSlice = reflect.Append(reflectSlice, reflect.ValueOf(anySliceSubValue))
-		}
-		return reflectSlice.Interface(), nil
+		return sliceForValues(anySlice), nil
 	default:
 		return nil, errors.New("invalid optionv1.Value: no value of oneof is set")
 	}
 }
 
-func validateKeyToValue(keyToValue map[string]any) error {
+// newOptions canonicalizes the keyToValue map and returns a new Options.
+//
+// The given invalidOptionErrors are errors already encountered by the caller, and are
+// reported together with any errors encountered while canonicalizing.
+func newOptions(keyToValue map[string]any, invalidOptionErrors []*invalidOptionError) (Options, error) {
+	canonicalKeyToValue, canonicalizeInvalidOptionErrors := canonicalizeKeyToValue(keyToValue)
+	invalidOptionErrors = append(invalidOptionErrors, canonicalizeInvalidOptionErrors...)
+	if len(invalidOptionErrors) > 0 {
+		return nil, newInvalidOptionsError(invalidOptionErrors)
+	}
+	return newOptionsNoValidate(canonicalKeyToValue), nil
+}
+
+// canonicalizeKeyToValue validates the key/value map and returns a new map with every
+// value converted to its canonical form.
+//
+// Every invalid key and value is reported, rather than stopping at the first.
+func canonicalizeKeyToValue(keyToValue map[string]any) (map[string]any, []*invalidOptionError) {
+	canonicalKeyToValue := make(map[string]any, len(keyToValue))
+	var invalidOptionErrors []*invalidOptionError
 	for key, value := range keyToValue {
 		// This should all be validated via protovalidate, and the below doesn't
 		// even encapsulate all the validation.
 		if len(key) == 0 {
-			return errors.New("invalid option key: key cannot be empty")
+			invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(key, "invalid option key: key cannot be empty"))
+			continue
+		}
+		canonicalValue, err := canonicalizeKeyValue(key, value)
+		if err != nil {
+			invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(key, err.Error()))
+			continue
+		}
+		// Copy so that the caller cannot modify the Options through the values they passed.
+		canonicalKeyToValue[key] = cloneValue(canonicalValue)
+	}
+	return canonicalKeyToValue, invalidOptionErrors
+}
+
+// canonicalizeKeyValue is canonicalizeValue for the value of the given key.
+//
+// The value may be a map if the key has ReservedKeyPrefix, see Options.Get.
+func canonicalizeKeyValue(key string, value any) (any, error) {
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() != reflect.Map {
+		return canonicalizeValue(value)
+	}
+	if !strings.HasPrefix(key, ReservedKeyPrefix) {
+		return nil, fmt.Errorf("invalid option value: map values are only supported for keys with the prefix %q", ReservedKeyPrefix)
+	}
+	if reflectValue.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("invalid option value: map keys must be strings but got %T", value)
+	}
+	if reflectValue.Len() == 0 {
+		return nil, errors.New("invalid option value: map must be non-empty")
+	}
+	canonicalKeyToSubValue := make(map[string]any, reflectValue.Len())
+	mapIter := reflectValue.MapRange()
+	for mapIter.Next() {
+		subKey := mapIter.Key().String()
+		if subKey == "" {
+			return nil, errors.New("invalid option value: map keys must be non-empty")
+		}
+		canonicalSubValue, err := canonicalizeValue(mapIter.Value().Interface())
+		if err != nil {
+			return nil, err
 		}
-		if err := validateValue(value); err != nil {
-			return err
+		canonicalKeyToSubValue[subKey] = canonicalSubValue
+	}
+	return canonicalKeyToSubValue, nil
+}
+
+// cloneValue returns a deep copy of the value if it is a slice or a map, and the value
+// otherwise.
+//
+// Values other than slices and maps are immutable.
+func cloneValue(value any) any {
+	if mapValue, ok := value.(map[string]any); ok {
+		cloneMapValue := make(map[string]any, len(mapValue))
+		for key, subValue := range mapValue {
+			cloneMapValue[key] = cloneValue(subValue)
 		}
+		return cloneMapValue
+	}
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() != reflect.Slice {
+		return value
 	}
-	return nil
+	cloneReflectValue := reflect.MakeSlice(reflectValue.Type(), reflectValue.Len(), reflectValue.Len())
+	for i := range reflectValue.Len() {
+		// The elements of a []any are interfaces, so check the kind of the element itself.
+		if subValue := reflectValue.Index(i).Interface(); reflect.ValueOf(subValue).Kind() == reflect.Slice {
+			cloneReflectValue.Index(i).Set(reflect.ValueOf(cloneValue(subValue)))
+			continue
+		}
+		cloneReflectValue.Index(i).Set(reflectValue.Index(i))
+	}
+	return cloneReflectValue.Interface()
 }
 
 func validateValue(value any) error {
+	_, err := canonicalizeValue(value)
+	return err
+}
+
+// canonicalizeValue validates the value and converts it to the form it has after a round
+// trip through its Protobuf representation.
+//
+// Integers are converted to int64, floats to float64, and slices to a slice of the
+// canonical element type, recursively. For example, []any{[]any{int32(1)}} is
+// converted to [][]int64{{1}}. Slices with elements of different canonical types are
+// converted to []any, for example []any{int32(1), []any{"foo"}} is converted to
+// []any{int64(1), []string{"foo"}}. Maps are not supported, see canonicalizeKeyValue.
+func canonicalizeValue(value any) (any, error) {
 	if value == nil {
-		return errors.New("invalid option value: value cannot be nil")
+		return nil, errors.New("invalid option value: value cannot be nil")
 	}
 	switch reflectValue := reflect.ValueOf(value); reflectValue.Kind() {
 	case reflect.Bool:
 		t := reflectValue.Bool()
 		if !t {
-			return errors.New("invalid option value: bool must be true")
+			return nil, errors.New("invalid option value: bool must be true")
 		}
-		return nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return t, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		t := reflectValue.Int()
 		if t == 0 {
-			return errors.New("invalid option value: int must be non-zero")
+			return nil, errors.New("invalid option value: int must be non-zero")
 		}
-		return nil
+		return t, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		t := reflectValue.Uint()
+		if t == 0 {
+			return nil, errors.New("invalid option value: int must be non-zero")
+		}
+		if t > math.MaxInt64 {
+			return nil, fmt.Errorf("invalid option value: %d overflows int64", t)
+		}
+		return int64(t), nil
 	case reflect.Float32, reflect.Float64:
 		t := reflectValue.Float()
 		if t == 0 {
-			return errors.New("invalid option value: float must be non-zero")
+			return nil, errors.New("invalid option value: float must be non-zero")
 		}
-		return nil
+		return t, nil
 	case reflect.String:
 		t := reflectValue.String()
 		if t == "" {
-			return errors.New("invalid option value: string must be non-empty")
+			return nil, errors.New("invalid option value: string must be non-empty")
 		}
-		return nil
+		return t, nil
 	case reflect.Slice:
 		vLen := reflectValue.Len()
 		if vLen == 0 {
-			return errors.New("invalid option value: slice must be non-empty")
+			return nil, errors.New("invalid option value: slice must be non-empty")
+		}
+		if t, ok := value.([]byte); ok {
+			return t, nil
 		}
-		firstValue := reflectValue.Index(0).Interface()
-		firstValueType := reflect.TypeOf(firstValue)
-		for i := 1; i < vLen; i++ {
-			subValue := reflectValue.Index(i).Interface()
-			subValueType := reflect.TypeOf(subValue)
-			// reflect.Types are comparable with == per documentation.
-			if firstValueType != subValueType {
-				return fmt.Errorf("invalid option value: slice must have values of the same type but detected types %v and %v", firstValueType, subValueType)
+		canonicalSubValues := make([]any, vLen)
+		for i := range vLen {
+			canonicalSubValue, err := canonicalizeValue(reflectValue.Index(i).Interface())
+			if err != nil {
+				return nil, err
 			}
+			canonicalSubValues[i] = canonicalSubValue
 		}
-		return nil
+		return sliceForValues(canonicalSubValues), nil
 	case reflect.Invalid, reflect.Uintptr, reflect.Complex64, reflect.Complex128, reflect.Array, reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer | reflect.Ptr, reflect.Struct, reflect.UnsafePointer:
-		return fmt.Errorf("invalid option value: unhandled type %T", value)
+		return nil, fmt.Errorf("invalid option value: unhandled type %T", value)
 	default:
-		return fmt.Errorf("invalid option value: unhandled type %T", value)
+		return nil, fmt.Errorf("invalid option value: unhandled type %T", value)
+	}
+}
+
+// sliceForValues returns a slice of the type of the values if all values have the same
+// type, and the values as a []any otherwise.
+//
+// The values must be non-empty.
+func sliceForValues(values []any) any {
+	firstValueType := reflect.TypeOf(values[0])
+	for _, value := range values[1:] {
+		// reflect.Types are comparable with == per documentation.
+		if reflect.TypeOf(value) != firstValueType {
+			return values
+		}
+	}
+	reflectSlice := reflect.MakeSlice(reflect.SliceOf(firstValueType), len(values), len(values))
+	for i, value := range values {
+		reflectSlice.Index(i).Set(reflect.ValueOf(value))
 	}
+	return reflectSlice.Interface()
 }
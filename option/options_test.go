@@ -24,8 +24,10 @@
 package option
 
 import (
+	"math"
 	"testing"
 
+	optionv1 "buf.build/gen/go/bufbuild/bufplugin/protocolbuffers/go/buf/plugin/option/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -55,6 +57,133 @@ func TestOptionsRoundTrip(t *testing.T) {
 	)
 }
 
+func TestOptionsNestedRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	testOptionsNestedRoundTrip(t, [][][]string{{{"foo", "bar"}, {"baz"}}, {{"bat"}}}, [][][]string{{{"foo", "bar"}, {"baz"}}, {{"bat"}}})
+	testOptionsNestedRoundTrip(t, [][]bool{{true}, {true, true}}, [][]bool{{true}, {true, true}})
+	testOptionsNestedRoundTrip(t, [][]byte{[]byte("foo"), []byte("bar")}, [][]byte{[]byte("foo"), []byte("bar")})
+	testOptionsNestedRoundTrip(t, [][][]byte{{[]byte("foo")}}, [][][]byte{{[]byte("foo")}})
+	testOptionsNestedRoundTrip(t, []any{[]any{int32(1), int8(2)}, []any{uint16(3)}}, [][]int64{{1, 2}, {3}})
+	testOptionsNestedRoundTrip(t, []any{[]float32{1.5}, []any{2.5}}, [][]float64{{1.5}, {2.5}})
+	testOptionsNestedRoundTrip(t, []any{[]any{[]any{"foo"}}, [][]string{{"bar"}}}, [][][]string{{{"foo"}}, {{"bar"}}})
+	testOptionsNestedRoundTrip(t, []any{"foo", int32(1)}, []any{"foo", int64(1)})
+	testOptionsNestedRoundTrip(t, []any{[]string{"foo"}, []int32{1}}, []any{[]string{"foo"}, []int64{1}})
+	testOptionsNestedRoundTrip(t, []any{[]any{"foo"}, []any{int64(1)}}, []any{[]string{"foo"}, []int64{1}})
+	testOptionsNestedRoundTrip(t, []any{[]any{"foo", true}, []any{"bar", true}}, [][]any{{"foo", true}, {"bar", true}})
+	testOptionsNestedRoundTrip(t, []any{int8(1), []any{"foo", []float32{1.5}}}, []any{int64(1), []any{"foo", []float64{1.5}}})
+	testOptionsNestedRoundTrip(t, int32(5), int64(5))
+	testOptionsNestedRoundTrip(t, float32(1.5), float64(1.5))
+}
+
+func TestOptionsNestedUnsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []any{
+		[][]string{{"foo"}, {}},
+		[]string{"foo", ""},
+		[]bool{true, false},
+		[]any{[]any{nil}},
+		[]any{"foo", []any{int64(1), false}},
+		map[string]any{},
+		map[string]any{"": "bar"},
+		map[string]any{"foo": ""},
+		map[int]string{1: "foo"},
+		struct{}{},
+	} {
+		_, err := NewOptions(map[string]any{"foo": value})
+		assert.Error(t, err, "%v", value)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	defaultOptions := DefaultOptions(
+		&Schema{
+			Keys: []*KeySpec{
+				{Key: "suffix", Type: TypeString, Default: "API"},
+				{Key: "excludes", Type: TypeStringSlice, Default: []string{"foo"}},
+				{Key: "max_length", Type: TypeInt64, Default: 10},
+			},
+		},
+	)
+	pluginOptions, err := NewOptions(
+		map[string]any{
+			"suffix":   "Service",
+			"excludes": []string{"bar", "baz"},
+		},
+	)
+	require.NoError(t, err)
+	ruleOptions, err := NewOptions(
+		map[string]any{
+			"suffix": "Rule",
+		},
+	)
+	require.NoError(t, err)
+
+	options := Merge(defaultOptions, pluginOptions, nil, ruleOptions)
+	testOptionsEqual(
+		t,
+		map[string]any{
+			"suffix":     "Rule",
+			"excludes":   []string{"bar", "baz"},
+			"max_length": int64(10),
+		},
+		options,
+	)
+	// Layers are not modified.
+	testOptionsEqual(
+		t,
+		map[string]any{
+			"suffix":   "Service",
+			"excludes": []string{"bar", "baz"},
+		},
+		pluginOptions,
+	)
+	testOptionsEqual(t, map[string]any{}, Merge(nil))
+}
+
+func TestOptionsProtoConversion(t *testing.T) {
+	t.Parallel()
+
+	options, err := NewOptions(
+		map[string]any{
+			"foo": "bar",
+			"baz": []int32{1, 2},
+			"bat": true,
+		},
+	)
+	require.NoError(t, err)
+	protoOptions, err := OptionsToProto(options)
+	require.NoError(t, err)
+	require.Len(t, protoOptions, 3)
+	assert.Equal(t, "bat", protoOptions[0].GetKey())
+	assert.Equal(t, "baz", protoOptions[1].GetKey())
+	assert.Equal(t, "foo", protoOptions[2].GetKey())
+
+	keyToProtoValue := make(map[string]*optionv1.Value)
+	for _, protoOption := range protoOptions {
+		keyToProtoValue[protoOption.GetKey()] = protoOption.GetValue()
+	}
+	roundTripOptions, err := OptionsForProtoValues(keyToProtoValue)
+	require.NoError(t, err)
+	assert.Equal(t, options, roundTripOptions)
+
+	protoValue, err := ValueToProtoValue([]any{int8(1)})
+	require.NoError(t, err)
+	value, err := ValueForProtoValue(protoValue)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, value)
+	protoValue, err = ValueToProtoValue([]any{"foo", 1})
+	require.NoError(t, err)
+	value, err = ValueForProtoValue(protoValue)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"foo", int64(1)}, value)
+	_, err = ValueForProtoValue(&optionv1.Value{})
+	assert.Error(t, err)
+}
+
 func TestOptionsValidateValueError(t *testing.T) {
 	t.Parallel()
 
@@ -62,12 +191,166 @@ func TestOptionsValidateValueError(t *testing.T) {
 	assert.Error(t, err)
 	err = validateValue(0)
 	assert.Error(t, err)
-	err = validateValue([]any{1, "foo"})
+	err = validateValue([]any{1, ""})
+	assert.Error(t, err)
+	err = validateValue([]any{[]string{"foo"}, struct{}{}})
+	assert.Error(t, err)
+}
+
+func TestOptionsMapValues(t *testing.T) {
+	t.Parallel()
+
+	stringMap := map[string]string{"foo": "bar"}
+	options, err := NewOptions(
+		map[string]any{
+			"bufplugin_map":   stringMap,
+			"bufplugin_slice": map[string]any{"foo": []int32{1}},
+		},
+	)
+	require.NoError(t, err)
+	stringMap["foo"] = "baz"
+
+	value, ok := options.Get("bufplugin_map")
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"foo": "bar"}, value)
+	value, ok = options.Get("bufplugin_slice")
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"foo": []int64{1}}, value)
+	getStringMap, ok, err := options.GetStringMap("bufplugin_map")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"foo": "bar"}, getStringMap)
+	getStringMap["foo"] = "baz"
+	getStringMap, _, err = options.GetStringMap("bufplugin_map")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"foo": "bar"}, getStringMap)
+	_, _, err = options.GetStringMap("bufplugin_slice")
 	assert.Error(t, err)
-	err = validateValue([]any{[]string{"foo"}, "foo"})
+
+	clone := options.Clone()
+	assert.True(t, Equal(options, clone))
+	value, _ = clone.Get("bufplugin_map")
+	value.(map[string]any)["foo"] = "baz"
+	value, _ = options.Get("bufplugin_map")
+	assert.Equal(t, map[string]any{"foo": "bar"}, value)
+
+	// Maps are only supported as the value of keys with ReservedKeyPrefix.
+	_, err = NewOptions(map[string]any{"map": stringMap})
+	assert.ErrorContains(t, err, "map values are only supported for keys with the prefix")
+	// Maps cannot be nested, for example maps of lists of maps.
+	for _, value := range []any{
+		[]any{map[string]any{"foo": "bar"}},
+		map[string]any{"foo": map[string]any{"bar": "baz"}},
+		map[string]any{"foo": []any{map[string]any{"bar": "baz"}}},
+	} {
+		_, err = NewOptions(map[string]any{"bufplugin_map": value})
+		assert.Error(t, err, "%v", value)
+	}
+
+	// Maps have no Protobuf representation.
+	_, err = options.ToProto()
 	assert.Error(t, err)
 }
 
+func TestOptionsImmutable(t *testing.T) {
+	t.Parallel()
+
+	stringSlice := []string{"foo", "bar"}
+	int64Slice := []int64{1, 2}
+	nestedSlice := [][]int64{{1, 2}}
+	options, err := NewOptions(
+		map[string]any{
+			"strings": stringSlice,
+			"int64s":  int64Slice,
+			"nested":  nestedSlice,
+		},
+	)
+	require.NoError(t, err)
+	stringSlice[0] = "baz"
+	int64Slice[0] = 3
+	nestedSlice[0][0] = 3
+
+	value, err := GetStringSliceValue(options, "strings")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, value)
+	value[0] = "baz"
+	int64Value, err := GetInt64SliceValue(options, "int64s")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, int64Value)
+	int64Value[0] = 3
+	anyValue, ok := options.Get("nested")
+	require.True(t, ok)
+	assert.Equal(t, [][]int64{{1, 2}}, anyValue)
+
+	value, err = GetStringSliceValue(options, "strings")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, value)
+	int64Value, err = GetInt64SliceValue(options, "int64s")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, int64Value)
+
+	clone := options.Clone()
+	assert.True(t, Equal(options, clone))
+	value, err = GetStringSliceValue(clone, "strings")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, value)
+	anyValue, ok = clone.Get("nested")
+	require.True(t, ok)
+	assert.Equal(t, [][]int64{{1, 2}}, anyValue)
+	anyValue.([][]int64)[0][0] = 3
+	anyValue, ok = options.Get("nested")
+	require.True(t, ok)
+	assert.Equal(t, [][]int64{{1, 2}}, anyValue)
+}
+
+func TestOptionsAggregatedErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewOptions(
+		map[string]any{
+			"foo": false,
+			"bar": "valid",
+			"baz": []any{1, ""},
+			"bat": 0,
+		},
+	)
+	require.Error(t, err)
+	assert.Equal(
+		t,
+		`invalid options: option "bat": invalid option value: int must be non-zero; `+
+			`option "baz": invalid option value: string must be non-empty; `+
+			`option "foo": invalid option value: bool must be true`,
+		err.Error(),
+	)
+
+	_, err = OptionsForProtoOptions(
+		[]*optionv1.Option{
+			{
+				Key:   "foo",
+				Value: &optionv1.Value{},
+			},
+			{
+				Key: "bar",
+				Value: &optionv1.Value{
+					Type: &optionv1.Value_StringValue{
+						StringValue: "valid",
+					},
+				},
+			},
+			{
+				Key: "baz",
+			},
+		},
+	)
+	require.Error(t, err)
+	assert.Equal(
+		t,
+		`invalid options: option "baz": invalid optionv1.Value: value cannot be nil; `+
+			`option "foo": invalid optionv1.Value: no value of oneof is set`,
+		err.Error(),
+	)
+}
+
 func testOptionsRoundTrip(t *testing.T, value any) {
 	protoValue, err := valueToProtoValue(value)
 	require.NoError(t, err)
@@ -83,3 +366,89 @@ func testOptionsRoundTripDifferentInputOutput(t *testing.T, input any, expectedO
 	require.NoError(t, err)
 	assert.Equal(t, expectedOutput, actualValue)
 }
+
+func TestOptionsTypedGetters(t *testing.T) {
+	t.Parallel()
+
+	options, err := NewOptions(
+		map[string]any{
+			"bool_key":         true,
+			"int_key":          5,
+			"int64_key":        int64(6),
+			"uint_key":         uint32(7),
+			"float_key":        1.5,
+			"string_key":       "foo",
+			"string_slice_key": []string{"foo", "bar"},
+			"any_slice_key":    []any{"foo", "bar"},
+			"int_slice_key":    []int64{1, 2},
+		},
+	)
+	require.NoError(t, err)
+
+	boolValue, ok, err := options.GetBool("bool_key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, boolValue)
+	_, _, err = options.GetBool("string_key")
+	assert.EqualError(t, err, `unexpected type for option value "string_key": expected bool, got string`)
+
+	int64Value, ok, err := options.GetInt64("int_key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), int64Value)
+	int64Value, _, err = options.GetInt64("int64_key")
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), int64Value)
+	int64Value, _, err = options.GetInt64("uint_key")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), int64Value)
+	_, _, err = options.GetInt64("float_key")
+	assert.EqualError(t, err, `unexpected type for option value "float_key": expected int64, got float64`)
+
+	float64Value, ok, err := options.GetFloat64("float_key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, float64Value)
+	_, _, err = options.GetFloat64("int_key")
+	assert.Error(t, err)
+
+	stringValue, ok, err := options.GetString("string_key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "foo", stringValue)
+	_, _, err = options.GetString("bool_key")
+	assert.Error(t, err)
+
+	stringSliceValue, ok, err := options.GetStringSlice("string_slice_key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"foo", "bar"}, stringSliceValue)
+	stringSliceValue, _, err = options.GetStringSlice("any_slice_key")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, stringSliceValue)
+	_, _, err = options.GetStringSlice("int_slice_key")
+	assert.EqualError(t, err, `unexpected type for option value "int_slice_key": expected []string, got []int64`)
+
+	stringValue, ok, err = options.GetString("missing_key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Empty(t, stringValue)
+
+	_, err = NewOptions(map[string]any{"uint_key": uint64(math.MaxUint64)})
+	assert.Error(t, err)
+}
+
+func testOptionsNestedRoundTrip(t *testing.T, input any, expectedOutput any) {
+	options, err := NewOptions(map[string]any{"foo": input})
+	require.NoError(t, err)
+	value, ok := options.Get("foo")
+	require.True(t, ok)
+	assert.Equal(t, expectedOutput, value)
+	protoOptions, err := options.ToProto()
+	require.NoError(t, err)
+	roundTripOptions, err := OptionsForProtoOptions(protoOptions)
+	require.NoError(t, err)
+	roundTripValue, ok := roundTripOptions.Get("foo")
+	require.True(t, ok)
+	assert.Equal(t, expectedOutput, roundTripValue)
+}
@@ -0,0 +1,141 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	byteSizeUnitToMultiplier = map[string]int64{
+		"":    1,
+		"b":   1,
+		"kb":  1000,
+		"mb":  1000 * 1000,
+		"gb":  1000 * 1000 * 1000,
+		"tb":  1000 * 1000 * 1000 * 1000,
+		"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+		"kib": 1 << 10,
+		"mib": 1 << 20,
+		"gib": 1 << 30,
+		"tib": 1 << 40,
+		"pib": 1 << 50,
+	}
+	byteSizeStringUnits = []struct {
+		unit       string
+		multiplier int64
+	}{
+		{"PiB", 1 << 50},
+		{"TiB", 1 << 40},
+		{"GiB", 1 << 30},
+		{"MiB", 1 << 20},
+		{"KiB", 1 << 10},
+	}
+)
+
+// ByteSize is a number of bytes.
+type ByteSize int64
+
+// String implements fmt.Stringer.
+//
+// The result uses the largest binary unit that represents the size exactly, for
+// example "64MiB" or "1000B", and can be parsed by ParseByteSize.
+func (b ByteSize) String() string {
+	for _, byteSizeStringUnit := range byteSizeStringUnits {
+		if b != 0 && int64(b)%byteSizeStringUnit.multiplier == 0 {
+			return strconv.FormatInt(int64(b)/byteSizeStringUnit.multiplier, 10) + byteSizeStringUnit.unit
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// ParseByteSize parses a byte size such as "64MiB".
+//
+// A byte size is a non-negative integer optionally followed by a unit. Units are
+// case-insensitive and are one of B, KB, MB, GB, TB, and PB for powers of 1000, or KiB,
+// MiB, GiB, TiB, and PiB for powers of 1024. A size with no unit is a number of bytes.
+func ParseByteSize(s string) (ByteSize, error) {
+	trimmed := strings.TrimSpace(s)
+	numberEnd := strings.IndexFunc(trimmed, func(r rune) bool { return r < '0' || r > '9' })
+	if numberEnd == -1 {
+		numberEnd = len(trimmed)
+	}
+	if numberEnd == 0 {
+		return 0, fmt.Errorf("invalid byte size %q: must start with a non-negative integer", s)
+	}
+	number, err := strconv.ParseInt(trimmed[:numberEnd], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q: %w", s, err)
+	}
+	unit := strings.ToLower(strings.TrimSpace(trimmed[numberEnd:]))
+	multiplier, ok := byteSizeUnitToMultiplier[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, trimmed[numberEnd:])
+	}
+	if number > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("invalid byte size %q: overflows int64", s)
+	}
+	return ByteSize(number * multiplier), nil
+}
+
+// *** PRIVATE ***
+
+// parseDurationValue parses a duration option value, which must be a string accepted
+// by time.ParseDuration.
+func parseDurationValue(value any) (time.Duration, error) {
+	stringValue, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("expected duration string such as \"30s\", got %T", value)
+	}
+	duration, err := time.ParseDuration(stringValue)
+	if err != nil {
+		return 0, err
+	}
+	return duration, nil
+}
+
+// parseTimestampValue parses a timestamp option value, which must be an RFC 3339 string.
+func parseTimestampValue(value any) (time.Time, error) {
+	stringValue, ok := value.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 timestamp string, got %T", value)
+	}
+	timestamp, err := time.Parse(time.RFC3339, stringValue)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid RFC 3339 timestamp %q", stringValue)
+	}
+	return timestamp, nil
+}
+
+// parseByteSizeValue parses a byte size option value, which must either be a string
+// accepted by ParseByteSize or a non-negative integer number of bytes.
+func parseByteSizeValue(value any) (ByteSize, error) {
+	switch normalizedValue := normalizeScalarValue(value).(type) {
+	case string:
+		return ParseByteSize(normalizedValue)
+	case int64:
+		if normalizedValue < 0 {
+			return 0, errors.New("byte size must be non-negative")
+		}
+		return ByteSize(normalizedValue), nil
+	default:
+		return 0, fmt.Errorf("expected byte size string such as \"64MiB\" or integer, got %T", value)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	testParseByteSize(t, "0", 0)
+	testParseByteSize(t, "1024", 1024)
+	testParseByteSize(t, "10B", 10)
+	testParseByteSize(t, "64MiB", 64<<20)
+	testParseByteSize(t, "64 mib", 64<<20)
+	testParseByteSize(t, "2KB", 2000)
+	testParseByteSize(t, "1GiB", 1<<30)
+	for _, s := range []string{"", "MiB", "-1MiB", "1.5MiB", "1XB", "9999999PiB"} {
+		_, err := ParseByteSize(s)
+		assert.Error(t, err, s)
+	}
+	assert.Equal(t, "64MiB", ByteSize(64<<20).String())
+	assert.Equal(t, "1000B", ByteSize(1000).String())
+	assert.Equal(t, "0B", ByteSize(0).String())
+}
+
+func TestOptionsParsedTypes(t *testing.T) {
+	t.Parallel()
+
+	options, err := NewOptions(
+		map[string]any{
+			"timeout":       "30s",
+			"since":         "2024-01-02T15:04:05Z",
+			"max_size":      "64MiB",
+			"max_size_int":  1024,
+			"bad_timeout":   "thirty",
+			"bad_timestamp": "2024-01-02",
+		},
+	)
+	require.NoError(t, err)
+
+	duration, ok, err := options.GetDuration("timeout")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, duration)
+	timestamp, ok, err := options.GetTimestamp("since")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), timestamp)
+	byteSize, ok, err := options.GetByteSize("max_size")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ByteSize(64<<20), byteSize)
+	byteSize, _, err = options.GetByteSize("max_size_int")
+	require.NoError(t, err)
+	assert.Equal(t, ByteSize(1024), byteSize)
+	_, _, err = options.GetDuration("bad_timeout")
+	assert.Error(t, err)
+	_, _, err = options.GetTimestamp("bad_timestamp")
+	assert.Error(t, err)
+	_, ok, err = options.GetDuration("missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	schema := &Schema{
+		Keys: []*KeySpec{
+			{Key: "timeout", Type: TypeDuration, Default: "1m"},
+			{Key: "since", Type: TypeTimestamp},
+			{Key: "max_size", Type: TypeByteSize},
+			{Key: "bad_timeout", Type: TypeDuration},
+			{Key: "bad_timestamp", Type: TypeTimestamp},
+		},
+		AllowUnknownKeys: true,
+	}
+	require.NoError(t, ValidateSchema(schema))
+	err = ValidateOptions(schema, options)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `option "bad_timeout": time: invalid duration "thirty"`)
+	assert.Contains(t, err.Error(), `option "bad_timestamp": invalid RFC 3339 timestamp "2024-01-02"`)
+	assert.NotContains(t, err.Error(), `option "timeout"`)
+	assert.Error(t, ValidateSchema(&Schema{Keys: []*KeySpec{{Key: "timeout", Type: TypeDuration, Default: "thirty"}}}))
+
+	type config struct {
+		Timeout time.Duration `option:"timeout"`
+		Since   *time.Time    `option:"since"`
+		MaxSize ByteSize      `option:"max_size"`
+	}
+	var cfg config
+	require.NoError(t, Unmarshal(options, &cfg))
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+	require.NotNil(t, cfg.Since)
+	assert.Equal(t, timestamp, *cfg.Since)
+	assert.Equal(t, ByteSize(64<<20), cfg.MaxSize)
+}
+
+func testParseByteSize(t *testing.T, s string, expected ByteSize) {
+	actual, err := ParseByteSize(s)
+	require.NoError(t, err, s)
+	assert.Equal(t, expected, actual, s)
+}
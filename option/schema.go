@@ -0,0 +1,546 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"buf.build/go/bufplugin/internal/pkg/xslices"
+)
+
+const (
+	// RedactedValue is the value that Redact replaces the values of secret options with.
+	RedactedValue = "REDACTED"
+
+	keyMinLen = 3
+)
+
+var keyRegexp = regexp.MustCompile("^[a-z][a-z_]*[a-z]$")
+
+// Schema describes the options that a plugin accepts.
+//
+// A Schema is attached to a plugin's Spec. The framework validates the Options of every
+// request against the Schema before any handlers are invoked, and returns a single error
+// listing every unknown or invalid option.
+type Schema struct {
+	// Keys are the specs for the option keys that the plugin accepts.
+	//
+	// No two KeySpecs may have the same Key.
+	Keys []*KeySpec
+	// AllowUnknownKeys allows Options to contain keys that have no KeySpec.
+	//
+	// By default, an unknown key results in an error.
+	AllowUnknownKeys bool
+}
+
+// KeySpec is the spec for a single option key.
+type KeySpec struct {
+	// Required.
+	//
+	// The key must have at least three characters.
+	// The key must start and end with a lowercase letter from a-z, and only consist
+	// of lowercase letters from a-z and underscores.
+	Key string
+	// Required.
+	Type Type
+	// Description is a description of the option for users of the plugin.
+	//
+	// Optional.
+	//
+	// This is included in generated documentation and in the document produced by
+	// SchemaToJSONSchema, so that editors can display it. Must be valid UTF-8.
+	Description string
+	// Example is an example value of the option.
+	//
+	// Optional.
+	//
+	// This is included in generated documentation and in the document produced by
+	// SchemaToJSONSchema. Must be a valid value of type Type that is allowed by AllowedValues.
+	Example any
+	// Default is the value of the option if it is not set.
+	//
+	// Optional.
+	//
+	// When a Schema is attached to a plugin, RuleHandlers see the Default as if it had been
+	// set by the caller. See OptionsWithDefaults.
+	//
+	// Must be of type Type if set. Cannot be set if Required is true.
+	Default any
+	// Required says that the option must be set.
+	//
+	// When a Schema is attached to a plugin, a request that does not set a required option
+	// fails before any RuleHandlers are invoked, with an error that names the key and its Type.
+	Required bool
+	// AllowedValues are the only values that the option may have.
+	//
+	// Optional. If empty, all values of type Type are allowed.
+	//
+	// Each allowed value must be of type Type, or of the element type of Type if Type is a
+	// slice type, in which case every element of the value must be allowed. Cannot be set
+	// for TypeBool, TypeBytes, TypeDuration, TypeTimestamp, or TypeByteSize.
+	AllowedValues []any
+	// AllowedValuesCaseInsensitive says that string values match AllowedValues without
+	// regard to case, as defined by strings.EqualFold.
+	//
+	// When a Schema is attached to a plugin, values are rewritten to the spelling used in
+	// AllowedValues before RuleHandlers see them, so RuleHandlers can compare values
+	// exactly. See CanonicalizeAllowedValues.
+	//
+	// Can only be set for TypeString or TypeStringSlice keys with AllowedValues. No two
+	// AllowedValues may be equal without regard to case.
+	AllowedValuesCaseInsensitive bool
+	// Aliases are deprecated keys that are accepted in place of Key.
+	//
+	// Optional.
+	//
+	// This allows an option to be renamed without breaking existing configurations. When a
+	// Schema is attached to a plugin, RuleHandlers see the value of an alias under Key, and
+	// a deprecation warning is written for each alias that is used. See ResolveAliases.
+	//
+	// Each alias must be a valid key, and no alias may be equal to another key or alias in the
+	// Schema. Key and its aliases cannot be set together.
+	Aliases []string
+	// Secret says that the value of the option is sensitive, such as an API token.
+	//
+	// Values of secret options are never included in errors produced by ValidateOptions,
+	// and are replaced by RedactedValue in the Options returned by Redact. Use Redact
+	// before logging or otherwise displaying Options.
+	Secret bool
+	// ExpandEnv says that environment variable references such as ${API_TOKEN} are expanded
+	// in the value of the option.
+	//
+	// This allows credentials and machine-specific paths to be kept out of configuration
+	// files. When a Schema is attached to a plugin, references are expanded on the plugin
+	// side before the Options are validated. See ExpandEnv for the syntax.
+	//
+	// References are expanded with the environment of the plugin process, not that of the
+	// caller. Plugins run with check.NewExecRunner, pluginrpc.NewExecRunner, or
+	// check.NewClientForDaemon are started with an empty environment, so every reference
+	// fails to expand unless the caller forwards the variable, for example with
+	// check.ExecRunnerWithForwardedEnv.
+	//
+	// Can only be set for TypeString or TypeStringSlice keys.
+	ExpandEnv bool
+}
+
+// ValidateSchema validates all values on a Schema.
+//
+// This is exposed publicly so it can be run as part of plugin tests.
+func ValidateSchema(schema *Schema) error {
+	keyToKeySpec := make(map[string]*KeySpec, len(schema.Keys))
+	for _, keySpec := range schema.Keys {
+		if err := validateKeySpec(keySpec); err != nil {
+			return wrapValidateSchemaError(err)
+		}
+		for _, key := range append([]string{keySpec.Key}, keySpec.Aliases...) {
+			if _, ok := keyToKeySpec[key]; ok {
+				return newValidateSchemaErrorf("duplicate key or alias %q", key)
+			}
+			keyToKeySpec[key] = keySpec
+		}
+	}
+	return nil
+}
+
+// ValidateOptions validates the Options against the Schema.
+//
+// Every unknown key, missing required key, value of the wrong type, and value that is not
+// allowed is reported in the returned error.
+//
+// The Schema is assumed to be valid.
+func ValidateOptions(schema *Schema, options Options) error {
+	keyToKeySpec := make(map[string]*KeySpec, len(schema.Keys))
+	aliasToKeySpec := make(map[string]*KeySpec)
+	for _, keySpec := range schema.Keys {
+		keyToKeySpec[keySpec.Key] = keySpec
+		for _, alias := range keySpec.Aliases {
+			aliasToKeySpec[alias] = keySpec
+		}
+	}
+	var invalidOptionErrors []*invalidOptionError
+	options.Range(
+		func(key string, value any) {
+			keySpec, ok := keyToKeySpec[key]
+			if !ok {
+				keySpec, ok = aliasToKeySpec[key]
+				if !ok {
+					if !schema.AllowUnknownKeys {
+						invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(key, "unknown option"))
+					}
+					return
+				}
+				for _, otherKey := range append([]string{keySpec.Key}, keySpec.Aliases...) {
+					if otherKey == key {
+						continue
+					}
+					if _, ok := options.Get(otherKey); ok {
+						invalidOptionErrors = append(
+							invalidOptionErrors,
+							newInvalidOptionErrorf(key, "deprecated alias of %q cannot be set together with %q", keySpec.Key, otherKey),
+						)
+						return
+					}
+				}
+			}
+			if err := validateValueHasType(value, keySpec.Type); err != nil {
+				if keySpec.Secret {
+					err = fmt.Errorf("expected value of type %v", keySpec.Type)
+				}
+				invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(key, err.Error()))
+				return
+			}
+			if err := validateValueAllowed(keySpec, value); err != nil {
+				invalidOptionErrors = append(invalidOptionErrors, newInvalidOptionError(key, err.Error()))
+			}
+		},
+	)
+	for _, keySpec := range schema.Keys {
+		if !keySpec.Required {
+			continue
+		}
+		if !optionsHasKeyOrAlias(options, keySpec) {
+			invalidOptionErrors = append(
+				invalidOptionErrors,
+				newInvalidOptionErrorf(keySpec.Key, "required option is not set, expected value of type %v", keySpec.Type),
+			)
+		}
+	}
+	if len(invalidOptionErrors) == 0 {
+		return nil
+	}
+	return newInvalidOptionsError(invalidOptionErrors)
+}
+
+// ResolveAliases returns a new Options with every key that is a deprecated alias in the
+// Schema replaced by the key it is an alias of, along with a deprecation warning for each
+// alias that was used.
+//
+// Warnings are sorted, and are of the form:
+//
+//	option "old_key" is deprecated, use "new_key" instead
+//
+// The Schema is assumed to be valid, and the Options are assumed to have been validated
+// against the Schema with ValidateOptions.
+func ResolveAliases(schema *Schema, options Options) (Options, []string) {
+	aliasToKeySpec := make(map[string]*KeySpec)
+	for _, keySpec := range schema.Keys {
+		for _, alias := range keySpec.Aliases {
+			aliasToKeySpec[alias] = keySpec
+		}
+	}
+	keyToValue := make(map[string]any)
+	var warnings []string
+	options.Range(
+		func(key string, value any) {
+			if keySpec, ok := aliasToKeySpec[key]; ok {
+				warnings = append(warnings, fmt.Sprintf("option %q is deprecated, use %q instead", key, keySpec.Key))
+				key = keySpec.Key
+			}
+			keyToValue[key] = value
+		},
+	)
+	sort.Strings(warnings)
+	return newOptionsNoValidate(keyToValue), warnings
+}
+
+// CanonicalizeAllowedValues returns a new Options with every string value of a key with
+// AllowedValuesCaseInsensitive, including every element of a string slice value, replaced
+// by the AllowedValue that it matches, as spelled in AllowedValues.
+//
+// For example, if AllowedValues is []any{"camelCase", "snake_case"}, a value of "CAMELCASE"
+// is replaced by "camelCase". Values of deprecated aliases are also replaced. Values that
+// do not match an AllowedValue are left as-is.
+//
+// The Schema is assumed to be valid, and the Options are assumed to have been validated
+// against the Schema with ValidateOptions.
+func CanonicalizeAllowedValues(schema *Schema, options Options) Options {
+	keyToKeySpec := make(map[string]*KeySpec)
+	for _, keySpec := range schema.Keys {
+		if !keySpec.AllowedValuesCaseInsensitive {
+			continue
+		}
+		for _, key := range append([]string{keySpec.Key}, keySpec.Aliases...) {
+			keyToKeySpec[key] = keySpec
+		}
+	}
+	if len(keyToKeySpec) == 0 {
+		return options
+	}
+	keyToValue := make(map[string]any)
+	options.Range(
+		func(key string, value any) {
+			if keySpec, ok := keyToKeySpec[key]; ok {
+				value = canonicalizeAllowedValue(keySpec, value)
+			}
+			keyToValue[key] = value
+		},
+	)
+	return newOptionsNoValidate(keyToValue)
+}
+
+// OptionsWithDefaults returns a new Options with the Default of every KeySpec in the
+// Schema set for each key that is not set in the given Options.
+//
+// Defaults are applied transparently: Options.Get and the typed getters report a defaulted
+// key as present, and Options.Range includes it.
+//
+// The Schema is assumed to be valid.
+func OptionsWithDefaults(schema *Schema, options Options) Options {
+	return Merge(DefaultOptions(schema), options)
+}
+
+// Redact returns a new Options with the value of every secret option in the Schema,
+// including options set by a deprecated alias, replaced by RedactedValue.
+//
+// The result is suitable for logging and debug output, but not for passing to a plugin.
+func Redact(schema *Schema, options Options) Options {
+	secretKeys := make(map[string]struct{})
+	for _, keySpec := range schema.Keys {
+		if !keySpec.Secret {
+			continue
+		}
+		for _, key := range append([]string{keySpec.Key}, keySpec.Aliases...) {
+			secretKeys[key] = struct{}{}
+		}
+	}
+	keyToValue := make(map[string]any)
+	options.Range(
+		func(key string, value any) {
+			if _, ok := secretKeys[key]; ok {
+				value = RedactedValue
+			}
+			keyToValue[key] = value
+		},
+	)
+	return newOptionsNoValidate(keyToValue)
+}
+
+// DefaultOptions returns a new Options with the Default of every KeySpec in the Schema
+// that has a Default.
+//
+// This is the lowest-precedence layer to pass to Merge.
+//
+// The Schema is assumed to be valid.
+func DefaultOptions(schema *Schema) Options {
+	keyToValue := make(map[string]any)
+	for _, keySpec := range schema.Keys {
+		if keySpec.Default == nil {
+			continue
+		}
+		defaultValue, err := canonicalizeValue(keySpec.Default)
+		if err != nil {
+			// This should never happen for a valid Schema.
+			defaultValue = keySpec.Default
+		}
+		keyToValue[keySpec.Key] = defaultValue
+	}
+	return newOptionsNoValidate(keyToValue)
+}
+
+// *** PRIVATE ***
+
+func validateKeySpec(keySpec *KeySpec) error {
+	if err := validateKey(keySpec.Key); err != nil {
+		return err
+	}
+	for _, alias := range keySpec.Aliases {
+		if err := validateKey(alias); err != nil {
+			return fmt.Errorf("invalid alias for key %q: %w", keySpec.Key, err)
+		}
+	}
+	if _, ok := typeToString[keySpec.Type]; !ok {
+		if keySpec.Type == 0 {
+			return fmt.Errorf("Type is not set for key %q", keySpec.Key)
+		}
+		return fmt.Errorf("Type is unknown for key %q: %v", keySpec.Key, keySpec.Type)
+	}
+	if len(keySpec.AllowedValues) > 0 {
+		switch keySpec.Type {
+		case TypeBool, TypeBytes, TypeDuration, TypeTimestamp, TypeByteSize:
+			return fmt.Errorf("AllowedValues cannot be set for key %q of type %v", keySpec.Key, keySpec.Type)
+		}
+		allowedValueType := keySpec.Type
+		if elemType, ok := keySpec.Type.elemType(); ok {
+			allowedValueType = elemType
+		}
+		for _, allowedValue := range keySpec.AllowedValues {
+			if !valueHasType(allowedValue, allowedValueType) {
+				return fmt.Errorf("AllowedValues for key %q must be of type %v but got %T", keySpec.Key, allowedValueType, allowedValue)
+			}
+		}
+	}
+	if keySpec.ExpandEnv && keySpec.Type != TypeString && keySpec.Type != TypeStringSlice {
+		return fmt.Errorf("ExpandEnv cannot be set for key %q of type %v", keySpec.Key, keySpec.Type)
+	}
+	if keySpec.AllowedValuesCaseInsensitive {
+		if keySpec.Type != TypeString && keySpec.Type != TypeStringSlice {
+			return fmt.Errorf("AllowedValuesCaseInsensitive cannot be set for key %q of type %v", keySpec.Key, keySpec.Type)
+		}
+		if len(keySpec.AllowedValues) == 0 {
+			return fmt.Errorf("AllowedValuesCaseInsensitive is set for key %q but AllowedValues is empty", keySpec.Key)
+		}
+		lowerAllowedValues := make(map[string]struct{}, len(keySpec.AllowedValues))
+		for _, allowedValue := range keySpec.AllowedValues {
+			// We validated that these are strings above.
+			lowerAllowedValue := strings.ToLower(normalizeScalarValue(allowedValue).(string)) //nolint:forcetypeassert
+			if _, ok := lowerAllowedValues[lowerAllowedValue]; ok {
+				return fmt.Errorf("AllowedValues for key %q contains %q more than once without regard to case", keySpec.Key, allowedValue)
+			}
+			lowerAllowedValues[lowerAllowedValue] = struct{}{}
+		}
+	}
+	if keySpec.Default != nil {
+		if keySpec.Required {
+			return fmt.Errorf("Default cannot be set for required key %q", keySpec.Key)
+		}
+		if err := validateValueHasType(keySpec.Default, keySpec.Type); err != nil {
+			return fmt.Errorf("Default for key %q is invalid: %w", keySpec.Key, err)
+		}
+		if err := validateValue(keySpec.Default); err != nil {
+			return fmt.Errorf("Default for key %q is invalid: %w", keySpec.Key, err)
+		}
+		if err := validateValueAllowed(keySpec, keySpec.Default); err != nil {
+			return fmt.Errorf("Default for key %q is invalid: %w", keySpec.Key, err)
+		}
+	}
+	if !utf8.ValidString(keySpec.Description) {
+		return fmt.Errorf("Description for key %q must be valid UTF-8", keySpec.Key)
+	}
+	if keySpec.Example != nil {
+		if err := validateValueHasType(keySpec.Example, keySpec.Type); err != nil {
+			return fmt.Errorf("Example for key %q is invalid: %w", keySpec.Key, err)
+		}
+		if err := validateValue(keySpec.Example); err != nil {
+			return fmt.Errorf("Example for key %q is invalid: %w", keySpec.Key, err)
+		}
+		if err := validateValueAllowed(keySpec, keySpec.Example); err != nil {
+			return fmt.Errorf("Example for key %q is invalid: %w", keySpec.Key, err)
+		}
+	}
+	return nil
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("Key is empty")
+	}
+	if len(key) < keyMinLen {
+		return fmt.Errorf("Key %q must be at least length %d", key, keyMinLen)
+	}
+	if !keyRegexp.MatchString(key) {
+		return fmt.Errorf("Key %q does not match %q", key, keyRegexp.String())
+	}
+	if strings.HasPrefix(key, ReservedKeyPrefix) {
+		return fmt.Errorf("Key %q has the prefix %q, which is reserved", key, ReservedKeyPrefix)
+	}
+	return nil
+}
+
+func optionsHasKeyOrAlias(options Options, keySpec *KeySpec) bool {
+	for _, key := range append([]string{keySpec.Key}, keySpec.Aliases...) {
+		if _, ok := options.Get(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validateValueAllowed validates that the value is one of the AllowedValues of the KeySpec.
+//
+// Assumes that the value is of the KeySpec's Type.
+func validateValueAllowed(keySpec *KeySpec, value any) error {
+	if len(keySpec.AllowedValues) == 0 {
+		return nil
+	}
+	var values []any
+	if _, ok := keySpec.Type.elemType(); ok {
+		reflectValue := reflect.ValueOf(value)
+		for i := range reflectValue.Len() {
+			values = append(values, reflectValue.Index(i).Interface())
+		}
+	} else {
+		values = []any{value}
+	}
+	for _, value := range values {
+		if !valueAllowed(keySpec, value) {
+			allowedValuesString := strings.Join(xslices.Map(keySpec.AllowedValues, formatAllowedValue), ", ")
+			if keySpec.Secret {
+				return fmt.Errorf("value must be one of [%s]", allowedValuesString)
+			}
+			return fmt.Errorf("value %s must be one of [%s]", formatAllowedValue(value), allowedValuesString)
+		}
+	}
+	return nil
+}
+
+func valueAllowed(keySpec *KeySpec, value any) bool {
+	normalizedValue := normalizeScalarValue(value)
+	for _, allowedValue := range keySpec.AllowedValues {
+		normalizedAllowedValue := normalizeScalarValue(allowedValue)
+		if normalizedValue == normalizedAllowedValue {
+			return true
+		}
+		if keySpec.AllowedValuesCaseInsensitive {
+			stringValue, ok1 := normalizedValue.(string)
+			allowedStringValue, ok2 := normalizedAllowedValue.(string)
+			if ok1 && ok2 && strings.EqualFold(stringValue, allowedStringValue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canonicalizeAllowedValue returns the value with every string, or every element of a
+// []string, replaced by the AllowedValue of the KeySpec that it matches without regard
+// to case.
+func canonicalizeAllowedValue(keySpec *KeySpec, value any) any {
+	switch value := value.(type) {
+	case string:
+		return canonicalizeAllowedStringValue(keySpec, value)
+	case []string:
+		canonicalValue := make([]string, len(value))
+		for i, stringValue := range value {
+			canonicalValue[i] = canonicalizeAllowedStringValue(keySpec, stringValue)
+		}
+		return canonicalValue
+	default:
+		return value
+	}
+}
+
+func canonicalizeAllowedStringValue(keySpec *KeySpec, value string) string {
+	for _, allowedValue := range keySpec.AllowedValues {
+		allowedStringValue, ok := normalizeScalarValue(allowedValue).(string)
+		if ok && strings.EqualFold(value, allowedStringValue) {
+			return allowedStringValue
+		}
+	}
+	return value
+}
+
+func formatAllowedValue(value any) string {
+	if stringValue, ok := value.(string); ok {
+		return strconv.Quote(stringValue)
+	}
+	return fmt.Sprintf("%v", value)
+}
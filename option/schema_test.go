@@ -0,0 +1,362 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchema(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(
+		t,
+		ValidateSchema(
+			&Schema{
+				Keys: []*KeySpec{
+					{Key: "foo_key", Type: TypeString, Default: "foo", Example: "bar", AllowedValues: []any{"foo", "bar"}, Description: "Foo."},
+					{Key: "bar_key", Type: TypeInt64Slice, Required: true, AllowedValues: []any{1, 2}},
+				},
+			},
+		),
+	)
+	// Keys must have at least three characters.
+	require.NoError(t, ValidateSchema(&Schema{Keys: []*KeySpec{{Key: "foo", Type: TypeString}}}))
+	testValidateSchemaError(t, &KeySpec{Key: "fo", Type: TypeString})
+	testValidateSchemaError(t, &KeySpec{Key: "Foo", Type: TypeString})
+	testValidateSchemaError(t, &KeySpec{Key: "bufplugin_foo", Type: TypeString})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, Aliases: []string{"bufplugin_foo"}})
+	testValidateSchemaError(t, &KeySpec{Key: "foo"})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, Default: 1})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, Default: "foo", Required: true})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, Default: "baz", AllowedValues: []any{"foo"}})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeBool, AllowedValues: []any{true}})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeStringSlice, AllowedValues: []any{1}})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, Example: 1})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, Example: "baz", AllowedValues: []any{"foo"}})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, Description: "\xff"})
+	err := ValidateSchema(
+		&Schema{
+			Keys: []*KeySpec{
+				{Key: "foo", Type: TypeString},
+				{Key: "foo", Type: TypeInt64},
+			},
+		},
+	)
+	assert.Error(t, err)
+}
+
+func TestValidateOptions(t *testing.T) {
+	t.Parallel()
+
+	schema := &Schema{
+		Keys: []*KeySpec{
+			{Key: "string_key", Type: TypeString, AllowedValues: []any{"foo", "bar"}},
+			{Key: "int_key", Type: TypeInt64},
+			{Key: "string_slice_key", Type: TypeStringSlice, AllowedValues: []any{"foo", "bar"}},
+			{Key: "required_key", Type: TypeBool, Required: true},
+		},
+	}
+	require.NoError(t, ValidateSchema(schema))
+
+	options, err := NewOptions(
+		map[string]any{
+			"string_key":       "foo",
+			"int_key":          1,
+			"string_slice_key": []any{"foo", "bar"},
+			"required_key":     true,
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, ValidateOptions(schema, options))
+
+	options, err = NewOptions(
+		map[string]any{
+			"string_key":       "baz",
+			"int_key":          1.5,
+			"string_slice_key": []string{"foo", "baz"},
+			"unknown_key":      true,
+		},
+	)
+	require.NoError(t, err)
+	err = ValidateOptions(schema, options)
+	assert.EqualError(
+		t,
+		err,
+		`invalid options: `+
+			`option "int_key": expected value of type int64, got float64; `+
+			`option "required_key": required option is not set, expected value of type bool; `+
+			`option "string_key": value "baz" must be one of ["foo", "bar"]; `+
+			`option "string_slice_key": value "baz" must be one of ["foo", "bar"]; `+
+			`option "unknown_key": unknown option`,
+	)
+
+	schema.AllowUnknownKeys = true
+	options, err = NewOptions(
+		map[string]any{
+			"required_key": true,
+			"unknown_key":  true,
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, ValidateOptions(schema, options))
+}
+
+func testValidateSchemaError(t *testing.T, keySpec *KeySpec) {
+	err := ValidateSchema(&Schema{Keys: []*KeySpec{keySpec}})
+	assert.Error(t, err, "%+v", keySpec)
+}
+
+func TestOptionsWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	schema := &Schema{
+		Keys: []*KeySpec{
+			{Key: "string_key", Type: TypeString, Default: "foo"},
+			{Key: "int_key", Type: TypeInt64, Default: 5},
+			{Key: "bool_key", Type: TypeBool},
+		},
+	}
+	require.NoError(t, ValidateSchema(schema))
+
+	options, err := NewOptions(
+		map[string]any{
+			"string_key": "bar",
+		},
+	)
+	require.NoError(t, err)
+	options = OptionsWithDefaults(schema, options)
+
+	stringValue, ok, err := options.GetString("string_key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "bar", stringValue)
+	int64Value, ok, err := options.GetInt64("int_key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), int64Value)
+	_, ok = options.Get("bool_key")
+	assert.False(t, ok)
+	protoOptions, err := options.ToProto()
+	require.NoError(t, err)
+	assert.Len(t, protoOptions, 2)
+}
+
+func TestCanonicalizeAllowedValues(t *testing.T) {
+	t.Parallel()
+
+	schema := &Schema{
+		Keys: []*KeySpec{
+			{
+				Key:                          "naming_style",
+				Type:                         TypeString,
+				AllowedValues:                []any{"snake_case", "camelCase"},
+				AllowedValuesCaseInsensitive: true,
+				Aliases:                      []string{"style"},
+			},
+			{
+				Key:                          "naming_styles",
+				Type:                         TypeStringSlice,
+				AllowedValues:                []any{"snake_case", "camelCase"},
+				AllowedValuesCaseInsensitive: true,
+			},
+			{
+				Key:           "case_sensitive_style",
+				Type:          TypeString,
+				AllowedValues: []any{"snake_case", "camelCase"},
+			},
+		},
+	}
+	require.NoError(t, ValidateSchema(schema))
+
+	options, err := NewOptions(
+		map[string]any{
+			"naming_style":         "CAMELCASE",
+			"naming_styles":        []string{"Snake_Case", "camelcase"},
+			"case_sensitive_style": "camelCase",
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, ValidateOptions(schema, options))
+	testOptionsEqual(
+		t,
+		map[string]any{
+			"naming_style":         "camelCase",
+			"naming_styles":        []string{"snake_case", "camelCase"},
+			"case_sensitive_style": "camelCase",
+		},
+		CanonicalizeAllowedValues(schema, options),
+	)
+	// The Options are not modified.
+	value, _, err := options.GetString("naming_style")
+	require.NoError(t, err)
+	assert.Equal(t, "CAMELCASE", value)
+
+	options, err = NewOptions(map[string]any{"style": "SNAKE_CASE"})
+	require.NoError(t, err)
+	testOptionsEqual(t, map[string]any{"style": "snake_case"}, CanonicalizeAllowedValues(schema, options))
+}
+
+func TestValidateOptionsAllowedValuesCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	schema := &Schema{
+		Keys: []*KeySpec{
+			{
+				Key:                          "naming_style",
+				Type:                         TypeString,
+				AllowedValues:                []any{"snake", "camel", "pascal"},
+				AllowedValuesCaseInsensitive: true,
+			},
+			{
+				Key:           "case_sensitive_style",
+				Type:          TypeString,
+				AllowedValues: []any{"snake", "camel", "pascal"},
+			},
+		},
+	}
+	require.NoError(t, ValidateSchema(schema))
+
+	options, err := NewOptions(
+		map[string]any{
+			"naming_style":         "Camel",
+			"case_sensitive_style": "camel",
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, ValidateOptions(schema, options))
+
+	options, err = NewOptions(
+		map[string]any{
+			"naming_style":         "kebab",
+			"case_sensitive_style": "Camel",
+		},
+	)
+	require.NoError(t, err)
+	assert.EqualError(
+		t,
+		ValidateOptions(schema, options),
+		`invalid options: `+
+			`option "case_sensitive_style": value "Camel" must be one of ["snake", "camel", "pascal"]; `+
+			`option "naming_style": value "kebab" must be one of ["snake", "camel", "pascal"]`,
+	)
+
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeInt64, AllowedValues: []any{1}, AllowedValuesCaseInsensitive: true})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, AllowedValuesCaseInsensitive: true})
+	testValidateSchemaError(t, &KeySpec{Key: "foo", Type: TypeString, AllowedValues: []any{"foo", "FOO"}, AllowedValuesCaseInsensitive: true})
+}
+
+func TestAliases(t *testing.T) {
+	t.Parallel()
+
+	schema := &Schema{
+		Keys: []*KeySpec{
+			{Key: "new_suffix", Type: TypeString, Aliases: []string{"old_suffix", "older_suffix"}, Required: true},
+		},
+	}
+	require.NoError(t, ValidateSchema(schema))
+
+	options, err := NewOptions(map[string]any{"old_suffix": "foo"})
+	require.NoError(t, err)
+	require.NoError(t, ValidateOptions(schema, options))
+	options, warnings := ResolveAliases(schema, options)
+	assert.Equal(t, []string{`option "old_suffix" is deprecated, use "new_suffix" instead`}, warnings)
+	value, ok, err := options.GetString("new_suffix")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "foo", value)
+	_, ok = options.Get("old_suffix")
+	assert.False(t, ok)
+
+	options, err = NewOptions(map[string]any{"new_suffix": "foo"})
+	require.NoError(t, err)
+	_, warnings = ResolveAliases(schema, options)
+	assert.Empty(t, warnings)
+
+	options, err = NewOptions(map[string]any{"old_suffix": "foo", "new_suffix": "bar"})
+	require.NoError(t, err)
+	assert.EqualError(
+		t,
+		ValidateOptions(schema, options),
+		`invalid options: option "old_suffix": deprecated alias of "new_suffix" cannot be set together with "new_suffix"`,
+	)
+	options, err = NewOptions(map[string]any{"old_suffix": int64(1)})
+	require.NoError(t, err)
+	assert.EqualError(
+		t,
+		ValidateOptions(schema, options),
+		`invalid options: option "old_suffix": expected value of type string, got int64`,
+	)
+
+	assert.Error(
+		t,
+		ValidateSchema(
+			&Schema{
+				Keys: []*KeySpec{
+					{Key: "foo_key", Type: TypeString, Aliases: []string{"bar_key"}},
+					{Key: "bar_key", Type: TypeString},
+				},
+			},
+		),
+	)
+	testValidateSchemaError(t, &KeySpec{Key: "foo_key", Type: TypeString, Aliases: []string{"Bar"}})
+}
+
+func TestSecret(t *testing.T) {
+	t.Parallel()
+
+	schema := &Schema{
+		Keys: []*KeySpec{
+			{Key: "api_token", Type: TypeString, Aliases: []string{"token"}, Secret: true},
+			{Key: "timeout", Type: TypeDuration, Secret: true},
+			{Key: "region", Type: TypeString, AllowedValues: []any{"us", "eu"}, Secret: true},
+			{Key: "endpoint", Type: TypeString},
+		},
+	}
+	require.NoError(t, ValidateSchema(schema))
+
+	options, err := NewOptions(
+		map[string]any{
+			"token":    "hunter_two",
+			"timeout":  "hunter_three",
+			"region":   "hunter_four",
+			"endpoint": "example.com",
+		},
+	)
+	require.NoError(t, err)
+	err = ValidateOptions(schema, options)
+	assert.EqualError(
+		t,
+		err,
+		`invalid options: `+
+			`option "region": value must be one of ["us", "eu"]; `+
+			`option "timeout": expected value of type duration`,
+	)
+	assert.NotContains(t, err.Error(), "hunter")
+
+	testOptionsEqual(
+		t,
+		map[string]any{
+			"token":    RedactedValue,
+			"timeout":  RedactedValue,
+			"region":   RedactedValue,
+			"endpoint": "example.com",
+		},
+		Redact(schema, options),
+	)
+}
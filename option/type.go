@@ -0,0 +1,187 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+const (
+	// TypeBool is a bool option value.
+	TypeBool Type = 1
+	// TypeInt64 is an integer option value.
+	TypeInt64 Type = 2
+	// TypeFloat64 is a floating-point option value.
+	TypeFloat64 Type = 3
+	// TypeString is a string option value.
+	TypeString Type = 4
+	// TypeBytes is a []byte option value.
+	TypeBytes Type = 5
+	// TypeInt64Slice is a slice of integer option values.
+	TypeInt64Slice Type = 6
+	// TypeFloat64Slice is a slice of floating-point option values.
+	TypeFloat64Slice Type = 7
+	// TypeStringSlice is a slice of string option values.
+	TypeStringSlice Type = 8
+	// TypeDuration is a duration option value.
+	//
+	// The value is a string accepted by time.ParseDuration, such as "30s" or "1h30m".
+	TypeDuration Type = 9
+	// TypeTimestamp is a timestamp option value.
+	//
+	// The value is an RFC 3339 string, such as "2024-01-02T15:04:05Z".
+	TypeTimestamp Type = 10
+	// TypeByteSize is a byte size option value.
+	//
+	// The value is either a string accepted by ParseByteSize, such as "64MiB", or a
+	// non-negative integer number of bytes.
+	TypeByteSize Type = 11
+)
+
+var (
+	typeToString = map[Type]string{
+		TypeBool:         "bool",
+		TypeInt64:        "int64",
+		TypeFloat64:      "float64",
+		TypeString:       "string",
+		TypeBytes:        "bytes",
+		TypeInt64Slice:   "[]int64",
+		TypeFloat64Slice: "[]float64",
+		TypeStringSlice:  "[]string",
+		TypeDuration:     "duration",
+		TypeTimestamp:    "timestamp",
+		TypeByteSize:     "byte size",
+	}
+	sliceTypeToElemType = map[Type]Type{
+		TypeInt64Slice:   TypeInt64,
+		TypeFloat64Slice: TypeFloat64,
+		TypeStringSlice:  TypeString,
+	}
+)
+
+// Type is the type of an option value.
+//
+// Types follow the same coercion rules as the typed getters on Options. For example,
+// a value of any Go integer type is a TypeInt64, and a []any where every element is
+// a string is a TypeStringSlice.
+type Type int
+
+// String implements fmt.Stringer.
+func (t Type) String() string {
+	if s, ok := typeToString[t]; ok {
+		return s
+	}
+	return strconv.Itoa(int(t))
+}
+
+// *** PRIVATE ***
+
+// elemType returns the element Type if t is a slice Type.
+func (t Type) elemType() (Type, bool) {
+	elemType, ok := sliceTypeToElemType[t]
+	return elemType, ok
+}
+
+// validateValueHasType returns an error describing why the value is not of the
+// given Type, if it is not.
+func validateValueHasType(value any, t Type) error {
+	var err error
+	switch t {
+	case TypeDuration:
+		_, err = parseDurationValue(value)
+	case TypeTimestamp:
+		_, err = parseTimestampValue(value)
+	case TypeByteSize:
+		_, err = parseByteSizeValue(value)
+	default:
+		if !valueHasType(value, t) {
+			err = fmt.Errorf("expected value of type %v, got %T", t, value)
+		}
+	}
+	return err
+}
+
+// valueHasType returns true if the value is of the given Type.
+func valueHasType(value any, t Type) bool {
+	if value == nil {
+		return false
+	}
+	reflectValue := reflect.ValueOf(value)
+	if elemType, ok := t.elemType(); ok {
+		if reflectValue.Kind() != reflect.Slice {
+			return false
+		}
+		if _, ok := value.([]byte); ok {
+			return false
+		}
+		for i := range reflectValue.Len() {
+			if !valueHasType(reflectValue.Index(i).Interface(), elemType) {
+				return false
+			}
+		}
+		return true
+	}
+	switch t {
+	case TypeBool:
+		return reflectValue.Kind() == reflect.Bool
+	case TypeInt64:
+		return isIntKind(reflectValue.Kind()) || isUintKind(reflectValue.Kind())
+	case TypeFloat64:
+		return isFloatKind(reflectValue.Kind())
+	case TypeString:
+		return reflectValue.Kind() == reflect.String
+	case TypeBytes:
+		_, ok := value.([]byte)
+		return ok
+	case TypeDuration, TypeTimestamp, TypeByteSize:
+		return validateValueHasType(value, t) == nil
+	default:
+		return false
+	}
+}
+
+// normalizeScalarValue converts an integer, float, or string value to int64,
+// float64, or string respectively so that values can be compared with ==.
+//
+// Other values are returned as-is.
+func normalizeScalarValue(value any) any {
+	reflectValue := reflect.ValueOf(value)
+	switch kind := reflectValue.Kind(); {
+	case isIntKind(kind):
+		return reflectValue.Int()
+	case isUintKind(kind):
+		return int64(reflectValue.Uint()) //nolint:gosec
+	case isFloatKind(kind):
+		return reflectValue.Float()
+	case kind == reflect.String:
+		return reflectValue.String()
+	default:
+		return value
+	}
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind == reflect.Int || kind == reflect.Int8 || kind == reflect.Int16 || kind == reflect.Int32 || kind == reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind == reflect.Uint || kind == reflect.Uint8 || kind == reflect.Uint16 || kind == reflect.Uint32 || kind == reflect.Uint64
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
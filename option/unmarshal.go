@@ -0,0 +1,281 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+const tagName = "option"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+	byteSizeType = reflect.TypeOf(ByteSize(0))
+)
+
+// Unmarshal decodes the Options into the struct pointed to by v.
+//
+// Each exported struct field tagged with `option:"key"` is set from the option value with
+// that key. Fields tagged with `option:"-"` and untagged fields are skipped, with the
+// exception of struct fields, which are handled as follows:
+//
+//   - An embedded struct, or an untagged struct field, is decoded from the same Options as
+//     its parent, as if its fields were declared on the parent.
+//   - A struct field tagged with `option:"prefix"` is decoded from the keys that start with
+//     "prefix_". For example, field "suffix" of a struct field tagged `option:"service"` is
+//     decoded from key "service_suffix".
+//
+// Pointers to structs and pointers to supported types are allocated as needed.
+//
+// Values are converted to the type of the field with the following rules:
+//
+//   - bool fields accept bool values.
+//   - Signed and unsigned integer fields accept integer values that fit in the field.
+//   - float32 and float64 fields accept floating-point and integer values.
+//   - string fields accept string values.
+//   - []byte fields accept []byte values.
+//   - time.Duration fields accept strings as parsed by time.ParseDuration.
+//   - time.Time fields accept RFC 3339 strings.
+//   - ByteSize fields accept strings as parsed by ParseByteSize and non-negative integers.
+//   - Other slice fields accept slices whose elements can be converted to the slice's
+//     element type by these rules, including []any and nested slices.
+//
+// A field whose key is not set is left unchanged, so defaults can be set on the struct
+// before calling Unmarshal. Keys that do not match any field are ignored; attach a Schema
+// to the plugin to reject unknown keys.
+//
+// Every value that cannot be converted is reported in the returned error.
+func Unmarshal(options Options, v any) error {
+	reflectValue := reflect.ValueOf(v)
+	if reflectValue.Kind() != reflect.Pointer || reflectValue.IsNil() || reflectValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("option.Unmarshal requires a non-nil pointer to a struct but got %T", v)
+	}
+	var invalidOptionErrors []*invalidOptionError
+	if err := unmarshalStruct(options, "", reflectValue.Elem(), &invalidOptionErrors); err != nil {
+		return err
+	}
+	if len(invalidOptionErrors) == 0 {
+		return nil
+	}
+	return newInvalidOptionsError(invalidOptionErrors)
+}
+
+// *** PRIVATE ***
+
+// unmarshalStruct decodes the Options into the struct value.
+//
+// Conversion errors are added to invalidOptionErrors. An error is returned if the
+// struct itself cannot be decoded into, for example if a tag is invalid.
+func unmarshalStruct(
+	options Options,
+	prefix string,
+	structValue reflect.Value,
+	invalidOptionErrors *[]*invalidOptionError,
+) error {
+	structType := structValue.Type()
+	for i := range structType.NumField() {
+		structField := structType.Field(i)
+		tag, hasTag := structField.Tag.Lookup(tagName)
+		if tag == "-" {
+			continue
+		}
+		if !structField.IsExported() && !structField.Anonymous {
+			if hasTag {
+				return fmt.Errorf("option.Unmarshal: field %s.%s is tagged but not exported", structType, structField.Name)
+			}
+			continue
+		}
+		fieldValue := structValue.Field(i)
+		if isStructOrStructPointer(structField.Type) && !isScalarStructType(structField.Type) && !hasTag {
+			if !structField.IsExported() && structField.Type.Kind() == reflect.Pointer {
+				// We cannot allocate an unexported embedded struct pointer.
+				continue
+			}
+			if err := unmarshalStruct(options, prefix, allocateStructValue(fieldValue), invalidOptionErrors); err != nil {
+				return err
+			}
+			continue
+		}
+		if !hasTag {
+			continue
+		}
+		if tag == "" {
+			return fmt.Errorf("option.Unmarshal: field %s.%s has an empty %q tag", structType, structField.Name, tagName)
+		}
+		key := prefix + tag
+		if isStructOrStructPointer(structField.Type) && !isScalarStructType(structField.Type) {
+			if err := unmarshalStruct(options, key+"_", allocateStructValue(fieldValue), invalidOptionErrors); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := options.Get(key)
+		if !ok {
+			continue
+		}
+		if err := unmarshalValue(value, fieldValue); err != nil {
+			*invalidOptionErrors = append(*invalidOptionErrors, newInvalidOptionError(key, err.Error()))
+		}
+	}
+	return nil
+}
+
+// unmarshalValue sets target to the value, converting as documented on Unmarshal.
+func unmarshalValue(value any, target reflect.Value) error {
+	if target.Kind() == reflect.Pointer {
+		newValue := reflect.New(target.Type().Elem())
+		if err := unmarshalValue(value, newValue.Elem()); err != nil {
+			return err
+		}
+		target.Set(newValue)
+		return nil
+	}
+	switch target.Type() {
+	case durationType:
+		duration, err := parseDurationValue(value)
+		if err != nil {
+			return err
+		}
+		target.SetInt(int64(duration))
+		return nil
+	case timeType:
+		timestamp, err := parseTimestampValue(value)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(timestamp))
+		return nil
+	case byteSizeType:
+		byteSize, err := parseByteSizeValue(value)
+		if err != nil {
+			return err
+		}
+		target.SetInt(int64(byteSize))
+		return nil
+	}
+	reflectValue := reflect.ValueOf(value)
+	kind := reflectValue.Kind()
+	switch targetKind := target.Kind(); {
+	case targetKind == reflect.Bool:
+		if kind != reflect.Bool {
+			return newCannotUnmarshalError(value, target.Type())
+		}
+		target.SetBool(reflectValue.Bool())
+	case isIntKind(targetKind):
+		if !isIntKind(kind) && !isUintKind(kind) {
+			return newCannotUnmarshalError(value, target.Type())
+		}
+		int64Value, ok := normalizeScalarValue(value).(int64)
+		if !ok || (isUintKind(kind) && int64Value < 0) || target.OverflowInt(int64Value) {
+			return fmt.Errorf("value %v overflows %v", value, target.Type())
+		}
+		target.SetInt(int64Value)
+	case isUintKind(targetKind):
+		if !isIntKind(kind) && !isUintKind(kind) {
+			return newCannotUnmarshalError(value, target.Type())
+		}
+		var uint64Value uint64
+		if isIntKind(kind) {
+			if reflectValue.Int() < 0 {
+				return fmt.Errorf("value %v overflows %v", value, target.Type())
+			}
+			uint64Value = uint64(reflectValue.Int())
+		} else {
+			uint64Value = reflectValue.Uint()
+		}
+		if target.OverflowUint(uint64Value) {
+			return fmt.Errorf("value %v overflows %v", value, target.Type())
+		}
+		target.SetUint(uint64Value)
+	case isFloatKind(targetKind):
+		switch {
+		case isFloatKind(kind):
+			target.SetFloat(reflectValue.Float())
+		case isIntKind(kind):
+			target.SetFloat(float64(reflectValue.Int()))
+		case isUintKind(kind):
+			target.SetFloat(float64(reflectValue.Uint()))
+		default:
+			return newCannotUnmarshalError(value, target.Type())
+		}
+	case targetKind == reflect.String:
+		if kind != reflect.String {
+			return newCannotUnmarshalError(value, target.Type())
+		}
+		target.SetString(reflectValue.String())
+	case targetKind == reflect.Slice:
+		if target.Type().Elem().Kind() == reflect.Uint8 {
+			bytesValue, ok := value.([]byte)
+			if !ok {
+				return newCannotUnmarshalError(value, target.Type())
+			}
+			// The value is shared with the Options, see Options.Get.
+			target.SetBytes(slices.Clone(bytesValue))
+			return nil
+		}
+		if kind != reflect.Slice {
+			return newCannotUnmarshalError(value, target.Type())
+		}
+		if _, ok := value.([]byte); ok {
+			return newCannotUnmarshalError(value, target.Type())
+		}
+		newSlice := reflect.MakeSlice(target.Type(), reflectValue.Len(), reflectValue.Len())
+		for i := range reflectValue.Len() {
+			if err := unmarshalValue(reflectValue.Index(i).Interface(), newSlice.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		target.Set(newSlice)
+	default:
+		return fmt.Errorf("unsupported field type %v", target.Type())
+	}
+	return nil
+}
+
+// isScalarStructType returns true if the type is a struct or struct pointer that is
+// decoded from a single option value, such as time.Time.
+func isScalarStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t == timeType
+}
+
+func isStructOrStructPointer(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// allocateStructValue returns the struct value for a struct or struct pointer field,
+// allocating the struct if the pointer is nil.
+func allocateStructValue(fieldValue reflect.Value) reflect.Value {
+	if fieldValue.Kind() != reflect.Pointer {
+		return fieldValue
+	}
+	if fieldValue.IsNil() {
+		fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+	}
+	return fieldValue.Elem()
+}
+
+func newCannotUnmarshalError(value any, targetType reflect.Type) error {
+	return fmt.Errorf("cannot unmarshal value of type %T into %v", value, targetType)
+}
@@ -0,0 +1,124 @@
+// Copyright 2024-2025 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	type serviceConfig struct {
+		Suffix  string   `option:"suffix"`
+		Exclude []string `option:"exclude"`
+	}
+	type commonConfig struct {
+		Verbose bool `option:"verbose"`
+	}
+	type config struct {
+		commonConfig
+
+		MaxLength   int32          `option:"max_length"`
+		Ratio       float64        `option:"ratio"`
+		Count       *uint          `option:"count"`
+		Data        []byte         `option:"data"`
+		Matrix      [][]int64      `option:"matrix"`
+		Service     serviceConfig  `option:"service"`
+		Message     *serviceConfig `option:"message"`
+		Unset       string         `option:"unset"`
+		Ignored     string         `option:"-"`
+		NotAnOption string
+	}
+
+	options, err := NewOptions(
+		map[string]any{
+			"verbose":         true,
+			"max_length":      int64(10),
+			"ratio":           int64(2),
+			"count":           int64(3),
+			"data":            []byte("foo"),
+			"matrix":          []any{[]int64{1, 2}, []int64{3}},
+			"service_suffix":  "Service",
+			"service_exclude": []any{"foo", "bar"},
+			"message_suffix":  "Message",
+			"unknown_key":     "foo",
+		},
+	)
+	require.NoError(t, err)
+	cfg := &config{
+		Unset: "default",
+	}
+	require.NoError(t, Unmarshal(options, cfg))
+	count := uint(3)
+	assert.Equal(
+		t,
+		&config{
+			commonConfig: commonConfig{
+				Verbose: true,
+			},
+			MaxLength: 10,
+			Ratio:     2,
+			Count:     &count,
+			Data:      []byte("foo"),
+			Matrix:    [][]int64{{1, 2}, {3}},
+			Service: serviceConfig{
+				Suffix:  "Service",
+				Exclude: []string{"foo", "bar"},
+			},
+			Message: &serviceConfig{
+				Suffix: "Message",
+			},
+			Unset: "default",
+		},
+		cfg,
+	)
+}
+
+func TestUnmarshalError(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Small  int8     `option:"small"`
+		Name   string   `option:"name"`
+		Names  []string `option:"names"`
+		Signed uint     `option:"signed"`
+	}
+
+	options, err := NewOptions(
+		map[string]any{
+			"small":  int64(1000),
+			"name":   true,
+			"names":  []any{"foo"},
+			"signed": int64(-1),
+		},
+	)
+	require.NoError(t, err)
+	err = Unmarshal(options, &config{})
+	assert.EqualError(
+		t,
+		err,
+		`invalid options: `+
+			`option "name": cannot unmarshal value of type bool into string; `+
+			`option "signed": value -1 overflows uint; `+
+			`option "small": value 1000 overflows int8`,
+	)
+
+	assert.Error(t, Unmarshal(options, config{}))
+	assert.Error(t, Unmarshal(options, (*config)(nil)))
+}